@@ -1161,6 +1161,17 @@ func forEachPublicIndexTableSpan(
 		prefix := roachpb.Key(rowenc.MakeIndexKeyPrefix(codec, table.GetID(), idx.ID))
 		f(roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
 	})
+	// The summaries of block range indexes are backed up with the rows they
+	// summarize.
+	for _, idx := range table.BlockRangeIndexes {
+		key := tableAndIndex{tableID: table.GetID(), indexID: idx.ID}
+		if added[key] {
+			continue
+		}
+		added[key] = true
+		prefix := roachpb.Key(rowenc.MakeIndexKeyPrefix(codec, table.GetID(), idx.ID))
+		f(roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+	}
 }
 
 // spansForAllTableIndexes returns non-overlapping spans for every index and
@@ -761,6 +761,12 @@ func spansForAllRestoreTableIndexes(
 			}
 			added[tableAndIndex{tableID: table.GetID(), indexID: index.GetID()}] = true
 		}
+		for _, idx := range table.GetBlockRangeIndexes() {
+			if err := sstIntervalTree.Insert(intervalSpan(table.IndexSpan(codec, idx.ID)), false); err != nil {
+				panic(errors.NewAssertionErrorWithWrappedErrf(err, "IndexSpan"))
+			}
+			added[tableAndIndex{tableID: table.GetID(), indexID: idx.ID}] = true
+		}
 	}
 	// If there are desc revisions, ensure that we also add any index spans
 	// in them that we didn't already get above e.g. indexes or tables that are
@@ -815,6 +815,22 @@ var zipInternalTablesPerNode = DebugZipTableRegistry{
 			"value",
 		},
 	},
+	"crdb_internal.node_plpgsql_statement_statistics": {
+		nonSensitiveCols: NonSensitiveColumns{
+			"node_id",
+			"routine_id",
+			"routine_version",
+			"routine_signature",
+			"statement_id",
+			"line_number",
+			"statement_type",
+			"exec_count",
+			"error_count",
+			"latency_total_sec",
+			"latency_avg_sec",
+			"latency_max_sec",
+		},
+	},
 	"crdb_internal.node_queries": {
 		// `client_address` contains unredacted client IP addresses.
 		nonSensitiveCols: NonSensitiveColumns{
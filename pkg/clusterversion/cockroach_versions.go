@@ -309,6 +309,40 @@ const (
 	// to be pipelined.
 	V24_1_ReplicatedLockPipelining
 
+	// V24_1_AddSystemNotificationsTable adds the system.notifications table,
+	// through which NOTIFY delivers notifications to the listening sessions on
+	// all nodes.
+	V24_1_AddSystemNotificationsTable
+
+	// V24_1_DeferrableConstraints enables DEFERRABLE foreign key and UNIQUE
+	// WITHOUT INDEX constraints, which are stored in table descriptors.
+	V24_1_DeferrableConstraints
+
+	// V24_1_ForeignTables enables CREATE SERVER and CREATE FOREIGN TABLE, which
+	// store foreign table metadata in table descriptors.
+	V24_1_ForeignTables
+
+	// V24_1_BlockRangeIndexes enables CREATE INDEX ... USING brin, which stores
+	// block range summaries of a column in table descriptors.
+	V24_1_BlockRangeIndexes
+
+	// V24_1_Collations enables CREATE COLLATION and database default
+	// collations, which are stored in database descriptors.
+	V24_1_Collations
+
+	// V24_1_AddSystemReplicationSlotsTable adds the system.replication_slots
+	// table, which stores logical replication slots.
+	V24_1_AddSystemReplicationSlotsTable
+
+	// V24_1_ExclusionConstraints enables EXCLUDE constraints, which are stored
+	// as UNIQUE WITHOUT INDEX constraints with exclusion operators in table
+	// descriptors.
+	V24_1_ExclusionConstraints
+
+	// V24_1_RowLevelSecurity enables row-level security policies, which are
+	// stored in table descriptors, and the BYPASSRLS role option.
+	V24_1_RowLevelSecurity
+
 	numKeys
 )
 
@@ -377,6 +411,14 @@ var versionTable = [numKeys]roachpb.Version{
 	V24_1_GossipMaximumIOOverload:              {Major: 23, Minor: 2, Internal: 20},
 	V24_1_EstimatedMVCCStatsInSplit:            {Major: 23, Minor: 2, Internal: 22},
 	V24_1_ReplicatedLockPipelining:             {Major: 23, Minor: 2, Internal: 24},
+	V24_1_AddSystemNotificationsTable:          {Major: 23, Minor: 2, Internal: 26},
+	V24_1_DeferrableConstraints:                {Major: 23, Minor: 2, Internal: 28},
+	V24_1_ForeignTables:                        {Major: 23, Minor: 2, Internal: 30},
+	V24_1_BlockRangeIndexes:                    {Major: 23, Minor: 2, Internal: 32},
+	V24_1_Collations:                           {Major: 23, Minor: 2, Internal: 34},
+	V24_1_AddSystemReplicationSlotsTable:       {Major: 23, Minor: 2, Internal: 36},
+	V24_1_ExclusionConstraints:                 {Major: 23, Minor: 2, Internal: 38},
+	V24_1_RowLevelSecurity:                     {Major: 23, Minor: 2, Internal: 40},
 }
 
 // Latest is always the highest version key. This is the maximum logical cluster
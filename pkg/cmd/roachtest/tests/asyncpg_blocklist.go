@@ -45,7 +45,6 @@ var asyncpgBlocklist = blocklist{
 	`test_copy.TestCopyTo.test_copy_to_table_basics`:                                                      "unknown",
 	`test_cursor.TestCursor.test_cursor_02`:                                                               "unknown",
 	`test_cursor.TestCursor.test_cursor_04`:                                                               "unknown",
-	`test_cursor.TestIterableCursor.test_cursor_iterable_02`:                                              "unsupported feature - https://github.com/cockroachdb/cockroach/issues/77099",
 	`test_exceptions.TestExceptions.test_exceptions_str`:                                                  "unknown",
 	`test_exceptions.TestExceptions.test_exceptions_unpacking`:                                            "unknown",
 	`test_execute.TestExecuteMany.test_executemany_client_failure_in_transaction`:                         "unknown",
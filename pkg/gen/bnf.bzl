@@ -89,6 +89,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:commit_transaction.bnf",
     "//docs/generated/sql/bnf:copy_stmt.bnf",
     "//docs/generated/sql/bnf:copy_to_stmt.bnf",
+    "//docs/generated/sql/bnf:create_aggregate_stmt.bnf",
     "//docs/generated/sql/bnf:create_as_col_qual_list.bnf",
     "//docs/generated/sql/bnf:create_as_constraint_def.bnf",
     "//docs/generated/sql/bnf:create_changefeed_stmt.bnf",
@@ -96,10 +97,12 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:create_ddl_stmt.bnf",
     "//docs/generated/sql/bnf:create_extension_stmt.bnf",
     "//docs/generated/sql/bnf:create_external_connection_stmt.bnf",
+    "//docs/generated/sql/bnf:create_foreign_table_stmt.bnf",
     "//docs/generated/sql/bnf:create_func.bnf",
     "//docs/generated/sql/bnf:create_index_stmt.bnf",
     "//docs/generated/sql/bnf:create_index_with_storage_param.bnf",
     "//docs/generated/sql/bnf:create_inverted_index_stmt.bnf",
+    "//docs/generated/sql/bnf:create_policy_stmt.bnf",
     "//docs/generated/sql/bnf:create_proc.bnf",
     "//docs/generated/sql/bnf:create_role_stmt.bnf",
     "//docs/generated/sql/bnf:create_schedule_for_backup_stmt.bnf",
@@ -107,11 +110,13 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:create_schedule_stmt.bnf",
     "//docs/generated/sql/bnf:create_schema_stmt.bnf",
     "//docs/generated/sql/bnf:create_sequence_stmt.bnf",
+    "//docs/generated/sql/bnf:create_server_stmt.bnf",
     "//docs/generated/sql/bnf:create_stats_stmt.bnf",
     "//docs/generated/sql/bnf:create_stmt.bnf",
     "//docs/generated/sql/bnf:create_table_as_stmt.bnf",
     "//docs/generated/sql/bnf:create_table_stmt.bnf",
     "//docs/generated/sql/bnf:create_table_with_storage_param.bnf",
+    "//docs/generated/sql/bnf:create_trigger_stmt.bnf",
     "//docs/generated/sql/bnf:create_type.bnf",
     "//docs/generated/sql/bnf:create_view_stmt.bnf",
     "//docs/generated/sql/bnf:deallocate_stmt.bnf",
@@ -119,21 +124,26 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:default_value_column_level.bnf",
     "//docs/generated/sql/bnf:delete_stmt.bnf",
     "//docs/generated/sql/bnf:discard_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_aggregate_stmt.bnf",
     "//docs/generated/sql/bnf:drop_column.bnf",
     "//docs/generated/sql/bnf:drop_constraint.bnf",
     "//docs/generated/sql/bnf:drop_database.bnf",
     "//docs/generated/sql/bnf:drop_ddl_stmt.bnf",
     "//docs/generated/sql/bnf:drop_external_connection_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_foreign_table_stmt.bnf",
     "//docs/generated/sql/bnf:drop_func_stmt.bnf",
     "//docs/generated/sql/bnf:drop_index.bnf",
     "//docs/generated/sql/bnf:drop_owned_by_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_policy_stmt.bnf",
     "//docs/generated/sql/bnf:drop_proc.bnf",
     "//docs/generated/sql/bnf:drop_role_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schedule_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schema.bnf",
     "//docs/generated/sql/bnf:drop_sequence_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_server_stmt.bnf",
     "//docs/generated/sql/bnf:drop_stmt.bnf",
     "//docs/generated/sql/bnf:drop_table.bnf",
+    "//docs/generated/sql/bnf:drop_trigger_stmt.bnf",
     "//docs/generated/sql/bnf:drop_type.bnf",
     "//docs/generated/sql/bnf:drop_view.bnf",
     "//docs/generated/sql/bnf:execute_stmt.bnf",
@@ -164,8 +174,11 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:legacy_transaction_stmt.bnf",
     "//docs/generated/sql/bnf:like_table_option_list.bnf",
     "//docs/generated/sql/bnf:limit_clause.bnf",
+    "//docs/generated/sql/bnf:listen_stmt.bnf",
+    "//docs/generated/sql/bnf:merge_stmt.bnf",
     "//docs/generated/sql/bnf:move_cursor_stmt.bnf",
     "//docs/generated/sql/bnf:not_null_column_level.bnf",
+    "//docs/generated/sql/bnf:notify_stmt.bnf",
     "//docs/generated/sql/bnf:offset_clause.bnf",
     "//docs/generated/sql/bnf:on_conflict.bnf",
     "//docs/generated/sql/bnf:opt_frame_clause.bnf",
@@ -211,6 +224,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:select_clause.bnf",
     "//docs/generated/sql/bnf:select_stmt.bnf",
     "//docs/generated/sql/bnf:set_cluster_setting.bnf",
+    "//docs/generated/sql/bnf:set_constraints_stmt.bnf",
     "//docs/generated/sql/bnf:set_csetting_stmt.bnf",
     "//docs/generated/sql/bnf:set_exprs_internal.bnf",
     "//docs/generated/sql/bnf:set_local_stmt.bnf",
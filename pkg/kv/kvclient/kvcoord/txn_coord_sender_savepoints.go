@@ -187,6 +187,26 @@ func (tc *TxnCoordSender) CanUseSavepoint(ctx context.Context, s kv.SavepointTok
 	return tc.checkSavepointLocked(sp, "release") == nil
 }
 
+// CanRollbackToSavepointForPartialRetry is part of the kv.TxnSender interface.
+func (tc *TxnCoordSender) CanRollbackToSavepointForPartialRetry(
+	ctx context.Context, s kv.SavepointToken,
+) bool {
+	if tc.typ != kv.RootTxn {
+		return false
+	}
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	// Only a retryable error that does not require the transaction to restart
+	// from the beginning (e.g. under READ COMMITTED isolation) allows the
+	// transaction to roll back to a savepoint and retry from there.
+	if tc.mu.txnState != txnRetryableError ||
+		tc.mu.storedRetryableErr.TxnMustRestartFromBeginning() {
+		return false
+	}
+	sp := s.(*savepoint)
+	return tc.checkSavepointLocked(sp, "rollback to") == nil
+}
+
 type errSavepointOperationInErrorTxn struct{}
 
 // ErrSavepointOperationInErrorTxn is reported when CreateSavepoint()
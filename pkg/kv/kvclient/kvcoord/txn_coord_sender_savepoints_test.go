@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -92,6 +93,11 @@ func TestSavepoints(t *testing.T) {
 			switch td.Cmd {
 			case "begin":
 				txn = kv.NewTxn(ctx, db, 0)
+				if td.HasArg("read-committed") {
+					if err := txn.SetIsoLevel(isolation.ReadCommitted); err != nil {
+						t.Fatal(err)
+					}
+				}
 				ptxn()
 
 			case "commit":
@@ -137,6 +143,18 @@ func TestSavepoints(t *testing.T) {
 				fmt.Fprintf(&buf, "txn error cleared\n")
 				fmt.Fprintf(&buf, "txn id %s\n", changed)
 
+			case "partial-reset":
+				prevID := txn.ID()
+				if err := txn.PrepareForPartialRetry(ctx); err != nil {
+					t.Fatal(err)
+				}
+				changed := "changed"
+				if prevID == txn.ID() {
+					changed = "not changed"
+				}
+				fmt.Fprintf(&buf, "txn error cleared\n")
+				fmt.Fprintf(&buf, "txn id %s\n", changed)
+
 			case "put":
 				b := txn.NewBatch()
 				b.Put(td.CmdArgs[0].Key, td.CmdArgs[1].Key)
@@ -229,6 +247,15 @@ func TestSavepoints(t *testing.T) {
 					fmt.Fprintf(&buf, "false\n")
 				}
 
+			case "can-rollback-partial":
+				spn := td.CmdArgs[0].Key
+				spt := sp[spn]
+				if txn.CanRollbackToSavepointForPartialRetry(ctx, spt) {
+					fmt.Fprintf(&buf, "true\n")
+				} else {
+					fmt.Fprintf(&buf, "false\n")
+				}
+
 			default:
 				td.Fatalf(t, "unknown directive: %s", td.Cmd)
 			}
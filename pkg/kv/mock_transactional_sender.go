@@ -216,6 +216,13 @@ func (m *MockTransactionalSender) CanUseSavepoint(context.Context, SavepointToke
 	panic("unimplemented")
 }
 
+// CanRollbackToSavepointForPartialRetry is part of the kv.TxnSender interface.
+func (m *MockTransactionalSender) CanRollbackToSavepointForPartialRetry(
+	context.Context, SavepointToken,
+) bool {
+	panic("unimplemented")
+}
+
 // Epoch is part of the TxnSender interface.
 func (m *MockTransactionalSender) Epoch() enginepb.TxnEpoch { panic("unimplemented") }
 
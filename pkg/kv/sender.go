@@ -176,6 +176,13 @@ type TxnSender interface {
 	// the given savepoint in the current transaction state. It will never error.
 	CanUseSavepoint(context.Context, SavepointToken) bool
 
+	// CanRollbackToSavepointForPartialRetry checks whether it would be valid to
+	// roll back to the given savepoint after the transaction has encountered a
+	// retryable error that allows a partial retry (e.g. under READ COMMITTED
+	// isolation). If it returns true, the caller must call
+	// PrepareForPartialRetry after rolling back. It will never error.
+	CanRollbackToSavepointForPartialRetry(context.Context, SavepointToken) bool
+
 	// SetFixedTimestamp makes the transaction run in an unusual way, at
 	// a "fixed timestamp": Timestamp and ReadTimestamp are set to ts,
 	// there's no clock uncertainty, and the txn's deadline is set to ts
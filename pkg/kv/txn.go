@@ -1744,6 +1744,16 @@ func (txn *Txn) CanUseSavepoint(ctx context.Context, s SavepointToken) bool {
 	return txn.mu.sender.CanUseSavepoint(ctx, s)
 }
 
+// CanRollbackToSavepointForPartialRetry checks whether it would be valid to
+// roll back to the given savepoint after the transaction has encountered a
+// retryable error that allows a partial retry. If it returns true, the caller
+// must call PrepareForPartialRetry after rolling back. It will never error.
+func (txn *Txn) CanRollbackToSavepointForPartialRetry(ctx context.Context, s SavepointToken) bool {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.CanRollbackToSavepointForPartialRetry(ctx, s)
+}
+
 // DeferCommitWait defers the transaction's commit-wait operation, passing
 // responsibility of commit-waiting from the Txn to the caller of this
 // method. The method returns a function which the caller must eventually
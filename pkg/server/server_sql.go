@@ -965,6 +965,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 			serverCacheMemoryMonitor.MakeBoundAccount(), cfg.stopper,
 		),
 		SequenceCacheNode: sessiondatapb.NewSequenceCacheNode(),
+		PLpgSQLStmtStats:  sql.NewPLpgSQLStmtStats(),
 		SessionInitCache: sessioninit.NewCache(
 			serverCacheMemoryMonitor.MakeBoundAccount(), cfg.stopper,
 		),
@@ -76,6 +76,12 @@ func (p *planner) addColumnImpl(
 	}
 	d = newDef
 
+	dbDesc, err := params.p.Descriptors().ByIDWithLeased(params.p.txn).WithoutNonPublic().Get().Database(params.ctx, desc.GetParentID())
+	if err != nil {
+		return err
+	}
+	tabledesc.MaybeApplyDefaultCollation(d, dbDesc.GetDefaultCollation())
+
 	cdd, err := tabledesc.MakeColumnDefDescs(params.ctx, d, &params.p.semaCtx, params.EvalContext(), tree.ColumnDefaultExprInAddColumn)
 	if err != nil {
 		return err
@@ -81,6 +81,23 @@ func AlterColumnType(
 	if err := schemaexpr.ValidateTTLExpressionDoesNotDependOnColumn(tableDesc, tableDesc.GetRowLevelTTL(), col); err != nil {
 		return err
 	}
+	for _, policy := range tableDesc.Policies {
+		if catalog.MakeTableColSet(policy.ColumnIDs...).Contains(col.GetID()) {
+			return sqlerrors.NewDependentBlocksOpError(
+				"alter type of", "column", col.GetName(), "policy", policy.Name,
+			)
+		}
+	}
+	for _, trigger := range tableDesc.Triggers {
+		if catalog.MakeTableColSet(trigger.ColumnIDs...).Contains(col.GetID()) {
+			return sqlerrors.NewDependentBlocksOpError(
+				"alter type of", "column", col.GetName(), "trigger", trigger.Name,
+			)
+		}
+	}
+	if err := checkColumnNotInBlockRangeIndex(tableDesc, col, "alter type of"); err != nil {
+		return err
+	}
 
 	typ, err := tree.ResolveType(ctx, t.ToType, params.p.semaCtx.GetTypeResolver())
 	if err != nil {
@@ -0,0 +1,198 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// This file contains the ALTER DOMAIN commands that are specific to domains.
+// ALTER DOMAIN RENAME TO, SET SCHEMA and OWNER TO are handled like the
+// corresponding ALTER TYPE commands.
+
+func setDomainDefault(params runParams, n *alterTypeNode, def tree.Expr) error {
+	if def == nil {
+		n.desc.Domain.DefaultExpr = nil
+	} else {
+		s, err := sanitizeDomainDefaultExpr(
+			params, def, n.desc.Domain.BaseType, tree.DomainDefaultExprInAlterDomain,
+		)
+		if err != nil {
+			return err
+		}
+		n.desc.Domain.DefaultExpr = &s
+	}
+	return params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	)
+}
+
+func (p *planner) setDomainNotNull(ctx context.Context, n *alterTypeNode, notNull bool) error {
+	if notNull && !n.desc.Domain.NotNull {
+		// The existing values of the domain must not be NULL.
+		value := &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{tree.DomainValueName}}
+		if err := p.validateDomainValues(
+			ctx, n.desc, &tree.IsNotNullExpr{Expr: value},
+			func(tableName, colName string) error {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values", colName, tableName)
+			},
+		); err != nil {
+			return err
+		}
+	}
+	n.desc.Domain.NotNull = notNull
+	return p.writeTypeSchemaChange(ctx, n.desc, tree.AsStringWithFQNames(n.n, p.Ann()))
+}
+
+func addDomainCheckConstraint(
+	params runParams, n *alterTypeNode, cmd *tree.AlterDomainAddConstraint,
+) error {
+	domain := n.desc.Domain
+	usedNames := make(map[string]struct{}, len(domain.CheckConstraints))
+	for i := range domain.CheckConstraints {
+		usedNames[domain.CheckConstraints[i].Name] = struct{}{}
+	}
+	name := string(cmd.ConstraintName)
+	if name == "" {
+		name = makeDomainCheckConstraintName(n.desc.Name, usedNames)
+	} else if _, ok := usedNames[name]; ok {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, n.desc.Name)
+	}
+	if err := validateDomainCheckExpr(
+		params, cmd.Expr, domain.BaseType, tree.DomainCheckExprInAlterDomain,
+	); err != nil {
+		return err
+	}
+	// The existing values of the domain must satisfy the new constraint.
+	if err := params.p.validateDomainValues(
+		params.ctx, n.desc, cmd.Expr,
+		func(tableName, colName string) error {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				colName, tableName)
+		},
+	); err != nil {
+		return err
+	}
+	domain.CheckConstraints = append(domain.CheckConstraints, descpb.TypeDescriptor_Domain_CheckConstraint{
+		Name: name,
+		Expr: tree.Serialize(cmd.Expr),
+	})
+	return params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	)
+}
+
+func (p *planner) dropDomainCheckConstraint(
+	ctx context.Context, n *alterTypeNode, cmd *tree.AlterDomainDropConstraint,
+) error {
+	domain := n.desc.Domain
+	for i := range domain.CheckConstraints {
+		if domain.CheckConstraints[i].Name == string(cmd.Constraint) {
+			domain.CheckConstraints = append(domain.CheckConstraints[:i], domain.CheckConstraints[i+1:]...)
+			return p.writeTypeSchemaChange(ctx, n.desc, tree.AsStringWithFQNames(n.n, p.Ann()))
+		}
+	}
+	if cmd.IfExists {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"constraint %q of domain %q does not exist, skipping", cmd.Constraint, n.desc.Name))
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"constraint %q of domain %q does not exist", cmd.Constraint, n.desc.Name)
+}
+
+func (p *planner) renameDomainCheckConstraint(
+	ctx context.Context, n *alterTypeNode, cmd *tree.AlterDomainRenameConstraint,
+) error {
+	domain := n.desc.Domain
+	idx := -1
+	for i := range domain.CheckConstraints {
+		switch domain.CheckConstraints[i].Name {
+		case string(cmd.Constraint):
+			idx = i
+		case string(cmd.NewName):
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", cmd.NewName, n.desc.Name)
+		}
+	}
+	if idx == -1 {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q of domain %q does not exist", cmd.Constraint, n.desc.Name)
+	}
+	domain.CheckConstraints[idx].Name = string(cmd.NewName)
+	return p.writeTypeSchemaChange(ctx, n.desc, tree.AsStringWithFQNames(n.n, p.Ann()))
+}
+
+// validateDomainValues checks that the values of the table columns of the
+// given domain satisfy the given predicate, in which VALUE refers to the value
+// being checked. Like CHECK constraints, the predicate is satisfied if it
+// evaluates to NULL. The error for the first column that has a value that
+// doesn't satisfy the predicate is created by violationErr.
+func (p *planner) validateDomainValues(
+	ctx context.Context,
+	desc *typedesc.Mutable,
+	pred tree.Expr,
+	violationErr func(tableName, colName string) error,
+) error {
+	for _, id := range desc.ReferencingDescriptorIDs {
+		refDesc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl, ok := refDesc.(catalog.TableDescriptor)
+		if !ok || !tbl.IsPhysicalTable() || tbl.IsSequence() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if !col.GetType().UserDefined() || typedesc.GetUserDefinedTypeDescID(col.GetType()) != desc.ID {
+				continue
+			}
+			// The values are stored as values of the base type of the domain.
+			value := &tree.CastExpr{
+				Expr:       &tree.ColumnItem{ColumnName: col.ColName()},
+				Type:       desc.Domain.BaseType,
+				SyntaxMode: tree.CastShort,
+			}
+			expr, err := tree.ReplaceDomainValue(pred, value)
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf(
+				`SELECT 1 FROM [%d AS t] WHERE NOT (%s) LIMIT 1`, tbl.GetID(), tree.Serialize(expr),
+			)
+			log.Infof(ctx, "validating domain %q with query %q", desc.Name, query)
+			row, err := p.InternalSQLTxn().QueryRowEx(
+				ctx, "validate-domain", p.txn, sessiondata.NodeUserSessionDataOverride, query,
+			)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				return violationErr(tbl.GetName(), col.GetName())
+			}
+		}
+	}
+	return nil
+}
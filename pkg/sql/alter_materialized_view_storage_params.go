@@ -0,0 +1,113 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/tablestorageparam"
+	"github.com/cockroachdb/errors"
+)
+
+type alterMaterializedViewStorageParamsNode struct {
+	n    *tree.AlterMaterializedViewStorageParams
+	desc *tabledesc.Mutable
+}
+
+// AlterMaterializedViewStorageParams sets or resets the storage parameters of
+// a materialized view.
+func (p *planner) AlterMaterializedViewStorageParams(
+	ctx context.Context, n *tree.AlterMaterializedViewStorageParams,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER MATERIALIZED VIEW",
+	); err != nil {
+		return nil, err
+	}
+
+	tn := n.Name.ToTableName()
+	_, desc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireViewDesc)
+	if err != nil {
+		return nil, err
+	}
+	if desc == nil {
+		// Noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := checkViewMatchesMaterialized(desc, true /* requireView */, true /* wantMaterialized */); err != nil {
+		return nil, err
+	}
+
+	// The view is refreshed in the background as its owner, so only the owner
+	// may configure it, like for REFRESH MATERIALIZED VIEW.
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	if !hasOwnership {
+		return nil, pgerror.Newf(
+			pgcode.InsufficientPrivilege,
+			"must be owner of materialized view %s",
+			desc.Name,
+		)
+	}
+	return &alterMaterializedViewStorageParamsNode{n: n, desc: desc}, nil
+}
+
+func (n *alterMaterializedViewStorageParamsNode) startExec(params runParams) error {
+	setter := tablestorageparam.NewSetter(n.desc)
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterTableSetStorageParams:
+		if err := storageparam.Set(
+			params.ctx,
+			params.p.SemaCtx(),
+			params.EvalContext(),
+			t.StorageParams,
+			setter,
+		); err != nil {
+			return err
+		}
+	case *tree.AlterTableResetStorageParams:
+		if err := storageparam.Reset(
+			params.ctx,
+			params.EvalContext(),
+			t.Params,
+			setter,
+		); err != nil {
+			return err
+		}
+	default:
+		return errors.AssertionFailedf("unexpected command %T", t)
+	}
+
+	if err := params.p.maybeStartMaterializedViewRefreshJob(params.ctx, n.desc); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx,
+		n.desc,
+		descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *alterMaterializedViewStorageParamsNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterMaterializedViewStorageParamsNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterMaterializedViewStorageParamsNode) Close(context.Context)        {}
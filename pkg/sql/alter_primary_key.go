@@ -72,6 +72,11 @@ func (p *planner) AlterPrimaryKey(
 		return err
 	}
 
+	if len(tableDesc.GetBlockRangeIndexes()) > 0 {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot change the primary key of %q because it has BRIN indexes", tableDesc.GetName())
+	}
+
 	if alterPrimaryKeyLocalitySwap != nil {
 		if err := p.checkNoRegionChangeUnderway(
 			ctx,
@@ -100,6 +100,12 @@ func (p *planner) AlterRoleNode(
 		return nil, err
 	}
 
+	if roleOptions.Contains(roleoption.BYPASSRLS) {
+		if err := p.checkRowLevelSecurityVersion(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	if roleOptions.Contains(roleoption.CONTROLCHANGEFEED) {
 		p.BufferClientNotice(ctx, pgnotice.Newf(roleoption.ControlChangefeedDeprecationNoticeMsg))
 	}
@@ -705,6 +705,30 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableSetRowLevelSecurity:
+			if err := params.p.checkRowLevelSecurityVersion(params.ctx); err != nil {
+				return err
+			}
+			if err := params.p.checkPolicyTableOwnership(params.ctx, n.tableDesc); err != nil {
+				return err
+			}
+			switch t.Mode {
+			case tree.RowLevelSecurityEnable:
+				descriptorChanged = descriptorChanged || !n.tableDesc.RowLevelSecurityEnabled
+				n.tableDesc.RowLevelSecurityEnabled = true
+			case tree.RowLevelSecurityDisable:
+				descriptorChanged = descriptorChanged || n.tableDesc.RowLevelSecurityEnabled
+				n.tableDesc.RowLevelSecurityEnabled = false
+			case tree.RowLevelSecurityForce:
+				descriptorChanged = descriptorChanged || !n.tableDesc.RowLevelSecurityForced
+				n.tableDesc.RowLevelSecurityForced = true
+			case tree.RowLevelSecurityNoForce:
+				descriptorChanged = descriptorChanged || n.tableDesc.RowLevelSecurityForced
+				n.tableDesc.RowLevelSecurityForced = false
+			default:
+				return errors.AssertionFailedf("unknown row-level security mode %d", t.Mode)
+			}
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -1776,6 +1800,9 @@ func dropColumnImpl(
 			pgcode.FeatureNotSupported,
 			"cannot alter system column %q", colToDrop.GetName())
 	}
+	if err := checkColumnNotInBlockRangeIndex(tableDesc, colToDrop, "drop"); err != nil {
+		return nil, err
+	}
 
 	if colToDrop.IsInaccessible() {
 		return nil, pgerror.Newf(
@@ -1851,6 +1878,18 @@ func dropColumnImpl(
 		return nil, err
 	}
 
+	// You can't drop a column referenced by a row-level security policy unless
+	// CASCADE was specified, in which case the policy is dropped as well.
+	if err := removePoliciesReferencingColumn(params, tableDesc, colToDrop, t.DropBehavior); err != nil {
+		return nil, err
+	}
+
+	// Likewise for triggers whose WHEN condition or UPDATE OF clause references
+	// the column.
+	if err := removeTriggersReferencingColumn(params, tableDesc, colToDrop, t.DropBehavior); err != nil {
+		return nil, err
+	}
+
 	if tableDesc.GetPrimaryIndex().CollectKeyColumnIDs().Contains(colToDrop.GetID()) {
 		return nil, sqlerrors.NewColumnReferencedByPrimaryKeyError(colToDrop.GetName())
 	}
@@ -58,6 +58,14 @@ func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, e
 		return nil, err
 	}
 
+	if n.Domain && desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(
+			pgcode.WrongObjectType,
+			"%q is not a domain",
+			tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations),
+		)
+	}
+
 	switch desc.Kind {
 	case descpb.TypeDescriptor_ALIAS:
 		// The implicit array types are not modifiable.
@@ -130,6 +138,18 @@ func (n *alterTypeNode) startExec(params runParams) error {
 		eventLogDone = true // done inside alterTypeOwner().
 	case *tree.AlterTypeDropValue:
 		err = params.p.dropEnumValue(params.ctx, n.desc, t.Val)
+	case *tree.AlterDomainSetDefault:
+		err = setDomainDefault(params, n, t.Default)
+	case *tree.AlterDomainSetNotNull:
+		err = params.p.setDomainNotNull(params.ctx, n, true /* notNull */)
+	case *tree.AlterDomainDropNotNull:
+		err = params.p.setDomainNotNull(params.ctx, n, false /* notNull */)
+	case *tree.AlterDomainAddConstraint:
+		err = addDomainCheckConstraint(params, n, t)
+	case *tree.AlterDomainDropConstraint:
+		err = params.p.dropDomainCheckConstraint(params.ctx, n, t)
+	case *tree.AlterDomainRenameConstraint:
+		err = params.p.renameDomainCheckConstraint(params.ctx, n, t)
 	default:
 		err = errors.AssertionFailedf("unknown alter type cmd %s", t)
 	}
@@ -1607,10 +1607,8 @@ func ValidateConstraint(
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
-					return validateUniqueConstraint(
-						ctx, tableDesc, uwi.GetName(),
-						uwi.CollectKeyColumnIDs().Ordered(),
-						uwi.GetPredicate(),
+					return validateUniqueWithoutIndexConstraint(
+						ctx, tableDesc, uwi,
 						indexIDForValidation,
 						txn,
 						sessionData.User(),
@@ -2813,6 +2811,11 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
+			if uc.IsExclusion() {
+				return validateExclusionConstraint(
+					ctx, tableDesc, uc, 0 /* indexIDForValidation */, txn, user, false, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
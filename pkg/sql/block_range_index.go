@@ -0,0 +1,717 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
+	"github.com/cockroachdb/cockroach/pkg/sql/regions"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// A block range index summarizes the values of one column over consecutive
+// ranges ("blocks") of the leading primary key column, which must be an
+// ascending INT. Block b of an index with range size n covers the rows whose
+// leading primary key value lies in [b*n, (b+1)*n). The summary of a block is
+// stored as a set of deltas in the index's key span, each of which summarizes
+// some of the rows written to the block:
+//
+//   /Table/<table id>/<index id>/<b>/<delta id> -> <flags><min><max>
+//
+// Every batch of writes to a block adds a new delta with a unique ID with a
+// blind put, so that concurrent writers to the same block do not conflict.
+// The deltas of a block are merged when the summaries are read, and blocks
+// with many deltas are compacted into a single delta in the background.
+// Summaries are widened by every write to the table and never narrowed, so a
+// block without a summary contains no rows and a block whose summary does not
+// overlap a constraint contains no row satisfying it.
+
+// defaultBlockRangeSize is the number of consecutive leading primary key
+// values summarized together when the pages_per_range storage parameter is
+// not specified.
+const defaultBlockRangeSize = 128
+
+// blockRangeSummarizeChunkBlocks is the number of blocks summarized per
+// transaction when a block range index is built for an existing table.
+const blockRangeSummarizeChunkBlocks = 64
+
+// blockRangeScanBatchSize is the maximum number of deltas read per KV request
+// when the summaries are read to restrict a scan.
+const blockRangeScanBatchSize = 1024
+
+// blockRangeCompactionThreshold is the number of deltas of a block above which
+// the deltas are compacted into one after they are read.
+const blockRangeCompactionThreshold = 16
+
+const blockRangeHasNulls byte = 1 << 0
+
+// blockRangeNumber returns the block that the given leading primary key value
+// falls into.
+func blockRangeNumber(pk int64, size int64) int64 {
+	b := pk / size
+	if pk%size != 0 && pk < 0 {
+		b--
+	}
+	return b
+}
+
+// blockRangeKey returns the prefix of the keys that store the deltas of the
+// summary of the given block.
+func blockRangeKey(
+	codec keys.SQLCodec, tableID descpb.ID, indexID descpb.IndexID, block int64,
+) roachpb.Key {
+	return encoding.EncodeVarintAscending(codec.IndexPrefix(uint32(tableID), uint32(indexID)), block)
+}
+
+// blockRangeDeltaKey returns the key that stores the given delta of the
+// summary of the given block.
+func blockRangeDeltaKey(
+	codec keys.SQLCodec, tableID descpb.ID, indexID descpb.IndexID, block int64, deltaID int64,
+) roachpb.Key {
+	return encoding.EncodeVarintAscending(blockRangeKey(codec, tableID, indexID, block), deltaID)
+}
+
+// decodeBlockRangeKey returns the block of a key in the span of a block range
+// index with the given prefix.
+func decodeBlockRangeKey(prefix, key roachpb.Key) (block int64, _ error) {
+	_, block, err := encoding.DecodeVarintAscending(key[len(prefix):])
+	return block, err
+}
+
+// newBlockRangeDeltaID returns a unique ID for a new delta.
+func newBlockRangeDeltaID(evalCtx *eval.Context) int64 {
+	return int64(builtins.GenerateUniqueInt(
+		builtins.ProcessUniqueID(evalCtx.NodeID.SQLInstanceID()),
+	))
+}
+
+// blockRangeSummary is the summary of the values of a column in one block.
+type blockRangeSummary struct {
+	hasNulls bool
+	// min and max are nil if the block has no non-NULL values.
+	min, max tree.Datum
+}
+
+func (s *blockRangeSummary) add(evalCtx *eval.Context, d tree.Datum) error {
+	if d == tree.DNull {
+		s.hasNulls = true
+		return nil
+	}
+	if s.min == nil {
+		s.min, s.max = d, d
+		return nil
+	}
+	if c, err := d.CompareError(evalCtx, s.min); err != nil {
+		return err
+	} else if c < 0 {
+		s.min = d
+	}
+	if c, err := d.CompareError(evalCtx, s.max); err != nil {
+		return err
+	} else if c > 0 {
+		s.max = d
+	}
+	return nil
+}
+
+func (s *blockRangeSummary) merge(evalCtx *eval.Context, other *blockRangeSummary) error {
+	s.hasNulls = s.hasNulls || other.hasNulls
+	if other.min == nil {
+		return nil
+	}
+	if err := s.add(evalCtx, other.min); err != nil {
+		return err
+	}
+	return s.add(evalCtx, other.max)
+}
+
+func (s *blockRangeSummary) encode() ([]byte, error) {
+	var flags byte
+	if s.hasNulls {
+		flags |= blockRangeHasNulls
+	}
+	buf := []byte{flags}
+	min, max := s.min, s.max
+	if min == nil {
+		min, max = tree.DNull, tree.DNull
+	}
+	buf, err := valueside.Encode(buf, valueside.NoColumnID, min, nil /* scratch */)
+	if err != nil {
+		return nil, err
+	}
+	return valueside.Encode(buf, valueside.NoColumnID, max, nil /* scratch */)
+}
+
+func (s *blockRangeSummary) decode(a *tree.DatumAlloc, typ *types.T, b []byte) error {
+	if len(b) == 0 {
+		return errors.AssertionFailedf("empty block range summary")
+	}
+	s.hasNulls = b[0]&blockRangeHasNulls != 0
+	min, b, err := valueside.Decode(a, typ, b[1:])
+	if err != nil {
+		return err
+	}
+	max, _, err := valueside.Decode(a, typ, b)
+	if err != nil {
+		return err
+	}
+	if min == tree.DNull {
+		s.min, s.max = nil, nil
+	} else {
+		s.min, s.max = min, max
+	}
+	return nil
+}
+
+// mayContain returns whether the block may contain a value that satisfies
+// the given single-column constraint.
+func (s *blockRangeSummary) mayContain(evalCtx *eval.Context, c *constraint.Constraint) bool {
+	var sp constraint.Span
+	if s.hasNulls {
+		null := constraint.MakeKey(tree.DNull)
+		sp.Init(null, constraint.IncludeBoundary, null, constraint.IncludeBoundary)
+		if c.IntersectsSpan(evalCtx, &sp) {
+			return true
+		}
+	}
+	if s.min == nil {
+		return false
+	}
+	sp.Init(
+		constraint.MakeKey(s.min), constraint.IncludeBoundary,
+		constraint.MakeKey(s.max), constraint.IncludeBoundary,
+	)
+	return c.IntersectsSpan(evalCtx, &sp)
+}
+
+// blockRangeSummaries accumulates the summaries of the rows written by a
+// statement so that each touched block is read and rewritten once per batch.
+type blockRangeSummaries struct {
+	evalCtx *eval.Context
+	codec   keys.SQLCodec
+	desc    catalog.TableDescriptor
+	indexes []descpb.TableDescriptor_BlockRangeIndex
+	// pkColID is the leading primary key column of the table.
+	pkColID descpb.ColumnID
+	// pending holds, for each index, the summaries of the blocks written
+	// since the last flush.
+	pending []map[int64]*blockRangeSummary
+	alloc   tree.DatumAlloc
+}
+
+// makeBlockRangeSummaries returns an accumulator for the given block range
+// indexes of the table, or nil if there are none.
+func makeBlockRangeSummaries(
+	evalCtx *eval.Context,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	indexes []descpb.TableDescriptor_BlockRangeIndex,
+) *blockRangeSummaries {
+	if len(indexes) == 0 {
+		return nil
+	}
+	s := &blockRangeSummaries{
+		evalCtx: evalCtx,
+		codec:   codec,
+		desc:    desc,
+		indexes: indexes,
+		pkColID: desc.GetPrimaryIndex().GetKeyColumnID(0),
+		pending: make([]map[int64]*blockRangeSummary, len(indexes)),
+	}
+	for i := range s.pending {
+		s.pending[i] = make(map[int64]*blockRangeSummary)
+	}
+	return s
+}
+
+// affectedBy returns whether updating the given columns can change the
+// summaries.
+func (s *blockRangeSummaries) affectedBy(cols []catalog.Column) bool {
+	for _, col := range cols {
+		if col.GetID() == s.pkColID {
+			return true
+		}
+		for i := range s.indexes {
+			if col.GetID() == s.indexes[i].ColumnID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// add records the values of a written row.
+func (s *blockRangeSummaries) add(values tree.Datums, colIDtoRowIndex catalog.TableColMap) error {
+	pkIdx, ok := colIDtoRowIndex.Get(s.pkColID)
+	if !ok {
+		return errors.AssertionFailedf("leading primary key column %d is not being written", s.pkColID)
+	}
+	pk := int64(tree.MustBeDInt(values[pkIdx]))
+	for i := range s.indexes {
+		idx := &s.indexes[i]
+		colIdx, ok := colIDtoRowIndex.Get(idx.ColumnID)
+		if !ok {
+			return errors.AssertionFailedf(
+				"column %d of block range index %q is not being written", idx.ColumnID, idx.Name,
+			)
+		}
+		block := blockRangeNumber(pk, idx.RangeSize)
+		sum, ok := s.pending[i][block]
+		if !ok {
+			sum = &blockRangeSummary{}
+			s.pending[i][block] = sum
+		}
+		if err := sum.add(s.evalCtx, values[colIdx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush adds the pending summaries to the batch as new deltas. The deltas are
+// written with blind puts to keys that no other writer uses, so concurrent
+// writers to the same block do not conflict with each other.
+func (s *blockRangeSummaries) flush(b *kv.Batch) error {
+	for i := range s.indexes {
+		for block, sum := range s.pending[i] {
+			val, err := sum.encode()
+			if err != nil {
+				return err
+			}
+			b.Put(blockRangeDeltaKey(
+				s.codec, s.desc.GetID(), s.indexes[i].ID, block, newBlockRangeDeltaID(s.evalCtx),
+			), val)
+		}
+		s.pending[i] = make(map[int64]*blockRangeSummary)
+	}
+	return nil
+}
+
+// blockRangeSpans restricts the given spans of the primary index to the
+// blocks whose summaries in the block range index may contain a value
+// satisfying the constraint. Only the summaries of the blocks that overlap the
+// spans are read, a page at a time, and the memory used for them is accounted
+// for in the given account. It also returns the blocks that have enough
+// deltas to be compacted.
+func blockRangeSpans(
+	ctx context.Context,
+	txn *kv.Txn,
+	evalCtx *eval.Context,
+	acc *mon.BoundAccount,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	idx *descpb.TableDescriptor_BlockRangeIndex,
+	c *constraint.Constraint,
+	spans roachpb.Spans,
+) (_ roachpb.Spans, toCompact []int64, _ error) {
+	if len(spans) == 0 {
+		return nil, nil, nil
+	}
+	col, err := catalog.MustFindColumnByID(desc, idx.ColumnID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sorted := make(roachpb.Spans, len(spans))
+	copy(sorted, spans)
+	sort.Sort(sorted)
+
+	// Only read the summaries of the blocks between the first and the last
+	// block that the spans overlap.
+	pkPrefix := codec.IndexPrefix(uint32(desc.GetID()), uint32(desc.GetPrimaryIndexID()))
+	prefix := codec.IndexPrefix(uint32(desc.GetID()), uint32(idx.ID))
+	readSpan := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+	if pk, ok := decodeLeadingPK(pkPrefix, sorted[0].Key); ok {
+		readSpan.Key = blockRangeKey(codec, desc.GetID(), idx.ID, blockRangeNumber(pk, idx.RangeSize))
+	}
+	last := sorted[len(sorted)-1]
+	end := last.EndKey
+	if len(end) == 0 {
+		end = last.Key
+	}
+	for _, sp := range sorted {
+		if sp.EndKey.Compare(end) > 0 {
+			end = sp.EndKey
+		}
+	}
+	if pk, ok := decodeLeadingPK(pkPrefix, end); ok {
+		readSpan.EndKey = blockRangeKey(
+			codec, desc.GetID(), idx.ID, blockRangeNumber(pk, idx.RangeSize),
+		).PrefixEnd()
+	}
+
+	var a tree.DatumAlloc
+	var blocks roachpb.Spans
+	// sum accumulates the deltas of the current block.
+	var sum blockRangeSummary
+	var delta blockRangeSummary
+	curBlock, numDeltas := int64(0), 0
+	finishBlock := func() error {
+		if numDeltas == 0 {
+			return nil
+		}
+		if numDeltas > blockRangeCompactionThreshold {
+			toCompact = append(toCompact, curBlock)
+		}
+		mayContain := sum.mayContain(evalCtx, c)
+		sum, numDeltas = blockRangeSummary{}, 0
+		if !mayContain {
+			return nil
+		}
+		lo := curBlock * idx.RangeSize
+		sp := roachpb.Span{Key: encoding.EncodeVarintAscending(pkPrefix.Clone(), lo)}
+		if lo > math.MaxInt64-idx.RangeSize {
+			sp.EndKey = pkPrefix.PrefixEnd()
+		} else {
+			sp.EndKey = encoding.EncodeVarintAscending(pkPrefix.Clone(), lo+idx.RangeSize)
+		}
+		if n := len(blocks); n > 0 && blocks[n-1].EndKey.Equal(sp.Key) {
+			blocks[n-1].EndKey = sp.EndKey
+			return nil
+		}
+		if err := acc.Grow(ctx, int64(len(sp.Key)+len(sp.EndKey))); err != nil {
+			return err
+		}
+		blocks = append(blocks, sp)
+		return nil
+	}
+	for readSpan.Valid() {
+		b := txn.NewBatch()
+		b.Header.MaxSpanRequestKeys = blockRangeScanBatchSize
+		b.Scan(readSpan.Key, readSpan.EndKey)
+		if err := txn.Run(ctx, b); err != nil {
+			return nil, nil, err
+		}
+		res := &b.Results[0]
+		var pageBytes int64
+		for i := range res.Rows {
+			pageBytes += int64(len(res.Rows[i].Key) + len(res.Rows[i].ValueBytes()))
+		}
+		if err := acc.Grow(ctx, pageBytes); err != nil {
+			return nil, nil, err
+		}
+		for _, kv := range res.Rows {
+			block, err := decodeBlockRangeKey(prefix, kv.Key)
+			if err != nil {
+				return nil, nil, err
+			}
+			if block != curBlock {
+				if err := finishBlock(); err != nil {
+					return nil, nil, err
+				}
+				curBlock = block
+			}
+			if err := delta.decode(&a, col.GetType(), kv.ValueBytes()); err != nil {
+				return nil, nil, err
+			}
+			if err := sum.merge(evalCtx, &delta); err != nil {
+				return nil, nil, err
+			}
+			numDeltas++
+		}
+		acc.Shrink(ctx, pageBytes)
+		readSpan = res.ResumeSpanAsValue()
+	}
+	if err := finishBlock(); err != nil {
+		return nil, nil, err
+	}
+
+	var res roachpb.Spans
+	for i, j := 0, 0; i < len(sorted) && j < len(blocks); {
+		if inter := sorted[i].Intersect(blocks[j]); inter.Valid() {
+			res = append(res, inter)
+		}
+		end := sorted[i].EndKey
+		if len(end) == 0 {
+			end = sorted[i].Key.Next()
+		}
+		if end.Compare(blocks[j].EndKey) <= 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res, toCompact, nil
+}
+
+// decodeLeadingPK returns the leading primary key value of a key in the span of
+// the primary index with the given prefix. It returns false if the key does
+// not contain one, e.g. because it is the start or the end of the index span.
+func decodeLeadingPK(pkPrefix, key roachpb.Key) (int64, bool) {
+	if !bytes.HasPrefix(key, pkPrefix) || len(key) == len(pkPrefix) {
+		return 0, false
+	}
+	_, pk, err := encoding.DecodeVarintAscending(key[len(pkPrefix):])
+	if err != nil {
+		return 0, false
+	}
+	return pk, true
+}
+
+// compactBlockRanges merges the deltas of each of the given blocks of the
+// block range index into a single delta.
+func compactBlockRanges(
+	ctx context.Context,
+	txn *kv.Txn,
+	evalCtx *eval.Context,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	idx *descpb.TableDescriptor_BlockRangeIndex,
+	blocks []int64,
+) error {
+	col, err := catalog.MustFindColumnByID(desc, idx.ColumnID)
+	if err != nil {
+		return err
+	}
+	var a tree.DatumAlloc
+	for _, block := range blocks {
+		key := blockRangeKey(codec, desc.GetID(), idx.ID, block)
+		b := txn.NewBatch()
+		var sum, delta blockRangeSummary
+		for sp := (roachpb.Span{Key: key, EndKey: key.PrefixEnd()}); sp.Valid(); {
+			scan := txn.NewBatch()
+			scan.Header.MaxSpanRequestKeys = blockRangeScanBatchSize
+			scan.Scan(sp.Key, sp.EndKey)
+			if err := txn.Run(ctx, scan); err != nil {
+				return err
+			}
+			res := &scan.Results[0]
+			for _, kv := range res.Rows {
+				if err := delta.decode(&a, col.GetType(), kv.ValueBytes()); err != nil {
+					return err
+				}
+				if err := sum.merge(evalCtx, &delta); err != nil {
+					return err
+				}
+				b.Del(kv.Key)
+			}
+			sp = res.ResumeSpanAsValue()
+		}
+		val, err := sum.encode()
+		if err != nil {
+			return err
+		}
+		b.Put(blockRangeDeltaKey(
+			codec, desc.GetID(), idx.ID, block, newBlockRangeDeltaID(evalCtx),
+		), val)
+		if err := txn.Run(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maybeCompactBlockRangesAsync compacts the deltas of the given blocks of the
+// block range index in a background transaction.
+func (p *planner) maybeCompactBlockRangesAsync(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	idx descpb.TableDescriptor_BlockRangeIndex,
+	blocks []int64,
+) {
+	if len(blocks) == 0 {
+		return
+	}
+	execCfg := p.ExecCfg()
+	// The compaction outlives the statement that triggered it.
+	ctx = execCfg.AmbientCtx.AnnotateCtx(context.Background())
+	if err := execCfg.DistSQLSrv.Stopper.RunAsyncTask(ctx, "compact-block-range-index", func(ctx context.Context) {
+		sd := NewInternalSessionData(ctx, execCfg.Settings, "compact-block-range-index")
+		if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+			evalCtx := createSchemaChangeEvalCtx(
+				ctx, execCfg, sd, txn.KV().ReadTimestamp(), txn.Descriptors(),
+			)
+			return compactBlockRanges(
+				ctx, txn.KV(), &evalCtx.Context, execCfg.Codec, desc, &idx, blocks,
+			)
+		}); err != nil {
+			// The deltas are compacted again the next time they are read.
+			log.Warningf(ctx, "failed to compact block range index %q: %v", idx.Name, err)
+		}
+	}); err != nil {
+		log.Warningf(ctx, "failed to compact block range index %q: %v", idx.Name, err)
+	}
+}
+
+// summarizeBlockRanges merges the values of the rows whose leading primary
+// key value is at least start into the summaries of the block range index,
+// stopping after blockRangeSummarizeChunkBlocks blocks. It returns the leading
+// primary key value to resume from, or done if the table was exhausted.
+func summarizeBlockRanges(
+	ctx context.Context,
+	txn isql.Txn,
+	evalCtx *eval.Context,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	idx descpb.TableDescriptor_BlockRangeIndex,
+	start int64,
+) (resume int64, done bool, _ error) {
+	pkCol, err := catalog.MustFindColumnByID(desc, desc.GetPrimaryIndex().GetKeyColumnID(0))
+	if err != nil {
+		return 0, false, err
+	}
+	col, err := catalog.MustFindColumnByID(desc, idx.ColumnID)
+	if err != nil {
+		return 0, false, err
+	}
+	pkName := tree.NameString(pkCol.GetName())
+	row, err := txn.QueryRowEx(
+		ctx, "summarize-block-range-index-start", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(`SELECT min(%[1]s) FROM [%[2]d AS t] WHERE %[1]s >= $1`, pkName, desc.GetID()),
+		start,
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	if row == nil || row[0] == tree.DNull {
+		return 0, true, nil
+	}
+	lo := blockRangeNumber(int64(tree.MustBeDInt(row[0])), idx.RangeSize) * idx.RangeSize
+	query := fmt.Sprintf(
+		`SELECT %[1]s, %[2]s FROM [%[3]d AS t] WHERE %[1]s >= $1`,
+		pkName, tree.NameString(col.GetName()), desc.GetID(),
+	)
+	args := []interface{}{lo}
+	chunk := idx.RangeSize * blockRangeSummarizeChunkBlocks
+	if lo <= math.MaxInt64-chunk {
+		resume = lo + chunk
+		query += ` AND ` + pkName + ` < $2`
+		args = append(args, resume)
+	} else {
+		done = true
+	}
+	rows, err := txn.QueryBufferedEx(
+		ctx, "summarize-block-range-index", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		query, args...,
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	var colIDtoRowIndex catalog.TableColMap
+	colIDtoRowIndex.Set(pkCol.GetID(), 0)
+	colIDtoRowIndex.Set(col.GetID(), 1)
+	s := makeBlockRangeSummaries(
+		evalCtx, codec, desc, []descpb.TableDescriptor_BlockRangeIndex{idx},
+	)
+	for _, r := range rows {
+		if err := s.add(r, colIDtoRowIndex); err != nil {
+			return 0, false, err
+		}
+	}
+	b := txn.KV().NewBatch()
+	if err := s.flush(b); err != nil {
+		return 0, false, err
+	}
+	if err := txn.KV().Run(ctx, b); err != nil {
+		return 0, false, err
+	}
+	return resume, done, nil
+}
+
+// checkColumnNotInBlockRangeIndex returns an error if the column is
+// summarized by a block range index of the table.
+func checkColumnNotInBlockRangeIndex(
+	desc catalog.TableDescriptor, col catalog.Column, op string,
+) error {
+	for _, idx := range desc.GetBlockRangeIndexes() {
+		if idx.ColumnID == col.GetID() {
+			return sqlerrors.NewDependentBlocksOpError(op, "column", col.GetName(), "index", idx.Name)
+		}
+	}
+	return nil
+}
+
+// maybeSummarizeBlockRangeIndexes summarizes the existing rows of the table
+// into its block range indexes that are not public yet, and makes them public.
+func (sc *SchemaChanger) maybeSummarizeBlockRangeIndexes(
+	ctx context.Context, table catalog.TableDescriptor,
+) error {
+	if table.Dropped() {
+		return nil
+	}
+	var pending []descpb.TableDescriptor_BlockRangeIndex
+	for _, idx := range table.GetBlockRangeIndexes() {
+		if !idx.Public {
+			pending = append(pending, idx)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Once every node has leased a version of the table with the new indexes,
+	// all rows written from then on widen their summaries, so the rows read
+	// below are the only ones left to summarize.
+	cachedRegions, err := regions.NewCachedDatabaseRegions(ctx, sc.db.KV(), sc.leaseMgr)
+	if err != nil {
+		return err
+	}
+	if _, err := WaitToUpdateLeases(ctx, sc.leaseMgr, cachedRegions, table.GetID()); err != nil {
+		return err
+	}
+
+	sd := NewInternalSessionData(ctx, sc.execCfg.Settings, "summarize-block-range-index")
+	for _, idx := range pending {
+		log.Infof(ctx, "summarizing block range index %q", idx.Name)
+		for start, done := int64(math.MinInt64), false; !done; {
+			var resume int64
+			if err := sc.txn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+				evalCtx := createSchemaChangeEvalCtx(
+					ctx, sc.execCfg, sd, txn.KV().ReadTimestamp(), txn.Descriptors(),
+				)
+				resume, done, err = summarizeBlockRanges(
+					ctx, txn, &evalCtx.Context, sc.execCfg.Codec, table, idx, start,
+				)
+				return err
+			}); err != nil {
+				return err
+			}
+			start = resume
+		}
+	}
+
+	log.Info(ctx, "making block range indexes public")
+	return sc.txn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		mut, err := txn.Descriptors().MutableByID(txn.KV()).Table(ctx, table.GetID())
+		if err != nil {
+			return err
+		}
+		for i := range mut.BlockRangeIndexes {
+			for _, idx := range pending {
+				if mut.BlockRangeIndexes[i].ID == idx.ID {
+					mut.BlockRangeIndexes[i].Public = true
+				}
+			}
+		}
+		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, mut, txn.KV())
+	})
+}
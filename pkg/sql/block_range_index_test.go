@@ -0,0 +1,75 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlockRangeIndexDeltas verifies that every write to a block of a block
+// range index adds a delta to its summary, and that the deltas are compacted
+// after they are read.
+func TestBlockRangeIndexDeltas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	codec := s.ApplicationLayer().Codec()
+
+	r := sqlutils.MakeSQLRunner(sqlDB)
+	r.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, ts INT, FAMILY (k, ts))`)
+	r.Exec(t, `INSERT INTO t SELECT i, i * 10 FROM generate_series(1, 1000) AS g(i)`)
+	r.Exec(t, `CREATE INDEX t_ts_idx ON t USING brin (ts) WITH (pages_per_range = 100)`)
+
+	desc := desctestutils.TestingGetPublicTableDescriptor(kvDB, codec, "defaultdb", "t")
+	require.Len(t, desc.GetBlockRangeIndexes(), 1)
+	idx := desc.GetBlockRangeIndexes()[0]
+	numDeltas := func(block int64) int {
+		key := blockRangeKey(codec, desc.GetID(), idx.ID, block)
+		kvs, err := kvDB.Scan(ctx, key, key.PrefixEnd(), 0 /* maxRows */)
+		require.NoError(t, err)
+		return len(kvs)
+	}
+	require.Equal(t, 1, numDeltas(0))
+
+	// Each statement that writes to block 0 adds a delta to its summary.
+	const numWrites = 2 * blockRangeCompactionThreshold
+	for i := 0; i < numWrites; i++ {
+		r.Exec(t, `UPDATE t SET ts = ts + 1 WHERE k = $1`, i+1)
+	}
+	require.Equal(t, numWrites+1, numDeltas(0))
+	require.Equal(t, 1, numDeltas(1))
+
+	// Reading the summaries merges the deltas, and compacts the deltas of
+	// block 0 in the background.
+	testutils.SucceedsSoon(t, func() error {
+		r.CheckQueryResults(t, `SELECT count(*) FROM t WHERE ts BETWEEN 5000 AND 5990`, [][]string{{"100"}})
+		if n := numDeltas(0); n != 1 {
+			return errors.Newf("expected block 0 to be compacted, found %d deltas", n)
+		}
+		return nil
+	})
+	r.CheckQueryResults(t, `SELECT k FROM t WHERE ts < 15 ORDER BY k`, [][]string{{"1"}})
+	r.CheckQueryResults(t, `SELECT k FROM t WHERE ts = 21`, [][]string{{"2"}})
+}
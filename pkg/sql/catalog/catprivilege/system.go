@@ -78,6 +78,8 @@ var (
 		catconstants.MVCCStatistics,
 		catconstants.TxnExecInsightsTableName,
 		catconstants.StmtExecInsightsTableName,
+		catconstants.NotificationsTableName,
+		catconstants.ReplicationSlotsTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -75,7 +75,7 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 	case types.StringFamily, types.CollatedStringFamily:
 		if t.Family() == types.CollatedStringFamily {
 			if _, err := language.Parse(t.Locale()); err != nil {
-				return pgerror.Newf(pgcode.Syntax, `invalid locale %s`, t.Locale())
+				return pgerror.Wrapf(err, pgcode.Syntax, `invalid locale %s`, t.Locale())
 			}
 		}
 
@@ -140,6 +140,26 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 			)
 		}
 
+	case types.GeometricFamily:
+		if !version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"geometric types not supported until version 24.1",
+			)
+		}
+
+	case types.RangeFamily, types.MultiRangeFamily:
+		if types.IsWildcardRangeType(t) {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"value type %s cannot be used for table columns", t.String())
+		}
+		if !version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"range types not supported until version 24.1",
+			)
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -213,6 +233,13 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		return true
 	case types.TSVectorFamily, types.TSQueryFamily:
 		return true
+	case types.MultiRangeFamily:
+		// Multiranges do not have a key encoding.
+		return true
+	case types.GeometricFamily:
+		// The geometric types have no ordering, so they do not have a key
+		// encoding either.
+		return true
 	}
 	return false
 }
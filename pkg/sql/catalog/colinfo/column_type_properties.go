@@ -57,6 +57,8 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
 	case types.TupleFamily:
 		for _, t := range typ.TupleContents() {
 			if CanHaveCompositeKeyEncoding(t) {
@@ -84,7 +86,10 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.Box2DFamily,
 		types.PGLSNFamily,
 		types.RefCursorFamily,
+		types.MultiRangeFamily,
+		types.GeometricFamily,
 		types.VoidFamily,
+		types.TriggerFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
 		types.TSVectorFamily:
@@ -375,3 +375,11 @@ var IdentifySystemColumns = ResultColumns{
 	{Name: "xlogpos", Typ: types.String},
 	{Name: "dbname", Typ: types.String},
 }
+
+// CreateReplicationSlotColumns is the schema for CREATE_REPLICATION_SLOT.
+var CreateReplicationSlotColumns = ResultColumns{
+	{Name: "slot_name", Typ: types.String},
+	{Name: "consistent_point", Typ: types.String},
+	{Name: "snapshot_name", Typ: types.String},
+	{Name: "output_plugin", Typ: types.String},
+}
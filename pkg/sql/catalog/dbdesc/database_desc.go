@@ -217,6 +217,26 @@ func (desc *immutable) HasPublicSchemaWithDescriptor() bool {
 	return found
 }
 
+// GetCollation implements the DatabaseDescriptor interface.
+func (desc *immutable) GetCollation(name string) (descpb.DatabaseDescriptor_Collation, bool) {
+	for _, c := range desc.Collations {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return descpb.DatabaseDescriptor_Collation{}, false
+}
+
+// GetPublication implements the DatabaseDescriptor interface.
+func (desc *immutable) GetPublication(name string) (descpb.DatabaseDescriptor_Publication, bool) {
+	for _, p := range desc.Publications {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return descpb.DatabaseDescriptor_Publication{}, false
+}
+
 // GetNonDroppedSchemaName returns the name in the schema mapping entry for the
 // given ID, if it's not marked as dropped, empty string otherwise.
 func (desc *immutable) GetNonDroppedSchemaName(schemaID descpb.ID) string {
@@ -255,9 +275,44 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		desc.validateMultiRegion(vea)
 	}
 
+	desc.validateCollations(vea)
+	desc.validatePublications(vea)
 	desc.maybeValidateSystemDatabaseSchemaVersion(vea)
 }
 
+// validateCollations checks that the collations of the database have distinct
+// names and a locale.
+func (desc *immutable) validateCollations(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Collations))
+	for _, c := range desc.Collations {
+		if c.Name == "" || c.Locale == "" {
+			vea.Report(errors.AssertionFailedf(
+				"collation %q has an empty name or locale", c.Name))
+		}
+		if _, ok := names[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate collation name %q", c.Name))
+		}
+		names[c.Name] = struct{}{}
+	}
+}
+
+func (desc *immutable) validatePublications(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Publications))
+	for _, p := range desc.Publications {
+		if p.Name == "" {
+			vea.Report(errors.AssertionFailedf("publication has an empty name"))
+		}
+		if _, ok := names[p.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate publication name %q", p.Name))
+		}
+		names[p.Name] = struct{}{}
+		if p.AllTables && len(p.TableIDs) > 0 {
+			vea.Report(errors.AssertionFailedf(
+				"publication %q includes all tables but also lists table IDs", p.Name))
+		}
+	}
+}
+
 // validateMultiRegion performs checks specific to multi-region DBs.
 func (desc *immutable) validateMultiRegion(vea catalog.ValidationErrorAccumulator) {
 	if desc.RegionConfig.PrimaryRegion == "" {
@@ -327,6 +327,14 @@ func WithPublicSchemaID(publicSchemaID descpb.ID) NewInitialOption {
 	}
 }
 
+// WithDefaultCollation is used to create a DatabaseDescriptor whose string
+// columns use the given locale unless they specify a collation.
+func WithDefaultCollation(locale string) NewInitialOption {
+	return func(desc *descpb.DatabaseDescriptor) {
+		desc.DefaultCollation = locale
+	}
+}
+
 // NewInitial constructs a new Mutable for an initial version from an id and
 // name with default privileges.
 func NewInitial(
@@ -295,6 +295,17 @@ type DatabaseDescriptor interface {
 	// HasPublicSchemaWithDescriptor returns true iff the database has a public
 	// schema which itself has a descriptor.
 	HasPublicSchemaWithDescriptor() bool
+	// GetDefaultCollation returns the locale of the default collation of the
+	// database, or the empty string if it uses the "C" collation.
+	GetDefaultCollation() string
+	// GetCollations returns the collations created in the database.
+	GetCollations() []descpb.DatabaseDescriptor_Collation
+	// GetCollation returns the collation with the given name, if any.
+	GetCollation(name string) (descpb.DatabaseDescriptor_Collation, bool)
+	// GetPublications returns the publications created in the database.
+	GetPublications() []descpb.DatabaseDescriptor_Publication
+	// GetPublication returns the publication with the given name, if any.
+	GetPublication(name string) (descpb.DatabaseDescriptor_Publication, bool)
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -332,6 +343,13 @@ type TableDescriptor interface {
 	IsPhysicalTable() bool
 	// MaterializedView returns whether this TableDescriptor is a MaterializedView.
 	MaterializedView() bool
+	// IsForeignTable returns true if the TableDescriptor describes a foreign
+	// table, whose rows are read from files in external storage rather than
+	// from the KV layer.
+	IsForeignTable() bool
+	// GetForeignTable returns the server and options of a foreign table. Only
+	// valid if IsForeignTable is true.
+	GetForeignTable() *descpb.TableDescriptor_ForeignTable
 	// IsAs returns true if the TableDescriptor describes a Table that was created
 	// with a CREATE TABLE AS command.
 	IsAs() bool
@@ -759,6 +777,19 @@ type TableDescriptor interface {
 	// IsSchemaLocked returns true if we don't allow performing schema changes
 	// on this table descriptor.
 	IsSchemaLocked() bool
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table.
+	IsRowLevelSecurityEnabled() bool
+	// IsRowLevelSecurityForced returns true if row-level security also applies
+	// to the owner of the table.
+	IsRowLevelSecurityForced() bool
+	// GetPolicies returns the row-level security policies defined on the table.
+	GetPolicies() []descpb.TableDescriptor_Policy
+	// GetTriggers returns the triggers defined on the table.
+	GetTriggers() []descpb.TableDescriptor_Trigger
+	// GetBlockRangeIndexes returns the block range indexes of the table,
+	// including those whose summaries are still being written.
+	GetBlockRangeIndexes() []descpb.TableDescriptor_BlockRangeIndex
 	// IsPrimaryKeySwapMutation returns true if the mutation is a primary key
 	// swap mutation or a secondary index used by the declarative schema changer
 	// for a primary index swap.
@@ -820,6 +851,10 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -907,6 +942,30 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types, which
+// are base types with optional constraints on their values.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// GetBaseType returns the type the domain is defined over.
+	GetBaseType() *types.T
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// or the empty string if it has none.
+	GetDefaultExpr() string
+
+	// NumCheckConstraints returns the number of CHECK constraints of the
+	// domain.
+	NumCheckConstraints() int
+
+	// GetCheckConstraint returns the name and serialized expression of the
+	// CHECK constraint at the given ordinal.
+	GetCheckConstraint(ordinal int) (name, expr string)
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -991,6 +1050,14 @@ type FunctionDescriptor interface {
 	// IsProcedure returns true if the descriptor represents a procedure. It
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
+
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate function.
+	IsAggregate() bool
+
+	// IsVariadic returns true if the last input parameter of the routine is
+	// VARIADIC.
+	IsVariadic() bool
 }
 
 // FilterDroppedDescriptor returns an error if the descriptor state is DROP.
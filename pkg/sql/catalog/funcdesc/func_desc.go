@@ -233,6 +233,25 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if desc.IsProcedure() {
+			vea.Report(errors.AssertionFailedf("procedure cannot be an aggregate"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		if agg.TransitionFunctionID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate transition function not set"))
+		}
+		deps := catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)
+		for _, id := range []descpb.ID{agg.TransitionFunctionID, agg.FinalFunctionID, agg.CombineFunctionID} {
+			if id != descpb.InvalidID && !deps.Contains(id) {
+				vea.Report(errors.AssertionFailedf(
+					"aggregate support function %d missing from depends-on-functions references", id))
+			}
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -330,9 +349,9 @@ func (desc *immutable) validateInboundFunctionRef(
 			backrefFunctionDesc.GetName(), backrefFunctionDesc.GetID())
 	}
 	// Validate all other references are unset.
-	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil {
-		return errors.AssertionFailedf("function reference has invalid references (%v, %v %v)",
-			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs)
+	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil || ref.TriggerIDs != nil {
+		return errors.AssertionFailedf("function reference has invalid references (%v, %v, %v, %v)",
+			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs, ref.TriggerIDs)
 	}
 	// Validate a reference exists to this function.
 	for _, refID := range backrefFunctionDesc.GetDependsOnFunctions() {
@@ -409,6 +428,23 @@ func (desc *immutable) validateInboundTableRef(
 			cstID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
 		)
 	}
+	for _, triggerID := range by.TriggerIDs {
+		var found bool
+		for _, trigger := range backRefTbl.GetTriggers() {
+			if trigger.ID == triggerID {
+				found = trigger.FuncID == desc.GetID()
+				break
+			}
+		}
+		if !found {
+			return errors.AssertionFailedf(
+				"depended-on-by relation %q (%d) does not have a trigger with ID %d that references function %q (%d)",
+				backRefTbl.GetName(), by.ID, triggerID, desc.GetName(), desc.GetID(),
+			)
+		}
+		foundInTable = true
+	}
+
 	if foundInTable {
 		return nil
 	}
@@ -463,6 +499,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if desc.Aggregate != nil && catid.IsOIDUserDefined(desc.Aggregate.StateType.Oid()) {
+		if err := fn(desc.Aggregate.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -630,6 +671,53 @@ func (desc *Mutable) RemoveConstraintReference(id descpb.ID, constraintID descpb
 	}
 }
 
+// AddTriggerReference adds back reference to a trigger to the function. Unlike
+// other references from tables, a trigger does not create a dependency cycle if
+// the function also depends on the table, since the function is only invoked
+// when rows of the table are modified.
+func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing == triggerID {
+					return
+				}
+			}
+			ids := append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			desc.DependedOnBy[i].TriggerIDs = ids
+			return
+		}
+	}
+	desc.DependedOnBy = append(
+		desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{
+			ID:         id,
+			TriggerIDs: []descpb.TriggerID{triggerID},
+		},
+	)
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+}
+
+// RemoveTriggerReference removes back reference to a trigger from the function.
+func (desc *Mutable) RemoveTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			var ids []descpb.TriggerID
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing != triggerID {
+					ids = append(ids, existing)
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = ids
+			desc.maybeRemoveTableReference(id)
+			return
+		}
+	}
+}
+
 // AddFunctionReference adds back reference for a function invoking this function.
 func (desc *Mutable) AddFunctionReference(id descpb.ID) error {
 	for _, f := range desc.DependsOnFunctions {
@@ -711,7 +799,8 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
-		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 && len(ref.TriggerIDs) == 0 {
 			continue
 		}
 		ret = append(ret, ref)
@@ -800,6 +889,9 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	// when the return type is based on output parameters.
 	ret.ReturnsRecordType = types.IsRecordType(desc.ReturnType.Type)
 	ret.Types = signatureTypes
+	if desc.IsVariadic() {
+		ret.Types = tree.VariadicParamTypes(signatureTypes)
+	}
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
 		return nil, err
@@ -811,6 +903,20 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UserDefinedAggregate = &tree.UserDefinedAggregate{
+			TransitionFunc: catid.FuncIDToOID(agg.TransitionFunctionID),
+			StateType:      agg.StateType,
+			InitCond:       agg.InitCond,
+		}
+		if agg.FinalFunctionID != descpb.InvalidID {
+			ret.UserDefinedAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFunctionID)
+		}
+		if agg.CombineFunctionID != descpb.InvalidID {
+			ret.UserDefinedAggregate.CombineFunc = catid.FuncIDToOID(agg.CombineFunctionID)
+		}
+	}
 
 	return ret, nil
 }
@@ -890,6 +996,21 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.Aggregate != nil
+}
+
+// IsVariadic implements the FunctionDescriptor interface.
+func (desc *immutable) IsVariadic() bool {
+	for i := len(desc.Params) - 1; i >= 0; i-- {
+		if class := ToTreeRoutineParamClass(desc.Params[i].Class); tree.IsInParamClass(class) {
+			return class == tree.RoutineParamVariadic
+		}
+	}
+	return false
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -235,13 +235,17 @@ func ResolveExistingObject(
 		goodType := true
 		switch lookupFlags.DesiredTableDescKind {
 		case tree.ResolveRequireTableDesc:
-			goodType = table.IsTable()
+			// Foreign tables cannot be altered or written like other tables, so
+			// statements that require a table don't accept them.
+			goodType = table.IsTable() && !table.IsForeignTable()
 		case tree.ResolveRequireViewDesc:
 			goodType = table.IsView()
 		case tree.ResolveRequireTableOrViewDesc:
 			goodType = table.IsTable() || table.IsView()
 		case tree.ResolveRequireSequenceDesc:
 			goodType = table.IsSequence()
+		case tree.ResolveRequireForeignTableDesc:
+			goodType = table.IsForeignTable()
 		}
 		if !goodType {
 			return nil, prefix, sqlerrors.NewWrongObjectTypeError(getResolvedTn(), lookupFlags.DesiredTableDescKind.String())
@@ -1047,6 +1047,11 @@ func FunctionDescs(
 		if err := rewriteIDsInTypesT(fnDesc.ReturnType.Type, descriptorRewrites); err != nil {
 			return err
 		}
+		if fnDesc.Aggregate != nil {
+			if err := rewriteIDsInTypesT(fnDesc.Aggregate.StateType, descriptorRewrites); err != nil {
+				return err
+			}
+		}
 
 		// Rewrite Dependency IDs.
 		for i, depID := range fnDesc.DependsOn {
@@ -1079,6 +1084,23 @@ func FunctionDescs(
 			}
 		}
 
+		// The support functions of an aggregate are also in DependsOnFunctions,
+		// so they must have been rewritten above.
+		if agg := fnDesc.Aggregate; agg != nil {
+			for _, funcID := range []*descpb.ID{&agg.TransitionFunctionID, &agg.FinalFunctionID, &agg.CombineFunctionID} {
+				if *funcID == descpb.InvalidID {
+					continue
+				}
+				if funcRewrite, ok := descriptorRewrites[*funcID]; ok {
+					*funcID = funcRewrite.ID
+				} else {
+					return errors.AssertionFailedf(
+						"cannot restore aggregate %q because referenced function %d was not found",
+						fnDesc.Name, *funcID)
+				}
+			}
+		}
+
 		// Rewrite back reference IDs.
 		for i, dep := range fnDesc.DependedOnBy {
 			if depRewrite, ok := descriptorRewrites[dep.ID]; ok {
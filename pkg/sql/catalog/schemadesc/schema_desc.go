@@ -506,10 +506,11 @@ func (desc *Mutable) ReplaceOverload(
 	}
 	for i := range fn.Signatures {
 		sig := fn.Signatures[i]
-		match := existing.Types.Length() == len(sig.ArgTypes) &&
+		existingTypes := existing.Types.Types()
+		match := len(existingTypes) == len(sig.ArgTypes) &&
 			len(existing.OutParamOrdinals) == len(sig.OutParamOrdinals)
 		for j := 0; match && j < len(sig.ArgTypes); j++ {
-			match = existing.Types.GetAt(j).Equivalent(sig.ArgTypes[j])
+			match = existingTypes[j].Equivalent(sig.ArgTypes[j])
 		}
 		for j := 0; match && j < len(sig.OutParamOrdinals); j++ {
 			match = existing.OutParamOrdinals[j] == sig.OutParamOrdinals[j] &&
@@ -566,6 +567,9 @@ func (desc *immutable) GetResolvedFuncDefinition(
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
 		}
+		if sig.IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		// There is no need to look at the parameter classes since ArgTypes
 		// already contains only parameters that are included into the
 		// signature of the overload.
@@ -577,6 +581,9 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			)
 		}
 		overload.Types = paramTypes
+		if sig.IsVariadic {
+			overload.Types = tree.VariadicParamTypes(paramTypes)
+		}
 		if len(sig.OutParamTypes) > 0 {
 			outParamTypes := make(tree.ParamTypes, len(sig.OutParamTypes))
 			for j := range outParamTypes {
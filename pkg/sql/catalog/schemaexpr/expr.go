@@ -457,6 +457,14 @@ func SanitizeVarFreeExpr(
 				return typedExpr, nil
 			}
 		}
+		if expectedType.Family() == types.CollatedStringFamily &&
+			actualType.Family() == types.StringFamily {
+			// Like in Postgres, a string expression assigned to a collated string
+			// column, e.g. by a computed column, takes the collation of the column.
+			return tree.TypeCheck(ctx, &tree.CollateExpr{
+				Expr: typedExpr, Locale: expectedType.Locale(),
+			}, semaCtx, expectedType)
+		}
 		return nil, fmt.Errorf("expected %s expression to have type %s, but '%s' has type %s",
 			context, expectedType, expr, actualType)
 	}
@@ -0,0 +1,83 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// HashIndexAsExpressionIndex returns the expression index that implements the
+// hash index created by the given CREATE INDEX ... USING hash statement. Like
+// in Postgres, a hash index only stores a hash of the indexed value, so it
+// stays compact for large values but can only be used for equality filters.
+// The index is an expression index on
+//
+//	fnv64(crdb_internal.datums_to_bytes(<value>))
+//
+// and the optimizer constrains its scans with the hashes of the values of
+// equality filters on the indexed value, the same way it constrains the shard
+// column of hash-sharded indexes. The filters themselves are still applied to
+// the rows of the index, so hash collisions do not matter.
+func HashIndexAsExpressionIndex(n *tree.CreateIndex) (*tree.CreateIndex, error) {
+	if n.Unique {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "hash" does not support unique indexes`)
+	}
+	if len(n.Columns) != 1 {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "hash" does not support multicolumn indexes`)
+	}
+	if n.Sharded != nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "hash" does not support hash-sharded indexes`)
+	}
+	elem := n.Columns[0]
+	if elem.Direction != tree.DefaultDirection {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "hash" does not support ASC/DESC options`)
+	}
+	if elem.NullsOrder != tree.DefaultNullsOrder {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "hash" does not support NULLS FIRST/LAST options`)
+	}
+	if elem.OpClass != "" {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			`operator class %q does not exist for access method "hash"`, elem.OpClass)
+	}
+	value := elem.Expr
+	if value == nil {
+		value = &tree.ColumnItem{ColumnName: elem.Column}
+	}
+	unresolvedFunc := func(funcName string) tree.ResolvableFunctionReference {
+		return tree.ResolvableFunctionReference{
+			FunctionReference: &tree.UnresolvedName{
+				NumParts: 1,
+				Parts:    tree.NameParts{funcName},
+			},
+		}
+	}
+	res := *n
+	res.Hash = false
+	res.Columns = tree.IndexElemList{{
+		Expr: &tree.FuncExpr{
+			Func: unresolvedFunc("fnv64"),
+			Exprs: tree.Exprs{
+				&tree.FuncExpr{
+					Func:  unresolvedFunc("crdb_internal.datums_to_bytes"),
+					Exprs: tree.Exprs{value},
+				},
+			},
+		},
+	}}
+	return &res, nil
+}
@@ -15,9 +15,13 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
 )
 
 // ValidateUniqueWithoutIndexPredicate verifies that an expression is a valid
@@ -55,3 +59,70 @@ func ValidateUniqueWithoutIndexPredicate(
 	}
 	return expr, nil
 }
+
+// ValidateExclusionOperators verifies that the operators of an EXCLUDE
+// constraint can be used to compare the values of the corresponding columns.
+// If the operators are valid, it returns their serialized form.
+//
+// An operator is valid if all of the following are true:
+//
+//   - It is defined for the type of its column and results in a boolean.
+//   - It is commutative, so that the result of comparing two rows does not
+//     depend on which one was written first.
+//   - It is supported by the access method of the constraint. The btree
+//     access method only supports equality.
+func ValidateExclusionOperators(
+	method string, colTypes []*types.T, ops []treecmp.ComparisonOperator,
+) ([]string, error) {
+	if len(colTypes) != len(ops) {
+		return nil, errors.AssertionFailedf(
+			"expected %d exclusion operators, found %d", len(colTypes), len(ops),
+		)
+	}
+	res := make([]string, len(ops))
+	for i, op := range ops {
+		typ := colTypes[i]
+		if _, ok := LookupExclusionOperator(op, typ); !ok {
+			return nil, pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s %s %s", typ.SQLStringForError(), op, typ.SQLStringForError(),
+			)
+		}
+		switch op.Symbol {
+		case treecmp.EQ, treecmp.NE, treecmp.Overlaps:
+		default:
+			return nil, errors.WithDetail(
+				pgerror.Newf(pgcode.WrongObjectType, "operator %s is not commutative", op),
+				"Only commutative operators can be used in exclusion constraints.",
+			)
+		}
+		if method == "btree" && op.Symbol != treecmp.EQ {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"operator %s is not supported by access method %q", op, method,
+			)
+		}
+		res[i] = op.Symbol.String()
+	}
+	return res, nil
+}
+
+// LookupExclusionOperator returns the overload of an exclusion constraint
+// operator for operands of the given type.
+func LookupExclusionOperator(op treecmp.ComparisonOperator, typ *types.T) (*tree.CmpOp, bool) {
+	// Some operators, like !=, are implemented in terms of another operator.
+	op, _, _, _, _ = tree.FoldComparisonExpr(op, nil /* left */, nil /* right */)
+	return tree.CmpOps[op.Symbol].LookupImpl(typ, typ)
+}
+
+// ParseExclusionOperators returns the operators of an EXCLUDE constraint from
+// their serialized form.
+func ParseExclusionOperators(ops []string) ([]treecmp.ComparisonOperator, error) {
+	res := make([]treecmp.ComparisonOperator, len(ops))
+	for i, op := range ops {
+		sym, ok := treecmp.ComparisonOperatorSymbolFromString(op)
+		if !ok {
+			return nil, errors.AssertionFailedf("unknown exclusion operator %q", op)
+		}
+		res[i] = treecmp.MakeComparisonOperator(sym)
+	}
+	return res, nil
+}
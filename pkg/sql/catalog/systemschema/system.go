@@ -1156,6 +1156,34 @@ CREATE TABLE system.mvcc_statistics (
 			created
 		)
 	);`
+
+	// NotificationsTableSchema stores the notifications sent with NOTIFY. The
+	// rows are written by the notifying transaction, and are delivered to the
+	// listening sessions on every node through a rangefeed once it commits.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	txn_id     UUID NOT NULL,
+	seq        INT8 NOT NULL,
+	channel    STRING NOT NULL,
+	payload    STRING NOT NULL,
+	pid        INT4 NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (txn_id, seq),
+	FAMILY "primary" (txn_id, seq, channel, payload, pid, created_at)
+);`
+
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// with the CREATE_REPLICATION_SLOT replication command, and the position up
+	// to which their consumers confirmed receiving the changes.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	slot_name           STRING NOT NULL,
+	database_id         INT8 NOT NULL,
+	plugin              STRING NOT NULL,
+	confirmed_flush_lsn INT8 NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, database_id, plugin, confirmed_flush_lsn)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1396,6 +1424,8 @@ func MakeSystemTables() []SystemTable {
 		SystemMVCCStatisticsTable,
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
+		NotificationsTable,
+		ReplicationSlotsTable,
 	}
 }
 
@@ -4700,6 +4730,73 @@ var (
 			tbl.NextConstraintID++
 		},
 	)
+
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "txn_id", ID: 1, Type: types.Uuid},
+				{Name: "seq", ID: 2, Type: types.Int},
+				{Name: "channel", ID: 3, Type: types.String},
+				{Name: "payload", ID: 4, Type: types.String},
+				{Name: "pid", ID: 5, Type: types.Int4},
+				{Name: "created_at", ID: 6, Type: types.TimestampTZ},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"txn_id", "seq", "channel", "payload", "pid", "created_at"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"txn_id", "seq"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+			},
+		),
+	)
+
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "database_id", ID: 2, Type: types.Int},
+				{Name: "plugin", ID: 3, Type: types.String},
+				{Name: "confirmed_flush_lsn", ID: 4, Type: types.Int},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"slot_name", "database_id", "plugin", "confirmed_flush_lsn"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"slot_name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // ColumnDefDescs contains the non-error return values for MakeColumnDefDescs.
@@ -86,6 +87,24 @@ func (cdd *ColumnDefDescs) ForEachTypedExpr(
 	return nil
 }
 
+// MaybeApplyDefaultCollation applies the default collation of a database,
+// given by its locale, to a column definition which has a string type without
+// a collation. Computed columns and the columns of CREATE TABLE ... AS are left
+// alone, since they take the type of their expression.
+func MaybeApplyDefaultCollation(d *tree.ColumnTableDef, locale string) {
+	if locale == "" || d.IsCreateAs || d.IsComputed() {
+		return
+	}
+	typ, ok := tree.GetStaticallyKnownType(d.Type)
+	if !ok || typ.Family() != types.StringFamily {
+		return
+	}
+	switch typ.Oid() {
+	case oid.T_text, oid.T_varchar, oid.T_bpchar:
+		d.Type = types.MakeCollatedString(typ, locale)
+	}
+}
+
 // MakeColumnDefDescs creates the column descriptor for a column, as well as the
 // index descriptor if the column is a primary key or unique.
 //
@@ -161,6 +180,13 @@ func MakeColumnDefDescs(
 	if err != nil {
 		return nil, err
 	}
+	if typ, err := semaCtx.ResolveCollatedType(ctx, resType); err != nil {
+		return nil, err
+	} else if typ != resType {
+		// The column refers to collations created with CREATE COLLATION, which
+		// are replaced with their locales.
+		resType, d.Type = typ, typ
+	}
 	if err = colinfo.ValidateColumnDefType(ctx, evalCtx.Settings.Version, resType); err != nil {
 		return nil, err
 	}
@@ -525,6 +551,21 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policy expressions.
+	for i := range tableDesc.Policies {
+		policy := &tableDesc.Policies[i]
+		if policy.UsingExpr != "" {
+			if err := renameInExpr(&policy.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if policy.WithCheckExpr != "" {
+			if err := renameInExpr(&policy.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
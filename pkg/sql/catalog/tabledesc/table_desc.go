@@ -650,6 +650,31 @@ func (desc *wrapper) IsSchemaLocked() bool {
 	return desc.SchemaLocked
 }
 
+// IsRowLevelSecurityEnabled implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityEnabled() bool {
+	return desc.RowLevelSecurityEnabled
+}
+
+// IsRowLevelSecurityForced implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityForced() bool {
+	return desc.RowLevelSecurityForced
+}
+
+// GetPolicies implements the TableDescriptor interface.
+func (desc *wrapper) GetPolicies() []descpb.TableDescriptor_Policy {
+	return desc.Policies
+}
+
+// GetTriggers implements the TableDescriptor interface.
+func (desc *wrapper) GetTriggers() []descpb.TableDescriptor_Trigger {
+	return desc.Triggers
+}
+
+// GetBlockRangeIndexes implements the TableDescriptor interface.
+func (desc *wrapper) GetBlockRangeIndexes() []descpb.TableDescriptor_BlockRangeIndex {
+	return desc.BlockRangeIndexes
+}
+
 // IsPrimaryKeySwapMutation implements the TableDescriptor interface.
 func (desc *wrapper) IsPrimaryKeySwapMutation(m *descpb.DescriptorMutation) bool {
 	switch t := m.Descriptor_.(type) {
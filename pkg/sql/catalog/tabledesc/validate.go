@@ -199,6 +199,11 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all trigger functions exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundFuncRef(desc.Triggers[i].FuncID, vdg))
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -263,6 +268,17 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in trigger functions.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		fn, err := vdg.GetFunctionDescriptor(trigger.FuncID)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, trigger.ID))
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -376,6 +392,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -697,6 +730,25 @@ func validateMutation(m *descpb.DescriptorMutation) error {
 	return nil
 }
 
+// validateForeignTable validates the properties that are specific to foreign
+// tables. Their rows are synthesized by the file readers, which only produce
+// the columns of the primary index, so no other index may exist.
+func (desc *wrapper) validateForeignTable(vea catalog.ValidationErrorAccumulator) {
+	if !desc.IsTable() {
+		vea.Report(errors.AssertionFailedf("foreign table must not be a view or sequence"))
+	}
+	if desc.GetForeignTable().Server == "" {
+		vea.Report(errors.AssertionFailedf("foreign table has no server"))
+	}
+	if len(desc.GetIndexes()) > 0 || len(desc.GetMutations()) > 0 {
+		vea.Report(errors.AssertionFailedf(
+			"foreign table must not have secondary indexes or mutations"))
+	}
+	if len(desc.OutboundFKs) > 0 || len(desc.InboundFKs) > 0 {
+		vea.Report(errors.AssertionFailedf("foreign table must not have foreign keys"))
+	}
+}
+
 // ValidateSelf validates that the table descriptor is well formed. Checks
 // include validating the table, column and index names, verifying that column
 // names and index names are unique and verifying that column IDs and index IDs
@@ -760,6 +812,15 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		}
 	}
 
+	if desc.RefreshMaxStaleness < 0 {
+		vea.Report(errors.AssertionFailedf(
+			"negative refresh max staleness %s", desc.RefreshMaxStaleness))
+	}
+	if desc.RefreshMaxStaleness != 0 && !desc.MaterializedView() {
+		vea.Report(errors.AssertionFailedf(
+			"has a refresh max staleness despite not being a materialized view"))
+	}
+
 	desc.validateAutoStatsSettings(vea)
 
 	if desc.IsSequence() {
@@ -808,6 +869,10 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		return
 	}
 
+	if desc.IsForeignTable() {
+		desc.validateForeignTable(vea)
+	}
+
 	// We maintain forward compatibility, so if you see this error message with a
 	// version older that what this client supports, then there's a
 	// maybeFillInDescriptor missing from some codepath.
@@ -869,7 +934,10 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
+			desc.validatePolicies(columnsByID),
+			desc.validateTriggers(columnsByID),
 			desc.validateTableIndexes(columnsByID, vea.IsActive),
+			desc.validateBlockRangeIndexes(columnsByID),
 			desc.validatePartitioning(),
 		}
 		hasErrs := false
@@ -1325,6 +1393,168 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validatePolicies validates that the row-level security policies are well
+// formed. Checks include validating the names, IDs, column IDs and
+// expressions of the policies.
+func (desc *wrapper) validatePolicies(columnsByID map[descpb.ColumnID]catalog.Column) error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	ids := make(map[descpb.PolicyID]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if p.Name == "" {
+			return errors.AssertionFailedf("policy %d has an empty name", p.ID)
+		}
+		if _, ok := names[p.Name]; ok {
+			return errors.AssertionFailedf("duplicate policy name: %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if p.ID == 0 || p.ID >= desc.NextPolicyID {
+			return errors.AssertionFailedf("policy %q has invalid ID %d", p.Name, p.ID)
+		}
+		if _, ok := ids[p.ID]; ok {
+			return errors.AssertionFailedf("duplicate policy ID: %d", p.ID)
+		}
+		ids[p.ID] = struct{}{}
+		if _, ok := descpb.TableDescriptor_Policy_Command_name[int32(p.Command)]; !ok {
+			return errors.AssertionFailedf("policy %q has invalid command %d", p.Name, p.Command)
+		}
+		for _, colID := range p.ColumnIDs {
+			if _, ok := columnsByID[colID]; !ok {
+				return errors.Newf("policy %q contains unknown column \"%d\"", p.Name, colID)
+			}
+		}
+		for _, e := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if e == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(e)
+			if err != nil {
+				return err
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf("policy %q refers to unknown columns in expression: %s", p.Name, e)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTriggers validates that the triggers are well formed. Checks include
+// validating the names, IDs, events, column IDs and WHEN conditions of the
+// triggers.
+func (desc *wrapper) validateTriggers(columnsByID map[descpb.ColumnID]catalog.Column) error {
+	names := make(map[string]struct{}, len(desc.Triggers))
+	ids := make(map[descpb.TriggerID]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		if t.Name == "" {
+			return errors.AssertionFailedf("trigger %d has an empty name", t.ID)
+		}
+		if _, ok := names[t.Name]; ok {
+			return errors.AssertionFailedf("duplicate trigger name: %q", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if t.ID == 0 || t.ID >= desc.NextTriggerID {
+			return errors.AssertionFailedf("trigger %q has invalid ID %d", t.Name, t.ID)
+		}
+		if _, ok := ids[t.ID]; ok {
+			return errors.AssertionFailedf("duplicate trigger ID: %d", t.ID)
+		}
+		ids[t.ID] = struct{}{}
+		if t.FuncID == descpb.InvalidID {
+			return errors.AssertionFailedf("trigger %q has no function", t.Name)
+		}
+		if len(t.Events) == 0 {
+			return errors.AssertionFailedf("trigger %q has no events", t.Name)
+		}
+		for _, ev := range t.Events {
+			if _, ok := descpb.TableDescriptor_Trigger_Event_Type_name[int32(ev.Type)]; !ok {
+				return errors.AssertionFailedf("trigger %q has invalid event %d", t.Name, ev.Type)
+			}
+			if len(ev.ColumnIDs) > 0 && ev.Type != descpb.TableDescriptor_Trigger_Event_UPDATE {
+				return errors.AssertionFailedf("trigger %q has columns for a %s event", t.Name, ev.Type)
+			}
+			for _, colID := range ev.ColumnIDs {
+				if !catalog.MakeTableColSet(t.ColumnIDs...).Contains(colID) {
+					return errors.AssertionFailedf(
+						"trigger %q is missing event column %d in its column IDs", t.Name, colID)
+				}
+			}
+		}
+		for _, colID := range t.ColumnIDs {
+			if _, ok := columnsByID[colID]; !ok {
+				return errors.Newf("trigger %q contains unknown column \"%d\"", t.Name, colID)
+			}
+		}
+		if t.WhenExpr != "" {
+			if _, err := parser.ParseExpr(t.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateBlockRangeIndexes validates that the block range indexes are well
+// formed. Their names and IDs are shared with the other indexes of the table,
+// and their blocks are ranges of values of the leading primary key column,
+// which must therefore be an ascending integer column.
+func (desc *wrapper) validateBlockRangeIndexes(
+	columnsByID map[descpb.ColumnID]catalog.Column,
+) error {
+	if len(desc.BlockRangeIndexes) == 0 {
+		return nil
+	}
+	if desc.IsForeignTable() || !desc.IsTable() {
+		return errors.AssertionFailedf("only tables can have block range indexes")
+	}
+	pk := desc.GetPrimaryIndex()
+	if pk.NumKeyColumns() == 0 {
+		return ErrMissingPrimaryKey
+	}
+	if col, ok := columnsByID[pk.GetKeyColumnID(0)]; !ok ||
+		col.GetType().Family() != types.IntFamily ||
+		pk.GetKeyColumnDirection(0) != catenumpb.IndexColumn_ASC {
+		return errors.AssertionFailedf(
+			"block range indexes require an ascending integer leading primary key column")
+	}
+	names := make(map[string]struct{}, len(desc.BlockRangeIndexes))
+	ids := make(map[descpb.IndexID]struct{}, len(desc.BlockRangeIndexes))
+	for _, idx := range desc.NonDropIndexes() {
+		names[idx.GetName()] = struct{}{}
+		ids[idx.GetID()] = struct{}{}
+	}
+	for i := range desc.BlockRangeIndexes {
+		idx := &desc.BlockRangeIndexes[i]
+		if idx.Name == "" {
+			return pgerror.Newf(pgcode.Syntax, "empty index name")
+		}
+		if _, ok := names[idx.Name]; ok {
+			return errors.AssertionFailedf("duplicate index name: %q", idx.Name)
+		}
+		names[idx.Name] = struct{}{}
+		if idx.ID == 0 || idx.ID >= desc.NextIndexID {
+			return errors.AssertionFailedf("block range index %q has invalid ID %d", idx.Name, idx.ID)
+		}
+		if _, ok := ids[idx.ID]; ok {
+			return errors.AssertionFailedf("block range index %q has duplicate ID %d", idx.Name, idx.ID)
+		}
+		ids[idx.ID] = struct{}{}
+		if _, ok := columnsByID[idx.ColumnID]; !ok {
+			return errors.Newf("block range index %q contains unknown column \"%d\"", idx.Name, idx.ColumnID)
+		}
+		if idx.RangeSize <= 0 {
+			return errors.AssertionFailedf(
+				"block range index %q has invalid range size %d", idx.Name, idx.RangeSize)
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -1362,6 +1592,20 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if ops := c.UniqueWithoutIndexDesc().ExclusionOperators; len(ops) > 0 && len(ops) != c.NumKeyColumns() {
+			return errors.Newf(
+				"exclusion constraint %q has %d operators for %d columns", c.GetName(), len(ops), c.NumKeyColumns(),
+			)
+		}
+		if uc := c.UniqueWithoutIndexDesc(); uc.IsExclusion() && uc.Deferrable {
+			return errors.Newf("exclusion constraint %q cannot be deferrable", c.GetName())
+		}
+		if uc := c.UniqueWithoutIndexDesc(); uc.InitiallyDeferred && !uc.Deferrable {
+			return errors.Newf(
+				"unique without index constraint %q is initially deferred but not deferrable", c.GetName(),
+			)
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
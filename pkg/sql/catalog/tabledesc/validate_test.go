@@ -140,6 +140,17 @@ var validationMap = []struct {
 			"SchemaLocked":                  {status: thisFieldReferencesNoObjects},
 			"ImportEpoch":                   {status: thisFieldReferencesNoObjects},
 			"ImportType":                    {status: thisFieldReferencesNoObjects},
+			"Policies":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextPolicyID":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"Triggers":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextTriggerID":                 {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurityEnabled":       {status: thisFieldReferencesNoObjects},
+			"RowLevelSecurityForced":        {status: thisFieldReferencesNoObjects},
+			"ForeignTable":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"BlockRangeIndexes":             {status: iSolemnlySwearThisFieldIsValidated},
+			"LastRefreshTime":               {status: thisFieldReferencesNoObjects},
+			"RefreshMaxStaleness":           {status: iSolemnlySwearThisFieldIsValidated},
+			"RefreshJobID":                  {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -225,17 +236,23 @@ var validationMap = []struct {
 			"OnUpdate":            {status: thisFieldReferencesNoObjects},
 			"Match":               {status: thisFieldReferencesNoObjects},
 			"ConstraintID":        {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrable":          {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred":   {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":         {status: thisFieldReferencesNoObjects},
-			"Validity":     {status: thisFieldReferencesNoObjects},
-			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
-			"ConstraintID": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":            {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":          {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":               {status: thisFieldReferencesNoObjects},
+			"Validity":           {status: thisFieldReferencesNoObjects},
+			"Predicate":          {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID":       {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrable":         {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred":  {status: thisFieldReferencesNoObjects},
+			"ExclusionOperators": {status: iSolemnlySwearThisFieldIsValidated},
+			"ExclusionMethod":    {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -259,6 +276,7 @@ var validationMap = []struct {
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Composite":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"Domain":                        {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -328,6 +346,7 @@ var validationMap = []struct {
 			"Version":                       {status: thisFieldReferencesNoObjects},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"IsProcedure":                   {status: thisFieldReferencesNoObjects},
+			"Aggregate":                     {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 }
@@ -1730,6 +1749,66 @@ func TestValidateTableDesc(t *testing.T) {
 					},
 				},
 			}},
+		{err: `policy "p" contains unknown column "2"`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextConstraintID: 1,
+				NextPolicyID:     2,
+				Policies: []descpb.TableDescriptor_Policy{
+					{
+						ID:        1,
+						Name:      "p",
+						UsingExpr: "bar > 0",
+						ColumnIDs: []descpb.ColumnID{1, 2},
+					},
+				},
+			}},
+		{err: `trigger "t" contains unknown column "2"`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextConstraintID: 1,
+				NextTriggerID:    2,
+				Triggers: []descpb.TableDescriptor_Trigger{
+					{
+						ID:     1,
+						Name:   "t",
+						FuncID: 100,
+						Events: []descpb.TableDescriptor_Trigger_Event{
+							{Type: descpb.TableDescriptor_Trigger_Event_UPDATE, ColumnIDs: []descpb.ColumnID{2}},
+						},
+						ForEachRow: true,
+						ColumnIDs:  []descpb.ColumnID{2},
+					},
+				},
+			}},
 		{err: `unique without index constraint "bar_unique" contains duplicate column "1"`,
 			desc: descpb.TableDescriptor{
 				ID:            2,
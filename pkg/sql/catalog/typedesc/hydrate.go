@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
@@ -103,22 +104,22 @@ func ensureTypeIsHydratedRecursive(
 			maybeName = &name
 		}
 	}
-	ensureTypeMetadataIsHydrated(&t.TypeMeta, maybeName, maybeDesc)
-	return nil
+	return ensureTypeMetadataIsHydrated(ctx, t, maybeName, maybeDesc)
 }
 
 func ensureTypeMetadataIsHydrated(
-	tm *types.UserDefinedTypeMetadata, maybeName *tree.TypeName, maybeDesc catalog.TypeDescriptor,
-) {
+	ctx context.Context, t *types.T, maybeName *tree.TypeName, maybeDesc catalog.TypeDescriptor,
+) error {
+	tm := &t.TypeMeta
 	var version uint32
 	if maybeDesc != nil {
 		version = uint32(maybeDesc.GetVersion())
 	} else if maybeName == nil {
 		// Return early because there's nothing to hydrate with.
-		return
+		return nil
 	}
 	if *tm != (types.UserDefinedTypeMetadata{}) && tm.Version == version {
-		return
+		return nil
 	}
 	tm.Version = version
 	if maybeName != nil {
@@ -130,11 +131,33 @@ func ensureTypeMetadataIsHydrated(
 		}
 	}
 	if maybeDesc == nil {
-		return
+		return nil
 	}
 	if maybeDesc.AsTableImplicitRecordTypeDescriptor() != nil {
 		tm.ImplicitRecordType = true
-		return
+		return nil
+	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		domainData := &types.DomainMetadata{
+			NotNull:          d.IsNotNull(),
+			CheckConstraints: make([]types.DomainCheckConstraint, d.NumCheckConstraints()),
+		}
+		if def := d.GetDefaultExpr(); def != "" {
+			domainData.DefaultExpr = &def
+		}
+		for i := range domainData.CheckConstraints {
+			c := &domainData.CheckConstraints[i]
+			c.Name, c.Expr = d.GetCheckConstraint(i)
+			typedExpr, err := typeCheckDomainCheckConstraint(ctx, c.Expr, t.DomainBaseType())
+			if err != nil {
+				*tm = types.UserDefinedTypeMetadata{}
+				return errors.NewAssertionErrorWithWrappedErrf(err,
+					"failed to type check CHECK constraint %q of domain %s", c.Name, d.GetName())
+			}
+			c.TypedExpr = typedExpr
+		}
+		tm.DomainData = domainData
+		return nil
 	}
 	if e := maybeDesc.AsEnumTypeDescriptor(); e != nil {
 		if imm, ok := e.(*immutable); ok {
@@ -159,4 +182,23 @@ func ensureTypeMetadataIsHydrated(
 			}
 		}
 	}
+	return nil
+}
+
+// typeCheckDomainCheckConstraint parses and type checks the serialized CHECK
+// constraint of a domain with the given base type. VALUE is replaced by the
+// ordinal reference @1, which refers to the value being checked.
+func typeCheckDomainCheckConstraint(
+	ctx context.Context, expr string, baseType *types.T,
+) (tree.TypedExpr, error) {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err = tree.ReplaceDomainValue(parsed, tree.NewTypedOrdinalReference(0, baseType))
+	if err != nil {
+		return nil, err
+	}
+	semaCtx := tree.MakeSemaContext()
+	return tree.TypeCheck(ctx, parsed, &semaCtx, types.Bool)
 }
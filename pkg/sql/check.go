@@ -277,7 +277,7 @@ func nonMatchingRowQuery(
 func validateForeignKey(
 	ctx context.Context,
 	txn isql.Txn,
-	srcTable *tabledesc.Mutable,
+	srcTable catalog.TableDescriptor,
 	targetTable catalog.TableDescriptor,
 	fk *descpb.ForeignKeyConstraint,
 	indexIDForValidation descpb.IndexID,
@@ -302,7 +302,7 @@ func validateForeignKey(
 
 		log.Infof(ctx, "validating MATCH FULL FK %q (%q [%v] -> %q [%v]) with query %q",
 			fk.Name,
-			srcTable.Name, colNames,
+			srcTable.GetName(), colNames,
 			targetTable.GetName(), referencedColumnNames,
 			query,
 		)
@@ -327,7 +327,7 @@ func validateForeignKey(
 
 	log.Infof(ctx, "validating FK %q (%q [%v] -> %q [%v]) with query %q",
 		fk.Name,
-		srcTable.Name, colNames, targetTable.GetName(), referencedColumnNames,
+		srcTable.GetName(), colNames, targetTable.GetName(), referencedColumnNames,
 		query,
 	)
 
@@ -339,7 +339,7 @@ func validateForeignKey(
 	if values.Len() > 0 {
 		return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
 			"foreign key violation: %q row %s has no match in %q",
-			srcTable.Name, formatValues(colNames, values), targetTable.GetName()), fk.Name)
+			srcTable.GetName(), formatValues(colNames, values), targetTable.GetName()), fk.Name)
 	}
 	return nil
 }
@@ -503,12 +503,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				p.InternalSQLTxn(),
 				p.User(),
@@ -583,12 +581,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -642,6 +638,230 @@ func validateUniqueConstraint(
 		query,
 	)
 
+	values, err := queryValidationRow(ctx, txn, user, "validate unique constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		return makeUniqueValidationError(constraintName, colNames, values, preExisting)
+	}
+	return nil
+}
+
+// makeUniqueValidationError returns the error for a failed validation of the
+// given unique constraint, where values is a key that is duplicated.
+func makeUniqueValidationError(
+	constraintName string, colNames []string, values tree.Datums, preExisting bool,
+) error {
+	valuesStr := make([]string, len(values))
+	for i := range values {
+		valuesStr[i] = values[i].String()
+	}
+	// Note: this error message mirrors the message produced by Postgres
+	// when it fails to add a unique index due to duplicated keys.
+	errMsg := "could not create unique constraint"
+	if preExisting {
+		errMsg = "failed to validate unique constraint"
+	}
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(
+				pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+			),
+			constraintName,
+		),
+		fmt.Sprintf(
+			"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+		),
+	)
+}
+
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given UNIQUE WITHOUT INDEX or EXCLUDE constraint. See
+// validateUniqueConstraint for a description of the arguments.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uc.UniqueWithoutIndexDesc().IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uc.UniqueWithoutIndexDesc(), indexIDForValidation, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uc.GetName(),
+		uc.CollectKeyColumnIDs().Ordered(),
+		uc.GetPredicate(),
+		indexIDForValidation,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// conflictingRowQuery generates and returns a query for a pair of rows that
+// violate the specified exclusion constraint. The query returns the values of
+// the constrained columns of both rows.
+//
+// For example, the constraint EXCLUDE (a WITH =, b WITH &&) on the table "tbl"
+// with primary key k would require the following query:
+//
+// SELECT l.a, l.b, r.a, r.b
+// FROM (SELECT a, b, k FROM tbl) AS l, (SELECT a, b, k FROM tbl) AS r
+// WHERE l.a = r.a AND l.b && r.b AND (l.k) != (r.k)
+// LIMIT 1
+//
+// The predicate of a partial exclusion constraint filters both sides of the
+// join. See duplicateRowQuery for a description of indexIDForValidation.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, uc.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(uc.ExclusionOperators) != len(colNames) {
+		return "", nil, errors.AssertionFailedf(
+			"exclusion constraint %q has %d operators for %d columns",
+			uc.Name, len(uc.ExclusionOperators), len(colNames),
+		)
+	}
+
+	// Rows are told apart using the key columns of the index that is read.
+	var keyIndex catalog.Index = srcTbl.GetPrimaryIndex()
+	indexHint := ""
+	if indexIDForValidation != 0 {
+		keyIndex, err = catalog.MustFindIndexByID(srcTbl, indexIDForValidation)
+		if err != nil {
+			return "", nil, err
+		}
+		indexHint = fmt.Sprintf("@[%d]", indexIDForValidation)
+	}
+	keyColNames, err := catalog.ColumnNamesForIDs(srcTbl, keyIndex.IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var cols []string
+	seen := make(map[string]struct{}, len(colNames)+len(keyColNames))
+	for _, names := range [][]string{colNames, keyColNames} {
+		for _, n := range names {
+			if _, ok := seen[n]; !ok {
+				seen[n] = struct{}{}
+				cols = append(cols, tree.NameString(n))
+			}
+		}
+	}
+	where := ""
+	if uc.Predicate != "" {
+		where = fmt.Sprintf(" WHERE (%s)", uc.Predicate)
+	}
+	side := fmt.Sprintf(
+		"(SELECT %s FROM [%d AS tbl]%s%s)", strings.Join(cols, ", "), srcTbl.GetID(), indexHint, where,
+	)
+
+	outCols := make([]string, 0, 2*len(colNames))
+	for _, prefix := range []string{"l", "r"} {
+		for _, n := range colNames {
+			outCols = append(outCols, fmt.Sprintf("%s.%s", prefix, tree.NameString(n)))
+		}
+	}
+	conds := make([]string, 0, len(colNames)+1)
+	for i, n := range colNames {
+		conds = append(conds, fmt.Sprintf(
+			"l.%[1]s %[2]s r.%[1]s", tree.NameString(n), uc.ExclusionOperators[i],
+		))
+	}
+	lKey := make([]string, len(keyColNames))
+	rKey := make([]string, len(keyColNames))
+	for i, n := range keyColNames {
+		lKey[i] = "l." + tree.NameString(n)
+		rKey[i] = "r." + tree.NameString(n)
+	}
+	conds = append(conds, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(lKey, ", "), strings.Join(rKey, ", "),
+	))
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM %[2]s AS l, %[2]s AS r WHERE %[3]s LIMIT 1`,
+		strings.Join(outCols, ", "),  // 1
+		side,                         // 2
+		strings.Join(conds, " AND "), // 3
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint. See
+// validateUniqueConstraint for a description of the arguments.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, uc, indexIDForValidation)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := queryValidationRow(ctx, txn, user, "validate exclusion constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
+				),
+				uc.Name,
+			),
+			fmt.Sprintf(
+				"Key (%[1]s)=(%[2]s) conflicts with key (%[1]s)=(%[3]s).",
+				strings.Join(colNames, ", "),
+				strings.Join(valuesStr[:n], ", "),
+				strings.Join(valuesStr[n:], ", "),
+			),
+		)
+	}
+	return nil
+}
+
+// queryValidationRow runs a query that validates a constraint and returns the
+// first row it produces, if any.
+func queryValidationRow(
+	ctx context.Context, txn isql.Txn, user username.SQLUsername, opName string, query string,
+) (tree.Datums, error) {
 	sessionDataOverride := sessiondata.NoSessionDataOverride
 	sessionDataOverride.User = user
 	// We are likely to have performed a lot of work before getting here (e.g.
@@ -655,13 +875,14 @@ func validateUniqueConstraint(
 	// retries in order to not waste (a lot of) work that was performed before
 	// we got here.
 	var values tree.Datums
+	var err error
 	retryOptions := retry.Options{
 		InitialBackoff: 20 * time.Millisecond,
 		Multiplier:     1.5,
 		MaxRetries:     5,
 	}
 	for r := retry.StartWithCtx(ctx, retryOptions); r.Next(); {
-		values, err = txn.QueryRowEx(ctx, "validate unique constraint", txn.KV(), sessionDataOverride, query)
+		values, err = txn.QueryRowEx(ctx, opName, txn.KV(), sessionDataOverride, query)
 		if err == nil {
 			break
 		}
@@ -672,32 +893,9 @@ func validateUniqueConstraint(
 			log.Infof(ctx, "retrying the validation query because of %v", err)
 			continue
 		}
-		return err
+		return nil, err
 	}
-	if values.Len() > 0 {
-		valuesStr := make([]string, len(values))
-		for i := range values {
-			valuesStr[i] = values[i].String()
-		}
-		// Note: this error message mirrors the message produced by Postgres
-		// when it fails to add a unique index due to duplicated keys.
-		errMsg := "could not create unique constraint"
-		if preExisting {
-			errMsg = "failed to validate unique constraint"
-		}
-		return errors.WithDetail(
-			pgerror.WithConstraintName(
-				pgerror.Newf(
-					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
-				),
-				constraintName,
-			),
-			fmt.Sprintf(
-				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
-			),
-		)
-	}
-	return nil
+	return values, err
 }
 
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
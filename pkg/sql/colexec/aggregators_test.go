@@ -47,6 +47,9 @@ type aggregatorTestCase struct {
 	expected  colexectestutils.Tuples
 
 	constArguments [][]execinfrapb.Expression
+	// userDefined, if set, contains the specification of each aggregate
+	// function that is execinfrapb.UserDefined.
+	userDefined []*execinfrapb.AggregatorSpec_UserDefinedAggregation
 	// spec will be populated during init().
 	spec           *execinfrapb.AggregatorSpec
 	aggDistinct    []bool
@@ -156,6 +159,9 @@ func (tc *aggregatorTestCase) init() error {
 		if tc.constArguments != nil {
 			aggregations[i].Arguments = tc.constArguments[i]
 		}
+		if tc.userDefined != nil {
+			aggregations[i].UserDefined = tc.userDefined[i]
+		}
 		if tc.aggDistinct != nil {
 			aggregations[i].Distinct = tc.aggDistinct[i]
 		}
@@ -201,6 +201,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 	case core.Ordinality != nil:
 		return nil
 
+	case core.Expand != nil:
+		return nil
+
 	case core.HashJoiner != nil:
 		if !core.HashJoiner.OnExpr.Empty() && core.HashJoiner.Type != descpb.InnerJoin {
 			return errNonInnerHashJoinWithOnExpr
@@ -305,6 +308,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 		// (#55408), so we fallback to the row-by-row engine.
 		return errChangeFrontierWrap
 	case core.Ordinality != nil:
+	case core.Expand != nil:
 	case core.BulkRowWriter != nil:
 	case core.InvertedFilterer != nil:
 	case core.InvertedJoiner != nil:
@@ -317,6 +321,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	case core.StreamIngestionFrontier != nil:
 		return errStreamIngestionWrap
 	case core.HashGroupJoiner != nil:
+	case core.ForeignTableReader != nil:
 	default:
 		return errors.AssertionFailedf("unexpected processor core %q", core)
 	}
@@ -1155,6 +1160,14 @@ func NewColOperator(
 			result.ColumnTypes = spec.Input[0].ColumnTypes
 			result.ColumnTypes = append(result.ColumnTypes, types.Int)
 
+		case core.Expand != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+			result.Root, result.ColumnTypes = colexecbase.NewExpandOp(
+				getStreamingAllocator(ctx, args), inputs[0].Root, spec.Input[0].ColumnTypes, core.Expand,
+			)
+
 		case core.HashJoiner != nil:
 			if err := checkNumIn(inputs, 2); err != nil {
 				return r, err
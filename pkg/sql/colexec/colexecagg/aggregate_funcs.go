@@ -486,6 +486,9 @@ func NewAggregateFuncsAlloc(
 		// function, make sure to account for the memory under that struct in
 		// its constructor.
 		default:
+			// The remaining aggregate functions, including user-defined
+			// aggregates whose support functions are arbitrary expressions, are
+			// evaluated on datums by wrapping the row-execution implementation.
 			freshAllocator = true
 			switch aggKind {
 			case HashAggKind:
@@ -73,12 +73,15 @@ func (a *defaultOrderedAgg) Compute(
 					}
 					a.isFirstGroup = false
 				}
-				// Note that the only function that takes no arguments is COUNT_ROWS, and
-				// it has an optimized implementation, so we don't need to check whether
-				// len(inputIdxs) is at least 1.
-				firstArg := a.inputArgsConverter.GetDatumColumn(int(inputIdxs[0]))[tupleIdx]
-				for j, colIdx := range inputIdxs[1:] {
-					a.scratch.otherArgs[j] = a.inputArgsConverter.GetDatumColumn(int(colIdx))[tupleIdx]
+				// COUNT_ROWS is the only builtin aggregate that takes no arguments, and it
+				// has an optimized implementation, but user-defined aggregates may take no
+				// arguments too. The first argument is NULL in that case.
+				firstArg := tree.Datum(tree.DNull)
+				if len(inputIdxs) > 0 {
+					firstArg = a.inputArgsConverter.GetDatumColumn(int(inputIdxs[0]))[tupleIdx]
+					for j, colIdx := range inputIdxs[1:] {
+						a.scratch.otherArgs[j] = a.inputArgsConverter.GetDatumColumn(int(colIdx))[tupleIdx]
+					}
 				}
 				if err := a.fn.Add(a.ctx, firstArg, a.scratch.otherArgs...); err != nil {
 					colexecerror.ExpectedError(err)
@@ -105,12 +108,15 @@ func (a *defaultOrderedAgg) Compute(
 					}
 					a.isFirstGroup = false
 				}
-				// Note that the only function that takes no arguments is COUNT_ROWS, and
-				// it has an optimized implementation, so we don't need to check whether
-				// len(inputIdxs) is at least 1.
-				firstArg := a.inputArgsConverter.GetDatumColumn(int(inputIdxs[0]))[tupleIdx]
-				for j, colIdx := range inputIdxs[1:] {
-					a.scratch.otherArgs[j] = a.inputArgsConverter.GetDatumColumn(int(colIdx))[tupleIdx]
+				// COUNT_ROWS is the only builtin aggregate that takes no arguments, and it
+				// has an optimized implementation, but user-defined aggregates may take no
+				// arguments too. The first argument is NULL in that case.
+				firstArg := tree.Datum(tree.DNull)
+				if len(inputIdxs) > 0 {
+					firstArg = a.inputArgsConverter.GetDatumColumn(int(inputIdxs[0]))[tupleIdx]
+					for j, colIdx := range inputIdxs[1:] {
+						a.scratch.otherArgs[j] = a.inputArgsConverter.GetDatumColumn(int(colIdx))[tupleIdx]
+					}
 				}
 				if err := a.fn.Add(a.ctx, firstArg, a.scratch.otherArgs...); err != nil {
 					colexecerror.ExpectedError(err)
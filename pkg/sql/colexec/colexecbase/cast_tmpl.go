@@ -88,6 +88,13 @@ func _CAST(to, from, evalCtx, toType, buf interface{}) {
 
 // */}}
 
+// isDomainCast returns whether the cast is to a domain type from a different
+// type. Such casts need to check the constraints of the domain, which is only
+// supported by the row engine.
+func isDomainCast(fromType, toType *types.T) bool {
+	return toType.IsDomain() && !fromType.Identical(toType)
+}
+
 func isIdentityCast(fromType, toType *types.T) bool {
 	if fromType.Identical(toType) {
 		return true
@@ -115,6 +122,13 @@ func GetCastOperator(
 	toType *types.T,
 	evalCtx *eval.Context,
 ) (colexecop.Operator, error) {
+	if isDomainCast(fromType, toType) {
+		return nil, errors.Errorf(
+			"unhandled cast %s -> %s",
+			fromType.SQLStringForError(),
+			toType.SQLStringForError(),
+		)
+	}
 	input = colexecutils.NewVectorTypeEnforcer(allocator, input, toType, resultIdx)
 	base := castOpBase{
 		OneInputInitCloserHelper: colexecop.MakeOneInputInitCloserHelper(input),
@@ -190,6 +204,9 @@ func GetCastOperator(
 }
 
 func IsCastSupported(fromType, toType *types.T) bool {
+	if isDomainCast(fromType, toType) {
+		return false
+	}
 	if fromType.Family() == types.UnknownFamily {
 		return true
 	}
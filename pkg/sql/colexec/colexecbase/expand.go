@@ -0,0 +1,142 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecbase
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// expandOp is an operator that implements the Expand operator of GROUPING
+// SETS, ROLLUP and CUBE. It produces a copy of each input tuple for each
+// grouping set, consisting of the input columns, followed by a column for each
+// of the grouping columns, which is NULL if the grouping set doesn't group on
+// it, followed by the index of the grouping set.
+//
+// All the copies of an input tuple are produced consecutively, so the operator
+// preserves the ordering of its input.
+type expandOp struct {
+	colexecop.OneInputHelper
+
+	allocator    *colmem.Allocator
+	outputTypes  []*types.T
+	numInputCols int
+	groupingCols []uint32
+	numSets      int
+	// inSet[i][j] is true if the grouping set i groups on groupingCols[j].
+	inSet [][]bool
+
+	// batch is the input batch being expanded, and nextIdx is the index of the
+	// next output tuple among the batch.Length()*numSets tuples expanded from
+	// it.
+	batch   coldata.Batch
+	nextIdx int
+
+	output coldata.Batch
+	// sel is the selection vector of the input tuple of each output tuple.
+	sel []int
+}
+
+var _ colexecop.Operator = &expandOp{}
+
+// NewExpandOp returns a new Expand operator.
+func NewExpandOp(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	spec *execinfrapb.ExpandSpec,
+) (colexecop.Operator, []*types.T) {
+	outputTypes := make([]*types.T, 0, len(inputTypes)+len(spec.GroupingCols)+1)
+	outputTypes = append(outputTypes, inputTypes...)
+	for _, col := range spec.GroupingCols {
+		outputTypes = append(outputTypes, inputTypes[col])
+	}
+	outputTypes = append(outputTypes, types.Int)
+	inSet := make([][]bool, len(spec.GroupingSets))
+	for i, set := range spec.GroupingSets {
+		inSet[i] = make([]bool, len(spec.GroupingCols))
+		for _, idx := range set.Cols {
+			inSet[i][idx] = true
+		}
+	}
+	return &expandOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		outputTypes:    outputTypes,
+		numInputCols:   len(inputTypes),
+		groupingCols:   spec.GroupingCols,
+		numSets:        len(spec.GroupingSets),
+		inSet:          inSet,
+	}, outputTypes
+}
+
+func (e *expandOp) Next() coldata.Batch {
+	if e.batch == nil || e.nextIdx == e.batch.Length()*e.numSets {
+		e.batch = e.Input.Next()
+		e.nextIdx = 0
+		if e.batch.Length() == 0 {
+			return coldata.ZeroBatch
+		}
+	}
+
+	remaining := e.batch.Length()*e.numSets - e.nextIdx
+	e.output, _ = e.allocator.ResetMaybeReallocateNoMemLimit(e.outputTypes, e.output, remaining)
+	n := e.output.Capacity()
+	if n > remaining {
+		n = remaining
+	}
+	if cap(e.sel) < n {
+		e.sel = make([]int, n)
+	}
+	e.sel = e.sel[:n]
+	inputSel := e.batch.Selection()
+	for i := range e.sel {
+		idx := (e.nextIdx + i) / e.numSets
+		if inputSel != nil {
+			idx = inputSel[idx]
+		}
+		e.sel[i] = idx
+	}
+
+	e.allocator.PerformOperation(e.output.ColVecs(), func() {
+		for i := 0; i < e.numInputCols; i++ {
+			e.output.ColVec(i).Copy(coldata.SliceArgs{
+				Src:       e.batch.ColVec(i),
+				Sel:       e.sel,
+				SrcEndIdx: n,
+			})
+		}
+		for j, col := range e.groupingCols {
+			outVec := e.output.ColVec(e.numInputCols + j)
+			outVec.Copy(coldata.SliceArgs{
+				Src:       e.batch.ColVec(int(col)),
+				Sel:       e.sel,
+				SrcEndIdx: n,
+			})
+			nulls := outVec.Nulls()
+			for i := 0; i < n; i++ {
+				if !e.inSet[(e.nextIdx+i)%e.numSets][j] {
+					nulls.SetNull(i)
+				}
+			}
+		}
+		setIDs := e.output.ColVec(len(e.outputTypes) - 1).Int64()
+		for i := 0; i < n; i++ {
+			setIDs[i] = int64((e.nextIdx + i) % e.numSets)
+		}
+	})
+	e.nextIdx += n
+	e.output.SetLength(n)
+	return e.output
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecbase_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestExpand(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tcs := []struct {
+		tuples     colexectestutils.Tuples
+		expected   colexectestutils.Tuples
+		inputTypes []*types.T
+		spec       execinfrapb.ExpandSpec
+	}{
+		{
+			// GROUPING SETS ((@1), (@2)).
+			tuples:     colexectestutils.Tuples{{1, "a"}, {2, nil}, {nil, "c"}},
+			inputTypes: []*types.T{types.Int, types.String},
+			spec: execinfrapb.ExpandSpec{
+				GroupingCols: []uint32{0, 1},
+				GroupingSets: []execinfrapb.ExpandSpec_GroupingSet{
+					{Cols: []uint32{0}},
+					{Cols: []uint32{1}},
+				},
+			},
+			expected: colexectestutils.Tuples{
+				{1, "a", 1, nil, 0},
+				{1, "a", nil, "a", 1},
+				{2, nil, 2, nil, 0},
+				{2, nil, nil, nil, 1},
+				{nil, "c", nil, nil, 0},
+				{nil, "c", nil, "c", 1},
+			},
+		},
+		{
+			// ROLLUP (@2, @1) without the empty grouping set.
+			tuples:     colexectestutils.Tuples{{1, "a"}, {2, "b"}},
+			inputTypes: []*types.T{types.Int, types.String},
+			spec: execinfrapb.ExpandSpec{
+				GroupingCols: []uint32{1, 0},
+				GroupingSets: []execinfrapb.ExpandSpec_GroupingSet{
+					{Cols: []uint32{0, 1}},
+					{Cols: []uint32{0}},
+				},
+			},
+			expected: colexectestutils.Tuples{
+				{1, "a", "a", 1, 0},
+				{1, "a", "a", nil, 1},
+				{2, "b", "b", 2, 0},
+				{2, "b", "b", nil, 1},
+			},
+		},
+		{
+			// Only some of the input columns are grouped on, and the same grouping
+			// set appears twice.
+			tuples:     colexectestutils.Tuples{{1, 10}, {2, 20}},
+			inputTypes: []*types.T{types.Int, types.Int},
+			spec: execinfrapb.ExpandSpec{
+				GroupingCols: []uint32{1},
+				GroupingSets: []execinfrapb.ExpandSpec_GroupingSet{
+					{Cols: []uint32{0}},
+					{Cols: []uint32{0}},
+				},
+			},
+			expected: colexectestutils.Tuples{
+				{1, 10, 10, 0},
+				{1, 10, 10, 1},
+				{2, 20, 20, 0},
+				{2, 20, 20, 1},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		colexectestutils.RunTests(t, testAllocator, []colexectestutils.Tuples{tc.tuples}, tc.expected, colexectestutils.OrderedVerifier,
+			func(input []colexecop.Operator) (colexecop.Operator, error) {
+				op, _ := colexecbase.NewExpandOp(testAllocator, input[0], tc.inputTypes, &tc.spec)
+				return op, nil
+			})
+	}
+}
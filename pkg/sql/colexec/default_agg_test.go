@@ -129,6 +129,58 @@ func TestDefaultAggregateFunc(t *testing.T) {
 				{0, 4},
 			},
 		},
+		{
+			name: "UserDefined",
+			typs: types.TwoIntCols,
+			input: colexectestutils.Tuples{
+				{0, 1},
+				{0, nil},
+				{0, 2},
+				{1, nil},
+				{2, 3},
+				{2, 4},
+				{2, nil},
+			},
+			groupCols: []uint32{0},
+			aggCols:   [][]uint32{{0}, {1}, {1}, {}},
+			aggFns: []execinfrapb.AggregatorSpec_Func{
+				execinfrapb.AnyNotNull,
+				execinfrapb.UserDefined,
+				execinfrapb.UserDefined,
+				execinfrapb.UserDefined,
+			},
+			userDefined: []*execinfrapb.AggregatorSpec_UserDefinedAggregation{
+				nil,
+				// A strict transition function without an initial state, which
+				// uses the first non-NULL input as the initial state.
+				{
+					StateType:        types.Int,
+					ResultType:       types.Int,
+					Transition:       execinfrapb.Expression{Expr: "@1 + @2"},
+					TransitionStrict: true,
+				},
+				// A non-strict transition function with a final function.
+				{
+					StateType:  types.Int,
+					ResultType: types.Int,
+					InitCond:   execinfrapb.Expression{Expr: "0:::INT8"},
+					Transition: execinfrapb.Expression{Expr: "CASE WHEN @2 IS NULL THEN @1 ELSE @1 + 1 END"},
+					Final:      execinfrapb.Expression{Expr: "@1 * 10"},
+				},
+				// An aggregate without arguments.
+				{
+					StateType:  types.Int,
+					ResultType: types.Int,
+					InitCond:   execinfrapb.Expression{Expr: "0:::INT8"},
+					Transition: execinfrapb.Expression{Expr: "@1 + 1"},
+				},
+			},
+			expected: colexectestutils.Tuples{
+				{0, 3, 20, 3},
+				{1, nil, 0, 1},
+				{2, 7, 20, 3},
+			},
+		},
 	}
 
 	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
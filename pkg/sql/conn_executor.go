@@ -90,6 +90,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tochar"
 	"github.com/cockroachdb/cockroach/pkg/util/ulid"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/logtags"
 	"github.com/cockroachdb/redact"
@@ -347,6 +348,10 @@ type Server struct {
 	reCache           *tree.RegexpCache
 	toCharFormatCache *tochar.FormatCache
 
+	// notifications routes the notifications sent with NOTIFY to the
+	// sessions on this node that are listening on their channel.
+	notifications *notificationRegistry
+
 	// pool is the parent monitor for all session monitors.
 	pool *mon.BytesMonitor
 
@@ -463,6 +468,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 		insights:                insightsProvider,
 		reCache:                 tree.NewRegexpCache(512),
 		toCharFormatCache:       tochar.NewFormatCache(512),
+		notifications:           newNotificationRegistry(cfg),
 		indexUsageStats: idxusage.NewLocalIndexUsageStats(&idxusage.Config{
 			ChannelSize: idxusage.DefaultChannelSize,
 			Setting:     cfg.Settings,
@@ -632,6 +638,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.reportedStats.Start(ctx, stopper)
 
 	s.txnIDCache.Start(ctx, stopper)
+
+	s.notifications.start(ctx, stopper)
 }
 
 // GetSQLStatsController returns the persistedsqlstats.Controller for current
@@ -1165,6 +1173,7 @@ func (s *Server) newConnExecutor(
 		portals:      make(map[string]PreparedPortal),
 	}
 	ex.extraTxnState.prepStmtsNamespaceMemAcc = ex.sessionMon.MakeBoundAccount()
+	ex.extraTxnState.deferredConstraintChecks.memAcc = ex.sessionMon.MakeBoundAccount()
 	dsdp := catsessiondata.NewDescriptorSessionDataStackProvider(sdMutIterator.sds)
 	ex.extraTxnState.descCollection = s.cfg.CollectionFactory.NewCollection(
 		ctx, descs.WithDescriptorSessionDataProvider(dsdp), descs.WithMonitor(ex.sessionMon),
@@ -1255,10 +1264,14 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.closeAllPortals(
 		ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 	)
-	if err := ex.extraTxnState.sqlCursors.closeAll(cursorCloseForExplicitClose); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, cursorCloseForExplicitClose); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
+	if ex.notificationListener != nil {
+		ex.notificationListener.unlistenAll()
+	}
+
 	var payloadErr error
 	if closeType == normalClose {
 		// We'll cleanup the SQL txn by creating a non-retriable (commit:true) event.
@@ -1317,6 +1330,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
+		ex.extraTxnState.deferredConstraintChecks.memAcc.Close(ctx)
 	}
 
 	if ex.sessionTracing.Enabled() {
@@ -1455,6 +1469,11 @@ type connExecutor struct {
 		// validateDbZoneConfig should the DB zone config on commit.
 		validateDbZoneConfig bool
 
+		// deferredConstraintChecks are the checks of deferrable constraints that
+		// were postponed by the current transaction. They are validated when the
+		// transaction commits.
+		deferredConstraintChecks deferredConstraintChecks
+
 		// txnCounter keeps track of how many SQL txns have been open since
 		// the start of the session. This is used for logging, to
 		// distinguish statements that belong to separate SQL transactions.
@@ -1622,6 +1641,24 @@ type connExecutor struct {
 		// The map key is the sequence descpb.ID.
 		createdSequences map[descpb.ID]struct{}
 
+		// notifications are the notifications queued by NOTIFY statements in the
+		// current transaction. They are sent to the listening sessions when the
+		// transaction commits.
+		notifications []Notification
+
+		// notificationsTxnID is the ID of the KV transaction that wrote the
+		// notifications to system.notifications.
+		notificationsTxnID uuid.UUID
+
+		// listenActions are the LISTEN and UNLISTEN statements executed in the
+		// current transaction. They are applied when the transaction commits.
+		listenActions []listenAction
+
+		// holdsNotificationFeed is set if the current transaction executed
+		// LISTEN, and holds a reference to the notifications rangefeed until it
+		// finishes.
+		holdsNotificationFeed bool
+
 		// shouldLogToTelemetry indicates if the current transaction should be
 		// logged to telemetry. It is used in telemetry transaction sampling
 		// mode to emit all statement events for a particular transaction.
@@ -1724,6 +1761,10 @@ type connExecutor struct {
 	// going to find a suitable time to close the connection.
 	draining bool
 
+	// notificationListener is the session's LISTEN state. It is lazily
+	// allocated by the first LISTEN statement.
+	notificationListener *notificationListener
+
 	// executorType is set to whether this executor is an ordinary executor which
 	// responds to user queries or an internal one.
 	executorType executorType
@@ -2053,6 +2094,7 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 		ex.extraTxnState.descCollection.ReleaseAll(ctx)
 		ex.extraTxnState.jobs.reset()
 		ex.extraTxnState.validateDbZoneConfig = false
+		ex.extraTxnState.deferredConstraintChecks.reset(ctx)
 		ex.extraTxnState.schemaChangerState.memAcc.Clear(ctx)
 		ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 			mode:   ex.sessionData().NewSchemaChangerMode,
@@ -2070,10 +2112,23 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	if ev.eventType != txnCommit {
 		closeReason = cursorCloseForTxnRollback
 	}
-	if err := ex.extraTxnState.sqlCursors.closeAll(closeReason); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, closeReason); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
+	// The rows that carry the notifications of a committed transaction are no
+	// longer needed once it commits, since the rangefeeds deliver them from the
+	// MVCC history.
+	if ev.eventType == txnCommit && len(ex.extraTxnState.notifications) > 0 {
+		ex.server.notifications.deleteSent(ex.extraTxnState.notificationsTxnID)
+	}
+	ex.extraTxnState.notifications = nil
+	if ev.eventType == txnCommit {
+		ex.applyListenActions()
+	}
+	ex.extraTxnState.listenActions = nil
+	ex.releaseNotificationFeed()
+
 	switch ev.eventType {
 	case txnCommit, txnRollback:
 		ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.closeAllPortals(
@@ -2491,6 +2546,15 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd, replRes)
+		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2504,6 +2568,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// Closing the res will send the pending notifications, if the session is
+		// not in a transaction, and flush the connection's buffer.
+		res = ex.clientComm.CreateFlushResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2529,7 +2597,7 @@ func (ex *connExecutor) execCmd() (retErr error) {
 			// txnState.finishSQLTxn() is being called, as the underlying resources of
 			// pausable portals hasn't been cleared yet.
 			ex.extraTxnState.prepStmtsNamespace.closeAllPausablePortals(ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc)
-			if err := ex.extraTxnState.sqlCursors.closeAll(cursorCloseForTxnRollback); err != nil {
+			if err := ex.extraTxnState.sqlCursors.closeAll(ctx, cursorCloseForTxnRollback); err != nil {
 				log.Warningf(ctx, "error closing cursors: %v", err)
 			}
 		}
@@ -2593,6 +2661,11 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		// Notifications received from LISTEN channels are only sent to the
+		// client in between transactions, like in Postgres.
+		if nres, ok := res.(NotificationResult); ok && ex.idleConn() {
+			ex.bufferPendingNotifications(nres)
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2769,10 +2842,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -3628,6 +3705,18 @@ func txnPriorityToProto(mode tree.UserPriority) roachpb.UserPriority {
 	return pri
 }
 
+// txnPriorityFromProto is the inverse of txnPriorityToProto.
+func txnPriorityFromProto(pri roachpb.UserPriority) tree.UserPriority {
+	switch pri {
+	case roachpb.MinUserPriority:
+		return tree.Low
+	case roachpb.MaxUserPriority:
+		return tree.High
+	default:
+		return tree.Normal
+	}
+}
+
 func (ex *connExecutor) txnPriorityWithSessionDefault(mode tree.UserPriority) roachpb.UserPriority {
 	if mode == tree.UnspecifiedUserPriority {
 		mode = tree.UserPriority(ex.sessionData().DefaultTxnPriority)
@@ -3743,6 +3832,9 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		indexUsageStats:      ex.indexUsageStats,
 		statementPreparer:    ex,
 	}
+	if ex.executorType != executorTypeInternal {
+		evalCtx.deferredConstraintChecks = &ex.extraTxnState.deferredConstraintChecks
+	}
 	rng, _ := randutil.NewPseudoRand()
 	evalCtx.RNG = rng
 	evalCtx.copyFromExecCfg(ex.server.cfg)
@@ -3825,8 +3917,10 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.sqlCursors = ex.getCursorAccessor()
 	p.storedProcTxnState = ex.getStoredProcTxnStateAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
+	p.notifications = ex.getNotificationsAccessor()
 
 	p.queryCacheSession.Init()
+	p.dynamicQueryCache = newDynamicQueryCache()
 	p.optPlanningCtx.init(p)
 	p.schemaResolver.sessionDataStack = p.EvalContext().SessionDataStack
 	p.schemaResolver.descCollection = p.Descriptors()
@@ -4330,6 +4424,12 @@ func (ex *connExecutor) getCreatedSequencesAccessor() createdSequences {
 	}
 }
 
+func (ex *connExecutor) getNotificationsAccessor() notifications {
+	return connExNotificationsAccessor{
+		ex: ex,
+	}
+}
+
 // sessionEventf logs a message to the session event log (if any).
 func (ex *connExecutor) sessionEventf(ctx context.Context, format string, args ...interface{}) {
 	if log.ExpensiveLogEnabled(ctx, 2) {
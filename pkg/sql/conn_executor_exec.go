@@ -898,6 +898,16 @@ func (ex *connExecutor) execStmtInOpenState(
 		ev, payload := ex.execShowCommitTimestampInOpenState(ctx, s, res, canAutoCommit)
 		return ev, payload, nil
 
+	case *tree.FetchCursor:
+		// Rows fetched from a BINARY cursor are sent in the binary format. As in
+		// Postgres, the formats requested by the Bind message take precedence when
+		// the FETCH is executed through the extended protocol.
+		if portal == nil {
+			if cursor := p.sqlCursors.getCursor(s.Name); cursor != nil && cursor.binary {
+				res.UseBinaryFormat()
+			}
+		}
+
 	case *tree.Prepare:
 		// This is handling the SQL statement "PREPARE". See execPrepare for
 		// handling of the protocol-level command for preparing statements.
@@ -1540,7 +1550,7 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retEr
 		ex.recordDDLTxnTelemetry(failed)
 	}()
 
-	if err := ex.extraTxnState.sqlCursors.closeAll(cursorCloseForTxnCommit); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, cursorCloseForTxnCommit); err != nil {
 		return err
 	}
 
@@ -1567,6 +1577,10 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retEr
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	if err := ex.planner.validateDeferredConstraints(ctx, false /* onlyImmediate */); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1667,7 +1681,7 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 func (ex *connExecutor) rollbackSQLTransaction(
 	ctx context.Context, stmt tree.Statement,
 ) (fsm.Event, fsm.EventPayload) {
-	if err := ex.extraTxnState.sqlCursors.closeAll(cursorCloseForTxnRollback); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, cursorCloseForTxnRollback); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
 
@@ -1700,6 +1714,10 @@ func (ex *connExecutor) dispatchReadCommittedStmtToExecutionEngine(
 	if err != nil {
 		return err
 	}
+	// The notifications queued by an attempt of the statement are discarded
+	// along with its writes to system.notifications, so that the next attempt
+	// queues them again.
+	numNotifications := len(ex.extraTxnState.notifications)
 
 	maxRetries := int(ex.sessionData().MaxRetriesForReadCommitted)
 	for attemptNum := 0; ; attemptNum++ {
@@ -1752,6 +1770,7 @@ func (ex *connExecutor) dispatchReadCommittedStmtToExecutionEngine(
 		if err := ex.state.mu.txn.RollbackToSavepoint(ctx, readCommittedSavePointToken); err != nil {
 			return err
 		}
+		ex.discardNotificationsAfter(numNotifications)
 		if err := ex.state.mu.txn.PrepareForPartialRetry(ctx); err != nil {
 			return err
 		}
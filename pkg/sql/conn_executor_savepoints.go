@@ -101,10 +101,12 @@ func (ex *connExecutor) execSavepointInOpenState(
 	}
 
 	sp := savepoint{
-		name:            s.Name,
-		commitOnRelease: commitOnRelease,
-		kvToken:         token,
-		numDDL:          ex.extraTxnState.numDDL,
+		name:             s.Name,
+		commitOnRelease:  commitOnRelease,
+		kvToken:          token,
+		numDDL:           ex.extraTxnState.numDDL,
+		numNotifications: len(ex.extraTxnState.notifications),
+		numListenActions: len(ex.extraTxnState.listenActions),
 	}
 	savepoints.push(sp)
 	ex.sessionDataStack.PushTopClone()
@@ -209,6 +211,8 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 		ev, payload := ex.makeErrEvent(err, s)
 		return ev, payload
 	}
+	ex.discardNotificationsAfter(entry.numNotifications)
+	ex.discardListenActionsAfter(entry.numListenActions)
 
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
@@ -286,6 +290,7 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 		return ev, payload
 	}
 
+	numNotifications, numListenActions := entry.numNotifications, entry.numListenActions
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
@@ -293,6 +298,8 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.discardNotificationsAfter(numNotifications)
+	ex.discardListenActionsAfter(numListenActions)
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -319,6 +326,22 @@ func (ex *connExecutor) popSavepointsToIdx(stmt tree.Statement, idx int) error {
 	return nil
 }
 
+// discardNotificationsAfter discards the notifications queued by NOTIFY
+// after the first n ones, when rolling back to a savepoint.
+func (ex *connExecutor) discardNotificationsAfter(n int) {
+	if n < len(ex.extraTxnState.notifications) {
+		ex.extraTxnState.notifications = ex.extraTxnState.notifications[:n]
+	}
+}
+
+// discardListenActionsAfter discards the LISTEN and UNLISTEN statements
+// executed after the first n ones, when rolling back to a savepoint.
+func (ex *connExecutor) discardListenActionsAfter(n int) {
+	if n < len(ex.extraTxnState.listenActions) {
+		ex.extraTxnState.listenActions = ex.extraTxnState.listenActions[:n]
+	}
+}
+
 // isCommitOnReleaseSavepoint returns true if the savepoint name implies special
 // release semantics: releasing it commits the underlying KV txn.
 func (ex *connExecutor) isCommitOnReleaseSavepoint(savepoint tree.Name) bool {
@@ -357,6 +380,17 @@ type savepoint struct {
 	// more DDL statements were executed since the savepoint's creation.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
+
+	// The number of notifications queued by NOTIFY in the transaction (at the
+	// time the savepoint was created). The notifications queued after that are
+	// discarded when rolling back to the savepoint, along with the rows they
+	// wrote to system.notifications.
+	numNotifications int
+
+	// The number of LISTEN and UNLISTEN statements executed in the transaction
+	// (at the time the savepoint was created). The ones executed after that are
+	// discarded when rolling back to the savepoint.
+	numListenActions int
 }
 
 type savepointStack []savepoint
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -429,6 +430,43 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for streaming logical replication changes to
+// the client using the Copy-both pgwire subprotocol (START_REPLICATION).
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// ClientMessages receives the payloads of the CopyData messages sent by the
+	// client while the stream is active. It is closed once the client ends the
+	// stream with CopyDone or CopyFail.
+	ClientMessages <-chan []byte
+	// StreamDone is closed once execution finishes and ClientMessages is no
+	// longer being consumed.
+	StreamDone chan<- struct{}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (e StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -447,6 +485,26 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications is pushed by the LISTEN/NOTIFY machinery when a
+// notification is received for a session that is listening on a channel. It
+// gives the connExecutor an opportunity to send the notification to the client
+// while the session is idle.
+//
+// DeliverNotifications commands produce a FlushResult.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (DeliverNotifications) isExtendedProtocolCmd() bool { return false }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -741,6 +799,9 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
 
@@ -841,6 +902,11 @@ type RestrictedCommandResult interface {
 	// data in the provided column when sending messages to the client.
 	GetFormatCode(colIdx int) (pgwirebase.FormatCode, error)
 
+	// UseBinaryFormat makes the result serialize every column of its rows in
+	// the binary format. It is used to FETCH from cursors declared with BINARY,
+	// and needs to be called before SetColumns.
+	UseBinaryFormat()
+
 	// AddRow accumulates a result row.
 	//
 	// The implementation cannot hold on to the row slice; it needs to make a
@@ -939,12 +1005,22 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationResult
 }
 
 // FlushResult represents the result of a Flush command. When this result is
 // closed, all previously accumulated results are flushed to the client.
 type FlushResult interface {
 	ResultBase
+	NotificationResult
+}
+
+// NotificationResult is implemented by the results that can carry
+// asynchronous LISTEN/NOTIFY notifications to the client.
+type NotificationResult interface {
+	// BufferNotification appends a notification to the result. It is sent to
+	// the client when the result is closed.
+	BufferNotification(n Notification)
 }
 
 // DrainResult represents the result of a Drain command. Closing this result
@@ -985,6 +1061,23 @@ type CopyOutResult interface {
 	SendCopyDone(ctx context.Context) error
 }
 
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+
+	// SendCopyBoth sends the copy both response starting the replication
+	// stream and flushes it to the client.
+	SendCopyBoth(ctx context.Context) error
+
+	// SendCopyBothData sends a COPY data message on the replication stream and
+	// flushes it to the client.
+	SendCopyBothData(ctx context.Context, copyData []byte) error
+
+	// SendCopyDone sends the copy done response to the client.
+	SendCopyDone(ctx context.Context) error
+}
+
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
 // represents a lock on the delivery of results to a SQL client. While such a
 // lock is used, no more results are delivered. The lock itself can be used to
@@ -1098,6 +1191,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the NotificationResult interface.
+func (r *streamingCommandResult) BufferNotification(n Notification) {
+	// Unimplemented: the internal executor does not support notifications.
+}
+
 // SendNotice is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	// Unimplemented: the internal executor does not support notices.
@@ -1116,6 +1214,12 @@ func (r *streamingCommandResult) GetFormatCode(colIdx int) (pgwirebase.FormatCod
 	return pgwirebase.FormatText, nil
 }
 
+// UseBinaryFormat is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) UseBinaryFormat() {
+	// Rows aren't serialized in the streamingCommandResult, so the format
+	// doesn't matter.
+}
+
 // AddRow is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	// AddRow() and SetRowsAffected() are never called on the same command
@@ -398,7 +398,12 @@ func (c *copyMachine) canSupportVectorized(table catalog.TableDescriptor) bool {
 	// row container. I think that requires a vectorized version of lookup
 	// join. TODO(cucaroach): extend the vectorized insert code to support
 	// insertFastPath style FK checks.
-	return len(table.EnforcedOutboundForeignKeys()) == 0
+	if len(table.EnforcedOutboundForeignKeys()) != 0 {
+		return false
+	}
+	// Vectorized COPY bypasses the table writers that maintain the summaries
+	// of block range indexes.
+	return len(table.GetBlockRangeIndexes()) == 0
 }
 
 func (c *copyMachine) initVectorizedCopy(ctx context.Context, typs []*types.T) error {
@@ -56,6 +56,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/nstree"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
@@ -176,6 +177,7 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalLocalMetricsTableID:                crdbInternalLocalMetricsTable,
 		catconstants.CrdbInternalNodeExecutionInsightsTableID:       crdbInternalNodeExecutionInsightsTable,
 		catconstants.CrdbInternalNodeMemoryMonitorsTableID:          crdbInternalNodeMemoryMonitors,
+		catconstants.CrdbInternalNodePLpgSQLStmtStatsTableID:        crdbInternalNodePLpgSQLStmtStatsTable,
 		catconstants.CrdbInternalNodeStmtStatsTableID:               crdbInternalNodeStmtStatsTable,
 		catconstants.CrdbInternalNodeTxnExecutionInsightsTableID:    crdbInternalNodeTxnExecutionInsightsTable,
 		catconstants.CrdbInternalNodeTxnStatsTableID:                crdbInternalNodeTxnStatsTable,
@@ -2188,6 +2190,57 @@ CREATE TABLE crdb_internal.node_txn_stats (
 	},
 }
 
+// crdbInternalNodePLpgSQLStmtStatsTable exposes the execution statistics of
+// the statements within PL/pgSQL routines executed on this node.
+var crdbInternalNodePLpgSQLStmtStatsTable = virtualSchemaTable{
+	comment: `per-statement execution statistics for PL/pgSQL routines ` +
+		`(in-memory, not durable; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.node_plpgsql_statement_statistics (
+  node_id             INT NOT NULL,
+  routine_id          OID NOT NULL,
+  routine_version     INT NOT NULL,
+  routine_signature   STRING NOT NULL,
+  statement_id        INT NOT NULL,
+  line_number         INT NOT NULL,
+  statement_type      STRING NOT NULL,
+  exec_count          INT NOT NULL,
+  error_count         INT NOT NULL,
+  latency_total_sec   FLOAT NOT NULL,
+  latency_avg_sec     FLOAT NOT NULL,
+  latency_max_sec     FLOAT NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA); err != nil {
+			return err
+		}
+		stats := p.execCfg.PLpgSQLStmtStats
+		if stats == nil {
+			return nil
+		}
+		nodeID, _ := p.execCfg.NodeInfo.NodeID.OptionalNodeID() // zero if not available
+		for _, e := range stats.entries() {
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDOid(e.info.RoutineID),
+				tree.NewDInt(tree.DInt(e.info.RoutineVersion)),
+				tree.NewDString(e.info.RoutineSignature),
+				tree.NewDInt(tree.DInt(e.info.StmtID)),
+				tree.NewDInt(tree.DInt(e.info.LineNo)),
+				tree.NewDString(e.info.StmtType),
+				tree.NewDInt(tree.DInt(e.execCount)),
+				tree.NewDInt(tree.DInt(e.errorCount)),
+				tree.NewDFloat(tree.DFloat(e.totalLatency.Seconds())),
+				tree.NewDFloat(tree.DFloat(e.meanLatency().Seconds())),
+				tree.NewDFloat(tree.DFloat(e.maxLatency.Seconds())),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // crdbInternalSessionTraceTable exposes the latest trace collected on this
 // session (via SET TRACING={ON/OFF})
 //
@@ -3521,6 +3574,45 @@ func writeCreateTypeDescRow(
 			tree.DNull,                           // enum_members
 		)
 	}
+	if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{d.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		def := &tree.DomainDef{BaseType: d.GetBaseType()}
+		if d.IsNotNull() {
+			def.Nullable.Nullability = tree.NotNull
+		} else {
+			def.Nullable.Nullability = tree.SilentNull
+		}
+		if expr := d.GetDefaultExpr(); expr != "" {
+			if def.DefaultExpr, err = parser.ParseExpr(expr); err != nil {
+				return false, err
+			}
+		}
+		def.CheckExprs = make([]tree.ColumnTableDefCheckExpr, d.NumCheckConstraints())
+		for i := range def.CheckExprs {
+			checkName, checkExpr := d.GetCheckConstraint(i)
+			def.CheckExprs[i].ConstraintName = tree.Name(checkName)
+			if def.CheckExprs[i].Expr, err = parser.ParseExpr(checkExpr); err != nil {
+				return false, err
+			}
+		}
+		node := &tree.CreateType{
+			Variety:   tree.Domain,
+			TypeName:  name,
+			DomainDef: def,
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(d.GetID())),   // descriptor_id
+			tree.NewDString(d.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
 	return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 }
 
@@ -3660,6 +3752,24 @@ func createRoutinePopulate(
 				// otherwise.
 				continue
 			}
+			if fnDesc.IsAggregate() {
+				stmt, err := makeCreateAggregateStmt(ctx, p, fnDesc, fnIDToScName)
+				if err != nil {
+					return err
+				}
+				if err := addRow(
+					tree.NewDInt(tree.DInt(fnIDToDBID[fnDesc.GetID()])), // database_id
+					tree.NewDString(fnIDToDBName[fnDesc.GetID()]),       // database_name
+					tree.NewDInt(tree.DInt(fnIDToScID[fnDesc.GetID()])), // schema_id
+					tree.NewDString(fnIDToScName[fnDesc.GetID()]),       // schema_name
+					tree.NewDInt(tree.DInt(fnDesc.GetID())),             // function_id
+					tree.NewDString(fnDesc.GetName()),                   // function_name
+					tree.NewDString(tree.AsString(stmt)),                // create_statement
+				); err != nil {
+					return err
+				}
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.Name.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -3709,6 +3819,61 @@ func createRoutinePopulate(
 	}
 }
 
+// makeCreateAggregateStmt returns the CREATE AGGREGATE statement for the given
+// user-defined aggregate function. The names of the aggregate and of its
+// support functions are qualified with the schema names in fnIDToScName.
+func makeCreateAggregateStmt(
+	ctx context.Context,
+	p *planner,
+	fnDesc catalog.FunctionDescriptor,
+	fnIDToScName map[descpb.ID]string,
+) (*tree.CreateAggregate, error) {
+	routineName := func(id descpb.ID, name string) tree.RoutineName {
+		var prefix tree.ObjectNamePrefix
+		if scName, ok := fnIDToScName[id]; ok {
+			prefix = tree.ObjectNamePrefix{ExplicitSchema: true, SchemaName: tree.Name(scName)}
+		}
+		return tree.MakeRoutineNameFromPrefix(prefix, tree.Name(name))
+	}
+	supportFn := func(id descpb.ID) (*tree.RoutineName, error) {
+		if id == descpb.InvalidID {
+			return nil, nil
+		}
+		desc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Function(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		name := routineName(id, desc.GetName())
+		return &name, nil
+	}
+	agg := fnDesc.FuncDesc().Aggregate
+	stmt := &tree.CreateAggregate{
+		Name:     routineName(fnDesc.GetID(), fnDesc.GetName()),
+		Params:   make(tree.RoutineParams, len(fnDesc.GetParams())),
+		SType:    agg.StateType,
+		InitCond: agg.InitCond,
+	}
+	for i, param := range fnDesc.GetParams() {
+		stmt.Params[i] = tree.RoutineParam{
+			Name:  tree.Name(param.Name),
+			Type:  param.Type,
+			Class: funcdesc.ToTreeRoutineParamClass(param.Class),
+		}
+	}
+	sfunc, err := supportFn(agg.TransitionFunctionID)
+	if err != nil {
+		return nil, err
+	}
+	stmt.SFunc = *sfunc
+	if stmt.FinalFunc, err = supportFn(agg.FinalFunctionID); err != nil {
+		return nil, err
+	}
+	if stmt.CombineFunc, err = supportFn(agg.CombineFunctionID); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
 var crdbInternalCreateFunctionStmtsTable = virtualSchemaTable{
 	comment: "CREATE statements for all user-defined functions",
 	schema: `
@@ -0,0 +1,377 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// CreateAggregate creates a user-defined aggregate function. The aggregate is
+// stored as a function descriptor that references its support functions,
+// which must be user-defined functions themselves.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+	// Nodes running older versions would resolve the aggregate as a plain
+	// user-defined function.
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregates", clusterversion.V24_1)
+	}
+
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, n.Name.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate in the system database")
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	if n.scDesc.SchemaKind() == catalog.SchemaTemporary {
+		return unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+
+	typeDeps := make(typeDependencies)
+	addTypeDeps := func(typ *types.T) {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
+			typeDeps[id] = struct{}{}
+		})
+	}
+
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	argTypes := make([]*types.T, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.Class != tree.RoutineParamDefault && param.Class != tree.RoutineParamIn {
+			return pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates can only have input parameters")
+		}
+		if param.DefaultVal != nil {
+			return pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates cannot have default arguments")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return err
+		}
+		pbParams[i] = pbParam
+		argTypes[i] = pbParam.Type
+		addTypeDeps(pbParam.Type)
+	}
+
+	stateType, err := tree.ResolveType(params.ctx, n.n.SType, params.p)
+	if err != nil {
+		return err
+	}
+	if stateType.Family() == types.VoidFamily || stateType.Family() == types.UnknownFamily {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"aggregate transition data type cannot be %s", stateType.SQLStringForError())
+	}
+	addTypeDeps(stateType)
+
+	// The transition function takes the state followed by the aggregated
+	// arguments and returns the new state.
+	sfuncArgTypes := append([]*types.T{stateType}, argTypes...)
+	sfunc, err := n.resolveSupportFunction(params, "transition", &n.n.SFunc, sfuncArgTypes)
+	if err != nil {
+		return err
+	}
+	if !sfunc.GetReturnType().Type.Equivalent(stateType) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"return type of transition function %s is not %s", sfunc.GetName(), stateType.SQLStringForError())
+	}
+	supportFuncs := []catalog.FunctionDescriptor{sfunc}
+
+	returnType := stateType
+	var ffunc catalog.FunctionDescriptor
+	if n.n.FinalFunc != nil {
+		ffunc, err = n.resolveSupportFunction(params, "final", n.n.FinalFunc, []*types.T{stateType})
+		if err != nil {
+			return err
+		}
+		returnType = ffunc.GetReturnType().Type
+		supportFuncs = append(supportFuncs, ffunc)
+	}
+	if returnType.Family() == types.VoidFamily {
+		return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregates cannot return type void")
+	}
+
+	var cfunc catalog.FunctionDescriptor
+	if n.n.CombineFunc != nil {
+		cfunc, err = n.resolveSupportFunction(params, "combine", n.n.CombineFunc, []*types.T{stateType, stateType})
+		if err != nil {
+			return err
+		}
+		if !cfunc.GetReturnType().Type.Equivalent(stateType) {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"return type of combine function %s is not %s", cfunc.GetName(), stateType.SQLStringForError())
+		}
+		supportFuncs = append(supportFuncs, cfunc)
+	}
+
+	if n.n.InitCond == nil && sfunc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT {
+		// If the transition function is strict and the initial state is NULL,
+		// the first input becomes the state, so it must have the state type.
+		if len(argTypes) != 1 || !argTypes[0].Equivalent(stateType) {
+			return pgerror.New(pgcode.InvalidFunctionDefinition,
+				"must not omit initial value when transition function is strict and transition type is not compatible with input type")
+		}
+	}
+	if n.n.InitCond != nil {
+		if _, _, err := tree.ParseAndRequireString(stateType, *n.n.InitCond, params.EvalContext()); err != nil {
+			return err
+		}
+	}
+
+	// The aggregate is as volatile as the most volatile of its support
+	// functions.
+	vol := catpb.Function_IMMUTABLE
+	functionDeps := make(functionDependencies)
+	for _, fn := range supportFuncs {
+		switch fn.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			vol = catpb.Function_VOLATILE
+		case catpb.Function_STABLE:
+			if vol == catpb.Function_IMMUTABLE {
+				vol = catpb.Function_STABLE
+			}
+		}
+		functionDeps[fn.GetID()] = struct{}{}
+	}
+
+	agg := &descpb.FunctionDescriptor_Aggregate{
+		TransitionFunctionID: sfunc.GetID(),
+		StateType:            stateType,
+		InitCond:             n.n.InitCond,
+	}
+	if ffunc != nil {
+		agg.FinalFunctionID = ffunc.GetID()
+	}
+	if cfunc != nil {
+		agg.CombineFunctionID = cfunc.GetID()
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(params.ctx, n.dbDesc, n.scDesc.GetName())
+	if err != nil {
+		return err
+	}
+
+	existing, err := params.p.matchRoutine(
+		params.ctx, &tree.RoutineObj{FuncName: n.n.Name, Params: n.n.Params}, false, /* required */
+		tree.UDFRoutine|tree.ProcedureRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return err
+	}
+
+	var aggDesc *funcdesc.Mutable
+	if existing != nil {
+		if !n.n.Replace {
+			return pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid))
+		if err != nil {
+			return err
+		}
+		if !aggDesc.IsAggregate() {
+			formatStr := "%q is a function"
+			if aggDesc.IsProcedure() {
+				formatStr = "%q is a procedure"
+			}
+			return errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				formatStr,
+				aggDesc.Name,
+			)
+		}
+		if !returnType.Equivalent(aggDesc.ReturnType.Type) {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+		}
+		if err := removeRoutineReferences(params, aggDesc); err != nil {
+			return err
+		}
+		// The parameter types cannot change, but their names might.
+		aggDesc.Params = pbParams
+		aggDesc.ReturnType.Type = returnType
+	} else {
+		id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+		if err != nil {
+			return err
+		}
+		privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+			n.dbDesc.GetDefaultPrivilegeDescriptor(),
+			n.scDesc.GetDefaultPrivilegeDescriptor(),
+			n.dbDesc.GetID(),
+			params.SessionData().User(),
+			privilege.Routines,
+		)
+		if err != nil {
+			return err
+		}
+		newDesc := funcdesc.NewMutableFunctionDescriptor(
+			id,
+			n.dbDesc.GetID(),
+			n.scDesc.GetID(),
+			string(n.n.Name.ObjectName),
+			pbParams,
+			returnType,
+			false, /* returnSet */
+			false, /* isProcedure */
+			privileges,
+		)
+		aggDesc = &newDesc
+	}
+	addTypeDeps(returnType)
+	aggDesc.Aggregate = agg
+	aggDesc.Volatility = vol
+	aggDesc.NullInputBehavior = catpb.Function_CALLED_ON_NULL_INPUT
+	if err := addRoutineReferences(
+		params, aggDesc, n.n.Name.String(), nil /* planDeps */, typeDeps, functionDeps,
+	); err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if err := params.p.writeFuncSchemaChange(params.ctx, aggDesc); err != nil {
+			return err
+		}
+	} else {
+		if err := params.p.createDescriptor(
+			params.ctx,
+			aggDesc,
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+		); err != nil {
+			return err
+		}
+		mutScDesc.AddFunction(
+			aggDesc.GetName(),
+			descpb.SchemaDescriptor_FunctionSignature{
+				ID:          aggDesc.GetID(),
+				ArgTypes:    argTypes,
+				ReturnType:  returnType,
+				IsAggregate: true,
+			},
+		)
+		if err := params.p.writeSchemaDescChange(params.ctx, mutScDesc, "Create Aggregate"); err != nil {
+			return err
+		}
+	}
+
+	fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.String())
+	return params.p.logEvent(params.ctx, aggDesc.GetID(), &eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+		IsReplace:    existing != nil,
+	})
+}
+
+// resolveSupportFunction resolves the support function of the aggregate with
+// the given name and exact argument types. The kind of the support function
+// is only used in error messages.
+func (n *createAggregateNode) resolveSupportFunction(
+	params runParams, kind string, name *tree.RoutineName, argTypes []*types.T,
+) (catalog.FunctionDescriptor, error) {
+	routineObj := tree.RoutineObj{
+		FuncName: *name,
+		Params:   make(tree.RoutineParams, len(argTypes)),
+	}
+	for i, typ := range argTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamDefault}
+	}
+	path := params.p.CurrentSearchPath()
+	fnDef, err := params.p.ResolveFunction(
+		params.ctx, tree.MakeUnresolvedFunctionName(name.ToUnresolvedObjectName().ToUnresolvedName()), &path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(
+		params.ctx, params.p, &routineObj, &path, tree.UDFRoutine|tree.BuiltinRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, unimplemented.Newf("CREATE AGGREGATE builtin support function",
+			"%s function %s%s must be a user-defined function", kind, fnDef.Name, ol.Signature(true /* simplify */))
+	}
+	fnDesc, err := params.p.Descriptors().ByIDWithLeased(params.p.Txn()).Get().Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.IsAggregate() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%s function %s cannot be an aggregate function", kind, fnDesc.GetName())
+	}
+	if fnDesc.GetReturnType().ReturnSet {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function %s returns a set", fnDesc.GetName())
+	}
+	if dbID := fnDesc.GetParentID(); dbID != n.dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"dependent function %s cannot be from another database", fnDesc.GetName())
+	}
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
@@ -0,0 +1,195 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
+	"github.com/cockroachdb/errors"
+	"golang.org/x/text/language"
+)
+
+type createCollationNode struct {
+	n         *tree.CreateCollation
+	dbDesc    *dbdesc.Mutable
+	collation descpb.DatabaseDescriptor_Collation
+}
+
+// CreateCollation creates a collation in the current database.
+// Privileges: CREATE on the database.
+func (p *planner) CreateCollation(ctx context.Context, n *tree.CreateCollation) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE COLLATION",
+	); err != nil {
+		return nil, err
+	}
+	if err := checkCollationsVersion(ctx, p.EvalContext()); err != nil {
+		return nil, err
+	}
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if _, found := dbDesc.GetCollation(string(n.Name)); found {
+		if n.IfNotExists {
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"collation %q already exists, skipping", n.Name))
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"collation %q already exists", n.Name)
+	}
+	// Collation names and locales are used interchangeably, so a collation
+	// cannot be named after a locale, which it would shadow.
+	if _, err := language.Parse(string(n.Name)); err == nil ||
+		collatedstring.IsDefaultEquivalentCollation(string(n.Name)) {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.InvalidName,
+				"collation name %q conflicts with a locale", n.Name),
+			"choose a name which is not a locale",
+		)
+	}
+
+	c := descpb.DatabaseDescriptor_Collation{Name: string(n.Name), Deterministic: true}
+	if n.From != "" {
+		if from, found := dbDesc.GetCollation(string(n.From)); found {
+			c.Locale, c.Deterministic = from.Locale, from.Deterministic
+		} else {
+			// Locales can be used as collations directly, so they can be copied
+			// as well.
+			c.Locale = string(n.From)
+		}
+	} else {
+		exprEval := p.ExprEvaluator("CREATE COLLATION")
+		for _, opt := range n.Options {
+			switch key := strings.ToLower(string(opt.Key)); key {
+			case "locale", "lc_collate":
+				if c.Locale, err = exprEval.String(ctx, paramparse.UnresolvedNameToStrVal(opt.Value)); err != nil {
+					return nil, err
+				}
+			case "lc_ctype":
+				// Character classification is always done according to Unicode, so
+				// the value is ignored.
+			case "provider":
+				provider, err := exprEval.String(ctx, paramparse.UnresolvedNameToStrVal(opt.Value))
+				if err != nil {
+					return nil, err
+				}
+				if !strings.EqualFold(provider, "icu") {
+					return nil, errors.WithHint(
+						pgerror.Newf(pgcode.FeatureNotSupported,
+							"unsupported collation provider: %s", provider),
+						"only the icu provider is supported",
+					)
+				}
+			case "deterministic":
+				if c.Deterministic, err = exprEval.Bool(ctx, opt.Value); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"collation attribute %q not recognized", key)
+			}
+		}
+		if c.Locale == "" {
+			return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+				`parameter "locale" must be specified`)
+		}
+	}
+	if err := validateCollationLocale(c.Locale, c.Deterministic); err != nil {
+		return nil, err
+	}
+	return &createCollationNode{n: n, dbDesc: dbDesc, collation: c}, nil
+}
+
+// checkCollationsVersion returns an error if collations, which are stored in
+// database descriptors, are not supported by the active cluster version.
+func checkCollationsVersion(ctx context.Context, evalCtx *eval.Context) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1_Collations) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"collations are not supported until version 24.1")
+	}
+	return nil
+}
+
+// validateCollationLocale returns an error if the given locale cannot be used
+// for a collation. Since strings are compared using their collation keys,
+// collations which ignore differences between strings, e.g. case-insensitive
+// collations, must be declared as nondeterministic.
+func validateCollationLocale(locale string, deterministic bool) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid locale %s", locale)
+	}
+	if deterministic {
+		switch tag.TypeForKey("ks") {
+		case "level1", "level2":
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidObjectDefinition,
+					"locale %s ignores differences between strings", locale),
+				"create the collation with deterministic = false",
+			)
+		}
+	}
+	return nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createCollationNode) ReadingOwnWrites() {}
+
+func (n *createCollationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("collation"))
+	n.dbDesc.Collations = append(n.dbDesc.Collations, n.collation)
+	if err := validateDescriptor(params.ctx, params.p, n.dbDesc); err != nil {
+		return err
+	}
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createCollationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createCollationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createCollationNode) Close(context.Context)        {}
+
+// ResolveCollation implements the tree.CollationResolver interface.
+func (p *planner) ResolveCollation(
+	ctx context.Context, name string,
+) (locale string, found bool, err error) {
+	if p.txn == nil || p.CurrentDatabase() == "" {
+		return "", false, nil
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Database(ctx, p.CurrentDatabase())
+	if err != nil || db == nil {
+		return "", false, err
+	}
+	c, found := db.GetCollation(name)
+	return c.Locale, found, nil
+}
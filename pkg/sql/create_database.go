@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/errors"
+	"golang.org/x/text/language"
 )
 
 type createDatabaseNode struct {
@@ -63,12 +64,16 @@ func (p *planner) CreateDatabase(ctx context.Context, n *tree.CreateDatabase) (p
 		}
 	}
 
-	if col := n.Collate; col != "" {
-		// We only support C and C.UTF-8.
-		if col != "C" && col != "C.UTF-8" {
+	if col := databaseDefaultCollation(n.Collate); col != "" {
+		// Other than C and C.UTF-8, we only support ICU locales, which become
+		// the default collation of the string columns in the database.
+		if _, err := language.Parse(col); err != nil {
 			return nil, unimplemented.NewWithIssueDetailf(16618, "create.db.collation",
 				"unsupported collation: %s", col)
 		}
+		if err := checkCollationsVersion(ctx, p.EvalContext()); err != nil {
+			return nil, err
+		}
 	}
 
 	if ctype := n.CType; ctype != "" {
@@ -162,6 +167,16 @@ func (p *planner) CanCreateDatabase(ctx context.Context) error {
 	return nil
 }
 
+// databaseDefaultCollation returns the locale to use as the default collation
+// of a database created with the given LC_COLLATE, or the empty string if
+// strings are compared bytewise.
+func databaseDefaultCollation(lcCollate string) string {
+	if lcCollate == "C" || lcCollate == "C.UTF-8" {
+		return ""
+	}
+	return lcCollate
+}
+
 func (n *createDatabaseNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("database"))
 
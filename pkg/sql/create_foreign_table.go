@@ -0,0 +1,426 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn/connectionpb"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+// foreignDataWrapperCloudStorage is the only foreign-data wrapper. Its servers
+// are external connections to cloud storage, and the tables of its servers are
+// files in that storage.
+const foreignDataWrapperCloudStorage = "cloud_storage"
+
+// The options of CREATE SERVER.
+const foreignServerOptionLocation = "location"
+
+// The options of CREATE FOREIGN TABLE.
+const (
+	foreignTableOptionFilename    = "filename"
+	foreignTableOptionFormat      = "format"
+	foreignTableOptionDelimiter   = "delimiter"
+	foreignTableOptionHeader      = "header"
+	foreignTableOptionNull        = "null"
+	foreignTableOptionCompression = "compression"
+)
+
+var foreignTableFormats = map[string]roachpb.IOFileFormat_FileFormat{
+	"csv":     roachpb.IOFileFormat_CSV,
+	"parquet": roachpb.IOFileFormat_Parquet,
+	"avro":    roachpb.IOFileFormat_Avro,
+}
+
+var foreignTableCompressions = map[string]roachpb.IOFileFormat_Compression{
+	"auto":   roachpb.IOFileFormat_Auto,
+	"none":   roachpb.IOFileFormat_None,
+	"gzip":   roachpb.IOFileFormat_Gzip,
+	"bzip":   roachpb.IOFileFormat_Bzip,
+	"snappy": roachpb.IOFileFormat_Snappy,
+}
+
+// foreignTableFileFormat returns the format in which the files of a foreign
+// table are read. The options are validated when the table is created.
+func foreignTableFileFormat(ft *descpb.TableDescriptor_ForeignTable) (roachpb.IOFileFormat, error) {
+	format := roachpb.IOFileFormat{Format: roachpb.IOFileFormat_CSV}
+	for _, o := range ft.Options {
+		if o.Name != foreignTableOptionFormat {
+			continue
+		}
+		f, ok := foreignTableFormats[o.Value]
+		if !ok {
+			return roachpb.IOFileFormat{}, errors.AssertionFailedf("invalid foreign table format %q", o.Value)
+		}
+		format.Format = f
+	}
+	switch format.Format {
+	case roachpb.IOFileFormat_Avro:
+		format.Avro.Format = roachpb.AvroOptions_OCF
+	}
+	for _, o := range ft.Options {
+		switch o.Name {
+		case foreignTableOptionDelimiter:
+			r, _ := utf8.DecodeRuneInString(o.Value)
+			format.Csv.Comma = r
+		case foreignTableOptionHeader:
+			if header, err := strconv.ParseBool(o.Value); err != nil {
+				return roachpb.IOFileFormat{}, errors.NewAssertionErrorWithWrappedErrf(err, "invalid header option")
+			} else if header {
+				format.Csv.Skip = 1
+			}
+		case foreignTableOptionNull:
+			null := o.Value
+			format.Csv.NullEncoding = &null
+		case foreignTableOptionCompression:
+			c, ok := foreignTableCompressions[o.Value]
+			if !ok {
+				return roachpb.IOFileFormat{}, errors.AssertionFailedf("invalid foreign table compression %q", o.Value)
+			}
+			format.Compression = c
+		}
+	}
+	return format, nil
+}
+
+// foreignTableOptions returns the options of a foreign table as they are
+// displayed by SHOW CREATE.
+func foreignTableOptions(ft *descpb.TableDescriptor_ForeignTable) tree.ForeignOptions {
+	opts := make(tree.ForeignOptions, len(ft.Options))
+	for i, o := range ft.Options {
+		opts[i] = tree.ForeignOption{Name: tree.Name(o.Name), Value: o.Value}
+	}
+	return opts
+}
+
+// validateForeignTableOptions checks the options of CREATE FOREIGN TABLE and
+// returns them as they are stored in the table descriptor.
+func validateForeignTableOptions(
+	opts tree.ForeignOptions,
+) ([]descpb.TableDescriptor_ForeignTable_Option, error) {
+	res := make([]descpb.TableDescriptor_ForeignTable_Option, 0, len(opts))
+	seen := make(map[string]bool, len(opts))
+	format := "csv"
+	for _, o := range opts {
+		name := strings.ToLower(string(o.Name))
+		if seen[name] {
+			return nil, pgerror.Newf(pgcode.Syntax, "option %q provided more than once", name)
+		}
+		seen[name] = true
+		value := o.Value
+		switch name {
+		case foreignTableOptionFilename:
+			if value == "" {
+				return nil, pgerror.New(pgcode.InvalidParameterValue, "filename must not be empty")
+			}
+		case foreignTableOptionFormat:
+			value = strings.ToLower(value)
+			if _, ok := foreignTableFormats[value]; !ok {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"format %q is not supported", o.Value)
+			}
+			format = value
+		case foreignTableOptionDelimiter:
+			if utf8.RuneCountInString(value) != 1 {
+				return nil, pgerror.New(pgcode.InvalidParameterValue,
+					"delimiter must be a single character")
+			}
+		case foreignTableOptionHeader:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"header requires a Boolean value")
+			}
+			value = strconv.FormatBool(b)
+		case foreignTableOptionNull:
+		case foreignTableOptionCompression:
+			value = strings.ToLower(value)
+			if _, ok := foreignTableCompressions[value]; !ok {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"compression %q is not supported", o.Value)
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid option %q", name)
+		}
+		res = append(res, descpb.TableDescriptor_ForeignTable_Option{Name: name, Value: value})
+	}
+	if !seen[foreignTableOptionFilename] {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "option \"filename\" is required")
+	}
+	if format != "csv" {
+		for _, name := range []string{
+			foreignTableOptionDelimiter, foreignTableOptionHeader, foreignTableOptionNull,
+		} {
+			if seen[name] {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"option %q is only supported for format 'csv'", name)
+			}
+		}
+	}
+	return res, nil
+}
+
+// validateForeignTableColumn checks that a column of a foreign table only has
+// a name, a type and a nullability, since its values are read from files.
+func validateForeignTableColumn(d *tree.ColumnTableDef) error {
+	if d.IsSerial || d.GeneratedIdentity.IsGeneratedAsIdentity || d.Hidden ||
+		d.PrimaryKey.IsPrimaryKey || d.Unique.IsUnique || d.HasDefaultExpr() ||
+		d.HasOnUpdateExpr() || len(d.CheckExprs) > 0 || d.HasFKConstraint() ||
+		d.IsComputed() || d.HasColumnFamily() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"column %q of a foreign table only supports NULL and NOT NULL constraints", d.Name)
+	}
+	return nil
+}
+
+// lookupForeignServer returns the type of the external connection of a
+// foreign server, and whether it exists.
+func (p *planner) lookupForeignServer(
+	ctx context.Context, name string,
+) (connectionType string, ok bool, _ error) {
+	row, err := p.InternalSQLTxn().QueryRowEx(ctx, "lookup-foreign-server", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT connection_type FROM system.external_connections WHERE connection_name = $1`,
+		name,
+	)
+	if err != nil || row == nil {
+		return "", false, err
+	}
+	return string(tree.MustBeDString(row[0])), true, nil
+}
+
+type createServerNode struct {
+	n        *tree.CreateServer
+	location string
+}
+
+// CreateServer implements the CREATE SERVER statement. A server of the
+// cloud_storage foreign-data wrapper is an external connection, which may be
+// used and dropped as such.
+func (p *planner) CreateServer(ctx context.Context, n *tree.CreateServer) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_ForeignTables) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE SERVER is not supported until version 24.1")
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE SERVER",
+	); err != nil {
+		return nil, err
+	}
+	if n.Wrapper != foreignDataWrapperCloudStorage {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"foreign-data wrapper %q does not exist", n.Wrapper)
+	}
+	node := &createServerNode{n: n}
+	for _, o := range n.Options {
+		if name := strings.ToLower(string(o.Name)); name != foreignServerOptionLocation {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid option %q", name)
+		}
+		node.location = o.Value
+	}
+	if node.location == "" {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "option \"location\" is required")
+	}
+	return node, nil
+}
+
+func (n *createServerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("server"))
+	return params.p.createExternalConnection(params, &tree.CreateExternalConnection{
+		ConnectionLabelSpec: tree.LabelSpec{
+			IfNotExists: n.n.IfNotExists,
+			Label:       tree.NewStrVal(string(n.n.Name)),
+		},
+		As: tree.NewStrVal(n.location),
+	})
+}
+
+func (n *createServerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createServerNode) Values() tree.Datums          { return nil }
+func (n *createServerNode) Close(context.Context)        {}
+
+type createForeignTableNode struct {
+	n       *tree.CreateForeignTable
+	dbDesc  catalog.DatabaseDescriptor
+	options []descpb.TableDescriptor_ForeignTable_Option
+}
+
+// CreateForeignTable implements the CREATE FOREIGN TABLE statement.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_ForeignTables) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE FOREIGN TABLE is not supported until version 24.1")
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	var numCols int
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign tables only support column definitions")
+		}
+		if err := validateForeignTableColumn(d); err != nil {
+			return nil, err
+		}
+		numCols++
+	}
+	if numCols == 0 {
+		return nil, pgerror.New(pgcode.InvalidTableDefinition,
+			"foreign tables must have at least one column")
+	}
+	options, err := validateForeignTableOptions(n.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if dbDesc.IsMultiRegion() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"foreign tables are not supported in multi-region database %q", dbDesc.GetName())
+	}
+
+	server := string(n.Server)
+	connectionType, ok, err := p.lookupForeignServer(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", server)
+	}
+	if connectionType != connectionpb.TypeStorage.String() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"server %q is not a %s server", server, foreignDataWrapperCloudStorage)
+	}
+	if err := p.CheckPrivilege(ctx, &syntheticprivilege.ExternalConnectionPrivilege{
+		ConnectionName: server,
+	}, privilege.USAGE); err != nil {
+		return nil, err
+	}
+
+	return &createForeignTableNode{n: n, dbDesc: dbDesc, options: options}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+
+	schema, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent, &n.n.Table,
+		tree.ResolveRequireForeignTableDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			params.p.BufferClientNotice(
+				params.ctx,
+				pgnotice.Newf("relation %q already exists, skipping", n.n.Table.Table()),
+			)
+			return nil
+		}
+		return err
+	}
+
+	id, err := params.extendedEvalCtx.DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Tables,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The table gets a hidden rowid primary key like any table without one.
+	// Its values are derived from the position of the rows in the files.
+	var creationTime hlc.Timestamp
+	desc, err := newTableDesc(params, &tree.CreateTable{
+		Table: n.n.Table,
+		Defs:  n.n.Defs,
+	}, n.dbDesc, schema, id, creationTime, privs, nil /* affected */)
+	if err != nil {
+		return err
+	}
+	desc.ForeignTable = &descpb.TableDescriptor_ForeignTable{
+		Server:  string(n.n.Server),
+		Options: n.options,
+	}
+	desc.State = descpb.DescriptorState_PUBLIC
+
+	if err := params.p.createDescriptor(
+		params.ctx,
+		desc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	if err := params.p.addBackRefsFromAllTypesInTable(params.ctx, desc); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, params.p, desc); err != nil {
+		return err
+	}
+
+	// Log Create Table event. This is an auditable log event and is
+	// recorded in the same transaction as the table descriptor update.
+	return params.p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+func (n *createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createForeignTableNode) Values() tree.Datums          { return nil }
+func (n *createForeignTableNode) Close(context.Context)        {}
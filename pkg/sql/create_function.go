@@ -52,10 +52,6 @@ type createFunctionNode struct {
 func (n *createFunctionNode) ReadingOwnWrites() {}
 
 func (n *createFunctionNode) startExec(params runParams) error {
-	if n.cf.RoutineBody != nil {
-		return unimplemented.NewWithIssue(85144, "CREATE FUNCTION...sql_body unimplemented")
-	}
-
 	if err := params.p.canCreateOnSchema(
 		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
 	); err != nil {
@@ -178,6 +174,7 @@ func (n *createFunctionNode) createNewFunction(
 			IsProcedure:      udfDesc.IsProcedure(),
 			OutParamOrdinals: outParamOrdinals,
 			OutParamTypes:    outParamTypes,
+			IsVariadic:       udfDesc.IsVariadic(),
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -194,10 +191,12 @@ func (n *createFunctionNode) replaceFunction(
 	existing *tree.QualifiedOverload,
 ) error {
 
-	if n.cf.IsProcedure != udfDesc.IsProcedure() {
+	if n.cf.IsProcedure != udfDesc.IsProcedure() || udfDesc.IsAggregate() {
 		formatStr := "%q is a function"
 		if udfDesc.IsProcedure() {
 			formatStr = "%q is a procedure"
+		} else if udfDesc.IsAggregate() {
+			formatStr = "%q is an aggregate function"
 		}
 		return errors.WithDetailf(
 			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
@@ -260,45 +259,20 @@ func (n *createFunctionNode) replaceFunction(
 	}
 
 	// Removing all existing references before adding new references.
-	for _, id := range udfDesc.DependsOn {
-		backRefMutable, err := params.p.Descriptors().MutableByID(params.p.txn).Table(params.ctx, id)
-		if err != nil {
-			return err
-		}
-		backRefMutable.DependedOnBy = removeMatchingReferences(backRefMutable.DependedOnBy, udfDesc.ID)
-		jobDesc := fmt.Sprintf(
-			"removing udf reference %s(%d) in table %s(%d)",
-			udfDesc.Name, udfDesc.ID, backRefMutable.Name, backRefMutable.ID,
-		)
-		if err := params.p.writeSchemaChange(params.ctx, backRefMutable, descpb.InvalidMutationID, jobDesc); err != nil {
-			return err
-		}
-	}
-	jobDesc := fmt.Sprintf("updating type back reference %d for function %d", udfDesc.DependsOnTypes, udfDesc.ID)
-	if err := params.p.removeTypeBackReferences(params.ctx, udfDesc.DependsOnTypes, udfDesc.ID, jobDesc); err != nil {
+	if err := removeRoutineReferences(params, udfDesc); err != nil {
 		return err
 	}
-	for _, id := range udfDesc.DependsOnFunctions {
-		backRefMutable, err := params.p.Descriptors().MutableByID(params.p.txn).Function(params.ctx, id)
-		if err != nil {
-			return err
-		}
-		if err := backRefMutable.RemoveFunctionReference(udfDesc.ID); err != nil {
-			return err
-		}
-		if err := params.p.writeFuncSchemaChange(params.ctx, backRefMutable); err != nil {
-			return err
-		}
-	}
 	// Add all new references.
 	if err := n.addUDFReferences(udfDesc, params); err != nil {
 		return err
 	}
 
-	// The only allowed change is reordering OUT parameters in respect to input
-	// ones, but we have a more general "signature change" check here to be
-	// safe.
-	signatureChanged := len(existing.OutParamOrdinals) != len(outParamOrdinals)
+	// The only allowed changes are reordering OUT parameters in respect to input
+	// ones and marking the last input parameter as VARIADIC (or not), but we
+	// have a more general "signature change" check here to be safe.
+	_, wasVariadic := existing.Types.(tree.VariadicParamTypes)
+	signatureChanged := wasVariadic != udfDesc.IsVariadic() ||
+		len(existing.OutParamOrdinals) != len(outParamOrdinals)
 	for i := 0; !signatureChanged && i < len(outParamOrdinals); i++ {
 		signatureChanged = existing.OutParamOrdinals[i] != outParamOrdinals[i] ||
 			!existing.OutParamTypes.GetAt(i).Equivalent(outParamTypes[i])
@@ -315,6 +289,7 @@ func (n *createFunctionNode) replaceFunction(
 				IsProcedure:      n.cf.IsProcedure,
 				OutParamOrdinals: outParamOrdinals,
 				OutParamTypes:    outParamTypes,
+				IsVariadic:       udfDesc.IsVariadic(),
 			},
 		); err != nil {
 			return err
@@ -327,6 +302,42 @@ func (n *createFunctionNode) replaceFunction(
 	return params.p.writeFuncSchemaChange(params.ctx, udfDesc)
 }
 
+// removeRoutineReferences removes the back references to the given routine
+// from the relations, types and functions it depends on.
+func removeRoutineReferences(params runParams, udfDesc *funcdesc.Mutable) error {
+	for _, id := range udfDesc.DependsOn {
+		backRefMutable, err := params.p.Descriptors().MutableByID(params.p.txn).Table(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		backRefMutable.DependedOnBy = removeMatchingReferences(backRefMutable.DependedOnBy, udfDesc.ID)
+		jobDesc := fmt.Sprintf(
+			"removing udf reference %s(%d) in table %s(%d)",
+			udfDesc.Name, udfDesc.ID, backRefMutable.Name, backRefMutable.ID,
+		)
+		if err := params.p.writeSchemaChange(params.ctx, backRefMutable, descpb.InvalidMutationID, jobDesc); err != nil {
+			return err
+		}
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for function %d", udfDesc.DependsOnTypes, udfDesc.ID)
+	if err := params.p.removeTypeBackReferences(params.ctx, udfDesc.DependsOnTypes, udfDesc.ID, jobDesc); err != nil {
+		return err
+	}
+	for _, id := range udfDesc.DependsOnFunctions {
+		backRefMutable, err := params.p.Descriptors().MutableByID(params.p.txn).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefMutable.RemoveFunctionReference(udfDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefMutable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (n *createFunctionNode) getMutableFuncDesc(
 	scDesc catalog.SchemaDescriptor, params runParams,
 ) (fnDesc *funcdesc.Mutable, existing *tree.QualifiedOverload, err error) {
@@ -406,14 +417,30 @@ func (n *createFunctionNode) getMutableFuncDesc(
 }
 
 func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params runParams) error {
+	return addRoutineReferences(
+		params, udfDesc, n.cf.Name.String(), n.planDeps, n.typeDeps, n.functionDeps,
+	)
+}
+
+// addRoutineReferences adds the forward references of the given routine to
+// the relations, types and functions it depends on, as well as the
+// corresponding back references.
+func addRoutineReferences(
+	params runParams,
+	udfDesc *funcdesc.Mutable,
+	name string,
+	planDeps planDependencies,
+	typeDeps typeDependencies,
+	functionDeps functionDependencies,
+) error {
 	// Get all table IDs for which we need to update back references, including
 	// tables used directly in function body or as implicit types.
 	backrefTblIDs := catalog.DescriptorIDSet{}
 	implicitTypeTblIDs := catalog.DescriptorIDSet{}
-	for id := range n.planDeps {
+	for id := range planDeps {
 		backrefTblIDs.Add(id)
 	}
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		if isTable, err := params.p.descIsTable(params.ctx, id); err != nil {
 			return err
 		} else if isTable {
@@ -437,7 +464,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 		backRefMutables[id] = backRefMutable
 	}
 
-	for id, updated := range n.planDeps {
+	for id, updated := range planDeps {
 		backRefMutable := backRefMutables[id]
 		for _, dep := range updated.deps {
 			dep.ID = udfDesc.ID
@@ -450,7 +477,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 			backRefMutable,
 			descpb.InvalidMutationID,
 			fmt.Sprintf("updating udf reference %q in table %s(%d)",
-				n.cf.Name.String(), updated.desc.GetName(), updated.desc.GetID(),
+				name, updated.desc.GetName(), updated.desc.GetID(),
 			),
 		); err != nil {
 			return err
@@ -464,7 +491,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 			backRefMutable,
 			descpb.InvalidMutationID,
 			fmt.Sprintf("updating udf reference %q in table %s(%d)",
-				n.cf.Name.String(), backRefMutable.GetName(), backRefMutable.GetID(),
+				name, backRefMutable.GetName(), backRefMutable.GetID(),
 			),
 		); err != nil {
 			return err
@@ -473,7 +500,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 
 	// Add type back references. Skip table implicit types (we update table back
 	// references above).
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		if implicitTypeTblIDs.Contains(id) {
 			continue
 		}
@@ -483,8 +510,8 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 		}
 	}
 
-	udfDesc.DependsOnFunctions = make([]descpb.ID, 0, len(n.functionDeps))
-	for id := range n.functionDeps {
+	udfDesc.DependsOnFunctions = make([]descpb.ID, 0, len(functionDeps))
+	for id := range functionDeps {
 		// Add a reference to the dependency in here. Note that we need to add
 		// this dep before updating the back reference in case it's a
 		// self-dependent function (so that we get a proper error from
@@ -507,7 +534,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	udfDesc.DependsOn = backrefTblIDs.Ordered()
 
 	typeDepIDs := catalog.DescriptorIDSet{}
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		typeDepIDs.Add(id)
 	}
 	udfDesc.DependsOnTypes = typeDepIDs.Difference(implicitTypeTblIDs).Ordered()
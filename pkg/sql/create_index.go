@@ -12,6 +12,7 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
@@ -20,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -69,6 +71,23 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a table or materialized view", tableDesc.Name)
 	}
 
+	if n.Hash {
+		if n, err = schemaexpr.HashIndexAsExpressionIndex(n); err != nil {
+			return nil, err
+		}
+	}
+
+	if n.BlockRange {
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_BlockRangeIndexes) {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				"BRIN indexes are not supported until version 24.1")
+		}
+		if tableDesc.IsView() || tableDesc.IsForeignTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"cannot create BRIN index on %q", tableDesc.Name)
+		}
+	}
+
 	if tableDesc.MaterializedView() {
 		if n.Sharded != nil {
 			return nil, pgerror.New(pgcode.InvalidObjectDefinition,
@@ -750,6 +769,9 @@ func maybeCreateAndAddShardCol(
 
 func (n *createIndexNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("index"))
+	if n.n.BlockRange {
+		return n.startExecBlockRange(params)
+	}
 	foundIndex := catalog.FindIndexByName(n.tableDesc, string(n.n.Name))
 	if foundIndex != nil {
 		if foundIndex.Dropped() {
@@ -868,6 +890,123 @@ func (n *createIndexNode) startExec(params runParams) error {
 		})
 }
 
+// startExecBlockRange adds a block range index to the table. The index is
+// added in a non-public state, in which writes maintain its summaries, and is
+// made public by the schema changer once the existing rows are summarized.
+func (n *createIndexNode) startExecBlockRange(params runParams) error {
+	desc := n.tableDesc
+	if n.n.Unique {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes cannot be unique")
+	}
+	if n.n.Sharded != nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes cannot be hash sharded")
+	}
+	if len(n.n.Storing) > 0 {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes cannot store columns")
+	}
+	if n.n.PartitionByIndex.ContainsPartitioningClause() {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes cannot be partitioned")
+	}
+	if n.n.Predicate != nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes cannot be partial")
+	}
+	if n.n.Invisibility.Value != 0 {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes cannot be invisible")
+	}
+	if len(n.n.Columns) != 1 || n.n.Columns[0].Expr != nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes must summarize a single column")
+	}
+
+	pkIndex := desc.GetPrimaryIndex()
+	pkCol, err := catalog.MustFindColumnByID(desc, pkIndex.GetKeyColumnID(0))
+	if err != nil {
+		return err
+	}
+	if pkCol.GetType().Family() != types.IntFamily ||
+		pkIndex.GetKeyColumnDirection(0) != catenumpb.IndexColumn_ASC || pkIndex.IsSharded() {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot create BRIN index on %q", desc.GetName()),
+			"BRIN indexes require the leading primary key column to be an ascending INT.",
+		)
+	}
+	col, err := catalog.MustFindColumnByTreeName(desc, n.n.Columns[0].Column)
+	if err != nil {
+		return err
+	}
+	if !col.Public() {
+		return colinfo.NewUndefinedColumnError(string(n.n.Columns[0].Column))
+	}
+	if !colinfo.ColumnTypeIsIndexable(col.GetType()) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"column %s of type %s cannot be summarized by a BRIN index", col.GetName(), col.GetType().Name())
+	}
+
+	name := string(n.n.Name)
+	if name == "" {
+		base := fmt.Sprintf("%s_%s_idx", desc.GetName(), col.GetName())
+		name = base
+		for i := 1; blockRangeIndexNameInUse(desc, name); i++ {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+	} else if blockRangeIndexNameInUse(desc, name) {
+		if n.n.IfNotExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateRelation, "index with name %q already exists", name)
+	}
+
+	idx := descpb.TableDescriptor_BlockRangeIndex{
+		Name:      name,
+		ID:        desc.NextIndexID,
+		ColumnID:  col.GetID(),
+		RangeSize: defaultBlockRangeSize,
+	}
+	if err := storageparam.Set(
+		params.ctx,
+		params.p.SemaCtx(),
+		params.EvalContext(),
+		n.n.StorageParams,
+		&indexstorageparam.Setter{BlockRangeIndex: &idx},
+	); err != nil {
+		return err
+	}
+	desc.NextIndexID++
+	desc.BlockRangeIndexes = append(desc.BlockRangeIndexes, idx)
+
+	// Tables created in this transaction get a job too, so that rows written
+	// by CREATE TABLE AS are summarized after they are backfilled.
+	if err := params.p.createOrUpdateSchemaChangeJob(
+		params.ctx, desc, tree.AsStringWithFQNames(n.n, params.Ann()), descpb.InvalidMutationID,
+	); err != nil {
+		return err
+	}
+	if err := params.p.writeTableDesc(params.ctx, desc); err != nil {
+		return err
+	}
+
+	return params.p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateIndex{
+			TableName: n.n.Table.FQString(),
+			IndexName: name,
+		})
+}
+
+// blockRangeIndexNameInUse returns whether an index, including a block range
+// index, of the table has the given name.
+func blockRangeIndexNameInUse(desc catalog.TableDescriptor, name string) bool {
+	if catalog.FindIndexByName(desc, name) != nil {
+		return true
+	}
+	for _, idx := range desc.GetBlockRangeIndexes() {
+		if idx.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (*createIndexNode) Next(runParams) (bool, error) { return false, nil }
 func (*createIndexNode) Values() tree.Datums          { return tree.Datums{} }
 func (*createIndexNode) Close(context.Context)        {}
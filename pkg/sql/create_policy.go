@@ -0,0 +1,224 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tableDesc *tabledesc.Mutable
+	roleNames []string
+}
+
+// CreatePolicy creates a row-level security policy on a table.
+// Privileges: ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE POLICY",
+	); err != nil {
+		return nil, err
+	}
+	if err := p.checkRowLevelSecurityVersion(ctx); err != nil {
+		return nil, err
+	}
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkPolicyTableOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	switch n.Cmd {
+	case tree.PolicyCommandSelect, tree.PolicyCommandDelete:
+		if n.WithCheck != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case tree.PolicyCommandInsert:
+		if n.Using != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+
+	roles, err := decodeusername.FromRoleSpecList(
+		p.SessionData(), username.PurposeValidation, n.Roles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var roleNames []string
+	for _, role := range roles {
+		if role.IsPublicRole() {
+			// A policy that applies to PUBLIC applies to every role, which is
+			// represented by an empty list of roles.
+			roleNames = nil
+			break
+		}
+		if err := p.CheckRoleExists(ctx, role); err != nil {
+			return nil, err
+		}
+		roleNames = append(roleNames, role.Normalized())
+	}
+
+	return &createPolicyNode{n: n, tableDesc: tableDesc, roleNames: roleNames}, nil
+}
+
+// checkRowLevelSecurityVersion returns an error if row-level security, which
+// is stored in table descriptors and role options, is not supported by the
+// active cluster version. Nodes running older versions would ignore the
+// policies of a table.
+func (p *planner) checkRowLevelSecurityVersion(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_RowLevelSecurity) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"row-level security is not supported until version 24.1")
+	}
+	return nil
+}
+
+// checkPolicyTableOwnership returns an error if the current user is not the
+// owner of the table. Only the owner of a table may change its row-level
+// security policies and settings.
+func (p *planner) checkPolicyTableOwnership(
+	ctx context.Context, tableDesc *tabledesc.Mutable,
+) error {
+	hasOwnership, err := p.HasOwnership(ctx, tableDesc)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(tableDesc.GetName()))
+	}
+	return nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createPolicyNode) ReadingOwnWrites() {}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("policy"))
+	tableDesc := n.tableDesc
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == string(n.n.Name) {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.n.Name, tableDesc.GetName())
+		}
+	}
+
+	tn, err := params.p.getQualifiedTableName(params.ctx, tableDesc)
+	if err != nil {
+		return err
+	}
+	var colIDs catalog.TableColSet
+	buildExpr := func(expr tree.Expr, exprContext tree.SchemaExprContext) (string, error) {
+		if expr == nil {
+			return "", nil
+		}
+		serialized, _, cols, err := schemaexpr.DequalifyAndValidateExpr(
+			params.ctx,
+			tableDesc,
+			expr,
+			types.Bool,
+			exprContext,
+			params.p.SemaCtx(),
+			volatility.Volatile,
+			tn,
+			params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+		)
+		if err != nil {
+			return "", err
+		}
+		colIDs.UnionWith(cols)
+		return serialized, nil
+	}
+	usingExpr, err := buildExpr(n.n.Using, tree.PolicyUsingExpr)
+	if err != nil {
+		return err
+	}
+	withCheckExpr, err := buildExpr(n.n.WithCheck, tree.PolicyWithCheckExpr)
+	if err != nil {
+		return err
+	}
+
+	if tableDesc.NextPolicyID == 0 {
+		tableDesc.NextPolicyID = 1
+	}
+	tableDesc.Policies = append(tableDesc.Policies, descpb.TableDescriptor_Policy{
+		Name:          string(n.n.Name),
+		ID:            tableDesc.NextPolicyID,
+		Restrictive:   n.n.Type == tree.PolicyTypeRestrictive,
+		Command:       policyCommandToProto(n.n.Cmd),
+		RoleNames:     n.roleNames,
+		UsingExpr:     usingExpr,
+		WithCheckExpr: withCheckExpr,
+		ColumnIDs:     colIDs.Ordered(),
+	})
+	tableDesc.NextPolicyID++
+
+	if err := validateDescriptor(params.ctx, params.p, tableDesc); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
+
+// policyCommandToProto converts a tree.PolicyCommand to its descriptor
+// representation.
+func policyCommandToProto(cmd tree.PolicyCommand) descpb.TableDescriptor_Policy_Command {
+	switch cmd {
+	case tree.PolicyCommandAll:
+		return descpb.TableDescriptor_Policy_ALL
+	case tree.PolicyCommandSelect:
+		return descpb.TableDescriptor_Policy_SELECT
+	case tree.PolicyCommandInsert:
+		return descpb.TableDescriptor_Policy_INSERT
+	case tree.PolicyCommandUpdate:
+		return descpb.TableDescriptor_Policy_UPDATE
+	case tree.PolicyCommandDelete:
+		return descpb.TableDescriptor_Policy_DELETE
+	default:
+		panic(errors.AssertionFailedf("unknown policy command %d", cmd))
+	}
+}
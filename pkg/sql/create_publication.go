@@ -0,0 +1,183 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type createPublicationNode struct {
+	n           *tree.CreatePublication
+	dbDesc      *dbdesc.Mutable
+	publication descpb.DatabaseDescriptor_Publication
+}
+
+// CreatePublication creates a publication in the current database.
+// Privileges: CREATE on the database, ownership of the published tables, and
+// admin for FOR ALL TABLES.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"publications not supported until version 24.1")
+	}
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if dbDesc.GetID() == keys.SystemDatabaseID {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create a publication in the system database")
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if _, found := dbDesc.GetPublication(string(n.Name)); found {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", n.Name)
+	}
+
+	pub := descpb.DatabaseDescriptor_Publication{
+		Name:            string(n.Name),
+		AllTables:       n.AllTables,
+		PublishInsert:   true,
+		PublishUpdate:   true,
+		PublishDelete:   true,
+		PublishTruncate: true,
+		OwnerProto:      p.User().EncodeProto(),
+	}
+	if n.AllTables {
+		isAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			return nil, pgerror.New(pgcode.InsufficientPrivilege,
+				"must be admin to create FOR ALL TABLES publication")
+		}
+	}
+	var tableIDs catalog.DescriptorIDSet
+	for i := range n.Tables {
+		tn := &n.Tables[i]
+		_, tableDesc, err := resolver.ResolveExistingTableObject(ctx, p, tn, tree.ObjectLookupFlags{
+			Required:             true,
+			DesiredObjectKind:    tree.TableObject,
+			DesiredTableDescKind: tree.ResolveRequireTableDesc,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if tableDesc.GetParentID() != dbDesc.GetID() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot add relation %q to publication: it is not in the current database",
+				tree.ErrString(tn))
+		}
+		if tableDesc.IsTemporary() {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add relation %q to publication: temporary tables cannot be replicated",
+				tree.ErrString(tn))
+		}
+		hasOwnership, err := p.HasOwnership(ctx, tableDesc)
+		if err != nil {
+			return nil, err
+		}
+		if !hasOwnership {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of table %s", tree.Name(tableDesc.GetName()))
+		}
+		if !tableIDs.Contains(tableDesc.GetID()) {
+			tableIDs.Add(tableDesc.GetID())
+			pub.TableIDs = append(pub.TableIDs, tableDesc.GetID())
+		}
+	}
+
+	exprEval := p.ExprEvaluator("CREATE PUBLICATION")
+	for _, opt := range n.Options {
+		switch key := strings.ToLower(string(opt.Key)); key {
+		case "publish":
+			publish, err := exprEval.String(ctx, paramparse.UnresolvedNameToStrVal(opt.Value))
+			if err != nil {
+				return nil, err
+			}
+			pub.PublishInsert, pub.PublishUpdate, pub.PublishDelete, pub.PublishTruncate =
+				false, false, false, false
+			for _, op := range strings.Split(publish, ",") {
+				switch op = strings.ToLower(strings.TrimSpace(op)); op {
+				case "insert":
+					pub.PublishInsert = true
+				case "update":
+					pub.PublishUpdate = true
+				case "delete":
+					pub.PublishDelete = true
+				case "truncate":
+					pub.PublishTruncate = true
+				default:
+					return nil, pgerror.Newf(pgcode.Syntax,
+						"unrecognized value for publication option %q: %q", key, op)
+				}
+			}
+		case "publish_via_partition_root":
+			// Partitions are not separate relations, so changes are always
+			// published using the identity of the table.
+			if _, err := exprEval.Bool(ctx, opt.Value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.Syntax, "unrecognized publication parameter: %q", key),
+				"supported parameters are publish and publish_via_partition_root",
+			)
+		}
+	}
+	return &createPublicationNode{n: n, dbDesc: dbDesc, publication: pub}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createPublicationNode) ReadingOwnWrites() {}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("publication"))
+	n.dbDesc.Publications = append(n.dbDesc.Publications, n.publication)
+	if err := validateDescriptor(params.ctx, params.p, n.dbDesc); err != nil {
+		return err
+	}
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPublicationNode) Close(context.Context)        {}
@@ -97,6 +97,12 @@ func (p *planner) CreateRoleNode(
 		return nil, err
 	}
 
+	if roleOptions.Contains(roleoption.BYPASSRLS) {
+		if err := p.checkRowLevelSecurityVersion(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Using CREATE ROLE syntax enables NOLOGIN by default.
 	if isRole && !roleOptions.Contains(roleoption.LOGIN) && !roleOptions.Contains(roleoption.NOLOGIN) {
 		roleOptions = append(roleOptions,
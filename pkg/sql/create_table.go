@@ -192,11 +192,13 @@ func getSchemaForCreateTable(
 				mismatchedType = false
 				switch kind {
 				case tree.ResolveRequireTableDesc:
-					mismatchedType = !tableDescriptor.IsTable()
+					mismatchedType = !tableDescriptor.IsTable() || tableDescriptor.IsForeignTable()
 				case tree.ResolveRequireViewDesc:
 					mismatchedType = !tableDescriptor.IsView()
 				case tree.ResolveRequireSequenceDesc:
 					mismatchedType = !tableDescriptor.IsSequence()
+				case tree.ResolveRequireForeignTableDesc:
+					mismatchedType = !tableDescriptor.IsForeignTable()
 				}
 				// If kind any is passed then there will never be a mismatch
 				// and we can return an exists error.
@@ -672,6 +674,9 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintDeferrability{},
+		"",  /* exclusionMethod */
+		nil, /* exclusionOps */
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -694,11 +699,19 @@ func addUniqueWithoutIndexTableDef(
 	validationBehavior tree.ValidationBehavior,
 	semaCtx *tree.SemaContext,
 ) error {
-	if !sessionData.EnableUniqueWithoutIndexConstraints {
+	// EXCLUDE constraints are stored as unique constraints without an index,
+	// but they are not experimental.
+	if !sessionData.EnableUniqueWithoutIndexConstraints && !d.IsExclusion() {
 		return pgerror.New(pgcode.FeatureNotSupported,
 			"unique constraints without an index are not yet supported",
 		)
 	}
+	// Nodes running older versions would enforce an EXCLUDE constraint as a
+	// plain UNIQUE WITHOUT INDEX constraint.
+	if d.IsExclusion() && !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1_ExclusionConstraints) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"EXCLUDE constraints are not supported until version 24.1")
+	}
 	if len(d.Storing) > 0 {
 		return pgerror.New(pgcode.FeatureNotSupported,
 			"unique constraints without an index cannot store columns",
@@ -716,6 +729,16 @@ func addUniqueWithoutIndexTableDef(
 			"creating a unique constraint using UNIQUE WITH NOT VISIBLE INDEX is not supported",
 		)
 	}
+	if d.Deferrability.Deferrable {
+		if d.IsExclusion() {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"only UNIQUE WITHOUT INDEX constraints can be DEFERRABLE",
+			)
+		}
+		if err := checkDeferrableConstraintsVersion(ctx, evalCtx); err != nil {
+			return err
+		}
+	}
 
 	// If there is a predicate, validate it.
 	var predicate string
@@ -735,7 +758,8 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability,
+		string(d.ExclusionMethod), d.ExclusionOps, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -744,7 +768,8 @@ func addUniqueWithoutIndexTableDef(
 
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
-// constraint to the descriptor.
+// constraint to the descriptor. If exclusionOps is not empty, the constraint
+// is an EXCLUDE constraint that compares the columns with the given operators.
 //
 // The passed validationBehavior is used to determine whether or not preexisting
 // entries in the table need to be validated against the unique constraint being
@@ -755,6 +780,9 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
+	exclusionMethod string,
+	exclusionOps []treecmp.ComparisonOperator,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -774,10 +802,28 @@ func ResolveUniqueWithoutIndexConstraint(
 		cols[i] = col
 	}
 
+	var exclusionOperators []string
+	if len(exclusionOps) > 0 {
+		var err error
+		colTypes := make([]*types.T, len(cols))
+		for i := range cols {
+			colTypes[i] = cols[i].GetType()
+		}
+		exclusionOperators, err = schemaexpr.ValidateExclusionOperators(exclusionMethod, colTypes, exclusionOps)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
+		name := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(exclusionOps) > 0 {
+			// This matches the name Postgres generates for exclusion constraints.
+			name = fmt.Sprintf("%s_%s_excl", tbl.GetName(), strings.Join(colNames, "_"))
+		}
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			name,
 			func(p string) bool {
 				return catalog.FindConstraintByName(tbl, p) != nil
 			},
@@ -803,12 +849,16 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:               constraintName,
+		TableID:            tbl.ID,
+		ColumnIDs:          columnIDs,
+		Predicate:          predicate,
+		Validity:           validity,
+		ConstraintID:       tbl.NextConstraintID,
+		Deferrable:         deferrability.Deferrable,
+		InitiallyDeferred:  deferrability.InitiallyDeferred,
+		ExclusionOperators: exclusionOperators,
+		ExclusionMethod:    exclusionMethod,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -858,6 +908,11 @@ func ResolveFK(
 	validationBehavior tree.ValidationBehavior,
 	evalCtx *eval.Context,
 ) error {
+	if d.Deferrability.Deferrable {
+		if err := checkDeferrableConstraintsVersion(ctx, evalCtx); err != nil {
+			return err
+		}
+	}
 	var originColSet catalog.TableColSet
 	originCols := make([]catalog.Column, len(d.FromCols))
 	for i, fromCol := range d.FromCols {
@@ -1053,6 +1108,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.Deferrable,
+		InitiallyDeferred:   d.Deferrability.InitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1343,8 +1400,10 @@ func NewTableDesc(
 	}
 
 	var dbID descpb.ID
+	var defaultCollation string
 	if db != nil {
 		dbID = db.GetID()
+		defaultCollation = db.GetDefaultCollation()
 	}
 	desc := tabledesc.InitTableDescriptor(
 		id, dbID, sc.GetID(), n.Table.Table(), creationTime, privileges, persistence,
@@ -1617,6 +1676,7 @@ func NewTableDesc(
 				return nil, pgerror.Newf(pgcode.Syntax, "virtual columns cannot have family specifications")
 			}
 
+			tabledesc.MaybeApplyDefaultCollation(d, defaultCollation)
 			cdd[i], err = tabledesc.MakeColumnDefDescs(ctx, d, semaCtx, evalCtx, tree.ColumnDefaultExprInNewTable)
 			if err != nil {
 				return nil, err
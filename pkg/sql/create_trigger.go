@@ -0,0 +1,317 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+	funcDesc  *funcdesc.Mutable
+}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: ownership of the table, and EXECUTE on the trigger function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create triggers", clusterversion.V24_1)
+	}
+	tn := n.TableName.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !tableDesc.IsPhysicalTable() || tableDesc.IsSequence() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q cannot have triggers", tableDesc.GetName())
+	}
+	hasOwnership, err := p.HasOwnership(ctx, tableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if !hasOwnership {
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(tableDesc.GetName()))
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case n.ActionTime == tree.TriggerActionTimeInsteadOf:
+		return nil, unimplemented.NewWithIssue(28296, "INSTEAD OF triggers")
+	case n.ForEach != tree.TriggerForEachRow:
+		return nil, unimplemented.NewWithIssue(28296, "FOR EACH STATEMENT triggers")
+	case len(n.Transitions) > 0:
+		return nil, unimplemented.NewWithIssue(28296, "trigger transition tables")
+	}
+	for _, ev := range n.Events {
+		if ev.EventType == tree.TriggerEventTruncate {
+			return nil, unimplemented.NewWithIssue(28296, "TRUNCATE triggers")
+		}
+	}
+
+	funcDesc, err := p.resolveTriggerFunction(ctx, n.FuncName)
+	if err != nil {
+		return nil, err
+	}
+	return &createTriggerNode{n: n, tableDesc: tableDesc, funcDesc: funcDesc}, nil
+}
+
+// resolveTriggerFunction resolves the function executed by a trigger. It must
+// be a user-defined function without parameters that returns TRIGGER.
+func (p *planner) resolveTriggerFunction(
+	ctx context.Context, name *tree.UnresolvedName,
+) (*funcdesc.Mutable, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(ctx, tree.MakeUnresolvedFunctionName(name), &path)
+	if err != nil {
+		return nil, err
+	}
+	var ol *tree.QualifiedOverload
+	for i := range fnDef.Overloads {
+		if o := &fnDef.Overloads[i]; o.Type == tree.UDFRoutine && o.Types.Length() == 0 {
+			ol = o
+			break
+		}
+	}
+	if ol == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedFunction, "function %s() does not exist", name)
+	}
+	if ol.FixedReturnType().Family() != types.TriggerFamily {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", name)
+	}
+	funcDesc, err := p.Descriptors().MutableByID(p.txn).Function(
+		ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, funcDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return funcDesc, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createTriggerNode) ReadingOwnWrites() {}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("trigger"))
+	tableDesc := n.tableDesc
+
+	existing := -1
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.n.Name) {
+			existing = i
+			break
+		}
+	}
+	if existing >= 0 && !n.n.Replace {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"trigger %q for relation %q already exists", n.n.Name, tableDesc.GetName())
+	}
+
+	trigger := descpb.TableDescriptor_Trigger{
+		Name:       string(n.n.Name),
+		ForEachRow: true,
+		FuncID:     n.funcDesc.GetID(),
+		FuncArgs:   n.n.FuncArgs,
+	}
+	switch n.n.ActionTime {
+	case tree.TriggerActionTimeBefore:
+		trigger.ActionTime = descpb.TableDescriptor_Trigger_BEFORE
+	case tree.TriggerActionTimeAfter:
+		trigger.ActionTime = descpb.TableDescriptor_Trigger_AFTER
+	default:
+		return errors.AssertionFailedf("unexpected trigger action time %s", n.n.ActionTime)
+	}
+
+	var colIDs catalog.TableColSet
+	var hasInsert, hasDelete bool
+	for _, ev := range n.n.Events {
+		event := descpb.TableDescriptor_Trigger_Event{}
+		switch ev.EventType {
+		case tree.TriggerEventInsert:
+			event.Type = descpb.TableDescriptor_Trigger_Event_INSERT
+			hasInsert = true
+		case tree.TriggerEventUpdate:
+			event.Type = descpb.TableDescriptor_Trigger_Event_UPDATE
+		case tree.TriggerEventDelete:
+			event.Type = descpb.TableDescriptor_Trigger_Event_DELETE
+			hasDelete = true
+		default:
+			return errors.AssertionFailedf("unexpected trigger event %s", ev.EventType)
+		}
+		for _, colName := range ev.Columns {
+			col, err := catalog.MustFindColumnByTreeName(tableDesc, colName)
+			if err != nil {
+				return err
+			}
+			event.ColumnIDs = append(event.ColumnIDs, col.GetID())
+			colIDs.Add(col.GetID())
+		}
+		trigger.Events = append(trigger.Events, event)
+	}
+
+	if n.n.When != nil {
+		whenCols, err := validateTriggerWhenExpr(
+			params.ctx, params.p.SemaCtx(), tableDesc, n.n.When,
+			n.n.ActionTime == tree.TriggerActionTimeBefore, hasInsert, hasDelete,
+		)
+		if err != nil {
+			return err
+		}
+		trigger.WhenExpr = tree.Serialize(n.n.When)
+		colIDs.UnionWith(whenCols)
+	}
+	trigger.ColumnIDs = colIDs.Ordered()
+
+	if existing >= 0 {
+		// Replace the trigger in place, keeping its ID.
+		old := &tableDesc.Triggers[existing]
+		trigger.ID = old.ID
+		if old.FuncID != trigger.FuncID {
+			oldFuncDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Function(params.ctx, old.FuncID)
+			if err != nil {
+				return err
+			}
+			oldFuncDesc.RemoveTriggerReference(tableDesc.GetID(), old.ID)
+			if err := params.p.writeFuncSchemaChange(params.ctx, oldFuncDesc); err != nil {
+				return err
+			}
+		}
+		*old = trigger
+	} else {
+		if tableDesc.NextTriggerID == 0 {
+			tableDesc.NextTriggerID = 1
+		}
+		trigger.ID = tableDesc.NextTriggerID
+		tableDesc.NextTriggerID++
+		tableDesc.Triggers = append(tableDesc.Triggers, trigger)
+	}
+
+	n.funcDesc.AddTriggerReference(tableDesc.GetID(), trigger.ID)
+	if err := params.p.writeFuncSchemaChange(params.ctx, n.funcDesc); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, params.p, tableDesc); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
+
+// validateTriggerWhenExpr validates the WHEN condition of a trigger and returns
+// the IDs of the columns that it references. The condition may only refer to
+// columns of the NEW and OLD rows, and must be a boolean expression without
+// subqueries.
+func validateTriggerWhenExpr(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	tableDesc catalog.TableDescriptor,
+	when tree.Expr,
+	isBefore, hasInsert, hasDelete bool,
+) (catalog.TableColSet, error) {
+	var colIDs catalog.TableColSet
+	// Replace each column reference with a NULL of the column type, so that the
+	// expression can be type-checked.
+	replaced, err := tree.SimpleVisit(when, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		c, ok := v.(*tree.ColumnItem)
+		if !ok {
+			return false, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s is not supported in a trigger WHEN condition", v)
+		}
+		var row string
+		if c.TableName != nil && c.TableName.NumParts == 1 {
+			row = c.TableName.Parts[0]
+		}
+		switch row {
+		case "new":
+			if hasDelete {
+				return false, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+					"DELETE trigger's WHEN condition cannot reference NEW values")
+			}
+		case "old":
+			if hasInsert {
+				return false, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+					"INSERT trigger's WHEN condition cannot reference OLD values")
+			}
+		default:
+			return false, nil, errors.WithHint(
+				pgerror.Newf(pgcode.UndefinedColumn, "column %q does not exist", c),
+				"A trigger WHEN condition can only refer to columns of the NEW and OLD rows.",
+			)
+		}
+		col, err := catalog.MustFindColumnByTreeName(tableDesc, c.ColumnName)
+		if err != nil {
+			return false, nil, err
+		}
+		if isBefore && row == "new" && col.IsComputed() {
+			return false, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+				"BEFORE trigger's WHEN condition cannot reference NEW generated columns")
+		}
+		colIDs.Add(col.GetID())
+		return false, &tree.CastExpr{Expr: tree.DNull, Type: col.GetType(), SyntaxMode: tree.CastShort}, nil
+	})
+	if err != nil {
+		return catalog.TableColSet{}, err
+	}
+
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	semaCtx.Properties.Require("WHEN", tree.RejectSpecial|tree.RejectSubqueries)
+	if _, err := tree.TypeCheckAndRequire(ctx, replaced, semaCtx, types.Bool, "WHEN"); err != nil {
+		return catalog.TableColSet{}, err
+	}
+	return colIDs, nil
+}
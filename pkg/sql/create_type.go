@@ -20,8 +20,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/enum"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -31,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -237,6 +241,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -323,6 +329,15 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Domain:
+		if !p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V24_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to create domains",
+				clusterversion.V24_1)
+		}
+		return params.p.createDomainWithID(
+			params, id, n.n.DomainDef, n.dbDesc, n.typeName,
+		)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -464,6 +479,151 @@ func CreateCompositeTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// CreateDomainTypeDesc creates a new domain type descriptor.
+func CreateDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	domainDef *tree.DomainDef,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := tree.ResolveType(params.ctx, domainDef.BaseType, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, baseType); err != nil {
+		return nil, err
+	}
+	if baseType.UserDefined() {
+		return nil, unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+	switch baseType.Family() {
+	case types.ArrayFamily, types.TupleFamily, types.RangeFamily, types.MultiRangeFamily:
+		return nil, unimplemented.NewWithIssuef(27796,
+			"domains over %s types are not yet supported", baseType.Family().Name())
+	}
+	if err := colinfo.ValidateColumnDefType(params.ctx, params.ExecCfg().Settings.Version, baseType); err != nil {
+		return nil, err
+	}
+	domain := &descpb.TypeDescriptor_Domain{
+		BaseType: baseType,
+		NotNull:  domainDef.Nullable.Nullability == tree.NotNull,
+	}
+
+	if domainDef.DefaultExpr != nil {
+		s, err := sanitizeDomainDefaultExpr(params, domainDef.DefaultExpr, baseType, tree.DomainDefaultExpr)
+		if err != nil {
+			return nil, err
+		}
+		domain.DefaultExpr = &s
+	}
+
+	usedNames := make(map[string]struct{})
+	for _, c := range domainDef.CheckExprs {
+		if c.ConstraintName == "" {
+			continue
+		}
+		if _, ok := usedNames[string(c.ConstraintName)]; ok {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", c.ConstraintName, typeName.Type())
+		}
+		usedNames[string(c.ConstraintName)] = struct{}{}
+	}
+	for _, c := range domainDef.CheckExprs {
+		if err := validateDomainCheckExpr(params, c.Expr, baseType, tree.DomainCheckExpr); err != nil {
+			return nil, err
+		}
+		name := string(c.ConstraintName)
+		if name == "" {
+			name = makeDomainCheckConstraintName(typeName.Type(), usedNames)
+			usedNames[name] = struct{}{}
+		}
+		domain.CheckConstraints = append(domain.CheckConstraints, descpb.TypeDescriptor_Domain_CheckConstraint{
+			Name: name,
+			Expr: tree.Serialize(c.Expr),
+		})
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// sanitizeDomainDefaultExpr type checks the DEFAULT expression of a domain with
+// the given base type and returns its serialized form.
+func sanitizeDomainDefaultExpr(
+	params runParams, expr tree.Expr, baseType *types.T, exprContext tree.SchemaExprContext,
+) (string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		params.ctx, expr, baseType, exprContext,
+		params.p.SemaCtx(), volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	version := params.ExecCfg().Settings.Version.ActiveVersionOrEmpty(params.ctx)
+	if err := funcdesc.MaybeFailOnUDFUsage(typedExpr, exprContext, version); err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// validateDomainCheckExpr type checks a CHECK constraint expression of a domain
+// with the given base type. The expression is type checked with a NULL of the
+// base type in place of VALUE, and the original expression is what gets stored
+// so that VALUE can be substituted during evaluation.
+func validateDomainCheckExpr(
+	params runParams, expr tree.Expr, baseType *types.T, exprContext tree.SchemaExprContext,
+) error {
+	nullValue := &tree.CastExpr{Expr: tree.DNull, Type: baseType, SyntaxMode: tree.CastShort}
+	expr, err := tree.ReplaceDomainValue(expr, nullValue)
+	if err != nil {
+		return err
+	}
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		params.ctx, expr, types.Bool, exprContext,
+		params.p.SemaCtx(), volatility.Volatile, false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return err
+	}
+	version := params.ExecCfg().Settings.Version.ActiveVersionOrEmpty(params.ctx)
+	return funcdesc.MaybeFailOnUDFUsage(typedExpr, exprContext, version)
+}
+
+// makeDomainCheckConstraintName returns the name of an unnamed CHECK constraint
+// of a domain, which is generated like in Postgres to be distinct from the
+// used names.
+func makeDomainCheckConstraintName(domainName string, usedNames map[string]struct{}) string {
+	name := domainName + "_check"
+	for i := 1; ; i++ {
+		if _, ok := usedNames[name]; !ok {
+			return name
+		}
+		name = fmt.Sprintf("%s_check%d", domainName, i)
+	}
+}
+
 func (p *planner) createEnumWithID(
 	params runParams,
 	id descpb.ID,
@@ -516,6 +676,27 @@ func (p *planner) createCompositeWithID(
 	return nil
 }
 
+func (p *planner) createDomainWithID(
+	params runParams,
+	id descpb.ID,
+	domainDef *tree.DomainDef,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := CreateDomainTypeDesc(params, id, domainDef, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params, id, typeName, typeDesc, dbDesc, schema)
+}
+
 func (p *planner) finishCreateType(
 	params runParams,
 	id descpb.ID,
@@ -38,6 +38,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/tablestorageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -173,6 +175,9 @@ func (n *createViewNode) startExec(params runParams) error {
 	if createView.Persistence.IsTemporary() {
 		telemetry.Inc(sqltelemetry.CreateTempViewCounter)
 	}
+	if createView.Recursive {
+		telemetry.Inc(sqltelemetry.CreateRecursiveViewCounter)
+	}
 
 	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
 		n.dbDesc.GetDefaultPrivilegeDescriptor(),
@@ -256,6 +261,18 @@ func (n *createViewNode) startExec(params runParams) error {
 					if err := desc.AllocateIDs(params.ctx, version); err != nil {
 						return err
 					}
+					if err := storageparam.Set(
+						params.ctx,
+						params.p.SemaCtx(),
+						params.EvalContext(),
+						createView.StorageParams,
+						tablestorageparam.NewSetter(&desc),
+					); err != nil {
+						return err
+					}
+					if err := params.p.maybeStartMaterializedViewRefreshJob(params.ctx, &desc); err != nil {
+						return err
+					}
 					// For multi-region databases, we want this descriptor to be GLOBAL instead.
 					if n.dbDesc.IsMultiRegion() {
 						desc.SetTableLocalityGlobal()
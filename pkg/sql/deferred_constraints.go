@@ -0,0 +1,467 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// deferredConstraintKeyBatchSize is the maximum number of keys of a deferred
+// constraint that are validated by looking up the rows with those keys. If
+// more keys were recorded, the constraint is validated against the whole
+// table instead, which is cheaper than a large number of point lookups.
+const deferredConstraintKeyBatchSize = 100
+
+// deferredConstraintMaxKeys is the maximum number of keys that are recorded
+// for a single deferred constraint. Past it, the keys are dropped and the
+// constraint is validated against the whole table.
+const deferredConstraintMaxKeys = 10000
+
+// deferredConstraintCheck identifies a deferrable constraint whose checks were
+// postponed until the end of the transaction.
+type deferredConstraintCheck struct {
+	tableID descpb.ID
+	name    string
+	// keys are the values of the constrained columns of the rows that violated
+	// the constraint when their statement was executed, deduplicated by their
+	// string representation. Rows that satisfied the constraint can only be
+	// made to violate it by later mutations, which are checked in turn, so only
+	// these keys need to be validated again.
+	keys map[string]tree.Datums
+	// keysSize is the memory accounted for keys.
+	keysSize int64
+	// full is set once keys were dropped, either because there were more than
+	// deferredConstraintMaxKeys of them or because they did not fit in the
+	// memory budget of the session. The constraint is then validated against
+	// the whole table.
+	full bool
+}
+
+// spill drops the keys of the check, which is then validated against the
+// whole table, and returns the memory that was accounted for them.
+func (c *deferredConstraintCheck) spill() (released int64) {
+	released = c.keysSize
+	c.keys = nil
+	c.keysSize = 0
+	c.full = true
+	return released
+}
+
+// deferredConstraintChecks is the set of deferred constraint checks that are
+// pending in the current transaction. It is safe for concurrent use, since
+// checks may run in parallel.
+type deferredConstraintChecks struct {
+	mu      syncutil.Mutex
+	pending []*deferredConstraintCheck
+	// memAcc accounts for the keys of the pending checks.
+	memAcc mon.BoundAccount
+}
+
+func (d *deferredConstraintChecks) add(
+	ctx context.Context, tableID descpb.ID, name string, key tree.Datums,
+) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var c *deferredConstraintCheck
+	for _, p := range d.pending {
+		if p.tableID == tableID && p.name == name {
+			c = p
+			break
+		}
+	}
+	if c == nil {
+		c = &deferredConstraintCheck{tableID: tableID, name: name, keys: make(map[string]tree.Datums)}
+		d.pending = append(d.pending, c)
+	}
+	if c.full {
+		return
+	}
+	k := tree.AsStringWithFlags(&key, tree.FmtParsable)
+	if _, ok := c.keys[k]; ok {
+		return
+	}
+	size := int64(len(k))
+	for _, datum := range key {
+		size += int64(datum.Size())
+	}
+	if len(c.keys) >= deferredConstraintMaxKeys || d.memAcc.Grow(ctx, size) != nil {
+		d.memAcc.Shrink(ctx, c.spill())
+		return
+	}
+	c.keys[k] = key
+	c.keysSize += size
+}
+
+func (d *deferredConstraintChecks) reset(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = nil
+	d.memAcc.Clear(ctx)
+}
+
+// constraintDeferrability returns whether the given constraint is deferrable,
+// and whether it is initially deferred.
+func constraintDeferrability(c catalog.Constraint) (deferrable, initiallyDeferred bool) {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred
+	}
+	if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		return uwoi.UniqueWithoutIndexDesc().Deferrable, uwoi.UniqueWithoutIndexDesc().InitiallyDeferred
+	}
+	return false, false
+}
+
+// checkDeferrableConstraintsVersion returns an error if deferrable constraints
+// are not supported by the active cluster version.
+func checkDeferrableConstraintsVersion(ctx context.Context, evalCtx *eval.Context) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1_DeferrableConstraints) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"deferrable constraints are not supported until version 24.1")
+	}
+	return nil
+}
+
+// CanDeferConstraintChecks is part of the eval.Planner interface.
+func (p *planner) CanDeferConstraintChecks() bool {
+	// Implicit transactions are committed at the end of the statement, so
+	// there is nothing to gain by deferring their checks. Internal executors
+	// don't track deferred checks since they may not commit the transaction
+	// they run in.
+	return !p.extendedEvalCtx.TxnImplicit && p.extendedEvalCtx.deferredConstraintChecks != nil
+}
+
+// DeferConstraintCheck is part of the eval.Planner interface.
+func (p *planner) DeferConstraintCheck(
+	ctx context.Context, tableID int, constraintName string, key tree.Datums,
+) {
+	p.extendedEvalCtx.deferredConstraintChecks.add(ctx, descpb.ID(tableID), constraintName, key)
+}
+
+// validateDeferredConstraints validates the pending deferred constraint checks
+// of the transaction. If onlyImmediate is true, only the constraints that are
+// no longer deferred according to the current constraint modes are validated,
+// and the others remain pending.
+func (p *planner) validateDeferredConstraints(ctx context.Context, onlyImmediate bool) error {
+	d := p.extendedEvalCtx.deferredConstraintChecks
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	modes := p.SessionData().ConstraintModes
+	var stillDeferred []*deferredConstraintCheck
+	var released int64
+	defer func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.memAcc.Shrink(ctx, released)
+	}()
+	for _, c := range pending {
+		tableDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Table(ctx, c.tableID)
+		if err != nil {
+			return err
+		}
+		// The constraint may have been dropped by the transaction after its
+		// checks were deferred.
+		var constraint catalog.Constraint
+		if !tableDesc.Dropped() {
+			constraint = catalog.FindConstraintByName(tableDesc, c.name)
+		}
+		if constraint != nil && constraint.IsEnforced() && onlyImmediate {
+			_, initiallyDeferred := constraintDeferrability(constraint)
+			if modes.IsDeferred(c.name, initiallyDeferred) {
+				stillDeferred = append(stillDeferred, c)
+				continue
+			}
+		}
+		released += c.keysSize
+		if constraint == nil || !constraint.IsEnforced() {
+			continue
+		}
+		if c.full || len(c.keys) > deferredConstraintKeyBatchSize {
+			err = p.validateDeferredConstraintFully(ctx, tableDesc, constraint)
+		} else {
+			keys := make([]tree.Datums, 0, len(c.keys))
+			for _, key := range c.keys {
+				keys = append(keys, key)
+			}
+			err = p.validateDeferredConstraint(ctx, tableDesc, constraint, keys)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	d.mu.Lock()
+	d.pending = append(stillDeferred, d.pending...)
+	d.mu.Unlock()
+	return nil
+}
+
+// validateDeferredConstraintFully checks that all the rows of the given table
+// satisfy the given deferred constraint. It uses the same set-based queries as
+// the validation of a constraint that is added to an existing table.
+func (p *planner) validateDeferredConstraintFully(
+	ctx context.Context, tableDesc catalog.TableDescriptor, constraint catalog.Constraint,
+) error {
+	txn := p.InternalSQLTxn()
+	if fk := constraint.AsForeignKey(); fk != nil {
+		targetDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Table(ctx, fk.GetReferencedTableID())
+		if err != nil {
+			return err
+		}
+		if targetDesc.Dropped() {
+			return nil
+		}
+		return validateForeignKey(
+			ctx, txn, tableDesc, targetDesc, fk.ForeignKeyDesc(), 0, /* indexIDForValidation */
+		)
+	}
+	if uc := constraint.AsUniqueWithoutIndex(); uc != nil {
+		return validateUniqueWithoutIndexConstraint(
+			ctx, tableDesc, uc, 0 /* indexIDForValidation */, txn, p.User(), true, /* preExisting */
+		)
+	}
+	return nil
+}
+
+// validateDeferredConstraint checks that the rows of the given table with the
+// given keys satisfy the given deferred constraint.
+func (p *planner) validateDeferredConstraint(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	constraint catalog.Constraint,
+	keys []tree.Datums,
+) error {
+	txn := p.InternalSQLTxn()
+	if fk := constraint.AsForeignKey(); fk != nil {
+		targetDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Table(ctx, fk.GetReferencedTableID())
+		if err != nil {
+			return err
+		}
+		if targetDesc.Dropped() {
+			return nil
+		}
+		originColNames, err := catalog.ColumnNamesForIDs(tableDesc, fk.ForeignKeyDesc().OriginColumnIDs)
+		if err != nil {
+			return err
+		}
+		referencedColNames, err := catalog.ColumnNamesForIDs(targetDesc, fk.ForeignKeyDesc().ReferencedColumnIDs)
+		if err != nil {
+			return err
+		}
+		on := make([]string, len(originColNames))
+		for i := range originColNames {
+			on[i] = fmt.Sprintf(
+				"t.%s = s.%s", tree.NameString(referencedColNames[i]), tree.NameString(originColNames[i]),
+			)
+		}
+		query := fmt.Sprintf(
+			`SELECT %[1]s FROM [%[2]d AS s]@{IGNORE_FOREIGN_KEYS}
+			 WHERE (%[3]s) AND NOT EXISTS (SELECT 1 FROM [%[4]d AS t] WHERE %[5]s) LIMIT 1`,
+			columnList(originColNames),                        // 1
+			tableDesc.GetID(),                                 // 2
+			deferredConstraintKeyFilter(originColNames, keys), // 3
+			targetDesc.GetID(),                                // 4
+			strings.Join(on, " AND "),                         // 5
+		)
+		values, err := txn.QueryRowEx(ctx, "validate deferred fk constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query)
+		if err != nil {
+			return err
+		}
+		if values.Len() > 0 {
+			return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
+				"foreign key violation: %q row %s has no match in %q",
+				tableDesc.GetName(), formatValues(originColNames, values), targetDesc.GetName()), fk.GetName())
+		}
+		return nil
+	}
+	if uc := constraint.AsUniqueWithoutIndex(); uc != nil {
+		colNames, err := catalog.ColumnNamesForIDs(tableDesc, uc.CollectKeyColumnIDs().Ordered())
+		if err != nil {
+			return err
+		}
+		where := deferredConstraintKeyFilter(colNames, keys)
+		if uc.IsPartial() {
+			where = fmt.Sprintf("(%s) AND (%s)", where, uc.GetPredicate())
+		}
+		query := fmt.Sprintf(
+			`SELECT %[1]s FROM [%[2]d AS tbl] WHERE %[3]s GROUP BY %[1]s HAVING count(*) > 1 LIMIT 1`,
+			columnList(colNames), // 1
+			tableDesc.GetID(),    // 2
+			where,                // 3
+		)
+		values, err := queryValidationRow(ctx, txn, p.User(), "validate deferred unique constraint", query)
+		if err != nil {
+			return err
+		}
+		if values.Len() > 0 {
+			return makeUniqueValidationError(uc.GetName(), colNames, values, true /* preExisting */)
+		}
+	}
+	return nil
+}
+
+// columnList returns the given column names as a comma-separated list.
+func columnList(colNames []string) string {
+	cols := make([]string, len(colNames))
+	for i, n := range colNames {
+		cols[i] = tree.NameString(n)
+	}
+	return strings.Join(cols, ", ")
+}
+
+// deferredConstraintKeyFilter returns a filter that matches the rows whose
+// given columns have one of the given keys. Keys are compared with IS NOT
+// DISTINCT FROM, so that a key of a MATCH FULL foreign key that mixes NULL
+// and non-NULL values matches the row it came from.
+func deferredConstraintKeyFilter(colNames []string, keys []tree.Datums) string {
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		b.WriteByte('(')
+		for j, d := range key {
+			if j > 0 {
+				b.WriteString(" AND ")
+			}
+			fmt.Fprintf(&b, "%s IS NOT DISTINCT FROM %s",
+				tree.NameString(colNames[j]), tree.AsStringWithFlags(d, tree.FmtParsable))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+type setConstraintsNode struct {
+	n *tree.SetConstraints
+}
+
+// SetConstraints implements the SET CONSTRAINTS statement.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	if err := checkDeferrableConstraintsVersion(ctx, p.EvalContext()); err != nil {
+		return nil, err
+	}
+	return &setConstraintsNode{n: n}, nil
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	p := params.p
+	// The constraint modes only last until the end of the transaction, so they
+	// have no effect outside of a transaction block. This matches Postgres.
+	if p.extendedEvalCtx.TxnImplicit {
+		p.BufferClientNotice(
+			params.ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return nil
+	}
+	if err := p.checkDeferrableConstraintNames(params.ctx, n.n.Names); err != nil {
+		return err
+	}
+	mode := sessiondata.ConstraintModeImmediate
+	if n.n.Deferred {
+		mode = sessiondata.ConstraintModeDeferred
+	}
+	if err := p.sessionDataMutatorIterator.applyOnTopMutator(func(m sessionDataMutator) error {
+		m.SetConstraintModes(n.n.Names, mode)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if n.n.Deferred {
+		return nil
+	}
+	// Checks that were deferred until now are performed immediately when the
+	// constraint is set to IMMEDIATE.
+	return p.validateDeferredConstraints(params.ctx, true /* onlyImmediate */)
+}
+
+// checkDeferrableConstraintNames returns an error if one of the given names
+// does not refer to a constraint on a table in the current database, or if
+// one of the constraints with the name is not deferrable. Unlike Postgres,
+// which only looks up the constraints in the schemas of the search path, all
+// the schemas of the current database are considered.
+func (p *planner) checkDeferrableConstraintNames(ctx context.Context, names tree.NameList) error {
+	if len(names) == 0 {
+		return nil
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	tables, err := p.Descriptors().GetAllTablesInDatabase(ctx, p.Txn(), db)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(names))
+	notDeferrable := make(map[string]bool)
+	for _, name := range names {
+		found[string(name)] = false
+	}
+	if err := tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tableDesc, err := catalog.AsTableDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		if tableDesc.Dropped() {
+			return nil
+		}
+		for _, c := range tableDesc.AllConstraints() {
+			if _, ok := found[c.GetName()]; !ok {
+				continue
+			}
+			found[c.GetName()] = true
+			if deferrable, _ := constraintDeferrability(c); !deferrable {
+				notDeferrable[c.GetName()] = true
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if !found[string(name)] {
+			return pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+		}
+		if notDeferrable[string(name)] {
+			return pgerror.Newf(pgcode.WrongObjectType, "constraint %q is not deferrable", name)
+		}
+	}
+	return nil
+}
+
+func (n *setConstraintsNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *setConstraintsNode) Close(ctx context.Context)           {}
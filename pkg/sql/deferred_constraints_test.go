@@ -0,0 +1,93 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeferredConstraintChecksSpill verifies that the keys of a deferred
+// constraint are dropped, and the constraint marked for validation against the
+// whole table, once there are too many of them or they exceed the memory
+// budget.
+func TestDeferredConstraintChecksSpill(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	startMonitor := func(budget int64) *mon.BytesMonitor {
+		m := mon.NewMonitor(mon.Options{
+			Name:      "test mon",
+			Increment: 1,
+			Settings:  cluster.MakeTestingClusterSettings(),
+		})
+		m.Start(ctx, nil, mon.NewStandaloneBudget(budget))
+		return m
+	}
+	key := func(i int) tree.Datums { return tree.Datums{tree.NewDInt(tree.DInt(i))} }
+
+	const budget = 1 << 10
+	limited := startMonitor(budget)
+	defer limited.Stop(ctx)
+	var d deferredConstraintChecks
+	d.memAcc = limited.MakeBoundAccount()
+
+	// Duplicate keys are only recorded once.
+	d.add(ctx, 1, "fk", key(1))
+	d.add(ctx, 1, "fk", key(1))
+	require.Len(t, d.pending, 1)
+	require.Len(t, d.pending[0].keys, 1)
+	require.False(t, d.pending[0].full)
+	used := d.memAcc.Used()
+	require.Positive(t, used)
+
+	// Exceeding the memory budget spills the check and releases its memory.
+	for i := 0; !d.pending[0].full; i++ {
+		d.add(ctx, 1, "fk", key(i))
+		require.Less(t, i, budget)
+	}
+	require.Nil(t, d.pending[0].keys)
+	require.Zero(t, d.memAcc.Used())
+
+	// Other checks are not affected.
+	d.add(ctx, 1, "uniq", key(1))
+	require.Len(t, d.pending, 2)
+	require.False(t, d.pending[1].full)
+	require.Equal(t, used, d.memAcc.Used())
+
+	d.reset(ctx)
+	require.Empty(t, d.pending)
+	require.Zero(t, d.memAcc.Used())
+	d.memAcc.Close(ctx)
+
+	// Exceeding the maximum number of keys spills the check as well, even if
+	// they fit in the memory budget.
+	unlimited := startMonitor(math.MaxInt64)
+	defer unlimited.Stop(ctx)
+	d.memAcc = unlimited.MakeBoundAccount()
+	defer d.memAcc.Close(ctx)
+	for i := 0; i < deferredConstraintMaxKeys; i++ {
+		d.add(ctx, 2, "fk", key(i))
+	}
+	require.False(t, d.pending[0].full)
+	d.add(ctx, 2, "fk", key(deferredConstraintMaxKeys))
+	require.True(t, d.pending[0].full)
+	require.Zero(t, d.memAcc.Used())
+}
@@ -133,6 +133,7 @@ func (p *planner) createDatabase(
 		owner,
 		dbdesc.MaybeWithDatabaseRegionConfig(regionConfig),
 		dbdesc.WithPublicSchemaID(publicSchemaID),
+		dbdesc.WithDefaultCollation(databaseDefaultCollation(database.Collate)),
 	)
 	includeCreatePriv := sqlclustersettings.PublicSchemaCreatePrivilegeEnabled.Get(&p.execCfg.Settings.SV)
 	publicSchema := schemadesc.NewBuilder(&descpb.SchemaDescriptor{
@@ -19,6 +19,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -445,6 +446,7 @@ func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) boo
 	switch n := node.(type) {
 	// Keep these cases alphabetized, please!
 	case *distinctNode:
+	case *expandNode:
 	case *exportNode:
 	case *filterNode:
 	case *groupNode:
@@ -521,6 +523,9 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 	case *distinctNode:
 		return checkSupportForPlanNode(n.plan)
 
+	case *expandNode:
+		return checkSupportForPlanNode(n.source)
+
 	case *exportNode:
 		return checkSupportForPlanNode(n.source)
 
@@ -539,6 +544,13 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 			if agg.distsqlBlocklist {
 				return cannotDistribute, newQueryNotSupportedErrorf("aggregate %q cannot be executed with distsql", agg.funcName)
 			}
+			if ud := agg.userDefined; ud != nil {
+				for _, e := range []tree.TypedExpr{ud.InitCond, ud.Transition, ud.Final} {
+					if err := checkExprForDistSQL(e); err != nil {
+						return cannotDistribute, err
+					}
+				}
+			}
 		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
@@ -2296,11 +2308,20 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			var err error
+			aggregations[i].UserDefined, err = makeUserDefinedAggregationSpec(ctx, planCtx, fholder.userDefined)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2335,6 +2356,72 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggregationSpec returns the spec for the given user-defined
+// aggregate function. The combine function is only included if it can be
+// evaluated remotely, since otherwise the aggregation cannot be planned in
+// multiple stages.
+func makeUserDefinedAggregationSpec(
+	ctx context.Context, planCtx *PlanningCtx, info *exec.UserDefinedAggInfo,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregation, error) {
+	spec := &execinfrapb.AggregatorSpec_UserDefinedAggregation{
+		StateType:        info.StateType,
+		ResultType:       info.ResultType,
+		TransitionStrict: info.TransitionStrict,
+		FinalStrict:      info.FinalStrict,
+		CombineStrict:    info.CombineStrict,
+	}
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	var err error
+	if info.InitCond != nil && info.InitCond != tree.DNull {
+		if spec.InitCond, err = ef.Make(info.InitCond); err != nil {
+			return nil, err
+		}
+	}
+	if spec.Transition, err = ef.Make(info.Transition); err != nil {
+		return nil, err
+	}
+	if info.Final != nil {
+		if spec.Final, err = ef.Make(info.Final); err != nil {
+			return nil, err
+		}
+	}
+	if info.Combine != nil && checkExprForDistSQL(info.Combine) == nil {
+		if spec.Combine, err = ef.Make(info.Combine); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// getDistAggregationInfo returns the blueprint for planning the given
+// aggregation in multiple stages. ok is false if the aggregation doesn't
+// support a local stage.
+func getDistAggregationInfo(
+	agg *execinfrapb.AggregatorSpec_Aggregation,
+) (_ physicalplan.DistAggregationInfo, ok bool) {
+	if agg.UserDefined != nil {
+		if agg.UserDefined.Combine.Empty() {
+			return physicalplan.DistAggregationInfo{}, false
+		}
+		return physicalplan.UserDefinedDistAggregation, true
+	}
+	info, ok := physicalplan.DistAggregationTable[agg.Func]
+	return info, ok
+}
+
+// getAggregateOutputType returns the type of the result of the given
+// aggregation when applied to arguments of the given types.
+func getAggregateOutputType(
+	agg *execinfrapb.AggregatorSpec_Aggregation, argTypes []*types.T,
+) (*types.T, error) {
+	if agg.UserDefined != nil {
+		return agg.UserDefined.ResultType, nil
+	}
+	_, outputType, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
+	return outputType, err
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2532,7 +2619,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 				break
 			}
 			// Check that the function supports a local stage.
-			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
+			if _, ok := getDistAggregationInfo(&e); !ok {
 				multiStage = false
 				break
 			}
@@ -2572,7 +2659,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := getDistAggregationInfo(&e)
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
 			if info.FinalRendering != nil {
@@ -2607,7 +2694,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// to all final aggregations.
 		finalIdx := 0
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := getDistAggregationInfo(&e)
 
 			// relToAbsLocalIdx maps each local stage for the given
 			// aggregation e to its final index in localAggs.  This
@@ -2629,6 +2716,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 					ColIdx:       e.ColIdx,
 					FilterColIdx: e.FilterColIdx,
 				}
+				if e.UserDefined != nil {
+					localAgg.UserDefined = physicalplan.UserDefinedLocalStage(e.UserDefined)
+				}
 
 				isNewAgg := true
 				for j, prevLocalAgg := range localAggs {
@@ -2656,7 +2746,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 					for j, c := range e.ColIdx {
 						argTypes[j] = inputTypes[c]
 					}
-					_, outputType, err := execagg.GetAggregateInfo(localFunc, argTypes...)
+					outputType, err := getAggregateOutputType(&localAgg, argTypes)
 					if err != nil {
 						return err
 					}
@@ -2680,6 +2770,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 					Func:   finalInfo.Fn,
 					ColIdx: argIdxs,
 				}
+				if e.UserDefined != nil {
+					finalAgg.UserDefined = physicalplan.UserDefinedFinalStage(e.UserDefined)
+				}
 
 				isNewAgg := true
 				for i, prevFinalAgg := range finalAggs {
@@ -2708,7 +2801,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 							// the current aggregation e.
 							argTypes[i] = intermediateTypes[argIdxs[i]]
 						}
-						_, outputType, err := execagg.GetAggregateInfo(finalInfo.Fn, argTypes...)
+						outputType, err := getAggregateOutputType(&finalAgg, argTypes)
 						if err != nil {
 							return err
 						}
@@ -2827,7 +2920,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			var ef physicalplan.ExprFactory
 			ef.Init(ctx, planCtx, nil /* indexVarMap */)
 			for i, e := range info.aggregations {
-				info := physicalplan.DistAggregationTable[e.Func]
+				info, _ := getDistAggregationInfo(&e)
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
 					// location of the result for this
@@ -2880,8 +2973,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
-		var err error
-		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
+		returnTyp, err := getAggregateOutputType(&agg, argTypes)
 		if err != nil {
 			return err
 		}
@@ -3703,17 +3795,26 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 	case *distinctNode:
 		plan, err = dsp.createPlanForDistinct(ctx, planCtx, n)
 
+	case *expandNode:
+		plan, err = dsp.createPlanForExpand(ctx, planCtx, n)
+
 	case *exportNode:
 		plan, err = dsp.createPlanForExport(ctx, planCtx, n)
 
 	case *filterNode:
-		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source.plan)
-		if err != nil {
-			return nil, err
-		}
+		if scan, ok := n.source.plan.(*scanNode); ok && scan.desc.IsForeignTable() && scan.hardLimit == 0 {
+			// Push the filter into the foreign table readers, which can use it
+			// to skip parts of the files.
+			plan, err = dsp.createForeignTableReaders(ctx, planCtx, scan, n.filter)
+		} else {
+			plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source.plan)
+			if err != nil {
+				return nil, err
+			}
 
-		if err := plan.AddFilter(ctx, n.filter, planCtx, plan.PlanToStreamColMap); err != nil {
-			return nil, err
+			if err := plan.AddFilter(ctx, n.filter, planCtx, plan.PlanToStreamColMap); err != nil {
+				return nil, err
+			}
 		}
 
 	case *groupNode:
@@ -3798,7 +3899,11 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 		}
 
 	case *scanNode:
-		plan, err = dsp.createTableReaders(ctx, planCtx, n)
+		if n.desc.IsForeignTable() {
+			plan, err = dsp.createForeignTableReaders(ctx, planCtx, n, nil /* filter */)
+		} else {
+			plan, err = dsp.createTableReaders(ctx, planCtx, n)
+		}
 
 	case *sortNode:
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
@@ -4187,6 +4292,55 @@ func (dsp *DistSQLPlanner) createPlanForOrdinality(
 	return plan, nil
 }
 
+func (dsp *DistSQLPlanner) createPlanForExpand(
+	ctx context.Context, planCtx *PlanningCtx, n *expandNode,
+) (*PhysicalPlan, error) {
+	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &execinfrapb.ExpandSpec{
+		GroupingCols: make([]uint32, len(n.groupingCols)),
+		GroupingSets: make([]execinfrapb.ExpandSpec_GroupingSet, len(n.groupingSets)),
+	}
+	for i, col := range n.groupingCols {
+		spec.GroupingCols[i] = uint32(plan.PlanToStreamColMap[col])
+	}
+	for i, set := range n.groupingSets {
+		spec.GroupingSets[i].Cols = make([]uint32, len(set))
+		for j, idx := range set {
+			spec.GroupingSets[i].Cols[j] = uint32(idx)
+		}
+	}
+
+	inputTypes := plan.GetResultTypes()
+	outputTypes := make([]*types.T, 0, len(inputTypes)+len(spec.GroupingCols)+1)
+	outputTypes = append(outputTypes, inputTypes...)
+	for _, col := range spec.GroupingCols {
+		outputTypes = append(outputTypes, inputTypes[col])
+	}
+	outputTypes = append(outputTypes, types.Int)
+	for i := len(inputTypes); i < len(outputTypes); i++ {
+		plan.PlanToStreamColMap = append(plan.PlanToStreamColMap, i)
+	}
+
+	// Each row is expanded independently, so the expansion can be performed on
+	// every stream, and the copies of a row are produced in the order of the
+	// input. Nodes that have not been upgraded to 24.1 cannot run the expand
+	// processor, so until the upgrade is finalized, it is planned on the gateway
+	// only.
+	core := execinfrapb.ProcessorCoreUnion{Expand: spec}
+	if !dsp.st.Version.IsActive(ctx, clusterversion.V24_1) {
+		plan.AddSingleGroupStage(
+			ctx, dsp.gatewaySQLInstanceID, core, execinfrapb.PostProcessSpec{}, outputTypes,
+		)
+		return plan, nil
+	}
+	plan.AddNoGroupingStage(core, execinfrapb.PostProcessSpec{}, outputTypes, plan.MergeOrdering)
+	return plan, nil
+}
+
 func createProjectSetSpec(
 	ctx context.Context, planCtx *PlanningCtx, n *projectSetPlanningInfo, indexVarMap []int,
 ) (*execinfrapb.ProjectSetSpec, error) {
@@ -4786,6 +4940,8 @@ func (c *localScanParallelizationChecker) enterNode(
 	switch n := plan.(type) {
 	case *distinctNode:
 		return true, nil
+	case *expandNode:
+		return true, nil
 	case *explainPlanNode:
 		// walkPlan doesn't recurse into explainPlanNode, so we have to manually
 		// walk over the wrapped plan.
@@ -4805,6 +4961,14 @@ func (c *localScanParallelizationChecker) enterNode(
 		for _, f := range n.funcs {
 			c.prohibitParallelization = f.hasFilter()
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// The support functions of user-defined aggregates might be
+				// evaluated as routines, which use the root txn, so we choose
+				// to be safe.
+				c.prohibitParallelization = true
+			}
+		}
 		return true, nil
 	case *indexJoinNode:
 		return true, nil
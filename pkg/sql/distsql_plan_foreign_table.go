@@ -0,0 +1,186 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// createForeignTableReaders generates a plan that reads the files of a foreign
+// table, with the files distributed round-robin over the healthy SQL
+// instances. If filter is non-nil, it is evaluated by the readers, which may
+// use it to skip parts of the files; it refers to the columns of the scan.
+func (dsp *DistSQLPlanner) createForeignTableReaders(
+	ctx context.Context, planCtx *PlanningCtx, n *scanNode, filter tree.TypedExpr,
+) (*PhysicalPlan, error) {
+	if n.lockingStrength != descpb.ScanLockingStrength_FOR_NONE {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"row-level locking is not supported on foreign table %q", n.desc.GetName())
+	}
+	format, err := foreignTableFileFormat(n.desc.GetForeignTable())
+	if err != nil {
+		return nil, err
+	}
+	uris, err := dsp.foreignTableFileURIs(ctx, planCtx, n.desc.GetForeignTable())
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []base.SQLInstanceID{dsp.gatewaySQLInstanceID}
+	if !planCtx.isLocal && n.hardLimit == 0 && len(uris) > 1 {
+		if instances, err = dsp.foreignTableReaderInstances(ctx, planCtx); err != nil {
+			return nil, err
+		}
+	}
+
+	colIDs := make([]descpb.ColumnID, len(n.cols))
+	typs := make([]*types.T, len(n.cols))
+	for i, col := range n.cols {
+		colIDs[i] = col.GetID()
+		typs[i] = col.GetType()
+	}
+	spans := make(roachpb.Spans, len(n.spans))
+	copy(spans, n.spans)
+	spec := execinfrapb.ForeignTableReaderSpec{
+		Table:     *n.desc.TableDesc(),
+		Format:    format,
+		ColumnIDs: colIDs,
+		Spans:     spans,
+		UserProto: planCtx.planner.User().EncodeProto(),
+	}
+	if filter != nil {
+		if spec.Filter, err = physicalplan.MakeExpression(
+			ctx, filter, planCtx, identityMap(make([]int, len(n.cols)), len(n.cols)),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each reader gets the files whose position in the sorted list is congruent
+	// to its index modulo the number of readers.
+	numReaders := len(instances)
+	if numReaders > len(uris) {
+		numReaders = len(uris)
+	}
+	corePlacement := make([]physicalplan.ProcessorCorePlacement, numReaders)
+	for i := range corePlacement {
+		s := spec
+		s.Uris = make(map[int32]string)
+		for j := i; j < len(uris); j += numReaders {
+			// File IDs start at 1 so that row IDs are never zero.
+			s.Uris[int32(j+1)] = uris[j]
+		}
+		corePlacement[i].SQLInstanceID = instances[i]
+		corePlacement[i].EstimatedRowCount = n.estimatedRowCount / uint64(numReaders)
+		corePlacement[i].Core.ForeignTableReader = &s
+	}
+
+	// The readers produce their rows in ascending row ID order, which is the
+	// order of the primary index, so a reverse scan needs a sort, which also
+	// applies the limit.
+	sortReverse := n.reverse && len(n.reqOrdering) > 0
+	var post execinfrapb.PostProcessSpec
+	if n.hardLimit != 0 && !sortReverse {
+		post.Limit = uint64(n.hardLimit)
+	}
+	p := planCtx.NewPhysicalPlan()
+	p.TotalEstimatedScannedRows += n.estimatedRowCount
+	// Note: we will set a merge ordering below.
+	p.AddNoInputStage(corePlacement, post, typs, execinfrapb.Ordering{})
+	p.PlanToStreamColMap = identityMap(make([]int, len(typs)), len(typs))
+	if sortReverse {
+		dsp.addSorters(ctx, p, n.reqOrdering, 0 /* alreadyOrderedPrefix */, n.hardLimit)
+	} else {
+		p.SetMergeOrdering(dsp.convertOrdering(n.reqOrdering, p.PlanToStreamColMap))
+	}
+	return p, nil
+}
+
+// foreignTableReaderInstances returns the SQL instances that can read the
+// files of a foreign table, sorted by ID.
+func (dsp *DistSQLPlanner) foreignTableReaderInstances(
+	ctx context.Context, planCtx *PlanningCtx,
+) ([]base.SQLInstanceID, error) {
+	all, err := dsp.GetAllInstancesByLocality(ctx, roachpb.Locality{})
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]base.SQLInstanceID, 0, len(all))
+	for _, info := range all {
+		if dsp.codec.ForSystemTenant() &&
+			dsp.checkInstanceHealthAndVersionSystem(ctx, planCtx, info.InstanceID) != NodeOK {
+			continue
+		}
+		instances = append(instances, info.InstanceID)
+	}
+	if len(instances) == 0 {
+		instances = append(instances, dsp.gatewaySQLInstanceID)
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i] < instances[j] })
+	return instances, nil
+}
+
+// foreignTableFileURIs returns the sorted URIs of the files of a foreign
+// table, expanding the wildcards of its filename option.
+func (dsp *DistSQLPlanner) foreignTableFileURIs(
+	ctx context.Context, planCtx *PlanningCtx, ft *descpb.TableDescriptor_ForeignTable,
+) ([]string, error) {
+	var filename string
+	for _, o := range ft.Options {
+		if o.Name == foreignTableOptionFilename {
+			filename = o.Value
+		}
+	}
+	uri := url.URL{Scheme: "external", Host: ft.Server, Path: "/" + strings.TrimPrefix(filename, "/")}
+	prefix := cloud.GetPrefixBeforeWildcard(uri.Path)
+	if len(prefix) == len(uri.Path) {
+		return []string{uri.String()}, nil
+	}
+	pattern := uri.Path[len(prefix):]
+	uri.Path = prefix
+	es, err := dsp.distSQLSrv.ExternalStorageFromURI(ctx, uri.String(), planCtx.planner.User())
+	if err != nil {
+		return nil, err
+	}
+	defer es.Close()
+	var uris []string
+	if err := es.List(ctx, "", "", func(s string) error {
+		ok, err := path.Match(pattern, s)
+		if ok {
+			uri.Path = prefix + s
+			uris = append(uris, uri.String())
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if len(uris) == 0 {
+		return nil, errors.Errorf("no files matched %q in prefix %q of server %q", pattern, prefix, ft.Server)
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
@@ -2092,7 +2092,11 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKConstraint.Name())
+		if trig := plan.cascades[i].Trigger; trig != nil {
+			log.VEventf(ctx, 2, "executing AFTER trigger %s", trig.Name())
+		} else {
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKConstraint.Name())
+		}
 
 		// We place a sequence point before every cascade, so that each subsequent
 		// cascade can observe the writes by the previous step. However, The
@@ -2162,7 +2166,8 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			planner,
 			evalCtx,
 			recv,
-			false, /* parallelCheck */
+			false,                           /* parallelCheck */
+			plan.cascades[i].Trigger != nil, /* discardRows */
 			defaultGetSaveFlowsFunc,
 			planner.instrumentation.getAssociateNodeWithComponentsFn(),
 			recv.stats.add,
@@ -2222,6 +2227,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 				evalCtxFactory(false /* usedConcurrently */),
 				recv,
 				false, /* parallelCheck */
+				false, /* discardRows */
 				defaultGetSaveFlowsFunc,
 				planner.instrumentation.getAssociateNodeWithComponentsFn(),
 				recv.stats.add,
@@ -2263,6 +2269,9 @@ var parallelChecksConcurrencyLimit = settings.RegisterIntSetting(
 // with other check queries. If parallelCheck is true, then getSaveFlowsFunc,
 // associateNodeWithComponents, and addTopLevelQueryStats must be
 // concurrency-safe (if non-nil).
+// - discardRows indicates that the rows produced by the query (e.g. the
+// results of the trigger functions fired by an AFTER trigger) are dropped
+// rather than treated as an error.
 // - getSaveFlowsFunc will only be called if
 // planner.instrumentation.ShouldSaveFlows() returns true.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
@@ -2272,6 +2281,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	evalCtx *extendedEvalContext,
 	recv *DistSQLReceiver,
 	parallelCheck bool,
+	discardRows bool,
 	getSaveFlowsFunc func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error,
 	associateNodeWithComponents func(exec.Node, execComponents),
 	addTopLevelQueryStats func(stats *topLevelQueryStats),
@@ -2307,9 +2317,14 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
 	defer addTopLevelQueryStats(&postqueryRecv.stats)
-	postqueryResultWriter := &errOnlyResultWriter{}
-	postqueryRecv.resultWriter = postqueryResultWriter
-	postqueryRecv.batchWriter = postqueryResultWriter
+	if discardRows {
+		postqueryRecv.resultWriter = &droppingResultWriter{}
+		postqueryRecv.batchWriter = nil
+	} else {
+		postqueryResultWriter := &errOnlyResultWriter{}
+		postqueryRecv.resultWriter = postqueryResultWriter
+		postqueryRecv.batchWriter = postqueryResultWriter
+	}
 	finishedSetupFn, cleanup := getFinishedSetupFn(planner)
 	defer cleanup()
 	dsp.Run(ctx, postqueryPlanCtx, planner.txn, postqueryPhysPlan, postqueryRecv, evalCtx, finishedSetupFn)
@@ -2402,7 +2417,8 @@ func (dsp *DistSQLPlanner) planAndRunChecksInParallel(
 			planner,
 			evalCtxFactory(true /* usedConcurrently */),
 			recv,
-			true, /* parallelCheck */
+			true,  /* parallelCheck */
+			false, /* discardRows */
 			getSaveFlowsFunc,
 			associateNodeWithComponents,
 			addTopLevelQueryStats,
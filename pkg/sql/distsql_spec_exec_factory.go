@@ -529,6 +529,10 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		i := len(groupCols) + j
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
+		if agg.UserDefined != nil {
+			return nil, unimplemented.NewWithIssue(
+				47473, "experimental opt-driven distsql planning: user-defined aggregate")
+		}
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
 			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
@@ -654,6 +658,12 @@ func (e *distSQLSpecExecFactory) ConstructOrdinality(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: ordinality")
 }
 
+func (e *distSQLSpecExecFactory) ConstructExpand(
+	input exec.Node, groupingCols []exec.NodeColumnOrdinal, groupingSets [][]int,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: expand")
+}
+
 func (e *distSQLSpecExecFactory) ConstructIndexJoin(
 	input exec.Node,
 	table cat.Table,
@@ -1010,6 +1020,7 @@ func (e *distSQLSpecExecFactory) ConstructUpsert(
 	arbiterIndexes cat.IndexOrdinals,
 	arbiterConstraints cat.UniqueOrdinals,
 	canaryCol exec.NodeColumnOrdinal,
+	mergeActionCol exec.NodeColumnOrdinal,
 	insertCols exec.TableColumnOrdinalSet,
 	fetchCols exec.TableColumnOrdinalSet,
 	updateCols exec.TableColumnOrdinalSet,
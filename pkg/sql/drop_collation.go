@@ -0,0 +1,94 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type dropCollationNode struct {
+	n      *tree.DropCollation
+	dbDesc *dbdesc.Mutable
+	// names are the collations to drop which exist in the database.
+	names map[string]struct{}
+}
+
+// DropCollation removes collations from the current database. Columns and
+// expressions which use a collation refer to its locale, so they are not
+// affected.
+// Privileges: CREATE on the database.
+func (p *planner) DropCollation(ctx context.Context, n *tree.DropCollation) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP COLLATION",
+	); err != nil {
+		return nil, err
+	}
+	if err := checkCollationsVersion(ctx, p.EvalContext()); err != nil {
+		return nil, err
+	}
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(n.Names))
+	for _, name := range n.Names {
+		if _, found := dbDesc.GetCollation(string(name)); found {
+			names[string(name)] = struct{}{}
+			continue
+		}
+		if !n.IfExists {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"collation %q does not exist", name)
+		}
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"collation %q does not exist, skipping", name))
+	}
+	if len(names) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return &dropCollationNode{n: n, dbDesc: dbDesc, names: names}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropCollationNode) ReadingOwnWrites() {}
+
+func (n *dropCollationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("collation"))
+	var collations []descpb.DatabaseDescriptor_Collation
+	for _, c := range n.dbDesc.Collations {
+		if _, ok := n.names[c.Name]; !ok {
+			collations = append(collations, c)
+		}
+	}
+	n.dbDesc.Collations = collations
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropCollationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropCollationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropCollationNode) Close(context.Context)        {}
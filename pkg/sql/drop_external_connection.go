@@ -47,7 +47,12 @@ func (p *planner) dropExternalConnection(params runParams, n *tree.DropExternalC
 	if err != nil {
 		return errors.Wrap(err, "failed to resolve External Connection name")
 	}
+	return p.dropExternalConnectionByName(params, name)
+}
 
+// dropExternalConnectionByName drops the External Connection with the given
+// name, which is also how foreign servers are dropped.
+func (p *planner) dropExternalConnectionByName(params runParams, name string) error {
 	// Check that the user has DROP privileges on the External Connection object.
 	ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
 		ConnectionName: name,
@@ -56,10 +61,16 @@ func (p *planner) dropExternalConnection(params runParams, n *tree.DropExternalC
 		return err
 	}
 
+	// The External Connection cannot be dropped while foreign tables read
+	// from it.
+	if err := p.checkForeignServerNotInUse(params.ctx, name); err != nil {
+		return err
+	}
+
 	// DROP EXTERNAL CONNECTION is only allowed for users with the `DROP`
 	// privilege on this object. We run the query as `node` since the user might
 	// not have `SELECT` on the system table.
-	if _ /* rows */, err = params.p.InternalSQLTxn().ExecEx(
+	if _ /* rows */, err := params.p.InternalSQLTxn().ExecEx(
 		params.ctx,
 		dropExternalConnectionOp,
 		params.p.Txn(),
@@ -71,7 +82,7 @@ func (p *planner) dropExternalConnection(params runParams, n *tree.DropExternalC
 
 	// We must also DELETE all rows from system.privileges that refer to
 	// external connection.
-	if _, err = params.p.InternalSQLTxn().ExecEx(
+	if _, err := params.p.InternalSQLTxn().ExecEx(
 		params.ctx,
 		dropExternalConnectionOp,
 		params.p.Txn(),
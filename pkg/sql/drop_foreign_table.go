@@ -0,0 +1,189 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+// checkForeignServerNotInUse returns an error if a foreign table reads from
+// the foreign server, or external connection, with the given name.
+func (p *planner) checkForeignServerNotInUse(ctx context.Context, name string) error {
+	all, err := p.Descriptors().GetAll(ctx, p.Txn())
+	if err != nil {
+		return err
+	}
+	var dependent catalog.TableDescriptor
+	if err := all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		if tbl, ok := desc.(catalog.TableDescriptor); ok && !tbl.Dropped() &&
+			tbl.IsForeignTable() && tbl.GetForeignTable().Server == name {
+			dependent = tbl
+			return iterutil.StopIteration()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if dependent != nil {
+		return pgerror.Newf(pgcode.DependentObjectsStillExist,
+			"cannot drop server %q because foreign table %q depends on it", name, dependent.GetName())
+	}
+	return nil
+}
+
+type dropServerNode struct {
+	n     *tree.DropServer
+	names []string
+}
+
+// DropServer implements the DROP SERVER statement.
+func (p *planner) DropServer(ctx context.Context, n *tree.DropServer) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP SERVER",
+	); err != nil {
+		return nil, err
+	}
+	if n.DropBehavior == tree.DropCascade {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"DROP SERVER ... CASCADE is not supported")
+	}
+	node := &dropServerNode{n: n}
+	for _, name := range n.Names {
+		_, ok, err := p.lookupForeignServer(ctx, string(name))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if !n.IfExists {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"server %q does not exist", name)
+			}
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"server %q does not exist, skipping", name))
+			continue
+		}
+		node.names = append(node.names, string(name))
+	}
+	return node, nil
+}
+
+func (n *dropServerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("server"))
+	for _, name := range n.names {
+		if err := params.p.dropExternalConnectionByName(params, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropServerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropServerNode) Values() tree.Datums          { return nil }
+func (n *dropServerNode) Close(context.Context)        {}
+
+type dropForeignTableNode struct {
+	n  *tree.DropForeignTable
+	td []toDelete
+}
+
+// DropForeignTable implements the DROP FOREIGN TABLE statement. Foreign
+// tables are dropped like tables, except that they have no data to clear.
+func (p *planner) DropForeignTable(
+	ctx context.Context, n *tree.DropForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+	node := &dropForeignTableNode{n: n}
+	seen := make(map[descpb.ID]bool, len(n.Names))
+	for i := range n.Names {
+		tn := &n.Names[i]
+		droppedDesc, err := p.prepareDrop(ctx, tn, !n.IfExists, tree.ResolveRequireForeignTableDesc)
+		if err != nil {
+			return nil, err
+		}
+		if droppedDesc == nil {
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"relation %q does not exist, skipping", tn.Table()))
+			continue
+		}
+		if seen[droppedDesc.ID] {
+			continue
+		}
+		seen[droppedDesc.ID] = true
+		node.td = append(node.td, toDelete{tn, droppedDesc})
+	}
+	for _, toDel := range node.td {
+		for _, ref := range toDel.desc.DependedOnBy {
+			if !seen[ref.ID] {
+				if err := p.canRemoveDependentFromTable(ctx, toDel.desc, ref, n.DropBehavior); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if len(node.td) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return node, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropForeignTableNode) ReadingOwnWrites() {}
+
+func (n *dropForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("foreign_table"))
+	for _, toDel := range n.td {
+		droppedViews, err := params.p.dropTableImpl(
+			params.ctx,
+			toDel.desc,
+			false, /* droppingDatabase */
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+			n.n.DropBehavior,
+		)
+		if err != nil {
+			return err
+		}
+		// Log a Drop Table event for this table. This is an auditable log event
+		// and is recorded in the same transaction as the table descriptor
+		// update.
+		if err := params.p.logEvent(params.ctx,
+			toDel.desc.ID,
+			&eventpb.DropTable{
+				TableName:           toDel.tn.FQString(),
+				CascadeDroppedViews: droppedViews,
+			}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropForeignTableNode) Values() tree.Datums          { return nil }
+func (n *dropForeignTableNode) Close(context.Context)        {}
@@ -39,17 +39,21 @@ type dropFunctionNode struct {
 
 // DropFunction drops a function.
 func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret planNode, err error) {
+	stmtName := "DROP FUNCTION"
+	if n.Aggregate {
+		stmtName = "DROP AGGREGATE"
+	}
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP FUNCTION",
+		stmtName,
 	); err != nil {
 		return nil, err
 	}
 
 	if n.DropBehavior == tree.DropCascade {
 		// TODO(chengxiong): remove this check when drop function cascade is supported.
-		return nil, unimplemented.Newf("DROP FUNCTION...CASCADE", "drop function cascade not supported")
+		return nil, unimplemented.Newf(stmtName+"...CASCADE", "drop function cascade not supported")
 	}
 	dropNode := &dropFunctionNode{
 		toDrop:       make([]*funcdesc.Mutable, 0, len(n.Routines)),
@@ -77,6 +81,15 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 		if err != nil {
 			return nil, err
 		}
+		if mut.IsAggregate() && !n.Aggregate {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", mut.Name),
+				"Use DROP AGGREGATE to drop aggregate functions.",
+			)
+		}
+		if !mut.IsAggregate() && n.Aggregate {
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", mut.Name)
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
@@ -117,6 +117,14 @@ func (n *dropIndexNode) startExec(params runParams) error {
 			return pgerror.Newf(pgcode.WrongObjectType, "%q is not a table or materialized view", tableDesc.Name)
 		}
 
+		if dropped, err := params.p.dropBlockRangeIndex(
+			ctx, index.tn, index.idxName, tableDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+		); err != nil {
+			return err
+		} else if dropped {
+			continue
+		}
+
 		// If we couldn't find the index by name, this is either a legitimate error or
 		// this statement contains an 'IF EXISTS' qualifier. Both of these cases are
 		// handled by `dropIndexByName()` below so we just ignore the error here.
@@ -520,6 +528,45 @@ func (p *planner) dropIndexByName(
 		})
 }
 
+// dropBlockRangeIndex drops the block range index of the table with the given
+// name, if there is one, along with its summaries.
+func (p *planner) dropBlockRangeIndex(
+	ctx context.Context,
+	tn *tree.TableName,
+	idxName tree.UnrestrictedName,
+	tableDesc *tabledesc.Mutable,
+	jobDesc string,
+) (dropped bool, _ error) {
+	for i := range tableDesc.BlockRangeIndexes {
+		idx := tableDesc.BlockRangeIndexes[i]
+		if idx.Name != string(idxName) {
+			continue
+		}
+		tableDesc.BlockRangeIndexes = append(
+			tableDesc.BlockRangeIndexes[:i], tableDesc.BlockRangeIndexes[i+1:]...,
+		)
+		// Writers that still use an older version of the descriptor may add
+		// summaries after they are deleted here. Index IDs are never reused, so
+		// those keys are ignored until the table is dropped.
+		prefix := p.ExecCfg().Codec.IndexPrefix(uint32(tableDesc.GetID()), uint32(idx.ID))
+		b := p.txn.NewBatch()
+		b.DelRange(prefix, prefix.PrefixEnd(), false /* returnKeys */)
+		if err := p.txn.Run(ctx, b); err != nil {
+			return false, err
+		}
+		if err := p.writeSchemaChange(ctx, tableDesc, descpb.InvalidMutationID, jobDesc); err != nil {
+			return false, err
+		}
+		return true, p.logEvent(ctx,
+			tableDesc.ID,
+			&eventpb.DropIndex{
+				TableName: tn.FQString(),
+				IndexName: string(idxName),
+			})
+	}
+	return false, nil
+}
+
 func (p *planner) removeDependents(
 	ctx context.Context,
 	tableDesc *tabledesc.Mutable,
@@ -0,0 +1,117 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type dropPolicyNode struct {
+	n         *tree.DropPolicy
+	tableDesc *tabledesc.Mutable
+	idx       int
+}
+
+// DropPolicy removes a row-level security policy from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP POLICY",
+	); err != nil {
+		return nil, err
+	}
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"relation %q does not exist, skipping", tn.Table()))
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.checkPolicyTableOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == string(n.Policy) {
+			return &dropPolicyNode{n: n, tableDesc: tableDesc, idx: i}, nil
+		}
+	}
+	if !n.IfExists {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", n.Policy, tableDesc.GetName())
+	}
+	p.BufferClientNotice(ctx, pgnotice.Newf(
+		"policy %q for relation %q does not exist, skipping", n.Policy, tableDesc.GetName()))
+	return newZeroNode(nil /* columns */), nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropPolicyNode) ReadingOwnWrites() {}
+
+func (n *dropPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("policy"))
+	tableDesc := n.tableDesc
+	tableDesc.Policies = append(tableDesc.Policies[:n.idx], tableDesc.Policies[n.idx+1:]...)
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPolicyNode) Close(context.Context)        {}
+
+// removePoliciesReferencingColumn removes the row-level security policies of
+// the table that reference the given column, which is being dropped. It
+// returns an error if there are any such policies and the drop behavior is not
+// CASCADE.
+func removePoliciesReferencingColumn(
+	params runParams, tableDesc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	var remaining []descpb.TableDescriptor_Policy
+	for _, policy := range tableDesc.Policies {
+		if !catalog.MakeTableColSet(policy.ColumnIDs...).Contains(col.GetID()) {
+			remaining = append(remaining, policy)
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return sqlerrors.NewDependentBlocksOpError(
+				"drop", "column", col.GetName(), "policy", policy.Name,
+			)
+		}
+		params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+			"drop cascades to policy %s on table %s", policy.Name, tableDesc.GetName()))
+	}
+	tableDesc.Policies = remaining
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type dropPublicationNode struct {
+	n      *tree.DropPublication
+	dbDesc *dbdesc.Mutable
+	// names are the publications to drop which exist in the database.
+	names map[string]struct{}
+}
+
+// DropPublication removes publications from the current database.
+// Privileges: ownership of the publications.
+func (p *planner) DropPublication(
+	ctx context.Context, n *tree.DropPublication,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(n.Names))
+	for _, name := range n.Names {
+		if pub, found := dbDesc.GetPublication(string(name)); found {
+			if !isAdmin && pub.OwnerProto.Decode() != p.User() {
+				return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of publication %s", name)
+			}
+			names[string(name)] = struct{}{}
+			continue
+		}
+		if !n.IfExists {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", name)
+		}
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"publication %q does not exist, skipping", name))
+	}
+	if len(names) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return &dropPublicationNode{n: n, dbDesc: dbDesc, names: names}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropPublicationNode) ReadingOwnWrites() {}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("publication"))
+	var publications []descpb.DatabaseDescriptor_Publication
+	for _, pub := range n.dbDesc.Publications {
+		if _, ok := n.names[pub.Name]; !ok {
+			publications = append(publications, pub)
+		}
+	}
+	n.dbDesc.Publications = publications
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPublicationNode) Close(context.Context)        {}
@@ -0,0 +1,141 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *tabledesc.Mutable
+	idx       int
+}
+
+// DropTrigger removes a trigger from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"relation %q does not exist, skipping", tn.Table()))
+		return newZeroNode(nil /* columns */), nil
+	}
+	hasOwnership, err := p.HasOwnership(ctx, tableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if !hasOwnership {
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(tableDesc.GetName()))
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Trigger) {
+			return &dropTriggerNode{n: n, tableDesc: tableDesc, idx: i}, nil
+		}
+	}
+	if !n.IfExists {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.Trigger, tableDesc.GetName())
+	}
+	p.BufferClientNotice(ctx, pgnotice.Newf(
+		"trigger %q for relation %q does not exist, skipping", n.Trigger, tableDesc.GetName()))
+	return newZeroNode(nil /* columns */), nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropTriggerNode) ReadingOwnWrites() {}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("trigger"))
+	tableDesc := n.tableDesc
+	if err := params.p.removeTriggerBackReference(params.ctx, tableDesc, &tableDesc.Triggers[n.idx]); err != nil {
+		return err
+	}
+	tableDesc.Triggers = append(tableDesc.Triggers[:n.idx], tableDesc.Triggers[n.idx+1:]...)
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTriggerNode) Close(context.Context)        {}
+
+// removeTriggerBackReference removes the back-reference to the given trigger
+// from its function.
+func (p *planner) removeTriggerBackReference(
+	ctx context.Context, tableDesc *tabledesc.Mutable, trigger *descpb.TableDescriptor_Trigger,
+) error {
+	funcDesc, err := p.Descriptors().MutableByID(p.txn).Function(ctx, trigger.FuncID)
+	if err != nil {
+		return err
+	}
+	funcDesc.RemoveTriggerReference(tableDesc.GetID(), trigger.ID)
+	return p.writeFuncSchemaChange(ctx, funcDesc)
+}
+
+// removeTriggersReferencingColumn removes the triggers of the table that
+// reference the given column, which is being dropped. It returns an error if
+// there are any such triggers and the drop behavior is not CASCADE.
+func removeTriggersReferencingColumn(
+	params runParams, tableDesc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	var remaining []descpb.TableDescriptor_Trigger
+	for i := range tableDesc.Triggers {
+		trigger := &tableDesc.Triggers[i]
+		if !catalog.MakeTableColSet(trigger.ColumnIDs...).Contains(col.GetID()) {
+			remaining = append(remaining, *trigger)
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return sqlerrors.NewDependentBlocksOpError(
+				"drop", "column", col.GetName(), "trigger", trigger.Name,
+			)
+		}
+		if err := params.p.removeTriggerBackReference(params.ctx, tableDesc, trigger); err != nil {
+			return err
+		}
+		params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+			"dropping trigger %q which depends on column %q", trigger.Name, col.GetName()))
+	}
+	tableDesc.Triggers = remaining
+	return nil
+}
@@ -1313,6 +1313,10 @@ type ExecutorConfig struct {
 	// Node-level sequence cache
 	SequenceCacheNode *sessiondatapb.SequenceCacheNode
 
+	// PLpgSQLStmtStats contains node-level execution statistics for the
+	// statements within PL/pgSQL routines. It may be nil.
+	PLpgSQLStmtStats *PLpgSQLStmtStats
+
 	// SessionInitCache cache; contains information used during authentication
 	// and per-role default settings.
 	SessionInitCache *sessioninit.Cache
@@ -2032,6 +2036,9 @@ func checkResultType(typ *types.T, fmtCode pgwirebase.FormatCode) error {
 	case types.INetFamily:
 	case types.OidFamily:
 	case types.PGLSNFamily:
+	case types.RangeFamily:
+	case types.MultiRangeFamily:
+	case types.GeometricFamily:
 	case types.RefCursorFamily:
 	case types.TupleFamily:
 	case types.EnumFamily:
@@ -3170,6 +3177,18 @@ func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
 
+// SetConstraintModes sets the check timing of the given deferrable
+// constraints, or of all constraints if names is empty.
+func (m *sessionDataMutator) SetConstraintModes(
+	names tree.NameList, mode sessiondata.ConstraintMode,
+) {
+	if len(names) == 0 {
+		m.data.ConstraintModes = m.data.ConstraintModes.WithAll(mode)
+		return
+	}
+	m.data.ConstraintModes = m.data.ConstraintModes.WithNamed(names.ToStrings(), mode)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionUseFollowerReads(val bool) {
 	m.data.DefaultTxnUseFollowerReads = val
 }
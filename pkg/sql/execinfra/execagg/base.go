@@ -89,6 +89,12 @@ func GetAggregateConstructor(
 		}
 		argTypes[j] = inputTypes[c]
 	}
+	if aggInfo.UserDefined != nil {
+		constructor, outputType, err = getUserDefinedAggregateInfo(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, argTypes,
+		)
+		return
+	}
 	arguments = make(tree.Datums, len(aggInfo.Arguments))
 	var d tree.Datum
 	for j, argument := range aggInfo.Arguments {
@@ -110,6 +116,59 @@ func GetAggregateConstructor(
 	return
 }
 
+// getUserDefinedAggregateInfo returns the aggregate constructor and the
+// return type for the given user-defined aggregate function when applied on
+// the given types.
+func getUserDefinedAggregateInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregation,
+	argTypes []*types.T,
+) (AggregateConstructor, *types.T, error) {
+	def := &builtins.UserDefinedAggregate{
+		StateType:        spec.StateType,
+		InitCond:         tree.DNull,
+		TransitionStrict: spec.TransitionStrict,
+		FinalStrict:      spec.FinalStrict,
+	}
+	// The support function expressions refer to the state followed by the
+	// arguments.
+	rowTypes := make([]*types.T, 0, len(argTypes)+1)
+	rowTypes = append(append(rowTypes, spec.StateType), argTypes...)
+	var h execinfrapb.ExprHelper
+	if err := h.Init(ctx, spec.Transition, rowTypes, semaCtx, evalCtx); err != nil {
+		return nil, nil, errors.Wrapf(err, "%s", spec.Transition)
+	}
+	if def.Transition = h.Expr(); def.Transition == nil {
+		return nil, nil, errors.AssertionFailedf("user-defined aggregate without transition expression")
+	}
+	if !spec.Final.Empty() {
+		h = execinfrapb.ExprHelper{}
+		if err := h.Init(ctx, spec.Final, rowTypes[:1], semaCtx, evalCtx); err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", spec.Final)
+		}
+		def.Final = h.Expr()
+	}
+	if !spec.InitCond.Empty() {
+		h = execinfrapb.ExprHelper{}
+		// Pass nil types and row - there are no variables in the initial
+		// condition.
+		if err := h.Init(ctx, spec.InitCond, nil /* types */, semaCtx, evalCtx); err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", spec.InitCond)
+		}
+		d, err := h.Eval(ctx, nil /* row */)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", spec.InitCond)
+		}
+		def.InitCond = d
+	}
+	constructor := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(evalCtx, def, argTypes)
+	}
+	return constructor, spec.ResultType, nil
+}
+
 // GetWindowFunctionInfo returns windowFunc constructor and the return type
 // when given fn is applied to given inputTypes.
 func GetWindowFunctionInfo(
@@ -64,7 +64,7 @@ import "github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 //
 // ATTENTION: When updating these fields, add a brief description of what
 // changed to the version history below.
-const Version execinfrapb.DistSQLVersion = 71
+const Version execinfrapb.DistSQLVersion = 72
 
 // MinAcceptedVersion is the oldest version that the server is compatible with.
 // A server will not accept flows with older versions.
@@ -76,6 +76,11 @@ const MinAcceptedVersion execinfrapb.DistSQLVersion = 71
 
 Please add new entries at the top.
 
+- Version: 72 (MinAcceptedVersion: 71)
+  - ExpandSpec has been introduced. A server running v72 can still process
+    all plans from servers running v71, thus the MinAcceptedVersion is kept
+    at 71.
+
 - Version: 71 (MinAcceptedVersion: 71)
   - On-wire representation of booleans and bytes-like values in the Arrow format
     has changed.
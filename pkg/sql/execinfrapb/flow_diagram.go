@@ -379,6 +379,19 @@ func (d *DistinctSpec) summary() (string, []string) {
 	return "Distinct", details
 }
 
+// summary implements the diagramCellType interface.
+func (e *ExpandSpec) summary() (string, []string) {
+	details := make([]string, len(e.GroupingSets))
+	for i, set := range e.GroupingSets {
+		cols := make([]uint32, len(set.Cols))
+		for j, idx := range set.Cols {
+			cols[j] = e.GroupingCols[idx]
+		}
+		details[i] = fmt.Sprintf("(%s)", colListStr(cols))
+	}
+	return "Expand", details
+}
+
 // summary implements the diagramCellType interface.
 func (o *OrdinalitySpec) summary() (string, []string) {
 	return "Ordinality", []string{}
@@ -683,6 +696,18 @@ func (i *IngestStoppedSpec) summary() (string, []string) {
 	return "IngestStoppedSpec", []string{detail}
 }
 
+// summary implements the diagramCellType interface.
+func (s *ForeignTableReaderSpec) summary() (string, []string) {
+	details := []string{
+		s.Table.Name,
+		fmt.Sprintf("Files: %d", len(s.Uris)),
+	}
+	if !s.Filter.Empty() {
+		details = append(details, fmt.Sprintf("Filter: %s", s.Filter))
+	}
+	return "ForeignTableReader", details
+}
+
 type diagramCell struct {
 	Title   string   `json:"title"`
 	Details []string `json:"details"`
@@ -0,0 +1,51 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// expandNode produces a copy of each row of its source for each grouping set.
+// Each copy has the source columns, followed by a column for each of the
+// grouping columns, followed by the index of the grouping set. The grouping
+// columns that are not part of the grouping set are NULL. Used to support
+// GROUPING SETS, ROLLUP and CUBE.
+type expandNode struct {
+	source  planNode
+	columns colinfo.ResultColumns
+
+	// groupingCols are the ordinals of the source columns that are grouped on
+	// by at least one grouping set.
+	groupingCols []exec.NodeColumnOrdinal
+
+	// groupingSets contains, for each grouping set, the indexes into
+	// groupingCols of the columns it groups on.
+	groupingSets [][]int
+}
+
+func (n *expandNode) startExec(runParams) error {
+	panic("expandNode can't be run in local mode")
+}
+
+func (n *expandNode) Next(params runParams) (bool, error) {
+	panic("expandNode can't be run in local mode")
+}
+
+func (n *expandNode) Values() tree.Datums {
+	panic("expandNode can't be run in local mode")
+}
+
+func (n *expandNode) Close(ctx context.Context) { n.source.Close(ctx) }
@@ -147,6 +147,7 @@ func buildStatementBundle(
 	queryErr, payloadErr, commErr error,
 	sv *settings.Values,
 	c inFlightTraceCollector,
+	plpgsqlStmtStats *PLpgSQLStmtStats,
 ) diagnosticsBundle {
 	if plan == nil {
 		return diagnosticsBundle{collectionErr: errors.AssertionFailedf("execution terminated early")}
@@ -163,6 +164,7 @@ func buildStatementBundle(
 	b.addExplainVec()
 	b.addTrace()
 	b.addInFlightTrace(c)
+	b.addPLpgSQLStmtStats(plpgsqlStmtStats)
 	b.addEnv(ctx)
 	b.addErrors(queryErr, payloadErr, commErr)
 
@@ -526,6 +528,17 @@ func (b *stmtBundleBuilder) addInFlightTrace(c inFlightTraceCollector) {
 	}
 }
 
+// addPLpgSQLStmtStats adds a file with the execution statistics of the
+// statements within PL/pgSQL routines invoked by the query, if there are any.
+func (b *stmtBundleBuilder) addPLpgSQLStmtStats(stats *PLpgSQLStmtStats) {
+	if stats == nil {
+		return
+	}
+	if output := stats.String(); output != "" {
+		b.z.AddFile("plpgsql-stmt-stats.txt", output)
+	}
+}
+
 // printError writes the given error string into buf (with a newline appended)
 // as well as accumulates the string into b.errorStrings. The method should only
 // be used for non-critical errors.
@@ -310,6 +310,22 @@ func (*DummyEvalPlanner) IsConstraintActive(
 	return false, errors.WithStack(errEvalPlanner)
 }
 
+// CanDeferConstraintChecks is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) CanDeferConstraintChecks() bool {
+	return false
+}
+
+// DeferConstraintCheck is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) DeferConstraintCheck(
+	ctx context.Context, tableID int, constraintName string, key tree.Datums,
+) {
+}
+
+// SendNotification is part of the eval.Planner interface.
+func (*DummyEvalPlanner) SendNotification(ctx context.Context, channel, payload string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the Planner interface.
 func (*DummyEvalPlanner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	return errors.WithStack(errEvalPlanner)
@@ -352,6 +368,13 @@ func (*DummyEvalPlanner) PLpgSQLFetchCursor(
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// PLpgSQLExecute is part of the Planner interface.
+func (*DummyEvalPlanner) PLpgSQLExecute(
+	context.Context, string, *tree.DTuple, bool, bool,
+) (tree.Datums, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
 func (p *DummyEvalPlanner) StartHistoryRetentionJob(
 	ctx context.Context, desc string, protectTS hlc.Timestamp, expiration time.Duration,
 ) (jobspb.JobID, error) {
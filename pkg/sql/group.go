@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -81,6 +82,8 @@ type aggregateFuncHolder struct {
 	// distsqlBlocklist is set when this function cannot be evaluated in
 	// distributed fashion.
 	distsqlBlocklist bool
+	// userDefined is set if this is a user-defined aggregate function.
+	userDefined *exec.UserDefinedAggInfo
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
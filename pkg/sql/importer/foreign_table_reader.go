@@ -0,0 +1,417 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// foreignTableReader is a processor that reads the rows of a foreign table
+// from the files in external storage assigned to it by the physical planner.
+type foreignTableReader struct {
+	execinfra.ProcessorBase
+
+	spec *execinfrapb.ForeignTableReaderSpec
+	desc catalog.TableDescriptor
+	// conv parses the columns of the table; conv.Datums holds the visible
+	// columns of the current row.
+	conv *row.DatumRowConverter
+	// outCols describes how each output column is produced.
+	outCols []foreignTableColumn
+	// keyPrefix is the prefix of the primary index keys of the table, used to
+	// check the synthesized row IDs against spec.Spans.
+	keyPrefix []byte
+	filter    execinfrapb.ExprHelper
+	// rowGroupFilters are the conjuncts of the filter that parquet files use to
+	// skip row groups.
+	rowGroupFilters []parquetRowGroupFilter
+
+	// fileIDs are the IDs of the files left to read, in ascending order.
+	fileIDs []int32
+	// fileID is the ID of the file being read by file, if any.
+	fileID int32
+	file   foreignFileReader
+
+	rowBuf rowenc.EncDatumRow
+}
+
+var _ execinfra.Processor = &foreignTableReader{}
+var _ execinfra.RowSource = &foreignTableReader{}
+
+const foreignTableReaderProcName = "foreign table reader"
+
+type foreignTableColumnKind int
+
+const (
+	// foreignTableColumnData is a visible column read from the files.
+	foreignTableColumnData foreignTableColumnKind = iota
+	// foreignTableColumnRowID is the hidden primary key column.
+	foreignTableColumnRowID
+	// foreignTableColumnTableOID is the tableoid system column.
+	foreignTableColumnTableOID
+	// foreignTableColumnNull is a system column that foreign tables don't
+	// have a value for.
+	foreignTableColumnNull
+)
+
+type foreignTableColumn struct {
+	kind foreignTableColumnKind
+	// ord is the ordinal of a data column among the visible columns.
+	ord int
+}
+
+// foreignFileReader reads the rows of a single file.
+type foreignFileReader interface {
+	// next fills the visible columns of the row converter with the next row of
+	// the file, and returns the position of that row in the file. ok is false
+	// once the file is exhausted.
+	next(ctx context.Context) (rowNum int64, ok bool, err error)
+	// close releases the resources of the reader.
+	close(ctx context.Context)
+}
+
+func newForeignTableReader(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.ForeignTableReaderSpec,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.Processor, error) {
+	mut := tabledesc.NewBuilder(&spec.Table).BuildCreatedMutableTable()
+	resolver := flowCtx.NewTypeResolver(flowCtx.Txn)
+	if err := typedesc.HydrateTypesInDescriptor(ctx, mut, &resolver); err != nil {
+		return nil, err
+	}
+	desc := mut.ImmutableCopy().(catalog.TableDescriptor)
+	if !desc.IsForeignTable() {
+		return nil, errors.AssertionFailedf("%q is not a foreign table", desc.GetName())
+	}
+
+	r := &foreignTableReader{
+		spec:      spec,
+		desc:      desc,
+		outCols:   make([]foreignTableColumn, len(spec.ColumnIDs)),
+		keyPrefix: rowenc.MakeIndexKeyPrefix(flowCtx.Codec(), desc.GetID(), desc.GetPrimaryIndexID()),
+		rowBuf:    make(rowenc.EncDatumRow, len(spec.ColumnIDs)),
+	}
+	visibleOrds := make(map[descpb.ColumnID]int)
+	for i, col := range desc.VisibleColumns() {
+		visibleOrds[col.GetID()] = i
+	}
+	outTypes := make([]*types.T, len(spec.ColumnIDs))
+	for i, id := range spec.ColumnIDs {
+		col := catalog.FindColumnByID(desc, id)
+		if col == nil {
+			return nil, errors.AssertionFailedf("column %d not found in foreign table %q", id, desc.GetName())
+		}
+		outTypes[i] = col.GetType()
+		switch {
+		case colinfo.IsColIDSystemColumn(id):
+			r.outCols[i].kind = foreignTableColumnNull
+			if colinfo.GetSystemColumnKindFromColumnID(id) == catpb.SystemColumnKind_TABLEOID {
+				r.outCols[i].kind = foreignTableColumnTableOID
+			}
+		case id == desc.GetPrimaryIndex().GetKeyColumnID(0):
+			r.outCols[i].kind = foreignTableColumnRowID
+		default:
+			ord, ok := visibleOrds[id]
+			if !ok {
+				return nil, errors.AssertionFailedf("column %q of foreign table %q is not visible", col.GetName(), desc.GetName())
+			}
+			r.outCols[i] = foreignTableColumn{kind: foreignTableColumnData, ord: ord}
+		}
+	}
+
+	for id := range spec.Uris {
+		r.fileIDs = append(r.fileIDs, id)
+	}
+	sort.Slice(r.fileIDs, func(i, j int) bool { return r.fileIDs[i] < r.fileIDs[j] })
+
+	if err := r.Init(
+		ctx, r, post, outTypes, flowCtx, processorID, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				r.close()
+				return nil
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	semaCtx := flowCtx.NewSemaContext(flowCtx.Txn)
+	conv, err := row.NewDatumRowConverter(
+		ctx, semaCtx, desc, nil /* targetColNames */, r.EvalCtx, nil, /* kvCh */
+		nil /* seqChunkProvider */, nil /* metrics */, flowCtx.Cfg.DB.KV(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.conv = conv
+	if err := r.filter.Init(ctx, spec.Filter, outTypes, semaCtx, r.EvalCtx); err != nil {
+		return nil, err
+	}
+	if spec.Format.Format == roachpb.IOFileFormat_Parquet && r.filter.Expr() != nil {
+		r.rowGroupFilters = makeParquetRowGroupFilters(r.filter.Expr(), r.outCols)
+	}
+	return r, nil
+}
+
+// Start is part of the RowSource interface.
+func (r *foreignTableReader) Start(ctx context.Context) {
+	r.StartInternal(ctx, foreignTableReaderProcName)
+}
+
+// Next is part of the RowSource interface.
+func (r *foreignTableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for r.State == execinfra.StateRunning {
+		outRow, err := r.nextRow(r.Ctx())
+		if err != nil {
+			r.MoveToDraining(err)
+			break
+		}
+		if outRow == nil {
+			r.MoveToDraining(nil /* err */)
+			break
+		}
+		if outRow := r.ProcessRowHelper(outRow); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, r.DrainHelper()
+}
+
+// nextRow returns the next row of the files that is within the spans and
+// passes the filter, or nil once all the files have been read.
+func (r *foreignTableReader) nextRow(ctx context.Context) (rowenc.EncDatumRow, error) {
+	for {
+		if r.file == nil {
+			if len(r.fileIDs) == 0 {
+				return nil, nil
+			}
+			r.fileID, r.fileIDs = r.fileIDs[0], r.fileIDs[1:]
+			var err error
+			if r.file, err = r.openFile(ctx, r.spec.Uris[r.fileID]); err != nil {
+				return nil, err
+			}
+		}
+		rowNum, ok, err := r.file.next(ctx)
+		if err != nil {
+			if uri, sanitizeErr := cloud.SanitizeExternalStorageURI(r.spec.Uris[r.fileID], nil /* extraParams */); sanitizeErr == nil {
+				err = errors.Wrapf(err, "reading %s", uri)
+			}
+			return nil, err
+		}
+		if !ok {
+			r.file.close(ctx)
+			r.file = nil
+			continue
+		}
+		if rowNum > math.MaxUint32 {
+			return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"foreign table files cannot have more than %d rows", uint64(math.MaxUint32)+1)
+		}
+		rowID := int64(r.fileID)<<32 | rowNum
+		if !roachpb.Spans(r.spec.Spans).ContainsKey(encoding.EncodeVarintAscending(r.keyPrefix, rowID)) {
+			continue
+		}
+		if err := r.fillRow(rowID); err != nil {
+			return nil, err
+		}
+		if pass, err := r.filter.EvalFilter(ctx, r.rowBuf); err != nil {
+			return nil, err
+		} else if !pass {
+			continue
+		}
+		return r.rowBuf, nil
+	}
+}
+
+// fillRow fills rowBuf with the output columns of the current row.
+func (r *foreignTableReader) fillRow(rowID int64) error {
+	for i, c := range r.outCols {
+		var d tree.Datum
+		switch c.kind {
+		case foreignTableColumnData:
+			d = r.conv.Datums[c.ord]
+			if d == nil {
+				d = tree.DNull
+			}
+			if d == tree.DNull && !r.conv.VisibleCols[c.ord].IsNullable() {
+				return sqlerrors.NewNonNullViolationError(r.conv.VisibleCols[c.ord].GetName())
+			}
+		case foreignTableColumnRowID:
+			d = tree.NewDInt(tree.DInt(rowID))
+		case foreignTableColumnTableOID:
+			d = tree.NewDOid(oid.Oid(r.desc.GetID()))
+		default:
+			d = tree.DNull
+		}
+		r.rowBuf[i] = rowenc.DatumToEncDatum(r.OutputTypes()[i], d)
+	}
+	return nil
+}
+
+// openFile returns a reader for the file with the given URI.
+func (r *foreignTableReader) openFile(ctx context.Context, uri string) (_ foreignFileReader, err error) {
+	es, err := r.FlowCtx.Cfg.ExternalStorageFromURI(ctx, uri, r.spec.User())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = es.Close()
+		}
+	}()
+
+	if r.spec.Format.Format == roachpb.IOFileFormat_Parquet {
+		return newParquetFileReader(ctx, es, r.conv, r.rowGroupFilters)
+	}
+
+	raw, _, err := es.ReadFile(ctx, "", cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return nil, err
+	}
+	src := &fileReader{counter: byteCounter{r: ioctx.ReaderCtxAdapter(ctx, raw)}}
+	decompressed, err := decompressingReader(&src.counter, uri, r.spec.Format.Compression)
+	if err != nil {
+		_ = raw.Close(ctx)
+		return nil, err
+	}
+	src.Reader = decompressed
+
+	importCtx := &parallelImportContext{
+		semaCtx:   r.conv.SemaCtx,
+		evalCtx:   r.conv.EvalCtx,
+		tableDesc: r.desc,
+	}
+	fr := &pipelineFileReader{
+		conv: r.conv,
+		closers: []func(ctx context.Context) error{
+			func(context.Context) error { return decompressed.Close() },
+			raw.Close,
+			func(context.Context) error { return es.Close() },
+		},
+	}
+	switch r.spec.Format.Format {
+	case roachpb.IOFileFormat_CSV:
+		fr.skip = int64(r.spec.Format.Csv.Skip)
+		fr.producer, fr.consumer = newCSVPipeline(&csvInputReader{
+			importCtx:           importCtx,
+			numExpectedDataCols: len(r.conv.VisibleCols),
+			opts:                r.spec.Format.Csv,
+		}, src)
+	case roachpb.IOFileFormat_Avro:
+		fr.producer, fr.consumer, err = newImportAvroPipeline(&avroInputReader{
+			importContext: importCtx,
+			opts:          r.spec.Format.Avro,
+		}, src)
+	default:
+		err = errors.AssertionFailedf("unsupported foreign table format %s", r.spec.Format.Format)
+	}
+	if err != nil {
+		fr.close(ctx)
+		return nil, err
+	}
+	return fr, nil
+}
+
+func (r *foreignTableReader) close() {
+	if r.InternalClose() {
+		if r.file != nil {
+			r.file.close(r.Ctx())
+			r.file = nil
+		}
+	}
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (r *foreignTableReader) ConsumerClosed() {
+	r.close()
+}
+
+// pipelineFileReader reads the rows of a file with the row producer and
+// consumer of an IMPORT format.
+type pipelineFileReader struct {
+	producer importRowProducer
+	consumer importRowConsumer
+	conv     *row.DatumRowConverter
+	// skip is the number of leading records of the file that are skipped, such
+	// as CSV headers.
+	skip    int64
+	rowNum  int64
+	closers []func(ctx context.Context) error
+}
+
+var _ foreignFileReader = &pipelineFileReader{}
+
+// next implements the foreignFileReader interface.
+func (r *pipelineFileReader) next(ctx context.Context) (int64, bool, error) {
+	for r.producer.Scan() {
+		if r.skip > 0 {
+			r.skip--
+			if err := r.producer.Skip(); err != nil {
+				return 0, false, err
+			}
+			continue
+		}
+		data, err := r.producer.Row()
+		if err != nil {
+			return 0, false, err
+		}
+		rowNum := r.rowNum
+		r.rowNum++
+		for i := range r.conv.Datums {
+			r.conv.Datums[i] = nil
+		}
+		if err := r.consumer.FillDatums(ctx, data, rowNum, r.conv); err != nil {
+			return 0, false, err
+		}
+		return rowNum, true, nil
+	}
+	return 0, false, r.producer.Err()
+}
+
+// close implements the foreignFileReader interface.
+func (r *pipelineFileReader) close(ctx context.Context) {
+	for _, c := range r.closers {
+		_ = c(ctx)
+	}
+}
+
+func init() {
+	rowexec.NewForeignTableReaderProcessor = newForeignTableReader
+}
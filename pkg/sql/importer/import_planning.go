@@ -817,6 +817,13 @@ func importPlanHook(
 				return err
 			}
 
+			// IMPORT ingests rows without going through the table writers that
+			// maintain the summaries of block range indexes.
+			if len(found.GetBlockRangeIndexes()) > 0 {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"IMPORT INTO is not supported for table %q with BRIN indexes", found.GetName())
+			}
+
 			// Validate target columns.
 			var intoCols []string
 			isTargetCol := make(map[string]bool)
@@ -0,0 +1,297 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+)
+
+// parquetFileReader reads the rows of a parquet file one row group at a time,
+// skipping the row groups that cannot contain rows passing the filter.
+type parquetFileReader struct {
+	reader  *parquet.Reader
+	src     *externalStorageReaderAt
+	conv    *row.DatumRowConverter
+	filters []parquetRowGroupFilter
+
+	// rowGroup is the index of the next row group to read.
+	rowGroup int
+	// cols holds the datums of the current row group, by column.
+	cols [][]tree.Datum
+	// rowInGroup is the index of the next row to return in the current row
+	// group, and firstRowNum is the position of its first row in the file.
+	rowInGroup  int
+	numInGroup  int
+	firstRowNum int64
+}
+
+var _ foreignFileReader = &parquetFileReader{}
+
+func newParquetFileReader(
+	ctx context.Context,
+	es cloud.ExternalStorage,
+	conv *row.DatumRowConverter,
+	filters []parquetRowGroupFilter,
+) (*parquetFileReader, error) {
+	size, err := es.Size(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	src := &externalStorageReaderAt{ctx: ctx, es: es, size: size}
+	names := make([]string, len(conv.VisibleCols))
+	for i, col := range conv.VisibleCols {
+		names[i] = col.GetName()
+	}
+	reader, err := parquet.NewReader(src, names, conv.VisibleColTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetFileReader{reader: reader, src: src, conv: conv, filters: filters}, nil
+}
+
+// next implements the foreignFileReader interface.
+func (r *parquetFileReader) next(ctx context.Context) (int64, bool, error) {
+	for r.rowInGroup >= r.numInGroup {
+		r.firstRowNum += int64(r.numInGroup)
+		r.cols, r.rowInGroup, r.numInGroup = nil, 0, 0
+		if r.rowGroup >= r.reader.NumRowGroups() {
+			return 0, false, nil
+		}
+		rg := r.rowGroup
+		r.rowGroup++
+		numRows := int(r.reader.RowGroupNumRows(rg))
+		skip, err := r.skipRowGroup(ctx, rg)
+		if err != nil {
+			return 0, false, err
+		}
+		if skip {
+			r.firstRowNum += int64(numRows)
+			continue
+		}
+		if r.cols, err = r.reader.ReadRowGroup(rg); err != nil {
+			return 0, false, err
+		}
+		r.numInGroup = numRows
+	}
+	rowNum := r.firstRowNum + int64(r.rowInGroup)
+	for i := range r.conv.Datums {
+		r.conv.Datums[i] = nil
+	}
+	for i := range r.cols {
+		d, err := coerceParquetDatum(ctx, r.cols[i][r.rowInGroup], r.conv.VisibleColTypes[i], r.conv)
+		if err != nil {
+			return 0, false, errors.Wrapf(err, "row %d: parse %q", rowNum+1, r.conv.VisibleCols[i].GetName())
+		}
+		r.conv.Datums[i] = d
+	}
+	r.rowInGroup++
+	return rowNum, true, nil
+}
+
+// skipRowGroup returns whether the statistics of a row group show that none of
+// its rows pass the filter.
+func (r *parquetFileReader) skipRowGroup(ctx context.Context, rg int) (bool, error) {
+	for _, f := range r.filters {
+		min, max, ok, err := r.reader.RowGroupBounds(rg, f.col)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+		if min, err = coerceParquetDatum(ctx, min, r.conv.VisibleColTypes[f.col], r.conv); err != nil {
+			return false, err
+		}
+		if max, err = coerceParquetDatum(ctx, max, r.conv.VisibleColTypes[f.col], r.conv); err != nil {
+			return false, err
+		}
+		if skip, err := f.excludes(r.conv.EvalCtx, min, max); err != nil || skip {
+			return skip, err
+		}
+	}
+	return false, nil
+}
+
+// close implements the foreignFileReader interface.
+func (r *parquetFileReader) close(context.Context) {
+	_ = r.reader.Close()
+	_ = r.src.es.Close()
+}
+
+// coerceParquetDatum converts a datum read from a parquet file to the type of
+// its column. Datums of other types, read from files written by other tools,
+// are converted through their string representation.
+func coerceParquetDatum(
+	ctx context.Context, d tree.Datum, typ *types.T, conv *row.DatumRowConverter,
+) (tree.Datum, error) {
+	if d == tree.DNull {
+		return d, nil
+	}
+	if !d.ResolvedType().Equivalent(typ) {
+		var str string
+		if s, ok := d.(*tree.DString); ok {
+			str = string(*s)
+		} else {
+			str = tree.AsStringWithFlags(d, tree.FmtBareStrings)
+		}
+		var err error
+		if d, err = rowenc.ParseDatumStringAs(ctx, typ, str, conv.EvalCtx, conv.SemaCtx); err != nil {
+			return nil, err
+		}
+	}
+	return tree.AdjustValueToType(typ, d)
+}
+
+// parquetRowGroupFilter is a conjunct of the filter of a foreign table scan
+// that compares a column to a constant.
+type parquetRowGroupFilter struct {
+	// col is the ordinal of the column among the visible columns.
+	col int
+	op  treecmp.ComparisonOperatorSymbol
+	val tree.Datum
+}
+
+// excludes returns whether no value between min and max satisfies the
+// comparison.
+func (f parquetRowGroupFilter) excludes(
+	cmpCtx tree.CompareContext, min, max tree.Datum,
+) (bool, error) {
+	if !min.ResolvedType().Equivalent(f.val.ResolvedType()) {
+		return false, nil
+	}
+	minCmp, err := min.CompareError(cmpCtx, f.val)
+	if err != nil {
+		return false, err
+	}
+	maxCmp, err := max.CompareError(cmpCtx, f.val)
+	if err != nil {
+		return false, err
+	}
+	switch f.op {
+	case treecmp.EQ:
+		return minCmp > 0 || maxCmp < 0, nil
+	case treecmp.LT:
+		return minCmp >= 0, nil
+	case treecmp.LE:
+		return minCmp > 0, nil
+	case treecmp.GT:
+		return maxCmp <= 0, nil
+	case treecmp.GE:
+		return maxCmp < 0, nil
+	}
+	return false, nil
+}
+
+// makeParquetRowGroupFilters returns the conjuncts of the filter that compare
+// a data column to a non-NULL constant.
+func makeParquetRowGroupFilters(
+	filter tree.TypedExpr, outCols []foreignTableColumn,
+) []parquetRowGroupFilter {
+	var res []parquetRowGroupFilter
+	var visit func(e tree.Expr)
+	visit = func(e tree.Expr) {
+		switch t := e.(type) {
+		case *tree.AndExpr:
+			visit(t.Left)
+			visit(t.Right)
+		case *tree.ParenExpr:
+			visit(t.Expr)
+		case *tree.ComparisonExpr:
+			op := t.Operator.Symbol
+			v, isVar := t.Left.(*tree.IndexedVar)
+			d, isConst := t.Right.(tree.Datum)
+			if !isVar {
+				// Normalize "constant op column" to "column op' constant".
+				v, isVar = t.Right.(*tree.IndexedVar)
+				d, isConst = t.Left.(tree.Datum)
+				switch op {
+				case treecmp.LT:
+					op = treecmp.GT
+				case treecmp.LE:
+					op = treecmp.GE
+				case treecmp.GT:
+					op = treecmp.LT
+				case treecmp.GE:
+					op = treecmp.LE
+				}
+			}
+			if !isVar || !isConst || d == tree.DNull || outCols[v.Idx].kind != foreignTableColumnData {
+				return
+			}
+			switch op {
+			case treecmp.EQ, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE:
+				res = append(res, parquetRowGroupFilter{col: outCols[v.Idx].ord, op: op, val: d})
+			}
+		}
+	}
+	visit(filter)
+	return res
+}
+
+// externalStorageReaderAt implements the io.ReaderAt and io.Seeker interfaces
+// needed by the parquet reader on top of a file in external storage, using a
+// ranged read for every ReadAt call.
+type externalStorageReaderAt struct {
+	ctx  context.Context
+	es   cloud.ExternalStorage
+	size int64
+	pos  int64
+}
+
+// ReadAt implements the io.ReaderAt interface.
+func (r *externalStorageReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	raw, _, err := r.es.ReadFile(r.ctx, "", cloud.ReadOptions{
+		Offset:     off,
+		LengthHint: int64(len(p)),
+		NoFileSize: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer raw.Close(r.ctx)
+	n, err := io.ReadFull(ioctx.ReaderCtxAdapter(r.ctx, raw), p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Seek implements the io.Seeker interface.
+func (r *externalStorageReaderAt) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.Newf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.pos = offset
+	return offset, nil
+}
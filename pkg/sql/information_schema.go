@@ -525,8 +525,21 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 				// type is a user defined type, then we should fill this value based on
 				// the schema it is under.
 				udtSchema := pgCatalogNameDString
-				typeMetaName := column.GetType().TypeMeta.Name
-				if typeMetaName != nil {
+				udtType := column.GetType()
+				typeMetaName := udtType.TypeMeta.Name
+				// Columns of domain types report the domain and, like in Postgres,
+				// the base type of the domain as the underlying type.
+				domainCatalog := tree.DNull
+				domainSchema := tree.DNull
+				domainName := tree.DNull
+				if udtType.IsDomain() {
+					if typeMetaName != nil {
+						domainCatalog = tree.NewDString(typeMetaName.Catalog)
+						domainSchema = tree.NewDString(typeMetaName.Schema)
+						domainName = tree.NewDString(typeMetaName.Name)
+					}
+					udtType = udtType.DomainBaseType()
+				} else if typeMetaName != nil {
 					udtSchema = tree.NewDString(typeMetaName.Schema)
 				}
 
@@ -570,18 +583,18 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					collationCatalog,                                          // collation_catalog
 					collationSchema,                                           // collation_schema
 					collationName,                                             // collation_name
-					tree.DNull,                                                // domain_catalog
-					tree.DNull,                                                // domain_schema
-					tree.DNull,                                                // domain_name
+					domainCatalog,                                             // domain_catalog
+					domainSchema,                                              // domain_schema
+					domainName,                                                // domain_name
 					dbNameStr,                                                 // udt_catalog
 					udtSchema,                                                 // udt_schema
-					tree.NewDString(column.GetType().PGName()), // udt_name
-					tree.DNull, // scope_catalog
-					tree.DNull, // scope_schema
-					tree.DNull, // scope_name
-					tree.DNull, // maximum_cardinality
-					tree.DNull, // dtd_identifier
-					tree.DNull, // is_self_referencing
+					tree.NewDString(udtType.PGName()),                         // udt_name
+					tree.DNull,                                                // scope_catalog
+					tree.DNull,                                                // scope_schema
+					tree.DNull,                                                // scope_name
+					tree.DNull,                                                // maximum_cardinality
+					tree.DNull,                                                // dtd_identifier
+					tree.DNull,                                                // is_self_referencing
 					yesOrNoDatum(column.IsGeneratedAsIdentity()), // is_identity
 					colGeneratedAsIdentity,                       // identity_generation
 					identityStart,                                // identity_start
@@ -820,7 +833,8 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 					cols = refTable.ForeignKeyReferencedColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+					// Like in Postgres, exclusion constraints are not included.
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -866,7 +880,8 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+					// Like in Postgres, exclusion constraints are not included.
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1463,6 +1478,10 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					// Postgres does not list exclusion constraints here.
+					if u := c.AsUniqueWithoutIndex(); u != nil && u.UniqueWithoutIndexDesc().IsExclusion() {
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
@@ -1471,16 +1490,17 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					deferrable, initiallyDeferred := constraintDeferrability(c)
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(deferrable),        // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1658,6 +1678,7 @@ var (
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
+	tableTypeForeign    = tree.NewDString("FOREIGN")
 )
 
 var informationSchemaTablesTable = virtualSchemaTable{
@@ -1689,6 +1710,9 @@ func addTablesTableRow(
 		} else if table.IsView() {
 			tableType = tableTypeView
 			insertable = noString
+		} else if table.IsForeignTable() {
+			tableType = tableTypeForeign
+			insertable = noString
 		} else if table.IsTemporary() {
 			tableType = tableTypeTemporary
 		}
@@ -2121,12 +2145,60 @@ var informationSchemaUsagePrivilegesTable = virtualSchemaTable{
 }
 
 var informationSchemaDomainsTable = virtualSchemaTable{
-	comment: "domains was created for compatibility and is currently unimplemented",
-	schema:  vtable.InformationSchemaDomains,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `domains
+https://www.postgresql.org/docs/16/infoschema-domains.html`,
+	schema: vtable.InformationSchemaDomains,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typeDesc catalog.TypeDescriptor) error {
+			domainDesc := typeDesc.AsDomainTypeDescriptor()
+			if domainDesc == nil {
+				return nil
+			}
+			baseType := domainDesc.GetBaseType()
+			dbNameStr := tree.NewDString(db.GetName())
+			collationCatalog := tree.DNull
+			collationSchema := tree.DNull
+			collationName := tree.DNull
+			if locale := baseType.Locale(); locale != "" {
+				collationCatalog = dbNameStr
+				collationSchema = pgCatalogNameDString
+				collationName = tree.NewDString(locale)
+			}
+			domainDefault := tree.DNull
+			if def := domainDesc.GetDefaultExpr(); def != "" {
+				domainDefault = tree.NewDString(def)
+			}
+			return addRow(
+				dbNameStr,                                         // domain_catalog
+				tree.NewDString(sc.GetName()),                     // domain_schema
+				tree.NewDString(typeDesc.GetName()),               // domain_name
+				tree.NewDString(baseType.InformationSchemaName()), // data_type
+				characterMaximumLength(baseType),                  // character_maximum_length
+				characterOctetLength(baseType),                    // character_octet_length
+				tree.DNull,                                        // character_set_catalog
+				tree.DNull,                                        // character_set_schema
+				tree.DNull,                                        // character_set_name
+				collationCatalog,                                  // collation_catalog
+				collationSchema,                                   // collation_schema
+				collationName,                                     // collation_name
+				numericPrecision(baseType),                        // numeric_precision
+				numericPrecisionRadix(baseType),                   // numeric_precision_radix
+				numericScale(baseType),                            // numeric_scale
+				datetimePrecision(baseType),                       // datetime_precision
+				tree.DNull,                                        // interval_type
+				tree.DNull,                                        // interval_precision
+				domainDefault,                                     // domain_default
+				dbNameStr,                                         // udt_catalog
+				pgCatalogNameDString,                              // udt_schema
+				tree.NewDString(baseType.PGName()),                // udt_name
+				tree.DNull,                                        // scope_catalog
+				tree.DNull,                                        // scope_schema
+				tree.DNull,                                        // scope_name
+				tree.DNull,                                        // maximum_cardinality
+				tree.DNull,                                        // dtd_identifier
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaSQLImplementationInfoTable = virtualSchemaTable{
@@ -2901,6 +2973,11 @@ func (r roleOptions) createRole() (tree.DBool, error) {
 	return tree.DBool(createRole), err
 }
 
+func (r roleOptions) bypassRLS() (tree.DBool, error) {
+	bypassRLS, err := r.Exists("BYPASSRLS")
+	return tree.DBool(bypassRLS), err
+}
+
 func forEachRoleQuery(ctx context.Context, p *planner) string {
 	return `
 SELECT
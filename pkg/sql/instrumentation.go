@@ -107,6 +107,11 @@ type instrumentationHelper struct {
 	// isTenant is set when the query is being executed on behalf of a tenant.
 	isTenant bool
 
+	// plpgsqlStmtStats, if set, collects execution statistics for the
+	// statements within PL/pgSQL routines invoked by the query. It is set when
+	// collecting a bundle or when running EXPLAIN ANALYZE (VERBOSE).
+	plpgsqlStmtStats *PLpgSQLStmtStats
+
 	// discardRows is set if we want to discard any results rather than sending
 	// them back to the client. Used for testing/benchmarking. Note that the
 	// resulting schema or the plan are not affected.
@@ -440,6 +445,9 @@ func (ih *instrumentationHelper) Setup(
 		ih.collectBundle, ih.diagRequestID, ih.diagRequest =
 			stmtDiagnosticsRecorder.ShouldCollectDiagnostics(ctx, fingerprint, "" /* planGist */)
 	}
+	if ih.collectBundle || (ih.outputMode != unmodifiedOutput && ih.explainFlags.Verbose) {
+		ih.plpgsqlStmtStats = newPLpgSQLStmtStats(1 /* numShards */, 0 /* maxEntries */)
+	}
 
 	ih.stmtDiagnosticsRecorder = stmtDiagnosticsRecorder
 	ih.withStatementTrace = cfg.TestingKnobs.WithStatementTrace
@@ -526,6 +534,7 @@ func (ih *instrumentationHelper) setupWithPlanGist(
 		ih.needFinish = true
 		ih.collectExecStats = true
 		ih.planGistMatchingBundle = true
+		ih.plpgsqlStmtStats = newPLpgSQLStmtStats(1 /* numShards */, 0 /* maxEntries */)
 		if ih.sp == nil || !ih.sp.IsVerbose() {
 			// We will create a verbose span
 			// - if we don't have a span yet, or
@@ -666,6 +675,7 @@ func (ih *instrumentationHelper) Finish(
 				bundleCtx, ih.explainFlags, cfg.DB, ie.(*InternalExecutor),
 				stmtRawSQL, &p.curPlan, planString, trace, placeholders, res.ErrAllowReleased(),
 				payloadErr, retErr, &p.extendedEvalCtx.Settings.SV, ih.inFlightTraceCollector,
+				ih.plpgsqlStmtStats,
 			)
 			// Include all non-critical errors as warnings. Note that these
 			// error strings might contain PII, but the warnings are only shown
@@ -846,6 +856,12 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	}
 	ob.AddTxnInfo(iso, ih.txnPriority, qos)
 
+	if ih.plpgsqlStmtStats != nil {
+		for _, e := range ih.plpgsqlStmtStats.entries() {
+			ob.AddRoutineStmtStats(e.info.Context(), e.execCount, e.errorCount, e.totalLatency)
+		}
+	}
+
 	if err := emitExplain(ctx, ob, ih.evalCtx, ih.codec, ih.explainPlan); err != nil {
 		ob.AddField("error emitting plan", fmt.Sprint(err))
 	}
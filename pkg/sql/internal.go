@@ -138,6 +138,12 @@ type InternalExecutor struct {
 	// An internal executor, if used with a not nil txn, should be always coupled
 	// with a single connExecutor which runs all passed sql statements.
 	extraTxnState *extraTxnState
+
+	// allowMaterializedViewMutation, if set, allows the statements executed by
+	// this internal executor to mutate materialized views.
+	//
+	// Warning: Not safe for concurrent use from multiple goroutines.
+	allowMaterializedViewMutation bool
 }
 
 // WithSyntheticDescriptors sets the synthetic descriptors before running the
@@ -161,6 +167,18 @@ func (ie *InternalExecutor) WithSyntheticDescriptors(
 	return run()
 }
 
+// withMaterializedViewMutations allows the statements executed by the provided
+// closure to mutate materialized views, and disallows it again afterward. It
+// is used by REFRESH MATERIALIZED VIEW ... INCREMENTAL to apply the changes to
+// the data of the view.
+func (ie *InternalExecutor) withMaterializedViewMutations(run func() error) error {
+	ie.allowMaterializedViewMutation = true
+	defer func() {
+		ie.allowMaterializedViewMutation = false
+	}()
+	return run()
+}
+
 // MakeInternalExecutor creates an InternalExecutor.
 // TODO (janexing): usage of it should be deprecated with `DescsTxnWithExecutor()`
 // or `Executor()`.
@@ -319,6 +337,7 @@ func (ie *InternalExecutor) initConnEx(
 	}
 
 	ex.executorType = executorTypeInternal
+	ex.planner.allowMaterializedViewMutation = ie.allowMaterializedViewMutation
 	return ex, nil
 
 }
@@ -1513,6 +1532,13 @@ func (icc *internalClientComm) CreateCopyOutResult(cmd CopyOut, pos CmdPos) Copy
 	panic("unimplemented")
 }
 
+// CreateStartReplicationResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateStartReplicationResult(
+	cmd StartReplication, pos CmdPos,
+) StartReplicationResult {
+	panic("unimplemented")
+}
+
 // CreateDrainResult is part of the ClientComm interface.
 func (icc *internalClientComm) CreateDrainResult(pos CmdPos) DrainResult {
 	panic("unimplemented")
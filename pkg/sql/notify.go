@@ -0,0 +1,783 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// maxNotificationPayloadLen is the maximum length of a NOTIFY payload. This
+// matches the default limit in Postgres.
+const maxNotificationPayloadLen = 8000
+
+// maxNotificationChannelLen is the maximum length of a notification channel
+// name. Postgres limits channel names to NAMEDATALEN-1 bytes.
+const maxNotificationChannelLen = 63
+
+var maxQueuedNotifications = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.max_queued_per_session",
+	"maximum number of notifications queued for delivery to a listening session; "+
+		"further notifications for the session are dropped until it receives the queued ones",
+	10000,
+	settings.PositiveInt,
+)
+
+// notificationCleanupInterval is the interval at which each node deletes the
+// rows of system.notifications that were not deleted by the notifying session
+// after its transaction committed, e.g. because the node crashed. Only rows
+// older than the interval are deleted.
+const notificationCleanupInterval = 10 * time.Minute
+
+var logDroppedNotification = log.Every(10 * time.Second)
+
+// Notification is an asynchronous notification generated by NOTIFY, to be
+// delivered to the sessions that are listening on its channel.
+type Notification struct {
+	// PID is the backend PID of the session that sent the notification.
+	PID int32
+	// Channel is the name of the channel the notification was sent on.
+	Channel string
+	// Payload is the (possibly empty) payload string of the notification.
+	Payload string
+}
+
+// committedNotification is a notification read from system.notifications,
+// along with the position of its row in the notifying transaction.
+type committedNotification struct {
+	Notification
+	ts    hlc.Timestamp
+	txnID uuid.UUID
+	seq   int64
+}
+
+func (n *committedNotification) less(o *committedNotification) bool {
+	if n.ts != o.ts {
+		return n.ts.Less(o.ts)
+	}
+	if c := bytes.Compare(n.txnID.GetBytes(), o.txnID.GetBytes()); c != 0 {
+		return c < 0
+	}
+	return n.seq < o.seq
+}
+
+// notificationRegistry keeps track of the sessions on this node that are
+// listening on notification channels, and routes notifications to them.
+//
+// NOTIFY writes its notifications to system.notifications in the notifying
+// transaction. The first LISTEN on a node starts a rangefeed on that table,
+// through which the notifications committed on any node are received. They are
+// buffered until the rangefeed frontier passes their commit timestamp, and are
+// then delivered to the local listeners in commit order. The rangefeed is
+// closed once no session on the node is listening anymore.
+type notificationRegistry struct {
+	cfg *ExecutorConfig
+
+	// stopper and ambientCtx are set when the Server is started. LISTEN is not
+	// supported before then.
+	stopper    *stop.Stopper
+	ambientCtx context.Context
+
+	decoder notificationDecoder
+
+	// feedMu serializes the start and the close of the rangefeed.
+	feedMu struct {
+		syncutil.Mutex
+		feed *rangefeed.RangeFeed
+		// refs is the number of references to the rangefeed: one for each
+		// channel that a session listens on, and one for each transaction that
+		// executed LISTEN and has not finished yet. The rangefeed is closed when
+		// the count drops to zero.
+		refs int
+		// stopped is set once the stopper closes the rangefeed. No rangefeed is
+		// started after that.
+		stopped bool
+	}
+
+	mu struct {
+		syncutil.Mutex
+		// channels maps each channel name to the listeners on it, and to the
+		// timestamp at which they started listening. Listeners only receive the
+		// notifications committed after that timestamp.
+		channels map[string]map[*notificationListener]hlc.Timestamp
+		// frontier is the timestamp up to which notifications have been
+		// delivered.
+		frontier hlc.Timestamp
+		// buffered are the notifications received from the rangefeed above the
+		// frontier.
+		buffered []committedNotification
+	}
+}
+
+func newNotificationRegistry(cfg *ExecutorConfig) *notificationRegistry {
+	r := &notificationRegistry{
+		cfg:     cfg,
+		decoder: makeNotificationDecoder(cfg),
+	}
+	r.mu.channels = make(map[string]map[*notificationListener]hlc.Timestamp)
+	return r
+}
+
+// start enables LISTEN on this node, and starts the background deletion of
+// the stale rows of system.notifications.
+func (r *notificationRegistry) start(ctx context.Context, stopper *stop.Stopper) {
+	r.stopper = stopper
+	r.ambientCtx = ctx
+	stopper.AddCloser(stop.CloserFn(func() {
+		r.feedMu.Lock()
+		defer r.feedMu.Unlock()
+		r.feedMu.stopped = true
+		r.closeFeedLocked()
+	}))
+	_ = stopper.RunAsyncTask(ctx, "notifications-cleanup", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		t := time.NewTicker(notificationCleanupInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				if !r.cfg.Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemNotificationsTable) {
+					continue
+				}
+				if _, err := r.cfg.InternalDB.Executor().ExecEx(
+					ctx, "delete-stale-notifications", nil, /* txn */
+					sessiondata.NodeUserSessionDataOverride,
+					`DELETE FROM system.notifications WHERE created_at < now() - $1::INTERVAL`,
+					notificationCleanupInterval,
+				); err != nil {
+					log.Warningf(ctx, "unable to delete stale notifications: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// acquireFeed adds a reference to the rangefeed on system.notifications, and
+// starts it if it is not running yet. Each call must be matched by a call to
+// releaseFeed.
+func (r *notificationRegistry) acquireFeed(ctx context.Context) error {
+	r.feedMu.Lock()
+	defer r.feedMu.Unlock()
+	if r.feedMu.feed == nil {
+		if err := r.startFeedLocked(ctx); err != nil {
+			return err
+		}
+	}
+	r.feedMu.refs++
+	return nil
+}
+
+// retainFeed adds a reference to the rangefeed, which must already be held by
+// the caller through another reference.
+func (r *notificationRegistry) retainFeed() {
+	r.feedMu.Lock()
+	defer r.feedMu.Unlock()
+	r.feedMu.refs++
+}
+
+// releaseFeed removes a reference to the rangefeed, and closes it if it was
+// the last one.
+func (r *notificationRegistry) releaseFeed() {
+	r.feedMu.Lock()
+	defer r.feedMu.Unlock()
+	r.feedMu.refs--
+	if r.feedMu.refs == 0 {
+		r.closeFeedLocked()
+	}
+}
+
+// closeFeedLocked closes the rangefeed, if it is running, and discards the
+// notifications it buffered.
+func (r *notificationRegistry) closeFeedLocked() {
+	if r.feedMu.feed == nil {
+		return
+	}
+	r.feedMu.feed.Close()
+	r.feedMu.feed = nil
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.buffered = nil
+}
+
+// startFeedLocked starts the rangefeed on system.notifications.
+func (r *notificationRegistry) startFeedLocked(ctx context.Context) error {
+	if r.stopper == nil || r.cfg.RangeFeedFactory == nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "LISTEN is not supported on this server")
+	}
+	if r.feedMu.stopped {
+		return pgerror.New(pgcode.AdminShutdown, "the server is shutting down")
+	}
+	tableID, err := r.cfg.SystemTableIDResolver.LookupSystemTableID(
+		ctx, string(catconstants.NotificationsTableName),
+	)
+	if err != nil {
+		return err
+	}
+	start := r.cfg.Clock.Now()
+	func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.mu.frontier = start
+	}()
+	prefix := r.cfg.Codec.TablePrefix(uint32(tableID))
+	feed, err := r.cfg.RangeFeedFactory.RangeFeed(
+		r.ambientCtx, "notifications",
+		[]roachpb.Span{{Key: prefix, EndKey: prefix.PrefixEnd()}},
+		start,
+		r.onValue,
+		rangefeed.WithSystemTablePriority(),
+		rangefeed.WithOnFrontierAdvance(r.onFrontierAdvance),
+	)
+	if err != nil {
+		return err
+	}
+	r.feedMu.feed = feed
+	return nil
+}
+
+// onValue buffers the notifications received from the rangefeed until the
+// frontier passes their commit timestamp.
+func (r *notificationRegistry) onValue(ctx context.Context, value *kvpb.RangeFeedValue) {
+	if !value.Value.IsPresent() {
+		// The row was deleted after the notification was delivered.
+		return
+	}
+	n, err := r.decoder.decode(value)
+	if err != nil {
+		log.Warningf(ctx, "unable to decode notification: %v", err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n.ts.LessEq(r.mu.frontier) {
+		// The notification was already delivered.
+		return
+	}
+	if _, ok := r.mu.channels[n.Channel]; !ok {
+		return
+	}
+	r.mu.buffered = append(r.mu.buffered, n)
+}
+
+// onFrontierAdvance delivers the buffered notifications committed at or below
+// the new frontier to the listeners on their channel.
+func (r *notificationRegistry) onFrontierAdvance(ctx context.Context, frontier hlc.Timestamp) {
+	// The listeners are woken up after releasing the registry lock, since
+	// pushing to their StmtBuf may block.
+	for _, l := range r.deliver(ctx, frontier) {
+		l.wakeup(ctx)
+	}
+}
+
+func (r *notificationRegistry) deliver(
+	ctx context.Context, frontier hlc.Timestamp,
+) (wakeups []*notificationListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if frontier.LessEq(r.mu.frontier) {
+		return nil
+	}
+	r.mu.frontier = frontier
+	var ready []committedNotification
+	remaining := r.mu.buffered[:0]
+	for _, n := range r.mu.buffered {
+		if n.ts.LessEq(frontier) {
+			ready = append(ready, n)
+		} else {
+			remaining = append(remaining, n)
+		}
+	}
+	r.mu.buffered = remaining
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i].less(&ready[j]) })
+	limit := int(maxQueuedNotifications.Get(&r.cfg.Settings.SV))
+	for i := range ready {
+		n := &ready[i]
+		if i > 0 && !ready[i-1].less(n) {
+			// The rangefeed emitted the same row twice.
+			continue
+		}
+		for l, since := range r.mu.channels[n.Channel] {
+			if n.ts.LessEq(since) {
+				continue
+			}
+			if l.enqueue(ctx, n.Notification, limit) {
+				wakeups = append(wakeups, l)
+			}
+		}
+	}
+	return wakeups
+}
+
+func (r *notificationRegistry) register(l *notificationListener, channel string) {
+	now := r.cfg.Clock.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners, ok := r.mu.channels[channel]
+	if !ok {
+		listeners = make(map[*notificationListener]hlc.Timestamp)
+		r.mu.channels[channel] = listeners
+	}
+	listeners[l] = now
+}
+
+func (r *notificationRegistry) deregister(l *notificationListener, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners := r.mu.channels[channel]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.channels, channel)
+	}
+}
+
+// notificationDecoder decodes the rows of system.notifications received from
+// the rangefeed.
+type notificationDecoder struct {
+	cfg     *ExecutorConfig
+	columns []catalog.Column
+	decoder valueside.Decoder
+}
+
+func makeNotificationDecoder(cfg *ExecutorConfig) notificationDecoder {
+	columns := systemschema.NotificationsTable.PublicColumns()
+	return notificationDecoder{
+		cfg:     cfg,
+		columns: columns,
+		decoder: valueside.MakeDecoder(columns),
+	}
+}
+
+func (d *notificationDecoder) decode(value *kvpb.RangeFeedValue) (committedNotification, error) {
+	var alloc tree.DatumAlloc
+	n := committedNotification{ts: value.Timestamp()}
+
+	// The transaction ID and the sequence number are decoded from the key.
+	keyVals := make([]rowenc.EncDatum, 2)
+	if _, err := rowenc.DecodeIndexKey(d.cfg.Codec, keyVals, nil, value.Key); err != nil {
+		return n, errors.Wrap(err, "failed to decode key")
+	}
+	for i, typ := range []*types.T{d.columns[0].GetType(), d.columns[1].GetType()} {
+		if err := keyVals[i].EnsureDecoded(typ, &alloc); err != nil {
+			return n, err
+		}
+	}
+	n.txnID = tree.MustBeDUuid(keyVals[0].Datum).UUID
+	n.seq = int64(tree.MustBeDInt(keyVals[1].Datum))
+
+	// The rest of the columns are stored as a family.
+	b, err := value.Value.GetTuple()
+	if err != nil {
+		return n, err
+	}
+	datums, err := d.decoder.Decode(&alloc, b)
+	if err != nil {
+		return n, err
+	}
+	n.Channel = string(tree.MustBeDString(datums[2]))
+	n.Payload = string(tree.MustBeDString(datums[3]))
+	n.PID = int32(tree.MustBeDInt(datums[4]))
+	return n, nil
+}
+
+// notificationListener is the per-session state used to receive
+// notifications.
+type notificationListener struct {
+	registry *notificationRegistry
+	stmtBuf  *StmtBuf
+
+	// channels is the set of channels the session is listening on. It is only
+	// accessed by the session's goroutine.
+	channels map[string]struct{}
+
+	mu struct {
+		syncutil.Mutex
+		// pending are the notifications received but not yet sent to the client.
+		pending []Notification
+		// wakeupPending is set if a DeliverNotifications command was pushed to
+		// the session's StmtBuf and the pending notifications have not been
+		// consumed since.
+		wakeupPending bool
+	}
+}
+
+// enqueue adds a notification to the pending list, unless it already holds
+// limit notifications. It returns true if the session needs to be woken up so
+// that the notification can be sent to the client even if the session is
+// idle.
+func (l *notificationListener) enqueue(ctx context.Context, n Notification, limit int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.mu.pending) >= limit {
+		if logDroppedNotification.ShouldLog() {
+			log.Warningf(ctx, "dropping notification on channel %q: "+
+				"%d notifications are already queued for the session (see %s)",
+				n.Channel, len(l.mu.pending), maxQueuedNotifications.Name())
+		}
+		return false
+	}
+	l.mu.pending = append(l.mu.pending, n)
+	if l.mu.wakeupPending {
+		return false
+	}
+	l.mu.wakeupPending = true
+	return true
+}
+
+// wakeup pushes a DeliverNotifications command to the session's StmtBuf.
+func (l *notificationListener) wakeup(ctx context.Context) {
+	if err := l.stmtBuf.Push(ctx, DeliverNotifications{}); err != nil {
+		// The session is shutting down.
+		log.VEventf(ctx, 2, "unable to deliver notification: %v", err)
+	}
+}
+
+// takePending returns the notifications that have not been sent to the client
+// yet and clears the pending list.
+func (l *notificationListener) takePending() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.mu.pending
+	l.mu.pending = nil
+	l.mu.wakeupPending = false
+	return pending
+}
+
+// listen registers the session as a listener on the given channel. The
+// caller must hold a reference to the rangefeed (see acquireFeed).
+func (l *notificationListener) listen(channel string) {
+	if _, ok := l.channels[channel]; ok {
+		return
+	}
+	l.channels[channel] = struct{}{}
+	l.registry.retainFeed()
+	l.registry.register(l, channel)
+}
+
+func (l *notificationListener) unlisten(channel string) {
+	if _, ok := l.channels[channel]; !ok {
+		return
+	}
+	delete(l.channels, channel)
+	l.registry.deregister(l, channel)
+	l.registry.releaseFeed()
+}
+
+func (l *notificationListener) unlistenAll() {
+	for channel := range l.channels {
+		l.unlisten(channel)
+	}
+}
+
+// listenAction is a LISTEN or UNLISTEN executed by the current transaction.
+// As in Postgres, they only take effect when the transaction commits.
+type listenAction struct {
+	channel string
+	// unlisten is set for UNLISTEN.
+	unlisten bool
+	// all is set for UNLISTEN *.
+	all bool
+}
+
+// notifications is the interface used by the planner to access the
+// LISTEN/NOTIFY state of the session.
+type notifications interface {
+	// listen registers the session as a listener on the given channel when the
+	// current transaction commits.
+	listen(ctx context.Context, channel string) error
+	// unlisten stops listening on the given channel when the current
+	// transaction commits.
+	unlisten(channel string) error
+	// unlistenAll stops listening on all channels when the current transaction
+	// commits.
+	unlistenAll() error
+	// queue records a notification sent by the current transaction. It returns
+	// the notification along with its position in the transaction, or false if
+	// the transaction already sent an identical notification.
+	queue(channel, payload string) (_ Notification, seq int, _ bool, _ error)
+	// numQueued returns the number of notifications queued by the current
+	// transaction.
+	numQueued() int
+	// discardQueuedAfter discards the notifications queued after the first n
+	// ones, when the writes of the statements that queued them are rolled back.
+	discardQueuedAfter(n int)
+}
+
+type connExNotificationsAccessor struct {
+	ex *connExecutor
+}
+
+var _ notifications = connExNotificationsAccessor{}
+
+func (c connExNotificationsAccessor) listener() *notificationListener {
+	if c.ex.notificationListener == nil {
+		// Lazily allocate.
+		c.ex.notificationListener = &notificationListener{
+			registry: c.ex.server.notifications,
+			stmtBuf:  c.ex.stmtBuf,
+			channels: make(map[string]struct{}),
+		}
+	}
+	return c.ex.notificationListener
+}
+
+func (c connExNotificationsAccessor) listen(ctx context.Context, channel string) error {
+	if c.ex.executorType == executorTypeInternal {
+		// The internal executor has no client to deliver notifications to.
+		return pgerror.New(pgcode.FeatureNotSupported, "LISTEN is not supported by the internal executor")
+	}
+	// The rangefeed is started right away, so that an error is reported by the
+	// LISTEN statement rather than at commit time. The transaction holds a
+	// reference to it until it finishes, so that it is still running when the
+	// session starts listening at commit.
+	if !c.ex.extraTxnState.holdsNotificationFeed {
+		if err := c.ex.server.notifications.acquireFeed(ctx); err != nil {
+			return err
+		}
+		c.ex.extraTxnState.holdsNotificationFeed = true
+	}
+	c.ex.extraTxnState.listenActions = append(
+		c.ex.extraTxnState.listenActions, listenAction{channel: channel},
+	)
+	return nil
+}
+
+func (c connExNotificationsAccessor) unlisten(channel string) error {
+	c.ex.extraTxnState.listenActions = append(
+		c.ex.extraTxnState.listenActions, listenAction{channel: channel, unlisten: true},
+	)
+	return nil
+}
+
+func (c connExNotificationsAccessor) unlistenAll() error {
+	c.ex.extraTxnState.listenActions = append(
+		c.ex.extraTxnState.listenActions, listenAction{unlisten: true, all: true},
+	)
+	return nil
+}
+
+func (c connExNotificationsAccessor) queue(
+	channel, payload string,
+) (Notification, int, bool, error) {
+	n := Notification{
+		PID:     int32(c.ex.queryCancelKey.GetPGBackendPID()),
+		Channel: channel,
+		Payload: payload,
+	}
+	// Postgres folds duplicate notifications sent in the same transaction.
+	for _, pending := range c.ex.extraTxnState.notifications {
+		if pending == n {
+			return n, 0, false, nil
+		}
+	}
+	c.ex.extraTxnState.notifications = append(c.ex.extraTxnState.notifications, n)
+	c.ex.extraTxnState.notificationsTxnID = c.ex.state.mu.txn.ID()
+	return n, len(c.ex.extraTxnState.notifications) - 1, true, nil
+}
+
+func (c connExNotificationsAccessor) numQueued() int {
+	return len(c.ex.extraTxnState.notifications)
+}
+
+func (c connExNotificationsAccessor) discardQueuedAfter(n int) {
+	c.ex.discardNotificationsAfter(n)
+}
+
+// emptyNotifications is the default impl used by the planner when the
+// connExecutor is not available.
+type emptyNotifications struct{}
+
+var _ notifications = emptyNotifications{}
+
+func (emptyNotifications) listen(context.Context, string) error {
+	return errors.AssertionFailedf("listen not supported in emptyNotifications")
+}
+
+func (emptyNotifications) unlisten(string) error {
+	return errors.AssertionFailedf("unlisten not supported in emptyNotifications")
+}
+
+func (emptyNotifications) unlistenAll() error {
+	return errors.AssertionFailedf("unlistenAll not supported in emptyNotifications")
+}
+
+func (emptyNotifications) queue(string, string) (Notification, int, bool, error) {
+	return Notification{}, 0, false, errors.AssertionFailedf("queue not supported in emptyNotifications")
+}
+
+func (emptyNotifications) numQueued() int { return 0 }
+
+func (emptyNotifications) discardQueuedAfter(int) {}
+
+// SendNotification is part of the eval.Planner interface. It writes the
+// notification to system.notifications in the current transaction, so that it
+// is delivered to the listening sessions on all nodes if the transaction
+// commits.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxNotificationChannelLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	if len(payload) >= maxNotificationPayloadLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemNotificationsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"NOTIFY is not supported until the cluster version is finalized")
+	}
+	n, seq, ok, err := p.notifications.queue(channel, payload)
+	if err != nil || !ok {
+		return err
+	}
+	_, err = p.InternalSQLTxn().ExecEx(
+		ctx, "notify", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (txn_id, seq, channel, payload, pid, created_at)
+VALUES ($1, $2, $3, $4, $5, now())`,
+		tree.NewDUuid(tree.DUuid{UUID: p.Txn().ID()}), seq, n.Channel, n.Payload, n.PID,
+	)
+	return err
+}
+
+// deleteSent deletes the rows written to system.notifications by the given
+// transaction, which just committed. The rangefeeds that deliver the
+// notifications read the committed rows from the MVCC history, so the rows are
+// no longer needed.
+func (r *notificationRegistry) deleteSent(txnID uuid.UUID) {
+	if r.stopper == nil {
+		return
+	}
+	ctx := r.ambientCtx
+	if err := r.stopper.RunAsyncTask(ctx, "delete-sent-notifications", func(ctx context.Context) {
+		if _, err := r.cfg.InternalDB.Executor().ExecEx(
+			ctx, "delete-sent-notifications", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE txn_id = $1`,
+			tree.NewDUuid(tree.DUuid{UUID: txnID}),
+		); err != nil {
+			// The rows are eventually deleted by the periodic cleanup.
+			log.VEventf(ctx, 2, "unable to delete sent notifications: %v", err)
+		}
+	}); err != nil {
+		log.VEventf(ctx, 2, "unable to delete sent notifications: %v", err)
+	}
+}
+
+// applyListenActions applies the LISTEN and UNLISTEN statements executed by
+// the transaction that just committed.
+func (ex *connExecutor) applyListenActions() {
+	if len(ex.extraTxnState.listenActions) == 0 {
+		return
+	}
+	l := connExNotificationsAccessor{ex: ex}.listener()
+	for _, a := range ex.extraTxnState.listenActions {
+		switch {
+		case a.all:
+			l.unlistenAll()
+		case a.unlisten:
+			l.unlisten(a.channel)
+		default:
+			l.listen(a.channel)
+		}
+	}
+}
+
+// releaseNotificationFeed releases the reference to the notifications
+// rangefeed held by the transaction that just finished, if it executed LISTEN.
+func (ex *connExecutor) releaseNotificationFeed() {
+	if ex.extraTxnState.holdsNotificationFeed {
+		ex.server.notifications.releaseFeed()
+		ex.extraTxnState.holdsNotificationFeed = false
+	}
+}
+
+// bufferPendingNotifications adds the notifications received by the session
+// to the given result, so that they are sent to the client when the result is
+// closed.
+func (ex *connExecutor) bufferPendingNotifications(res NotificationResult) {
+	if ex.notificationListener == nil {
+		return
+	}
+	for _, n := range ex.notificationListener.takePending() {
+		res.BufferNotification(n)
+	}
+}
+
+type listenNode struct {
+	n *tree.Listen
+}
+
+// Listen implements the LISTEN statement. The session starts listening on the
+// channel once the current transaction commits.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemNotificationsTable) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"LISTEN is not supported until the cluster version is finalized")
+	}
+	return &listenNode{n: n}, nil
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	return params.p.notifications.listen(params.ctx, string(n.n.ChannelName))
+}
+
+func (n *listenNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *listenNode) Close(ctx context.Context)           {}
+
+type notifyNode struct {
+	n *tree.Notify
+}
+
+// Notify implements the NOTIFY statement. The notification is only sent once
+// the current transaction commits.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	return &notifyNode{n: n}, nil
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.SendNotification(params.ctx, string(n.n.ChannelName), n.n.Payload)
+}
+
+func (n *notifyNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *notifyNode) Close(ctx context.Context)           {}
@@ -0,0 +1,96 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotificationFeedLifetime checks that the rangefeed on
+// system.notifications is only running while a session on the node listens on
+// a channel, or a transaction that executed LISTEN is open.
+func TestNotificationFeedLifetime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	tt := s.ApplicationLayer()
+	r := tt.SQLServer().(*Server).notifications
+
+	feedRunning := func() bool {
+		r.feedMu.Lock()
+		defer r.feedMu.Unlock()
+		return r.feedMu.feed != nil
+	}
+	requireFeed := func(running bool) {
+		t.Helper()
+		// The session releases its references after the results of the
+		// statement are sent to the client.
+		testutils.SucceedsSoon(t, func() error {
+			if feedRunning() != running {
+				return errors.Newf("expected rangefeed running=%t", running)
+			}
+			return nil
+		})
+	}
+
+	// Each connection pool holds a single session.
+	conn1 := tt.SQLConn(t)
+	conn1.SetMaxOpenConns(1)
+	conn2 := tt.SQLConn(t)
+	conn2.SetMaxOpenConns(1)
+
+	require.False(t, feedRunning())
+
+	// The rangefeed is started by LISTEN, and closed when the transaction that
+	// executed it rolls back.
+	_, err := conn1.Exec("BEGIN; LISTEN foo")
+	require.NoError(t, err)
+	requireFeed(true)
+	_, err = conn1.Exec("ROLLBACK")
+	require.NoError(t, err)
+	requireFeed(false)
+
+	// The rangefeed keeps running while any session listens on a channel.
+	_, err = conn1.Exec("LISTEN foo")
+	require.NoError(t, err)
+	_, err = conn2.Exec("LISTEN foo")
+	require.NoError(t, err)
+	_, err = conn2.Exec("LISTEN bar")
+	require.NoError(t, err)
+	requireFeed(true)
+	_, err = conn2.Exec("UNLISTEN *")
+	require.NoError(t, err)
+	requireFeed(true)
+
+	// The rangefeed is closed when the last listening session goes away.
+	require.NoError(t, conn1.Close())
+	requireFeed(false)
+
+	// It is started again by the next LISTEN.
+	_, err = conn2.Exec("LISTEN foo")
+	require.NoError(t, err)
+	requireFeed(true)
+	_, err = conn2.Exec("UNLISTEN foo")
+	require.NoError(t, err)
+	requireFeed(false)
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// BlockRangeIndex is an interface to a block range index, created with CREATE
+// INDEX ... USING brin. A block range index stores the minimum and maximum
+// value of a column for each block of consecutive values of the leading
+// primary key column of the table. It cannot be scanned by itself; instead, a
+// scan of the primary index can use it to skip the blocks that cannot contain
+// rows satisfying a constraint on the column.
+type BlockRangeIndex interface {
+	// ID is the stable identifier for this index that is guaranteed to be
+	// unique within the owning table.
+	ID() StableID
+
+	// Name is the name of the index.
+	Name() tree.Name
+
+	// ColumnOrdinal returns the ordinal of the summarized column within the
+	// table.
+	ColumnOrdinal() int
+
+	// RangeSize returns the number of values of the leading primary key column
+	// that each block spans.
+	RangeSize() int64
+}
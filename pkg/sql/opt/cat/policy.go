@@ -0,0 +1,93 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Policy is an interface to a row-level security policy defined on a table
+// with CREATE POLICY. When row-level security is enabled on a table, a row is
+// only visible to (or may only be written by) a user if the policies that
+// apply to the user and the command allow it.
+type Policy interface {
+	// Name is the name of the policy.
+	Name() tree.Name
+
+	// IsRestrictive returns true if the policy is combined with the other
+	// policies using AND rather than OR.
+	IsRestrictive() bool
+
+	// Command returns the command to which the policy applies.
+	Command() tree.PolicyCommand
+
+	// RoleCount returns the number of roles to which the policy applies. The
+	// policy applies to all roles if it is zero.
+	RoleCount() int
+
+	// Role returns the ith role to which the policy applies.
+	Role(i int) username.SQLUsername
+
+	// UsingExpr returns the SQL text of the USING expression of the policy, or
+	// the empty string if there is none. It filters the existing rows of the
+	// table.
+	UsingExpr() string
+
+	// WithCheckExpr returns the SQL text of the WITH CHECK expression of the
+	// policy, or the empty string if there is none. It restricts the new rows
+	// that can be written to the table.
+	WithCheckExpr() string
+}
+
+// ApplicablePolicies returns the ordinals of the row-level security policies
+// of the given table that apply to the current user and the given command. The
+// returned enforced value is false if row-level security does not apply to
+// the current user at all, either because it is not enabled on the table or
+// because the user owns the table (and row-level security is not forced) or
+// has the BYPASSRLS role option. If enforced is true and no policy applies,
+// then no rows are visible or writable.
+func ApplicablePolicies(
+	ctx context.Context, catalog Catalog, tab Table, cmd tree.PolicyCommand,
+) (ords []int, enforced bool, err error) {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return nil, false, nil
+	}
+	bypass, err := catalog.HasRoleOption(ctx, roleoption.BYPASSRLS)
+	if err != nil || bypass {
+		return nil, false, err
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		isOwner, err := catalog.HasOwnership(ctx, tab)
+		if err != nil || isOwner {
+			return nil, false, err
+		}
+	}
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		p := tab.Policy(i)
+		if p.Command() != tree.PolicyCommandAll && p.Command() != cmd {
+			continue
+		}
+		applies := p.RoleCount() == 0
+		for j := 0; j < p.RoleCount() && !applies; j++ {
+			if applies, err = catalog.IsMemberOfRole(ctx, p.Role(j)); err != nil {
+				return nil, false, err
+			}
+		}
+		if applies {
+			ords = append(ords, i)
+		}
+	}
+	return ords, true, nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -51,6 +52,11 @@ type Table interface {
 	// that they cannot be mutated.
 	IsMaterializedView() bool
 
+	// IsForeignTable returns true if this table is a foreign table, whose rows
+	// are read from files in external storage. Foreign tables cannot be
+	// mutated, and their rows cannot be looked up by key.
+	IsForeignTable() bool
+
 	// ColumnCount returns the number of columns in the table. This includes
 	// public columns, write-only columns, etc.
 	ColumnCount() int
@@ -180,6 +186,39 @@ type Table interface {
 	// IsHypothetical returns true if this is a hypothetical table (used when
 	// searching for index recommendations).
 	IsHypothetical() bool
+
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table, in which case its policies restrict the rows that users
+	// other than the owner can access.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if the row-level security policies
+	// of the table also apply to the table owner.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// the table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy, where
+	// i < PolicyCount.
+	Policy(i int) Policy
+
+	// TriggerCount returns the number of triggers defined on the table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount. Triggers are
+	// ordered by name, which is the order in which they fire.
+	Trigger(i int) Trigger
+
+	// BlockRangeIndexCount returns the number of block range indexes of the
+	// table that scans can use. Block range indexes are not included in
+	// IndexCount.
+	BlockRangeIndexCount() int
+
+	// BlockRangeIndex returns the ith block range index, where
+	// i < BlockRangeIndexCount.
+	BlockRangeIndex(i int) BlockRangeIndex
 }
 
 // CheckConstraint represents a check constraint on a table. Check constraints
@@ -328,6 +367,15 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrable is true if the checks for the constraint can be postponed until
+	// the end of the transaction.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if the checks for the constraint are postponed
+	// until the end of the transaction unless SET CONSTRAINTS specifies
+	// otherwise. It implies Deferrable.
+	InitiallyDeferred() bool
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -375,6 +423,26 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrable is true if the checks for the constraint can be postponed until
+	// the end of the transaction.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if the checks for the constraint are postponed
+	// until the end of the transaction unless SET CONSTRAINTS specifies
+	// otherwise. It implies Deferrable.
+	InitiallyDeferred() bool
+
+	// IsExclusion is true if this is an EXCLUDE constraint. Two rows conflict
+	// under an exclusion constraint if the operators returned by
+	// ExclusionOperator are true for all of the columns, rather than if all of
+	// the columns are equal. Exclusion constraints are never enforced by an
+	// index.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator used to compare the ith column of
+	// an exclusion constraint. It must only be called if IsExclusion is true.
+	ExclusionOperator(i int) treecmp.ComparisonOperator
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
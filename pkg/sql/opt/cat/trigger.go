@@ -0,0 +1,72 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/lib/pq/oid"
+)
+
+// Trigger is an interface to a trigger defined on a table with CREATE
+// TRIGGER. A trigger executes a function for each row modified by an INSERT,
+// UPDATE or DELETE, either before or after the row is modified.
+type Trigger interface {
+	// Name is the name of the trigger. It is unique within the table.
+	Name() tree.Name
+
+	// ActionTime returns whether the trigger fires before or after the row is
+	// modified.
+	ActionTime() tree.TriggerActionTime
+
+	// EventCount returns the number of events that cause the trigger to fire.
+	EventCount() int
+
+	// Event returns the ith event that causes the trigger to fire, where
+	// i < EventCount.
+	Event(i int) TriggerEvent
+
+	// ForEachRow returns true if the trigger fires once for each row, rather
+	// than once for each statement.
+	ForEachRow() bool
+
+	// WhenExpr returns the SQL text of the WHEN condition of the trigger, or
+	// the empty string if there is none. The condition can refer to the NEW
+	// and OLD rows.
+	WhenExpr() string
+
+	// FuncOID returns the OID of the function that the trigger executes.
+	FuncOID() oid.Oid
+
+	// FuncArgs returns the arguments that are passed to the trigger function
+	// through TG_ARGV.
+	FuncArgs() []string
+}
+
+// TriggerEvent is an event that causes a trigger to fire.
+type TriggerEvent struct {
+	// EventType is the type of statement that fires the trigger.
+	EventType tree.TriggerEventType
+
+	// Columns is the list of columns for an UPDATE OF event. The trigger only
+	// fires if one of these columns is the target of the UPDATE. It is empty
+	// for other events, and for an UPDATE event that fires for every update.
+	Columns []StableID
+}
+
+// HasTriggerEvent returns true if the trigger fires for the given event type.
+func HasTriggerEvent(trig Trigger, eventType tree.TriggerEventType) bool {
+	for i, n := 0, trig.EventCount(); i < n; i++ {
+		if trig.Event(i).EventType == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -234,6 +234,15 @@ func New(
 	return b
 }
 
+// PlanAsRoutineStatement configures the Builder to build a statement that is
+// planned and executed from within a routine, like the statements in the body
+// of a UDF. Subqueries are planned as lazily evaluated routines, and telemetry
+// is not incremented.
+func (b *Builder) PlanAsRoutineStatement() {
+	b.disableTelemetry = true
+	b.planLazySubqueries = true
+}
+
 // Build constructs the execution node tree and returns its root node if no
 // error occurred.
 func (b *Builder) Build() (_ exec.Plan, err error) {
@@ -135,6 +135,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	return ep, outputCols, nil
 }
 
@@ -158,6 +162,23 @@ func (b *Builder) tryBuildFastPathInsert(
 	if len(ins.UniqueChecks) != len(ins.FastPathUniqueChecks) {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// Checks of deferrable constraints may need to be postponed until the end
+	// of the transaction, which the fast path does not support.
+	for i := range ins.UniqueChecks {
+		if ins.UniqueChecks[i].Deferrable {
+			return execPlan{}, colOrdMap{}, false, nil
+		}
+	}
+	for i := range ins.FKChecks {
+		if ins.FKChecks[i].Deferrable {
+			return execPlan{}, colOrdMap{}, false, nil
+		}
+	}
+	// AFTER triggers are planned as cascades, which the fast path does not
+	// run.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -473,7 +494,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
 	cnt := len(ups.InsertCols) + len(ups.FetchCols) + len(ups.UpdateCols) + len(ups.CheckCols) +
-		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 1
+		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 2
 	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, ups.InsertCols)
 	colList = appendColsWhenPresent(colList, ups.FetchCols)
@@ -481,6 +502,9 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 	if ups.CanaryCol != 0 {
 		colList = append(colList, ups.CanaryCol)
 	}
+	if ups.MergeActionCol != 0 {
+		colList = append(colList, ups.MergeActionCol)
+	}
 	colList = appendColsWhenPresent(colList, ups.CheckCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexPutCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexDelCols)
@@ -500,6 +524,13 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 			return execPlan{}, colOrdMap{}, err
 		}
 	}
+	mergeActionCol := exec.NodeColumnOrdinal(-1)
+	if ups.MergeActionCol != 0 {
+		mergeActionCol, err = getNodeColumnOrdinal(inputCols, ups.MergeActionCol)
+		if err != nil {
+			return execPlan{}, colOrdMap{}, err
+		}
+	}
 	insertColOrds := ordinalSetFromColList(ups.InsertCols)
 	fetchColOrds := ordinalSetFromColList(ups.FetchCols)
 	updateColOrds := ordinalSetFromColList(ups.UpdateCols)
@@ -511,6 +542,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 		ups.ArbiterIndexes,
 		ups.ArbiterConstraints,
 		canaryCol,
+		mergeActionCol,
 		insertColOrds,
 		fetchColOrds,
 		updateColOrds,
@@ -775,13 +807,21 @@ func (b *Builder) checkContainsLocking(mainContainsLocking bool) {
 //
 // The checks consist of queries that will only return rows if a constraint is
 // violated. Those queries are each wrapped in an ErrorIfRows operator, which
-// will throw an appropriate error in case the inner query returns any rows.
+// will throw an appropriate error in case the inner query returns any rows. The
+// violations of a deferred constraint are recorded instead (see
+// maybeDeferCheck).
 func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 	defer b.checkContainsLocking(b.flags.IsSet(exec.PlanFlagContainsLocking))
 	b.flags.Unset(exec.PlanFlagContainsLocking)
 	md := b.mem.Metadata()
 	for i := range checks {
 		c := &checks[i]
+		var deferKey func(tree.Datums)
+		if c.Deferrable {
+			tab := md.Table(c.Table)
+			uc := tab.Unique(c.CheckOrdinal)
+			deferKey = b.maybeDeferCheck(tab, uc.Name(), uc.InitiallyDeferred())
+		}
 		// Construct the query that returns uniqueness violations.
 		query, queryCols, err := b.buildRelational(c.Check)
 		if err != nil {
@@ -797,6 +837,10 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if deferKey != nil {
+				deferKey(keyVals)
+				return nil
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -814,6 +858,18 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	md := b.mem.Metadata()
 	for i := range checks {
 		c := &checks[i]
+		var deferKey func(tree.Datums)
+		if c.Deferrable {
+			// The constraint is always registered on the origin table.
+			originTab := md.Table(c.OriginTable)
+			var fk cat.ForeignKeyConstraint
+			if c.FKOutbound {
+				fk = originTab.OutboundForeignKey(c.FKOrdinal)
+			} else {
+				fk = md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+			}
+			deferKey = b.maybeDeferCheck(originTab, fk.Name(), fk.InitiallyDeferred())
+		}
 		// Construct the query that returns FK violations.
 		query, queryCols, err := b.buildRelational(c.Check)
 		if err != nil {
@@ -829,6 +885,10 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if deferKey != nil {
+				deferKey(keyVals)
+				return nil
+			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -840,6 +900,26 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// maybeDeferCheck returns a non-nil function if the check for the given
+// deferrable constraint on the given table is postponed until the end of the
+// transaction, based on the constraint modes of the session. In that case the
+// check query is still run, but instead of returning an error, each violating
+// row is passed to the function, which registers its key with the planner so
+// that it can be validated again later.
+func (b *Builder) maybeDeferCheck(
+	tab cat.Table, constraintName string, initiallyDeferred bool,
+) func(key tree.Datums) {
+	planner := b.evalCtx.Planner
+	if planner == nil || !planner.CanDeferConstraintChecks() ||
+		!b.evalCtx.SessionData().ConstraintModes.IsDeferred(constraintName, initiallyDeferred) {
+		return nil
+	}
+	tableID := int(tab.ID())
+	return func(key tree.Datums) {
+		planner.DeferConstraintCheck(b.ctx, tableID, constraintName, key)
+	}
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
@@ -852,7 +932,17 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	// Generate an error of the form:
 	//   ERROR:  duplicate key value violates unique constraint "foo"
 	//   DETAIL: Key (k)=(2) already exists.
-	msg.WriteString("duplicate key value violates unique constraint ")
+	//
+	// or, for exclusion constraints:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with an existing key.
+	code := pgcode.UniqueViolation
+	if uc.IsExclusion() {
+		code = pgcode.ExclusionViolation
+		msg.WriteString("conflicting key value violates exclusion constraint ")
+	} else {
+		msg.WriteString("duplicate key value violates unique constraint ")
+	}
 	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
 
 	details.WriteString("Key (")
@@ -871,11 +961,15 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 		details.WriteString(d.String())
 	}
 
-	details.WriteString(") already exists.")
+	if uc.IsExclusion() {
+		details.WriteString(") conflicts with an existing key.")
+	} else {
+		details.WriteString(") already exists.")
+	}
 
 	return errors.WithDetail(
 		pgerror.WithConstraintName(
-			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
+			pgerror.Newf(code, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
@@ -244,6 +244,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (_ execPlan, outputCols colOrd
 	case *memo.ProjectSetExpr:
 		ep, outputCols, err = b.buildProjectSet(t)
 
+	case *memo.ExpandExpr:
+		ep, outputCols, err = b.buildExpand(t)
+
 	case *memo.WindowExpr:
 		ep, outputCols, err = b.buildWindow(t)
 
@@ -705,17 +708,24 @@ func (b *Builder) scanParams(
 		return exec.ScanParams{}, colOrdMap{}, errors.AssertionFailedf("scan can't provide required ordering")
 	}
 
+	var blockRangeIndex cat.BlockRangeIndex
+	if scan.BlockRangeConstraint != nil {
+		blockRangeIndex = tab.BlockRangeIndex(scan.BlockRangeIndex)
+	}
+
 	return exec.ScanParams{
-		NeededCols:         needed,
-		IndexConstraint:    scan.Constraint,
-		InvertedConstraint: scan.InvertedConstraint,
-		HardLimit:          hardLimit,
-		SoftLimit:          softLimit,
-		Reverse:            reverse,
-		Parallelize:        parallelize,
-		Locking:            locking,
-		EstimatedRowCount:  rowCount,
-		LocalityOptimized:  scan.LocalityOptimized,
+		NeededCols:           needed,
+		IndexConstraint:      scan.Constraint,
+		InvertedConstraint:   scan.InvertedConstraint,
+		BlockRangeIndex:      blockRangeIndex,
+		BlockRangeConstraint: scan.BlockRangeConstraint,
+		HardLimit:            hardLimit,
+		SoftLimit:            softLimit,
+		Reverse:              reverse,
+		Parallelize:          parallelize,
+		Locking:              locking,
+		EstimatedRowCount:    rowCount,
+		LocalityOptimized:    scan.LocalityOptimized,
 	}, outputMap, nil
 }
 
@@ -1645,6 +1655,38 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			if distinct {
+				return execPlan{}, colOrdMap{}, errors.AssertionFailedf(
+					"DISTINCT is not supported for user-defined aggregates",
+				)
+			}
+			argCols := make([]exec.NodeColumnOrdinal, len(uda.Args))
+			for j := range uda.Args {
+				variable, ok := uda.Args[j].(*memo.VariableExpr)
+				if !ok {
+					return execPlan{}, colOrdMap{}, errors.AssertionFailedf("only VariableOp args supported")
+				}
+				argCols[j], err = getNodeColumnOrdinal(inputCols, variable.Col)
+				if err != nil {
+					return execPlan{}, colOrdMap{}, err
+				}
+			}
+			udInfo, err := b.buildUserDefinedAggInfo(uda.Def)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			aggInfos[i] = exec.AggInfo{
+				FuncName:    uda.Def.Name,
+				ResultType:  item.Agg.DataType(),
+				ArgCols:     argCols,
+				Filter:      filterOrd,
+				UserDefined: udInfo,
+			}
+			outputCols.Set(item.Col, len(groupingColIdx)+i)
+			continue
+		}
+
 		name, overload := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
@@ -1714,6 +1756,45 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 	return ep, outputCols, nil
 }
 
+// buildUserDefinedAggInfo builds the support expressions of a user-defined
+// aggregate. The state and argument parameters are mapped to IndexedVars in
+// the order expected by exec.UserDefinedAggInfo.
+func (b *Builder) buildUserDefinedAggInfo(
+	def *memo.UserDefinedAggDef,
+) (_ *exec.UserDefinedAggInfo, err error) {
+	info := &exec.UserDefinedAggInfo{
+		StateType:        def.StateType,
+		ResultType:       def.Typ,
+		InitCond:         def.InitCond,
+		TransitionStrict: def.TransitionStrict,
+		FinalStrict:      def.FinalStrict,
+		CombineStrict:    def.CombineStrict,
+	}
+	paramCols := b.colOrdsAlloc.Alloc()
+	defer b.colOrdsAlloc.Free(paramCols)
+	for i, col := range def.Params {
+		paramCols.Set(col, i)
+	}
+	if info.Transition, err = b.buildScalarWithMap(paramCols, def.Transition); err != nil {
+		return nil, err
+	}
+	if def.Final != nil {
+		if info.Final, err = b.buildScalarWithMap(paramCols, def.Final); err != nil {
+			return nil, err
+		}
+	}
+	if def.Combine != nil {
+		combineCols := b.colOrdsAlloc.Alloc()
+		defer b.colOrdsAlloc.Free(combineCols)
+		combineCols.Set(def.Params[0], 0)
+		combineCols.Set(def.CombineParam, 1)
+		if info.Combine, err = b.buildScalarWithMap(combineCols, def.Combine); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
 func (b *Builder) buildDistinct(
 	distinct memo.RelExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
@@ -3345,6 +3426,47 @@ func (b *Builder) buildProjectSet(
 	return ep, inputCols, nil
 }
 
+func (b *Builder) buildExpand(expand *memo.ExpandExpr) (_ execPlan, outputCols colOrdMap, err error) {
+	input, inputCols, err := b.buildRelational(expand.Input)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
+	groupingCols := make([]exec.NodeColumnOrdinal, len(expand.GroupingCols))
+	for i, col := range expand.GroupingCols {
+		groupingCols[i], err = getNodeColumnOrdinal(inputCols, col)
+		if err != nil {
+			return execPlan{}, colOrdMap{}, err
+		}
+	}
+	groupingSets := make([][]int, len(expand.GroupingSets))
+	for i, set := range expand.GroupingSets {
+		groupingSets[i] = make([]int, 0, set.Len())
+		for j, col := range expand.OutCols {
+			if set.Contains(col) {
+				groupingSets[i] = append(groupingSets[i], j)
+			}
+		}
+	}
+
+	var ep execPlan
+	ep.root, err = b.factory.ConstructExpand(input.root, groupingCols, groupingSets)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
+	// The grouping columns and the grouping set index are ordered at the end of
+	// the list.
+	n := inputCols.MaxOrd() + 1
+	for _, col := range expand.OutCols {
+		inputCols.Set(col, n)
+		n++
+	}
+	inputCols.Set(expand.SetIDCol, n)
+
+	return ep, inputCols, nil
+}
+
 func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap, err error) {
 	udf := c.Proc.(*memo.UDFCallExpr)
 	if udf.Def == nil {
@@ -3394,6 +3516,8 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		true,  /* procedure */
 		nil,   /* blockState */
 		nil,   /* cursorDeclaration */
+		udf.Def.BodyStmtInfos,
+		udf.Def.CalledAfterStmt,
 	)
 
 	var ep execPlan
@@ -3929,6 +4053,7 @@ var boundedStalenessAllowList = map[opt.Operator]struct{}{
 	opt.OrdinalityOp:       {},
 	opt.Max1RowOp:          {},
 	opt.ProjectSetOp:       {},
+	opt.ExpandOp:           {},
 	opt.WindowOp:           {},
 	opt.ExplainOp:          {},
 }
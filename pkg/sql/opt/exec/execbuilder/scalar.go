@@ -704,6 +704,8 @@ func (b *Builder) buildExistsSubquery(
 				false, /* procedure */
 				nil,   /* blockState */
 				nil,   /* cursorDeclaration */
+				nil,   /* stmtInfos */
+				nil,   /* calledAfterStmt */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -824,6 +826,8 @@ func (b *Builder) buildSubquery(
 			false, /* procedure */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			nil,   /* stmtInfos */
+			nil,   /* calledAfterStmt */
 		), nil
 	}
 
@@ -883,6 +887,8 @@ func (b *Builder) buildSubquery(
 			false, /* procedure */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			nil,   /* stmtInfos */
+			nil,   /* calledAfterStmt */
 		), nil
 	}
 
@@ -994,6 +1000,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		false, /* procedure */
 		blockState,
 		udf.Def.CursorDeclaration,
+		udf.Def.BodyStmtInfos,
+		udf.Def.CalledAfterStmt,
 	), nil
 }
 
@@ -1049,6 +1057,8 @@ func (b *Builder) initRoutineExceptionHandler(
 			false, /* procedure */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			action.BodyStmtInfos,
+			action.CalledAfterStmt,
 		)
 	}
 	blockState.ExceptionHandler = exceptionHandler
@@ -1287,6 +1297,6 @@ func (b *Builder) buildTxnControl(
 		return f.DetachMemo(), nil
 	}
 	return tree.NewTxnControlExpr(
-		txnExpr.TxnOp, txnExpr.TxnModes, args, gen, txnExpr.Def.Name, txnExpr.Def.Typ,
+		txnExpr.TxnOp, txnExpr.TxnModes, txnExpr.Chain, args, gen, txnExpr.Def.Name, txnExpr.Def.Typ,
 	), nil
 }
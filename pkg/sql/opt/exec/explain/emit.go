@@ -121,6 +121,20 @@ func emitInternal(
 	}
 
 	for _, cascade := range plan.Cascades {
+		if cascade.Trigger != nil {
+			ob.EnterMetaNode("after-trigger")
+			ob.Attr("trigger", string(cascade.Trigger.Name()))
+			const createPlanIfMissing = true
+			triggerPlan, err := cascade.GetExplainPlan(ctx, createPlanIfMissing)
+			if err != nil {
+				return err
+			}
+			if err := emitInternal(ctx, triggerPlan.(*Plan), ob, spanFormatFn, visitedFKsByCascades); err != nil {
+				return err
+			}
+			ob.LeaveNode()
+			continue
+		}
 		ob.EnterMetaNode("fk-cascade")
 		ob.Attr("fk", cascade.FKConstraint.Name())
 		// Here we do want to allow creation of the plans for the cascades to be
@@ -345,6 +359,7 @@ var nodeNames = [...]string{
 	deleteRangeOp:          "delete range",
 	distinctOp:             "distinct",
 	errorIfRowsOp:          "error if rows",
+	expandOp:               "expand",
 	explainOp:              "explain",
 	explainOptOp:           "explain",
 	exportOp:               "export",
@@ -628,6 +643,9 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if a.Table != nil && !(a.Table.IsVirtualTable() && a.Params.IndexConstraint == nil) {
 			e.emitSpans("spans", a.Table, a.Index, a.Params)
 		}
+		if a.Params.BlockRangeConstraint != nil {
+			ob.Attr("block range index", a.Params.BlockRangeIndex.Name())
+		}
 
 		if a.Params.HardLimit > 0 {
 			ob.Attr("limit", a.Params.HardLimit)
@@ -844,6 +862,19 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			}
 		}
 
+	case expandOp:
+		a := n.args.(*expandArgs)
+		inputCols := a.Input.Columns()
+		sets := make([]string, len(a.GroupingSets))
+		for i, set := range a.GroupingSets {
+			cols := make([]exec.NodeColumnOrdinal, len(set))
+			for j, idx := range set {
+				cols[j] = a.GroupingCols[idx]
+			}
+			sets[i] = fmt.Sprintf("(%s)", printColumnList(inputCols, cols))
+		}
+		ob.Attr("grouping sets", strings.Join(sets, ", "))
+
 	case windowOp:
 		a := n.args.(*windowArgs)
 		if ob.flags.Verbose {
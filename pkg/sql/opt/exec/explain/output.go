@@ -432,6 +432,25 @@ func (ob *OutputBuilder) AddTxnInfo(
 	ob.AddTopLevelField("quality of service", txnQoSLevel.String())
 }
 
+// AddRoutineStmtStats adds a top-level field with the execution statistics of a
+// statement within a routine, which is described by the given context (e.g.
+// "PL/pgSQL function f(bigint) line 3 at RAISE").
+func (ob *OutputBuilder) AddRoutineStmtStats(
+	context string, execCount, errorCount int64, latency time.Duration,
+) {
+	if ob.flags.Deflake.Has(DeflakeVolatile) {
+		latency = 10 * time.Microsecond
+	}
+	value := fmt.Sprintf(
+		"executions: %s, execution time: %s",
+		humanizeutil.Count(uint64(execCount)), humanizeutil.Duration(latency),
+	)
+	if errorCount > 0 {
+		value += fmt.Sprintf(", errors: %s", humanizeutil.Count(uint64(errorCount)))
+	}
+	ob.AddTopLevelField(context, value)
+}
+
 // AddWarning adds the provided string to the list of warnings. Warnings will be
 // appended to the end of the output produced by BuildStringRows / BuildString.
 func (ob *OutputBuilder) AddWarning(warning string) {
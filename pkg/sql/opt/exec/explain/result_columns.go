@@ -83,6 +83,18 @@ func getResultColumns(
 		}
 		return appendColumns(inputs[0], args.(*projectSetArgs).ZipCols...), nil
 
+	case expandOp:
+		if len(inputs) == 0 {
+			return nil, nil
+		}
+		a := args.(*expandArgs)
+		cols := make(colinfo.ResultColumns, 0, len(a.GroupingCols)+1)
+		for _, col := range a.GroupingCols {
+			cols = append(cols, inputs[0][col])
+		}
+		cols = append(cols, colinfo.ResultColumn{Name: "grouping_set", Typ: types.Int})
+		return appendColumns(inputs[0], cols...), nil
+
 	case applyJoinOp:
 		if len(inputs) == 0 {
 			return nil, nil
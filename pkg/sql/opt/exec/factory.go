@@ -101,6 +101,11 @@ type ScanParams struct {
 	IndexConstraint    *constraint.Constraint
 	InvertedConstraint inverted.Spans
 
+	// If BlockRangeConstraint is non-nil, the scan only reads the blocks of
+	// the table whose BlockRangeIndex summaries overlap the constraint.
+	BlockRangeIndex      cat.BlockRangeIndex
+	BlockRangeConstraint *constraint.Constraint
+
 	// If non-zero, the scan returns this many rows.
 	HardLimit int64
 
@@ -214,6 +219,32 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if this is a user-defined aggregate function.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo describes how to evaluate a user-defined aggregate
+// function. The Transition and Final expressions refer to the aggregate state
+// as the IndexedVar with index 0 and to the aggregated arguments as the
+// IndexedVars following it. The Combine expression refers to the two states
+// being merged as the IndexedVars with indexes 0 and 1.
+type UserDefinedAggInfo struct {
+	StateType  *types.T
+	ResultType *types.T
+	// InitCond is the initial value of the state.
+	InitCond tree.Datum
+
+	Transition       tree.TypedExpr
+	TransitionStrict bool
+
+	// Final is nil if the result of the aggregate is its final state.
+	Final       tree.TypedExpr
+	FinalStrict bool
+
+	// Combine is nil if the aggregate cannot be evaluated in multiple stages.
+	Combine       tree.TypedExpr
+	CombineStrict bool
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -275,8 +306,15 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 // ConstructBuffer as an input; it should only be triggered if this buffer is
 // not empty.
 type Cascade struct {
+	// FKConstraint is the foreign key constraint that requires the cascading
+	// query. It is nil if the query fires an AFTER trigger instead.
 	FKConstraint cat.ForeignKeyConstraint
 
+	// Trigger is the row-level AFTER trigger that the query fires. It is nil if
+	// the query performs a foreign key action. The rows produced by the query
+	// are discarded.
+	Trigger cat.Trigger
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
@@ -352,7 +390,8 @@ type InsertFastPathCheck struct {
 }
 
 // MkErrFn is a function that generates an error which includes values from a
-// relevant row.
+// relevant row. For ErrorIfRows, it may return nil to ignore the row, which is
+// used to record the violations of deferred constraint checks.
 type MkErrFn func(tree.Datums) error
 
 // ExplainFactory is an extension of Factory used when constructing a plan that
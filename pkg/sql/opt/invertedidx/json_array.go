@@ -45,7 +45,7 @@ func (j *jsonOrArrayJoinPlanner) extractInvertedJoinConditionFromLeaf(
 	ctx context.Context, expr opt.ScalarExpr,
 ) opt.ScalarExpr {
 	switch t := expr.(type) {
-	case *memo.ContainsExpr, *memo.ContainedByExpr:
+	case *memo.ContainsExpr, *memo.ContainedByExpr, *memo.OverlapsExpr:
 		return j.extractJSONOrArrayJoinCondition(t)
 	default:
 		return nil
@@ -60,7 +60,7 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 	expr opt.ScalarExpr,
 ) opt.ScalarExpr {
 	var left, right, indexCol, val opt.ScalarExpr
-	commuteArgs, containedBy := false, false
+	commuteArgs, containedBy, overlaps := false, false, false
 	switch t := expr.(type) {
 	case *memo.ContainsExpr:
 		left = t.Left
@@ -69,6 +69,10 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 		left = t.Left
 		right = t.Right
 		containedBy = true
+	case *memo.OverlapsExpr:
+		left = t.Left
+		right = t.Right
+		overlaps = true
 	default:
 		return nil
 	}
@@ -84,13 +88,17 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 		// When the second argument is a variable or expression corresponding to
 		// the index column, we must commute the right and left arguments and
 		// construct a new expression. We get the equivalent InvertedExpression for
-		// right <@ left or right @> left.
+		// right <@ left, right @> left or right && left.
 		commuteArgs = true
 		indexCol, val = right, left
 	} else {
 		// If neither condition is met, we cannot create an InvertedExpression.
 		return nil
 	}
+	if overlaps && indexCol.DataType().Family() != types.ArrayFamily {
+		// The && operator is only index-accelerated for arrays.
+		return nil
+	}
 	if indexCol.DataType().Family() == types.ArrayFamily &&
 		j.index.Version() < descpb.EmptyArraysInInvertedIndexesVersion {
 		// We cannot plan inverted joins on array indexes that do not include
@@ -112,6 +120,9 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 	// If commuteArgs is true, we construct a new equivalent expression so that
 	// the left argument is the indexed column.
 	if commuteArgs {
+		if overlaps {
+			return j.factory.ConstructOverlaps(right, left)
+		}
 		if containedBy {
 			return j.factory.ConstructContains(right, left)
 		}
@@ -316,6 +327,9 @@ func (g *jsonOrArrayDatumsToInvertedExpr) Convert(
 			case treecmp.ContainedBy:
 				return getInvertedExprForJSONOrArrayIndexForContainedBy(ctx, g.evalCtx, d), nil
 
+			case treecmp.Overlaps:
+				return getInvertedExprForArrayIndexForOverlaps(ctx, g.evalCtx, d), nil
+
 			default:
 				return nil, fmt.Errorf("unsupported expression %v", t)
 			}
@@ -116,6 +116,28 @@ func (m *Memo) CheckExpr(e opt.Expr) {
 	case *SelectExpr:
 		checkFilters(t.Filters)
 
+	case *ExpandExpr:
+		if len(t.GroupingCols) != len(t.OutCols) {
+			panic(errors.AssertionFailedf("expand has %d grouping columns and %d output columns",
+				len(t.GroupingCols), len(t.OutCols)))
+		}
+		if !t.GroupingCols.ToSet().SubsetOf(t.Input.Relational().OutputCols) {
+			panic(errors.AssertionFailedf("expand grouping columns are not in input"))
+		}
+		outCols := t.OutCols.ToSet()
+		if outCols.Intersects(t.Input.Relational().OutputCols) ||
+			t.Input.Relational().OutputCols.Contains(t.SetIDCol) {
+			panic(errors.AssertionFailedf("expand reuses input columns"))
+		}
+		if len(t.GroupingSets) == 0 {
+			panic(errors.AssertionFailedf("expand has no grouping sets"))
+		}
+		for _, set := range t.GroupingSets {
+			if set.Empty() || !set.SubsetOf(outCols) {
+				panic(errors.AssertionFailedf("invalid expand grouping set %s", set))
+			}
+		}
+
 	case *UnionExpr, *UnionAllExpr, *LocalityOptimizedSearchExpr:
 		setPrivate := t.Private().(*SetPrivate)
 		outColSet := setPrivate.OutCols.ToSet()
@@ -739,6 +739,18 @@ type UDFDefinition struct {
 	// Body. It is only populated when verbose tracing is enabled.
 	BodyStmts []string
 
+	// BodyStmtInfos, if set, identifies the PL/pgSQL statement from which each
+	// statement in Body was built. An element is nil if the corresponding body
+	// statement does not correspond to a single PL/pgSQL statement. It is used
+	// to collect per-statement execution statistics.
+	BodyStmtInfos []*tree.RoutineStmtInfo
+
+	// CalledAfterStmt, if set, identifies the PL/pgSQL statement at the end of
+	// whose body statement this continuation routine is called. The execution
+	// of the statement is considered finished once the routine starts, so that
+	// the time spent in the routine is not attributed to the statement.
+	CalledAfterStmt *tree.RoutineStmtInfo
+
 	// ExceptionBlock contains information needed for exception-handling when the
 	// body of this routine returns an error. It can be unset.
 	ExceptionBlock *ExceptionBlock
@@ -770,6 +782,50 @@ type ExceptionBlock struct {
 	Actions []*UDFDefinition
 }
 
+// UserDefinedAggDef describes how to evaluate a user-defined aggregate
+// function. Its support functions are represented by scalar expressions that
+// refer to the parameter columns in Params: the first column is the aggregate
+// state, and the following columns are the aggregated arguments.
+type UserDefinedAggDef struct {
+	// Name is the name of the aggregate.
+	Name string
+
+	// Typ is the return type of the aggregate.
+	Typ *types.T
+
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+
+	// Volatility is the volatility of the most volatile support function.
+	Volatility volatility.V
+
+	// InitCond is the initial value of the state.
+	InitCond tree.Datum
+
+	// Params is the list of columns that represent the state and the
+	// aggregated arguments in Transition and Final.
+	Params opt.ColList
+
+	// Transition computes the new state from the state and the arguments of an
+	// input row. If TransitionStrict is true, input rows with a NULL argument
+	// are skipped.
+	Transition       opt.ScalarExpr
+	TransitionStrict bool
+
+	// Final computes the result of the aggregate from the final state. It is
+	// nil if the final state is the result. If FinalStrict is true, the result
+	// is NULL when the final state is NULL.
+	Final       opt.ScalarExpr
+	FinalStrict bool
+
+	// Combine merges the partial state represented by CombineParam into the
+	// state represented by the first column in Params. It is nil if the
+	// aggregate cannot be evaluated in multiple stages.
+	Combine       opt.ScalarExpr
+	CombineParam  opt.ColumnID
+	CombineStrict bool
+}
+
 // WindowFrame denotes the definition of a window frame for an individual
 // window function, excluding the OFFSET expressions, if present.
 type WindowFrame struct {
@@ -828,6 +884,7 @@ func (f *WindowFrame) String() string {
 func (s *ScanPrivate) IsCanonical() bool {
 	return s.Index == cat.PrimaryIndex &&
 		s.Constraint == nil &&
+		s.BlockRangeConstraint == nil &&
 		s.HardLimit == 0 &&
 		!s.LocalityOptimized
 }
@@ -837,6 +894,7 @@ func (s *ScanPrivate) IsCanonical() bool {
 func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 	return (s.Constraint == nil || s.Constraint.IsUnconstrained()) &&
 		s.InvertedConstraint == nil &&
+		s.BlockRangeConstraint == nil &&
 		s.HardLimit == 0 &&
 		s.PartialIndexPredicate(md) == nil &&
 		s.Locking.WaitPolicy != tree.LockWaitSkipLocked
@@ -847,6 +905,7 @@ func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 func (s *ScanPrivate) IsFullIndexScan(md *opt.Metadata) bool {
 	return (s.Constraint == nil || s.Constraint.IsUnconstrained()) &&
 		s.InvertedConstraint == nil &&
+		s.BlockRangeConstraint == nil &&
 		s.HardLimit == 0
 }
 
@@ -1324,14 +1383,25 @@ func CollectContiguousOrExprs(e opt.ScalarExpr) []opt.ScalarExpr {
 	return disjunctions
 }
 
+// GroupingSets lists the grouping sets of an Expand operator. Each grouping set
+// is the subset of the Expand's output columns that are not NULLed in the rows
+// it produces for that grouping set.
+type GroupingSets []opt.ColSet
+
 // FKCascades stores metadata necessary for building cascading queries.
 type FKCascades []FKCascade
 
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
 type FKCascade struct {
+	// FKConstraint is the foreign key constraint that requires the cascading
+	// query. It is nil if the query fires an AFTER trigger instead.
 	FKConstraint cat.ForeignKeyConstraint
 
+	// Trigger is the row-level AFTER trigger that the cascading query fires.
+	// It is nil if the query performs a foreign key action.
+	Trigger cat.Trigger
+
 	// Builder is an object that can be used as the "optbuilder" for the cascading
 	// query.
 	Builder CascadeBuilder
@@ -413,6 +413,28 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Childf("error: \"%s\"", t.ErrorText)
 		}
 
+	case *ExpandExpr:
+		if !f.HasFlags(ExprFmtHideColumns) {
+			f.formatRelColList(e, tp, "grouping columns:", t.GroupingCols)
+			f.Buffer.Reset()
+			f.Buffer.WriteString("grouping sets:")
+			for _, set := range t.GroupingSets {
+				f.Buffer.WriteString(" (")
+				first := true
+				for _, col := range t.OutCols {
+					if set.Contains(col) {
+						if !first {
+							f.space()
+						}
+						first = false
+						f.formatColSimple("" /* label */, col)
+					}
+				}
+				f.Buffer.WriteByte(')')
+			}
+			tp.Child(f.Buffer.String())
+		}
+
 	// Special-case handling for set operators to show the left and right
 	// input columns that correspond to the output columns.
 	case *UnionExpr, *IntersectExpr, *ExceptExpr,
@@ -491,6 +513,14 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			n := tp.Childf("inverted constraint: %s", b.String())
 			ic.Format(n, "spans", f.RedactableValues)
 		}
+		if c := private.BlockRangeConstraint; c != nil {
+			idx := md.Table(private.Table).BlockRangeIndex(private.BlockRangeIndex)
+			n := tp.Childf("block range index: %s", idx.Name())
+			for i := 0; i < c.Spans.Count(); i++ {
+				n.Childf("%s: %s", c.Columns.String(),
+					cat.MaybeMarkRedactable(c.Spans.Get(i).String(), f.RedactableValues))
+			}
+		}
 		if private.HardLimit.IsSet() {
 			tp.Childf("limit: %s", private.HardLimit)
 		}
@@ -676,6 +706,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			}
 			if t.CanaryCol != 0 {
 				f.formatRelColList(e, tp, "canary column:", opt.ColList{t.CanaryCol})
+				if t.MergeActionCol != 0 {
+					f.formatRelColList(e, tp, "merge action column:", opt.ColList{t.MergeActionCol})
+				}
 				f.formatOptionalColList(e, tp, "fetch columns:", t.FetchCols)
 				f.formatMutationCols(e, tp, "insert-mapping:", t.InsertCols, t.Table)
 				f.formatMutationCols(e, tp, "update-mapping:", t.UpdateCols, t.Table)
@@ -704,6 +737,11 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 	case *LockExpr:
 		f.formatLocking(tp, t.Locking)
 
+	case *BarrierExpr:
+		if t.LeakproofPermeable {
+			tp.Child("leakproof-permeable")
+		}
+
 	case *WithExpr:
 		switch t.Mtr {
 		case tree.CTEMaterializeAlways:
@@ -1079,7 +1117,11 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 
 	case opt.TxnControlOp:
 		controlExpr := scalar.(*TxnControlExpr)
-		fmt.Fprintf(f.Buffer, "%s; CALL %s", controlExpr.TxnOp, controlExpr.Def.Name)
+		fmt.Fprintf(f.Buffer, "%s", controlExpr.TxnOp)
+		if controlExpr.Chain {
+			f.Buffer.WriteString(" AND CHAIN")
+		}
+		fmt.Fprintf(f.Buffer, "; CALL %s", controlExpr.Def.Name)
 		f.FormatScalarProps(scalar)
 		tp = tp.Child(f.Buffer.String())
 		formatRoutineArgs(controlExpr.Args, tp)
@@ -1144,7 +1186,11 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 			intercepted = true
 		case *TxnControlExpr:
 			// As for UDFCallExpr, the arguments and body will be printed below.
-			fmt.Fprintf(f.Buffer, "%s; CALL %s", t.TxnOp, t.Def.Name)
+			fmt.Fprintf(f.Buffer, "%s", t.TxnOp)
+			if t.Chain {
+				f.Buffer.WriteString(" AND CHAIN")
+			}
+			fmt.Fprintf(f.Buffer, "; CALL %s", t.Def.Name)
 			intercepted = true
 		}
 	}
@@ -1349,6 +1395,10 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 	case *UDFCallExpr:
 		private = nil
 
+	case *UserDefinedAggExpr:
+		fmt.Fprintf(f.Buffer, ": %s", t.Def.Name)
+		private = nil
+
 	default:
 		private = scalar.Private()
 	}
@@ -1539,7 +1589,11 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 	if len(p.FKCascades) > 0 {
 		c := tp.Childf("cascades")
 		for i := range p.FKCascades {
-			c.Child(p.FKCascades[i].FKConstraint.Name())
+			if trig := p.FKCascades[i].Trigger; trig != nil {
+				c.Childf("trigger %s", trig.Name())
+			} else {
+				c.Child(p.FKCascades[i].FKConstraint.Name())
+			}
 		}
 	}
 }
@@ -1835,6 +1889,12 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *JoinPrivate:
 		// Nothing to show; flags are shown separately.
 
+	case *BarrierPrivate:
+		// Nothing to show; the leakproof-permeable flag is shown separately.
+
+	case *ExpandPrivate:
+		// Nothing to show; the grouping sets are shown separately.
+
 	case *ExplainPrivate, *opt.ColSet, *types.T, *ExportPrivate:
 		// Don't show anything, because it's mostly redundant.
 
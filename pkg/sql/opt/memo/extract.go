@@ -133,6 +133,15 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		for _, arg := range uda.Args {
+			if variable, ok := arg.(*VariableExpr); ok {
+				res.Add(variable.Col)
+			}
+		}
+		return res
+	}
+
 	for i, n := 0, e.ChildCount(); i < n; i++ {
 		if variable, ok := e.Child(i).(*VariableExpr); ok {
 			res.Add(variable.Col)
@@ -542,6 +542,13 @@ func (h *hasher) HashFKCascades(val FKCascades) {
 	}
 }
 
+func (h *hasher) HashGroupingSets(val GroupingSets) {
+	for i := range val {
+		h.HashInt(val[i].Len())
+		h.HashColSet(val[i])
+	}
+}
+
 func (h *hasher) HashExplainOptions(val tree.ExplainOptions) {
 	h.HashUint64(uint64(val.Mode))
 	hash := h.hash
@@ -1022,6 +1029,18 @@ func (h *hasher) IsFKCascadesEqual(l, r FKCascades) bool {
 	return true
 }
 
+func (h *hasher) IsGroupingSetsEqual(l, r GroupingSets) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		if !l[i].Equals(r[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *hasher) IsExplainOptionsEqual(l, r tree.ExplainOptions) bool {
 	return l == r
 }
@@ -1344,6 +1363,23 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	} else if r.CursorDeclaration != nil {
 		return false
 	}
+	if len(l.BodyStmtInfos) != len(r.BodyStmtInfos) {
+		return false
+	}
+	for i := range l.BodyStmtInfos {
+		if l.BodyStmtInfos[i] != r.BodyStmtInfos[i] {
+			if l.BodyStmtInfos[i] == nil || r.BodyStmtInfos[i] == nil ||
+				*l.BodyStmtInfos[i] != *r.BodyStmtInfos[i] {
+				return false
+			}
+		}
+	}
+	if l.CalledAfterStmt != r.CalledAfterStmt {
+		if l.CalledAfterStmt == nil || r.CalledAfterStmt == nil ||
+			*l.CalledAfterStmt != *r.CalledAfterStmt {
+			return false
+		}
+	}
 	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
 }
 
@@ -571,6 +571,13 @@ func TestInterner(t *testing.T) {
 			{val1: TupleOrdinal(0), val2: TupleOrdinal(1), equal: false},
 		}},
 
+		{hashFn: in.hasher.HashGroupingSets, eqFn: in.hasher.IsGroupingSetsEqual, variations: []testVariation{
+			{val1: GroupingSets{}, val2: GroupingSets{}, equal: true},
+			{val1: GroupingSets{opt.MakeColSet(1, 2), opt.MakeColSet(1)}, val2: GroupingSets{opt.MakeColSet(2, 1), opt.MakeColSet(1)}, equal: true},
+			{val1: GroupingSets{opt.MakeColSet(1, 2), opt.MakeColSet(1)}, val2: GroupingSets{opt.MakeColSet(1), opt.MakeColSet(1, 2)}, equal: false},
+			{val1: GroupingSets{opt.MakeColSet(1, 2), opt.MakeColSet(3)}, val2: GroupingSets{opt.MakeColSet(1), opt.MakeColSet(2, 3)}, equal: false},
+		}},
+
 		// PhysProps hash/isEqual methods are tested in TestInternerPhysProps.
 
 		{hashFn: in.hasher.HashLocking, eqFn: in.hasher.IsLockingEqual, variations: []testVariation{
@@ -1342,6 +1342,82 @@ func (b *logicalPropsBuilder) buildOrdinalityProps(ord *OrdinalityExpr, rel *pro
 	}
 }
 
+func (b *logicalPropsBuilder) buildExpandProps(expand *ExpandExpr, rel *props.Relational) {
+	BuildSharedProps(expand, &rel.Shared, b.evalCtx)
+
+	inputProps := expand.Input.Relational()
+	numSets := uint32(len(expand.GroupingSets))
+
+	// Output Columns
+	// --------------
+	// The grouping columns of each grouping set and the grouping set index are
+	// added to the columns projected by the input operator.
+	rel.OutputCols = inputProps.OutputCols.Copy()
+	for _, col := range expand.OutCols {
+		rel.OutputCols.Add(col)
+	}
+	rel.OutputCols.Add(expand.SetIDCol)
+
+	// Not Null Columns
+	// ----------------
+	// The grouping set index is not null. A grouping column is not null if its
+	// input column is not null and it is part of every grouping set. Other
+	// columns inherit not null property from input.
+	rel.NotNullCols = inputProps.NotNullCols.Copy()
+	rel.NotNullCols.Add(expand.SetIDCol)
+	for i, col := range expand.OutCols {
+		if !inputProps.NotNullCols.Contains(expand.GroupingCols[i]) {
+			continue
+		}
+		inAllSets := true
+		for _, set := range expand.GroupingSets {
+			if !set.Contains(col) {
+				inAllSets = false
+				break
+			}
+		}
+		if inAllSets {
+			rel.NotNullCols.Add(col)
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by BuildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	// Expand is equivalent to a cross join between the input and the grouping
+	// set indexes, followed by a projection of the grouping columns. Each
+	// grouping column is determined by its input column and the grouping set
+	// index.
+	rel.FuncDeps.CopyFrom(&inputProps.FuncDeps)
+	var setIDFuncDeps props.FuncDepSet
+	setIDCols := opt.MakeColSet(expand.SetIDCol)
+	setIDFuncDeps.AddStrictKey(setIDCols, setIDCols)
+	if numSets == 1 {
+		setIDFuncDeps.AddConstants(setIDCols)
+	}
+	rel.FuncDeps.MakeProduct(&setIDFuncDeps)
+	for i, col := range expand.OutCols {
+		rel.FuncDeps.AddSynthesizedCol(
+			opt.MakeColSet(expand.GroupingCols[i], expand.SetIDCol), col,
+		)
+	}
+	rel.FuncDeps.MakeNotNull(rel.NotNullCols)
+
+	// Cardinality
+	// -----------
+	// Each input row is produced once for each grouping set.
+	rel.Cardinality = inputProps.Cardinality.Product(props.Cardinality{Min: numSets, Max: numSets})
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildExpand(expand, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildWindowProps(window *WindowExpr, rel *props.Relational) {
 	BuildSharedProps(window, &rel.Shared, b.evalCtx)
 
@@ -1731,6 +1807,10 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		shared.VolatilitySet.Add(t.Def.Volatility)
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -1922,6 +2002,12 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.IsExclusion() {
+			// Exclusion constraints do not guarantee uniqueness of their
+			// columns, so they cannot be used as keys.
+			continue
+		}
+
 		if _, isPartial := unique.Predicate(); isPartial {
 			// Partial constraints cannot be considered while building functional
 			// dependency keys for the table because their keys are only unique
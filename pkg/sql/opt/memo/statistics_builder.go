@@ -487,6 +487,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.ProjectSetOp:
 		return sb.colStatProjectSet(colSet, e.(*ProjectSetExpr))
 
+	case opt.ExpandOp:
+		return sb.colStatExpand(colSet, e.(*ExpandExpr))
+
 	case opt.WithScanOp:
 		return sb.colStatWithScan(colSet, e.(*WithScanExpr))
 
@@ -834,6 +837,16 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 	s.VirtualCols.UnionWith(inputStats.VirtualCols)
 	pred := scan.PartialIndexPredicate(sb.md)
 
+	// If the scan uses a block range index, then it only reads the blocks
+	// whose summaries overlap the block range constraint. Block range indexes
+	// are meant for columns that are correlated with the primary key, so
+	// assume that these blocks hold only the rows satisfying the constraint.
+	if scan.BlockRangeConstraint != nil {
+		sb.constrainScan(scan, scan.BlockRangeConstraint, pred, relProps, s)
+		sb.finalizeFromCardinality(relProps)
+		return
+	}
+
 	// If the constraints and pred are nil, then this scan is an unconstrained
 	// scan on a non-partial index. The stats of the scan are the same as the
 	// underlying table stats.
@@ -2696,6 +2709,80 @@ func (sb *statisticsBuilder) colStatProjectSet(
 	return colStat
 }
 
+// +--------+
+// | Expand |
+// +--------+
+
+func (sb *statisticsBuilder) buildExpand(expand *ExpandExpr, relProps *props.Relational) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(expand)
+
+	// Each input row is produced once for each grouping set.
+	inputStats := expand.Input.Relational().Statistics()
+	s.RowCount = inputStats.RowCount * float64(len(expand.GroupingSets))
+	s.VirtualCols.UnionWith(inputStats.VirtualCols)
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatExpand(
+	colSet opt.ColSet, expand *ExpandExpr,
+) *props.ColumnStatistic {
+	relProps := expand.Relational()
+	s := relProps.Statistics()
+
+	colStat, _ := s.ColStats.Add(colSet)
+	numSets := float64(len(expand.GroupingSets))
+
+	// Map the requested grouping columns to the input columns they are copied
+	// from.
+	var reqOutCols opt.ColSet
+	reqInputCols := colSet.Intersection(expand.Input.Relational().OutputCols)
+	for i, col := range expand.OutCols {
+		if colSet.Contains(col) {
+			reqOutCols.Add(col)
+			reqInputCols.Add(expand.GroupingCols[i])
+		}
+	}
+
+	inputRowCount := s.RowCount / numSets
+	inputDistinctCount, inputNullCount := float64(1), float64(0)
+	if !reqInputCols.Empty() {
+		inputColStat := sb.colStatFromChild(reqInputCols, expand, 0 /* childIdx */)
+		inputDistinctCount = inputColStat.DistinctCount
+		inputNullCount = inputColStat.NullCount
+	}
+
+	if reqOutCols.Empty() && !colSet.Contains(expand.SetIDCol) {
+		// Only passthrough columns are requested, so every distinct value is
+		// repeated once for each grouping set.
+		colStat.DistinctCount = inputDistinctCount
+		colStat.NullCount = inputNullCount * numSets
+	} else {
+		// Each grouping set can produce a different set of distinct values. The
+		// requested grouping columns are NULL for every row of the grouping sets
+		// that don't contain all of them.
+		colStat.DistinctCount = inputDistinctCount * numSets
+		colStat.NullCount = 0
+		for _, set := range expand.GroupingSets {
+			if reqOutCols.SubsetOf(set) {
+				colStat.NullCount += inputNullCount
+			} else {
+				colStat.NullCount += inputRowCount
+			}
+		}
+	}
+
+	if colSet.Intersects(relProps.NotNullCols) {
+		colStat.NullCount = 0
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +----------+
 // | WithScan |
 // +----------+
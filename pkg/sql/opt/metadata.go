@@ -141,6 +141,11 @@ type Metadata struct {
 	// as a builtin function.
 	builtinRefsByName map[tree.UnresolvedName]struct{}
 
+	// rlsDeps stores the row-level security policies that were applied to the
+	// tables referenced by the query. They depend on the current user, so they
+	// must be determined again before the query is reused.
+	rlsDeps []rowLevelSecurityDep
+
 	// NOTE! When adding fields here, update Init (if reusing allocated
 	// data structures is desired), CopyFrom and TestMetadata.
 }
@@ -214,6 +219,11 @@ func (md *Metadata) Init() {
 		delete(md.builtinRefsByName, name)
 	}
 
+	rlsDeps := md.rlsDeps
+	for i := range rlsDeps {
+		rlsDeps[i] = rowLevelSecurityDep{}
+	}
+
 	// This initialization pattern ensures that fields are not unwittingly
 	// reused. Field reuse must be explicit.
 	*md = Metadata{}
@@ -227,6 +237,7 @@ func (md *Metadata) Init() {
 	md.objectRefsByName = objectRefsByName
 	md.privileges = privileges
 	md.builtinRefsByName = builtinRefsByName
+	md.rlsDeps = rlsDeps[:0]
 }
 
 // CopyFrom initializes the metadata with a copy of the provided metadata.
@@ -242,7 +253,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		len(md.sequences) != 0 || len(md.views) != 0 || len(md.userDefinedTypes) != 0 ||
 		len(md.userDefinedTypesSlice) != 0 || len(md.dataSourceDeps) != 0 ||
 		len(md.udfDeps) != 0 || len(md.objectRefsByName) != 0 || len(md.privileges) != 0 ||
-		len(md.builtinRefsByName) != 0 {
+		len(md.builtinRefsByName) != 0 || len(md.rlsDeps) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
 	md.schemas = append(md.schemas, from.schemas...)
@@ -315,6 +326,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		md.builtinRefsByName[name] = struct{}{}
 	}
 
+	md.rlsDeps = append(md.rlsDeps, from.rlsDeps...)
 	md.sequences = append(md.sequences, from.sequences...)
 	md.views = append(md.views, from.views...)
 	md.currUniqueID = from.currUniqueID
@@ -404,6 +416,19 @@ func (md *Metadata) CheckDependencies(
 		return false, err
 	}
 
+	// Check that the same row-level security policies apply to the current
+	// user.
+	for i := range md.rlsDeps {
+		dep := &md.rlsDeps[i]
+		ords, enforced, err := cat.ApplicablePolicies(ctx, optCatalog, dep.table, dep.cmd)
+		if err != nil {
+			return false, err
+		}
+		if enforced != dep.enforced || !intsEqual(ords, dep.policies) {
+			return false, nil
+		}
+	}
+
 	// Check that no referenced user defined types have changed.
 	for _, typ := range md.AllUserDefinedTypes() {
 		id := cat.StableID(catid.UserDefinedOIDToID(typ.Oid()))
@@ -434,13 +459,14 @@ func (md *Metadata) CheckDependencies(
 				if err != nil {
 					return false, maybeSwallowMetadataResolveErr(err)
 				}
+				paramTypes := overload.Types.Types()
 				routineObj := tree.RoutineObj{
 					FuncName: name.ToRoutineName(),
-					Params:   make(tree.RoutineParams, overload.Types.Length()),
+					Params:   make(tree.RoutineParams, len(paramTypes)),
 				}
 				for i := 0; i < len(routineObj.Params); i++ {
 					routineObj.Params[i] = tree.RoutineParam{
-						Type: overload.Types.GetAt(i),
+						Type: paramTypes[i],
 						// Since we're not in the DROP context, it's sufficient
 						// to specify only the input parameters.
 						Class: tree.RoutineParamIn,
@@ -498,6 +524,45 @@ func (md *Metadata) CheckDependencies(
 	return true, nil
 }
 
+// rowLevelSecurityDep records the row-level security policies that were
+// applied to a table for a given command.
+type rowLevelSecurityDep struct {
+	table    cat.Table
+	cmd      tree.PolicyCommand
+	enforced bool
+	policies []int
+}
+
+// AddRowLevelSecurityDependency tracks the row-level security policies that
+// were applied to the given table for the given command (see
+// cat.ApplicablePolicies). If the Memo using this metadata is cached, then a
+// call to CheckDependencies can detect if a different set of policies applies
+// to the current user, in which case the cached metadata is invalid.
+func (md *Metadata) AddRowLevelSecurityDependency(
+	tab cat.Table, cmd tree.PolicyCommand, policies []int, enforced bool,
+) {
+	md.rlsDeps = append(md.rlsDeps, rowLevelSecurityDep{
+		table:    tab,
+		cmd:      cmd,
+		enforced: enforced,
+		policies: policies,
+	})
+}
+
+// intsEqual returns true if the two slices contain the same integers in the
+// same order.
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // handleMetadataResolveErr swallows errors that are thrown when a database
 // object is dropped, since such an error potentially only means that the
 // metadata is stale and should be re-resolved.
@@ -54,6 +54,12 @@ func (c *CustomFuncs) HasColType(scalar opt.ScalarExpr, dstTyp *types.T) bool {
 	return scalar.DataType().Identical(dstTyp)
 }
 
+// IsNotNullDomain returns true if the given type is a domain that does not
+// allow NULL values.
+func (c *CustomFuncs) IsNotNullDomain(typ *types.T) bool {
+	return typ.IsDomain() && typ.TypeMeta.DomainData != nil && typ.TypeMeta.DomainData.NotNull
+}
+
 // IsTimestamp returns true if the given scalar expression is of type Timestamp.
 func (c *CustomFuncs) IsTimestamp(scalar opt.ScalarExpr) bool {
 	return scalar.DataType().Family() == types.TimestampFamily
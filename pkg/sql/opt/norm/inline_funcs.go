@@ -463,29 +463,7 @@ func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFCal
 func (c *CustomFuncs) ConvertUDFToSubquery(
 	args memo.ScalarListExpr, udfp *memo.UDFCallPrivate,
 ) opt.ScalarExpr {
-	// argForParam returns the argument that can be substituted for the given
-	// column, if the column is a parameter of the UDF. It returns ok=false if
-	// the column is not a UDF parameter.
-	argForParam := func(col opt.ColumnID) (e opt.Expr, ok bool) {
-		for i := range udfp.Def.Params {
-			if udfp.Def.Params[i] == col {
-				return args[i], true
-			}
-		}
-		return nil, false
-	}
-
-	// replace substitutes variables that are UDF parameters with the
-	// corresponding argument from the invocation of the UDF.
-	var replace ReplaceFunc
-	replace = func(nd opt.Expr) opt.Expr {
-		if t, ok := nd.(*memo.VariableExpr); ok {
-			if arg, ok := argForParam(t.Col); ok {
-				return arg
-			}
-		}
-		return c.f.Replace(nd, replace)
-	}
+	replace := c.udfParamReplacer(args, udfp)
 
 	// The presentation and ordering in the physical properties of the UDF
 	// statement must be preserved in the subquery to produce correct results.
@@ -548,3 +526,102 @@ func (c *CustomFuncs) ConvertUDFToSubquery(
 
 	return res
 }
+
+// IsInlinableSetReturningUDF returns true if the given set-returning UDF, which
+// is the only function in the zip of a ProjectSet, can be inlined as a lateral
+// join with its body. The requirements are the same as for IsInlinableUDF,
+// except that the UDF must be set-returning and may be record-returning. In
+// addition:
+//
+//  1. The body must not require an ordering of its result, since the ordering
+//     would be lost once the body is joined with the input of the ProjectSet.
+//  2. The types of the result columns of the body must be identical to the
+//     types of the given output columns of the zip.
+func (c *CustomFuncs) IsInlinableSetReturningUDF(
+	args memo.ScalarListExpr, udfp *memo.UDFCallPrivate, cols opt.ColList,
+) bool {
+	if udfp.Def == nil {
+		panic(errors.AssertionFailedf("expected non-nil UDF definition"))
+	}
+	if udfp.Def.IsRecursive || udfp.Def.Volatility == volatility.Volatile ||
+		len(udfp.Def.Body) != 1 || !udfp.Def.SetReturning {
+		return false
+	}
+	if !args.IsConstantsAndPlaceholdersAndVariables() {
+		return false
+	}
+	bodyProps := udfp.Def.BodyProps[0]
+	if !bodyProps.Ordering.Any() || len(bodyProps.Presentation) != len(cols) {
+		return false
+	}
+	md := c.mem.Metadata()
+	for i := range cols {
+		resultTyp := md.ColumnMeta(bodyProps.Presentation[i].ID).Type
+		if !resultTyp.Identical(md.ColumnMeta(cols[i]).Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConvertSetReturningUDFToRel returns a relational expression that is
+// equivalent to the given set-returning UDF and UDF arguments. The expression
+// produces the given columns.
+func (c *CustomFuncs) ConvertSetReturningUDFToRel(
+	args memo.ScalarListExpr, udfp *memo.UDFCallPrivate, cols opt.ColList,
+) memo.RelExpr {
+	replace := c.udfParamReplacer(args, udfp)
+	stmt := replace(udfp.Def.Body[0]).(memo.RelExpr)
+
+	// Map the result columns of the body to the output columns of the zip.
+	presentation := udfp.Def.BodyProps[0].Presentation
+	projections := make(memo.ProjectionsExpr, len(cols))
+	for i := range cols {
+		projections[i] = c.f.ConstructProjectionsItem(
+			c.f.ConstructVariable(presentation[i].ID), cols[i],
+		)
+	}
+	res := c.f.ConstructProject(stmt, projections, opt.ColSet{})
+
+	// If the UDF is strict, it should not produce any rows when any of the
+	// arguments are NULL. See ConvertUDFToSubquery for why IsNot is used rather
+	// than a TupleIsNotNull expression.
+	if !udfp.Def.CalledOnNullInput && len(args) > 0 {
+		filters := make(memo.FiltersExpr, len(args))
+		for i := range args {
+			filters[i] = c.f.ConstructFiltersItem(c.f.ConstructIsNot(args[i], memo.NullSingleton))
+		}
+		res = c.f.ConstructSelect(res, filters)
+	}
+	return res
+}
+
+// udfParamReplacer returns a ReplaceFunc that substitutes variables that are
+// parameters of the given UDF with the corresponding argument from the
+// invocation of the UDF.
+func (c *CustomFuncs) udfParamReplacer(
+	args memo.ScalarListExpr, udfp *memo.UDFCallPrivate,
+) ReplaceFunc {
+	// argForParam returns the argument that can be substituted for the given
+	// column, if the column is a parameter of the UDF. It returns ok=false if
+	// the column is not a UDF parameter.
+	argForParam := func(col opt.ColumnID) (e opt.Expr, ok bool) {
+		for i := range udfp.Def.Params {
+			if udfp.Def.Params[i] == col {
+				return args[i], true
+			}
+		}
+		return nil, false
+	}
+
+	var replace ReplaceFunc
+	replace = func(nd opt.Expr) opt.Expr {
+		if t, ok := nd.(*memo.VariableExpr); ok {
+			if arg, ok := argForParam(t.Col); ok {
+				return arg
+			}
+		}
+		return c.f.Replace(nd, replace)
+	}
+	return replace
+}
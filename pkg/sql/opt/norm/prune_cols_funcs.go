@@ -31,6 +31,12 @@ func (c *CustomFuncs) NeededOrdinalityCols(private *memo.OrdinalityPrivate) opt.
 	return private.Ordering.ColSet()
 }
 
+// NeededExpandCols returns the columns needed by an Expand operator's grouping
+// columns.
+func (c *CustomFuncs) NeededExpandCols(private *memo.ExpandPrivate) opt.ColSet {
+	return private.GroupingCols.ToSet()
+}
+
 // NeededExplainCols returns the columns needed by Explain's required physical
 // properties.
 func (c *CustomFuncs) NeededExplainCols(private *memo.ExplainPrivate) opt.ColSet {
@@ -78,6 +84,17 @@ func (c *CustomFuncs) neededMutationCols(
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	if private.MergeActionCol != 0 {
+		cols.Add(private.MergeActionCol)
+	}
+
+	// Add the columns of the rows that are passed to cascades. These are usually
+	// fetch or update columns, but a MERGE statement projects separate columns
+	// for the rows that it deletes.
+	for i := range private.FKCascades {
+		addCols(opt.OptionalColList(private.FKCascades[i].OldValues))
+		addCols(opt.OptionalColList(private.FKCascades[i].NewValues))
+	}
 
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
@@ -137,10 +154,12 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 	//   3. For Upsert, the corresponding FETCH column is needed when there is
 	//      no corresponding UPDATE column. In that case, either the INSERT or
 	//      FETCH column becomes the RETURN column, so both must be available
-	//      for the CASE expression.
+	//      for the CASE expression. An Upsert that implements a MERGE statement
+	//      can also delete rows, in which case it is treated like a Delete.
+	deletes := op == opt.DeleteOp || (op == opt.UpsertOp && private.MergeActionCol != 0)
 	for ord, col := range private.ReturnCols {
 		if col != 0 {
-			if op == opt.DeleteOp || len(private.UpdateCols) == 0 || private.UpdateCols[ord] == 0 {
+			if deletes || len(private.UpdateCols) == 0 || private.UpdateCols[ord] == 0 {
 				cols.Add(tabMeta.MetaID.ColumnID(ord))
 			}
 		}
@@ -222,8 +241,9 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 				cols.UnionWith(fkCols)
 			}
 		}
+	}
 
-	case opt.DeleteOp:
+	if deletes {
 		// Add in all strict key columns from all indexes, since these are needed
 		// to compose the keys of rows to delete. Include mutation indexes, since
 		// it is necessary to delete rows even from indexes that are being added
@@ -637,6 +657,19 @@ func (c *CustomFuncs) DerivePruneCols(e memo.RelExpr, disabledRules intsets.Fast
 		inputPruneCols := c.DerivePruneCols(ord.Input, disabledRules)
 		relProps.Rule.PruneCols = inputPruneCols.Difference(ord.Ordering.ColSet())
 
+	case opt.ExpandOp:
+		if disabledRules.Contains(int(opt.PruneExpandCols)) {
+			// Avoid rule cycles.
+			break
+		}
+		// Any pruneable input columns can potentially be pruned, as long as
+		// they're not grouped on. The grouping columns and the grouping set index
+		// cannot be pruned without adding an additional Project operator, so
+		// don't add them to the set.
+		expand := e.(*memo.ExpandExpr)
+		inputPruneCols := c.DerivePruneCols(expand.Input, disabledRules)
+		relProps.Rule.PruneCols = inputPruneCols.Difference(expand.GroupingCols.ToSet())
+
 	case opt.IndexJoinOp, opt.LookupJoinOp, opt.MergeJoinOp:
 		// There is no need to prune columns projected by Index, Lookup or Merge
 		// joins, since its parent will always be an "alternate" expression in the
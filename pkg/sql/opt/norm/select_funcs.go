@@ -68,6 +68,47 @@ func (c *CustomFuncs) MapSetOpFilterRight(
 	return c.f.RemapCols(filter.Condition, colMap)
 }
 
+// ExpandPushableCols returns the columns that a filter above the given Expand
+// can reference and still be pushed below it: the input columns, and the
+// output columns of the grouping columns that every grouping set groups on.
+func (c *CustomFuncs) ExpandPushableCols(
+	input memo.RelExpr, private *memo.ExpandPrivate,
+) opt.ColSet {
+	cols := input.Relational().OutputCols.Copy()
+	for _, col := range private.OutCols {
+		if groupedByAllSets(col, private) {
+			cols.Add(col)
+		}
+	}
+	return cols
+}
+
+// MapExpandFilter maps the filter onto the input of the Expand operator by
+// replacing the output columns of the grouping columns that every grouping set
+// groups on with the corresponding input columns.
+func (c *CustomFuncs) MapExpandFilter(
+	filter *memo.FiltersItem, private *memo.ExpandPrivate,
+) opt.ScalarExpr {
+	var colMap opt.ColMap
+	for i, col := range private.OutCols {
+		if groupedByAllSets(col, private) {
+			colMap.Set(int(col), int(private.GroupingCols[i]))
+		}
+	}
+	return c.f.RemapCols(filter.Condition, colMap)
+}
+
+// groupedByAllSets returns true if every grouping set of the Expand operator
+// groups on the given output column.
+func groupedByAllSets(col opt.ColumnID, private *memo.ExpandPrivate) bool {
+	for _, set := range private.GroupingSets {
+		if !set.Contains(col) {
+			return false
+		}
+	}
+	return true
+}
+
 // makeMapFromColLists maps each column ID in src to a column ID in dst. The
 // columns IDs are mapped based on their relative positions in the column lists,
 // e.g. the third item in src maps to the third item in dst. The lists must be
@@ -424,3 +465,39 @@ func (c *CustomFuncs) addConjuncts(
 func (c *CustomFuncs) ForDuplicateRemoval(private *memo.OrdinalityPrivate) (ok bool) {
 	return private.ForDuplicateRemoval
 }
+
+// IsLeakproofPermeableBarrier returns true if leakproof filters can be pushed
+// into a Barrier with the given private.
+func (c *CustomFuncs) IsLeakproofPermeableBarrier(private *memo.BarrierPrivate) bool {
+	return private.LeakproofPermeable
+}
+
+// IsLeakproofFilter returns true if the given filter is leakproof, meaning
+// that it has no side effects and cannot raise an error.
+func (c *CustomFuncs) IsLeakproofFilter(filter *memo.FiltersItem) bool {
+	return filter.ScalarProps().VolatilitySet.IsLeakproof()
+}
+
+// ExtractLeakproofFilters returns a new list containing only the leakproof
+// filters in the given list.
+func (c *CustomFuncs) ExtractLeakproofFilters(filters memo.FiltersExpr) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if c.IsLeakproofFilter(&filters[i]) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
+// ExtractNonLeakproofFilters returns a new list containing only the filters in
+// the given list that are not leakproof.
+func (c *CustomFuncs) ExtractNonLeakproofFilters(filters memo.FiltersExpr) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if !c.IsLeakproofFilter(&filters[i]) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
@@ -77,6 +77,11 @@ type Builder struct {
 	// a statement during session migration.
 	SkipAOST bool
 
+	// AllowMaterializedViewMutation is a control knob: if set, optbuilder
+	// allows mutations of materialized views. This is only used for the
+	// statements that apply the changes of an incremental refresh.
+	AllowMaterializedViewMutation bool
+
 	// -- Results --
 	//
 	// These fields are set during the building process and can be used after
@@ -322,7 +327,7 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable, *tree.CreateView,
 			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateRoutine:
@@ -373,6 +378,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
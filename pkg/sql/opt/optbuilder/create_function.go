@@ -85,7 +85,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	}()
 
 	if cf.RoutineBody != nil {
-		panic(unimplemented.New("CREATE FUNCTION sql_body", "CREATE FUNCTION...sql_body unimplemented"))
+		convertSQLStandardRoutineBody(cf)
 	}
 
 	if err := tree.ValidateRoutineOptions(cf.Options, cf.IsProcedure); err != nil {
@@ -177,6 +177,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	bodyScope := b.allocScope()
 	// routineParams are all parameters of PLpgSQL routines.
 	var routineParams []routineParam
+	// sawVariadic is true if a VARIADIC parameter has been seen.
+	var sawVariadic bool
 	var outParamTypes []*types.T
 	// When multiple OUT parameters are present, parameter names become the
 	// labels in the output RECORD type.
@@ -195,6 +197,18 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			}
 			outParamNames = append(outParamNames, paramName)
 		}
+		if param.Class == tree.RoutineParamVariadic {
+			if cf.IsProcedure {
+				panic(unimplemented.NewWithIssue(88947, "variadic procedures are not yet supported"))
+			}
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition, "VARIADIC parameter must be an array"))
+			}
+			sawVariadic = true
+		} else if sawVariadic && param.IsInParam() {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"VARIADIC parameter must be the last input parameter"))
+		}
 		// The parameter type must be supported by the current cluster version.
 		checkUnsupportedType(b.ctx, b.semaCtx, typ)
 		if types.IsRecordType(typ) {
@@ -252,10 +266,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition, "function result type must be %s because of OUT parameters", outParamType.Name()))
 		}
 		// Override the return types so that we do return type validation and SHOW
-		// CREATE correctly.
+		// CREATE correctly. A set-returning function with OUT parameters returns
+		// a set of the OUT parameter type.
 		funcReturnType = outParamType
 		cf.ReturnType = &tree.RoutineReturnType{
-			Type: outParamType,
+			Type:  outParamType,
+			SetOf: cf.ReturnType != nil && cf.ReturnType.SetOf,
 		}
 	} else if funcReturnType == nil {
 		if cf.IsProcedure {
@@ -277,6 +293,17 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "PL/pgSQL functions cannot return type unknown"))
 		}
 	}
+	if funcReturnType.Family() == types.TriggerFamily {
+		if language != tree.RoutineLangPLpgSQL {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "SQL functions cannot return type trigger"))
+		}
+		if len(cf.Params) > 0 {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.InvalidFunctionDefinition, "trigger functions cannot have declared arguments"),
+				"The arguments of the trigger can be accessed through TG_NARGS and TG_ARGV instead.",
+			))
+		}
+	}
 	// Collect the user defined type dependency of the return type.
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
 		typeDeps.Add(int(id))
@@ -320,13 +347,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			}
 		})
 	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType != nil && cf.ReturnType.SetOf {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-
 		// Parse the function body.
 		stmt, err := plpgsqlparser.Parse(funcBodyStr)
 		if err != nil {
@@ -336,14 +356,21 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// We need to disable stable function folding because we want to catch the
 		// volatility of stable functions. If folded, we only get a scalar and lose
 		// the volatility.
-		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
-			plBuilder := newPLpgSQLBuilder(
-				b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
-				routineParams, funcReturnType, cf.IsProcedure, nil, /* outScope */
-			)
-			stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
-		})
-		checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		//
+		// The body of a trigger function is only built when the trigger fires,
+		// since the types of the NEW and OLD variables depend on the table the
+		// trigger is defined on.
+		if funcReturnType.Family() != types.TriggerFamily {
+			isSetReturning := cf.ReturnType != nil && cf.ReturnType.SetOf
+			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+				plBuilder := newPLpgSQLBuilder(
+					b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
+					routineParams, funcReturnType, cf.IsProcedure, isSetReturning, nil, /* outScope */
+				)
+				stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+			})
+			checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		}
 
 		// Format the statements with qualified datasource names.
 		formatFuncBodyStmt(fmtCtx, stmt.AST, language, false /* newLine */)
@@ -395,6 +422,40 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	return outScope
 }
 
+// convertSQLStandardRoutineBody replaces the SQL-standard body of the given
+// routine, i.e. a RETURN statement or a BEGIN ATOMIC block, with an equivalent
+// body string of a SQL routine, so that it is built and stored like any other
+// SQL routine. RETURN expr is equivalent to SELECT expr.
+func convertSQLStandardRoutineBody(cf *tree.CreateRoutine) {
+	languageFound := false
+	for _, option := range cf.Options {
+		switch opt := option.(type) {
+		case tree.RoutineBodyStr:
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "duplicate function body specified"))
+		case tree.RoutineLanguage:
+			if opt != tree.RoutineLangSQL {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"inline SQL function body only valid for language SQL"))
+			}
+			languageFound = true
+		}
+	}
+	fmtCtx := tree.NewFmtCtx(tree.FmtParsable)
+	for i, stmt := range cf.RoutineBody.Stmts {
+		if ret, ok := stmt.(*tree.RoutineReturn); ok {
+			stmt = &tree.Select{Select: &tree.SelectClause{
+				Exprs: tree.SelectExprs{{Expr: ret.ReturnVal}},
+			}}
+		}
+		formatFuncBodyStmt(fmtCtx, stmt, tree.RoutineLangSQL, i > 0 /* newLine */)
+	}
+	if !languageFound {
+		cf.Options = append(cf.Options, tree.RoutineLangSQL)
+	}
+	cf.Options = append(cf.Options, tree.RoutineBodyStr(fmtCtx.CloseAndGetString()))
+	cf.RoutineBody = nil
+}
+
 func formatFuncBodyStmt(
 	fmtCtx *tree.FmtCtx, ast tree.NodeFormatter, lang tree.RoutineLanguage, newLine bool,
 ) {
@@ -71,7 +71,12 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		}
 	}()
 
-	defScope := b.buildStmtAtRoot(cv.AsSource, nil /* desiredTypes */)
+	// The query of a recursive view is a recursive CTE named after the view.
+	source := cv.AsSource
+	if cv.Recursive {
+		source = cv.RecursiveViewQuery()
+	}
+	defScope := b.buildStmtAtRoot(source, nil /* desiredTypes */)
 
 	p := defScope.makePhysicalProps().Presentation
 	if len(cv.ColumnNames) != 0 {
@@ -111,7 +116,7 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		&memo.CreateViewPrivate{
 			Syntax:    cv,
 			Schema:    schID,
-			ViewQuery: tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
+			ViewQuery: tree.AsStringWithFlags(source, tree.FmtParsable),
 			Columns:   p,
 			Deps:      b.schemaDeps,
 			TypeDeps:  b.schemaTypeDeps,
@@ -37,6 +37,7 @@ package optbuilder
 
 import (
 	"context"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -100,8 +101,43 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets is set if the GROUP BY clause has more than one grouping set
+	// (because it uses ROLLUP, CUBE or GROUPING SETS). Each set contains the
+	// columns in groupingSetCols that are grouped on in that grouping set. For
+	// example:
+	//
+	//   SELECT a, b, count(*) FROM t GROUP BY ROLLUP (a, b)
+	//
+	//   groupingSets: (a, b), (a), ()
+	//
+	groupingSets []opt.ColSet
+
+	// groupingSetCols is set along with groupingSets. It contains the grouping
+	// columns produced by the aggregation, which are NULL in the rows of the
+	// grouping sets that don't group on them. groupingSetCols[i] corresponds to
+	// groupingCols()[i], and groupStrs refers to these columns rather than to
+	// the columns in aggInScope.
+	groupingSetCols []scopeColumn
+
+	// groupingSetIDCol is set along with groupingSets. It is produced by the
+	// aggregation, and contains the index in groupingSets of the grouping set
+	// of each row. It is used to compute the GROUPING function.
+	groupingSetIDCol *scopeColumn
 }
 
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can expand to. This matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements in a CUBE. This matches
+// the limit in Postgres.
+const maxCubeElements = 12
+
+// maxGroupingArgs is the maximum number of arguments to the GROUPING function,
+// so that the result fits in an INT4. This matches the limit in Postgres.
+const maxGroupingArgs = 31
+
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
 // grouping column in an aggOutScope scope that projects that expression. It
 // is used to enforce scoping rules, since any non-aggregate, variable
@@ -279,6 +315,40 @@ func (a *aggregateInfo) ResolvedType() *types.T {
 var _ tree.Expr = &aggregateInfo{}
 var _ tree.TypedExpr = &aggregateInfo{}
 
+// groupingInfo stores information about a GROUPING function call.
+type groupingInfo struct {
+	*tree.GroupingExpr
+
+	// args are the arguments of the GROUPING function, type checked in the
+	// scope of the query it belongs to. They must match grouping expressions.
+	args []tree.TypedExpr
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+var _ tree.Expr = &groupingInfo{}
+var _ tree.TypedExpr = &groupingInfo{}
+
 func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 	// We have an aggregation if:
 	//  - we have a GROUP BY, or
@@ -322,6 +392,211 @@ func (b *Builder) constructGroupBy(
 	return b.factory.ConstructGroupBy(input, aggs, &private)
 }
 
+// constructGroupingSets constructs the aggregation for a GROUP BY clause with
+// multiple grouping sets. The grouping sets that group on at least one column
+// are computed by a single GroupBy, over an Expand expression that produces a
+// copy of each input row for each of these grouping sets, along with the index
+// of the grouping set. In each copy, the grouping columns that the grouping set
+// doesn't group on are NULL. For example:
+//
+//	SELECT a, b, sum(c) FROM t GROUP BY GROUPING SETS ((a), (b))
+//
+// is built as:
+//
+//	group-by (hash)
+//	 ├── grouping columns: a' b' grouping_set
+//	 ├── expand
+//	 │    ├── grouping columns: a b
+//	 │    ├── grouping sets: (a') (b')
+//	 │    └── scan t
+//	 └── aggregations
+//	      └── sum [as=sum]
+//	           └── c
+//
+// If there is a single such grouping set, a Project is used instead of the
+// Expand.
+//
+// The empty grouping set produces a row even if the input has no rows, so each
+// empty grouping set is computed by a separate ScalarGroupBy. The results of the
+// GroupBy and ScalarGroupBy expressions are combined with UnionAll.
+func (b *Builder) constructGroupingSets(
+	input memo.RelExpr, g *groupby, aggCols []scopeColumn, ordering opt.Ordering,
+) memo.RelExpr {
+	md := b.factory.Metadata()
+
+	// Collect the aggregations, deduplicating the columns; we don't need to
+	// produce the same aggregation multiple times.
+	var aggScalars []opt.ScalarExpr
+	var outCols opt.ColList
+	var aggColSet opt.ColSet
+	for i := range aggCols {
+		if id, scalar := aggCols[i].id, aggCols[i].scalar; !aggColSet.Contains(id) {
+			if scalar == nil {
+				// A "pass through" column (i.e. a VariableOp) is not legal as an
+				// aggregation.
+				panic(errors.AssertionFailedf("variable as aggregation"))
+			}
+			aggScalars = append(aggScalars, scalar)
+			outCols = append(outCols, id)
+			aggColSet.Add(id)
+		}
+	}
+	for i := range g.groupingSetCols {
+		outCols = append(outCols, g.groupingSetCols[i].id)
+	}
+	outCols = append(outCols, g.groupingSetIDCol.id)
+
+	var nonEmptySets, emptySets []int
+	for i := range g.groupingSets {
+		if g.groupingSets[i].Empty() {
+			emptySets = append(emptySets, i)
+		} else {
+			nonEmptySets = append(nonEmptySets, i)
+		}
+	}
+	numBranches := len(emptySets)
+	if len(nonEmptySets) > 0 {
+		numBranches++
+	}
+
+	// newCols returns the output columns of a branch, laid out like outCols.
+	// If there is a single branch, it produces outCols directly. Otherwise the
+	// branches produce new columns, and are combined with UnionAll.
+	newCols := func() opt.ColList {
+		if numBranches == 1 {
+			return outCols
+		}
+		cols := make(opt.ColList, len(outCols))
+		for i, id := range outCols {
+			colMeta := md.ColumnMeta(id)
+			cols[i] = md.AddColumn(colMeta.Alias, colMeta.Type)
+		}
+		return cols
+	}
+
+	constructAggs := func(cols opt.ColList) memo.AggregationsExpr {
+		aggs := make(memo.AggregationsExpr, len(aggScalars))
+		for i := range aggScalars {
+			aggs[i] = b.factory.ConstructAggregationsItem(aggScalars[i], cols[i])
+		}
+		return aggs
+	}
+	setIDConst := func(i int) opt.ScalarExpr {
+		return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int)
+	}
+	numAggs := len(aggScalars)
+	groupingCols := g.groupingCols()
+
+	var branches []memo.RelExpr
+	var branchCols []opt.ColList
+	if len(nonEmptySets) > 0 {
+		cols := newCols()
+		setIDCol := cols[len(cols)-1]
+		var groupByInput memo.RelExpr
+		if len(nonEmptySets) == 1 {
+			// With a single grouping set, the grouping set index is a constant,
+			// and each grouping column is either always or never grouped on.
+			set := g.groupingSets[nonEmptySets[0]]
+			passthrough := input.Relational().OutputCols.Copy()
+			projections := make(memo.ProjectionsExpr, 0, len(groupingCols)+1)
+			for i := range g.groupingSetCols {
+				col := &g.groupingSetCols[i]
+				var scalar opt.ScalarExpr
+				if set.Contains(col.id) {
+					scalar = b.factory.ConstructVariable(groupingCols[i].id)
+				} else {
+					scalar = b.factory.ConstructNull(col.typ)
+				}
+				projections = append(projections,
+					b.factory.ConstructProjectionsItem(scalar, cols[numAggs+i]),
+				)
+			}
+			projections = append(projections,
+				b.factory.ConstructProjectionsItem(setIDConst(nonEmptySets[0]), setIDCol),
+			)
+			groupByInput = b.factory.ConstructProject(input, projections, passthrough)
+		} else {
+			// Produce a copy of each input row for each grouping set. The
+			// non-empty grouping sets come first in groupingSets (see
+			// buildGroupingSets), so the index of a grouping set among the
+			// non-empty sets is also its index in groupingSets.
+			private := memo.ExpandPrivate{
+				GroupingCols: make(opt.ColList, len(groupingCols)),
+				OutCols:      make(opt.ColList, len(groupingCols)),
+				GroupingSets: make(memo.GroupingSets, len(nonEmptySets)),
+				SetIDCol:     setIDCol,
+			}
+			for i := range groupingCols {
+				private.GroupingCols[i] = groupingCols[i].id
+				private.OutCols[i] = cols[numAggs+i]
+			}
+			for i, idx := range nonEmptySets {
+				if idx != i {
+					panic(errors.AssertionFailedf("empty grouping set before non-empty grouping set"))
+				}
+				// Remap the grouping set to the output columns of this branch.
+				var set opt.ColSet
+				for j := range g.groupingSetCols {
+					if g.groupingSets[idx].Contains(g.groupingSetCols[j].id) {
+						set.Add(private.OutCols[j])
+					}
+				}
+				private.GroupingSets[i] = set
+			}
+			groupByInput = b.factory.ConstructExpand(input, &private)
+		}
+
+		var groupingColSet opt.ColSet
+		for _, id := range cols[numAggs:] {
+			groupingColSet.Add(id)
+		}
+		private := memo.GroupingPrivate{GroupingCols: groupingColSet}
+		private.Ordering.FromOrderingWithOptCols(ordering, groupingColSet)
+		branches = append(branches, b.factory.ConstructGroupBy(groupByInput, constructAggs(cols), &private))
+		branchCols = append(branchCols, cols)
+	}
+
+	for _, idx := range emptySets {
+		cols := newCols()
+		private := memo.GroupingPrivate{}
+		private.Ordering.FromOrderingWithOptCols(ordering, opt.ColSet{})
+		scalarGroupBy := b.factory.ConstructScalarGroupBy(input, constructAggs(cols), &private)
+
+		// None of the grouping columns are grouped on.
+		var passthrough opt.ColSet
+		for _, id := range cols[:numAggs] {
+			passthrough.Add(id)
+		}
+		projections := make(memo.ProjectionsExpr, 0, len(g.groupingSetCols)+1)
+		for i := range g.groupingSetCols {
+			projections = append(projections, b.factory.ConstructProjectionsItem(
+				b.factory.ConstructNull(g.groupingSetCols[i].typ), cols[numAggs+i],
+			))
+		}
+		projections = append(projections,
+			b.factory.ConstructProjectionsItem(setIDConst(idx), cols[len(cols)-1]),
+		)
+		branches = append(branches, b.factory.ConstructProject(scalarGroupBy, projections, passthrough))
+		branchCols = append(branchCols, cols)
+	}
+
+	// Combine the branches.
+	res, resCols := branches[0], branchCols[0]
+	for i := 1; i < len(branches); i++ {
+		unionCols := outCols
+		if i < len(branches)-1 {
+			unionCols = newCols()
+		}
+		res = b.factory.ConstructUnionAll(res, branches[i], &memo.SetPrivate{
+			LeftCols:  resCols,
+			RightCols: branchCols[i],
+			OutCols:   unionCols,
+		})
+		resCols = unionCols
+	}
+	return res
+}
+
 // buildGroupingColumns builds the grouping columns and adds them to the
 // groupby scopes that will be used to build the aggregation expression.
 // Returns the slice of grouping columns.
@@ -335,7 +610,12 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
 
 	// Copy the grouping columns to the aggOutScope.
-	g.aggOutScope.appendColumns(g.groupingCols())
+	if g.groupingSets != nil {
+		g.aggOutScope.appendColumns(g.groupingSetCols)
+		g.aggOutScope.appendColumn(g.groupingSetIDCol)
+	} else {
+		g.aggOutScope.appendColumns(g.groupingCols())
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -354,6 +634,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplementedWithIssueDetailf(46280, "ordered aggregates",
+				"ordered aggregates are not supported with multiple grouping sets"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -385,7 +669,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.def.Overload.UserDefinedAggregate != nil {
+			aggCols[i].scalar = b.constructUserDefinedAggregate(&aggInfos[i], args)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -430,12 +718,16 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.constructGroupingSets(g.aggInScope.expr, g, aggCols, g.aggInScope.ordering)
+	} else {
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -510,15 +802,188 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	if hasGroupingSets(groupBy) {
+		b.buildGroupingSets(groupBy, selects, projectionsScope, fromScope)
+	} else {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
 	}
 	g.buildingGroupingCols = false
 }
 
+// hasGroupingSets returns true if the GROUP BY clause contains ROLLUP, CUBE or
+// GROUPING SETS.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds the grouping columns of a GROUP BY clause that
+// contains ROLLUP, CUBE or GROUPING SETS, and computes its grouping sets. The
+// grouping set of the whole clause is the cross product of the grouping sets
+// of each of its items. For example:
+//
+//	GROUP BY a, ROLLUP (b, c)
+//
+// has the grouping sets (a, b, c), (a, b) and (a).
+//
+// If there is more than one grouping set, groupingSets, groupingSetCols and
+// groupingSetIDCol are populated, and groupStrs is updated to refer to the
+// grouping columns in groupingSetCols. Otherwise, the single grouping set is
+// built like a regular GROUP BY.
+func (b *Builder) buildGroupingSets(
+	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) {
+	g := fromScope.groupby
+
+	sets := []opt.ColSet{{}}
+	for _, e := range groupBy {
+		itemSets := b.expandGroupingSet(e, selects, projectionsScope, fromScope)
+		if len(sets)*len(itemSets) > maxGroupingSets {
+			panic(pgerror.Newf(pgcode.StatementTooComplex,
+				"too many grouping sets present (maximum %d)", maxGroupingSets))
+		}
+		product := make([]opt.ColSet, 0, len(sets)*len(itemSets))
+		for _, set := range sets {
+			for _, itemSet := range itemSets {
+				product = append(product, set.Union(itemSet))
+			}
+		}
+		sets = product
+	}
+	if len(sets) == 1 {
+		return
+	}
+
+	// Move the empty grouping sets to the end, so that the index of each
+	// non-empty grouping set is also its index in the Expand expression built
+	// by constructGroupingSets.
+	sort.SliceStable(sets, func(i, j int) bool {
+		return !sets[i].Empty() && sets[j].Empty()
+	})
+
+	// Create the grouping columns produced by the aggregation, and remap the
+	// grouping sets to them.
+	md := b.factory.Metadata()
+	groupingCols := g.groupingCols()
+	g.groupingSetCols = make([]scopeColumn, len(groupingCols))
+	var colMap opt.ColMap
+	ords := make(map[opt.ColumnID]int, len(groupingCols))
+	for i := range groupingCols {
+		col := &groupingCols[i]
+		g.groupingSetCols[i] = scopeColumn{
+			name: col.name,
+			typ:  col.typ,
+			id:   md.AddColumn(col.name.MetadataName(), col.typ),
+			expr: col.expr,
+		}
+		if _, ok := ords[col.id]; !ok {
+			ords[col.id] = i
+			colMap.Set(int(col.id), int(g.groupingSetCols[i].id))
+		}
+	}
+	g.groupingSets = make([]opt.ColSet, len(sets))
+	for i := range sets {
+		g.groupingSets[i] = sets[i].CopyAndMaybeRemap(colMap)
+	}
+	for exprStr, col := range g.groupStrs {
+		if i, ok := ords[col.id]; ok {
+			g.groupStrs[exprStr] = &g.groupingSetCols[i]
+		}
+	}
+	g.groupingSetIDCol = &scopeColumn{
+		name: scopeColName("grouping_set"),
+		typ:  types.Int,
+		id:   md.AddColumn("grouping_set", types.Int),
+	}
+}
+
+// expandGroupingSet builds the grouping columns of the given GROUP BY item,
+// and returns its grouping sets.
+func (b *Builder) expandGroupingSet(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	set, ok := groupBy.(*tree.GroupingSet)
+	if !ok {
+		// A regular expression, or a tuple of expressions, forms a single
+		// grouping set.
+		cols := b.buildGrouping(groupBy, selects, projectionsScope, fromScope, fromScope.groupby.aggInScope)
+		return []opt.ColSet{cols}
+	}
+
+	switch set.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (a, b) has the grouping sets (a, b), (a) and ().
+		elems := b.buildGroupingSetElems(set.Exprs, selects, projectionsScope, fromScope)
+		res := make([]opt.ColSet, len(elems)+1)
+		for i := range res {
+			for j := 0; j < len(elems)-i; j++ {
+				res[i].UnionWith(elems[j])
+			}
+		}
+		return res
+
+	case tree.CubeGroupingSet:
+		// CUBE (a, b) has the grouping sets (a, b), (a), (b) and ().
+		if len(set.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.TooManyColumns,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		elems := b.buildGroupingSetElems(set.Exprs, selects, projectionsScope, fromScope)
+		n := len(elems)
+		res := make([]opt.ColSet, 0, 1<<n)
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<(n-1-i)) != 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			res = append(res, set)
+		}
+		return res
+
+	case tree.ExplicitGroupingSets:
+		// GROUPING SETS (...) has the grouping sets of each of its elements.
+		var res []opt.ColSet
+		for _, e := range set.Exprs {
+			res = append(res, b.expandGroupingSet(e, selects, projectionsScope, fromScope)...)
+			if len(res) > maxGroupingSets {
+				panic(pgerror.Newf(pgcode.StatementTooComplex,
+					"too many grouping sets present (maximum %d)", maxGroupingSets))
+			}
+		}
+		return res
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %d", set.Type))
+	}
+}
+
+// buildGroupingSetElems builds the grouping columns of the elements of a
+// ROLLUP or CUBE, and returns the columns of each element.
+func (b *Builder) buildGroupingSetElems(
+	exprs tree.Exprs, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	elems := make([]opt.ColSet, len(exprs))
+	for i, e := range exprs {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			panic(pgerror.New(pgcode.Syntax, "ROLLUP and CUBE cannot contain ROLLUP, CUBE or GROUPING SETS"))
+		}
+		elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, fromScope.groupby.aggInScope)
+	}
+	return elems
+}
+
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the grouping columns corresponding
+// to the expression.
 //
 // groupBy          The given GROUP BY expression.
 // selects          The select expressions are needed in case the GROUP BY
@@ -538,7 +1003,7 @@ func (b *Builder) buildGroupingList(
 //	as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -636,7 +1101,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -646,7 +1112,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -940,6 +1408,11 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// Not all grouping columns are grouped on in every grouping set, so they
+		// don't determine the other columns of the table.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -968,6 +1441,9 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.IsExclusion() {
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
@@ -1010,3 +1486,54 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	}
 	return false
 }
+
+// buildGroupingFunc builds the GROUPING function. Bit i of the result
+// (counting from the rightmost argument) is set if the grouping set of the
+// current row doesn't group on argument i. With multiple grouping sets, this is
+// computed from the grouping set index; otherwise it is always 0.
+func (b *Builder) buildGroupingFunc(grouping *groupingInfo, inScope *scope) opt.ScalarExpr {
+	g := inScope.groupby
+	argCols := make([]opt.ColumnID, len(grouping.args))
+	for i, arg := range grouping.args {
+		var col *scopeColumn
+		if g != nil {
+			col = g.groupStrs[symbolicExprStr(arg)]
+		}
+		if col == nil {
+			panic(pgerror.New(pgcode.Grouping,
+				"arguments to GROUPING must be grouping expressions of the associated query level"))
+		}
+		argCols[i] = col.id
+	}
+
+	intConst := func(i int) opt.ScalarExpr {
+		return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int)
+	}
+	if g.groupingSets == nil {
+		return intConst(0)
+	}
+
+	masks := make([]int, len(g.groupingSets))
+	allEqual := true
+	for i, set := range g.groupingSets {
+		for _, col := range argCols {
+			masks[i] <<= 1
+			if !set.Contains(col) {
+				masks[i] |= 1
+			}
+		}
+		allEqual = allEqual && masks[i] == masks[0]
+	}
+	if allEqual {
+		return intConst(masks[0])
+	}
+
+	last := len(masks) - 1
+	whens := make(memo.ScalarListExpr, last)
+	for i := range whens {
+		whens[i] = b.factory.ConstructWhen(intConst(i), intConst(masks[i]))
+	}
+	return b.factory.ConstructCase(
+		b.factory.ConstructVariable(g.groupingSetIDCol.id), whens, intConst(masks[last]),
+	)
+}
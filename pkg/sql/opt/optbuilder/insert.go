@@ -233,6 +233,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		mb.init(b, "insert", tab, alias)
 	}
 
+	// Triggers are not yet fired for UPSERT and INSERT ON CONFLICT DO UPDATE,
+	// which may either insert or update each row.
+	if ins.OnConflict != nil && !ins.OnConflict.DoNothing {
+		checkRowLevelTriggersSupported(tab, mb.opName)
+	}
+
 	// Compute target columns in two cases:
 	//
 	//   1. When explicitly specified by name:
@@ -680,6 +686,10 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Fire any BEFORE triggers, which may change the values of the
+	// non-computed columns.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -697,6 +707,9 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Check the new rows against any row-level security policies.
+	mb.addRowLevelSecurityChecks(tree.PolicyCommandInsert)
+
 	// Project partial index PUT boolean columns.
 	mb.projectPartialIndexPutCols()
 
@@ -704,6 +717,8 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildRowLevelAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
@@ -903,6 +918,8 @@ func (mb *mutationBuilder) setUpsertCols(insertCols tree.NameList) {
 // buildUpsert constructs an Upsert operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
+	mb.b.checkRowLevelSecuritySupported(mb.tab, "UPSERT")
+
 	// Merge input insert and update columns using CASE expressions.
 	mb.projectUpsertColumns()
 
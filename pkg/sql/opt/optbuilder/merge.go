@@ -0,0 +1,750 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// duplicateMergeErrText is error text used when a target row is matched by
+// more than one source row that would update or delete it.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. The source rows are
+// left joined with the target table, and each joined row is assigned the
+// ordinal of the first WHEN clause that applies to it:
+//
+//	CREATE TABLE t (k INT PRIMARY KEY, v INT)
+//	MERGE INTO t USING s ON t.k = s.k
+//	WHEN MATCHED AND s.v IS NULL THEN DELETE
+//	WHEN MATCHED THEN UPDATE SET v = s.v
+//	WHEN NOT MATCHED THEN INSERT VALUES (s.k, s.v)
+//
+// This would create an input expression similar to this SQL:
+//
+//	SELECT *, CASE
+//	  WHEN t.k IS NOT NULL AND s.v IS NULL THEN 1
+//	  WHEN t.k IS NOT NULL THEN 2
+//	  WHEN t.k IS NULL THEN 3
+//	  ELSE 0
+//	END AS clause
+//	FROM s LEFT JOIN t ON t.k = s.k
+//
+// Rows with no clause (including rows handled by a DO NOTHING clause) are
+// filtered out, and an EnsureUpsertDistinctOn operator raises an error if a
+// target row would be updated or deleted more than once.
+//
+// All of the actions are then applied by a single Upsert operator, in the same
+// way as an INSERT .. ON CONFLICT DO UPDATE statement, with t.k as the canary
+// column. The insert and update columns select the values of the clause of
+// each row:
+//
+//	SELECT
+//	  *,
+//	  CASE clause WHEN 3 THEN s.k END AS ins_k,
+//	  CASE clause WHEN 3 THEN s.v END AS ins_v,
+//	  CASE clause WHEN 2 THEN s.v ELSE t.v END AS upd_v,
+//	  CASE clause WHEN 1 THEN 2 WHEN 2 THEN 1 WHEN 3 THEN 3 ELSE 0 END AS action
+//	FROM (...)
+//
+// The action column contains the tree.MergeActionType of each row, and is
+// used by the execution engine to delete the matched rows of DELETE clauses
+// rather than update them. See mutationBuilder.buildInputForMerge.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, check the permissions. Select
+	// permission is always required, since existing rows are matched against
+	// the source rows.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot merge into view \"%s\"", tab.Name(),
+		))
+	}
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+	b.checkRowLevelSecuritySupported(tab, "MERGE")
+
+	// Check the permissions required by each action.
+	var hasMutation, hasDelete, needDistinct bool
+	for _, when := range merge.Whens {
+		switch when.Action {
+		case tree.MergeInsert:
+			b.checkPrivilege(depName, tab, privilege.INSERT)
+		case tree.MergeUpdate:
+			b.checkPrivilege(depName, tab, privilege.UPDATE)
+		case tree.MergeDelete:
+			b.checkPrivilege(depName, tab, privilege.DELETE)
+			hasDelete = true
+		default:
+			continue
+		}
+		hasMutation = true
+		if when.Matched {
+			needDistinct = true
+		}
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, generalMutation)
+
+	input := b.buildMergeInput(merge, tab, alias, needDistinct, inScope)
+
+	var returning *tree.ReturningExprs
+	if resultsNeeded(merge.Returning) {
+		returning = merge.Returning.(*tree.ReturningExprs)
+	}
+
+	if !hasMutation {
+		// All of the clauses are DO NOTHING, so no rows are affected.
+		return b.buildMergeNoop(&input, returning)
+	}
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Triggers are not yet fired for MERGE, which may insert, update, or
+	// delete each row.
+	checkRowLevelTriggersSupported(tab, mb.opName)
+
+	mb.buildInputForMerge(merge, &input)
+
+	// Build the final upsert, including any returned expressions.
+	mb.buildMerge(returning, hasDelete)
+
+	return mb.outScope
+}
+
+// buildMergeNoop builds the output of a MERGE statement that does not modify
+// any rows. If there is a RETURNING clause, it is built over an empty set of
+// target rows so that its columns are still resolved and type checked.
+func (b *Builder) buildMergeNoop(input *mergeInput, returning *tree.ReturningExprs) *scope {
+	if returning == nil {
+		outScope := input.scope.replace()
+		outScope.expr = b.factory.ConstructValues(memo.EmptyScalarListExpr, &memo.ValuesPrivate{
+			Cols: opt.ColList{},
+			ID:   b.factory.Metadata().NextUniqueID(),
+		})
+		return outScope
+	}
+
+	// Only the ordinary target columns can be referenced by the RETURNING
+	// clause.
+	inScope := input.scope.replace()
+	for _, col := range input.scope.cols[:input.numFetchCols] {
+		if col.kind == cat.Ordinary && !col.mutation {
+			inScope.cols = append(inScope.cols, col)
+		}
+	}
+	inScope.expr = b.factory.ConstructSelect(
+		input.scope.expr, memo.FiltersExpr{b.factory.ConstructFiltersItem(memo.FalseSingleton)},
+	)
+
+	outScope := inScope.replace()
+	b.analyzeReturningList(returning, nil /* desiredTypes */, inScope, outScope)
+	b.buildProjectionList(inScope, outScope)
+	b.constructProjectForScope(inScope, outScope)
+	return outScope
+}
+
+// mergeInput describes the input of the Upsert operator that implements a
+// MERGE statement. Its scope contains the columns fetched from the target table
+// (in table ordinal order), followed by the columns of the source, and finally
+// the clause column, which contains the 1-based ordinal of the WHEN clause that
+// applies to each row.
+type mergeInput struct {
+	scope *scope
+
+	// numFetchCols is the number of columns fetched from the target table.
+	numFetchCols int
+
+	// numSourceCols is the number of columns of the USING data source.
+	numSourceCols int
+
+	// clauseCol is the ID of the clause column.
+	clauseCol opt.ColumnID
+}
+
+// buildMergeInput builds the input of the Upsert operator that implements a
+// MERGE statement. See the buildMerge comment for details.
+func (b *Builder) buildMergeInput(
+	merge *tree.Merge, tab cat.Table, alias tree.TableName, needDistinct bool, inScope *scope,
+) mergeInput {
+	f := b.factory
+
+	// USING
+	sourceScope := b.buildDataSource(merge.Source, nil /* indexFlags */, noLocking, inScope)
+
+	// Fetch columns from the target table, including mutation columns. See the
+	// buildInputForUpdate comment.
+	var indexFlags *tree.IndexFlags
+	if source, ok := merge.Table.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
+		indexFlags = source.IndexFlags
+	}
+	fetchScope := b.buildScan(
+		b.addTable(tab, &alias),
+		tableOrdinals(tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	// Check that the same table name is not used on both sides.
+	b.validateJoinTableNames(sourceScope, fetchScope)
+
+	// ON
+	joinScope := inScope.push()
+	joinScope.appendColumnsFromScope(fetchScope)
+	joinScope.appendColumnsFromScope(sourceScope)
+	on := b.resolveAndBuildScalar(
+		merge.On,
+		types.Bool,
+		exprKindOn,
+		tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+		joinScope,
+	)
+	joinScope.expr = f.ConstructLeftJoin(
+		sourceScope.expr,
+		fetchScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(on)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// A row is matched if the not-null primary key column of the target table
+	// is not NULL.
+	canaryCol := fetchScope.cols[findNotNullIndexCol(tab.Index(cat.PrimaryIndex))].id
+	isMatched := f.ConstructIsNot(f.ConstructVariable(canaryCol), memo.NullSingleton)
+	isNotMatched := f.ConstructIs(f.ConstructVariable(canaryCol), memo.NullSingleton)
+
+	// Build the condition of each WHEN clause. WHEN MATCHED conditions can refer to
+	// both the target and source columns, but WHEN NOT MATCHED conditions can
+	// only refer to source columns.
+	whens := make(memo.ScalarListExpr, len(merge.Whens))
+	for i, when := range merge.Whens {
+		cond, condScope := isNotMatched, sourceScope
+		if when.Matched {
+			cond, condScope = isMatched, joinScope
+		}
+		if when.Cond != nil {
+			cond = f.ConstructAnd(cond, b.resolveAndBuildScalar(
+				when.Cond, types.Bool, exprKindMergeWhen, tree.RejectSpecial, condScope,
+			))
+		}
+		var clause tree.DInt
+		if when.Action != tree.MergeDoNothing {
+			clause = tree.DInt(i + 1)
+		}
+		whens[i] = f.ConstructWhen(cond, f.ConstructConstVal(tree.NewDInt(clause), types.Int))
+	}
+	zero := f.ConstructConstVal(tree.NewDInt(0), types.Int)
+	caseExpr := f.ConstructCase(memo.TrueSingleton, whens, zero)
+
+	projectionsScope := joinScope.replace()
+	projectionsScope.appendColumnsFromScope(joinScope)
+	clauseCol := b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("merge_clause"), types.Int, nil /* expr */, caseExpr,
+	).id
+	b.constructProjectForScope(joinScope, projectionsScope)
+
+	// Discard rows that are not affected by any clause.
+	projectionsScope.expr = f.ConstructSelect(
+		projectionsScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(
+			f.ConstructNe(f.ConstructVariable(clauseCol), zero),
+		)},
+	)
+
+	res := mergeInput{
+		scope:         projectionsScope,
+		numFetchCols:  len(fetchScope.cols),
+		numSourceCols: len(sourceScope.cols),
+		clauseCol:     clauseCol,
+	}
+
+	// Raise an error if a target row is matched by multiple source rows. Rows
+	// that are not matched have NULL primary key columns, and are all kept.
+	if needDistinct {
+		var pkCols opt.ColSet
+		primaryIndex := tab.Index(cat.PrimaryIndex)
+		for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+			pkCols.Add(fetchScope.cols[primaryIndex.Column(i).Ordinal()].id)
+		}
+		res.scope = b.buildDistinctOn(
+			pkCols, projectionsScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+		)
+	}
+	return res
+}
+
+// buildInputForMerge projects the insert, fetch, and update columns of the
+// Upsert operator that implements a MERGE statement, as well as its action
+// column. See the buildMerge comment for details.
+func (mb *mutationBuilder) buildInputForMerge(merge *tree.Merge, input *mergeInput) {
+	f := mb.b.factory
+	clauseCol := f.ConstructVariable(input.clauseCol)
+
+	// Build the values assigned by each clause before projecting any columns,
+	// since the projected columns are named after the target columns, which
+	// would make references to the fetched columns ambiguous.
+	insertWhens := make([]memo.ScalarListExpr, mb.tab.ColumnCount())
+	updateWhens := make([]memo.ScalarListExpr, mb.tab.ColumnCount())
+	actionWhens := make(memo.ScalarListExpr, 0, len(merge.Whens))
+	for i, when := range merge.Whens {
+		if when.Action == tree.MergeDoNothing {
+			continue
+		}
+		clause := f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int)
+		switch when.Action {
+		case tree.MergeInsert:
+			mb.buildMergeInsertValues(when, input, clause, insertWhens)
+		case tree.MergeUpdate:
+			mb.buildMergeUpdateValues(when, input, clause, updateWhens)
+		}
+		action := f.ConstructConstVal(tree.NewDInt(tree.DInt(when.Action)), types.Int)
+		actionWhens = append(actionWhens, f.ConstructWhen(clause, action))
+	}
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+
+	// Add an insert column for each ordinary, non-computed column of the table.
+	// Its value is NULL for the rows that are not inserted.
+	projectionsScope := input.scope.replace()
+	projectionsScope.appendColumnsFromScope(input.scope)
+	for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+		col := mb.tab.Column(ord)
+		if col.Kind() != cat.Ordinary || col.IsComputed() {
+			continue
+		}
+		typ := col.DatumType()
+		scalar := f.ConstructNull(typ)
+		if len(insertWhens[ord]) > 0 {
+			scalar = f.ConstructCase(clauseCol, insertWhens[ord], scalar)
+		}
+		mb.insertColIDs[ord] = mb.b.synthesizeColumn(
+			projectionsScope, scopeColName(col.ColName()), typ, nil /* expr */, scalar,
+		).id
+		mb.targetColList = append(mb.targetColList, mb.tabID.ColumnID(ord))
+		mb.targetColSet.Add(mb.tabID.ColumnID(ord))
+	}
+	mb.b.constructProjectForScope(input.scope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Add the write-only mutation columns and the computed columns.
+	mb.addSynthesizedColsForInsert()
+
+	// The fetched target columns are only available once the inserted values
+	// have been computed, since otherwise they would be used by the computed
+	// column expressions.
+	mb.fetchScope = input.scope.replace()
+	mb.fetchScope.appendColumns(input.scope.cols[:input.numFetchCols])
+	mb.fetchScope.expr = input.scope.expr
+	mb.setFetchColIDs(mb.fetchScope.cols)
+	mb.canaryColID = mb.fetchColIDs[findNotNullIndexCol(mb.tab.Index(cat.PrimaryIndex))]
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+
+	// Add an update column for each column that is updated by any clause. Its
+	// value is the existing value for the rows of other clauses.
+	projectionsScope = mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for ord, whens := range updateWhens {
+		if len(whens) == 0 {
+			continue
+		}
+		col := mb.tab.Column(ord)
+		name := scopeColName(col.ColName()).WithMetadataName(string(col.ColName()) + "_new")
+		scalar := f.ConstructCase(clauseCol, whens, f.ConstructVariable(mb.fetchColIDs[ord]))
+		mb.updateColIDs[ord] = mb.b.synthesizeColumn(
+			projectionsScope, name, col.DatumType(), nil /* expr */, scalar,
+		).id
+		mb.targetColList = append(mb.targetColList, mb.tabID.ColumnID(ord))
+		mb.targetColSet.Add(mb.tabID.ColumnID(ord))
+	}
+
+	// Add the action column.
+	noAction := f.ConstructConstVal(tree.NewDInt(tree.DInt(tree.MergeDoNothing)), types.Int)
+	mb.mergeActionColID = mb.b.synthesizeColumn(
+		projectionsScope,
+		scopeColName("").WithMetadataName("merge_action"),
+		types.Int,
+		nil, /* expr */
+		f.ConstructCase(clauseCol, actionWhens, noAction),
+	).id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Add additional columns for computed expressions that may depend on any
+	// updated columns, as well as mutation columns with default values.
+	mb.addSynthesizedColsForUpdate()
+}
+
+// buildMergeInsertValues builds the values inserted by the given WHEN NOT
+// MATCHED clause of a MERGE statement. For each ordinary, non-computed column
+// of the table, a WHEN expression that matches the given clause ordinal is
+// appended to the list of the column in insertWhens. The inserted values can
+// only refer to the source columns.
+func (mb *mutationBuilder) buildMergeInsertValues(
+	when *tree.MergeWhen, input *mergeInput, clause opt.ScalarExpr, insertWhens []memo.ScalarListExpr,
+) {
+	sourceScope := input.scope.replace()
+	sourceScope.appendColumns(input.scope.cols[input.numFetchCols : input.numFetchCols+input.numSourceCols])
+
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+	if len(when.Columns) > 0 {
+		mb.addTargetNamedColsForInsert(when.Columns)
+		mb.checkNumCols(len(mb.targetColList), len(when.Values))
+	} else if len(when.Values) > 0 {
+		mb.addTargetTableColsForInsert(len(when.Values))
+	}
+	values := make([]tree.Expr, mb.tab.ColumnCount())
+	for i, colID := range mb.targetColList {
+		values[mb.tabID.ColumnOrdinal(colID)] = when.Values[i]
+	}
+
+	// VALUES expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require(exprKindValues.String(), tree.RejectSpecial)
+	sourceScope.context = exprKindValues
+
+	for ord, expr := range values {
+		targetCol := mb.tab.Column(ord)
+		if targetCol.Kind() != cat.Ordinary || targetCol.IsComputed() {
+			continue
+		}
+		if expr == nil {
+			expr = tree.DefaultVal{}
+		}
+		if _, ok := expr.(tree.DefaultVal); ok {
+			expr = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+		} else if targetCol.IsGeneratedAlwaysAsIdentity() {
+			// GENERATED ALWAYS AS IDENTITY columns are not allowed to be
+			// explicitly written to.
+			panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(targetCol.ColName())))
+		}
+		value := mb.buildMergeValue(expr, ord, sourceScope)
+		insertWhens[ord] = append(insertWhens[ord], mb.b.factory.ConstructWhen(clause, value))
+	}
+}
+
+// buildMergeUpdateValues builds the SET expressions of the given WHEN MATCHED
+// clause of a MERGE statement. For each updated column, a WHEN expression that
+// matches the given clause ordinal is appended to the list of the column in
+// updateWhens. The SET expressions can refer to both the target and source
+// columns.
+func (mb *mutationBuilder) buildMergeUpdateValues(
+	when *tree.MergeWhen, input *mergeInput, clause opt.ScalarExpr, updateWhens []memo.ScalarListExpr,
+) {
+	// SET expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("UPDATE SET", tree.RejectSpecial)
+
+	f := mb.b.factory
+	addValue := func(colID opt.ColumnID, value opt.ScalarExpr) {
+		ord := mb.tabID.ColumnOrdinal(colID)
+		updateWhens[ord] = append(updateWhens[ord], f.ConstructWhen(clause, value))
+	}
+
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+	for _, set := range when.Exprs {
+		mb.addTargetColsByName(set.Names)
+		targetCols := mb.targetColList[len(mb.targetColList)-len(set.Names):]
+
+		if !set.Tuple {
+			addValue(targetCols[0], mb.buildMergeSetValue(set.Expr, targetCols[0], input.scope))
+			continue
+		}
+
+		n := -1
+		switch t := set.Expr.(type) {
+		case *tree.Tuple:
+			n = len(t.Exprs)
+			if n == len(targetCols) {
+				for i, expr := range t.Exprs {
+					addValue(targetCols[i], mb.buildMergeSetValue(expr, targetCols[i], input.scope))
+				}
+			}
+
+		case *tree.Subquery:
+			// Build the subquery as a single scalar, which is a tuple if it
+			// projects multiple columns, and extract each of its columns.
+			desiredTypes := make([]*types.T, len(targetCols))
+			for i := range desiredTypes {
+				desiredTypes[i] = mb.md.ColumnMeta(targetCols[i]).Type
+			}
+			texpr := input.scope.resolveType(t, types.MakeTuple(desiredTypes))
+			scalar := mb.b.buildScalar(texpr, input.scope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+			if typ := texpr.ResolvedType(); typ.Family() != types.TupleFamily {
+				n = 1
+				if n == len(targetCols) {
+					addValue(targetCols[0], mb.castMergeValue(scalar, mb.tabID.ColumnOrdinal(targetCols[0])))
+				}
+			} else {
+				n = len(typ.TupleContents())
+				if n == len(targetCols) {
+					for i := range targetCols {
+						value := f.ConstructColumnAccess(scalar, memo.TupleOrdinal(i))
+						addValue(targetCols[i], mb.castMergeValue(value, mb.tabID.ColumnOrdinal(targetCols[i])))
+					}
+				}
+			}
+		}
+		if n < 0 {
+			panic(unimplementedWithIssueDetailf(35713, fmt.Sprintf("%T", set.Expr),
+				"source for a multiple-column UPDATE item must be a sub-SELECT or ROW() expression; not supported: %T", set.Expr))
+		}
+		if len(set.Names) != n {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"number of columns (%d) does not match number of values (%d)",
+				len(set.Names), n))
+		}
+	}
+}
+
+// buildMergeSetValue builds the value of a single-column SET expression of a
+// MERGE statement, which may be DEFAULT.
+func (mb *mutationBuilder) buildMergeSetValue(
+	expr tree.Expr, targetColID opt.ColumnID, inScope *scope,
+) opt.ScalarExpr {
+	ord := mb.tabID.ColumnOrdinal(targetColID)
+	if _, ok := expr.(tree.DefaultVal); ok {
+		expr = mb.parseDefaultExpr(targetColID)
+	} else if targetCol := mb.tab.Column(ord); targetCol.IsGeneratedAlwaysAsIdentity() {
+		// GENERATED ALWAYS AS IDENTITY columns are not allowed to be explicitly
+		// written to.
+		panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(targetCol.ColName())))
+	}
+	return mb.buildMergeValue(expr, ord, inScope)
+}
+
+// buildMergeValue builds a value that a MERGE statement assigns to the table
+// column with the given ordinal. See castMergeValue.
+func (mb *mutationBuilder) buildMergeValue(expr tree.Expr, ord int, inScope *scope) opt.ScalarExpr {
+	texpr := inScope.resolveType(expr, mb.tab.Column(ord).DatumType())
+	scalar := mb.b.buildScalar(texpr, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	return mb.castMergeValue(scalar, ord)
+}
+
+// castMergeValue wraps a value that a MERGE statement assigns to the table
+// column with the given ordinal in an assignment cast when necessary, so that
+// the values of all clauses have the type of the column. It is the equivalent
+// of addAssignmentCasts for the expressions of a single clause.
+func (mb *mutationBuilder) castMergeValue(scalar opt.ScalarExpr, ord int) opt.ScalarExpr {
+	srcType := scalar.DataType()
+	targetCol := mb.tab.Column(ord)
+	targetType := targetCol.DatumType()
+	if srcType.Identical(targetType) {
+		return scalar
+	}
+	if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+		panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+	}
+	return mb.b.factory.ConstructAssignmentCast(scalar, targetType)
+}
+
+// buildMerge constructs an Upsert operator that implements a MERGE statement,
+// possibly wrapped by a Project operator that corresponds to the given
+// RETURNING clause. hasDelete is true if the statement has a DELETE clause.
+func (mb *mutationBuilder) buildMerge(returning *tree.ReturningExprs, hasDelete bool) {
+	// Merge input insert and update columns using CASE expressions.
+	mb.projectUpsertColumns()
+
+	// Disambiguate names so that references in any expressions, such as a
+	// check constraint, refer to the correct columns.
+	mb.disambiguateColumns()
+
+	// Add any check constraint boolean columns to the input.
+	mb.addCheckConstraintCols(false /* isUpdate */)
+
+	// Add the partial index predicate expressions to the table metadata.
+	// These expressions are used to prune fetch columns during
+	// normalization.
+	mb.b.addPartialIndexPredicatesForTable(mb.md.TableMeta(mb.tabID), nil /* scan */)
+
+	// Project partial index PUT and DEL boolean columns.
+	mb.projectPartialIndexPutAndDelCols()
+
+	// Project the columns of the deleted rows that are referenced by foreign
+	// keys. This must happen before the input is buffered for the checks.
+	var deletedColIDs opt.OptionalColList
+	if hasDelete {
+		deletedColIDs = mb.projectMergeDeletedCols()
+	}
+
+	mb.buildUniqueChecksForUpsert()
+
+	mb.buildFKChecksForUpsert()
+
+	if hasDelete {
+		mb.buildFKChecksAndCascadesForMergeDelete(deletedColIDs)
+	}
+
+	private := mb.makeMutationPrivate(returning != nil)
+	mb.outScope.expr = mb.b.factory.ConstructUpsert(
+		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
+	)
+
+	mb.buildReturning(returning)
+}
+
+// projectMergeDeletedCols projects a column for each column of the target table
+// that is referenced by an inbound foreign key. The column contains the
+// existing value for the rows that are deleted by a MERGE statement, and NULL
+// for all other rows. The returned list contains the IDs of the projected
+// columns, indexed by table column ordinal.
+func (mb *mutationBuilder) projectMergeDeletedCols() opt.OptionalColList {
+	f := mb.b.factory
+	deletedColIDs := make(opt.OptionalColList, mb.tab.ColumnCount())
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	isDeleted := f.ConstructEq(
+		f.ConstructVariable(mb.mergeActionColID),
+		f.ConstructConstVal(tree.NewDInt(tree.DInt(tree.MergeDelete)), types.Int),
+	)
+	for i, n := 0, mb.tab.InboundForeignKeyCount(); i < n; i++ {
+		fk := mb.tab.InboundForeignKey(i)
+		for j, m := 0, fk.ColumnCount(); j < m; j++ {
+			ord := fk.ReferencedColumnOrdinal(mb.tab, j)
+			if deletedColIDs[ord] != 0 {
+				continue
+			}
+			col := mb.tab.Column(ord)
+			scalar := f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(isDeleted, f.ConstructVariable(mb.fetchColIDs[ord]))},
+				f.ConstructNull(col.DatumType()),
+			)
+			name := scopeColName("").WithMetadataName(string(col.ColName()) + "_del")
+			deletedColIDs[ord] = mb.b.synthesizeColumn(
+				projectionsScope, name, col.DatumType(), nil /* expr */, scalar,
+			).id
+		}
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+	return deletedColIDs
+}
+
+// buildFKChecksAndCascadesForMergeDelete builds FK check queries and cascades
+// for the rows deleted by a MERGE statement. It is similar to
+// buildFKChecksAndCascadesForDelete, but the deleted values come from the
+// columns projected by projectMergeDeletedCols, which are NULL for the rows
+// that are not deleted and therefore never match a referencing row.
+func (mb *mutationBuilder) buildFKChecksAndCascadesForMergeDelete(deletedColIDs opt.OptionalColList) {
+	if mb.tab.InboundForeignKeyCount() == 0 {
+		// No relevant FKs.
+		return
+	}
+
+	f := mb.b.factory
+	mb.ensureWithID()
+	for i, n := 0, mb.tab.InboundForeignKeyCount(); i < n; i++ {
+		h := &mb.fkCheckHelper
+		if !h.initWithInboundFK(mb, i) {
+			continue
+		}
+		cols := make(opt.ColList, len(h.tabOrdinals))
+		for j, tabOrd := range h.tabOrdinals {
+			cols[j] = deletedColIDs[tabOrd]
+		}
+
+		if a := h.fk.DeleteReferenceAction(); a != tree.Restrict && a != tree.NoAction {
+			telemetry.Inc(sqltelemetry.ForeignKeyCascadesUseCounter)
+			var builder memo.CascadeBuilder
+			switch a {
+			case tree.Cascade:
+				builder = newOnDeleteCascadeBuilder(mb.tab, i, h.otherTab)
+			case tree.SetNull, tree.SetDefault:
+				builder = newOnDeleteSetBuilder(mb.tab, i, h.otherTab, a)
+			default:
+				panic(errors.AssertionFailedf("unhandled action type %s", a))
+			}
+			mb.cascades = append(mb.cascades, memo.FKCascade{
+				FKConstraint: h.fk,
+				Builder:      builder,
+				WithID:       mb.withID,
+				OldValues:    cols,
+				NewValues:    nil,
+			})
+			continue
+		}
+
+		outCols := make(opt.ColList, len(cols))
+		for j, tabOrd := range h.tabOrdinals {
+			tableCol := mb.tab.Column(tabOrd)
+			outCols[j] = mb.md.AddColumn(string(tableCol.ColName()), tableCol.DatumType())
+		}
+		deletedRows := f.ConstructWithScan(&memo.WithScanPrivate{
+			With:    mb.withID,
+			InCols:  cols,
+			OutCols: outCols,
+			ID:      mb.md.NextUniqueID(),
+		})
+		mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(
+			deletedRows, outCols, h.fk.DeleteReferenceAction(),
+		))
+	}
+	telemetry.Inc(sqltelemetry.ForeignKeyChecksUseCounter)
+}
+
+// buildMergeCheckInputScan sets the expression of the given scope, which is
+// built by buildCheckInputScan, to a WithScan of the given input columns of a
+// MERGE statement. The rows deleted by the statement are filtered out, since
+// they have neither new values nor fetched values that are replaced by new
+// ones.
+func (mb *mutationBuilder) buildMergeCheckInputScan(outScope *scope, inputCols opt.ColList) *scope {
+	f := mb.b.factory
+	actionCol := mb.md.AddColumn("merge_action", types.Int)
+	inCols := make(opt.ColList, 0, len(inputCols)+1)
+	inCols = append(inCols, inputCols...)
+	outCols := make(opt.ColList, 0, len(inputCols)+1)
+	outCols = append(outCols, outScope.colList()...)
+	withScan := f.ConstructWithScan(&memo.WithScanPrivate{
+		With:    mb.withID,
+		InCols:  append(inCols, mb.mergeActionColID),
+		OutCols: append(outCols, actionCol),
+		ID:      mb.md.NextUniqueID(),
+	})
+	notDeleted := f.ConstructNe(
+		f.ConstructVariable(actionCol),
+		f.ConstructConstVal(tree.NewDInt(tree.DInt(tree.MergeDelete)), types.Int),
+	)
+	outScope.expr = mb.b.constructProject(
+		f.ConstructSelect(withScan, memo.FiltersExpr{f.ConstructFiltersItem(notDeleted)}),
+		outScope.cols,
+	)
+	return outScope
+}
@@ -146,6 +146,12 @@ type mutationBuilder struct {
 	// an insert; otherwise it's an update.
 	canaryColID opt.ColumnID
 
+	// mergeActionColID is the ID of the column that contains the
+	// tree.MergeActionType of each row of a MERGE statement, which decides
+	// whether a matched row is updated or deleted. It is 0 for all other
+	// statements.
+	mergeActionColID opt.ColumnID
+
 	// arbiters is the set of indexes and unique constraints that are used to
 	// detect conflicts for UPSERT and INSERT ON CONFLICT statements.
 	arbiters arbiterSet
@@ -314,6 +320,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		inScope,
 		false, /* disableNotVisibleIndex */
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandUpdate)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -425,6 +432,7 @@ func (mb *mutationBuilder) buildInputForDelete(
 		inScope,
 		false, /* disableNotVisibleIndex */
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandDelete)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -1067,6 +1075,7 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 		FetchCols:           checkEmptyList(mb.fetchColIDs),
 		UpdateCols:          checkEmptyList(mb.updateColIDs),
 		CanaryCol:           mb.canaryColID,
+		MergeActionCol:      mb.mergeActionColID,
 		ArbiterIndexes:      mb.arbiters.IndexOrdinals(),
 		ArbiterConstraints:  mb.arbiters.UniqueConstraintOrdinals(),
 		CheckCols:           checkEmptyList(mb.checkColIDs),
@@ -1214,6 +1223,14 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// Like in Postgres, a column of a domain type without a default expression
+	// uses the default expression of the domain, if any.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() {
+		if md := typ.TypeMeta.DomainData; md != nil && md.DefaultExpr != nil {
+			exprStr = *md.DefaultExpr
+		}
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
@@ -1564,6 +1581,9 @@ func (mb *mutationBuilder) buildCheckInputScan(
 	}
 
 	mb.ensureWithID()
+	if mb.mergeActionColID != 0 {
+		return mb.buildMergeCheckInputScan(outScope, inputCols), notNullOutCols
+	}
 	outScope.expr = mb.b.factory.ConstructWithScan(&memo.WithScanPrivate{
 		With:    mb.withID,
 		InCols:  inputCols,
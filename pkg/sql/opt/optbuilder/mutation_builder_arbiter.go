@@ -82,6 +82,12 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
+				if constraint.IsExclusion() {
+					panic(exclusionArbiterError())
+				}
+				if constraint.Deferrable() {
+					panic(deferrableArbiterError())
+				}
 				return makeSingleUniqueConstraintArbiterSet(mb, i)
 			}
 		}
@@ -126,6 +132,20 @@ func partialIndexArbiterError(onConflict *tree.OnConflict, tableName tree.Name)
 	)
 }
 
+func deferrableArbiterError() error {
+	return pgerror.New(
+		pgcode.ObjectNotInPrerequisiteState,
+		"ON CONFLICT does not support deferrable unique constraints as arbiters",
+	)
+}
+
+func exclusionArbiterError() error {
+	return pgerror.New(
+		pgcode.FeatureNotSupported,
+		"ON CONFLICT does not support exclusion constraints as arbiters",
+	)
+}
+
 // inferArbitersFromConflictOrds is a helper function for findArbiters that
 // infers a set of conflict arbiters from a list of column ordinals that a
 // user specified in an ON CONFLICT clause. See the comment above findArbiters
@@ -166,7 +186,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			// Deferrable and exclusion constraints cannot be arbiters. Conflicts
+			// with them are detected by the regular uniqueness checks instead.
+			if u := mb.tab.Unique(uc); u.WithoutIndex() && !u.Deferrable() && !u.IsExclusion() {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -224,6 +246,11 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			// Unique constraints with an index were handled above.
 			continue
 		}
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints do not guarantee uniqueness of their columns,
+			// so they can never be arbiters.
+			continue
+		}
 
 		// Determine whether the conflict columns match the columns in the
 		// unique constraint. If not, the constraint cannot be an arbiter. We
@@ -236,6 +263,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 		if !ucOrds.Equals(conflictOrds) {
 			continue
 		}
+		if uniqueConstraint.Deferrable() {
+			panic(deferrableArbiterError())
+		}
 
 		// If the unique constraint is not partial, it should be returned
 		// without any partial index arbiters.
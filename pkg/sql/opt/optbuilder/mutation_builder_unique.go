@@ -14,13 +14,18 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
 )
 
 // UniquenessChecksForGenRandomUUIDClusterMode controls the cluster setting for
@@ -59,6 +64,12 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 			continue
 		}
 		if h.init(mb, i) {
+			// Exclusion constraints may use operators other than equality, so
+			// they cannot be checked with a constrained lookup in the fast path.
+			if h.unique.IsExclusion() {
+				buildFastPathCheck = false
+				mb.fastPathUniqueChecks = nil
+			}
 			uniqueChecksItem, fastPathUniqueChecksItem := h.buildInsertionCheck(buildFastPathCheck)
 			if fastPathUniqueChecksItem == nil {
 				// If we can't build one fast path check, don't build any of them into
@@ -210,8 +221,14 @@ type uniqueCheckHelper struct {
 	// UniqueConstraint.
 	uniqueOrdinals intsets.Fast
 
+	// equalityOrdinals are the table ordinals of the columns that must be equal
+	// for two rows to conflict. For regular unique constraints this is the same
+	// as uniqueOrdinals. For exclusion constraints it only includes the columns
+	// compared with the = operator.
+	equalityOrdinals intsets.Fast
+
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not included in equalityOrdinals.
 	primaryKeyOrdinals intsets.Fast
 
 	// The scope and column ordinals of the scan that will serve as the right
@@ -233,9 +250,13 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds intsets.Fast
+	var uniqueOrds, equalityOrds intsets.Fast
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if !h.unique.IsExclusion() || h.unique.ExclusionOperator(i).Symbol == treecmp.EQ {
+			equalityOrds.Add(ord)
+		}
 	}
 
 	// Find the primary key columns that are not part of the unique constraint.
@@ -246,14 +267,28 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(equalityOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
 		return false
 	}
 
+	// Exclusion constraints are checked with overlap predicates that cannot be
+	// protected by the predicate locks used for uniqueness checks under weaker
+	// isolation levels, so they can only be enforced under SERIALIZABLE.
+	if h.unique.IsExclusion() && mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"exclusion constraint %q cannot be enforced under %s isolation",
+				h.unique.Name(), tree.IsolationLevelFromKVTxnIsolationLevel(mb.b.evalCtx.TxnIsoLevel),
+			),
+			"use a SERIALIZABLE transaction to modify this table",
+		))
+	}
+
 	h.uniqueOrdinals = uniqueOrds
+	h.equalityOrdinals = equalityOrds
 	h.primaryKeyOrdinals = primaryOrds
 
 	for tabOrd, ok := h.uniqueOrdinals.Next(0); ok; tabOrd, ok = h.uniqueOrdinals.Next(tabOrd + 1) {
@@ -268,6 +303,9 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		// If one of the columns is a UUID (or UUID casted to STRING or BYTES) set
 		// to gen_random_uuid() and we don't require uniqueness checks for
 		// gen_random_uuid(), unique check not needed.
+		if !h.equalityOrdinals.Contains(tabOrd) {
+			continue
+		}
 		switch mb.md.ColumnMeta(colID).Type.Family() {
 		case types.UuidFamily, types.StringFamily, types.BytesFamily:
 			if columnIsGenRandomUUID(mb.outScope.expr, colID) {
@@ -306,8 +344,14 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	//
+	// Only the columns compared with equality are considered, since a conflict
+	// with an exclusion constraint requires them to be equal.
+	if h.equalityOrdinals.Empty() {
+		return true
+	}
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	h.equalityOrdinals.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -456,13 +500,29 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		numFilters += 2
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(uniqueCheckScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+	if h.unique.IsExclusion() {
+		// Exclusion constraints compare each column with the operator given in
+		// the constraint:
+		//   (new_a op_a existing_a) AND (new_b op_b existing_b) AND ...
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			ord := h.unique.ColumnOrdinal(h.mb.tab, i)
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				h.buildExclusionComparison(
+					h.unique.ExclusionOperator(i),
+					uniqueCheckScope.cols[ord],
+					h.scanScope.cols[ord],
+				),
+			))
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(uniqueCheckScope.cols[i].id),
+					f.ConstructVariable(h.scanScope.cols[i].id),
+				),
+			))
+		}
 	}
 	// Find the ScanExpr which reads from the table this unique check applies to.
 	var uniqueFastPathCheck memo.RelExpr
@@ -537,8 +597,16 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Collect the key columns that will be shown in the error message if there
 	// is a duplicate key violation resulting from this uniqueness check.
 	keyCols := make(opt.ColList, 0, h.uniqueOrdinals.Len())
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		keyCols = append(keyCols, uniqueCheckScope.cols[i].id)
+	if h.unique.IsExclusion() {
+		// The columns of an exclusion constraint are not sorted, so the key
+		// columns must be collected in constraint order.
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			keyCols = append(keyCols, uniqueCheckScope.cols[h.unique.ColumnOrdinal(h.mb.tab, i)].id)
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			keyCols = append(keyCols, uniqueCheckScope.cols[i].id)
+		}
 	}
 
 	// Create a Project that passes-through only the key columns. This allows
@@ -551,6 +619,7 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		Table:        h.mb.tabID,
 		CheckOrdinal: h.uniqueOrdinal,
 		KeyCols:      keyCols,
+		Deferrable:   h.unique.Deferrable(),
 	})
 	if !buildFastPathCheck {
 		return uniqueChecks, nil
@@ -585,6 +654,26 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	return uniqueChecks, &fastPathChecks
 }
 
+// buildExclusionComparison builds a comparison between a column of a new row
+// and the same column of an existing row using the given exclusion constraint
+// operator.
+func (h *uniqueCheckHelper) buildExclusionComparison(
+	op treecmp.ComparisonOperator, newCol, existingCol scopeColumn,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	cmpOp, ok := schemaexpr.LookupExclusionOperator(op, newCol.typ)
+	if !ok {
+		panic(errors.AssertionFailedf(
+			"no overload for exclusion operator %s on type %s", op, newCol.typ.SQLString(),
+		))
+	}
+	return h.mb.b.constructComparison(
+		&tree.ComparisonExpr{Operator: op, Op: cmpOp},
+		f.ConstructVariable(newCol.id),
+		f.ConstructVariable(existingCol.id),
+	)
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
@@ -16,19 +16,27 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree/utils"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // plpgsqlBuilder translates a PLpgSQL AST into a series of SQL routines that
@@ -147,9 +155,30 @@ type plpgsqlBuilder struct {
 	// expressions.
 	colRefs *opt.ColSet
 
-	// returnType is the return type of the PL/pgSQL routine.
+	// returnType is the return type of the PL/pgSQL routine. For a
+	// set-returning routine, it is the type of each returned row.
 	returnType *types.T
 
+	// setReturning is true if the routine returns a set of rows, in which case
+	// RETURN NEXT and RETURN QUERY statements append rows to the result. The
+	// rows are accumulated into an array that is stored in the hidden variable
+	// resultVar, and unnested when the root block finishes executing.
+	setReturning bool
+	resultVar    ast.Variable
+
+	// rowCountVar is a hidden variable that tracks the number of rows processed
+	// by the most recent SQL statement. It is only declared if the routine uses
+	// GET DIAGNOSTICS ... ROW_COUNT, since updating it requires counting the
+	// rows of every SQL statement.
+	rowCountVar ast.Variable
+
+	// queryLoopStmts contains the statements that were synthesized to iterate
+	// over the rows of a query FOR loop or RETURN QUERY statement (see
+	// buildCursorForLoop and buildReturnQuery). For a statement that builds the
+	// query of a RETURN QUERY statement, the value is the number of columns the
+	// query must return; otherwise, it is zero.
+	queryLoopStmts map[ast.Statement]int
+
 	// continuations is a stack of sub-routines that are called to resume
 	// execution from a certain point within the PL/pgSQL routine. For example,
 	// branches of an IF-statement will call a continuation to resume execution
@@ -167,6 +196,10 @@ type plpgsqlBuilder struct {
 	// outParams is the set of OUT parameters for the routine.
 	outParams []ast.Variable
 
+	// inParamTypes are the types of the input parameters for the routine. They
+	// are used to describe the routine for GET DIAGNOSTICS ... PG_CONTEXT.
+	inParamTypes []*types.T
+
 	// outScope is the output scope for the routine. It is only used for
 	// transaction control statements in procedures, which need the presentation
 	// to construct a new procedure that will resume execution. Note that due to
@@ -174,6 +207,12 @@ type plpgsqlBuilder struct {
 	// building their body statements.
 	outScope *scope
 
+	// routineID and routineVersion identify the descriptor of the routine. They
+	// are used to attribute execution statistics to the statements of the
+	// routine, and are unset when the routine is being created.
+	routineID      oid.Oid
+	routineVersion uint64
+
 	routineName  string
 	isProcedure  bool
 	identCounter int
@@ -193,18 +232,19 @@ func newPLpgSQLBuilder(
 	colRefs *opt.ColSet,
 	routineParams []routineParam,
 	returnType *types.T,
-	isProcedure bool,
+	isProcedure, setReturning bool,
 	outScope *scope,
 ) *plpgsqlBuilder {
 	const initialBlocksCap = 2
 	b := &plpgsqlBuilder{
-		ob:          ob,
-		colRefs:     colRefs,
-		returnType:  returnType,
-		blocks:      make([]plBlock, 0, initialBlocksCap),
-		routineName: routineName,
-		isProcedure: isProcedure,
-		outScope:    outScope,
+		ob:           ob,
+		colRefs:      colRefs,
+		returnType:   returnType,
+		setReturning: setReturning,
+		blocks:       make([]plBlock, 0, initialBlocksCap),
+		routineName:  routineName,
+		isProcedure:  isProcedure,
+		outScope:     outScope,
 	}
 	// Build the initial block for the routine parameters, which are considered
 	// PL/pgSQL variables.
@@ -222,6 +262,9 @@ func newPLpgSQLBuilder(
 		if tree.IsOutParamClass(param.class) {
 			b.outParams = append(b.outParams, param.name)
 		}
+		if tree.IsInParamClass(param.class) {
+			b.inParamTypes = append(b.inParamTypes, param.typ)
+		}
 	}
 	return b
 }
@@ -245,6 +288,11 @@ type plBlock struct {
 	// constants tracks the variables that were declared as constant.
 	constants map[ast.Variable]struct{}
 
+	// notNull tracks the variables that were declared as NOT NULL. Every
+	// assignment to one of these variables is followed by a check that raises
+	// an error if the new value is NULL.
+	notNull map[ast.Variable]struct{}
+
 	// cursors is the set of cursor declarations for a PL/pgSQL block. It is set
 	// for bound cursor declarations, which allow a query to be associated with a
 	// cursor before it is opened.
@@ -282,6 +330,15 @@ func (b *plpgsqlBuilder) buildRootBlock(
 		}
 		s = b.addPLpgSQLAssign(s, param.name, &tree.CastExpr{Expr: tree.DNull, Type: param.typ})
 	}
+	var rc rowCountVisitor
+	ast.Walk(&rc, astBlock)
+	if rc.foundRowCount {
+		// Declare the hidden variable that tracks the row count for GET
+		// DIAGNOSTICS, and initialize it to zero.
+		b.rowCountVar = ast.Variable(b.makeIdentifier("_row_count"))
+		b.addVariable(b.rowCountVar, types.Int)
+		s = b.addPLpgSQLAssign(s, b.rowCountVar, tree.DZero)
+	}
 	if b.isProcedure {
 		var tc transactionControlVisitor
 		ast.Walk(&tc, astBlock)
@@ -294,7 +351,13 @@ func (b *plpgsqlBuilder) buildRootBlock(
 			return s
 		}
 	}
-	return b.buildBlock(astBlock, s)
+	s = b.buildBlock(astBlock, s)
+	if b.setReturning {
+		// The root block of a set-returning routine produces an array containing
+		// the result rows. Unnest it to produce the rows themselves.
+		s = b.unnestResult(s)
+	}
+	return s
 }
 
 // buildBlock constructs an expression that returns the result of executing a
@@ -307,11 +370,13 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 		panic(errors.AssertionFailedf("expected at least one PLpgSQL block"))
 	}
 	b.ensureScopeHasExpr(s)
+	astBlock = b.resolveAliases(astBlock)
 	block := b.pushBlock(plBlock{
 		label:     astBlock.Label,
 		vars:      make([]ast.Variable, 0, len(astBlock.Decls)),
 		varTypes:  make(map[ast.Variable]*types.T),
 		constants: make(map[ast.Variable]struct{}),
+		notNull:   make(map[ast.Variable]struct{}),
 		cursors:   make(map[ast.Variable]ast.CursorDeclaration),
 	})
 	defer b.popBlock()
@@ -325,35 +390,54 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 		}
 	}
 	// First, handle the variable declarations.
+	var recordVars []*ast.Declaration
+	var notNullChecks []ast.Statement
+	declareVar := func(dec *ast.Declaration, typ *types.T) {
+		b.addVariable(dec.Var, typ)
+		if dec.Expr != nil {
+			// Some variable declarations initialize the variable.
+			s = b.addPLpgSQLAssign(s, dec.Var, dec.Expr)
+		} else {
+			// Uninitialized variables are null.
+			s = b.addPLpgSQLAssign(s, dec.Var, &tree.CastExpr{Expr: tree.DNull, Type: typ})
+		}
+		if dec.Constant {
+			// Add to the constants map after initializing the variable, since
+			// constant variables only prevent assignment, not initialization.
+			block.constants[dec.Var] = struct{}{}
+		}
+		if dec.NotNull {
+			// The initial value of a NOT NULL variable is checked along with the
+			// other declarations, before the block body is executed.
+			block.notNull[dec.Var] = struct{}{}
+			notNullChecks = append(notNullChecks, b.makeNotNullCheck(dec.Var))
+		}
+	}
 	for i := range astBlock.Decls {
 		switch dec := astBlock.Decls[i].(type) {
 		case *ast.Declaration:
-			if dec.NotNull {
-				panic(notNullVarErr)
-			}
 			if dec.Collate != "" {
 				panic(collatedVarErr)
 			}
-			typ, err := tree.ResolveType(b.ob.ctx, dec.Typ, b.ob.semaCtx.TypeResolver)
-			if err != nil {
-				panic(err)
+			typ := b.resolveDeclType(dec.Typ)
+			if dec.NotNull {
+				if typ.Family() == types.TupleFamily {
+					panic(notNullRecordVarErr)
+				}
+				if dec.Expr == nil {
+					panic(pgerror.Newf(pgcode.NullValueNotAllowed,
+						"variable \"%s\" must have a default value, since it's declared NOT NULL", dec.Var,
+					))
+				}
 			}
 			if types.IsRecordType(typ) {
-				panic(recordVarErr)
-			}
-			b.addVariable(dec.Var, typ)
-			if dec.Expr != nil {
-				// Some variable declarations initialize the variable.
-				s = b.addPLpgSQLAssign(s, dec.Var, dec.Expr)
-			} else {
-				// Uninitialized variables are null.
-				s = b.addPLpgSQLAssign(s, dec.Var, &tree.CastExpr{Expr: tree.DNull, Type: typ})
-			}
-			if dec.Constant {
-				// Add to the constants map after initializing the variable, since
-				// constant variables only prevent assignment, not initialization.
-				block.constants[dec.Var] = struct{}{}
+				// The structure of a RECORD variable is determined by the first
+				// assignment to it, which can reference other variables from the
+				// block. Declare the RECORD variables after all other variables.
+				recordVars = append(recordVars, dec)
+				continue
 			}
+			declareVar(dec, typ)
 		case *ast.CursorDeclaration:
 			// Declaration of a bound cursor declares a variable of type refcursor.
 			b.addVariable(dec.Name, types.RefCursor)
@@ -361,6 +445,9 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 			block.cursors[dec.Name] = *dec
 		}
 	}
+	for _, dec := range recordVars {
+		declareVar(dec, b.resolveRecordVarType(astBlock, dec, s))
+	}
 	if types.IsRecordType(b.returnType) && types.IsWildcardTupleType(b.returnType) {
 		// For a RECORD-returning routine, infer the concrete type by examining the
 		// RETURN statements. This has to happen after building the declaration
@@ -371,6 +458,17 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 		ast.Walk(recordVisitor, astBlock)
 		b.returnType = recordVisitor.typ
 	}
+	if b.setReturning && b.resultVar == "" {
+		// This is the outermost block of a set-returning routine. Declare the
+		// hidden variable that accumulates the result rows, and initialize it to
+		// an empty array. This has to happen after the return type is inferred.
+		if err := types.CheckArrayElementType(b.returnType); err != nil {
+			panic(err)
+		}
+		b.resultVar = ast.Variable(b.makeIdentifier("_result_rows"))
+		b.addVariable(b.resultVar, b.resultType())
+		s = b.addPLpgSQLAssign(s, b.resultVar, tree.NewDArray(b.returnType))
+	}
 	// Build the exception handler. This has to happen after building the variable
 	// declarations, since the exception handler can reference the block's vars.
 	if exceptions := b.buildExceptions(astBlock); exceptions != nil {
@@ -386,10 +484,137 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 		blockCon.def.ExceptionBlock = exceptions
 		blockCon.def.Volatility = volatility.Volatile
 		b.appendPlpgSQLStmts(&blockCon, astBlock.Body)
+		if len(notNullChecks) > 0 {
+			// The NOT NULL checks for the declarations must not be caught by the
+			// exception handler, so they are executed before calling into the
+			// exception block.
+			b.pushContinuation(blockCon)
+			defer b.popContinuation()
+			return b.buildPLpgSQLStatements(notNullChecks, s)
+		}
 		return b.callContinuation(&blockCon, s)
 	}
 	// Finally, build the body statements for the block.
-	return b.buildPLpgSQLStatements(astBlock.Body, s)
+	return b.buildPLpgSQLStatements(append(notNullChecks, astBlock.Body...), s)
+}
+
+// resolveDeclType resolves the type of a PL/pgSQL variable declaration.
+func (b *plpgsqlBuilder) resolveDeclType(ref tree.ResolvableTypeReference) *types.T {
+	if percentType, ok := ref.(*ast.PercentTypeReference); ok {
+		return b.resolvePercentType(percentType)
+	}
+	typ, err := tree.ResolveType(b.ob.ctx, ref, b.ob.semaCtx.TypeResolver)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// resolvePercentType resolves the type for a name%TYPE declaration. A name
+// with a single part refers to a variable, and a qualified name refers to a
+// table column.
+func (b *plpgsqlBuilder) resolvePercentType(ref *ast.PercentTypeReference) *types.T {
+	name := ref.Name
+	if name.NumParts == 1 {
+		if typ, ok := b.lookupVariableType(ast.Variable(name.Parts[0])); ok {
+			return typ
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject, "variable \"%s\" does not exist", name.Parts[0]))
+	}
+	// The last part of the name is the column, and the remaining parts are the
+	// (possibly qualified) table name.
+	un, err := tree.NewUnresolvedObjectName(
+		name.NumParts-1, [3]string{name.Parts[1], name.Parts[2], name.Parts[3]}, tree.NoAnnotation,
+	)
+	if err != nil {
+		panic(err)
+	}
+	tn := un.ToTableName()
+	ds, _, _ := b.ob.resolveDataSource(&tn, privilege.SELECT)
+	tab, ok := ds.(cat.Table)
+	if !ok {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "\"%s\" is not a table", tn.ObjectName))
+	}
+	colName := tree.Name(name.Parts[0])
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.ColName() != colName || col.Visibility() == cat.Inaccessible {
+			continue
+		}
+		if b.ob.trackSchemaDeps {
+			dep := opt.SchemaDep{DataSource: tab}
+			dep.ColumnOrdinals.Add(i)
+			b.ob.schemaDeps = append(b.ob.schemaDeps, dep)
+		}
+		return col.DatumType()
+	}
+	panic(pgerror.Newf(pgcode.UndefinedColumn,
+		"column \"%s\" of relation \"%s\" does not exist", colName, tn.ObjectName,
+	))
+}
+
+// resolveRecordVarType infers the concrete type of a RECORD variable from its
+// initial value, or from the first assignment to the variable in the block
+// that declares it (see recordVarVisitor).
+func (b *plpgsqlBuilder) resolveRecordVarType(
+	astBlock *ast.Block, dec *ast.Declaration, s *scope,
+) *types.T {
+	v := recordVarVisitor{b: b, s: s, name: dec.Var, block: astBlock}
+	if dec.Expr != nil {
+		v.visitAssignedExpr(dec.Expr)
+	}
+	if v.typ == nil {
+		ast.Walk(&v, astBlock)
+	}
+	if v.typ == nil {
+		panic(errors.WithHint(
+			unimplemented.NewWithIssueDetailf(114874, "RECORD variable",
+				"could not determine the structure of RECORD variable \"%s\"", dec.Var,
+			),
+			"assign a row to the variable in the block that declares it, "+
+				"or declare the variable with a composite type",
+		))
+	}
+	return v.typ
+}
+
+// resolveAliases replaces references to the names declared with ALIAS FOR in
+// the given block with references to the aliased variables. The aliased
+// variable must already be in scope, or be declared earlier in the block.
+func (b *plpgsqlBuilder) resolveAliases(astBlock *ast.Block) *ast.Block {
+	var aliases map[ast.Variable]ast.Variable
+	declared := make(map[ast.Variable]struct{})
+	for _, decl := range astBlock.Decls {
+		var name ast.Variable
+		switch t := decl.(type) {
+		case *ast.Declaration:
+			name = t.Var
+		case *ast.CursorDeclaration:
+			name = t.Name
+		case *ast.AliasDeclaration:
+			name = t.Var
+			target := t.Target
+			if aliased, ok := aliases[target]; ok {
+				// This is an alias for another alias.
+				target = aliased
+			}
+			if _, ok := declared[target]; !ok && !b.isVariable(target) {
+				panic(pgerror.Newf(pgcode.UndefinedObject, "variable \"%s\" does not exist", t.Target))
+			}
+			if aliases == nil {
+				aliases = make(map[ast.Variable]ast.Variable)
+			}
+			aliases[t.Var] = target
+		}
+		if _, ok := declared[name]; ok {
+			panic(pgerror.Newf(pgcode.Syntax, "duplicate declaration at or near \"%s\"", name))
+		}
+		declared[name] = struct{}{}
+	}
+	if aliases == nil {
+		return astBlock
+	}
+	return ast.Walk(newAliasVisitor(astBlock, aliases), astBlock).(*ast.Block)
 }
 
 // buildPLpgSQLStatements performs the majority of the work building a PL/pgSQL
@@ -414,11 +639,18 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			return b.buildBlock(t, s)
 
 		case *ast.Return:
-			// If the routine has OUT-parameters or a VOID return type, the RETURN
-			// statement must have no expression. Otherwise, the RETURN statement must
-			// have a non-empty expression.
+			// If the routine is set-returning, has OUT-parameters or has a VOID
+			// return type, the RETURN statement must have no expression. Otherwise,
+			// the RETURN statement must have a non-empty expression.
 			expr := t.Expr
-			if b.hasOutParam() {
+			if b.setReturning {
+				// RETURN in a set-returning routine ends execution; the result rows
+				// have already been accumulated by RETURN NEXT and RETURN QUERY.
+				if expr != nil {
+					panic(returnWithSetofErr)
+				}
+				expr = tree.NewUnresolvedName(string(b.resultVar))
+			} else if b.hasOutParam() {
 				if expr != nil {
 					panic(returnWithOUTParameterErr)
 				}
@@ -438,18 +670,24 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			}
 			// RETURN is handled by projecting a single column with the expression
 			// that is being returned.
-			returnScalar := b.buildPLpgSQLExpr(expr, b.returnType, s)
+			returnScalar := b.buildPLpgSQLExpr(expr, b.resultType(), s)
 			b.addBarrierIfVolatile(s, returnScalar)
 			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
 			returnScope := s.push()
-			b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, returnScalar)
+			b.ob.synthesizeColumn(returnScope, returnColName, b.resultType(), nil /* expr */, returnScalar)
 			b.ob.constructProjectForScope(s, returnScope)
 			return returnScope
 
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
-			// name as the variable being assigned.
-			s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+			// name as the variable being assigned. Assigning to a single field of a
+			// composite variable replaces the whole variable with a tuple in which
+			// only that field has changed.
+			value := t.Value
+			if t.Field != "" {
+				value = b.makeFieldAssignValue(t.Var, t.Field, t.Value)
+			}
+			s = b.addPLpgSQLAssign(s, t.Var, value)
 			if b.hasExceptionHandler() {
 				// If exception handling is required, we have to start a new
 				// continuation after each variable assignment. This ensures that in the
@@ -460,9 +698,13 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 				// handleException comment for details on why this is necessary.
 				catchCon := b.makeContinuation("assign_exception_block")
 				catchCon.def.Volatility = volatility.Volatile
-				b.appendPlpgSQLStmts(&catchCon, stmts[i+1:])
+				b.appendPlpgSQLStmts(&catchCon, b.addNotNullChecks(stmts[i+1:], t.Var))
 				return b.callContinuation(&catchCon, s)
 			}
+			if b.isNotNullVar(t.Var) {
+				// Check the new value of a NOT NULL variable before continuing.
+				return b.buildPLpgSQLStatements(b.addNotNullChecks(stmts[i+1:], t.Var), s)
+			}
 
 		case *ast.If:
 			// IF statement control flow is handled by calling a "continuation"
@@ -520,9 +762,9 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// Return a single column that projects the result of the CASE statement.
 			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_if"))
 			returnScope := s.push()
-			scalar = b.coerceType(scalar, b.returnType)
+			scalar = b.coerceType(scalar, b.resultType())
 			b.addBarrierIfVolatile(s, scalar)
-			b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, scalar)
+			b.ob.synthesizeColumn(returnScope, returnColName, b.resultType(), nil /* expr */, scalar)
 			b.ob.constructProjectForScope(s, returnScope)
 			return returnScope
 
@@ -574,6 +816,81 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			}
 			return b.buildPLpgSQLStatements(b.prependStmt(loop, stmts[i+1:]), s)
 
+		case *ast.ForLoop:
+			switch c := t.Control.(type) {
+			case *ast.IntForLoopControl:
+				// An integer FOR loop is rewritten into a block that declares the loop
+				// bounds, followed by a LOOP with a conditional EXIT. See
+				// buildIntForLoop for details.
+				block := b.buildIntForLoop(t, c)
+				return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+			case *ast.QueryForLoopControl:
+				// A query FOR loop opens a cursor for the query, and fetches one row
+				// at a time until the cursor is exhausted. See buildQueryForLoop for
+				// details.
+				return b.buildQueryForLoop(t, c, stmts[i+1:], s)
+			default:
+				panic(errors.AssertionFailedf("unexpected FOR loop control: %T", c))
+			}
+
+		case *ast.ForEachArray:
+			// FOREACH is rewritten into a block that declares the array and an
+			// index, followed by a LOOP that assigns each array element to the
+			// target. See buildForEachArray for details.
+			block := b.buildForEachArray(t, s)
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ReturnNext:
+			// RETURN NEXT appends a row to the result of a set-returning routine,
+			// and then continues execution. The row is projected as a hidden
+			// column, which is then appended to the array of result rows:
+			//
+			//   RETURN NEXT [expr];
+			//   =>
+			//   _result_rows := array_append(_result_rows, [expr]);
+			//
+			if !b.setReturning {
+				panic(returnNextNonSetofErr)
+			}
+			expr := t.Expr
+			if b.hasOutParam() {
+				if expr != nil {
+					panic(returnNextWithOUTParameterErr)
+				}
+				expr = b.makeReturnForOutParams()
+			}
+			if expr == nil {
+				panic(emptyReturnNextErr)
+			}
+			nextName := b.makeIdentifier("_stmt_return_next")
+			nextScope := b.projectHiddenColumn(s, nextName, expr, b.returnType)
+			assign := b.makeResultAppend(tree.NewUnresolvedName(nextName))
+			return b.buildPLpgSQLStatements(b.prependStmt(assign, stmts[i+1:]), nextScope)
+
+		case *ast.ReturnQuery:
+			// RETURN QUERY is rewritten into a query FOR loop that appends each
+			// row of the query to the result, and counts the rows if the routine
+			// uses ROW_COUNT:
+			//
+			//   RETURN QUERY [query];
+			//   =>
+			//   DECLARE
+			//     _col_1 [type];
+			//     ...
+			//   BEGIN
+			//     _row_count := 0;
+			//     FOR _col_1, ... IN [query] LOOP
+			//       _result_rows := array_append(_result_rows, (_col_1, ...));
+			//       _row_count := _row_count + 1;
+			//     END LOOP;
+			//   END;
+			//
+			if !b.setReturning {
+				panic(returnQueryNonSetofErr)
+			}
+			block := b.buildReturnQuery(t)
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
 		case *ast.Exit:
 			if t.Condition != nil {
 				// EXIT with a condition is syntactic sugar for EXIT inside an IF stmt.
@@ -654,7 +971,9 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// crdb_internal.plpgsql_raise builtin function.
 			con := b.makeContinuation("_stmt_raise")
 			con.def.Volatility = volatility.Volatile
-			b.appendBodyStmt(&con, b.buildPLpgSQLRaise(con.s, b.getRaiseArgs(con.s, t)))
+			b.appendProfiledBodyStmt(
+				&con, b.buildPLpgSQLRaise(con.s, b.getRaiseArgs(con.s, t)), b.makeStmtInfo(t, "RAISE"),
+			)
 			b.appendPlpgSQLStmts(&con, stmts[i+1:])
 			return b.callContinuation(&con, s)
 
@@ -666,26 +985,21 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 				// incorrect location.
 				panic(setTxnNotAfterControlStmtErr)
 			}
-			if len(t.Target) > 1 {
-				seenTargets := make(map[ast.Variable]struct{})
-				for _, name := range t.Target {
-					if _, ok := seenTargets[name]; ok {
-						panic(dupIntoErr)
-					}
-					seenTargets[name] = struct{}{}
-				}
-			}
+			checkDuplicateTargets(t.Target)
 			strict := t.Strict || b.ob.evalCtx.SessionData().PLpgSQLUseStrictInto
 
 			// Create a new continuation routine to handle executing a SQL statement.
 			execCon := b.makeContinuation("_stmt_exec")
-			stmtScope := b.ob.buildStmtAtRootWithScope(t.SqlStmt, nil /* desiredTypes */, execCon.s)
+			sqlStmt := t.SqlStmt
+			if t.Target == nil && b.rowCountVar != "" {
+				sqlStmt = addRowCountReturning(sqlStmt)
+			}
+			stmtScope := b.ob.buildStmtAtRootWithScope(sqlStmt, nil /* desiredTypes */, execCon.s)
+			stmtInfo := b.makeStmtInfo(t, "SQL statement")
 			if t.Target == nil {
 				// When there is not INTO target, build the SQL statement into a body
 				// statement that is only executed for its side effects.
-				b.appendBodyStmt(&execCon, stmtScope)
-				b.appendPlpgSQLStmts(&execCon, stmts[i+1:])
-				return b.callContinuation(&execCon, s)
+				return b.buildSideEffectStmt(&execCon, stmtScope, stmtInfo, stmts[i+1:], s)
 			}
 			// This statement has an INTO target. Unlike the above case, we need the
 			// result of executing the SQL statement, since its result is assigned to
@@ -698,7 +1012,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			//
 			// Step 1: build a continuation for the remaining PLpgSQL statements.
 			retCon := b.makeContinuation("_stmt_exec_ret")
-			b.appendPlpgSQLStmts(&retCon, stmts[i+1:])
+			b.appendPlpgSQLStmts(&retCon, b.addNotNullChecks(stmts[i+1:], t.Target...))
 
 			// Ensure that the SQL statement returns at most one row.
 			limitVal := tree.DInt(1)
@@ -713,10 +1027,19 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 				stmtScope.makeOrderingChoice(),
 			)
 
+			var rowCount opt.ScalarExpr
 			if strict {
 				// Check that the expression produces exactly one row.
 				b.addOneRowCheck(stmtScope)
+				if b.rowCountVar != "" {
+					rowCount = b.ob.factory.ConstructConstVal(tree.NewDInt(1), types.Int)
+				}
 			} else {
+				if b.rowCountVar != "" {
+					// Project a column that will be NULL after the RIGHT join below if
+					// the SQL statement returned no rows.
+					rowCount = b.projectRowFound(stmtScope)
+				}
 				// Ensure that the SQL statement returns at least one row. The RIGHT
 				// join ensures that when the SQL statement returns no rows, it is
 				// extended with a single row of NULL values.
@@ -730,11 +1053,40 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 
 			// Step 2: build the INTO statement into a continuation routine that calls
 			// the previously built continuation.
-			intoScope := b.buildInto(stmtScope, t.Target)
-			intoScope = b.callContinuation(&retCon, intoScope)
+			intoScope := b.buildInto(stmtScope, t.Target, rowCount)
+			intoScope = b.callContinuationAfterStmt(&retCon, intoScope, stmtInfo)
 
 			// Step 3: call the INTO continuation from the parent scope.
-			b.appendBodyStmt(&execCon, intoScope)
+			b.appendProfiledBodyStmt(&execCon, intoScope, stmtInfo)
+			return b.callContinuation(&execCon, s)
+
+		case *ast.DynamicExecute:
+			// EXECUTE statements run a query string that is only known at runtime.
+			// The crdb_internal.plpgsql_execute builtin function plans and executes
+			// the query, and returns the first row of the result as a tuple. Similar
+			// to FETCH, the result is assigned to the INTO target in a separate
+			// continuation, which calls another continuation for the remaining
+			// PLpgSQL statements.
+			checkDuplicateTargets(t.Target)
+			if b.rowCountVar != "" {
+				panic(dynamicRowCountErr)
+			}
+			strict := t.Target != nil && (t.Strict || b.ob.evalCtx.SessionData().PLpgSQLUseStrictInto)
+			execCon := b.makeContinuation("_stmt_exec")
+			execCon.def.Volatility = volatility.Volatile
+			execScope := b.buildDynamicExecute(execCon.s, t, strict)
+			stmtInfo := b.makeStmtInfo(t, "EXECUTE")
+			if t.Target == nil {
+				// The statement is only executed for its side effects.
+				b.appendProfiledBodyStmt(&execCon, execScope, stmtInfo)
+				b.appendPlpgSQLStmts(&execCon, stmts[i+1:])
+				return b.callContinuation(&execCon, s)
+			}
+			retCon := b.makeContinuation("_stmt_exec_ret")
+			b.appendPlpgSQLStmts(&retCon, b.addNotNullChecks(stmts[i+1:], t.Target...))
+			intoScope := b.buildInto(execScope, t.Target, nil /* rowCount */)
+			intoScope = b.callContinuationAfterStmt(&retCon, intoScope, stmtInfo)
+			b.appendProfiledBodyStmt(&execCon, intoScope, stmtInfo)
 			return b.callContinuation(&execCon, s)
 
 		case *ast.Open:
@@ -762,19 +1114,39 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// Initialize the routine with the information needed to pipe the first
 			// body statement into a cursor.
 			query := b.resolveOpenQuery(t)
-			fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
-			fmtCtx.FormatNode(query)
-			openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
-				NameArgIdx: source.(*scopeColumn).getParamOrd(),
-				Scroll:     t.Scroll,
-				CursorSQL:  fmtCtx.CloseAndGetString(),
-			}
-			openScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
-			if openScope.expr.Relational().CanMutate {
-				// Cursors with mutations are invalid.
-				panic(cursorMutationErr)
-			}
-			b.appendBodyStmt(&openCon, openScope)
+			if query == nil {
+				// This is an OPEN ... FOR EXECUTE statement. The first body statement
+				// produces the query string and its arguments, and the query is
+				// planned and executed when the routine is evaluated.
+				openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+					NameArgIdx: source.(*scopeColumn).getParamOrd(),
+					Scroll:     t.Scroll,
+					Dynamic:    true,
+				}
+				openScope := b.buildDynamicQueryArgs(openCon.s, t.DynamicQuery, t.Params)
+				b.appendProfiledBodyStmt(&openCon, openScope, b.makeStmtInfo(t, "OPEN"))
+			} else {
+				fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
+				fmtCtx.FormatNode(query)
+				openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+					NameArgIdx: source.(*scopeColumn).getParamOrd(),
+					Scroll:     t.Scroll,
+					CursorSQL:  fmtCtx.CloseAndGetString(),
+				}
+				openScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
+				if openScope.expr.Relational().CanMutate {
+					// Cursors with mutations are invalid.
+					panic(cursorMutationErr)
+				}
+				if _, ok := b.queryLoopStmts[t]; ok {
+					// The cursor of a query FOR loop returns an extra leading column
+					// that indicates whether a row was fetched. For RETURN QUERY, the
+					// query must also match the result of the routine.
+					b.checkReturnQueryCols(t, openScope)
+					openScope = b.prependFoundColumn(openScope)
+				}
+				b.appendProfiledBodyStmt(&openCon, openScope, b.makeStmtInfo(t, "OPEN"))
+			}
 			b.appendPlpgSQLStmts(&openCon, stmts[i+1:])
 
 			// Build a statement to generate a unique name for the cursor if one
@@ -824,7 +1196,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			closeScope := closeCon.s.push()
 			b.ob.synthesizeColumn(closeScope, closeColName, types.Int, nil /* expr */, closeCall)
 			b.ob.constructProjectForScope(closeCon.s, closeScope)
-			b.appendBodyStmt(&closeCon, closeScope)
+			b.appendProfiledBodyStmt(&closeCon, closeScope, b.makeStmtInfo(t, "CLOSE"))
 			b.appendPlpgSQLStmts(&closeCon, stmts[i+1:])
 			return b.callContinuation(&closeCon, s)
 
@@ -843,11 +1215,16 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 					panic(fetchRowsErr)
 				}
 			}
+			if _, ok := b.queryLoopStmts[t]; !ok && b.rowCountVar != "" {
+				// The FETCH statements of a query FOR loop do not affect the row
+				// count, so they don't need to be rejected.
+				panic(fetchRowCountErr)
+			}
 			fetchCon := b.makeContinuation("_stmt_fetch")
 			fetchCon.def.Volatility = volatility.Volatile
 			fetchScope := b.buildFetch(fetchCon.s, t)
 			if t.IsMove {
-				b.appendBodyStmt(&fetchCon, fetchScope)
+				b.appendProfiledBodyStmt(&fetchCon, fetchScope, b.makeStmtInfo(t, "MOVE"))
 				b.appendPlpgSQLStmts(&fetchCon, stmts[i+1:])
 				return b.callContinuation(&fetchCon, s)
 			}
@@ -858,27 +1235,72 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// corresponding element.
 			fetchCol := fetchScope.cols[0].id
 			intoScope := fetchScope.push()
-			for j := range t.Target {
-				typ := b.resolveVariableForAssign(t.Target[j])
-				colName := scopeColName(t.Target[j])
-				scalar := b.ob.factory.ConstructColumnAccess(
-					b.ob.factory.ConstructVariable(fetchCol),
-					memo.TupleOrdinal(j),
-				)
-				scalar = b.coerceType(scalar, typ)
-				b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
+			if b.targetIsRecordVar(t.Target) {
+				// The elements of the tuple are the fields of the single composite
+				// target variable.
+				typ := b.resolveVariableForAssign(t.Target[0])
+				scalar := b.coerceType(b.ob.factory.ConstructVariable(fetchCol), typ)
+				b.ob.synthesizeColumn(intoScope, scopeColName(t.Target[0]), typ, nil /* expr */, scalar)
+			} else {
+				for j := range t.Target {
+					typ := b.resolveVariableForAssign(t.Target[j])
+					colName := scopeColName(t.Target[j])
+					scalar := b.ob.factory.ConstructColumnAccess(
+						b.ob.factory.ConstructVariable(fetchCol),
+						memo.TupleOrdinal(j),
+					)
+					scalar = b.coerceType(scalar, typ)
+					b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
+				}
 			}
 			b.ob.constructProjectForScope(fetchScope, intoScope)
 
 			// Call a continuation for the remaining PLpgSQL statements from the newly
 			// built statement that has updated variables. Then, call the fetch
 			// continuation from the parent scope.
+			stmtInfo := b.makeStmtInfo(t, "FETCH")
 			retCon := b.makeContinuation("_stmt_exec_ret")
-			b.appendPlpgSQLStmts(&retCon, stmts[i+1:])
-			intoScope = b.callContinuation(&retCon, intoScope)
-			b.appendBodyStmt(&fetchCon, intoScope)
+			b.appendPlpgSQLStmts(&retCon, b.addNotNullChecks(stmts[i+1:], t.Target...))
+			intoScope = b.callContinuationAfterStmt(&retCon, intoScope, stmtInfo)
+			b.appendProfiledBodyStmt(&fetchCon, intoScope, stmtInfo)
 			return b.callContinuation(&fetchCon, s)
 
+		case *ast.Perform:
+			// PERFORM executes a SELECT statement and discards the result. Similar
+			// to a SQL statement without an INTO target, it is built into a body
+			// statement that is only executed for its side effects.
+			performCon := b.makeContinuation("_stmt_perform")
+			stmtScope := b.ob.buildStmtAtRootWithScope(t.SqlStmt, nil /* desiredTypes */, performCon.s)
+			return b.buildSideEffectStmt(
+				&performCon, stmtScope, b.makeStmtInfo(t, "PERFORM"), stmts[i+1:], s,
+			)
+
+		case *ast.GetDiagnostics:
+			// GET DIAGNOSTICS is rewritten into an assignment for each item:
+			//
+			//   GET DIAGNOSTICS [var1] = ROW_COUNT, [var2] = PG_CONTEXT;
+			//   =>
+			//   [var1] := _row_count;
+			//   [var2] := 'PL/pgSQL function [name]([types]) line [n] at GET DIAGNOSTICS';
+			//
+			if t.IsStacked {
+				panic(getStackedDiagErr)
+			}
+			assigns := make([]ast.Statement, 0, len(t.DiagItems)+len(stmts)-i-1)
+			for _, item := range t.DiagItems {
+				var value ast.Expr
+				switch item.Kind {
+				case ast.GetDiagnosticsRowCount:
+					value = tree.NewUnresolvedName(string(b.rowCountVar))
+				case ast.GetDiagnosticsContext:
+					value = tree.NewDString(b.makeContext(t.LineNo, "GET DIAGNOSTICS"))
+				default:
+					panic(errors.AssertionFailedf("unexpected diagnostics item: %s", item.Kind))
+				}
+				assigns = append(assigns, &ast.Assignment{Var: item.Target, Value: value})
+			}
+			return b.buildPLpgSQLStatements(append(assigns, stmts[i+1:]...), s)
+
 		case *ast.Null:
 			// PL/pgSQL NULL statements are a no-op.
 			continue
@@ -889,9 +1311,6 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// During execution, a TxnControlExpr directs the session to commit or
 			// rollback the transaction, and supplies a plan for the continuation to
 			// run in the new transaction.
-			if t.Chain {
-				panic(txnControlWithChainErr)
-			}
 			// NOTE: postgres doesn't make the following checks until runtime (see
 			// also #119750).
 			// TODO(#88198): check the calling context, since transaction control
@@ -930,7 +1349,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			con := b.makeContinuation(name)
 			con.def.Volatility = volatility.Volatile
 			b.appendPlpgSQLStmts(&con, stmts)
-			return b.callContinuationWithTxnOp(&con, s, txnOpType, txnModes)
+			return b.callContinuationWithTxnOp(&con, s, txnOpType, txnModes, t.Chain)
 
 		default:
 			panic(unsupportedPLStmtErr)
@@ -943,7 +1362,8 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 }
 
 // resolveOpenQuery finds and validates the query that is bound to cursor for
-// the given OPEN statement.
+// the given OPEN statement. It returns nil for an OPEN ... FOR EXECUTE
+// statement, since the query is not known until the statement is executed.
 func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 	// Search the blocks in reverse order to ensure that more recent declarations
 	// are encountered first.
@@ -958,13 +1378,16 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 		}
 	}
 	stmt := open.Query
-	if stmt != nil && boundStmt != nil {
+	if (stmt != nil || open.DynamicQuery != nil) && boundStmt != nil {
 		// A bound cursor cannot be opened with "OPEN FOR" syntax.
 		panic(errors.WithHintf(
 			pgerror.New(pgcode.Syntax, "syntax error at or near \"FOR\""),
 			"cannot specify a query during OPEN for bound cursor \"%s\"", open.CurVar,
 		))
 	}
+	if open.DynamicQuery != nil {
+		return nil
+	}
 	if stmt == nil && boundStmt == nil {
 		// The query was not specified either during cursor declaration or in the
 		// open statement.
@@ -1038,20 +1461,15 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, va
 }
 
 // buildInto handles the mapping from the columns of a SQL statement to the
-// variables in an INTO target.
-func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *scope {
-	var targetTypes []*types.T
+// variables in an INTO target. If rowCount is non-nil, it is assigned to the
+// hidden variable that tracks the row count for GET DIAGNOSTICS.
+func (b *plpgsqlBuilder) buildInto(
+	stmtScope *scope, target []ast.Variable, rowCount opt.ScalarExpr,
+) *scope {
+	targetTypes := b.intoTargetTypes(target)
 	var targetNames []ast.Variable
-	if b.targetIsRecordVar(target) {
-		// For a single record-type variable, the SQL statement columns are assigned
-		// as elements of the variable, rather than the variable itself.
-		targetTypes = b.resolveVariableForAssign(target[0]).TupleContents()
-	} else {
+	if !b.targetIsRecordVar(target) {
 		targetNames = target
-		targetTypes = make([]*types.T, len(target))
-		for j := range target {
-			targetTypes[j] = b.resolveVariableForAssign(target[j])
-		}
 	}
 
 	// For each target, project an output column that aliases the
@@ -1076,14 +1494,580 @@ func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *sco
 		scalar = b.coerceType(scalar, typ)
 		b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
 	}
+	if rowCount != nil {
+		b.ob.synthesizeColumn(intoScope, scopeColName(b.rowCountVar), types.Int, nil /* expr */, rowCount)
+	}
 	b.ob.constructProjectForScope(stmtScope, intoScope)
 	if b.targetIsRecordVar(target) {
 		// Handle a single record-type variable (see projectRecordVar for details).
-		intoScope = b.projectRecordVar(intoScope, target[0])
+		intoScope = b.projectRecordVar(intoScope, target[0], len(targetTypes))
 	}
 	return intoScope
 }
 
+// buildIntForLoop rewrites an integer FOR loop into a block that evaluates the
+// loop bounds once, followed by a LOOP with a conditional EXIT:
+//
+//	FOR i IN [REVERSE] [lower]..[upper] BY [step] LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  _counter INT := [lower];
+//	  _upper INT := [upper];
+//	  _step INT := [step];
+//	  i INT;
+//	BEGIN
+//	  IF _counter IS NULL THEN RAISE ...; END IF;
+//	  IF _upper IS NULL THEN RAISE ...; END IF;
+//	  IF _step IS NULL THEN RAISE ...; END IF;
+//	  IF _step <= 0 THEN RAISE ...; END IF;
+//	  LOOP
+//	    IF _counter > _upper THEN
+//	      EXIT;
+//	    END IF;
+//	    i := _counter;
+//	    _counter := _counter + _step;
+//	    [body];
+//	  END LOOP;
+//	END;
+//
+// For a REVERSE loop, the comparison is flipped and the step is subtracted.
+// As in postgres, the loop variable is always declared by the block, so it
+// shadows any variable with the same name for the duration of the loop.
+func (b *plpgsqlBuilder) buildIntForLoop(
+	loop *ast.ForLoop, control *ast.IntForLoopControl,
+) *ast.Block {
+	if len(loop.Target) != 1 {
+		panic(intForLoopTargetErr)
+	}
+	target := loop.Target[0]
+	counter := ast.Variable(b.makeIdentifier("_for_counter"))
+	upper := ast.Variable(b.makeIdentifier("_for_upper"))
+	step := ast.Variable(b.makeIdentifier("_for_step"))
+	var stepExpr ast.Expr = tree.NewDInt(1)
+	if control.Step != nil {
+		stepExpr = control.Step
+	}
+	// The loop variable is declared after the bounds, so that the bounds can
+	// reference a variable with the same name from an enclosing block.
+	decls := []ast.Statement{
+		&ast.Declaration{Var: counter, Typ: types.Int, Expr: control.Lower},
+		&ast.Declaration{Var: upper, Typ: types.Int, Expr: control.Upper},
+		&ast.Declaration{Var: step, Typ: types.Int, Expr: stepExpr},
+		&ast.Declaration{Var: target, Typ: types.Int},
+	}
+	cmpOp, binOp := treecmp.GT, treebin.Plus
+	if control.Reverse {
+		cmpOp, binOp = treecmp.LT, treebin.Minus
+	}
+	name := func(v ast.Variable) tree.Expr {
+		return tree.NewUnresolvedName(string(v))
+	}
+	loopBody := make([]ast.Statement, 0, len(loop.Body)+3)
+	loopBody = append(loopBody,
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(cmpOp), Left: name(counter), Right: name(upper),
+			},
+			ThenBody: []ast.Statement{&ast.Exit{}},
+		},
+		&ast.Assignment{Var: target, Value: name(counter)},
+		&ast.Assignment{Var: counter, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(binOp), Left: name(counter), Right: name(step),
+		}},
+	)
+	loopBody = append(loopBody, loop.Body...)
+	return &ast.Block{
+		Decls: decls,
+		Body: []ast.Statement{
+			b.makeRaiseIf(&tree.IsNullExpr{Expr: name(counter)},
+				"lower bound of FOR loop cannot be null", pgcode.NullValueNotAllowed,
+			),
+			b.makeRaiseIf(&tree.IsNullExpr{Expr: name(upper)},
+				"upper bound of FOR loop cannot be null", pgcode.NullValueNotAllowed,
+			),
+			b.makeRaiseIf(&tree.IsNullExpr{Expr: name(step)},
+				"BY value of FOR loop cannot be null", pgcode.NullValueNotAllowed,
+			),
+			b.makeRaiseIf(
+				&tree.ComparisonExpr{
+					Operator: treecmp.MakeComparisonOperator(treecmp.LE), Left: name(step), Right: tree.NewDInt(0),
+				},
+				"BY value of FOR loop must be greater than zero", pgcode.InvalidParameterValue,
+			),
+			&ast.Loop{Label: loop.Label, Body: loopBody},
+		},
+	}
+}
+
+// buildQueryForLoop builds a FOR loop that iterates over the rows of a query.
+// A SELECT query is iterated using a cursor, so that its rows are fetched one
+// at a time as the loop executes (see buildCursorForLoop).
+//
+// Data-modifying statements cannot be used for a cursor. Similar to postgres,
+// they are executed to completion before the loop begins: the statement is
+// executed in a new continuation, and its rows are collected into an array
+// (see buildRowsArray). The rest of the continuation is a block that iterates
+// over the array (see makeArrayLoop), followed by the statements after the
+// loop.
+func (b *plpgsqlBuilder) buildQueryForLoop(
+	loop *ast.ForLoop, control *ast.QueryForLoopControl, stmts []ast.Statement, s *scope,
+) *scope {
+	if _, ok := control.Query.(*tree.Select); ok {
+		block := b.buildCursorForLoop(loop, control.Query)
+		return b.buildPLpgSQLStatements(b.prependStmt(block, stmts), s)
+	}
+	var elemTyp *types.T
+	if len(loop.Target) == 1 {
+		// A single target is either assigned the first column, or a tuple made
+		// from all the columns if it is a composite-type variable.
+		elemTyp = b.resolveVariableForAssign(loop.Target[0])
+	} else {
+		targetTypes := make([]*types.T, len(loop.Target))
+		for j := range loop.Target {
+			targetTypes[j] = b.resolveVariableForAssign(loop.Target[j])
+		}
+		elemTyp = types.MakeTuple(targetTypes)
+	}
+	queryCon := b.makeContinuation("_stmt_for_query")
+	stmtScope := b.ob.buildStmtAtRootWithScope(control.Query, nil /* desiredTypes */, queryCon.s)
+	b.checkReturnQueryCols(loop, stmtScope)
+	rowsVar := ast.Variable(b.makeIdentifier("_for_query_rows"))
+	rowsScope := b.buildRowsArray(queryCon.s, stmtScope, elemTyp, string(rowsVar))
+
+	// The array variable declared by the loop block is initialized with the
+	// column of the same name that was just projected.
+	block := b.makeArrayLoop(
+		loop.Label, loop.Target, rowsVar, types.MakeArray(elemTyp),
+		tree.NewUnresolvedName(string(rowsVar)), loop.Body,
+	)
+	bodyScope := b.buildPLpgSQLStatements(b.prependStmt(block, stmts), rowsScope)
+	b.appendBodyStmt(&queryCon, bodyScope)
+	return b.callContinuation(&queryCon, s)
+}
+
+// buildCursorForLoop returns a block that opens a cursor for the given query,
+// and fetches its rows one at a time into the given loop target:
+//
+//	DECLARE
+//	  _for_cursor REFCURSOR;
+//	  _for_found BOOL;
+//	  _for_col_1 [type];
+//	  ...
+//	BEGIN
+//	  OPEN _for_cursor FOR [query];
+//	  LOOP
+//	    FETCH _for_cursor INTO _for_found, _for_col_1, ...;
+//	    IF _for_found IS NULL THEN
+//	      EXIT;
+//	    END IF;
+//	    [target] := _for_col_1;
+//	    ...
+//	    [body];
+//	  END LOOP;
+//	  CLOSE _for_cursor;
+//	END;
+//
+// The cursor returns an extra leading column that is always true (see
+// prependFoundColumn), so that _for_found is only NULL once all rows have been
+// fetched. The rows are fetched into hidden variables so that the target keeps
+// the values of the last row after the loop. If the loop is left by RETURN, or
+// by EXIT or CONTINUE for an enclosing loop, the cursor is not closed until
+// the end of the transaction.
+func (b *plpgsqlBuilder) buildCursorForLoop(loop *ast.ForLoop, query tree.Statement) *ast.Block {
+	target := loop.Target
+	targetTypes := b.intoTargetTypes(target)
+	curVar := ast.Variable(b.makeIdentifier("_for_cursor"))
+	foundVar := ast.Variable(b.makeIdentifier("_for_found"))
+	decls := make([]ast.Statement, 0, len(targetTypes)+2)
+	decls = append(decls,
+		&ast.Declaration{Var: curVar, Typ: types.RefCursor},
+		&ast.Declaration{Var: foundVar, Typ: types.Bool},
+	)
+	fetchTarget := make([]ast.Variable, 0, len(targetTypes)+1)
+	fetchTarget = append(fetchTarget, foundVar)
+	cols := make(tree.Exprs, len(targetTypes))
+	for j, typ := range targetTypes {
+		colVar := ast.Variable(b.makeIdentifier("_for_col"))
+		decls = append(decls, &ast.Declaration{Var: colVar, Typ: typ})
+		fetchTarget = append(fetchTarget, colVar)
+		cols[j] = tree.NewUnresolvedName(string(colVar))
+	}
+	open := &ast.Open{CurVar: curVar, Query: query}
+	fetch := &ast.Fetch{
+		Cursor: tree.CursorStmt{Name: tree.Name(curVar), FetchType: tree.FetchNormal, Count: 1},
+		Target: fetchTarget,
+	}
+	if b.queryLoopStmts == nil {
+		b.queryLoopStmts = make(map[ast.Statement]int)
+	}
+	// The OPEN statement inherits the column check of a RETURN QUERY loop.
+	b.queryLoopStmts[open] = b.queryLoopStmts[loop]
+	b.queryLoopStmts[fetch] = 0
+
+	loopBody := make([]ast.Statement, 0, len(loop.Body)+len(target)+2)
+	loopBody = append(loopBody,
+		fetch,
+		&ast.If{
+			Condition: &tree.IsNullExpr{Expr: tree.NewUnresolvedName(string(foundVar))},
+			ThenBody:  []ast.Statement{&ast.Exit{}},
+		},
+	)
+	if b.targetIsRecordVar(target) {
+		loopBody = append(loopBody, &ast.Assignment{Var: target[0], Value: &tree.Tuple{Exprs: cols}})
+	} else {
+		for j := range target {
+			loopBody = append(loopBody, &ast.Assignment{Var: target[j], Value: cols[j]})
+		}
+	}
+	loopBody = append(loopBody, loop.Body...)
+	return &ast.Block{
+		Decls: decls,
+		Body: []ast.Statement{
+			open,
+			&ast.Loop{Label: loop.Label, Body: loopBody},
+			&ast.Close{CurVar: curVar},
+		},
+	}
+}
+
+// prependFoundColumn projects a column that is always true before the columns
+// of the given query scope. It is used for the cursor of a query FOR loop, so
+// that a fetched row that only contains NULL values can be distinguished from
+// the end of the rows.
+func (b *plpgsqlBuilder) prependFoundColumn(stmtScope *scope) *scope {
+	f := b.ob.factory
+	foundScope := stmtScope.push()
+	foundColName := scopeColName("").WithMetadataName(b.makeIdentifier("for_found"))
+	found := b.ob.synthesizeColumn(foundScope, foundColName, types.Bool, nil /* expr */, memo.TrueSingleton)
+	for i := range stmtScope.cols {
+		foundScope.appendColumn(&stmtScope.cols[i])
+	}
+	// Pass through all the columns of the query, including any columns that are
+	// only needed to order its rows.
+	foundScope.ordering = stmtScope.ordering
+	foundScope.expr = f.ConstructProject(
+		stmtScope.expr,
+		memo.ProjectionsExpr{f.ConstructProjectionsItem(memo.TrueSingleton, found.id)},
+		stmtScope.expr.Relational().OutputCols,
+	)
+	return foundScope
+}
+
+// buildReturnQuery rewrites a RETURN QUERY statement into a block with a query
+// FOR loop that appends each row of the query to the result of the routine. If
+// the routine uses ROW_COUNT, the loop also counts the rows.
+func (b *plpgsqlBuilder) buildReturnQuery(ret *ast.ReturnQuery) *ast.Block {
+	// Each column of the query is fetched into a hidden variable. The row is
+	// then built from the variables and appended to the result.
+	colTypes := []*types.T{b.returnType}
+	if b.returnType.Family() == types.TupleFamily {
+		colTypes = b.returnType.TupleContents()
+	}
+	decls := make([]ast.Statement, len(colTypes))
+	target := make([]ast.Variable, len(colTypes))
+	cols := make(tree.Exprs, len(colTypes))
+	for j, typ := range colTypes {
+		target[j] = ast.Variable(b.makeIdentifier("_return_query_col"))
+		decls[j] = &ast.Declaration{Var: target[j], Typ: typ}
+		cols[j] = tree.NewUnresolvedName(string(target[j]))
+	}
+	row := cols[0]
+	if b.returnType.Family() == types.TupleFamily {
+		row = &tree.CastExpr{Expr: &tree.Tuple{Exprs: cols}, Type: b.returnType}
+	}
+	body := []ast.Statement{b.makeResultAppend(row)}
+	var stmts []ast.Statement
+	if b.rowCountVar != "" {
+		count := tree.NewUnresolvedName(string(b.rowCountVar))
+		stmts = append(stmts, &ast.Assignment{Var: b.rowCountVar, Value: tree.DZero})
+		body = append(body, &ast.Assignment{Var: b.rowCountVar, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Plus), Left: count, Right: tree.NewDInt(1),
+		}})
+	}
+	loop := &ast.ForLoop{
+		Target:  target,
+		Control: &ast.QueryForLoopControl{Query: ret.SqlStmt},
+		Body:    body,
+	}
+	// The number of columns returned by the query is checked when it is built.
+	if b.queryLoopStmts == nil {
+		b.queryLoopStmts = make(map[ast.Statement]int)
+	}
+	b.queryLoopStmts[loop] = len(colTypes)
+	return &ast.Block{Decls: decls, Body: append(stmts, loop)}
+}
+
+// checkReturnQueryCols panics if the given statement builds the query of a
+// RETURN QUERY statement, and the query does not return the number of columns
+// in the result of the routine.
+func (b *plpgsqlBuilder) checkReturnQueryCols(stmt ast.Statement, stmtScope *scope) {
+	if numCols := b.queryLoopStmts[stmt]; numCols != 0 && len(stmtScope.cols) != numCols {
+		panic(returnQueryStructureErr)
+	}
+}
+
+// buildForEachArray rewrites a FOREACH loop into a block that iterates over
+// the elements of the array (see makeArrayLoop). Before entering the loop, the
+// block checks that the array is not NULL.
+func (b *plpgsqlBuilder) buildForEachArray(loop *ast.ForEachArray, s *scope) *ast.Block {
+	if loop.Slice != 0 {
+		panic(foreachSliceErr)
+	}
+	expr, _ := tree.WalkExpr(s, loop.Expr)
+	typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.AnyArray)
+	if err != nil {
+		panic(err)
+	}
+	arrTyp := typedExpr.ResolvedType()
+	switch arrTyp.Family() {
+	case types.ArrayFamily:
+	case types.UnknownFamily:
+		panic(foreachNullErr)
+	default:
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"FOREACH expression must yield an array, not type %s", arrTyp.Name(),
+		))
+	}
+	arrVar := ast.Variable(b.makeIdentifier("_foreach_array"))
+	block := b.makeArrayLoop(loop.Label, loop.Target, arrVar, arrTyp, loop.Expr, loop.Body)
+	nullCheck := b.makeRaiseIf(
+		&tree.IsNullExpr{Expr: tree.NewUnresolvedName(string(arrVar))},
+		foreachNullErr.Error(), pgcode.NullValueNotAllowed,
+	)
+	block.Body = b.prependStmt(nullCheck, block.Body)
+	return block
+}
+
+// makeArrayLoop returns a block that assigns each element of an array to the
+// given loop target, and then executes the loop body:
+//
+//	DECLARE
+//	  _arr [arrTyp] := [arrExpr];
+//	  _idx INT := 0;
+//	BEGIN
+//	  LOOP
+//	    _idx := _idx + 1;
+//	    IF _idx > cardinality(_arr) THEN
+//	      EXIT;
+//	    END IF;
+//	    [target] := _arr[_idx];
+//	    [body];
+//	  END LOOP;
+//	END;
+//
+// If the target has multiple variables, each one is assigned an element of
+// the (tuple-typed) array element.
+func (b *plpgsqlBuilder) makeArrayLoop(
+	label string,
+	target []ast.Variable,
+	arrVar ast.Variable,
+	arrTyp *types.T,
+	arrExpr ast.Expr,
+	body []ast.Statement,
+) *ast.Block {
+	idxVar := ast.Variable(b.makeIdentifier("_loop_idx"))
+	name := func(v ast.Variable) tree.Expr {
+		return tree.NewUnresolvedName(string(v))
+	}
+	makeElem := func() tree.Expr {
+		return &tree.IndirectionExpr{
+			Expr:        name(arrVar),
+			Indirection: tree.ArraySubscripts{{Begin: name(idxVar)}},
+		}
+	}
+	loopBody := make([]ast.Statement, 0, len(body)+len(target)+2)
+	loopBody = append(loopBody,
+		&ast.Assignment{Var: idxVar, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Plus), Left: name(idxVar), Right: tree.NewDInt(1),
+		}},
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+				Left:     name(idxVar),
+				Right: &tree.FuncExpr{
+					Func: tree.WrapFunction("cardinality"), Exprs: tree.Exprs{name(arrVar)},
+				},
+			},
+			ThenBody: []ast.Statement{&ast.Exit{}},
+		},
+	)
+	elemTyp := arrTyp.ArrayContents()
+	if len(target) == 1 && (elemTyp.Family() != types.TupleFamily ||
+		b.resolveVariableForAssign(target[0]).Family() == types.TupleFamily) {
+		loopBody = append(loopBody, &ast.Assignment{Var: target[0], Value: makeElem()})
+	} else {
+		if elemTyp.Family() != types.TupleFamily {
+			panic(nonCompositeTargetErr)
+		}
+		for j := range target {
+			var val ast.Expr = tree.DNull
+			if j < len(elemTyp.TupleContents()) {
+				val = &tree.ColumnAccessExpr{Expr: makeElem(), ByIndex: true, ColIndex: j}
+			}
+			loopBody = append(loopBody, &ast.Assignment{Var: target[j], Value: val})
+		}
+	}
+	loopBody = append(loopBody, body...)
+	return &ast.Block{
+		Decls: []ast.Statement{
+			&ast.Declaration{Var: arrVar, Typ: arrTyp, Expr: arrExpr},
+			&ast.Declaration{Var: idxVar, Typ: types.Int, Expr: tree.NewDInt(0)},
+		},
+		Body: []ast.Statement{&ast.Loop{Label: label, Body: loopBody}},
+	}
+}
+
+// buildRowsArray builds an expression that aggregates the rows returned by a
+// SQL statement into an array with the given element type, which is empty if
+// the statement returns no rows. If the element type is a tuple, each row is
+// converted into a tuple; otherwise, the first column of each row is used. The
+// array is projected as a column with the given name in a new child scope of
+// the given parent scope.
+func (b *plpgsqlBuilder) buildRowsArray(
+	parent, stmtScope *scope, elemTyp *types.T, colName string,
+) *scope {
+	f := b.ob.factory
+	md := f.Metadata()
+	makeElem := func(j int, typ *types.T) opt.ScalarExpr {
+		if j >= len(stmtScope.cols) {
+			// If there are fewer columns than elements, the remaining elements are
+			// NULL.
+			return f.ConstructConstVal(tree.DNull, typ)
+		}
+		return b.coerceType(f.ConstructVariable(stmtScope.cols[j].id), typ)
+	}
+	var elem opt.ScalarExpr
+	if elemTyp.Family() == types.TupleFamily {
+		elems := make(memo.ScalarListExpr, len(elemTyp.TupleContents()))
+		for j, typ := range elemTyp.TupleContents() {
+			elems[j] = makeElem(j, typ)
+		}
+		elem = f.ConstructTuple(elems, elemTyp)
+	} else {
+		elem = makeElem(0, elemTyp)
+	}
+	// Project the element, passing through any columns needed to order the
+	// rows. Then, aggregate the elements in order.
+	ordering := stmtScope.makeOrderingChoice()
+	elemCol := md.AddColumn(b.makeIdentifier("_row"), elemTyp)
+	input := f.ConstructProject(
+		stmtScope.expr,
+		memo.ProjectionsExpr{f.ConstructProjectionsItem(elem, elemCol)},
+		ordering.ColSet(),
+	)
+	arrTyp := types.MakeArray(elemTyp)
+	aggCol := md.AddColumn(b.makeIdentifier("_rows_agg"), arrTyp)
+	aggs := memo.AggregationsExpr{f.ConstructAggregationsItem(
+		f.ConstructArrayAgg(f.ConstructVariable(elemCol)), aggCol,
+	)}
+	groupBy := f.ConstructScalarGroupBy(input, aggs, &memo.GroupingPrivate{Ordering: ordering})
+
+	// ARRAY_AGG returns NULL when there are no input rows, so coalesce the
+	// result with an empty array.
+	rows := f.ConstructCoalesce(memo.ScalarListExpr{
+		f.ConstructVariable(aggCol),
+		f.ConstructConstVal(tree.NewDArray(elemTyp), arrTyp),
+	})
+	rowsScope := parent.push()
+	col := b.ob.synthesizeColumn(rowsScope, scopeColName(tree.Name(colName)), arrTyp, nil /* expr */, rows)
+	rowsScope.expr = b.ob.constructProject(groupBy, []scopeColumn{*col})
+	return rowsScope
+}
+
+// makeRaiseIf returns an IF statement that raises an error with the given
+// message and code if the condition is true.
+func (b *plpgsqlBuilder) makeRaiseIf(cond ast.Expr, message string, code pgcode.Code) *ast.If {
+	return &ast.If{
+		Condition: cond,
+		ThenBody: []ast.Statement{&ast.Raise{
+			LogLevel: "EXCEPTION",
+			Message:  message,
+			Code:     code.String(),
+		}},
+	}
+}
+
+// makeNotNullCheck returns an IF statement that raises an error if the given
+// NOT NULL variable is null.
+func (b *plpgsqlBuilder) makeNotNullCheck(name ast.Variable) *ast.If {
+	return b.makeRaiseIf(
+		&tree.IsNullExpr{Expr: tree.NewUnresolvedName(string(name))},
+		fmt.Sprintf("null value cannot be assigned to variable \"%s\" declared NOT NULL", name),
+		pgcode.NullValueNotAllowed,
+	)
+}
+
+// addNotNullChecks prepends a NOT NULL check to the given statements for each
+// NOT NULL variable in the given target, which has just been assigned.
+func (b *plpgsqlBuilder) addNotNullChecks(
+	stmts []ast.Statement, target ...ast.Variable,
+) []ast.Statement {
+	for _, name := range target {
+		if b.isNotNullVar(name) {
+			stmts = b.prependStmt(b.makeNotNullCheck(name), stmts)
+		}
+	}
+	return stmts
+}
+
+// projectHiddenColumn projects the given expression as a new column with the
+// given name, passing through the columns of the given scope. It is used for
+// intermediate values that are not PL/pgSQL variables.
+func (b *plpgsqlBuilder) projectHiddenColumn(
+	inScope *scope, name string, expr ast.Expr, typ *types.T,
+) *scope {
+	projScope := inScope.push()
+	for i := range inScope.cols {
+		projScope.appendColumn(&inScope.cols[i])
+	}
+	scalar := b.buildPLpgSQLExpr(expr, typ, inScope)
+	b.addBarrierIfVolatile(inScope, scalar)
+	b.ob.synthesizeColumn(projScope, scopeColName(tree.Name(name)), typ, nil /* expr */, scalar)
+	b.ob.constructProjectForScope(inScope, projScope)
+	return projScope
+}
+
+// makeResultAppend returns an assignment that appends the given row to the
+// result of a set-returning routine.
+func (b *plpgsqlBuilder) makeResultAppend(row ast.Expr) *ast.Assignment {
+	return &ast.Assignment{
+		Var: b.resultVar,
+		Value: &tree.FuncExpr{
+			Func:  tree.WrapFunction("array_append"),
+			Exprs: tree.Exprs{tree.NewUnresolvedName(string(b.resultVar)), row},
+		},
+	}
+}
+
+// unnestResult builds a ProjectSet that unnests the array of result rows that
+// is returned by the root block of a set-returning routine, so that each
+// element becomes a row.
+func (b *plpgsqlBuilder) unnestResult(s *scope) *scope {
+	const unnestFnName = "unnest"
+	props, overloads := builtinsregistry.GetBuiltinProperties(unnestFnName)
+	if len(overloads) == 0 || overloads[0].Types.Length() != 1 {
+		panic(errors.AssertionFailedf("expected single-argument overload for %s", unnestFnName))
+	}
+	unnestCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{b.ob.factory.ConstructVariable(s.cols[0].id)},
+		&memo.FunctionPrivate{
+			Name:       unnestFnName,
+			Typ:        b.returnType,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	unnestScope := s.push()
+	colName := scopeColName("").WithMetadataName(b.makeIdentifier("unnest"))
+	col := b.ob.synthesizeColumn(unnestScope, colName, b.returnType, nil /* expr */, nil /* scalar */)
+	unnestScope.expr = b.ob.factory.ConstructProjectSet(
+		s.expr, memo.ZipExpr{b.ob.factory.ConstructZipItem(unnestCall, opt.ColList{col.id})},
+	)
+	return unnestScope
+}
+
 // buildPLpgSQLRaise builds a Project expression which implements the
 // notice-sending behavior of RAISE statements.
 func (b *plpgsqlBuilder) buildPLpgSQLRaise(inScope *scope, args memo.ScalarListExpr) *scope {
@@ -1345,12 +2329,6 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 	handlers := make([]*memo.UDFDefinition, 0, len(block.Exceptions))
 	addHandler := func(codeStr string, handler *memo.UDFDefinition) {
 		code := pgcode.MakeCode(strings.ToUpper(codeStr))
-		switch code {
-		case pgcode.TransactionRollback, pgcode.TransactionIntegrityConstraintViolation,
-			pgcode.SerializationFailure, pgcode.StatementCompletionUnknown,
-			pgcode.DeadlockDetected:
-			panic(retryableErrErr)
-		}
 		codes = append(codes, code)
 		handlers = append(handlers, handler)
 	}
@@ -1392,17 +2370,19 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 // handleEndOfFunction handles the case when control flow reaches the end of a
 // PL/pgSQL routine without reaching a RETURN statement.
 func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
-	if b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
-		// Routines with OUT-parameters and VOID return types need not explicitly
-		// specify a RETURN statement.
+	if b.setReturning || b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
+		// Set-returning routines and routines with OUT-parameters and VOID return
+		// types need not explicitly specify a RETURN statement.
 		var returnExpr tree.Expr = tree.DNull
-		if b.hasOutParam() {
+		if b.setReturning {
+			returnExpr = tree.NewUnresolvedName(string(b.resultVar))
+		} else if b.hasOutParam() {
 			returnExpr = b.makeReturnForOutParams()
 		}
 		returnScope := inScope.push()
 		colName := scopeColName("_implicit_return")
-		returnScalar := b.buildPLpgSQLExpr(returnExpr, b.returnType, inScope)
-		b.ob.synthesizeColumn(returnScope, colName, b.returnType, nil /* expr */, returnScalar)
+		returnScalar := b.buildPLpgSQLExpr(returnExpr, b.resultType(), inScope)
+		b.ob.synthesizeColumn(returnScope, colName, b.resultType(), nil /* expr */, returnScalar)
 		b.ob.constructProjectForScope(inScope, returnScope)
 		return returnScope
 	}
@@ -1430,8 +2410,8 @@ func (b *plpgsqlBuilder) buildEndOfFunctionRaise(con *continuation) {
 	// ensures that the continuation routine's return type is correct.
 	eofColName := scopeColName("").WithMetadataName(b.makeIdentifier("end_of_function"))
 	eofScope := con.s.push()
-	typedNull := b.ob.factory.ConstructNull(b.returnType)
-	b.ob.synthesizeColumn(eofScope, eofColName, b.returnType, nil /* expr */, typedNull)
+	typedNull := b.ob.factory.ConstructNull(b.resultType())
+	b.ob.synthesizeColumn(eofScope, eofColName, b.resultType(), nil /* expr */, typedNull)
 	b.ob.constructProjectForScope(con.s, eofScope)
 	b.appendBodyStmt(con, eofScope)
 }
@@ -1496,6 +2476,184 @@ func (b *plpgsqlBuilder) addOneRowCheck(s *scope) {
 	s.expr = b.ob.factory.ConstructProject(s.expr, memo.ProjectionsExpr{}, originalCols)
 }
 
+// buildSideEffectStmt builds a SQL statement that is only executed for its
+// side effects into a body statement of the given continuation, followed by
+// the remaining PL/pgSQL statements. If the routine tracks the row count, the
+// rows produced by the SQL statement are counted and assigned to the row count
+// variable before calling a continuation for the remaining statements.
+// stmtInfo identifies the statement for execution statistics, and may be nil.
+func (b *plpgsqlBuilder) buildSideEffectStmt(
+	con *continuation,
+	stmtScope *scope,
+	stmtInfo *tree.RoutineStmtInfo,
+	stmts []ast.Statement,
+	s *scope,
+) *scope {
+	if b.rowCountVar == "" {
+		b.appendProfiledBodyStmt(con, stmtScope, stmtInfo)
+		b.appendPlpgSQLStmts(con, stmts)
+		return b.callContinuation(con, s)
+	}
+	// Add an optimization barrier to ensure that the columns of the statement
+	// are not pruned, since they may have side effects. Then, count the rows.
+	f := b.ob.factory
+	b.addBarrier(stmtScope)
+	countCol := f.Metadata().AddColumn(b.makeIdentifier("_count_rows"), types.Int)
+	aggs := memo.AggregationsExpr{f.ConstructAggregationsItem(f.ConstructCountRows(), countCol)}
+	groupBy := f.ConstructScalarGroupBy(stmtScope.expr, aggs, memo.EmptyGroupingPrivate)
+	countScope := con.s.push()
+	col := b.ob.synthesizeColumn(
+		countScope, scopeColName(b.rowCountVar), types.Int, nil /* expr */, f.ConstructVariable(countCol),
+	)
+	countScope.expr = b.ob.constructProject(groupBy, []scopeColumn{*col})
+	// Build the remaining statements into a separate continuation, rather than
+	// into the same body statement, so that their execution is not attributed to
+	// the SQL statement.
+	retCon := b.makeContinuation("_stmt_exec_ret")
+	b.appendPlpgSQLStmts(&retCon, stmts)
+	countScope = b.callContinuationAfterStmt(&retCon, countScope, stmtInfo)
+	b.appendProfiledBodyStmt(con, countScope, stmtInfo)
+	return b.callContinuation(con, s)
+}
+
+// addRowCountReturning returns a copy of the given data-modifying statement
+// with a RETURNING clause, so that the modified rows can be counted. Other
+// statements are returned unchanged.
+func addRowCountReturning(stmt tree.Statement) tree.Statement {
+	returning := &tree.ReturningExprs{tree.SelectExpr{Expr: tree.DNull}}
+	switch t := stmt.(type) {
+	case *tree.Insert:
+		if _, ok := t.Returning.(*tree.NoReturningClause); ok {
+			cpy := *t
+			cpy.Returning = returning
+			return &cpy
+		}
+	case *tree.Update:
+		if _, ok := t.Returning.(*tree.NoReturningClause); ok {
+			cpy := *t
+			cpy.Returning = returning
+			return &cpy
+		}
+	case *tree.Delete:
+		if _, ok := t.Returning.(*tree.NoReturningClause); ok {
+			cpy := *t
+			cpy.Returning = returning
+			return &cpy
+		}
+	}
+	return stmt
+}
+
+// projectRowFound handles the row count for a non-strict INTO statement, which
+// returns at most one row. It projects a constant column that will be NULL if
+// the statement returned no rows once the statement is extended with a row of
+// NULL values, and returns an expression for the row count.
+func (b *plpgsqlBuilder) projectRowFound(s *scope) opt.ScalarExpr {
+	f := b.ob.factory
+	foundCol := f.Metadata().AddColumn(b.makeIdentifier("_row_found"), types.Int)
+	s.expr = f.ConstructProject(
+		s.expr,
+		memo.ProjectionsExpr{f.ConstructProjectionsItem(
+			f.ConstructConstVal(tree.NewDInt(1), types.Int), foundCol,
+		)},
+		s.colSet(),
+	)
+	return f.ConstructCoalesce(memo.ScalarListExpr{
+		f.ConstructVariable(foundCol),
+		f.ConstructConstVal(tree.DZero, types.Int),
+	})
+}
+
+// buildDynamicExecute projects a call to the crdb_internal.plpgsql_execute
+// builtin function, which plans and executes the query string of a PLpgSQL
+// EXECUTE statement. If the statement has an INTO target, the elements of the
+// resulting tuple are projected as separate columns, one for each column
+// expected by the target.
+func (b *plpgsqlBuilder) buildDynamicExecute(
+	s *scope, execute *ast.DynamicExecute, strict bool,
+) *scope {
+	const execFnName = "crdb_internal.plpgsql_execute"
+	props, overloads := builtinsregistry.GetBuiltinProperties(execFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", execFnName))
+	}
+	var typs []*types.T
+	if execute.Target != nil {
+		typs = b.intoTargetTypes(execute.Target)
+	}
+	returnType := types.MakeTuple(typs)
+	elems := make(memo.ScalarListExpr, len(typs))
+	for i := range elems {
+		elems[i] = b.ob.factory.ConstructConstVal(tree.DNull, typs[i])
+	}
+	argsScope := b.buildDynamicQueryArgs(s, execute.Query, execute.Params)
+
+	// The arguments are:
+	//   1. The query string.
+	//   2. A tuple with the values of the USING parameters.
+	//   3. Whether the query must return exactly one row.
+	//   4. The types of the columns to return (empty if there is no target).
+	execCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.ob.factory.ConstructVariable(argsScope.cols[0].id),
+			b.ob.factory.ConstructVariable(argsScope.cols[1].id),
+			b.ob.factory.ConstructConstVal(tree.MakeDBool(tree.DBool(strict)), types.Bool),
+			b.ob.factory.ConstructTuple(elems, returnType),
+		},
+		&memo.FunctionPrivate{
+			Name:       execFnName,
+			Typ:        returnType,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	b.addBarrierIfVolatile(argsScope, execCall)
+	execColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_exec"))
+	execScope := argsScope.push()
+	execCol := b.ob.synthesizeColumn(execScope, execColName, returnType, nil /* expr */, execCall)
+	b.ob.constructProjectForScope(argsScope, execScope)
+	if len(typs) == 0 {
+		return execScope
+	}
+	// Project each element of the result tuple as a separate column.
+	elemScope := execScope.push()
+	for i, typ := range typs {
+		scalar := b.ob.factory.ConstructColumnAccess(
+			b.ob.factory.ConstructVariable(execCol.id), memo.TupleOrdinal(i),
+		)
+		b.ob.synthesizeColumn(elemScope, scopeColName(""), typ, nil /* expr */, scalar)
+	}
+	b.ob.constructProjectForScope(execScope, elemScope)
+	return elemScope
+}
+
+// buildDynamicQueryArgs projects the query string and a tuple of the USING
+// parameters for a PLpgSQL EXECUTE or OPEN ... FOR EXECUTE statement.
+func (b *plpgsqlBuilder) buildDynamicQueryArgs(s *scope, query ast.Expr, params []ast.Expr) *scope {
+	argsScope := s.push()
+	queryScalar := b.buildPLpgSQLExpr(query, types.String, s)
+	queryColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_query"))
+	b.ob.synthesizeColumn(argsScope, queryColName, types.String, nil /* expr */, queryScalar)
+	elems := make(memo.ScalarListExpr, len(params))
+	typs := make([]*types.T, len(params))
+	for i := range params {
+		param, _ := tree.WalkExpr(s, params[i])
+		typedParam, err := param.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		elems[i] = b.ob.buildScalar(typedParam, s, nil, nil, b.colRefs)
+		typs[i] = elems[i].DataType()
+	}
+	paramsType := types.MakeTuple(typs)
+	paramsColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_params"))
+	b.ob.synthesizeColumn(
+		argsScope, paramsColName, paramsType, nil /* expr */, b.ob.factory.ConstructTuple(elems, paramsType),
+	)
+	b.ob.constructProjectForScope(s, argsScope)
+	return argsScope
+}
+
 // buildFetch projects a call to the crdb_internal.plpgsql_fetch builtin
 // function, which handles cursors for the PLpgSQL FETCH and MOVE statements.
 func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
@@ -1522,18 +2680,7 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	// For a FETCH statement, we have to pass the expected result types.
 	var typs []*types.T
 	if !fetch.IsMove {
-		if b.targetIsRecordVar(fetch.Target) {
-			// If the target is a single record-type variable, the columns of the
-			// FETCH are assigned as its *elements*, rather than directly to the
-			// variable.
-			typs = b.resolveVariableForAssign(fetch.Target[0]).TupleContents()
-		} else {
-			typs = make([]*types.T, len(fetch.Target))
-			for i := range fetch.Target {
-				typ := b.resolveVariableForAssign(fetch.Target[i])
-				typs[i] = typ
-			}
-		}
+		typs = b.intoTargetTypes(fetch.Target)
 	}
 	returnType := types.MakeTuple(typs)
 	elems := make(memo.ScalarListExpr, len(typs))
@@ -1568,11 +2715,40 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	b.ob.constructProjectForScope(s, fetchScope)
 	if !fetch.IsMove && b.targetIsRecordVar(fetch.Target) {
 		// Handle a single record-type variable (see projectRecordVar for details).
-		fetchScope = b.projectRecordVar(fetchScope, fetch.Target[0])
+		fetchScope = b.projectRecordVar(fetchScope, fetch.Target[0], len(fetchScope.cols))
 	}
 	return fetchScope
 }
 
+// intoTargetTypes returns the types of the columns that are assigned to the
+// given INTO target. If the target is a single record-type variable, the
+// columns are assigned as its *elements*, rather than directly to the
+// variable.
+func (b *plpgsqlBuilder) intoTargetTypes(target []ast.Variable) []*types.T {
+	if b.targetIsRecordVar(target) {
+		return b.resolveVariableForAssign(target[0]).TupleContents()
+	}
+	typs := make([]*types.T, len(target))
+	for i := range target {
+		typs[i] = b.resolveVariableForAssign(target[i])
+	}
+	return typs
+}
+
+// checkDuplicateTargets panics if the same variable appears more than once in
+// the given INTO target.
+func checkDuplicateTargets(target []ast.Variable) {
+	if len(target) > 1 {
+		seenTargets := make(map[ast.Variable]struct{})
+		for _, name := range target {
+			if _, ok := seenTargets[name]; ok {
+				panic(dupIntoErr)
+			}
+			seenTargets[name] = struct{}{}
+		}
+	}
+}
+
 // targetIsSingleCompositeVar returns true if the given INTO target is a single
 // RECORD-type variable.
 func (b *plpgsqlBuilder) targetIsRecordVar(target []ast.Variable) bool {
@@ -1582,17 +2758,21 @@ func (b *plpgsqlBuilder) targetIsRecordVar(target []ast.Variable) bool {
 // projectRecordVar handles the special case when a single RECORD-type variable
 // is the target of an INTO clause or FETCH statement. In this case, the columns
 // from the SQL statement (or FETCH) should be wrapped into a tuple, which is
-// assigned to the RECORD-type variable.
-func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable) *scope {
+// assigned to the RECORD-type variable. The first numElems columns are wrapped
+// into the tuple, and any remaining columns are passed through.
+func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable, numElems int) *scope {
 	typ := b.resolveVariableForAssign(name)
 	recordScope := s.push()
-	elems := make(memo.ScalarListExpr, len(s.cols))
+	elems := make(memo.ScalarListExpr, numElems)
 	for j := range elems {
 		elems[j] = b.ob.factory.ConstructVariable(s.cols[j].id)
 	}
 	tuple := b.ob.factory.ConstructTuple(elems, typ)
-	col := b.ob.synthesizeColumn(recordScope, scopeColName(name), typ, nil /* expr */, tuple)
-	recordScope.expr = b.ob.constructProject(s.expr, []scopeColumn{*col})
+	b.ob.synthesizeColumn(recordScope, scopeColName(name), typ, nil /* expr */, tuple)
+	for j := len(elems); j < len(s.cols); j++ {
+		recordScope.appendColumn(&s.cols[j])
+	}
+	recordScope.expr = b.ob.constructProject(s.expr, recordScope.cols)
 	return recordScope
 }
 
@@ -1626,7 +2806,7 @@ func (b *plpgsqlBuilder) makeContinuation(conName string) continuation {
 		def: &memo.UDFDefinition{
 			Params:            params,
 			Name:              b.makeIdentifier(conName),
-			Typ:               b.returnType,
+			Typ:               b.resultType(),
 			CalledOnNullInput: true,
 			BlockState:        b.block().state,
 			RoutineType:       tree.UDFRoutine,
@@ -1666,6 +2846,38 @@ func (b *plpgsqlBuilder) appendBodyStmt(con *continuation, bodyScope *scope) {
 	con.def.BodyProps = append(con.def.BodyProps, bodyScope.makePhysicalProps())
 }
 
+// appendProfiledBodyStmt is like appendBodyStmt, but it also records the
+// PL/pgSQL statement from which the body statement was built, so that execution
+// statistics can be collected for it. If stmtInfo is nil, it is equivalent to
+// appendBodyStmt.
+func (b *plpgsqlBuilder) appendProfiledBodyStmt(
+	con *continuation, bodyScope *scope, stmtInfo *tree.RoutineStmtInfo,
+) {
+	b.appendBodyStmt(con, bodyScope)
+	if stmtInfo == nil {
+		return
+	}
+	// BodyStmtInfos is only populated up to the last profiled body statement,
+	// so pad it with nil entries for the preceding body statements.
+	for len(con.def.BodyStmtInfos) < len(con.def.Body)-1 {
+		con.def.BodyStmtInfos = append(con.def.BodyStmtInfos, nil)
+	}
+	con.def.BodyStmtInfos = append(con.def.BodyStmtInfos, stmtInfo)
+}
+
+// callContinuationAfterStmt is like callContinuation, but it is used when the
+// continuation is called at the end of the body statement built for the given
+// PL/pgSQL statement. The execution of the statement is considered finished
+// once the continuation starts, so that the time spent in the remaining
+// statements is not attributed to it. If stmtInfo is nil, it is equivalent to
+// callContinuation.
+func (b *plpgsqlBuilder) callContinuationAfterStmt(
+	con *continuation, s *scope, stmtInfo *tree.RoutineStmtInfo,
+) *scope {
+	con.def.CalledAfterStmt = stmtInfo
+	return b.callContinuation(con, s)
+}
+
 // appendPlpgSQLStmts builds the given PLpgSQL statements into a relational
 // expression and appends it to the given continuation routine's body statements
 // list.
@@ -1688,7 +2900,7 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 
 	returnColName := scopeColName("").WithMetadataName(con.def.Name)
 	returnScope := s.push()
-	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, call)
+	b.ob.synthesizeColumn(returnScope, returnColName, b.resultType(), nil /* expr */, call)
 	b.ob.constructProjectForScope(s, returnScope)
 	return returnScope
 }
@@ -1697,7 +2909,11 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 // continuation in a TxnControlExpr that will commit or abort the current
 // transaction before resuming execution with the continuation.
 func (b *plpgsqlBuilder) callContinuationWithTxnOp(
-	con *continuation, s *scope, txnOp tree.StoredProcTxnOp, txnModes tree.TransactionModes,
+	con *continuation,
+	s *scope,
+	txnOp tree.StoredProcTxnOp,
+	txnModes tree.TransactionModes,
+	chain bool,
 ) *scope {
 	if con == nil {
 		panic(errors.AssertionFailedf("nil continuation with transaction control"))
@@ -1708,7 +2924,9 @@ func (b *plpgsqlBuilder) callContinuationWithTxnOp(
 	b.addBarrier(s)
 	returnScope := s.push()
 	args := b.makeContinuationArgs(con, s)
-	txnPrivate := &memo.TxnControlPrivate{TxnOp: txnOp, TxnModes: txnModes, Def: con.def}
+	txnPrivate := &memo.TxnControlPrivate{
+		TxnOp: txnOp, TxnModes: txnModes, Chain: chain, Def: con.def,
+	}
 	if b.outScope != nil {
 		txnPrivate.Props = b.outScope.makePhysicalProps()
 		txnPrivate.OutCols = b.outScope.colList()
@@ -1720,7 +2938,7 @@ func (b *plpgsqlBuilder) callContinuationWithTxnOp(
 	}
 	txnControlExpr := b.ob.factory.ConstructTxnControl(args, txnPrivate)
 	returnColName := scopeColName("").WithMetadataName(con.def.Name)
-	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, txnControlExpr)
+	b.ob.synthesizeColumn(returnScope, returnColName, b.resultType(), nil /* expr */, txnControlExpr)
 	b.ob.constructProjectForScope(s, returnScope)
 	return returnScope
 }
@@ -1775,7 +2993,7 @@ func (b *plpgsqlBuilder) addBarrierIfVolatile(s *scope, expr opt.ScalarExpr) {
 // addBarrier adds an optimization barrier to the given scope, in order to
 // prevent side effects from being duplicated, eliminated, or reordered.
 func (b *plpgsqlBuilder) addBarrier(s *scope) {
-	s.expr = b.ob.factory.ConstructBarrier(s.expr)
+	s.expr = b.ob.factory.ConstructBarrier(s.expr, &memo.BarrierPrivate{})
 }
 
 // buildPLpgSQLExpr parses and builds the given SQL expression into a ScalarExpr
@@ -1837,6 +3055,40 @@ func (b *plpgsqlBuilder) resolveVariableForAssign(name ast.Variable) *types.T {
 	panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
 }
 
+// makeFieldAssignValue returns an expression for the new value of a composite
+// variable after the given field has been assigned the given value, as in
+// "r.x := val". The remaining fields keep their current values.
+func (b *plpgsqlBuilder) makeFieldAssignValue(
+	name ast.Variable, field tree.Name, val ast.Expr,
+) ast.Expr {
+	typ := b.resolveVariableForAssign(name)
+	if typ.Family() != types.TupleFamily {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s.%s\" is not a known variable", name, field))
+	}
+	labels := typ.TupleLabels()
+	tuple := &tree.Tuple{
+		Exprs:  make(tree.Exprs, len(typ.TupleContents())),
+		Labels: labels,
+	}
+	found := false
+	for i := range tuple.Exprs {
+		if i < len(labels) && labels[i] == string(field) {
+			tuple.Exprs[i] = val
+			found = true
+			continue
+		}
+		tuple.Exprs[i] = &tree.ColumnAccessExpr{
+			Expr:     tree.NewUnresolvedName(string(name)),
+			ByIndex:  true,
+			ColIndex: i,
+		}
+	}
+	if !found {
+		panic(pgerror.Newf(pgcode.UndefinedColumn, "record \"%s\" has no field \"%s\"", name, field))
+	}
+	return tuple
+}
+
 func (b *plpgsqlBuilder) prependStmt(stmt ast.Statement, stmts []ast.Statement) []ast.Statement {
 	newStmts := make([]ast.Statement, 0, len(stmts)+1)
 	newStmts = append(newStmts, stmt)
@@ -1854,6 +3106,96 @@ func (b *plpgsqlBuilder) makeIdentifier(id string) string {
 	return fmt.Sprintf("%s_%d", id, b.identCounter)
 }
 
+// resultType returns the type of the result of the PL/pgSQL statements and
+// continuations. For a set-returning routine, this is an array of rows.
+func (b *plpgsqlBuilder) resultType() *types.T {
+	if b.setReturning {
+		return types.MakeArray(b.returnType)
+	}
+	return b.returnType
+}
+
+// isVariable returns true if a variable with the given name is in scope.
+func (b *plpgsqlBuilder) isVariable(name ast.Variable) bool {
+	for i := range b.blocks {
+		if _, ok := b.blocks[i].varTypes[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupVariableType returns the type of the variable with the given name, if
+// it is in scope. Unlike resolveVariableForAssign, constants are allowed.
+func (b *plpgsqlBuilder) lookupVariableType(name ast.Variable) (*types.T, bool) {
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if typ, ok := b.blocks[i].varTypes[name]; ok {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
+// isNotNullVar returns true if the variable with the given name was declared
+// NOT NULL.
+func (b *plpgsqlBuilder) isNotNullVar(name ast.Variable) bool {
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		block := &b.blocks[i]
+		if _, ok := block.varTypes[name]; ok {
+			_, notNull := block.notNull[name]
+			return notNull
+		}
+	}
+	return false
+}
+
+// makeContext returns a description of the given line in the routine, in the
+// format used by GET DIAGNOSTICS ... PG_CONTEXT. Note that only the current
+// routine is described, rather than the entire call stack.
+func (b *plpgsqlBuilder) makeContext(lineNo int, stmtName string) string {
+	info := tree.RoutineStmtInfo{
+		RoutineSignature: b.routineSignature(),
+		LineNo:           lineNo,
+		StmtType:         stmtName,
+	}
+	return info.Context()
+}
+
+// routineSignature returns the name of the routine followed by the types of its
+// input parameters, e.g. "f(bigint,text)".
+func (b *plpgsqlBuilder) routineSignature() string {
+	var sb strings.Builder
+	sb.WriteString(b.routineName)
+	sb.WriteByte('(')
+	for i, typ := range b.inParamTypes {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(typ.SQLStandardName())
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// makeStmtInfo returns the information used to collect execution statistics
+// for the given PL/pgSQL statement, which is described by stmtType in the
+// format used by PG_CONTEXT. It returns nil if the statement was not produced
+// by the parser, e.g. because it was synthesized while building another
+// statement.
+func (b *plpgsqlBuilder) makeStmtInfo(stmt ast.Statement, stmtType string) *tree.RoutineStmtInfo {
+	if stmt.GetStmtID() == 0 {
+		return nil
+	}
+	return &tree.RoutineStmtInfo{
+		RoutineSignature: b.routineSignature(),
+		RoutineID:        b.routineID,
+		RoutineVersion:   b.routineVersion,
+		StmtID:           stmt.GetStmtID(),
+		LineNo:           stmt.GetLineNo(),
+		StmtType:         stmtType,
+	}
+}
+
 func (b *plpgsqlBuilder) hasOutParam() bool {
 	return len(b.outParams) > 0
 }
@@ -2037,7 +3379,8 @@ func (b *plpgsqlBuilder) variableCount() int {
 
 // recordTypeVisitor is used to infer the concrete return type for a
 // record-returning PLpgSQL routine. It visits each return statement and checks
-// that the types of all returned expressions are either identical or UNKNOWN.
+// that the types of all returned expressions can be combined into a single
+// type (see mergeRecordTypes).
 type recordTypeVisitor struct {
 	ctx     context.Context
 	semaCtx *tree.SemaContext
@@ -2063,32 +3406,80 @@ func (r *recordTypeVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, re
 			// that reference those variables.
 			return t, false
 		}
-	case *ast.Return:
-		desired := types.Any
-		if r.typ.Family() != types.UnknownFamily {
-			desired = r.typ
-		}
-		expr, _ := tree.WalkExpr(r.s, t.Expr)
-		typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
-		if err != nil {
-			panic(err)
-		}
-		typ := typedExpr.ResolvedType()
-		if typ.Family() == types.UnknownFamily {
-			return stmt, false
-		}
-		if typ.Family() != types.TupleFamily {
-			panic(nonCompositeErr)
-		}
-		if r.typ.Family() == types.UnknownFamily {
-			r.typ = typ
-			return stmt, false
+	case *ast.ForLoop:
+		if _, ok := t.Control.(*ast.IntForLoopControl); ok {
+			// The loop implicitly declares its variable, so the loop body can't be
+			// type-checked yet.
+			return t, false
 		}
-		if !typ.Identical(r.typ) {
+	case *ast.Return:
+		r.visitReturnExpr(t.Expr)
+	case *ast.ReturnNext:
+		r.visitReturnExpr(t.Expr)
+	}
+	return stmt, true
+}
+
+// visitReturnExpr checks the type of an expression returned by a RETURN or
+// RETURN NEXT statement, and uses it to infer the concrete return type.
+func (r *recordTypeVisitor) visitReturnExpr(expr ast.Expr) {
+	if expr == nil {
+		return
+	}
+	desired := types.Any
+	if r.typ.Family() != types.UnknownFamily {
+		desired = r.typ
+	}
+	expr, _ = tree.WalkExpr(r.s, expr)
+	typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
+	if err != nil {
+		panic(err)
+	}
+	typ := typedExpr.ResolvedType()
+	if typ.Family() == types.UnknownFamily {
+		return
+	}
+	if typ.Family() != types.TupleFamily {
+		panic(nonCompositeErr)
+	}
+	if r.typ.Family() == types.UnknownFamily {
+		r.typ = typ
+		return
+	}
+	if !typ.Identical(r.typ) {
+		merged, ok := mergeRecordTypes(r.typ, typ)
+		if !ok {
 			panic(recordReturnErr)
 		}
+		r.typ = merged
 	}
-	return stmt, true
+}
+
+// mergeRecordTypes attempts to combine two tuple types that are returned by a
+// RECORD-returning routine. This is possible if the tuples have the same number
+// of elements, and each pair of elements is either identical, or one of them is
+// UNKNOWN (e.g. a NULL value). The labels of the first type are kept.
+func mergeRecordTypes(left, right *types.T) (_ *types.T, ok bool) {
+	leftContents, rightContents := left.TupleContents(), right.TupleContents()
+	if len(leftContents) != len(rightContents) {
+		return nil, false
+	}
+	contents := make([]*types.T, len(leftContents))
+	for i := range leftContents {
+		switch {
+		case leftContents[i].Identical(rightContents[i]),
+			rightContents[i].Family() == types.UnknownFamily:
+			contents[i] = leftContents[i]
+		case leftContents[i].Family() == types.UnknownFamily:
+			contents[i] = rightContents[i]
+		default:
+			return nil, false
+		}
+	}
+	if left.TupleLabels() == nil {
+		return types.MakeTuple(contents), true
+	}
+	return types.MakeLabeledTuple(contents, left.TupleLabels()), true
 }
 
 // transactionControlVisitor is used to check for COMMIT or ROLLBACK statements
@@ -2109,28 +3500,358 @@ func (tc *transactionControlVisitor) Visit(
 	return stmt, !tc.foundTxnControlStatement
 }
 
+// recordVarVisitor is used to infer the concrete type of a RECORD variable.
+// The type is determined by the first statement in the declaring block that
+// assigns a row to the variable: an assignment, a SELECT ... INTO statement,
+// a data-modifying statement with RETURNING ... INTO, an EXECUTE of a constant
+// query string, a FETCH from a cursor with a known query, or a query FOR loop.
+// Nested blocks are not visited, since their variable declarations have not
+// been built yet.
+type recordVarVisitor struct {
+	b     *plpgsqlBuilder
+	s     *scope
+	name  ast.Variable
+	block *ast.Block
+	typ   *types.T
+
+	// openQueries maps each cursor variable that has been opened with
+	// OPEN ... FOR <query> to its query, in order to determine the structure of
+	// the rows fetched from the cursor.
+	openQueries map[ast.Variable]tree.Statement
+}
+
+var _ ast.StatementVisitor = &recordVarVisitor{}
+
+func (r *recordVarVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, recurse bool) {
+	if r.typ != nil {
+		return stmt, false
+	}
+	switch t := stmt.(type) {
+	case *ast.Block:
+		if t != r.block {
+			return t, false
+		}
+	case *ast.Assignment:
+		if t.Var == r.name && t.Field == "" {
+			r.visitAssignedExpr(t.Value)
+		}
+	case *ast.Execute:
+		if len(t.Target) == 1 && t.Target[0] == r.name {
+			r.visitQuery(t.SqlStmt)
+		}
+	case *ast.DynamicExecute:
+		if len(t.Target) == 1 && t.Target[0] == r.name {
+			// Only a constant query string determines the structure of the rows
+			// at build time.
+			if str, ok := t.Query.(*tree.StrVal); ok {
+				if parsed, err := parser.ParseOne(str.RawString()); err == nil {
+					r.visitQuery(parsed.AST)
+				}
+			}
+		}
+	case *ast.Open:
+		if t.Query != nil {
+			if r.openQueries == nil {
+				r.openQueries = make(map[ast.Variable]tree.Statement)
+			}
+			r.openQueries[t.CurVar] = t.Query
+		}
+	case *ast.Fetch:
+		if !t.IsMove && len(t.Target) == 1 && t.Target[0] == r.name {
+			if query := r.cursorQuery(ast.Variable(t.Cursor.Name)); query != nil {
+				r.visitQuery(query)
+			}
+		}
+	case *ast.ForLoop:
+		if c, ok := t.Control.(*ast.QueryForLoopControl); ok {
+			if len(t.Target) == 1 && t.Target[0] == r.name {
+				r.visitQuery(c.Query)
+			}
+		}
+	}
+	return stmt, r.typ == nil
+}
+
+// cursorQuery returns the query of the given cursor variable, either from an
+// earlier OPEN ... FOR statement in the block, or from the declaration of a
+// bound cursor. It returns nil if the query is unknown.
+func (r *recordVarVisitor) cursorQuery(name ast.Variable) tree.Statement {
+	if query, ok := r.openQueries[name]; ok {
+		return query
+	}
+	for _, decl := range r.block.Decls {
+		if c, ok := decl.(*ast.CursorDeclaration); ok && c.Name == name {
+			return c.Query
+		}
+	}
+	for i := len(r.b.blocks) - 1; i >= 0; i-- {
+		if c, ok := r.b.blocks[i].cursors[name]; ok {
+			return c.Query
+		}
+	}
+	return nil
+}
+
+// visitAssignedExpr infers the type of the RECORD variable from an expression
+// that is assigned to it. NULL values do not determine the type.
+func (r *recordVarVisitor) visitAssignedExpr(expr ast.Expr) {
+	typ := r.typeOf(func() *types.T {
+		expr, _ := tree.WalkExpr(r.s, expr)
+		typedExpr, err := expr.TypeCheck(r.b.ob.ctx, r.b.ob.semaCtx, types.AnyTuple)
+		if err != nil {
+			panic(err)
+		}
+		return typedExpr.ResolvedType()
+	})
+	if typ == nil || typ.Family() == types.UnknownFamily {
+		return
+	}
+	if typ.Family() != types.TupleFamily {
+		panic(nonCompositeTargetErr)
+	}
+	r.typ = typ
+}
+
+// visitQuery infers the type of the RECORD variable from the columns of a
+// query whose rows are assigned to it. Building a data-modifying statement is
+// not free of side effects, so the type of its RETURNING clause is determined
+// from an equivalent SELECT over the target table (see returningQuery).
+func (r *recordVarVisitor) visitQuery(stmt tree.Statement) {
+	if _, ok := stmt.(*tree.Select); !ok {
+		if stmt = returningQuery(stmt); stmt == nil {
+			return
+		}
+	}
+	r.typ = r.typeOf(func() *types.T {
+		stmtScope := r.b.ob.buildStmtAtRootWithScope(stmt, nil /* desiredTypes */, r.s)
+		typs := make([]*types.T, len(stmtScope.cols))
+		labels := make([]string, len(stmtScope.cols))
+		for i := range stmtScope.cols {
+			typs[i] = stmtScope.cols[i].typ
+			labels[i] = string(stmtScope.cols[i].name.ReferenceName())
+		}
+		return types.MakeLabeledTuple(typs, labels)
+	})
+}
+
+// returningQuery returns a SELECT statement that produces the same columns as
+// the RETURNING clause of the given data-modifying statement. It returns nil if
+// the statement is not a data-modifying statement with a RETURNING clause.
+func returningQuery(stmt tree.Statement) tree.Statement {
+	var with *tree.With
+	var returning tree.ReturningClause
+	var from tree.TableExprs
+	switch t := stmt.(type) {
+	case *tree.Insert:
+		with, returning, from = t.With, t.Returning, tree.TableExprs{t.Table}
+	case *tree.Update:
+		with, returning = t.With, t.Returning
+		from = append(tree.TableExprs{t.Table}, t.From...)
+	case *tree.Delete:
+		with, returning = t.With, t.Returning
+		from = append(tree.TableExprs{t.Table}, t.Using...)
+	default:
+		return nil
+	}
+	exprs, ok := returning.(*tree.ReturningExprs)
+	if !ok {
+		return nil
+	}
+	return &tree.Select{
+		With: with,
+		Select: &tree.SelectClause{
+			Exprs: tree.SelectExprs(*exprs),
+			From:  tree.From{Tables: from},
+		},
+	}
+}
+
+// typeOf calls the given function to determine a type. It returns nil if the
+// function fails, which happens if the statement references a variable that
+// has not been declared yet. In that case the statement is skipped; any error
+// will be reported when the statement is built.
+func (r *recordVarVisitor) typeOf(fn func() *types.T) (typ *types.T) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			if ok, _ := errorutil.ShouldCatch(rec); !ok {
+				panic(rec)
+			}
+			typ = nil
+		}
+	}()
+	return fn()
+}
+
+// aliasVisitor replaces references to the names declared with ALIAS FOR with
+// references to the aliased variables. This includes the targets of PL/pgSQL
+// statements, as well as the SQL statements and expressions they contain.
+type aliasVisitor struct {
+	aliases     map[ast.Variable]ast.Variable
+	block       *ast.Block
+	exprVisitor utils.SQLStmtVisitor
+}
+
+var _ ast.StatementVisitor = &aliasVisitor{}
+
+func newAliasVisitor(block *ast.Block, aliases map[ast.Variable]ast.Variable) *aliasVisitor {
+	v := &aliasVisitor{aliases: aliases, block: block}
+	v.exprVisitor.Fn = func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if name, ok := expr.(*tree.UnresolvedName); ok && !name.Star {
+			switch name.NumParts {
+			case 1:
+				if target, ok := aliases[ast.Variable(name.Parts[0])]; ok {
+					return false, tree.NewUnresolvedName(string(target)), nil
+				}
+			case 2:
+				// This may be an access of a field of the aliased variable, as in
+				// "alias.x".
+				if target, ok := aliases[ast.Variable(name.Parts[1])]; ok {
+					return false, tree.NewUnresolvedName(string(target), name.Parts[0]), nil
+				}
+			}
+		}
+		return true, expr, nil
+	}
+	return v
+}
+
+func (v *aliasVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, recurse bool) {
+	if t, ok := stmt.(*ast.Block); ok && t != v.block {
+		for _, decl := range t.Decls {
+			if dec, ok := decl.(*ast.Declaration); ok {
+				if _, ok := v.aliases[dec.Var]; ok {
+					// The nested block declares a variable with the same name as the
+					// alias, so references within the block are to that variable.
+					return stmt, false
+				}
+			}
+		}
+	}
+	newStmt, recurse = v.exprVisitor.Visit(stmt)
+	if v.exprVisitor.Err != nil {
+		panic(v.exprVisitor.Err)
+	}
+	switch t := newStmt.(type) {
+	case *ast.Assignment:
+		if name, ok := v.aliases[t.Var]; ok {
+			cpy := t.CopyNode()
+			cpy.Var = name
+			newStmt = cpy
+		}
+	case *ast.Execute:
+		if target, ok := v.renameTarget(t.Target); ok {
+			cpy := t.CopyNode()
+			cpy.Target = target
+			newStmt = cpy
+		}
+	case *ast.DynamicExecute:
+		if target, ok := v.renameTarget(t.Target); ok {
+			cpy := t.CopyNode()
+			cpy.Target = target
+			newStmt = cpy
+		}
+	case *ast.Fetch:
+		target, ok := v.renameTarget(t.Target)
+		cursor, renameCursor := v.aliases[ast.Variable(t.Cursor.Name)]
+		if ok || renameCursor {
+			cpy := t.CopyNode()
+			cpy.Target = target
+			if renameCursor {
+				cpy.Cursor.Name = tree.Name(cursor)
+			}
+			newStmt = cpy
+		}
+	case *ast.Open:
+		if name, ok := v.aliases[t.CurVar]; ok {
+			cpy := t.CopyNode()
+			cpy.CurVar = name
+			newStmt = cpy
+		}
+	case *ast.Close:
+		if name, ok := v.aliases[t.CurVar]; ok {
+			cpy := t.CopyNode()
+			cpy.CurVar = name
+			newStmt = cpy
+		}
+	case *ast.ForLoop:
+		if target, ok := v.renameTarget(t.Target); ok {
+			cpy := t.CopyNode()
+			cpy.Target = target
+			newStmt = cpy
+		}
+	case *ast.ForEachArray:
+		if target, ok := v.renameTarget(t.Target); ok {
+			cpy := t.CopyNode()
+			cpy.Target = target
+			newStmt = cpy
+		}
+	case *ast.GetDiagnostics:
+		for i, item := range t.DiagItems {
+			if name, ok := v.aliases[item.Target]; ok {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+					t = newStmt.(*ast.GetDiagnostics)
+				}
+				t.DiagItems[i] = &ast.GetDiagnosticsItem{Kind: item.Kind, Target: name}
+			}
+		}
+	}
+	return newStmt, recurse
+}
+
+// renameTarget returns a copy of the given target with aliases replaced by
+// the aliased variables. It returns false if the target has no aliases.
+func (v *aliasVisitor) renameTarget(target []ast.Variable) ([]ast.Variable, bool) {
+	var newTarget []ast.Variable
+	for i, name := range target {
+		if aliased, ok := v.aliases[name]; ok {
+			if newTarget == nil {
+				newTarget = append([]ast.Variable(nil), target...)
+			}
+			newTarget[i] = aliased
+		}
+	}
+	if newTarget == nil {
+		return target, false
+	}
+	return newTarget, true
+}
+
+// rowCountVisitor is used to check for GET DIAGNOSTICS statements that
+// retrieve the ROW_COUNT item, so that the row count can be tracked.
+type rowCountVisitor struct {
+	foundRowCount bool
+}
+
+var _ ast.StatementVisitor = &rowCountVisitor{}
+
+func (rc *rowCountVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, recurse bool) {
+	if t, ok := stmt.(*ast.GetDiagnostics); ok {
+		for _, item := range t.DiagItems {
+			if item.Kind == ast.GetDiagnosticsRowCount {
+				rc.foundRowCount = true
+			}
+		}
+	}
+	return stmt, !rc.foundRowCount
+}
+
 var (
 	unsupportedPLStmtErr = unimplemented.New("unimplemented PL/pgSQL statement",
 		"attempted to use a PL/pgSQL statement that is not yet supported",
 	)
-	notNullVarErr = unimplemented.NewWithIssueDetail(105243, "not null variable",
-		"not-null PL/pgSQL variables are not yet supported",
+	notNullRecordVarErr = pgerror.New(pgcode.Syntax,
+		"row or record variable cannot be NOT NULL",
 	)
 	collatedVarErr = unimplemented.NewWithIssueDetail(105245, "variable collation",
 		"collation for PL/pgSQL variables is not yet supported",
 	)
-	recordVarErr = unimplemented.NewWithIssueDetail(114874, "RECORD variable",
-		"RECORD type for PL/pgSQL variables is not yet supported",
-	)
 	dupIntoErr = unimplemented.New("duplicate INTO target",
 		"assigning to a variable more than once in the same INTO statement is not supported",
 	)
 	scrollableCursorErr = unimplemented.NewWithIssue(77102,
 		"DECLARE SCROLL CURSOR",
 	)
-	retryableErrErr = unimplemented.NewWithIssue(111446,
-		"catching a Transaction Retry error in a PLpgSQL EXCEPTION block is not yet implemented",
-	)
 	recordReturnErr = errors.WithHint(
 		unimplemented.NewWithIssue(115384,
 			"returning different types from a RECORD-returning function is not yet supported",
@@ -2169,6 +3890,39 @@ var (
 	emptyReturnErr = pgerror.New(pgcode.Syntax,
 		"missing expression at or near \"RETURN;\"",
 	)
+	returnWithSetofErr = errors.WithHint(
+		pgerror.New(pgcode.DatatypeMismatch,
+			"RETURN cannot have a parameter in function returning set",
+		),
+		"Use RETURN NEXT or RETURN QUERY.",
+	)
+	returnNextNonSetofErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot use RETURN NEXT in a non-SETOF function",
+	)
+	returnNextWithOUTParameterErr = pgerror.New(pgcode.DatatypeMismatch,
+		"RETURN NEXT cannot have a parameter in function with OUT parameters",
+	)
+	emptyReturnNextErr = pgerror.New(pgcode.Syntax,
+		"RETURN NEXT must have a parameter",
+	)
+	returnQueryNonSetofErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot use RETURN QUERY in a non-SETOF function",
+	)
+	returnQueryStructureErr = pgerror.New(pgcode.DatatypeMismatch,
+		"structure of query does not match function result type",
+	)
+	intForLoopTargetErr = pgerror.New(pgcode.Syntax,
+		"integer FOR loop must have only one target variable",
+	)
+	nonCompositeTargetErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot assign non-composite value to a row variable",
+	)
+	foreachNullErr = pgerror.New(pgcode.NullValueNotAllowed,
+		"FOREACH expression must not be null",
+	)
+	foreachSliceErr = unimplemented.New("FOREACH SLICE",
+		"FOREACH with a SLICE clause is not yet supported",
+	)
 	txnControlWithExceptionErr = errors.WithDetail(
 		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
 		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a block with exception handlers",
@@ -2176,8 +3930,14 @@ var (
 	txnInUDFErr = errors.WithDetail(
 		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
 		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a user-defined function")
-	txnControlWithChainErr = unimplemented.NewWithIssue(119646,
-		"COMMIT or ROLLBACK with AND CHAIN syntax is not yet implemented",
+	getStackedDiagErr = unimplemented.New("GET STACKED DIAGNOSTICS",
+		"GET STACKED DIAGNOSTICS is not yet supported",
+	)
+	dynamicRowCountErr = unimplemented.New("ROW_COUNT after EXECUTE",
+		"GET DIAGNOSTICS ROW_COUNT is not yet supported in a routine with a dynamic EXECUTE statement",
+	)
+	fetchRowCountErr = unimplemented.New("ROW_COUNT after FETCH",
+		"GET DIAGNOSTICS ROW_COUNT is not yet supported in a routine with a FETCH or MOVE statement",
 	)
 	setTxnNotAfterControlStmtErr = errors.WithHint(
 		pgerror.New(pgcode.ActiveSQLTransaction, "SET TRANSACTION must be called before any query"),
@@ -26,7 +26,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -209,6 +208,9 @@ func (b *Builder) buildRoutine(
 	// the function. Return types like user defined return types may change
 	// since the function was first created.
 	rtyp := f.ResolvedType()
+	if rtyp.Family() == types.TriggerFamily {
+		panic(pgerror.New(pgcode.FeatureNotSupported, "trigger functions can only be called as triggers"))
+	}
 	if rtyp.UserDefined() {
 		funcReturnType, err := tree.ResolveType(b.ctx,
 			&tree.OIDTypeReference{OID: rtyp.Oid()}, b.semaCtx.TypeResolver)
@@ -268,6 +270,13 @@ func (b *Builder) buildRoutine(
 				colRefs,
 			))
 		}
+		if paramTypes, ok := o.Types.(tree.VariadicParamTypes); ok {
+			// Collect the trailing arguments into an array for the VARIADIC
+			// parameter.
+			n := len(paramTypes) - 1
+			varArgs := args[n:]
+			args = append(args[:n:n], b.factory.ConstructArray(varArgs, paramTypes[n].Typ))
+		}
 	}
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
@@ -281,10 +290,15 @@ func (b *Builder) buildRoutine(
 	var params opt.ColList
 	if o.Types.Length() > 0 {
 		// Add all input parameters to the scope.
-		paramTypes, ok := o.Types.(tree.ParamTypes)
-		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+		var paramTypes tree.ParamTypes
+		switch t := o.Types.(type) {
+		case tree.ParamTypes:
+			paramTypes = t
+		case tree.VariadicParamTypes:
+			// The VARIADIC parameter is an array in the body of the routine.
+			paramTypes = tree.ParamTypes(t)
+		default:
+			panic(errors.AssertionFailedf("unexpected parameter types of routine: %T", o.Types))
 		}
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
@@ -352,7 +366,7 @@ func (b *Builder) buildRoutine(
 				if i == len(stmts)-1 {
 					finishResolveType(stmtScope)
 					expr, physProps, isMultiColDataSource =
-						b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+						b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f.ResolvedType())
 				}
 				body[i] = expr
 				bodyProps[i] = physProps
@@ -387,12 +401,13 @@ func (b *Builder) buildRoutine(
 		var expr memo.RelExpr
 		var physProps *physical.Required
 		plBuilder := newPLpgSQLBuilder(
-			b, def.Name, stmt.AST.Label, colRefs, routineParams, rtyp, isProc, outScope,
+			b, def.Name, stmt.AST.Label, colRefs, routineParams, rtyp, isProc, isSetReturning, outScope,
 		)
+		plBuilder.routineID, plBuilder.routineVersion = o.Oid, o.Version
 		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		finishResolveType(stmtScope)
 		expr, physProps, isMultiColDataSource =
-			b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+			b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f.ResolvedType())
 		body = []memo.RelExpr{expr}
 		bodyProps = []*physical.Required{physProps}
 		if b.verboseTracing {
@@ -429,10 +444,9 @@ func (b *Builder) buildRoutine(
 // expanding a tuple into multiple columns, or combining multiple columns into
 // a tuple.
 func (b *Builder) finishBuildLastStmt(
-	stmtScope *scope, bodyScope *scope, isSetReturning bool, f *tree.FuncExpr,
+	stmtScope *scope, bodyScope *scope, isSetReturning bool, rtyp *types.T,
 ) (expr memo.RelExpr, physProps *physical.Required, isMultiColDataSource bool) {
 	expr, physProps = stmtScope.expr, stmtScope.makePhysicalProps()
-	rtyp := f.ResolvedType()
 
 	// Add a LIMIT 1 to the last statement if the UDF is not
 	// set-returning. This is valid because any other rows after the
@@ -0,0 +1,175 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// applicablePolicies returns the ordinals of the row-level security policies
+// of the given table that apply to the current user and the given command, and
+// whether row-level security is enforced at all (see cat.ApplicablePolicies).
+// The result is recorded in the metadata, since it depends on the current
+// user.
+func (b *Builder) applicablePolicies(
+	tab cat.Table, cmd tree.PolicyCommand,
+) (ords []int, enforced bool) {
+	// Row-level security is applied when a view or routine is used, not when it
+	// is defined.
+	if !tab.IsRowLevelSecurityEnabled() || b.insideViewDef || b.insideFuncDef {
+		return nil, false
+	}
+	ords, enforced, err := cat.ApplicablePolicies(b.ctx, b.catalog, tab, cmd)
+	if err != nil {
+		panic(err)
+	}
+	b.factory.Metadata().AddRowLevelSecurityDependency(tab, cmd, ords, enforced)
+	return ords, enforced
+}
+
+// addRowLevelSecurityFilter filters the rows of the table scanned by the
+// expression in the given scope, so that only the rows allowed by the USING
+// expressions of the applicable row-level security policies remain.
+//
+// The filtered scan is wrapped in a security barrier, so that filters of the
+// query cannot be evaluated on the hidden rows. Otherwise, a filter such as
+// 1/(secret-42) = 0 could reveal them through an error or other side effect.
+// Only leakproof filters are pushed into the barrier by the optimizer.
+func (b *Builder) addRowLevelSecurityFilter(tab cat.Table, s *scope, cmd tree.PolicyCommand) {
+	ords, enforced := b.applicablePolicies(tab, cmd)
+	if !enforced {
+		return
+	}
+	filter := b.buildPolicyExpr(tab, ords, s, cat.Policy.UsingExpr)
+	s.expr = b.factory.ConstructSelect(
+		s.expr, memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	)
+	s.expr = b.factory.ConstructBarrier(s.expr, &memo.BarrierPrivate{LeakproofPermeable: true})
+}
+
+// buildPolicyExpr builds the expression that a row must satisfy according to
+// the given row-level security policies. exprFn returns the SQL text of the
+// policy expression to use, or the empty string if the policy does not
+// restrict the rows. Permissive policies are combined using OR and restrictive
+// policies using AND. If no permissive policy has an expression, no row
+// satisfies the result.
+func (b *Builder) buildPolicyExpr(
+	tab cat.Table, ords []int, s *scope, exprFn func(cat.Policy) string,
+) opt.ScalarExpr {
+	var permissive, restrictive opt.ScalarExpr
+	for _, ord := range ords {
+		policy := tab.Policy(ord)
+		sql := exprFn(policy)
+		if sql == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(sql)
+		if err != nil {
+			panic(err)
+		}
+		texpr := s.resolveAndRequireType(expr, types.Bool)
+		scalar := b.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		switch {
+		case policy.IsRestrictive() && restrictive == nil:
+			restrictive = scalar
+		case policy.IsRestrictive():
+			restrictive = b.factory.ConstructAnd(restrictive, scalar)
+		case permissive == nil:
+			permissive = scalar
+		default:
+			permissive = b.factory.ConstructOr(permissive, scalar)
+		}
+	}
+	if permissive == nil {
+		return memo.FalseSingleton
+	}
+	if restrictive != nil {
+		return b.factory.ConstructAnd(permissive, restrictive)
+	}
+	return permissive
+}
+
+// policyCheckExpr returns the SQL text of the expression that new rows must
+// satisfy according to the given policy. The USING expression is used if the
+// policy has no WITH CHECK expression, as in Postgres.
+func policyCheckExpr(policy cat.Policy) string {
+	if expr := policy.WithCheckExpr(); expr != "" {
+		return expr
+	}
+	return policy.UsingExpr()
+}
+
+// checkRowLevelSecuritySupported panics with an unimplemented error if
+// row-level security is enforced on the given table. It is used by statements
+// that do not support row-level security yet.
+func (b *Builder) checkRowLevelSecuritySupported(tab cat.Table, stmt string) {
+	if _, enforced := b.applicablePolicies(tab, tree.PolicyCommandAll); enforced {
+		panic(unimplemented.Newf(stmt, "%s is not supported on tables with row-level security", stmt))
+	}
+}
+
+// addRowLevelSecurityChecks projects a column that raises an error for any
+// new row that does not satisfy the WITH CHECK expressions of the row-level
+// security policies that apply to the given command. The projection is
+// wrapped in an optimization barrier so that it is not pruned.
+func (mb *mutationBuilder) addRowLevelSecurityChecks(cmd tree.PolicyCommand) {
+	ords, enforced := mb.b.applicablePolicies(mb.tab, cmd)
+	if !enforced {
+		return
+	}
+	f := mb.b.factory
+	check := mb.b.buildPolicyExpr(mb.tab, ords, mb.outScope, policyCheckExpr)
+
+	const forceErrorFnName = "crdb_internal.force_error"
+	props, overloads := builtinsregistry.GetBuiltinProperties(forceErrorFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", forceErrorFnName))
+	}
+	msg := fmt.Sprintf("new row violates row-level security policy for table %q", mb.tab.Name())
+	errorFn := f.ConstructFunction(
+		memo.ScalarListExpr{
+			f.ConstructConstVal(tree.NewDString(pgcode.InsufficientPrivilege.String()), types.String),
+			f.ConstructConstVal(tree.NewDString(msg), types.String),
+		},
+		&memo.FunctionPrivate{
+			Name:       forceErrorFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	caseExpr := f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{
+			f.ConstructWhen(f.ConstructIsNot(check, memo.TrueSingleton), errorFn),
+		},
+		f.ConstructNull(types.Int),
+	)
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	colName := scopeColName("").WithMetadataName("rls_check")
+	mb.b.synthesizeColumn(projectionsScope, colName, types.Int, nil /* expr */, caseExpr)
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	projectionsScope.expr = f.ConstructBarrier(projectionsScope.expr, &memo.BarrierPrivate{})
+	mb.outScope = projectionsScope
+}
@@ -130,6 +130,7 @@ const (
 	exprKindHaving
 	exprKindLateralJoin
 	exprKindLimit
+	exprKindMergeWhen
 	exprKindOffset
 	exprKindOn
 	exprKindOrderBy
@@ -153,6 +154,7 @@ var exprKindName = [...]string{
 	exprKindHaving:            "HAVING",
 	exprKindLateralJoin:       "LATERAL JOIN",
 	exprKindLimit:             "LIMIT",
+	exprKindMergeWhen:         "MERGE WHEN",
 	exprKindOffset:            "OFFSET",
 	exprKindOn:                "ON",
 	exprKindOrderBy:           "ORDER BY",
@@ -1031,6 +1033,13 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.ColumnItem:
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
+			if s.builder.insideUDF || s.builder.insideFuncDef {
+				// Within a routine, r.x may refer to the field x of a composite
+				// variable or parameter r.
+				if expr := s.resolveRoutineVarField(t); expr != nil {
+					return true, expr
+				}
+			}
 			// It may be a reference to a table, e.g. SELECT tbl FROM tbl.
 			// Attempt to resolve as a TupleStar.
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -1092,6 +1101,9 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			break
 		}
 
+	case *tree.GroupingExpr:
+		expr = s.replaceGrouping(t)
+
 	case *tree.ArrayFlatten:
 		if sub, ok := t.Subquery.(*tree.Subquery); ok {
 			// Copy the ArrayFlatten expression so that the tree isn't mutated.
@@ -1133,6 +1145,35 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	return true, expr
 }
 
+// resolveRoutineVarField attempts to resolve a column item of the form r.x as
+// an access of the field x of the composite routine variable or parameter r. It
+// returns nil if r is not such a variable.
+func (s *scope) resolveRoutineVarField(t *tree.ColumnItem) tree.Expr {
+	if t.TableName == nil || t.TableName.NumParts != 1 {
+		return nil
+	}
+	varName := tree.Name(t.TableName.Parts[0])
+	colI, err := colinfo.ResolveColumnItem(s.builder.ctx, s, &tree.ColumnItem{ColumnName: varName})
+	if err != nil {
+		return nil
+	}
+	col := colI.(*scopeColumn)
+	if col.table.ObjectName != "" || col.typ.Family() != types.TupleFamily {
+		return nil
+	}
+	for _, label := range col.typ.TupleLabels() {
+		if label == string(t.ColumnName) {
+			return &tree.ColumnAccessExpr{
+				Expr:    tree.NewUnresolvedName(string(varName)),
+				ColName: t.ColumnName,
+			}
+		}
+	}
+	panic(pgerror.Newf(pgcode.UndefinedColumn,
+		"record \"%s\" has no field \"%s\"", varName, t.ColumnName,
+	))
+}
+
 // replaceSRF returns an srf struct that can be used to replace a raw SRF. When
 // this struct is encountered during the build process, it is replaced with a
 // reference to the column returned by the SRF (if the SRF returns a single
@@ -1290,6 +1331,7 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	checkUserDefinedAggregateUsage(f)
 
 	private := memo.FunctionPrivate{
 		Name:       def.Name,
@@ -1300,6 +1342,46 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	return s.builder.buildAggregateFunction(f, &private, tempScope, s)
 }
 
+// replaceGrouping returns a groupingInfo that can be used to replace a
+// GROUPING function call. The groupingInfo is built once the grouping columns
+// of the query are known.
+func (s *scope) replaceGrouping(g *tree.GroupingExpr) tree.Expr {
+	props := &s.builder.semaCtx.Properties
+	if props.IsSet(tree.RejectNestedAggregates) {
+		panic(pgerror.New(pgcode.Grouping,
+			"aggregate function calls cannot contain grouping operations"))
+	}
+	if props.IsSet(tree.RejectAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", props.Context()))
+	}
+	switch s.context {
+	case exprKindWhere:
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", s.context.String()))
+
+	case exprKindOn:
+		panic(pgerror.New(pgcode.Grouping,
+			"grouping operations are not allowed in JOIN conditions"))
+	}
+	if len(g.Exprs) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called within a subquery
+	// context.
+	defer s.builder.semaCtx.Properties.Restore(s.builder.semaCtx.Properties)
+	s.builder.semaCtx.Properties.Require("GROUPING", tree.RejectSpecial)
+
+	args := make([]tree.TypedExpr, len(g.Exprs))
+	for i, e := range g.Exprs {
+		args[i] = s.resolveType(e, types.Any)
+	}
+	return &groupingInfo{GroupingExpr: g, args: args}
+}
+
 func (s *scope) lookupWindowDef(name tree.Name) *tree.WindowDef {
 	for i := range s.windowDefs {
 		if s.windowDefs[i].Name == name {
@@ -1362,6 +1444,7 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	checkUserDefinedAggregateUsage(f)
 
 	// We will be performing type checking on expressions from PARTITION BY and
 	// ORDER BY clauses below, and we need the semantic context to know that we
@@ -163,7 +163,7 @@ func (b *Builder) buildDataSource(
 			if b.shouldBuildLockOp() {
 				locking = nil
 			}
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -173,6 +173,8 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 			)
+			b.addRowLevelSecurityFilter(t, outScope, tree.PolicyCommandSelect)
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -492,9 +494,11 @@ func (b *Builder) buildScanFromTableRef(
 	if b.shouldBuildLockOp() {
 		locking = nil
 	}
-	return b.buildScan(
+	outScope = b.buildScan(
 		tabMeta, ordinals, indexFlags, locking, inScope, false, /* disableNotVisibleIndex */
 	)
+	b.addRowLevelSecurityFilter(tab, outScope, tree.PolicyCommandSelect)
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -0,0 +1,532 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// Row-level triggers are built as part of the mutation that fires them:
+//
+//   - BEFORE triggers are built as projections over the mutation input. The
+//     trigger function is called with the NEW and OLD rows, and the row it
+//     returns replaces the values that are written. If the function returns
+//     NULL, the row is filtered out and not modified.
+//
+//   - AFTER triggers are built as "cascades" that run after the mutation, over
+//     the buffered mutation input. Their results are discarded.
+//
+// Triggers with the same action time fire in order of their names.
+
+// checkRowLevelTriggersSupported panics if the given table has row-level
+// triggers and the mutation cannot fire them.
+func checkRowLevelTriggersSupported(tab cat.Table, opName string) {
+	for i, n := 0, tab.TriggerCount(); i < n; i++ {
+		if tab.Trigger(i).ForEachRow() {
+			panic(unimplemented.NewWithIssueDetailf(28296, opName,
+				"%s is not supported on tables with row-level triggers", opName,
+			))
+		}
+	}
+}
+
+// rowLevelTriggers returns the ordinals of the row-level triggers on the target
+// table that fire at the given time for the given event.
+func (mb *mutationBuilder) rowLevelTriggers(
+	actionTime tree.TriggerActionTime, eventType tree.TriggerEventType,
+) []int {
+	var ords []int
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if !trig.ForEachRow() || trig.ActionTime() != actionTime {
+			continue
+		}
+		for j, m := 0, trig.EventCount(); j < m; j++ {
+			if event := trig.Event(j); event.EventType == eventType && mb.triggerEventMatches(event) {
+				ords = append(ords, i)
+				break
+			}
+		}
+	}
+	return ords
+}
+
+// triggerEventMatches returns true if the columns of an UPDATE OF event
+// include one of the target columns of the mutation. Events without columns
+// always match.
+func (mb *mutationBuilder) triggerEventMatches(event cat.TriggerEvent) bool {
+	if len(event.Columns) == 0 {
+		return true
+	}
+	for _, colID := range event.Columns {
+		for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+			if mb.tab.Column(i).ColID() == colID && mb.targetColSet.Contains(mb.tabID.ColumnID(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// triggerRowOrdinals returns the ordinals of the table columns that make up
+// the NEW and OLD rows passed to a trigger function. These are the public
+// columns that are not hidden, in table order.
+func triggerRowOrdinals(tab cat.Table) []int {
+	ords := make([]int, 0, tab.ColumnCount())
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// triggerRowType returns the tuple type of the NEW and OLD rows.
+func triggerRowType(tab cat.Table, ords []int) *types.T {
+	contents := make([]*types.T, len(ords))
+	labels := make([]string, len(ords))
+	for i, ord := range ords {
+		contents[i] = tab.Column(ord).DatumType()
+		labels[i] = string(tab.Column(ord).ColName())
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// buildTriggerRow constructs a tuple of the given columns, which map 1-to-1 to
+// the fields of rowType. Zero column IDs become NULL fields. If cols is nil,
+// the row itself is NULL.
+func (b *Builder) buildTriggerRow(cols opt.OptionalColList, rowType *types.T) opt.ScalarExpr {
+	if cols == nil {
+		return b.factory.ConstructNull(rowType)
+	}
+	elems := make(memo.ScalarListExpr, len(cols))
+	for i, col := range cols {
+		if col == 0 {
+			elems[i] = b.factory.ConstructNull(rowType.TupleContents()[i])
+		} else {
+			elems[i] = b.factory.ConstructVariable(col)
+		}
+	}
+	return b.factory.ConstructTuple(elems, rowType)
+}
+
+// buildTriggerWhen builds the WHEN condition of a trigger, if it has one. The
+// NEW and OLD columns map 1-to-1 to ords; they are nil if the event has no NEW
+// or OLD row. Returns nil if the trigger has no WHEN condition.
+func (b *Builder) buildTriggerWhen(
+	tab cat.Table, trig cat.Trigger, ords []int, newCols, oldCols opt.OptionalColList,
+) opt.ScalarExpr {
+	when := trig.WhenExpr()
+	if when == "" {
+		return nil
+	}
+	expr, err := parser.ParseExpr(when)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build a scope in which NEW.x and OLD.x resolve to the columns of the
+	// mutation input.
+	whenScope := b.allocScope()
+	addCols := func(tableName tree.Name, cols opt.OptionalColList) {
+		table := tree.MakeUnqualifiedTableName(tableName)
+		for i, col := range cols {
+			if col == 0 {
+				continue
+			}
+			tabCol := tab.Column(ords[i])
+			whenScope.cols = append(whenScope.cols, scopeColumn{
+				name:  scopeColName(tabCol.ColName()),
+				table: table,
+				typ:   tabCol.DatumType(),
+				id:    col,
+			})
+		}
+	}
+	addCols("new", newCols)
+	addCols("old", oldCols)
+
+	texpr := whenScope.resolveAndRequireType(expr, types.Bool)
+	return b.buildScalar(texpr, whenScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// buildTriggerFunctionCall builds a call to the function of a row-level
+// trigger. The PL/pgSQL body is built with NEW, OLD and the TG_ variables as
+// parameters, and the function returns a row of type rowType.
+func (b *Builder) buildTriggerFunctionCall(
+	tab cat.Table,
+	trig cat.Trigger,
+	eventType tree.TriggerEventType,
+	rowType *types.T,
+	newRow, oldRow opt.ScalarExpr,
+) opt.ScalarExpr {
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, trig.FuncOID())
+	if err != nil {
+		panic(err)
+	}
+	if o.Language != tree.RoutineLangPLpgSQL {
+		panic(errors.AssertionFailedf("trigger function %s is not a PL/pgSQL function", name))
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o, nil /* name */)
+
+	tabName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	tgArgv := tree.NewDArray(types.String)
+	for _, arg := range trig.FuncArgs() {
+		if err := tgArgv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+	constArg := func(d tree.Datum) opt.ScalarExpr {
+		return b.factory.ConstructConstVal(d, d.ResolvedType())
+	}
+	params := []struct {
+		name ast.Variable
+		arg  opt.ScalarExpr
+	}{
+		{name: "new", arg: newRow},
+		{name: "old", arg: oldRow},
+		{name: "tg_name", arg: constArg(tree.NewDString(string(trig.Name())))},
+		{name: "tg_when", arg: constArg(tree.NewDString(trig.ActionTime().String()))},
+		{name: "tg_level", arg: constArg(tree.NewDString("ROW"))},
+		{name: "tg_op", arg: constArg(tree.NewDString(eventType.String()))},
+		{name: "tg_relid", arg: constArg(tree.NewDOid(oid.Oid(tab.ID())))},
+		{name: "tg_table_name", arg: constArg(tree.NewDString(string(tab.Name())))},
+		{name: "tg_table_schema", arg: constArg(tree.NewDString(tabName.Schema()))},
+		{name: "tg_nargs", arg: constArg(tree.NewDInt(tree.DInt(len(trig.FuncArgs()))))},
+		{name: "tg_argv", arg: constArg(tgArgv)},
+	}
+
+	// The parameters are the only columns in scope for the function body.
+	bodyScope := b.allocScope()
+	args := make(memo.ScalarListExpr, len(params))
+	paramCols := make(opt.ColList, len(params))
+	routineParams := make([]routineParam, len(params))
+	for i := range params {
+		typ := params[i].arg.DataType()
+		col := b.synthesizeColumn(bodyScope, funcParamColName(params[i].name, i), typ, nil /* expr */, nil /* scalar */)
+		col.setParamOrd(i)
+		args[i] = params[i].arg
+		paramCols[i] = col.id
+		routineParams[i] = routineParam{name: params[i].name, typ: typ, class: tree.RoutineParamIn}
+	}
+
+	oldTrackingSchemaDeps := b.trackSchemaDeps
+	oldInsideUDF := b.insideUDF
+	oldInsideDataSource := b.insideDataSource
+	defer func() {
+		b.trackSchemaDeps = oldTrackingSchemaDeps
+		b.insideUDF = oldInsideUDF
+		b.insideDataSource = oldInsideDataSource
+	}()
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+	b.insideDataSource = false
+
+	stmt, err := plpgsql.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	plBuilder := newPLpgSQLBuilder(
+		b, name.Object(), stmt.AST.Label, nil /* colRefs */, routineParams, rowType,
+		false /* isProcedure */, false /* setReturning */, nil, /* outScope */
+	)
+	plBuilder.routineID, plBuilder.routineVersion = o.Oid, o.Version
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+	body, bodyProps, _ := b.finishBuildLastStmt(stmtScope, bodyScope, false /* isSetReturning */, rowType)
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+
+	return b.factory.ConstructUDFCall(
+		args,
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:       name.Object(),
+				Typ:        rowType,
+				Volatility: o.Volatility,
+				// The function must be called for a DELETE, where NEW is NULL, and for
+				// an INSERT, where OLD is NULL.
+				CalledOnNullInput: true,
+				RoutineType:       tree.UDFRoutine,
+				RoutineLang:       tree.RoutineLangPLpgSQL,
+				Body:              []memo.RelExpr{body},
+				BodyProps:         []*physical.Required{bodyProps},
+				BodyStmts:         bodyStmts,
+				Params:            paramCols,
+			},
+		},
+	)
+}
+
+// buildRowLevelBeforeTriggers wraps the mutation input in projections that
+// call the BEFORE row-level triggers that fire for the given event. For INSERT
+// and UPDATE, the values returned by each trigger become the new values of the
+// row. Rows for which a trigger returns NULL are filtered out.
+//
+// It must be called after the new values of non-computed columns have been
+// added to the input, and before computed columns are added, since computed
+// columns depend on the values returned by the triggers.
+func (mb *mutationBuilder) buildRowLevelBeforeTriggers(eventType tree.TriggerEventType) {
+	triggers := mb.rowLevelTriggers(tree.TriggerActionTimeBefore, eventType)
+	if len(triggers) == 0 {
+		return
+	}
+	f := mb.b.factory
+	ords := triggerRowOrdinals(mb.tab)
+	rowType := triggerRowType(mb.tab, ords)
+
+	for _, trigOrd := range triggers {
+		trig := mb.tab.Trigger(trigOrd)
+
+		var newCols, oldCols opt.OptionalColList
+		switch eventType {
+		case tree.TriggerEventInsert:
+			newCols = make(opt.OptionalColList, len(ords))
+			for i, ord := range ords {
+				if !mb.tab.Column(ord).IsComputed() {
+					newCols[i] = mb.insertColIDs[ord]
+				}
+			}
+		case tree.TriggerEventUpdate:
+			newCols = make(opt.OptionalColList, len(ords))
+			oldCols = make(opt.OptionalColList, len(ords))
+			for i, ord := range ords {
+				oldCols[i] = mb.fetchColIDs[ord]
+				newCols[i] = oldCols[i]
+				if mb.updateColIDs[ord] != 0 {
+					newCols[i] = mb.updateColIDs[ord]
+				}
+			}
+		case tree.TriggerEventDelete:
+			oldCols = make(opt.OptionalColList, len(ords))
+			for i, ord := range ords {
+				oldCols[i] = mb.fetchColIDs[ord]
+			}
+		}
+		newRow := mb.b.buildTriggerRow(newCols, rowType)
+		oldRow := mb.b.buildTriggerRow(oldCols, rowType)
+
+		call := mb.b.buildTriggerFunctionCall(mb.tab, trig, eventType, rowType, newRow, oldRow)
+		if cond := mb.b.buildTriggerWhen(mb.tab, trig, ords, newCols, oldCols); cond != nil {
+			// If the WHEN condition is not satisfied, the row is modified as if
+			// the trigger did not exist.
+			unchanged := newRow
+			if eventType == tree.TriggerEventDelete {
+				unchanged = oldRow
+			}
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(cond, call)},
+				unchanged,
+			)
+		}
+
+		// Project the result of the trigger function. The barrier prevents the
+		// function call from being inlined, which could evaluate it more than
+		// once for each row.
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		resultName := scopeColName("").WithMetadataName(fmt.Sprintf("%s_result", trig.Name()))
+		resultCol := mb.b.synthesizeColumn(projectionsScope, resultName, rowType, nil /* expr */, call)
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		projectionsScope.expr = f.ConstructBarrier(projectionsScope.expr, &memo.BarrierPrivate{})
+		mb.outScope = projectionsScope
+
+		// Skip the rows for which the trigger function returned NULL.
+		result := f.ConstructVariable(resultCol.id)
+		mb.outScope.expr = f.ConstructSelect(mb.outScope.expr, memo.FiltersExpr{
+			f.ConstructFiltersItem(f.ConstructIsNot(result, f.ConstructNull(rowType))),
+		})
+		if eventType == tree.TriggerEventDelete {
+			continue
+		}
+
+		// Extract the new values of the row from the result.
+		projectionsScope = mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		for i, ord := range ords {
+			tabCol := mb.tab.Column(ord)
+			if tabCol.IsComputed() {
+				// Computed columns are computed from the values returned by the
+				// trigger, so their values in the returned row are ignored.
+				continue
+			}
+			colName := scopeColName(tabCol.ColName()).WithMetadataName(
+				fmt.Sprintf("%s_%s", tabCol.ColName(), trig.Name()),
+			)
+			newCol := mb.b.synthesizeColumn(
+				projectionsScope, colName, tabCol.DatumType(), nil, /* expr */
+				f.ConstructColumnAccess(result, memo.TupleOrdinal(i)),
+			)
+			if eventType == tree.TriggerEventInsert {
+				mb.insertColIDs[ord] = newCol.id
+			} else {
+				mb.updateColIDs[ord] = newCol.id
+			}
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+	}
+
+	// Make sure the table column names refer to the values returned by the
+	// last trigger.
+	mb.disambiguateColumns()
+}
+
+// buildRowLevelAfterTriggers plans the AFTER row-level triggers that fire for
+// the given event. Each trigger is planned as a query that runs after the
+// mutation, over the buffered mutation input.
+//
+// It must be called after the mutation input is complete.
+func (mb *mutationBuilder) buildRowLevelAfterTriggers(eventType tree.TriggerEventType) {
+	triggers := mb.rowLevelTriggers(tree.TriggerActionTimeAfter, eventType)
+	if len(triggers) == 0 {
+		return
+	}
+	ords := triggerRowOrdinals(mb.tab)
+	var oldValues, newValues opt.ColList
+	switch eventType {
+	case tree.TriggerEventInsert:
+		newValues = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			newValues[i] = mb.insertColIDs[ord]
+		}
+	case tree.TriggerEventUpdate:
+		oldValues = make(opt.ColList, len(ords))
+		newValues = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			oldValues[i] = mb.fetchColIDs[ord]
+			newValues[i] = mb.mapToReturnColID(ord)
+		}
+	case tree.TriggerEventDelete:
+		oldValues = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			oldValues[i] = mb.fetchColIDs[ord]
+		}
+	}
+	for _, cols := range []opt.ColList{oldValues, newValues} {
+		for _, col := range cols {
+			if col == 0 {
+				panic(errors.AssertionFailedf("missing column for AFTER trigger on %s", mb.tab.Name()))
+			}
+		}
+	}
+
+	mb.ensureWithID()
+	for _, trigOrd := range triggers {
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			Trigger: mb.tab.Trigger(trigOrd),
+			Builder: &afterTriggerBuilder{
+				table:          mb.tab,
+				triggerOrdinal: trigOrd,
+				eventType:      eventType,
+			},
+			WithID:    mb.withID,
+			OldValues: oldValues,
+			NewValues: newValues,
+		})
+	}
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder that builds the query which
+// fires an AFTER row-level trigger for each row modified by a mutation. The
+// query projects the result of the trigger function for each row. The results
+// are discarded; the trigger function is run for its side effects.
+type afterTriggerBuilder struct {
+	table          cat.Table
+	triggerOrdinal int
+	eventType      tree.TriggerEventType
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (memo.RelExpr, error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		f := b.factory
+		md := f.Metadata()
+		trig := tb.table.Trigger(tb.triggerOrdinal)
+		ords := triggerRowOrdinals(tb.table)
+		rowType := triggerRowType(tb.table, ords)
+
+		inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+		inCols = append(inCols, oldValues...)
+		inCols = append(inCols, newValues...)
+		outCols := make(opt.ColList, len(inCols))
+		for i := range inCols {
+			c := md.ColumnMeta(inCols[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+
+		// Construct a dummy operator as the binding.
+		md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		var input memo.RelExpr = f.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		var oldCols, newCols opt.OptionalColList
+		if len(oldValues) > 0 {
+			oldCols = opt.OptionalColList(outCols[:len(oldValues)])
+		}
+		if len(newValues) > 0 {
+			newCols = opt.OptionalColList(outCols[len(oldValues):])
+		}
+		if cond := b.buildTriggerWhen(tb.table, trig, ords, newCols, oldCols); cond != nil {
+			input = f.ConstructSelect(input, memo.FiltersExpr{f.ConstructFiltersItem(cond)})
+		}
+
+		call := b.buildTriggerFunctionCall(
+			tb.table, trig, tb.eventType, rowType,
+			b.buildTriggerRow(newCols, rowType), b.buildTriggerRow(oldCols, rowType),
+		)
+		resultCol := md.AddColumn(fmt.Sprintf("%s_result", trig.Name()), rowType)
+		return f.ConstructProject(
+			input,
+			memo.ProjectionsExpr{f.ConstructProjectionsItem(call, resultCol)},
+			opt.ColSet{},
+		)
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/lib/pq/oid"
+)
+
+// checkUserDefinedAggregateUsage panics if the given type-checked call of a
+// user-defined aggregate uses features that are not supported for
+// user-defined aggregates. It is a no-op for other functions.
+func checkUserDefinedAggregateUsage(f *tree.FuncExpr) {
+	if f.ResolvedOverload().UserDefinedAggregate == nil {
+		return
+	}
+	switch {
+	case f.WindowDef != nil:
+		panic(unimplemented.Newf("user-defined aggregate window",
+			"user-defined aggregates cannot be used as window functions"))
+	case f.Type == tree.DistinctFuncType:
+		panic(unimplemented.Newf("user-defined aggregate distinct",
+			"DISTINCT is not supported for user-defined aggregates"))
+	case f.OrderBy != nil:
+		panic(unimplemented.Newf("user-defined aggregate order by",
+			"ORDER BY is not supported for user-defined aggregates"))
+	}
+}
+
+// constructUserDefinedAggregate constructs a UserDefinedAgg expression for the
+// given call of a user-defined aggregate. The support functions of the
+// aggregate are built as calls over synthesized parameter columns, which are
+// mapped to the aggregate state and arguments during execution.
+func (b *Builder) constructUserDefinedAggregate(
+	agg *aggregateInfo, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := agg.def.Overload
+	uda := o.UserDefinedAggregate
+	b.factory.Metadata().AddUserDefinedFunction(o, agg.Func.ReferenceByName)
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+		panic(err)
+	}
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+
+	def := &memo.UserDefinedAggDef{
+		Name:       agg.def.Name,
+		Typ:        agg.ResolvedType(),
+		StateType:  uda.StateType,
+		Volatility: o.Volatility,
+		InitCond:   tree.DNull,
+	}
+	if uda.InitCond != nil {
+		d, _, err := tree.ParseAndRequireString(uda.StateType, *uda.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		def.InitCond = d
+	}
+
+	// Synthesize a column for the state, one for each argument, and one for the
+	// partial state merged by the combine function.
+	paramScope := b.allocScope()
+	b.synthesizeColumn(paramScope, scopeColName("state"), uda.StateType, nil /* expr */, nil /* scalar */)
+	for i := range args {
+		argColName := funcParamColName("" /* name */, i)
+		b.synthesizeColumn(paramScope, argColName, o.Types.GetAt(i), nil /* expr */, nil /* scalar */)
+	}
+	b.synthesizeColumn(paramScope, scopeColName("partial_state"), uda.StateType, nil /* expr */, nil /* scalar */)
+	params := make(tree.Exprs, len(paramScope.cols))
+	def.Params = make(opt.ColList, len(args)+1)
+	for i := range paramScope.cols {
+		params[i] = &paramScope.cols[i]
+		if i < len(def.Params) {
+			def.Params[i] = paramScope.cols[i].id
+		}
+	}
+	state, partialState := params[0], params[len(params)-1]
+	def.CombineParam = paramScope.cols[len(paramScope.cols)-1].id
+
+	// Do not track the support functions as dependencies of the statement,
+	// since they are dependencies of the aggregate itself.
+	oldTrackingSchemaDeps := b.trackSchemaDeps
+	defer func() { b.trackSchemaDeps = oldTrackingSchemaDeps }()
+	b.trackSchemaDeps = false
+
+	def.Transition, def.TransitionStrict = b.buildAggregateSupportFunction(
+		uda.TransitionFunc, params[:len(params)-1], uda.StateType, paramScope,
+	)
+	if uda.FinalFunc != 0 {
+		def.Final, def.FinalStrict = b.buildAggregateSupportFunction(
+			uda.FinalFunc, tree.Exprs{state}, def.Typ, paramScope,
+		)
+	}
+	if uda.CombineFunc != 0 {
+		def.Combine, def.CombineStrict = b.buildAggregateSupportFunction(
+			uda.CombineFunc, tree.Exprs{state, partialState}, uda.StateType, paramScope,
+		)
+	}
+
+	return b.factory.ConstructUserDefinedAgg(args, &memo.UserDefinedAggPrivate{Def: def})
+}
+
+// buildAggregateSupportFunction builds a call of the support function with
+// the given OID over the given parameter columns. It returns the built
+// expression and whether the function is strict.
+func (b *Builder) buildAggregateSupportFunction(
+	fnOID oid.Oid, args tree.Exprs, desired *types.T, paramScope *scope,
+) (_ opt.ScalarExpr, strict bool) {
+	f := &tree.FuncExpr{
+		Func:  tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: fnOID}},
+		Exprs: args,
+	}
+	typedExpr := paramScope.resolveType(f, desired)
+	call := b.buildScalar(typedExpr, paramScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	return call, !typedExpr.(*tree.FuncExpr).ResolvedOverload().CalledOnNullInput
+}
@@ -617,9 +617,20 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
+	// We can't mutate materialized views, except from the statements issued by
+	// REFRESH MATERIALIZED VIEW ... INCREMENTAL. The memo is not reused in that
+	// case, so that other statements can never share it.
 	if tab.IsMaterializedView() {
-		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+		if !b.AllowMaterializedViewMutation {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+		}
+		b.DisableMemoReuse = true
+	}
+
+	// Foreign tables are read-only: their rows are read from files in external
+	// storage, which are never written.
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate foreign table %q", tab.Name()))
 	}
 
 	return tab, depName, alias, columns
@@ -196,6 +196,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"UniqueID":             {fullName: "opt.UniqueID", passByVal: true},
 		"WithID":               {fullName: "opt.WithID", passByVal: true},
 		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true},
+		"UserDefinedAggDef":    {fullName: "memo.UserDefinedAggDef", isPointer: true, usePointerIntern: true},
 		"StoredProcTxnOp":      {fullName: "tree.StoredProcTxnOp", passByVal: true},
 		"TransactionModes":     {fullName: "tree.TransactionModes", passByVal: true},
 		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
@@ -207,6 +208,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"JoinFlags":            {fullName: "memo.JoinFlags", passByVal: true},
 		"WindowFrame":          {fullName: "memo.WindowFrame", passByVal: true},
 		"FKCascades":           {fullName: "memo.FKCascades", passByVal: true},
+		"GroupingSets":         {fullName: "memo.GroupingSets", passByVal: true},
 		"ExplainOptions":       {fullName: "tree.ExplainOptions", passByVal: true},
 		"StatementReturnType":  {fullName: "tree.StatementReturnType", passByVal: true},
 		"StatementType":        {fullName: "tree.StatementType", passByVal: true},
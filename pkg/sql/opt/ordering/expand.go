@@ -0,0 +1,39 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package ordering
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+)
+
+func expandCanProvideOrdering(expr memo.RelExpr, required *props.OrderingChoice) bool {
+	// Expand produces all the copies of an input row before moving on to the
+	// next input row, so it can pass through orderings that only depend on
+	// columns present in the input.
+	return required.CanProjectCols(expr.(*memo.ExpandExpr).Input.Relational().OutputCols)
+}
+
+func expandBuildChildReqOrdering(
+	parent memo.RelExpr, required *props.OrderingChoice, childIdx int,
+) props.OrderingChoice {
+	if childIdx != 0 {
+		return props.OrderingChoice{}
+	}
+	return projectOrderingToInput(parent.(*memo.ExpandExpr).Input, required)
+}
+
+func expandBuildProvided(expr memo.RelExpr, required *props.OrderingChoice) opt.Ordering {
+	e := expr.(*memo.ExpandExpr)
+	rel := e.Relational()
+	return remapProvided(e.Input.ProvidedPhysical().Ordering, &rel.FuncDeps, rel.OutputCols)
+}
@@ -189,6 +189,11 @@ func init() {
 		buildChildReqOrdering: ordinalityBuildChildReqOrdering,
 		buildProvidedOrdering: ordinalityBuildProvided,
 	}
+	funcMap[opt.ExpandOp] = funcs{
+		canProvideOrdering:    expandCanProvideOrdering,
+		buildChildReqOrdering: expandBuildChildReqOrdering,
+		buildProvidedOrdering: expandBuildProvided,
+	}
 	funcMap[opt.MergeJoinOp] = funcs{
 		canProvideOrdering:    mergeJoinCanProvideOrdering,
 		buildChildReqOrdering: mergeJoinBuildChildReqOrdering,
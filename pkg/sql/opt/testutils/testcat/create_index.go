@@ -12,6 +12,7 @@ package testcat
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
 )
@@ -34,6 +35,11 @@ func (tc *Catalog) CreateIndex(stmt *tree.CreateIndex, version descpb.IndexDescr
 		view = tc.View(&tn)
 	}
 
+	if stmt.BlockRange {
+		tab.addBlockRangeIndex(stmt)
+		return
+	}
+
 	// Convert stmt to a tree.IndexTableDef so that Table.addIndex can be used
 	// to add the index to the table.
 	indexTableDef := &tree.IndexTableDef{
@@ -58,3 +64,29 @@ func (tc *Catalog) CreateIndex(stmt *tree.CreateIndex, version descpb.IndexDescr
 		view.addIndex(indexTableDef)
 	}
 }
+
+// addBlockRangeIndex adds a block range index, created with CREATE INDEX ...
+// USING brin, to the table. Its range size is set by the pages_per_range
+// storage parameter, which must be an integer literal.
+func (tt *Table) addBlockRangeIndex(stmt *tree.CreateIndex) {
+	if len(stmt.Columns) != 1 || stmt.Columns[0].Column == "" {
+		panic(errors.Newf("block range indexes must have a single column"))
+	}
+	idx := &BlockRangeIndex{
+		IdxID:     cat.StableID(len(tt.Indexes) + len(tt.blockRangeIndexes) + 1),
+		IdxName:   stmt.Name,
+		Ordinal:   tt.FindOrdinal(string(stmt.Columns[0].Column)),
+		BlockSize: 128,
+	}
+	for _, param := range stmt.StorageParams {
+		if param.Key != "pages_per_range" {
+			panic(errors.Newf("invalid storage parameter %q", param.Key))
+		}
+		n, err := param.Value.(*tree.NumVal).AsInt64()
+		if err != nil {
+			panic(err)
+		}
+		idx.BlockSize = n
+	}
+	tt.blockRangeIndexes = append(tt.blockRangeIndexes, idx)
+}
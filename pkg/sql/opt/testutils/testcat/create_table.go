@@ -358,7 +358,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability, def.ExclusionOps,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
@@ -377,6 +379,8 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						tree.ConstraintDeferrability{},
+						nil, /* exclusionOps */
 					)
 				} else {
 					tab.addIndex(
@@ -678,6 +682,8 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrable:               d.Deferrability.Deferrable,
+		initiallyDeferred:        d.Deferrability.InitiallyDeferred,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -713,7 +719,12 @@ func (tt *Table) addCheckConstraint(check *tree.CheckConstraintTableDef) {
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
+	exclusionOps []treecmp.ComparisonOperator,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -725,7 +736,11 @@ func (tt *Table) addUniqueConstraint(
 	for i, c := range columns {
 		cols[i] = tt.FindOrdinal(string(c.Column))
 	}
-	sort.Ints(cols)
+	// The operators of an exclusion constraint correspond to the columns in the
+	// order in which they were declared.
+	if len(exclusionOps) == 0 {
+		sort.Ints(cols)
+	}
 
 	// Create the constraint.
 	u := UniqueConstraint{
@@ -734,6 +749,10 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+
+		deferrable:        deferrability.Deferrable,
+		initiallyDeferred: deferrability.InitiallyDeferred,
+		exclusionOps:      exclusionOps,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -744,7 +763,8 @@ func (tt *Table) addUniqueConstraint(
 	for _, c := range tt.uniqueConstraints {
 		if reflect.DeepEqual(c.columnOrdinals, u.columnOrdinals) &&
 			c.predicate == u.predicate &&
-			c.withoutIndex == u.withoutIndex {
+			c.withoutIndex == u.withoutIndex &&
+			reflect.DeepEqual(c.exclusionOps, u.exclusionOps) {
 			return
 		}
 	}
@@ -874,7 +894,10 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintDeferrability{},
+			nil, /* exclusionOps */
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
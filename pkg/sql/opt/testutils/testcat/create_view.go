@@ -19,7 +19,11 @@ func (tc *Catalog) CreateView(stmt *tree.CreateView) *View {
 	tc.qualifyTableName(&stmt.Name)
 
 	fmtCtx := tree.NewFmtCtx(tree.FmtParsable)
-	stmt.AsSource.Format(fmtCtx)
+	if stmt.Recursive {
+		stmt.RecursiveViewQuery().Format(fmtCtx)
+	} else {
+		stmt.AsSource.Format(fmtCtx)
+	}
 
 	view := &View{
 		ViewID:      tc.nextStableID(),
@@ -134,10 +134,12 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition, "function result type must be %s because of OUT parameters", outParamType.Name()))
 		}
 		// Override the return types so that we do return type validation and SHOW
-		// CREATE correctly.
+		// CREATE correctly. A set-returning function with OUT parameters returns
+		// a set of the OUT parameter type.
 		retType = outParamType
 		c.ReturnType = &tree.RoutineReturnType{
-			Type: outParamType,
+			Type:  outParamType,
+			SetOf: c.ReturnType != nil && c.ReturnType.SetOf,
 		}
 	} else if retType == nil {
 		if c.IsProcedure {
@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
@@ -315,6 +316,16 @@ func (tc *Catalog) HasRoleOption(ctx context.Context, roleOption roleoption.Opti
 	return true, nil
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (tc *Catalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	return true, nil
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (tc *Catalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	return true, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (tc *Catalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -759,6 +770,8 @@ type Table struct {
 	implicitRBRIndexElem *tree.IndexElem
 
 	homeRegion string
+
+	blockRangeIndexes []*BlockRangeIndex
 }
 
 var _ cat.Table = &Table{}
@@ -820,6 +833,11 @@ func (tt *Table) IsMaterializedView() bool {
 	return false
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (tt *Table) IsForeignTable() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (tt *Table) ColumnCount() int {
 	return len(tt.Columns)
@@ -962,6 +980,46 @@ func (tt *Table) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (tt *Table) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (tt *Table) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (tt *Table) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (tt *Table) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// BlockRangeIndexCount is part of the cat.Table interface.
+func (tt *Table) BlockRangeIndexCount() int {
+	return len(tt.blockRangeIndexes)
+}
+
+// BlockRangeIndex is part of the cat.Table interface.
+func (tt *Table) BlockRangeIndex(i int) cat.BlockRangeIndex {
+	return tt.blockRangeIndexes[i]
+}
+
 // FindOrdinal returns the ordinal of the column with the given name.
 func (tt *Table) FindOrdinal(name string) int {
 	for i, col := range tt.Columns {
@@ -1447,6 +1505,9 @@ type ForeignKeyConstraint struct {
 	matchMethod  tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1514,6 +1575,16 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1523,6 +1594,10 @@ type UniqueConstraint struct {
 	predicate      string
 	withoutIndex   bool
 	validated      bool
+
+	deferrable        bool
+	initiallyDeferred bool
+	exclusionOps      []treecmp.ComparisonOperator
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1574,6 +1649,26 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) IsExclusion() bool {
+	return len(u.exclusionOps) > 0
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperator {
+	return u.exclusionOps[i]
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
@@ -1630,6 +1725,37 @@ func (ts *Sequence) CollectTypes(ord int) (descpb.IDs, error) {
 	return nil, nil
 }
 
+// BlockRangeIndex implements the cat.BlockRangeIndex interface for testing
+// purposes.
+type BlockRangeIndex struct {
+	IdxID     cat.StableID
+	IdxName   tree.Name
+	Ordinal   int
+	BlockSize int64
+}
+
+var _ cat.BlockRangeIndex = &BlockRangeIndex{}
+
+// ID is part of the cat.BlockRangeIndex interface.
+func (bi *BlockRangeIndex) ID() cat.StableID {
+	return bi.IdxID
+}
+
+// Name is part of the cat.BlockRangeIndex interface.
+func (bi *BlockRangeIndex) Name() tree.Name {
+	return bi.IdxName
+}
+
+// ColumnOrdinal is part of the cat.BlockRangeIndex interface.
+func (bi *BlockRangeIndex) ColumnOrdinal() int {
+	return bi.Ordinal
+}
+
+// RangeSize is part of the cat.BlockRangeIndex interface.
+func (bi *BlockRangeIndex) RangeSize() int64 {
+	return bi.BlockSize
+}
+
 // Family implements the cat.Family interface for testing purposes.
 type Family struct {
 	FamName string
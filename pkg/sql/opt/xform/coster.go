@@ -593,6 +593,9 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 	case opt.ProjectSetOp:
 		cost = c.computeProjectSetCost(candidate.(*memo.ProjectSetExpr))
 
+	case opt.ExpandOp:
+		cost = c.computeExpandCost(candidate.(*memo.ExpandExpr))
+
 	case opt.InsertOp:
 		insertExpr, _ := candidate.(*memo.InsertExpr)
 		if len(insertExpr.FastPathUniqueChecks) != 0 {
@@ -798,6 +801,18 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 	}
 	baseCost := memo.Cost(numSpans * randIOCostFactor)
 
+	// If the scan uses a block range index, add the cost of reading the
+	// summary of every block of the table before the scan starts.
+	if scan.BlockRangeConstraint != nil {
+		tableRowCount := rowCount
+		if sel := stats.Selectivity.AsFloat(); sel > 0 {
+			tableRowCount = rowCount / sel
+		}
+		idx := c.mem.Metadata().Table(scan.Table).BlockRangeIndex(scan.BlockRangeIndex)
+		numBlocks := math.Max(1, tableRowCount/float64(idx.RangeSize()))
+		baseCost += memo.Cost(randIOCostFactor + numBlocks*seqIOCostFactor)
+	}
+
 	// If this is a virtual scan, add the cost of fetching table descriptors.
 	if c.mem.Metadata().Table(scan.Table).IsVirtualTable() {
 		baseCost += virtualScanTableDescriptorFetchCost
@@ -1458,6 +1473,17 @@ func (c *coster) computeProjectSetCost(projectSet *memo.ProjectSetExpr) memo.Cos
 	return cost
 }
 
+func (c *coster) computeExpandCost(expand *memo.ExpandExpr) memo.Cost {
+	// Each grouping column and the grouping set index are set on each row.
+	rowCount := expand.Relational().Statistics().RowCount
+	synthesizedColCount := len(expand.OutCols) + 1
+	cost := memo.Cost(rowCount) * memo.Cost(synthesizedColCount) * cpuCostFactor
+
+	// Add the CPU cost of emitting the rows.
+	cost += memo.Cost(rowCount) * cpuCostFactor
+	return cost
+}
+
 // getOrderingColStats returns the column statistic for the columns in the
 // OrderingChoice oc. The OrderingChoice should be a member of expr. We include
 // the Memo as an argument so that functions that call this function can be used
@@ -71,6 +71,12 @@ func (c *CustomFuncs) HasInvertedIndexes(scanPrivate *memo.ScanPrivate) bool {
 	return false
 }
 
+// HasBlockRangeIndexes returns true if at least one block range index is
+// defined on the Scan operator's table.
+func (c *CustomFuncs) HasBlockRangeIndexes(scanPrivate *memo.ScanPrivate) bool {
+	return c.e.mem.Metadata().Table(scanPrivate.Table).BlockRangeIndexCount() > 0
+}
+
 // RemapJoinColsInFilter returns a new FiltersExpr where columns in leftSrc's
 // table are replaced with columns of the same ordinal in leftDst's table and
 // rightSrc's table are replaced with columns of the same ordinal in rightDst's
@@ -369,6 +369,12 @@ func (c *CustomFuncs) generateLookupJoinsImpl(
 		return
 	}
 
+	// The rows of a foreign table are read by scanning its files, so they
+	// cannot be looked up by key.
+	if md.Table(scanPrivate.Table).IsForeignTable() {
+		return
+	}
+
 	// Initialize the constraint builder.
 	c.cb.Init(
 		c.e.f,
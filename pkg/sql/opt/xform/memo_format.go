@@ -300,6 +300,9 @@ func (mf *memoFormatter) formatPrivate(e opt.Expr, physProps *physical.Required)
 		if t.InvertedConstraint != nil {
 			fmt.Fprintf(mf.buf, ",constrained inverted")
 		}
+		if t.BlockRangeConstraint != nil {
+			fmt.Fprintf(mf.buf, ",block range")
+		}
 		if t.HardLimit.IsSet() {
 			fmt.Fprintf(mf.buf, ",lim=%s", t.HardLimit)
 		}
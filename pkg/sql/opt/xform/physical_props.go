@@ -175,6 +175,11 @@ func BuildChildPhysicalProps(
 	case opt.OrdinalityOp, opt.ProjectOp, opt.ProjectSetOp:
 		childProps.LimitHint = parentProps.LimitHint
 
+	case opt.ExpandOp:
+		// Each input row is produced once for each grouping set.
+		numSets := float64(len(parent.(*memo.ExpandExpr).GroupingSets))
+		childProps.LimitHint = math.Ceil(parentProps.LimitHint / numSets)
+
 	case opt.TopKOp:
 		if parentProps.Ordering.Any() {
 			break
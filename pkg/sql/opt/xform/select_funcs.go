@@ -939,6 +939,80 @@ func (c *CustomFuncs) GenerateInvertedIndexScans(
 	})
 }
 
+// GenerateBlockRangeScans enumerates the block range indexes of the Scan
+// operator's table and generates an alternate scan of the primary index for
+// each block range index whose column is constrained by the filters. For
+// example:
+//
+//	CREATE TABLE t (k INT PRIMARY KEY, ts TIMESTAMP);
+//	CREATE INDEX t_ts_idx ON t USING brin (ts);
+//	SELECT * FROM t WHERE ts > '2024-01-01';
+//
+// The constraint of the filter on ts is attached to the scan as its
+// BlockRangeConstraint. The scan only reads the blocks of t whose summaries
+// overlap the constraint, and the filter is applied to the rows of these
+// blocks by a Select above the scan.
+func (c *CustomFuncs) GenerateBlockRangeScans(
+	grp memo.RelExpr, required *physical.Required, scanPrivate *memo.ScanPrivate, filters memo.FiltersExpr,
+) {
+	if scanPrivate.Flags.ForceIndex && scanPrivate.Flags.Index != cat.PrimaryIndex {
+		return
+	}
+	if scanPrivate.Flags.ForceInvertedIndex || scanPrivate.Flags.ForceZigzag {
+		return
+	}
+	tab := c.e.mem.Metadata().Table(scanPrivate.Table)
+	for i, n := 0, tab.BlockRangeIndexCount(); i < n; i++ {
+		col := scanPrivate.Table.ColumnID(tab.BlockRangeIndex(i).ColumnOrdinal())
+		cons := c.blockRangeConstraint(col, filters)
+		if cons == nil {
+			continue
+		}
+		newScanPrivate := *scanPrivate
+		newScanPrivate.BlockRangeIndex = i
+		newScanPrivate.BlockRangeConstraint = cons
+
+		var sb indexScanBuilder
+		sb.Init(c, scanPrivate.Table)
+		sb.SetScan(&newScanPrivate)
+		sb.AddSelect(filters)
+		sb.Build(grp)
+	}
+}
+
+// blockRangeConstraint returns the intersection of the single-column
+// constraints that the filters place on the given column, or nil if the
+// filters do not constrain the column. The filters imply the constraint, so
+// every row that satisfies the filters satisfies the constraint.
+func (c *CustomFuncs) blockRangeConstraint(
+	col opt.ColumnID, filters memo.FiltersExpr,
+) *constraint.Constraint {
+	var res *constraint.Constraint
+	for i := range filters {
+		cs := filters[i].ScalarProps().Constraints
+		if cs == nil {
+			continue
+		}
+		for j, n := 0, cs.Length(); j < n; j++ {
+			cons := cs.Constraint(j)
+			if cons.Columns.Count() != 1 || cons.Columns.Get(0).ID() != col ||
+				cons.Columns.Get(0).Descending() || cons.IsUnconstrained() {
+				continue
+			}
+			if res == nil {
+				res = &constraint.Constraint{}
+				*res = *cons
+				continue
+			}
+			res.IntersectWith(c.e.evalCtx, cons)
+		}
+	}
+	if res == nil || res.IsContradiction() {
+		return nil
+	}
+	return res
+}
+
 // tryConstrainIndex tries to derive a constraint for the given index from the
 // specified filter. If a constraint is derived, it is returned along with any
 // filter remaining after extracting the constraint. If no constraint can be
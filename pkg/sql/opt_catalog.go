@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
@@ -27,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
@@ -450,6 +452,31 @@ func (oc *optCatalog) HasRoleOption(
 	return oc.planner.HasRoleOption(ctx, roleOption)
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.HasOwnership(ctx, desc)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(
+	ctx context.Context, role username.SQLUsername,
+) (bool, error) {
+	user := oc.planner.User()
+	if user == role {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (oc *optCatalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -777,6 +804,13 @@ type optTable struct {
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
+
+	// triggers is the set of triggers for this table, ordered by name.
+	triggers []optTrigger
+
+	// blockRangeIndexes is the set of public block range indexes of this
+	// table.
+	blockRangeIndexes []optBlockRangeIndex
 }
 
 var _ cat.Table = &optTable{}
@@ -907,12 +941,24 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:              u.GetName(),
+			table:             ot.ID(),
+			columns:           u.CollectKeyColumnIDs().Ordered(),
+			predicate:         u.GetPredicate(),
+			withoutIndex:      true,
+			validity:          u.GetConstraintValidity(),
+			deferrable:        u.UniqueWithoutIndexDesc().Deferrable,
+			initiallyDeferred: u.UniqueWithoutIndexDesc().InitiallyDeferred,
+		}
+		if u.UniqueWithoutIndexDesc().IsExclusion() {
+			// The operators of an exclusion constraint correspond to the columns in
+			// the order in which they were declared.
+			ops, err := schemaexpr.ParseExclusionOperators(u.UniqueWithoutIndexDesc().ExclusionOperators)
+			if err != nil {
+				return nil, err
+			}
+			ot.uniqueConstraints[i].columns = u.UniqueWithoutIndexDesc().ColumnIDs
+			ot.uniqueConstraints[i].exclusionOps = ops
 		}
 	}
 
@@ -1017,6 +1063,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1030,9 +1078,34 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 
+	if triggers := desc.GetTriggers(); len(triggers) > 0 {
+		ot.triggers = make([]optTrigger, len(triggers))
+		for i := range triggers {
+			ot.triggers[i].desc = &triggers[i]
+		}
+		// Triggers for the same event fire in alphabetical order by name.
+		sort.Slice(ot.triggers, func(i, j int) bool {
+			return ot.triggers[i].desc.Name < ot.triggers[j].desc.Name
+		})
+	}
+
+	for i := range desc.GetBlockRangeIndexes() {
+		idx := &desc.GetBlockRangeIndexes()[i]
+		if !idx.Public {
+			continue
+		}
+		ord, err := ot.lookupColumnOrdinal(idx.ColumnID)
+		if err != nil {
+			return nil, err
+		}
+		ot.blockRangeIndexes = append(ot.blockRangeIndexes, optBlockRangeIndex{desc: idx, ord: ord})
+	}
+
 	ot.primaryFamily.init(ot, &desc.GetFamilies()[0])
 	ot.families = make([]optFamily, len(desc.GetFamilies())-1)
 	for i := range ot.families {
@@ -1205,6 +1278,11 @@ func (ot *optTable) IsMaterializedView() bool {
 	return ot.desc.MaterializedView()
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return ot.desc.IsForeignTable()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.columns)
@@ -1394,6 +1472,46 @@ func (ot *optTable) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.IsRowLevelSecurityForced()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.desc.GetPolicies())
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	return &optPolicy{desc: &ot.desc.GetPolicies()[i]}
+}
+
+// BlockRangeIndexCount is part of the cat.Table interface.
+func (ot *optTable) BlockRangeIndexCount() int {
+	return len(ot.blockRangeIndexes)
+}
+
+// BlockRangeIndex is part of the cat.Table interface.
+func (ot *optTable) BlockRangeIndex(i int) cat.BlockRangeIndex {
+	return &ot.blockRangeIndexes[i]
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -1783,6 +1901,157 @@ func (oc *optCheckConstraint) ColumnOrdinal(i int) int {
 	return ord
 }
 
+// optPolicy is a wrapper around descpb.TableDescriptor_Policy that
+// implements the cat.Policy interface.
+type optPolicy struct {
+	desc *descpb.TableDescriptor_Policy
+}
+
+var _ cat.Policy = &optPolicy{}
+
+// Name is part of the cat.Policy interface.
+func (op *optPolicy) Name() tree.Name {
+	return tree.Name(op.desc.Name)
+}
+
+// IsRestrictive is part of the cat.Policy interface.
+func (op *optPolicy) IsRestrictive() bool {
+	return op.desc.Restrictive
+}
+
+// Command is part of the cat.Policy interface.
+func (op *optPolicy) Command() tree.PolicyCommand {
+	switch op.desc.Command {
+	case descpb.TableDescriptor_Policy_SELECT:
+		return tree.PolicyCommandSelect
+	case descpb.TableDescriptor_Policy_INSERT:
+		return tree.PolicyCommandInsert
+	case descpb.TableDescriptor_Policy_UPDATE:
+		return tree.PolicyCommandUpdate
+	case descpb.TableDescriptor_Policy_DELETE:
+		return tree.PolicyCommandDelete
+	default:
+		return tree.PolicyCommandAll
+	}
+}
+
+// RoleCount is part of the cat.Policy interface.
+func (op *optPolicy) RoleCount() int {
+	return len(op.desc.RoleNames)
+}
+
+// Role is part of the cat.Policy interface.
+func (op *optPolicy) Role(i int) username.SQLUsername {
+	return username.MakeSQLUsernameFromPreNormalizedString(op.desc.RoleNames[i])
+}
+
+// UsingExpr is part of the cat.Policy interface.
+func (op *optPolicy) UsingExpr() string {
+	return op.desc.UsingExpr
+}
+
+// WithCheckExpr is part of the cat.Policy interface.
+func (op *optPolicy) WithCheckExpr() string {
+	return op.desc.WithCheckExpr
+}
+
+// optBlockRangeIndex is a wrapper around
+// descpb.TableDescriptor_BlockRangeIndex that implements the
+// cat.BlockRangeIndex interface.
+type optBlockRangeIndex struct {
+	desc *descpb.TableDescriptor_BlockRangeIndex
+	ord  int
+}
+
+var _ cat.BlockRangeIndex = &optBlockRangeIndex{}
+
+// ID is part of the cat.BlockRangeIndex interface.
+func (oi *optBlockRangeIndex) ID() cat.StableID {
+	return cat.StableID(oi.desc.ID)
+}
+
+// Name is part of the cat.BlockRangeIndex interface.
+func (oi *optBlockRangeIndex) Name() tree.Name {
+	return tree.Name(oi.desc.Name)
+}
+
+// ColumnOrdinal is part of the cat.BlockRangeIndex interface.
+func (oi *optBlockRangeIndex) ColumnOrdinal() int {
+	return oi.ord
+}
+
+// RangeSize is part of the cat.BlockRangeIndex interface.
+func (oi *optBlockRangeIndex) RangeSize() int64 {
+	return oi.desc.RangeSize
+}
+
+// optTrigger is a wrapper around descpb.TableDescriptor_Trigger that
+// implements the cat.Trigger interface.
+type optTrigger struct {
+	desc *descpb.TableDescriptor_Trigger
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (ot *optTrigger) Name() tree.Name {
+	return tree.Name(ot.desc.Name)
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (ot *optTrigger) ActionTime() tree.TriggerActionTime {
+	if ot.desc.ActionTime == descpb.TableDescriptor_Trigger_AFTER {
+		return tree.TriggerActionTimeAfter
+	}
+	return tree.TriggerActionTimeBefore
+}
+
+// EventCount is part of the cat.Trigger interface.
+func (ot *optTrigger) EventCount() int {
+	return len(ot.desc.Events)
+}
+
+// Event is part of the cat.Trigger interface.
+func (ot *optTrigger) Event(i int) cat.TriggerEvent {
+	event := &ot.desc.Events[i]
+	var res cat.TriggerEvent
+	switch event.Type {
+	case descpb.TableDescriptor_Trigger_Event_INSERT:
+		res.EventType = tree.TriggerEventInsert
+	case descpb.TableDescriptor_Trigger_Event_UPDATE:
+		res.EventType = tree.TriggerEventUpdate
+	case descpb.TableDescriptor_Trigger_Event_DELETE:
+		res.EventType = tree.TriggerEventDelete
+	}
+	if len(event.ColumnIDs) > 0 {
+		res.Columns = make([]cat.StableID, len(event.ColumnIDs))
+		for j, colID := range event.ColumnIDs {
+			res.Columns[j] = cat.StableID(colID)
+		}
+	}
+	return res
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (ot *optTrigger) ForEachRow() bool {
+	return ot.desc.ForEachRow
+}
+
+// WhenExpr is part of the cat.Trigger interface.
+func (ot *optTrigger) WhenExpr() string {
+	return ot.desc.WhenExpr
+}
+
+// FuncOID is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncOID() oid.Oid {
+	return catid.FuncIDToOID(ot.desc.FuncID)
+}
+
+// FuncArgs is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncArgs() []string {
+	return ot.desc.FuncArgs
+}
+
 type optTableStat struct {
 	stat           *stats.TableStatistic
 	columnOrdinals []int
@@ -1939,6 +2208,12 @@ type optUniqueConstraint struct {
 	withoutIndex bool
 	validity     descpb.ConstraintValidity
 
+	deferrable        bool
+	initiallyDeferred bool
+
+	// exclusionOps is set for exclusion constraints.
+	exclusionOps []treecmp.ComparisonOperator
+
 	uniquenessGuaranteedByAnotherIndex bool
 }
 
@@ -1995,6 +2270,26 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return len(u.exclusionOps) > 0
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperator {
+	return u.exclusionOps[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2012,6 +2307,9 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2083,6 +2381,16 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2276,6 +2584,11 @@ func (ot *optVirtualTable) IsMaterializedView() bool {
 	return false
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	return len(ot.columns)
@@ -2430,6 +2743,46 @@ func (ot *optVirtualTable) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// BlockRangeIndexCount is part of the cat.Table interface.
+func (ot *optVirtualTable) BlockRangeIndexCount() int {
+	return 0
+}
+
+// BlockRangeIndex is part of the cat.Table interface.
+func (ot *optVirtualTable) BlockRangeIndex(i int) cat.BlockRangeIndex {
+	panic(errors.AssertionFailedf("no block range indexes"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
@@ -161,6 +161,23 @@ func (ef *execFactory) ConstructScan(
 	if err != nil {
 		return nil, err
 	}
+	if params.BlockRangeConstraint != nil && ef.planner.txn != nil {
+		brinIdx := params.BlockRangeIndex.(*optBlockRangeIndex).desc
+		acc := ef.planner.Mon().MakeBoundAccount()
+		var toCompact []int64
+		scan.spans, toCompact, err = blockRangeSpans(
+			ef.ctx, ef.planner.txn, ef.planner.EvalContext(), &acc, ef.planner.ExecCfg().Codec, tabDesc,
+			brinIdx, params.BlockRangeConstraint, scan.spans,
+		)
+		acc.Close(ef.ctx)
+		if err != nil {
+			return nil, err
+		}
+		ef.planner.maybeCompactBlockRangesAsync(ef.ctx, tabDesc, *brinIdx, toCompact)
+		if len(scan.spans) == 0 {
+			return newZeroNode(scan.resultColumns), nil
+		}
+	}
 
 	scan.isFull = len(scan.spans) == 1 && scan.spans[0].EqualValue(
 		scan.desc.IndexSpan(ef.planner.ExecCfg().Codec, scan.index.GetID()),
@@ -544,6 +561,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -641,6 +659,32 @@ func (ef *execFactory) ConstructOrdinality(input exec.Node, colName string) (exe
 	}, nil
 }
 
+// ConstructExpand is part of the exec.Factory interface.
+func (ef *execFactory) ConstructExpand(
+	input exec.Node, groupingCols []exec.NodeColumnOrdinal, groupingSets [][]int,
+) (exec.Node, error) {
+	plan := input.(planNode)
+	inputColumns := planColumns(plan)
+	cols := make(colinfo.ResultColumns, 0, len(inputColumns)+len(groupingCols)+1)
+	cols = append(cols, inputColumns...)
+	for _, col := range groupingCols {
+		cols = append(cols, colinfo.ResultColumn{
+			Name: inputColumns[col].Name,
+			Typ:  inputColumns[col].Typ,
+		})
+	}
+	cols = append(cols, colinfo.ResultColumn{
+		Name: "grouping_set",
+		Typ:  types.Int,
+	})
+	return &expandNode{
+		source:       plan,
+		columns:      cols,
+		groupingCols: groupingCols,
+		groupingSets: groupingSets,
+	}, nil
+}
+
 // ConstructIndexJoin is part of the exec.Factory interface.
 func (ef *execFactory) ConstructIndexJoin(
 	input exec.Node,
@@ -1614,6 +1658,7 @@ func (ef *execFactory) ConstructUpsert(
 	arbiterIndexes cat.IndexOrdinals,
 	arbiterConstraints cat.UniqueOrdinals,
 	canaryCol exec.NodeColumnOrdinal,
+	mergeActionCol exec.NodeColumnOrdinal,
 	insertColOrdSet exec.TableColumnOrdinalSet,
 	fetchColOrdSet exec.TableColumnOrdinalSet,
 	updateColOrdSet exec.TableColumnOrdinalSet,
@@ -1663,6 +1708,20 @@ func (ef *execFactory) ConstructUpsert(
 		return nil, err
 	}
 
+	// Create the table deleter if the upsert implements a MERGE statement,
+	// which can delete matched rows.
+	var rd row.Deleter
+	if mergeActionCol != -1 {
+		rd = row.MakeDeleter(
+			ef.planner.ExecCfg().Codec,
+			tabDesc,
+			fetchCols,
+			&ef.planner.ExecCfg().Settings.SV,
+			internal,
+			ef.planner.ExecCfg().GetRowMetrics(internal),
+		)
+	}
+
 	// Instantiate the upsert node.
 	ups := upsertNodePool.Get().(*upsertNode)
 	*ups = upsertNode{
@@ -1671,11 +1730,13 @@ func (ef *execFactory) ConstructUpsert(
 			checkOrds:  checks,
 			insertCols: ri.InsertCols,
 			tw: optTableUpserter{
-				ri:            ri,
-				canaryOrdinal: int(canaryCol),
-				fetchCols:     fetchCols,
-				updateCols:    updateCols,
-				ru:            ru,
+				ri:                 ri,
+				canaryOrdinal:      int(canaryCol),
+				mergeActionOrdinal: int(mergeActionCol),
+				fetchCols:          fetchCols,
+				updateCols:         updateCols,
+				ru:                 ru,
+				rd:                 rd,
 			},
 		},
 	}
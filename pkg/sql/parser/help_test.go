@@ -104,6 +104,9 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TYPE t SET ??`, `ALTER TYPE`},
 		{`ALTER TYPE t RENAME ??`, `ALTER TYPE`},
 		{`ALTER TYPE t DROP VALUE ??`, `ALTER TYPE`},
+		{`ALTER DOMAIN ??`, `ALTER TYPE`},
+		{`ALTER DOMAIN d ??`, `ALTER TYPE`},
+		{`ALTER DOMAIN d SET ??`, `ALTER TYPE`},
 
 		{`ALTER INDEX foo@bar RENAME ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar RENAME TO blih ??`, `ALTER INDEX`},
@@ -311,6 +314,10 @@ func TestContextualHelp(t *testing.T) {
 		{`UPSERT INTO blah VALUES (1) ??`, `VALUES`},
 		{`UPSERT INTO blah TABLE foo ??`, `TABLE`},
 
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah USING ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON true WHEN ??`, `MERGE`},
+
 		{`UPDATE blah ??`, `UPDATE`},
 		{`UPDATE blah SET ??`, `UPDATE`},
 		{`UPDATE blah SET x = 3 WHERE true ??`, `UPDATE`},
@@ -530,6 +537,9 @@ func TestContextualHelp(t *testing.T) {
 		{`SET blah TO ??`, `SET SESSION`},
 		{`SET blah TO 42 ??`, `SET SESSION`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
+
 		{`SET CLUSTER ??`, `SET CLUSTER SETTING`},
 		{`SET CLUSTER SETTING blah = 42 ??`, `SET CLUSTER SETTING`},
 
@@ -588,6 +598,34 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
 		{`ALTER PROCEDURE ??`, `ALTER PROCEDURE`},
 		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE OR REPLACE TRIGGER ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+		{`DROP POLICY ??`, `DROP POLICY`},
+
+		{`CREATE COLLATION ??`, `CREATE COLLATION`},
+		{`DROP COLLATION ??`, `DROP COLLATION`},
+
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+
+		{`CREATE SERVER ??`, `CREATE SERVER`},
+		{`CREATE SERVER s FOREIGN DATA WRAPPER ??`, `CREATE SERVER`},
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE t (a INT) ??`, `CREATE FOREIGN TABLE`},
+		{`DROP SERVER ??`, `DROP SERVER`},
+		{`DROP FOREIGN TABLE ??`, `DROP FOREIGN TABLE`},
+
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE OR REPLACE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+
+		{`LISTEN ??`, `LISTEN`},
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
 	}
 
 	// The following checks that the test definition above exercises all
@@ -75,6 +75,12 @@ func unimplementedWithIssueDetail(sqllex sqlLexer, issue int, detail string) int
     return 1
 }
 
+// recursiveViewWithoutColumnList reports the error for a CREATE RECURSIVE
+// VIEW statement that omits the column list.
+func recursiveViewWithoutColumnList(sqllex sqlLexer) int {
+    return setErr(sqllex, pgerror.New(pgcode.Syntax, "CREATE RECURSIVE VIEW requires a column list"))
+}
+
 func processBinaryQualOp(
   sqllex sqlLexer,
   op tree.Operator,
@@ -525,6 +531,12 @@ func (u *sqlSymUnion) updateExpr() *tree.UpdateExpr {
 func (u *sqlSymUnion) updateExprs() tree.UpdateExprs {
     return u.val.(tree.UpdateExprs)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) limit() *tree.Limit {
     return u.val.(*tree.Limit)
 }
@@ -633,9 +645,18 @@ func (u *sqlSymUnion) readWriteMode() tree.ReadWriteMode {
 func (u *sqlSymUnion) deferrableMode() tree.DeferrableMode {
     return u.val.(tree.DeferrableMode)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) idxElem() tree.IndexElem {
     return u.val.(tree.IndexElem)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionElem {
+    return u.val.(tree.ExclusionElem)
+}
+func (u *sqlSymUnion) exclusionElems() []tree.ExclusionElem {
+    return u.val.([]tree.ExclusionElem)
+}
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
@@ -859,6 +880,12 @@ func (u *sqlSymUnion) routineBody() *tree.RoutineBody {
 func (u *sqlSymUnion) functionObj() tree.RoutineObj {
     return u.val.(tree.RoutineObj)
 }
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() []tree.AggregateOption {
+    return u.val.([]tree.AggregateOption)
+}
 func (u *sqlSymUnion) routineObjs() tree.RoutineObjs {
     return u.val.(tree.RoutineObjs)
 }
@@ -889,6 +916,36 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
     return u.val.(*tree.ShowFingerprintOptions)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() *tree.TriggerEvent {
+    return u.val.(*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() []*tree.TriggerEvent {
+    return u.val.([]*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerTransition() *tree.TriggerTransition {
+    return u.val.(*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerTransitions() []*tree.TriggerTransition {
+    return u.val.([]*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
+    return u.val.(tree.TriggerForEach)
+}
+func (u *sqlSymUnion) policyType() tree.PolicyType {
+    return u.val.(tree.PolicyType)
+}
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
+func (u *sqlSymUnion) foreignOption() tree.ForeignOption {
+    return u.val.(tree.ForeignOption)
+}
+func (u *sqlSymUnion) foreignOptions() tree.ForeignOptions {
+    return u.val.(tree.ForeignOptions)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -914,11 +971,11 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
-%token <str> BOOLEAN BOTH BOX2D BUNDLE BY
+%token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
-%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
+%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMBINEFUNC COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
@@ -927,17 +984,17 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS DISABLE
+%token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTERNAL EXTRACT EXTRACT_DURATION EXTREMES
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX FORCE_INVERTED_INDEX
 %token <str> FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
 %token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
@@ -952,9 +1009,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INITCOND INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -964,43 +1021,43 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOBYPASSRLS NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PERMISSIVE PHYSICAL PLACEMENT PLACING
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PROCEDURES PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
+%token <str> RANGE RANGE_ADJACENT RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
-%token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
+%token <str> SAME_AS SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
-%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
+%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS SFUNC
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SPLIT SQL SQLLOGIN
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING STYPE SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -1016,7 +1073,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRAPPER WRITE
 
 %token <str> YEAR
 
@@ -1143,6 +1200,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> alter_rename_view_stmt
 %type <tree.Statement> alter_view_set_schema_stmt
 %type <tree.Statement> alter_view_owner_stmt
+%type <tree.Statement> alter_view_storage_params_stmt
 
 // ALTER SEQUENCE
 %type <tree.Statement> alter_rename_sequence_stmt
@@ -1207,6 +1265,13 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
 %type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_policy_stmt
+%type <tree.Statement> create_collation_stmt
+%type <tree.Statement> create_publication_stmt
+%type <tree.Statement> create_server_stmt
+%type <tree.Statement> create_foreign_table_stmt
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
@@ -1232,6 +1297,13 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
 %type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_policy_stmt
+%type <tree.Statement> drop_collation_stmt
+%type <tree.Statement> drop_publication_stmt
+%type <tree.Statement> drop_server_stmt
+%type <tree.Statement> drop_foreign_table_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1267,6 +1339,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> savepoint_stmt
 
 %type <tree.Statement> preparable_set_stmt nonpreparable_set_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_local_stmt
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
@@ -1334,6 +1407,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> merge_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
@@ -1414,6 +1490,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.UserPriority> transaction_user_priority
 %type <tree.ReadWriteMode> transaction_read_mode
 %type <tree.DeferrableMode> transaction_deferrable_mode
+%type <tree.ConstraintDeferrability> opt_deferrable
 
 %type <str> name opt_name opt_name_parens
 %type <str> privilege savepoint_name
@@ -1482,6 +1559,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <[]string> session_var_parts
 %type <tree.SelectExprs> opt_target_list target_list
 %type <tree.UpdateExprs> set_clause_list
+%type <tree.MergeWhens> merge_when_list
+%type <*tree.MergeWhen> merge_when_clause merge_update_or_delete merge_insert merge_do_nothing
+%type <tree.Expr> opt_merge_when_cond
 %type <*tree.UpdateExpr> set_clause multiple_set_clause
 %type <tree.ArraySubscripts> array_subscripts
 %type <tree.GroupBy> group_clause
@@ -1520,7 +1600,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <*tree.TenantSpec> virtual_cluster_spec virtual_cluster_spec_opt_all
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
-%type <bool> opt_index_access_method
+%type <bool> constraints_set_mode
+%type <str> opt_index_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1564,6 +1645,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby sortby_index
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExclusionElem> exclusion_elem
+%type <[]tree.ExclusionElem> exclusion_elem_list
+%type <str> opt_exclusion_access_method
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1673,6 +1757,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Expr> opt_alter_column_using
 
 %type <tree.Persistence> opt_temp
+%type <bool> opt_view_recursive
 %type <tree.Persistence> opt_persistence_temp_table
 %type <bool> role_or_group_or_user
 
@@ -1690,22 +1775,51 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <privilege.TargetObjectType> target_object_type
 
 // User defined function relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name routine_as
 %type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list
-%type <tree.RoutineParam> routine_param_with_default routine_param
+%type <tree.RoutineParams> table_func_column_list
+%type <tree.RoutineParam> routine_param_with_default routine_param table_func_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
 %type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
 %type <tree.RoutineParamClass> routine_param_class
+
 %type <*tree.UnresolvedObjectName> routine_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
 %type <tree.Statements> routine_body_stmt_list
 %type <*tree.RoutineBody> opt_routine_body
 %type <tree.RoutineObj> function_with_paramtypes
 %type <tree.RoutineObjs> function_with_paramtypes_list
+%type <tree.RoutineObj> aggregate_with_paramtypes
+%type <tree.RoutineObjs> aggregate_with_paramtypes_list
+%type <tree.RoutineParams> aggregate_params
+%type <tree.AggregateOption> aggregate_option
+%type <[]tree.AggregateOption> aggregate_option_list
 %type <empty> opt_link_sym
 
+// Trigger relevant components.
+%type <tree.TriggerActionTime> trigger_action_time
+%type <*tree.TriggerEvent> trigger_event
+%type <[]*tree.TriggerEvent> trigger_event_list
+%type <*tree.TriggerTransition> trigger_transition
+%type <[]*tree.TriggerTransition> trigger_transition_list opt_trigger_transition_list
+%type <bool> transition_is_new
+%type <tree.TriggerForEach> trigger_for_each
+%type <tree.Expr> trigger_when
+%type <[]string> trigger_func_args
+%type <str> trigger_func_arg
+
+// Row-level security policy relevant components.
+%type <tree.PolicyType> opt_policy_type
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.Expr> opt_policy_using opt_policy_with_check
+
+// Foreign server and foreign table relevant components.
+%type <tree.ForeignOptions> opt_foreign_options foreign_option_list
+%type <tree.ForeignOption> foreign_option
+
 %type <*tree.LabelSpec> label_spec
 
 %type <*tree.ShowRangesOptions> opt_show_ranges_options show_ranges_options
@@ -1754,7 +1868,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND RANGE_ADJACENT SAME_AS DISTANCE SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1819,6 +1933,8 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
@@ -1885,6 +2001,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... { ENABLE | DISABLE | FORCE | NO FORCE } ROW LEVEL SECURITY
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -1945,11 +2062,14 @@ alter_partition_stmt:
 // %Text:
 // ALTER [MATERIALIZED] VIEW [IF EXISTS] <name> RENAME TO <newname>
 // ALTER [MATERIALIZED] VIEW [IF EXISTS] <name> SET SCHEMA <newschemaname>
+// ALTER MATERIALIZED VIEW [IF EXISTS] <name> SET ( <storage_parameter> = <value> [, ...] )
+// ALTER MATERIALIZED VIEW [IF EXISTS] <name> RESET ( <storage_parameter> [, ...] )
 // %SeeAlso: WEBDOCS/alter-view.html
 alter_view_stmt:
   alter_rename_view_stmt
 | alter_view_set_schema_stmt
 | alter_view_owner_stmt
+| alter_view_storage_params_stmt
 // ALTER VIEW has its error help token here because the ALTER VIEW
 // prefix is spread over multiple non-terminals.
 | ALTER VIEW error // SHOW HELP: ALTER VIEW
@@ -2955,6 +3075,26 @@ alter_table_cmd:
       Params: $3.storageParamKeys(),
     }
   }
+  // ALTER TABLE <name> ENABLE ROW LEVEL SECURITY
+| ENABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityEnable}
+  }
+  // ALTER TABLE <name> DISABLE ROW LEVEL SECURITY
+| DISABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityDisable}
+  }
+  // ALTER TABLE <name> FORCE ROW LEVEL SECURITY
+| FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityForce}
+  }
+  // ALTER TABLE <name> NO FORCE ROW LEVEL SECURITY
+| NO FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Mode: tree.RowLevelSecurityNoForce}
+  }
 
 audit_mode:
   READ WRITE { $$.val = tree.AuditModeReadWrite }
@@ -3092,6 +3232,18 @@ identity_option_list:
 //   DROP ATTRIBUTE [IF EXISTS] <name> [ CASCADE | RESTRICT ]
 //   ALTER ATTRIBUTE <name> [ SET DATA ] TYPE <type> [ COLLATE <collation> ] [ CASCADE | RESTRICT ]
 //
+// ALTER DOMAIN <typename> <command>
+//
+// Domain commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name> [ CASCADE | RESTRICT ]
+//   ALTER DOMAIN ... RENAME CONSTRAINT <oldname> TO <newname>
+//   ALTER DOMAIN ... RENAME TO <newname>
+//   ALTER DOMAIN ... SET SCHEMA <newschemaname>
+//   ALTER DOMAIN ... OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+//
 // %SeeAlso: WEBDOCS/alter-type.html
 alter_type_stmt:
   ALTER TYPE type_name ADD VALUE SCONST opt_add_val_placement
@@ -3171,6 +3323,126 @@ alter_type_stmt:
     return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
+| ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{
+        Default: $6.expr(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropNotNull{},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name ADD CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Expr: $7.expr(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name ADD CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        ConstraintName: tree.Name($6),
+        Expr: $9.expr(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        IfExists: true,
+        Constraint: tree.Name($8),
+        DropBehavior: $9.dropBehavior(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name RENAME CONSTRAINT constraint_name TO constraint_name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainRenameConstraint{
+        Constraint: tree.Name($6),
+        NewName: tree.Name($8),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeRename{
+        NewName: tree.Name($6),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeSetSchema{
+        Schema: tree.Name($6),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeOwner{
+        Owner: $6.roleSpec(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER TYPE
 
 opt_add_val_placement:
   BEFORE SCONST
@@ -3244,7 +3516,7 @@ alter_attribute_action:
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
-// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA]
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA | INCREMENTAL]
 refresh_stmt:
   REFRESH MATERIALIZED VIEW opt_concurrently view_name opt_clear_data
   {
@@ -3254,6 +3526,14 @@ refresh_stmt:
       RefreshDataOption: $6.refreshDataOption(),
     }
   }
+| REFRESH MATERIALIZED VIEW opt_concurrently view_name INCREMENTAL
+  {
+    $$.val = &tree.RefreshMaterializedView{
+      Name: $5.unresolvedObjectName(),
+      Concurrently: $4.bool(),
+      Incremental: true,
+    }
+  }
 | REFRESH error // SHOW HELP: REFRESH
 
 opt_clear_data:
@@ -3984,11 +4264,7 @@ import_format:
   }
 
 alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
+  ALTER AGGREGATE error
   {
     return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
   }
@@ -4720,7 +4996,8 @@ create_extension_stmt:
 // %Text:
 // CREATE [ OR REPLACE ] FUNCTION
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
-//    [ RETURNS rettype ]
+//    [ RETURNS rettype
+//      | RETURNS TABLE ( column_name column_type [, ...] ) ]
 //  { LANGUAGE lang_name
 //    | { IMMUTABLE | STABLE | VOLATILE }
 //    | [ NOT ] LEAKPROOF
@@ -4730,7 +5007,7 @@ create_extension_stmt:
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set routine_return_type
+  RETURNS opt_return_set routine_return_type
   opt_create_routine_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToRoutineName()
@@ -4740,11 +5017,43 @@ create_func_stmt:
       Name: name,
       Params: $6.routineParams(),
       ReturnType: &tree.RoutineReturnType{
-        Type: $11.typeReference(),
-        SetOf: $10.bool(),
+        Type: $10.typeReference(),
+        SetOf: $9.bool(),
+      },
+      Options: $11.routineOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
+  RETURNS TABLE '(' table_func_column_list ')'
+  opt_create_routine_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToRoutineName()
+    params := $6.routineParams()
+    for i := range params {
+      if params[i].IsOutParam() {
+        return setErr(sqllex, pgerror.New(pgcode.Syntax, "OUT and INOUT arguments aren't allowed in TABLE functions"))
+      }
+    }
+    // RETURNS TABLE is shorthand for OUT parameters and a SETOF return type.
+    // The return type is the type of the only column, or RECORD if there are
+    // multiple columns.
+    cols := $11.routineParams()
+    var retType tree.ResolvableTypeReference = types.AnyTuple
+    if len(cols) == 1 {
+      retType = cols[0].Type
+    }
+    $$.val = &tree.CreateRoutine{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      Name: name,
+      Params: append(params, cols...),
+      ReturnType: &tree.RoutineReturnType{
+        Type: retType,
+        SetOf: true,
       },
-      Options: $12.routineOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $13.routineOptions(),
+      RoutineBody: $14.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
@@ -4793,10 +5102,6 @@ opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -4870,12 +5175,29 @@ routine_param:
     }
   }
 
+table_func_column_list:
+  table_func_column { $$.val = tree.RoutineParams{$1.routineParam()} }
+| table_func_column_list ',' table_func_column
+  {
+    $$.val = append($1.routineParams(), $3.routineParam())
+  }
+
+table_func_column:
+  param_name routine_param_type
+  {
+    $$.val = tree.RoutineParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.RoutineParamOut,
+    }
+  }
+
 routine_param_class:
   IN { $$.val = tree.RoutineParamIn }
 | OUT { $$.val = tree.RoutineParamOut }
 | INOUT { $$.val = tree.RoutineParamInOut }
 | IN OUT { $$.val = tree.RoutineParamInOut }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| VARIADIC { $$.val = tree.RoutineParamVariadic }
 
 routine_param_type:
   typename
@@ -5047,41 +5369,672 @@ drop_func_stmt:
       DropBehavior: $4.dropBehavior(),
     }
   }
-| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-procedure.html
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Procedure: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Procedure: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE aggregate_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS aggregate_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) (
+//      SFUNC = sfunc,
+//      STYPE = state_data_type
+//      [ , FINALFUNC = ffunc ]
+//      [ , COMBINEFUNC = combinefunc ]
+//      [ , INITCOND = initial_condition ]
+//    )
+// %SeeAlso: DROP AGGREGATE, CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name aggregate_params '(' aggregate_option_list ')'
+  {
+    name := $4.unresolvedObjectName().ToRoutineName()
+    n, err := tree.NewCreateAggregate($2.bool(), name, $5.routineParams(), $7.aggregateOptions())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = []tree.AggregateOption{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  SFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionSFunc,
+      Routine: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| STYPE '=' typename
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionSType,
+      Type: $3.typeReference(),
+    }
+  }
+| FINALFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionFinalFunc,
+      Routine: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| COMBINEFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionCombineFunc,
+      Routine: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| INITCOND '=' SCONST
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionInitCond,
+      Value: $3,
+    }
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER name { BEFORE | AFTER | INSTEAD OF } { event [ OR ... ] }
+//    ON table_name
+//    [ REFERENCING { { OLD | NEW } TABLE [ AS ] transition_relation_name } [ ... ] ]
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    [ WHEN ( condition ) ]
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( arguments )
+//
+// where event can be one of:
+//
+//    INSERT
+//    UPDATE [ OF column_name [, ... ] ]
+//    DELETE
+//    TRUNCATE
+// %SeeAlso: DROP TRIGGER, CREATE FUNCTION
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list
+  ON table_name opt_trigger_transition_list trigger_for_each trigger_when
+  EXECUTE function_or_procedure func_name '(' trigger_func_args ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      TableName: $8.unresolvedObjectName(),
+      Transitions: $9.triggerTransitions(),
+      ForEach: $10.triggerForEach(),
+      When: $11.expr(),
+      FuncName: $14.unresolvedName(),
+      FuncArgs: $16.strs(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+| INSTEAD OF
+  {
+    $$.val = tree.TriggerActionTimeInsteadOf
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = []*tree.TriggerEvent{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventInsert}
+  }
+| DELETE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventDelete}
+  }
+| UPDATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate}
+  }
+| UPDATE OF name_list
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate, Columns: $3.nameList()}
+  }
+| TRUNCATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventTruncate}
+  }
+
+opt_trigger_transition_list:
+  REFERENCING trigger_transition_list
+  {
+    $$.val = $2.triggerTransitions()
+  }
+| /* EMPTY */
+  {
+    $$.val = []*tree.TriggerTransition(nil)
+  }
+
+trigger_transition_list:
+  trigger_transition
+  {
+    $$.val = []*tree.TriggerTransition{$1.triggerTransition()}
+  }
+| trigger_transition_list trigger_transition
+  {
+    $$.val = append($1.triggerTransitions(), $2.triggerTransition())
+  }
+
+trigger_transition:
+  transition_is_new TABLE opt_as name
+  {
+    $$.val = &tree.TriggerTransition{Name: tree.Name($4), IsNew: $1.bool()}
+  }
+
+transition_is_new:
+  NEW
+  {
+    $$.val = true
+  }
+| OLD
+  {
+    $$.val = false
+  }
+
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
+trigger_for_each:
+  FOR opt_each ROW
+  {
+    $$.val = tree.TriggerForEachRow
+  }
+| FOR opt_each STATEMENT
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+trigger_func_args:
+  trigger_func_arg
+  {
+    $$.val = []string{$1}
+  }
+| trigger_func_args ',' trigger_func_arg
+  {
+    $$.val = append($1.strs(), $3)
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+trigger_func_arg:
+  ICONST
+  {
+    $$ = $1.numVal().OrigString()
+  }
+| FCONST
+  {
+    $$ = $1.numVal().OrigString()
+  }
+| SCONST
+| unrestricted_name
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Trigger: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      IfExists: true,
+      Trigger: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: CREATE POLICY - define a new row-level security policy for a table
+// %Category: DDL
+// %Text:
+// CREATE POLICY name ON table_name
+//    [ AS { PERMISSIVE | RESTRICTIVE } ]
+//    [ FOR { ALL | SELECT | INSERT | UPDATE | DELETE } ]
+//    [ TO role_name [, ...] ]
+//    [ USING ( using_expression ) ]
+//    [ WITH CHECK ( check_expression ) ]
+// %SeeAlso: DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_type opt_policy_command opt_policy_roles
+  opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.CreatePolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      Type: $6.policyType(),
+      Cmd: $7.policyCommand(),
+      Roles: $8.roleSpecList(),
+      Using: $9.expr(),
+      WithCheck: $10.expr(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_type:
+  AS PERMISSIVE
+  {
+    $$.val = tree.PolicyTypePermissive
+  }
+| AS RESTRICTIVE
+  {
+    $$.val = tree.PolicyTypeRestrictive
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyTypePermissive
+  }
+
+opt_policy_command:
+  FOR ALL
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+| FOR SELECT
+  {
+    $$.val = tree.PolicyCommandSelect
+  }
+| FOR INSERT
+  {
+    $$.val = tree.PolicyCommandInsert
+  }
+| FOR UPDATE
+  {
+    $$.val = tree.PolicyCommandUpdate
+  }
+| FOR DELETE
+  {
+    $$.val = tree.PolicyCommandDelete
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_using:
+  USING '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_policy_with_check:
+  WITH CHECK '(' a_expr ')'
+  {
+    $$.val = $4.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+// %Help: DROP POLICY - remove a row-level security policy from a table
+// %Category: DDL
+// %Text: DROP POLICY [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      Policy: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      IfExists: true,
+      Policy: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
+// %Help: CREATE COLLATION - define a new collation
+// %Category: DDL
+// %Text:
+// CREATE COLLATION [ IF NOT EXISTS ] <name> (
+//    [ LOCALE = <locale>, ]
+//    [ LC_COLLATE = <lc_collate>, ]
+//    [ LC_CTYPE = <lc_ctype>, ]
+//    [ PROVIDER = <provider>, ]
+//    [ DETERMINISTIC = <boolean> ]
+// )
+// CREATE COLLATION [ IF NOT EXISTS ] <name> FROM <existing_collation>
+// %SeeAlso: DROP COLLATION
+create_collation_stmt:
+  CREATE COLLATION name '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.CreateCollation{Name: tree.Name($3), Options: $5.storageParams()}
+  }
+| CREATE COLLATION IF NOT EXISTS name '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.CreateCollation{Name: tree.Name($6), IfNotExists: true, Options: $8.storageParams()}
+  }
+| CREATE COLLATION name FROM name
+  {
+    $$.val = &tree.CreateCollation{Name: tree.Name($3), From: tree.Name($5)}
+  }
+| CREATE COLLATION IF NOT EXISTS name FROM name
+  {
+    $$.val = &tree.CreateCollation{Name: tree.Name($6), IfNotExists: true, From: tree.Name($8)}
+  }
+| CREATE COLLATION error // SHOW HELP: CREATE COLLATION
+
+// %Help: DROP COLLATION - remove a collation
+// %Category: DDL
+// %Text: DROP COLLATION [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE COLLATION
+drop_collation_stmt:
+  DROP COLLATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropCollation{Names: $3.nameList(), DropBehavior: $4.dropBehavior()}
+  }
+| DROP COLLATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropCollation{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP COLLATION error // SHOW HELP: DROP COLLATION
+
+// %Help: CREATE PUBLICATION - define a new publication for logical replication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name>
+//    [ FOR ALL TABLES | FOR TABLE <tablename> [, ...] ]
+//    [ WITH ( publish = '<operation> [, ...]' ) ]
+// %SeeAlso: DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name opt_with_storage_parameter_list
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), Options: $4.storageParams()}
+  }
+| CREATE PUBLICATION name FOR ALL TABLES opt_with_storage_parameter_list
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), AllTables: true, Options: $7.storageParams()}
+  }
+| CREATE PUBLICATION name FOR TABLE table_name_list opt_with_storage_parameter_list
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), Tables: $6.tableNames(), Options: $7.storageParams()}
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $3.nameList(), DropBehavior: $4.dropBehavior()}
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: CREATE SERVER - define a new foreign server
+// %Category: DDL
+// %Text:
+// CREATE SERVER [ IF NOT EXISTS ] server_name
+//    FOREIGN DATA WRAPPER fdw_name
+//    [ OPTIONS ( option 'value' [, ... ] ) ]
+// %SeeAlso: CREATE FOREIGN TABLE, DROP SERVER
+create_server_stmt:
+  CREATE SERVER name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($3),
+      Wrapper: tree.Name($7),
+      Options: $8.foreignOptions(),
+    }
+  }
+| CREATE SERVER IF NOT EXISTS name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      IfNotExists: true,
+      Name: tree.Name($6),
+      Wrapper: tree.Name($10),
+      Options: $11.foreignOptions(),
+    }
+  }
+| CREATE SERVER error // SHOW HELP: CREATE SERVER
+
+// %Help: CREATE FOREIGN TABLE - define a new foreign table
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [ IF NOT EXISTS ] table_name ( [ <column_def> [, ...] ] )
+//    SERVER server_name
+//    [ OPTIONS ( option 'value' [, ... ] ) ]
+// %SeeAlso: CREATE SERVER, DROP FOREIGN TABLE
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      Defs: $6.tblDefs(),
+      Server: tree.Name($9),
+      Options: $10.foreignOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      IfNotExists: true,
+      Table: $7.unresolvedObjectName().ToTableName(),
+      Defs: $9.tblDefs(),
+      Server: tree.Name($12),
+      Options: $13.foreignOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+opt_foreign_options:
+  OPTIONS '(' foreign_option_list ')'
+  {
+    $$.val = $3.foreignOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ForeignOptions(nil)
+  }
+
+foreign_option_list:
+  foreign_option
+  {
+    $$.val = tree.ForeignOptions{$1.foreignOption()}
+  }
+| foreign_option_list ',' foreign_option
+  {
+    $$.val = append($1.foreignOptions(), $3.foreignOption())
+  }
+
+foreign_option:
+  name SCONST
+  {
+    $$.val = tree.ForeignOption{Name: tree.Name($1), Value: $2}
+  }
+
+// %Help: DROP SERVER - remove a foreign server
+// %Category: DDL
+// %Text: DROP SERVER [ IF EXISTS ] server_name [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE SERVER
+drop_server_stmt:
+  DROP SERVER name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropServer{
+      Names: $3.nameList(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP SERVER IF EXISTS name_list opt_drop_behavior
   {
-    $$.val = &tree.DropRoutine{
+    $$.val = &tree.DropServer{
+      Names: $5.nameList(),
       IfExists: true,
-      Routines: $5.routineObjs(),
       DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+| DROP SERVER error // SHOW HELP: DROP SERVER
 
-// %Help: DROP PROCEDURE - remove a procedure
+// %Help: DROP FOREIGN TABLE - remove a foreign table
 // %Category: DDL
-// %Text:
-// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
-//    [ CASCADE | RESTRICT ]
-// %SeeAlso: WEBDOCS/drop-procedure.html
-drop_proc_stmt:
-  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+// %Text: DROP FOREIGN TABLE [ IF EXISTS ] table_name [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE FOREIGN TABLE
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropRoutine{
-      Procedure: true,
-      Routines: $3.routineObjs(),
-      DropBehavior: $4.dropBehavior(),
+    $$.val = &tree.DropForeignTable{
+      Names: $4.tableNames(),
+      DropBehavior: $5.dropBehavior(),
     }
   }
-| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropRoutine{
+    $$.val = &tree.DropForeignTable{
+      Names: $6.tableNames(),
       IfExists: true,
-      Procedure: true,
-      Routines: $5.routineObjs(),
-      DropBehavior: $6.dropBehavior(),
+      DropBehavior: $7.dropBehavior(),
     }
   }
-| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
 
 function_with_paramtypes_list:
   function_with_paramtypes
@@ -5093,6 +6046,40 @@ function_with_paramtypes_list:
     $$.val = append($1.routineObjs(), $3.functionObj())
   }
 
+aggregate_with_paramtypes_list:
+  aggregate_with_paramtypes
+  {
+    $$.val = tree.RoutineObjs{$1.functionObj()}
+  }
+  | aggregate_with_paramtypes_list ',' aggregate_with_paramtypes
+  {
+    $$.val = append($1.routineObjs(), $3.functionObj())
+  }
+
+aggregate_with_paramtypes:
+  db_object_name aggregate_params
+  {
+    $$.val = tree.RoutineObj{
+      FuncName: $1.unresolvedObjectName().ToRoutineName(),
+      Params: $2.routineParams(),
+    }
+  }
+  | db_object_name
+  {
+    $$.val = tree.RoutineObj{
+      FuncName: $1.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+
+// aggregate_params is the parameter list of an aggregate function, where '*'
+// stands for an aggregate that takes no arguments.
+aggregate_params:
+  func_params
+  | '(' '*' ')'
+  {
+    $$.val = tree.RoutineParams{}
+  }
+
 function_with_paramtypes:
   db_object_name func_params
   {
@@ -5230,22 +6217,17 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -5257,23 +6239,16 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
-| DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -5288,6 +6263,13 @@ create_ddl_stmt:
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
+| create_collation_stmt // EXTEND WITH HELP: CREATE COLLATION
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
+| create_server_stmt   // EXTEND WITH HELP: CREATE SERVER
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5674,6 +6656,13 @@ drop_ddl_stmt:
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
+| drop_collation_stmt // EXTEND WITH HELP: DROP COLLATION
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
+| drop_server_stmt   // EXTEND WITH HELP: DROP SERVER
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5788,7 +6777,9 @@ drop_database_stmt:
 
 // %Help: DROP TYPE - remove a type
 // %Category: DDL
-// %Text: DROP TYPE [IF EXISTS] <type_name> [, ...] [CASCASE | RESTRICT]
+// %Text:
+// DROP TYPE [IF EXISTS] <type_name> [, ...] [CASCASE | RESTRICT]
+// DROP DOMAIN [IF EXISTS] <type_name> [, ...] [CASCASE | RESTRICT]
 drop_type_stmt:
   DROP TYPE type_name_list opt_drop_behavior
   {
@@ -5807,6 +6798,24 @@ drop_type_stmt:
     }
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
+| DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+      Domain: true,
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+      Domain: true,
+    }
+  }
 
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
@@ -6029,6 +7038,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6755,7 +7765,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -7235,6 +8245,33 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the check timing of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -9750,6 +10787,7 @@ alter_schema_stmt:
 //    FOREIGN KEY ( <colnames...> ) REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //    UNIQUE ( <colnames...> ) [{STORING | INCLUDE | COVERING} ( <colnames...> )]
 //    CHECK ( <expr> )
+//    EXCLUDE [USING <method>] ( <colname> WITH <operator> [, ...] ) [WHERE <expr>]
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | NOT VISIBLE | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr> | ON UPDATE <expr> | GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [( <opt_sequence_option_list> )]}
@@ -10260,7 +11298,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -10268,6 +11306,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -10424,6 +11463,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability().Deferrable {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable")
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -10431,6 +11473,12 @@ constraint_elem:
 | UNIQUE opt_without_index '(' index_params ')'
     opt_storing opt_partition_by_index opt_deferrable opt_where_clause
   {
+    if $8.constraintDeferrability().Deferrable && !$2.bool() {
+      // Only UNIQUE WITHOUT INDEX constraints are checked by queries that can
+      // be deferred.
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported,
+        "only UNIQUE WITHOUT INDEX constraints can be DEFERRABLE"))
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       WithoutIndex: $2.bool(),
       IndexTableDef: tree.IndexTableDef{
@@ -10439,6 +11487,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -10462,11 +11511,53 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE opt_exclusion_access_method '(' exclusion_elem_list ')' opt_where_clause opt_deferrable
+  {
+    $$.val = tree.NewExclusionConstraintTableDef(
+      tree.Name($2), $4.exclusionElems(), $6.expr(), $7.constraintDeferrability(),
+    )
+  }
+
+opt_exclusion_access_method:
+  USING name
+  {
+    switch $2 {
+      case "gist", "btree":
+        $$ = $2
+      case "gin", "hash", "spgist", "brin":
+        return unimplemented(sqllex, "exclude using " + $2)
+      default:
+        sqllex.Error("unrecognized access method: " + $2)
+        return 1
     }
   }
-| EXCLUDE USING error
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclusion_elem_list:
+  exclusion_elem
+  {
+    $$.val = []tree.ExclusionElem{$1.exclusionElem()}
+  }
+| exclusion_elem_list ',' exclusion_elem
+  {
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
+  }
+
+exclusion_elem:
+  name WITH all_op
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExclusionElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -10575,12 +11666,31 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| INITIALLY DEFERRED
+  {
+    // INITIALLY DEFERRED implies DEFERRABLE.
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
 
 storing:
   COVERING
@@ -10959,11 +12069,16 @@ role_or_group_or_user:
 // %Category: DDL
 // %Text:
 // CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
-// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
+// CREATE [TEMPORARY | TEMP] RECURSIVE VIEW [IF NOT EXISTS] <viewname> ( <colnames...> ) AS <source>
+// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )]
+//   [WITH ( <storage_parameter> = <value> [, ...] )] AS <source> [WITH [NO] DATA]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
   {
+    if $3.bool() && len($6.nameList()) == 0 {
+      return recursiveViewWithoutColumnList(sqllex)
+    }
     name := $5.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
@@ -10972,12 +12087,16 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: false,
       Replace: false,
+      Recursive: $3.bool(),
     }
   }
 // We cannot use a rule like opt_or_replace here as that would cause a conflict
 // with the opt_temp rule.
 | CREATE OR REPLACE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
   {
+    if $5.bool() && len($8.nameList()) == 0 {
+      return recursiveViewWithoutColumnList(sqllex)
+    }
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
@@ -10986,10 +12105,14 @@ create_view_stmt:
       Persistence: $4.persistence(),
       IfNotExists: false,
       Replace: true,
+      Recursive: $5.bool(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
   {
+    if $3.bool() && len($9.nameList()) == 0 {
+      return recursiveViewWithoutColumnList(sqllex)
+    }
     name := $8.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
@@ -10998,29 +12121,32 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: true,
       Replace: false,
+      Recursive: $3.bool(),
     }
   }
-| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_with_data
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $5.nameList(),
-      AsSource: $7.slct(),
+      AsSource: $8.slct(),
       Materialized: true,
-      WithData: $8.bool(),
+      WithData: $9.bool(),
+      StorageParams: $6.storageParams(),
     }
   }
-| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_with_data
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $8.nameList(),
-      AsSource: $10.slct(),
+      AsSource: $11.slct(),
       Materialized: true,
       IfNotExists: true,
-      WithData: $11.bool(),
+      WithData: $12.bool(),
+      StorageParams: $9.storageParams(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
@@ -11147,6 +12273,14 @@ role_option:
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
   }
+| BYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOBYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
 
 role_options:
   role_option
@@ -11189,13 +12323,22 @@ subject_clause:
   }
 
 opt_view_recursive:
-  /* EMPTY */ { /* no error */ }
-| RECURSIVE { return unimplemented(sqllex, "create recursive view") }
+  /* EMPTY */
+  {
+    $$.val = false
+  }
+| RECURSIVE
+  {
+    $$.val = true
+  }
 
 
 // %Help: CREATE TYPE - create a type
 // %Category: DDL
-// %Text: CREATE TYPE [IF NOT EXISTS] <type_name> AS ENUM (...)
+// %Text:
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS ENUM (...)
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS (<label> <type> [, ...])
+// CREATE DOMAIN <type_name> [AS] <type> [DEFAULT <expr>] [[CONSTRAINT <name>] {NOT NULL | NULL | CHECK (<expr>)} ...]
 create_type_stmt:
   // Enum types.
   CREATE TYPE type_name AS ENUM '(' opt_enum_val_list ')'
@@ -11241,7 +12384,19 @@ create_type_stmt:
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
   // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+| CREATE DOMAIN type_name opt_as typename col_qual_list
+  {
+    name := $3.unresolvedObjectName()
+    def, err := tree.NewDomainDef(name, $5.typeReference(), $6.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.CreateType{
+      TypeName: name,
+      Variety: tree.Domain,
+      DomainDef: def,
+    }
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -11318,7 +12473,9 @@ create_index_stmt:
       PartitionByIndex: $14.partitionByIndex(),
       StorageParams:    $15.storageParams(),
       Predicate:        $16.expr(),
-      Inverted:         $8.bool(),
+      Inverted:         $8 == "inverted",
+      BlockRange:       $8 == "brin",
+      Hash:             $8 == "hash",
       Concurrently:     $4.bool(),
       Invisibility:     $17.indexInvisibility(),
     }
@@ -11335,7 +12492,9 @@ create_index_stmt:
       Sharded:          $15.shardedIndexDef(),
       Storing:          $16.nameList(),
       PartitionByIndex: $17.partitionByIndex(),
-      Inverted:         $11.bool(),
+      Inverted:         $11 == "inverted",
+      BlockRange:       $11 == "brin",
+      Hash:             $11 == "hash",
       StorageParams:    $18.storageParams(),
       Predicate:        $19.expr(),
       Concurrently:     $4.bool(),
@@ -11385,10 +12544,12 @@ opt_index_access_method:
     /* FORCE DOC */
     switch $2 {
       case "gin", "gist":
-        $$.val = true
+        $$ = "inverted"
       case "btree":
-        $$.val = false
-      case "hash", "spgist", "brin":
+        $$ = ""
+      case "brin", "hash":
+        $$ = $2
+      case "spgist":
         return unimplemented(sqllex, "index using " + $2)
       default:
         sqllex.Error("unrecognized access method: " + $2)
@@ -11397,7 +12558,7 @@ opt_index_access_method:
   }
 | /* EMPTY */
   {
-    $$.val = false
+    $$ = ""
   }
 
 opt_concurrently:
@@ -11731,6 +12892,40 @@ alter_view_owner_stmt:
     }
   }
 
+alter_view_storage_params_stmt:
+  ALTER MATERIALIZED VIEW relation_expr SET '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.AlterMaterializedViewStorageParams{
+      Name: $4.unresolvedObjectName(),
+      IfExists: false,
+      Cmd: &tree.AlterTableSetStorageParams{StorageParams: $7.storageParams()},
+    }
+  }
+| ALTER MATERIALIZED VIEW IF EXISTS relation_expr SET '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.AlterMaterializedViewStorageParams{
+      Name: $6.unresolvedObjectName(),
+      IfExists: true,
+      Cmd: &tree.AlterTableSetStorageParams{StorageParams: $9.storageParams()},
+    }
+  }
+| ALTER MATERIALIZED VIEW relation_expr RESET '(' storage_parameter_key_list ')'
+  {
+    $$.val = &tree.AlterMaterializedViewStorageParams{
+      Name: $4.unresolvedObjectName(),
+      IfExists: false,
+      Cmd: &tree.AlterTableResetStorageParams{Params: $7.storageParamKeys()},
+    }
+  }
+| ALTER MATERIALIZED VIEW IF EXISTS relation_expr RESET '(' storage_parameter_key_list ')'
+  {
+    $$.val = &tree.AlterMaterializedViewStorageParams{
+      Name: $6.unresolvedObjectName(),
+      IfExists: true,
+      Cmd: &tree.AlterTableResetStorageParams{Params: $9.storageParamKeys()},
+    }
+  }
+
 alter_sequence_set_schema_stmt:
 	ALTER SEQUENCE relation_expr SET SCHEMA schema_name
 	 {
@@ -12652,6 +13847,109 @@ returning_clause:
     $$.val = tree.AbsentReturningClause
   }
 
+// %Help: MERGE - conditionally insert, update or delete rows of a table
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//        USING <source> ON <expr>
+//        WHEN MATCHED [AND <expr>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//        WHEN NOT MATCHED [AND <expr>] THEN
+//          { INSERT [(<colnames...>)] { VALUES (<exprs...>) | DEFAULT VALUES } | DO NOTHING }
+//        [...]
+//        [RETURNING <exprs...>]
+// %SeeAlso: INSERT, UPDATE, DELETE, UPSERT
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list returning_clause
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+      Returning: $10.retClause(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED opt_merge_when_cond THEN merge_update_or_delete
+  {
+    when := $5.mergeWhen()
+    when.Matched = true
+    when.Cond = $3.expr()
+    $$.val = when
+  }
+| WHEN MATCHED opt_merge_when_cond THEN merge_do_nothing
+  {
+    when := $5.mergeWhen()
+    when.Matched = true
+    when.Cond = $3.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN merge_insert
+  {
+    when := $6.mergeWhen()
+    when.Cond = $4.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN merge_do_nothing
+  {
+    when := $6.mergeWhen()
+    when.Cond = $4.expr()
+    $$.val = when
+  }
+
+opt_merge_when_cond:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_update_or_delete:
+  UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeUpdate, Exprs: $3.updateExprs()}
+  }
+| DELETE
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDelete}
+  }
+
+merge_insert:
+  INSERT VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert, Values: $4.exprs()}
+  }
+| INSERT '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert, Columns: $3.nameList(), Values: $7.exprs()}
+  }
+| INSERT DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert}
+  }
+
+merge_do_nothing:
+  DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDoNothing}
+  }
+
 // %Help: UPDATE - update rows of a table
 // %Category: DML
 // %Text:
@@ -13399,9 +14697,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.ExplicitGroupingSets, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13980,6 +15287,32 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
+// %Help: LISTEN - listen for a notification
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - generate a notification
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
 // UNLISTEN
 unlisten_stmt:
    UNLISTEN type_name
@@ -14205,8 +15538,6 @@ simple_typename:
   }
 | const_typename
 | interval_type
-| POINT error { return unimplementedWithIssueDetail(sqllex, 21286, "point") } // needed or else it generates a syntax error.
-| POLYGON error { return unimplementedWithIssueDetail(sqllex, 21286, "polygon") } // needed or else it generates a syntax error.
 
 geo_shape_type:
   POINT { $$.val = geopb.ShapeType_Point }
@@ -14246,6 +15577,8 @@ const_geo:
   GEOGRAPHY { $$.val = types.Geography }
 | GEOMETRY  { $$.val = types.Geometry }
 | BOX2D     { $$.val = types.Box2D }
+| POINT     { $$.val = types.Point }
+| POLYGON   { $$.val = types.Polygon }
 | GEOMETRY '(' geo_shape_type ')'
   {
     $$.val = types.MakeGeometry($3.geoShapeType(), 0)
@@ -14873,6 +16206,18 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr RANGE_ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr SAME_AS a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Same), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr DISTANCE a_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -15427,7 +16772,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -15438,8 +16786,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
-| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
+| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_application_name '(' ALL expr_list opt_sort_clause_no_index ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -15765,6 +17119,16 @@ special_function:
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
   }
 | GREATEST '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POINT '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POINT '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POLYGON '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POLYGON '(' error { return helpWithFunctionByName(sqllex, $1) }
 | LEAST '(' expr_list ')'
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
@@ -16085,6 +17449,9 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| RANGE_ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
+| SAME_AS { $$.val = treecmp.MakeComparisonOperator(treecmp.Same) }
+| DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.Distance) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -17047,6 +18414,7 @@ unreserved_keyword:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -17061,6 +18429,7 @@ unreserved_keyword:
 | CLUSTER
 | CLUSTERS
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17108,10 +18477,13 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -17137,6 +18509,7 @@ unreserved_keyword:
 | FAILURE
 | FILES
 | FILTER
+| FINALFUNC
 | FIRST
 | FOLLOWING
 | FORMAT
@@ -17185,9 +18558,11 @@ unreserved_keyword:
 | INDEX
 | INDEXES
 | INHERITS
+| INITCOND
 | INJECT
 | INPUT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | INVISIBLE
@@ -17215,6 +18590,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
@@ -17222,6 +18598,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17247,15 +18624,18 @@ unreserved_keyword:
 | NAMES
 | NAN
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
+| NOBYPASSRLS
 | NOCREATEDB
 | NOCREATELOGIN
 | NOCANCELQUERY
@@ -17278,6 +18658,7 @@ unreserved_keyword:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | OPERATOR
 | OPT
@@ -17297,6 +18678,7 @@ unreserved_keyword:
 | PAUSE
 | PAUSED
 | PER
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLAN
@@ -17304,6 +18686,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -17329,6 +18712,7 @@ unreserved_keyword:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17347,6 +18731,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -17368,6 +18753,7 @@ unreserved_keyword:
 | SCROLL
 | SETTING
 | SETTINGS
+| SFUNC
 | STATUS
 | SAVEPOINT
 | SCANS
@@ -17407,6 +18793,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -17416,6 +18803,7 @@ unreserved_keyword:
 | STORE
 | STORED
 | STORING
+| STYPE
 | STRAIGHT
 | STREAM
 | STRICT
@@ -17484,6 +18872,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WRAPPER
 | WRITE
 | YEAR
 | ZONE
@@ -17535,6 +18924,7 @@ bare_label_keywords:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -17556,6 +18946,7 @@ bare_label_keywords:
 | COLLATION
 | COLUMN
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17617,13 +19008,16 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DISTINCT
 | DO
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ELSE
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_INFO_DIR
@@ -17654,6 +19048,7 @@ bare_label_keywords:
 | FALSE
 | FAMILY
 | FILES
+| FINALFUNC
 | FIRST
 | FLOAT
 | FOLLOWING
@@ -17717,6 +19112,7 @@ bare_label_keywords:
 | INDEX_BEFORE_NAME_THEN_PAREN
 | INDEX_BEFORE_PAREN
 | INHERITS
+| INITCOND
 | INITIALLY
 | INJECT
 | INNER
@@ -17724,6 +19120,7 @@ bare_label_keywords:
 | INPUT
 | INSENSITIVE
 | INSERT
+| INSTEAD
 | INT
 | INTEGER
 | INTERVAL
@@ -17762,6 +19159,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17771,6 +19169,7 @@ bare_label_keywords:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17795,10 +19194,12 @@ bare_label_keywords:
 | NAN
 | NATURAL
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
+| NOBYPASSRLS
 | NOCANCELQUERY
 | NOCONTROLCHANGEFEED
 | NOCONTROLJOB
@@ -17816,6 +19217,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -17830,6 +19232,7 @@ bare_label_keywords:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | ONLY
 | OPERATOR
@@ -17853,6 +19256,7 @@ bare_label_keywords:
 | PAUSE
 | PAUSED
 | PER
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLACING
@@ -17862,6 +19266,7 @@ bare_label_keywords:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGON
 | POLYGONM
 | POLYGONZ
@@ -17892,6 +19297,7 @@ bare_label_keywords:
 | REDACT
 | REF
 | REFERENCES
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17910,6 +19316,7 @@ bare_label_keywords:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -17954,6 +19361,7 @@ bare_label_keywords:
 | SETS
 | SETTING
 | SETTINGS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -17976,6 +19384,7 @@ bare_label_keywords:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STATUS
@@ -17986,6 +19395,7 @@ bare_label_keywords:
 | STORE
 | STORED
 | STORING
+| STYPE
 | STRAIGHT
 | STREAM
 | STRICT
@@ -18074,6 +19484,7 @@ bare_label_keywords:
 | VOTERS
 | WHEN
 | WORK
+| WRAPPER
 | WRITE
 | ZONE
 
@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -638,6 +639,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				return err
 			}
 
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
 				return err
@@ -652,7 +657,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				tree.MakeDBool(isRoot || createDB),   // rolcreatedb
 				tree.MakeDBool(roleCanLogin),         // rolcanlogin.
 				tree.DBoolFalse,                      // rolreplication
-				tree.DBoolFalse,                      // rolbypassrls
+				tree.MakeDBool(bypassRLS),            // rolbypassrls
 				negOneVal,                            // rolconnlimit
 				passwdStarString,                     // rolpassword
 				rolValidUntil,                        // rolvaliduntil
@@ -713,6 +718,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -742,6 +748,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			relKind = relKindSequence
 			relAm = oidZero
 			replIdent = "n"
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
+			replIdent = "n"
 		}
 		relPersistence := relPersistencePermanent
 		if table.IsTemporary() {
@@ -793,23 +803,24 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.DNull,      // relacl
 			relOptions,      // reloptions
 			// These columns were automatically created by pg_catalog_test's missing column generator.
-			tree.DNull,                 // relforcerowsecurity
+			tree.MakeDBool(tree.DBool(table.IsRowLevelSecurityForced())), // relforcerowsecurity
 			tree.DNull,                 // relispartition
 			tree.DNull,                 // relispopulated
 			tree.NewDString(replIdent), // relreplident
 			tree.DNull,                 // relrewrite
-			tree.DNull,                 // relrowsecurity
-			tree.DNull,                 // relpartbound
+			tree.MakeDBool(tree.DBool(table.IsRowLevelSecurityEnabled())), // relrowsecurity
+			tree.DNull, // relpartbound
 			// These columns were automatically created by pg_catalog_test's missing column generator.
 			tree.DNull, // relminmxid
 		); err != nil {
 			return err
 		}
 
-		// Skip adding indexes for sequences (their table descriptors have a primary
-		// index to make them comprehensible to backup/restore, but PG doesn't include
-		// an index in pg_class).
-		if table.IsSequence() {
+		// Skip adding indexes for sequences and foreign tables (their table
+		// descriptors have a primary index to make them comprehensible to
+		// backup/restore and the optimizer, but PG doesn't include an index in
+		// pg_class).
+		if table.IsSequence() || table.IsForeignTable() {
 			return nil
 		}
 
@@ -897,11 +908,32 @@ https://www.postgresql.org/docs/9.5/catalog-pg-collation.html`,
 					return err
 				}
 			}
+			// Collations created with CREATE COLLATION live in the public schema
+			// of their database.
+			publicSchemaOid := schemaOid(db.GetSchemaID(catconstants.PublicSchemaName))
+			for _, c := range db.GetCollations() {
+				if err := addRow(
+					h.CollationOid(c.Name),                      // oid
+					tree.NewDString(c.Name),                     // collname
+					publicSchemaOid,                             // collnamespace
+					tree.DNull,                                  // collowner
+					builtins.DatEncodingUTFId,                   // collencoding
+					tree.NewDString(c.Locale),                   // collcollate
+					tree.NewDString(c.Locale),                   // collctype
+					collProviderICU,                             // collprovider
+					tree.DNull,                                  // collversion
+					tree.MakeDBool(tree.DBool(c.Deterministic)), // collisdeterministic
+				); err != nil {
+					return err
+				}
+			}
 			return nil
 		})
 	},
 }
 
+var collProviderICU = tree.NewDString("i")
+
 var (
 	conTypeCheck     = tree.NewDString("c")
 	conTypeFK        = tree.NewDString("f")
@@ -912,7 +944,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -964,6 +995,7 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		conexclop := tree.DNull
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -1051,6 +1083,24 @@ func populateTableConstraints(
 				return err
 			}
 			condef = tree.NewDString(buf.String())
+		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+			contype = conTypeExclusion
+			conoid = h.UniqueWithoutIndexConstraintOid(
+				db.GetID(), sc.GetID(), table.GetID(), uwoi,
+			)
+			if conkey, err = colIDArrayToDatum(uwoi.UniqueWithoutIndexDesc().ColumnIDs); err != nil {
+				return err
+			}
+			if conexclop, err = exclusionOperatorOids(h, table, uwoi.UniqueWithoutIndexDesc()); err != nil {
+				return err
+			}
+			f := tree.NewFmtCtx(tree.FmtPGCatalog)
+			if err := formatExclusionConstraint(
+				ctx, f, table, uwoi, p.SemaCtx(), p.SessionData(), tree.FmtPGCatalog,
+			); err != nil {
+				return err
+			}
+			condef = tree.NewDString(f.CloseAndGetString())
 		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
 			contype = conTypeUnique
 			f := tree.NewFmtCtx(tree.FmtSimple)
@@ -1064,6 +1114,10 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			f.FormatNode(&tree.ConstraintDeferrability{
+				Deferrable:        uwoi.UniqueWithoutIndexDesc().Deferrable,
+				InitiallyDeferred: uwoi.UniqueWithoutIndexDesc().InitiallyDeferred,
+			})
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1094,13 +1148,14 @@ func populateTableConstraints(
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
 
+		deferrable, initiallyDeferred := constraintDeferrability(c)
 		if err := addRow(
-			conoid,                   // oid
-			dNameOrNull(c.GetName()), // conname
-			namespaceOid,             // connamespace
-			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			conoid,                                 // oid
+			dNameOrNull(c.GetName()),               // conname
+			namespaceOid,                           // connamespace
+			contype,                                // contype
+			tree.MakeDBool(tree.DBool(deferrable)), // condeferrable
+			tree.MakeDBool(tree.DBool(initiallyDeferred)),            // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1117,7 +1172,7 @@ func populateTableConstraints(
 			tree.DNull,     // conpfeqop
 			tree.DNull,     // conppeqop
 			tree.DNull,     // conffeqop
-			tree.DNull,     // conexclop
+			conexclop,      // conexclop
 			conbin,         // conbin
 			consrc,         // consrc
 			condef,         // condef
@@ -1310,6 +1365,41 @@ func colIDArrayToDatum(arr []descpb.ColumnID) (tree.Datum, error) {
 	return d, nil
 }
 
+// exclusionOperatorOids returns an array with the OIDs of the operators of the
+// given exclusion constraint, as listed in pg_operator.
+func exclusionOperatorOids(
+	h oidHasher, table catalog.TableDescriptor, uc *descpb.UniqueWithoutIndexConstraint,
+) (tree.Datum, error) {
+	ops, err := schemaexpr.ParseExclusionOperators(uc.ExclusionOperators)
+	if err != nil {
+		return nil, err
+	}
+	d := tree.NewDArray(types.Oid)
+	for i, op := range ops {
+		col, err := catalog.MustFindColumnByID(table, uc.ColumnIDs[i])
+		if err != nil {
+			return nil, err
+		}
+		overload, ok := schemaexpr.LookupExclusionOperator(op, col.GetType())
+		if !ok {
+			return nil, errors.AssertionFailedf(
+				"no overload for exclusion operator %s on type %s", op, col.GetType().SQLString(),
+			)
+		}
+		params, returnTyper := tree.GetParamsAndReturnType(overload)
+		opOid := h.OperatorOid(
+			op.String(),
+			tree.NewDOid(params.Types()[0].Oid()),
+			tree.NewDOid(params.Types()[1].Oid()),
+			tree.NewDOid(returnTyper(nil).Oid()),
+		)
+		if err := d.Append(opOid); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
 // colIDArrayToVector returns an INT2VECTOR containing the ColumnIDs, or NULL if
 // there are no ColumnIDs.
 func colIDArrayToVector(arr []descpb.ColumnID) (tree.Datum, error) {
@@ -1333,6 +1423,15 @@ https://www.postgresql.org/docs/9.6/catalog-pg-conversion.html`,
 	unimplemented: true,
 }
 
+// datCollate returns the LC_COLLATE of a database, which is the locale of its
+// default collation if it has one.
+func datCollate(db catalog.DatabaseDescriptor) tree.Datum {
+	if col := db.GetDefaultCollation(); col != "" {
+		return tree.NewDString(col)
+	}
+	return builtins.DatEncodingEnUTF8
+}
+
 var pgCatalogDatabaseTable = virtualSchemaTable{
 	comment: `available databases (incomplete)
 https://www.postgresql.org/docs/9.5/catalog-pg-database.html`,
@@ -1351,7 +1450,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-database.html`,
 					// If there is a change in encoding value for the database we must update
 					// the definitions of getdatabaseencoding within pg_builtin.
 					builtins.DatEncodingUTFId,  // encoding
-					builtins.DatEncodingEnUTF8, // datcollate
+					datCollate(db),             // datcollate
 					builtins.DatEncodingEnUTF8, // datctype
 					tree.DBoolFalse,            // datistemplate
 					tree.DBoolTrue,             // datallowconn
@@ -2632,12 +2731,17 @@ func addPgProcUDFRow(
 	var argNames tree.Datum
 	argNamesArray := tree.NewDArray(types.String)
 	foundAnyArgNames := false
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
 		if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
 			return err
 		}
-		// We only support IN arguments at the moment.
-		if err := argModes.Append(tree.NewDString("i")); err != nil {
+		argMode := "i"
+		if param.Class == catpb.Function_Param_VARIADIC {
+			argMode = "v"
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
+		}
+		if err := argModes.Append(tree.NewDString(argMode)); err != nil {
 			return err
 		}
 		if len(param.Name) > 0 {
@@ -2655,7 +2759,10 @@ func addPgProcUDFRow(
 	kind := tree.NewDString("f")
 	if fnDesc.IsProcedure() {
 		kind = tree.NewDString("p")
+	} else if fnDesc.IsAggregate() {
+		kind = tree.NewDString("a")
 	}
+	isAgg := tree.MakeDBool(tree.DBool(fnDesc.IsAggregate()))
 
 	lang := languageInternalOid
 	if fnDesc.GetLanguage() == catpb.Function_PLPGSQL {
@@ -2671,9 +2778,9 @@ func addPgProcUDFRow(
 		lang,            // prolang
 		tree.DNull,      // procost
 		tree.DNull,      // prorows
-		oidZero,         // provariadic
+		variadicType,    // provariadic
 		tree.DNull,      // protransform
-		tree.DBoolFalse, // proisagg
+		isAgg,           // proisagg
 		tree.DBoolFalse, // proiswindow
 		tree.DBoolFalse, // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
@@ -2883,6 +2990,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -2901,7 +3012,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					negOneVal,                             // rolconnlimit
 					passwdStarString,                      // rolpassword
 					rolValidUntil,                         // rolvaliduntil
-					tree.DBoolFalse,                       // rolbypassrls
+					tree.MakeDBool(bypassRLS),             // rolbypassrls
 					settings,                              // rolconfig
 				)
 			})
@@ -3204,17 +3315,16 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultiRange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3235,8 +3345,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
-	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3342,14 +3450,39 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
-	case types.VoidFamily:
-		// void does not have an array type.
+	case types.VoidFamily, types.TriggerFamily:
+		// void and trigger do not have an array type.
+	case types.RangeFamily, types.MultiRangeFamily:
+		typType = typTypeRange
+		if typ.Family() == types.MultiRangeFamily {
+			typType = typTypeMultiRange
+		}
+		// The wildcard anyrange and anymultirange types do not have array types.
+		if !types.IsWildcardRangeType(typ) {
+			typArray = tree.NewDOid(types.CalcArrayOid(typ))
+		}
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typTypMod := negOneVal
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		builtinPrefix = builtins.PGIOBuiltinPrefix(typ.DomainBaseType())
+		typBaseType = tree.NewDOid(typ.DomainBaseType().Oid())
+		typTypMod = tree.NewDInt(tree.DInt(typ.TypeModifier()))
+		if md := typ.TypeMeta.DomainData; md != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(md.NotNull))
+			if md.DefaultExpr != nil {
+				typDefault = tree.NewDString(*md.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3379,13 +3512,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
-		negOneVal,       // typtypmod
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
+		typTypMod,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3687,6 +3820,10 @@ https://www.postgresql.org/docs/9.5/view-pg-user.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -3698,7 +3835,7 @@ https://www.postgresql.org/docs/9.5/view-pg-user.html`,
 					tree.MakeDBool(isSuper || createDB),  // usecreatedb
 					tree.MakeDBool(isRoot || isSuper),    // usesuper
 					tree.DBoolFalse,                      // userepl
-					tree.DBoolFalse,                      // usebypassrls
+					tree.MakeDBool(bypassRLS),            // usebypassrls
 					passwdStarString,                     // passwd
 					validUntil,                           // valuntil
 					settings,                             // useconfig
@@ -3809,6 +3946,10 @@ https://www.postgresql.org/docs/13/view-pg-shadow.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
 				return err
@@ -3820,7 +3961,7 @@ https://www.postgresql.org/docs/13/view-pg-shadow.html`,
 				tree.MakeDBool(isRoot || createDB),   // usecreatedb
 				tree.MakeDBool(isRoot || isSuper),    // usesuper
 				tree.DBoolFalse,                      // userepl
-				tree.DBoolFalse,                      // usebypassrls
+				tree.MakeDBool(bypassRLS),            // usebypassrls
 				passwdStarString,                     // passwd
 				rolValidUntil,                        // valuntil
 				settings,                             // useconfig
@@ -4135,13 +4276,85 @@ var pgCatalogStatProgressBasebackupTable = virtualSchemaTable{
 	unimplemented: true,
 }
 
+var (
+	polCmdAll    = tree.NewDString("*")
+	polCmdSelect = tree.NewDString("r")
+	polCmdInsert = tree.NewDString("a")
+	polCmdUpdate = tree.NewDString("w")
+	polCmdDelete = tree.NewDString("d")
+)
+
 var pgCatalogPolicyTable = virtualSchemaTable{
-	comment: "pg_policy was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPolicy,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `row-level security policies
+https://www.postgresql.org/docs/current/catalog-pg-policy.html`,
+	schema: vtable.PgCatalogPolicy,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables have no policies */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i := range table.GetPolicies() {
+					policy := &table.GetPolicies()[i]
+					var polCmd tree.Datum
+					switch policy.Command {
+					case descpb.TableDescriptor_Policy_SELECT:
+						polCmd = polCmdSelect
+					case descpb.TableDescriptor_Policy_INSERT:
+						polCmd = polCmdInsert
+					case descpb.TableDescriptor_Policy_UPDATE:
+						polCmd = polCmdUpdate
+					case descpb.TableDescriptor_Policy_DELETE:
+						polCmd = polCmdDelete
+					default:
+						polCmd = polCmdAll
+					}
+					// Postgres uses the zero OID to represent the PUBLIC role.
+					polRoles := tree.NewDArray(types.Oid)
+					if len(policy.RoleNames) == 0 {
+						if err := polRoles.Append(oidZero); err != nil {
+							return err
+						}
+					}
+					for _, roleName := range policy.RoleNames {
+						role := username.MakeSQLUsernameFromPreNormalizedString(roleName)
+						roleOid := oidZero
+						if !role.IsPublicRole() {
+							roleOid = h.UserOid(role)
+						}
+						if err := polRoles.Append(roleOid); err != nil {
+							return err
+						}
+					}
+					polQual, polWithCheck := tree.DNull, tree.DNull
+					if policy.UsingExpr != "" {
+						expr, err := schemaexpr.FormatExprForDisplay(ctx, table, policy.UsingExpr, &p.semaCtx, p.SessionData(), tree.FmtPGCatalog)
+						if err != nil {
+							return err
+						}
+						polQual = tree.NewDString(expr)
+					}
+					if policy.WithCheckExpr != "" {
+						expr, err := schemaexpr.FormatExprForDisplay(ctx, table, policy.WithCheckExpr, &p.semaCtx, p.SessionData(), tree.FmtPGCatalog)
+						if err != nil {
+							return err
+						}
+						polWithCheck = tree.NewDString(expr)
+					}
+					if err := addRow(
+						h.PolicyOid(table.GetID(), policy.ID),           // oid
+						tree.NewDName(policy.Name),                      // polname
+						tableOid(table.GetID()),                         // polrelid
+						polCmd,                                          // polcmd
+						tree.MakeDBool(!tree.DBool(policy.Restrictive)), // polpermissive
+						polRoles,     // polroles
+						polQual,      // polqual
+						polWithCheck, // polwithcheck
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatArchiverTable = virtualSchemaTable{
@@ -4172,12 +4385,50 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications for logical replication
+https://www.postgresql.org/docs/current/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, pub := range db.GetPublications() {
+					if err := addRow(
+						h.PublicationOid(db.GetID(), pub.Name),          // oid
+						tree.NewDName(pub.Name),                         // pubname
+						h.UserOid(pub.OwnerProto.Decode()),              // pubowner
+						tree.MakeDBool(tree.DBool(pub.AllTables)),       // puballtables
+						tree.MakeDBool(tree.DBool(pub.PublishInsert)),   // pubinsert
+						tree.MakeDBool(tree.DBool(pub.PublishUpdate)),   // pubupdate
+						tree.MakeDBool(tree.DBool(pub.PublishDelete)),   // pubdelete
+						tree.MakeDBool(tree.DBool(pub.PublishTruncate)), // pubtruncate
+						tree.DBoolFalse, // pubviaroot
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
+}
+
+// publicationIncludesTable returns whether the publication includes the
+// table.
+func publicationIncludesTable(
+	pub *descpb.DatabaseDescriptor_Publication, table catalog.TableDescriptor,
+) bool {
+	if !table.IsTable() || table.IsVirtualTable() || table.IsTemporary() {
+		return false
+	}
+	if pub.AllTables {
+		return true
+	}
+	for _, id := range pub.TableIDs {
+		if id == table.GetID() {
+			return true
+		}
+	}
+	return false
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4230,8 +4481,8 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				tree.NewDString(string(name)),          /* name */
 				tree.NewDString(c.statement),           /* statement */
 				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
-				tree.DBoolFalse,                        /* is_binary */
-				tree.DBoolFalse,                        /* is_scrollable */
+				tree.MakeDBool(tree.DBool(c.binary)),   /* is_binary */
+				tree.MakeDBool(tree.DBool(c.scroll)),   /* is_scrollable */
 				tz,                                     /* creation_date */
 			); err != nil {
 				return err
@@ -4332,12 +4583,28 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables included in publications
+https://www.postgresql.org/docs/current/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables are not published */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				pubs := db.GetPublications()
+				for i := range pubs {
+					if !publicationIncludesTable(&pubs[i], table) {
+						continue
+					}
+					if err := addRow(
+						tree.NewDName(pubs[i].Name),    // pubname
+						tree.NewDName(sc.GetName()),    // schemaname
+						tree.NewDName(table.GetName()), // tablename
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4368,14 +4635,54 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `logical replication slots
+https://www.postgresql.org/docs/current/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemReplicationSlotsTable) {
+			return nil
+		}
+		slots, err := getReplicationSlots(ctx, p.InternalSQLTxn())
+		if err != nil {
+			return err
+		}
+		slotsByDB := make(map[descpb.ID][]replicationSlot)
+		for _, slot := range slots {
+			slotsByDB[slot.dbID] = append(slotsByDB[slot.dbID], slot)
+		}
+		return forEachDatabaseDesc(ctx, p, nil /* all databases */, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, slot := range slotsByDB[db.GetID()] {
+					confirmedFlushLSN := tree.NewDString(slot.confirmedFlushLSN.String())
+					if err := addRow(
+						tree.NewDName(slot.name),    // slot_name
+						tree.NewDName(slot.plugin),  // plugin
+						slotTypeLogical,             // slot_type
+						dbOid(db.GetID()),           // datoid
+						tree.NewDName(db.GetName()), // database
+						tree.DBoolFalse,             // temporary
+						tree.DBoolFalse,             // active
+						tree.DNull,                  // active_pid
+						tree.DNull,                  // xmin
+						tree.DNull,                  // catalog_xmin
+						confirmedFlushLSN,           // restart_lsn
+						confirmedFlushLSN,           // confirmed_flush_lsn
+						walStatusReserved,           // wal_status
+						tree.DNull,                  // safe_wal_size
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
+var (
+	slotTypeLogical   = tree.NewDString("logical")
+	walStatusReserved = tree.NewDString("reserved")
+)
+
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
 	comment: "pg_subscription_rel was created for compatibility and is currently unimplemented",
 	schema:  vtable.PgCatalogSubscriptionRel,
@@ -4644,12 +4951,30 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables explicitly added to publications
+https://www.postgresql.org/docs/current/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables are not published */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for _, pub := range db.GetPublications() {
+					for _, tableID := range pub.TableIDs {
+						if tableID != table.GetID() {
+							continue
+						}
+						if err := addRow(
+							h.PublicationRelOid(db.GetID(), pub.Name, tableID), // oid
+							h.PublicationOid(db.GetID(), pub.Name),             // prpubid
+							tableOid(tableID),                                  // prrelid
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4723,11 +5048,15 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
 	types.PGLSNFamily:       typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
+	types.MultiRangeFamily:  typCategoryRange,
+	types.GeometricFamily:   typCategoryGeometric,
 	types.RefCursorFamily:   typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4738,6 +5067,9 @@ func typCategory(typ *types.T) tree.Datum {
 	if typ.UserDefined() && typ.Family() == types.TupleFamily {
 		return typCategoryComposite
 	}
+	if types.IsWildcardRangeType(typ) {
+		return typCategoryPseudo
+	}
 	return datumToTypeCategory[typ.Family()]
 }
 
@@ -4848,11 +5180,66 @@ https://www.postgresql.org/docs/9.6/catalog-pg-aggregate.html`,
 						}
 					}
 				}
-				return nil
+				return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(scDesc catalog.SchemaDescriptor) error {
+					return scDesc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+						if !sig.IsAggregate {
+							return nil
+						}
+						fnDesc, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, sig.ID)
+						if err != nil {
+							return err
+						}
+						return addPgAggregateUDFRow(fnDesc, addRow)
+					})
+				})
 			})
 	},
 }
 
+// addPgAggregateUDFRow adds the pg_aggregate row for the given user-defined
+// aggregate function.
+func addPgAggregateUDFRow(
+	fnDesc catalog.FunctionDescriptor, addRow func(...tree.Datum) error,
+) error {
+	agg := fnDesc.FuncDesc().Aggregate
+	regprocForZeroOid := tree.NewDOidWithName(0, types.RegProc, "-")
+	supportFn := func(id descpb.ID) tree.Datum {
+		if id == descpb.InvalidID {
+			return regprocForZeroOid
+		}
+		return tree.NewDOidWithType(catid.FuncIDToOID(id), types.RegProc)
+	}
+	initVal := tree.DNull
+	if agg.InitCond != nil {
+		initVal = tree.NewDString(*agg.InitCond)
+	}
+	return addRow(
+		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())).AsRegProc(fnDesc.GetName()), // aggfnoid
+		tree.NewDString("n"),                // aggkind
+		zeroVal,                             // aggnumdirectargs
+		supportFn(agg.TransitionFunctionID), // aggtransfn
+		supportFn(agg.FinalFunctionID),      // aggfinalfn
+		supportFn(agg.CombineFunctionID),    // aggcombinefn
+		regprocForZeroOid,                   // aggserialfn
+		regprocForZeroOid,                   // aggdeserialfn
+		regprocForZeroOid,                   // aggmtransfn
+		regprocForZeroOid,                   // aggminvtransfn
+		regprocForZeroOid,                   // aggmfinalfn
+		tree.DBoolFalse,                     // aggfinalextra
+		tree.DBoolFalse,                     // aggmfinalextra
+		oidZero,                             // aggsortop
+		tree.NewDOid(agg.StateType.Oid()),   // aggtranstype
+		tree.DNull,                          // aggtransspace
+		tree.DNull,                          // aggmtranstype
+		tree.DNull,                          // aggmtransspace
+		initVal,                             // agginitval
+		tree.DNull,                          // aggminitval
+		// These columns were automatically created by pg_catalog_test's missing column generator.
+		tree.DNull, // aggfinalmodify
+		tree.DNull, // aggmfinalmodify
+	)
+}
+
 // oidHasher provides a consistent hashing mechanism for object identifiers in
 // pg_catalog tables, allowing for reliable joins across tables.
 //
@@ -4941,6 +5328,9 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	policyTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5133,6 +5523,28 @@ func stringOid(s string) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) PolicyOid(tableID descpb.ID, policyID descpb.PolicyID) *tree.DOid {
+	h.writeTypeTag(policyTypeTag)
+	h.writeTable(tableID)
+	h.writeUInt32(uint32(policyID))
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationRelOid(dbID descpb.ID, name string, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
 func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	h.writeTypeTag(castTypeTag)
 	h.writeUInt32(uint32(srcID))
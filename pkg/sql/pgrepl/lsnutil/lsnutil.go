@@ -11,15 +11,19 @@
 package lsnutil
 
 import (
-	"time"
-
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
-// HLCToLSN converts a HLC to a LSN.
+// HLCToLSN converts a HLC to a LSN. The LSN is the wall time of the HLC, so
+// LSNs are ordered like the timestamps they were derived from, and can be
+// converted back using LSNToHLC. The logical component of the HLC is lost.
 // It is in a separate package to prevent the `lsn` package importing `log`.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	return lsn.LSN(h.WallTime)
+}
+
+// LSNToHLC converts a LSN produced by HLCToLSN back to a HLC.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(l)}
 }
@@ -0,0 +1,312 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput implements the encoding of the messages of the pgoutput
+// logical decoding plugin, as well as the messages of the streaming
+// replication protocol which carry them.
+//
+// See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// PluginName is the name of the output plugin.
+const PluginName = "pgoutput"
+
+// ProtoVersion is the version of the logical replication protocol produced
+// by the encoder.
+const ProtoVersion = 1
+
+// MaxProtoVersion is the highest version of the logical replication protocol
+// a client can request. Later versions only differ from ProtoVersion when
+// streaming of in-progress transactions or two-phase commit is requested.
+const MaxProtoVersion = 4
+
+// Message types of the logical replication protocol.
+const (
+	msgBegin    byte = 'B'
+	msgCommit   byte = 'C'
+	msgRelation byte = 'R'
+	msgInsert   byte = 'I'
+	msgUpdate   byte = 'U'
+	msgDelete   byte = 'D'
+)
+
+// Message types of the streaming replication protocol, which are sent in
+// CopyData messages.
+const (
+	msgXLogData            byte = 'w'
+	msgPrimaryKeepalive    byte = 'k'
+	msgStandbyStatusUpdate byte = 'r'
+)
+
+// standbyStatusUpdateLen is the length of a standby status update message:
+// its type, three LSNs, the client's clock and the reply flag.
+const standbyStatusUpdateLen = 1 + 8*3 + 8 + 1
+
+// Tags of the tuples of Update and Delete messages, and of the values of a
+// tuple.
+const (
+	tupleNew byte = 'N'
+	tupleKey byte = 'K'
+
+	valueNull byte = 'n'
+	valueText byte = 't'
+)
+
+// ReplicaIdentityDefault is the replica identity of a relation whose old
+// tuples only include the columns of the primary key.
+const ReplicaIdentityDefault byte = 'd'
+
+// columnFlagKey is set on the columns which are part of the replica identity.
+const columnFlagKey byte = 1
+
+// pgEpoch is the epoch of the timestamps of the replication protocol.
+var pgEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Begin is the message sent at the start of a transaction.
+type Begin struct {
+	// FinalLSN is the LSN of the commit of the transaction.
+	FinalLSN   lsn.LSN
+	CommitTime time.Time
+	XID        uint32
+}
+
+// Commit is the message sent at the end of a transaction.
+type Commit struct {
+	// CommitLSN is the LSN of the commit of the transaction.
+	CommitLSN lsn.LSN
+	// EndLSN is the LSN following the commit.
+	EndLSN     lsn.LSN
+	CommitTime time.Time
+}
+
+// Column describes a column of a relation.
+type Column struct {
+	Name         string
+	TypeOID      oid.Oid
+	TypeModifier int32
+	// IsKey is set if the column is part of the replica identity of the
+	// relation, i.e. of its primary key.
+	IsKey bool
+}
+
+// Relation is the message describing a table. It is sent before the first
+// change to a table, and again after the table's schema has changed.
+type Relation struct {
+	OID             oid.Oid
+	Namespace       string
+	Name            string
+	ReplicaIdentity byte
+	Columns         []Column
+}
+
+// Value is the value of a column in a tuple, in the text format.
+type Value struct {
+	IsNull bool
+	Text   []byte
+}
+
+// Tuple is the set of values of the columns of a row, in the order of the
+// columns of the Relation message of its table.
+type Tuple []Value
+
+// Insert is the message sent for an inserted row.
+type Insert struct {
+	RelationOID oid.Oid
+	New         Tuple
+}
+
+// Update is the message sent for an updated row.
+type Update struct {
+	RelationOID oid.Oid
+	// Key are the old values of the key columns. It is only set if the key
+	// was changed by the update, in which case the values of the other
+	// columns are NULL.
+	Key Tuple
+	New Tuple
+}
+
+// Delete is the message sent for a deleted row.
+type Delete struct {
+	RelationOID oid.Oid
+	// Key are the values of the key columns of the deleted row. The values of
+	// the other columns are NULL.
+	Key Tuple
+}
+
+// AppendBegin appends the encoding of a Begin message to buf.
+func AppendBegin(buf []byte, m Begin) []byte {
+	buf = append(buf, msgBegin)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(m.FinalLSN))
+	buf = appendTime(buf, m.CommitTime)
+	return binary.BigEndian.AppendUint32(buf, m.XID)
+}
+
+// AppendCommit appends the encoding of a Commit message to buf.
+func AppendCommit(buf []byte, m Commit) []byte {
+	buf = append(buf, msgCommit)
+	buf = append(buf, 0 /* flags */)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(m.CommitLSN))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(m.EndLSN))
+	return appendTime(buf, m.CommitTime)
+}
+
+// AppendRelation appends the encoding of a Relation message to buf.
+func AppendRelation(buf []byte, m Relation) []byte {
+	buf = append(buf, msgRelation)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(m.OID))
+	buf = appendString(buf, m.Namespace)
+	buf = appendString(buf, m.Name)
+	buf = append(buf, m.ReplicaIdentity)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(m.Columns)))
+	for _, c := range m.Columns {
+		var flags byte
+		if c.IsKey {
+			flags |= columnFlagKey
+		}
+		buf = append(buf, flags)
+		buf = appendString(buf, c.Name)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(c.TypeOID))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(c.TypeModifier))
+	}
+	return buf
+}
+
+// AppendInsert appends the encoding of an Insert message to buf.
+func AppendInsert(buf []byte, m Insert) []byte {
+	buf = append(buf, msgInsert)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(m.RelationOID))
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, m.New)
+}
+
+// AppendUpdate appends the encoding of an Update message to buf.
+func AppendUpdate(buf []byte, m Update) []byte {
+	buf = append(buf, msgUpdate)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(m.RelationOID))
+	if m.Key != nil {
+		buf = append(buf, tupleKey)
+		buf = appendTuple(buf, m.Key)
+	}
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, m.New)
+}
+
+// AppendDelete appends the encoding of a Delete message to buf.
+func AppendDelete(buf []byte, m Delete) []byte {
+	buf = append(buf, msgDelete)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(m.RelationOID))
+	buf = append(buf, tupleKey)
+	return appendTuple(buf, m.Key)
+}
+
+// AppendXLogData appends the header of an XLogData message of the streaming
+// replication protocol to buf. The header is followed by the data of the
+// message, e.g. a pgoutput message.
+func AppendXLogData(buf []byte, start, walEnd lsn.LSN, sendTime time.Time) []byte {
+	buf = append(buf, msgXLogData)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(start))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walEnd))
+	return appendTime(buf, sendTime)
+}
+
+// AppendPrimaryKeepalive appends a primary keepalive message of the streaming
+// replication protocol to buf. If replyRequested is set, the client should
+// reply with a standby status update as soon as possible.
+func AppendPrimaryKeepalive(
+	buf []byte, walEnd lsn.LSN, sendTime time.Time, replyRequested bool,
+) []byte {
+	buf = append(buf, msgPrimaryKeepalive)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walEnd))
+	buf = appendTime(buf, sendTime)
+	if replyRequested {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// StandbyStatusUpdate is the message periodically sent by the client to
+// report its progress.
+type StandbyStatusUpdate struct {
+	// WrittenLSN is the LSN up to which the client has received changes.
+	WrittenLSN lsn.LSN
+	// FlushedLSN is the LSN up to which the client has persisted the changes.
+	// Changes before this LSN are not sent again when replication is
+	// restarted from the slot.
+	FlushedLSN lsn.LSN
+	// AppliedLSN is the LSN up to which the client has applied the changes.
+	AppliedLSN     lsn.LSN
+	ClientTime     time.Time
+	ReplyRequested bool
+}
+
+// IsStandbyStatusUpdate returns whether a CopyData message sent by the client
+// is a standby status update, as opposed to e.g. hot standby feedback.
+func IsStandbyStatusUpdate(data []byte) bool {
+	return len(data) > 0 && data[0] == msgStandbyStatusUpdate
+}
+
+// ParseStandbyStatusUpdate parses a standby status update message sent in a
+// CopyData message by the client.
+func ParseStandbyStatusUpdate(data []byte) (StandbyStatusUpdate, error) {
+	if len(data) == 0 || data[0] != msgStandbyStatusUpdate {
+		return StandbyStatusUpdate{}, errors.Newf("unexpected replication message")
+	}
+	if len(data) != standbyStatusUpdateLen {
+		return StandbyStatusUpdate{}, errors.Newf(
+			"invalid standby status update message of %d bytes", len(data))
+	}
+	data = data[1:]
+	readLSN := func() lsn.LSN {
+		v := binary.BigEndian.Uint64(data)
+		data = data[8:]
+		return lsn.LSN(v)
+	}
+	var m StandbyStatusUpdate
+	m.WrittenLSN = readLSN()
+	m.FlushedLSN = readLSN()
+	m.AppliedLSN = readLSN()
+	m.ClientTime = duration.AddMicros(pgEpoch, int64(binary.BigEndian.Uint64(data)))
+	m.ReplyRequested = data[8] != 0
+	return m, nil
+}
+
+func appendTime(buf []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(buf, uint64(duration.DiffMicros(t, pgEpoch)))
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, s...)
+	return append(buf, 0)
+}
+
+func appendTuple(buf []byte, t Tuple) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(t)))
+	for _, v := range t {
+		if v.IsNull {
+			buf = append(buf, valueNull)
+			continue
+		}
+		buf = append(buf, valueText)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(v.Text)))
+		buf = append(buf, v.Text...)
+	}
+	return buf
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// 2000-01-01 00:00:01 is 1,000,000 (0x0F4240) microseconds after the epoch
+	// of the replication protocol.
+	ts := time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)
+	tsBytes := []byte{0, 0, 0, 0, 0, 0x0F, 0x42, 0x40}
+	cat := func(parts ...[]byte) []byte {
+		var res []byte
+		for _, p := range parts {
+			res = append(res, p...)
+		}
+		return res
+	}
+	tuple := Tuple{{Text: []byte("1")}, {IsNull: true}, {Text: []byte("")}}
+	tupleBytes := []byte{
+		0, 3,
+		't', 0, 0, 0, 1, '1',
+		'n',
+		't', 0, 0, 0, 0,
+	}
+
+	for _, tc := range []struct {
+		name     string
+		encoded  []byte
+		expected []byte
+	}{
+		{
+			name:    "begin",
+			encoded: AppendBegin(nil, Begin{FinalLSN: lsn.LSN(0x1_00000002), CommitTime: ts, XID: 7}),
+			expected: cat(
+				[]byte{'B', 0, 0, 0, 1, 0, 0, 0, 2},
+				tsBytes,
+				[]byte{0, 0, 0, 7},
+			),
+		},
+		{
+			name:    "commit",
+			encoded: AppendCommit(nil, Commit{CommitLSN: 1, EndLSN: 2, CommitTime: ts}),
+			expected: cat(
+				[]byte{'C', 0},
+				[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+				[]byte{0, 0, 0, 0, 0, 0, 0, 2},
+				tsBytes,
+			),
+		},
+		{
+			name: "relation",
+			encoded: AppendRelation(nil, Relation{
+				OID:             oid.Oid(104),
+				Namespace:       "public",
+				Name:            "t",
+				ReplicaIdentity: ReplicaIdentityDefault,
+				Columns: []Column{
+					{Name: "k", TypeOID: oid.T_int8, TypeModifier: -1, IsKey: true},
+					{Name: "v", TypeOID: oid.T_varchar, TypeModifier: 14},
+				},
+			}),
+			expected: cat(
+				[]byte{'R', 0, 0, 0, 104},
+				[]byte("public\x00t\x00"),
+				[]byte{'d', 0, 2},
+				[]byte{1, 'k', 0, 0, 0, 0, 20, 0xFF, 0xFF, 0xFF, 0xFF},
+				[]byte{0, 'v', 0, 0, 0, 0x04, 0x13, 0, 0, 0, 14},
+			),
+		},
+		{
+			name:     "insert",
+			encoded:  AppendInsert(nil, Insert{RelationOID: 104, New: tuple}),
+			expected: cat([]byte{'I', 0, 0, 0, 104, 'N'}, tupleBytes),
+		},
+		{
+			name:     "update",
+			encoded:  AppendUpdate(nil, Update{RelationOID: 104, New: tuple}),
+			expected: cat([]byte{'U', 0, 0, 0, 104, 'N'}, tupleBytes),
+		},
+		{
+			name:    "update with key",
+			encoded: AppendUpdate(nil, Update{RelationOID: 104, Key: tuple, New: tuple}),
+			expected: cat(
+				[]byte{'U', 0, 0, 0, 104, 'K'}, tupleBytes, []byte{'N'}, tupleBytes,
+			),
+		},
+		{
+			name:     "delete",
+			encoded:  AppendDelete(nil, Delete{RelationOID: 104, Key: tuple}),
+			expected: cat([]byte{'D', 0, 0, 0, 104, 'K'}, tupleBytes),
+		},
+		{
+			name:    "xlogdata",
+			encoded: AppendXLogData(nil, 1, 2, ts),
+			expected: cat(
+				[]byte{'w'},
+				[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+				[]byte{0, 0, 0, 0, 0, 0, 0, 2},
+				tsBytes,
+			),
+		},
+		{
+			name:    "keepalive",
+			encoded: AppendPrimaryKeepalive(nil, 2, ts, true /* replyRequested */),
+			expected: cat(
+				[]byte{'k'},
+				[]byte{0, 0, 0, 0, 0, 0, 0, 2},
+				tsBytes,
+				[]byte{1},
+			),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.encoded)
+		})
+	}
+}
+
+func TestParseStandbyStatusUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	data := []byte{
+		'r',
+		0, 0, 0, 0, 0, 0, 0, 3,
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0x0F, 0x42, 0x40,
+		1,
+	}
+	require.True(t, IsStandbyStatusUpdate(data))
+	m, err := ParseStandbyStatusUpdate(data)
+	require.NoError(t, err)
+	require.Equal(t, StandbyStatusUpdate{
+		WrittenLSN:     3,
+		FlushedLSN:     2,
+		AppliedLSN:     1,
+		ClientTime:     time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC),
+		ReplyRequested: true,
+	}, m)
+
+	_, err = ParseStandbyStatusUpdate(data[:len(data)-1])
+	require.Error(t, err)
+	require.False(t, IsStandbyStatusUpdate([]byte{'h'}))
+	_, err = ParseStandbyStatusUpdate([]byte{'h'})
+	require.Error(t, err)
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplicationSlotProtectedTimestamp checks that the snapshot exported by
+// a replication slot can be read, and that the protected timestamp record of
+// the slot follows the position confirmed by the client until the slot is
+// dropped.
+func TestReplicationSlotProtectedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	systemDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+	systemDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+
+	pgURL, cleanup := s.PGUrl(
+		t, serverutils.CertsDirPrefix("pgrepl_replication_slot_test"), serverutils.User(username.RootUser),
+	)
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	results, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput (SNAPSHOT 'export')`).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, results[0].Rows, 1)
+	consistentPoint, snapshotName := string(results[0].Rows[0][1]), string(results[0].Rows[0][2])
+	require.NotEmpty(t, snapshotName)
+
+	// The snapshot does not contain the changes made after the slot was
+	// created.
+	sqlDB.Exec(t, `INSERT INTO t VALUES (2)`)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT k FROM t AS OF SYSTEM TIME '%s'`, snapshotName),
+		[][]string{{"1"}},
+	)
+
+	countRecords := func(where string, args ...interface{}) int {
+		var n int
+		sqlDB.QueryRow(t, `SELECT count(*) FROM system.protected_ts_records
+WHERE meta_type = 'replication_slot' AND meta = 's' AND `+where, args...,
+		).Scan(&n)
+		return n
+	}
+	require.Equal(t, 1, countRecords(`ts = $1::DECIMAL`, snapshotName))
+
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{String: fmt.Sprintf(
+		`START_REPLICATION SLOT s LOGICAL %s (proto_version '1', publication_names 'p')`, consistentPoint,
+	)})
+	require.NoError(t, fe.Flush())
+	for started := false; !started; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			started = true
+		case *pgproto3.ErrorResponse:
+			t.Fatal(pgconn.ErrorResponseToPgError(msg))
+		}
+	}
+
+	// Confirm the position of the last keepalive message, and request a new one,
+	// until the record protects a later timestamp.
+	var walEnd uint64
+	testutils.SucceedsSoon(t, func() error {
+		fe.Send(&pgproto3.CopyData{Data: standbyStatusUpdate(walEnd)})
+		require.NoError(t, fe.Flush())
+		for {
+			msg, err := fe.Receive()
+			require.NoError(t, err)
+			if m, ok := msg.(*pgproto3.CopyData); ok && len(m.Data) > 0 && m.Data[0] == 'k' {
+				walEnd = binary.BigEndian.Uint64(m.Data[1:9])
+				break
+			}
+		}
+		if countRecords(`ts > $1::DECIMAL`, snapshotName) == 0 {
+			return errors.New("protected timestamp record was not advanced")
+		}
+		return nil
+	})
+
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		_, done = msg.(*pgproto3.ReadyForQuery)
+	}
+
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT s`).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, 0, countRecords(`true`))
+}
+
+// standbyStatusUpdate returns a standby status update message confirming the
+// given LSN and requesting a keepalive message.
+func standbyStatusUpdate(lsn uint64) []byte {
+	buf := []byte{'r'}
+	for i := 0; i < 3; i++ {
+		buf = binary.BigEndian.AppendUint64(buf, lsn)
+	}
+	buf = binary.BigEndian.AppendUint64(buf, 0 /* clientTime */)
+	return append(buf, 1 /* replyRequested */)
+}
@@ -59,6 +59,7 @@ type commandResult struct {
 	// buffer contains items that are sent before the connection is closed.
 	buffer struct {
 		notices            []pgnotice.Notice
+		notifications      []sql.Notification
 		paramStatusUpdates []paramStatusUpdate
 	}
 
@@ -88,6 +89,10 @@ type commandResult struct {
 	// to have an entry for every column.
 	formatCodes []pgwirebase.FormatCode
 
+	// binaryFormat is set by UseBinaryFormat, in which case SetColumns encodes
+	// every column in the binary format.
+	binaryFormat bool
+
 	// types is a map from result column index to its type T, similar to formatCodes
 	// (except types must always be set).
 	types []*types.T
@@ -148,6 +153,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, n := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(n); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -239,6 +250,12 @@ func (r *commandResult) GetFormatCode(colIdx int) (pgwirebase.FormatCode, error)
 	return fmtCode, nil
 }
 
+// UseBinaryFormat is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) UseBinaryFormat() {
+	r.assertNotReleased()
+	r.binaryFormat = true
+}
+
 // beforeAdd should be called before rows are buffered.
 func (r *commandResult) beforeAdd() error {
 	r.assertNotReleased()
@@ -332,6 +349,11 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationResult interface.
+func (r *commandResult) BufferNotification(n sql.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, n)
+}
+
 // SendNotice is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	if err := r.conn.bufferNotice(ctx, notice); err != nil {
@@ -344,6 +366,12 @@ func (r *commandResult) SendNotice(ctx context.Context, notice pgnotice.Notice)
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
 	r.conn.writerState.fi.registerCmd(r.pos)
+	if r.binaryFormat {
+		r.formatCodes = make([]pgwirebase.FormatCode, len(cols))
+		for i := range r.formatCodes {
+			r.formatCodes[i] = pgwirebase.FormatBinary
+		}
+	}
 	if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
@@ -413,6 +441,29 @@ func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	return r.conn.bufferCopyDone()
 }
 
+// SendCopyBoth is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBoth(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	if err := r.conn.bufferCopyBoth(); err != nil {
+		return err
+	}
+	return r.conn.Flush(r.pos)
+}
+
+// SendCopyBothData is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBothData(ctx context.Context, copyData []byte) error {
+	if err := r.beforeAdd(); err != nil {
+		return err
+	}
+	if err := r.conn.bufferCopyData(copyData, r); err != nil {
+		return err
+	}
+	// Replication messages are sent as soon as they are produced rather than
+	// waiting for the results buffer to fill up.
+	return r.conn.Flush(r.pos)
+}
+
 // SetRowsAffected is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetRowsAffected(ctx context.Context, n int) {
 	r.assertNotReleased()
@@ -642,6 +693,10 @@ func (r *limitedCommandResult) moreResultsNeeded(ctx context.Context) error {
 			if err := r.conn.Flush(r.pos); err != nil {
 				return err
 			}
+		case sql.DeliverNotifications:
+			// Notifications are not sent while in a transaction. They remain
+			// pending until the next Sync outside of the transaction.
+			r.conn.stmtBuf.AdvanceOne()
 		default:
 			// If the portal is immediately followed by a COMMIT, we can proceed and
 			// let the portal be destroyed at the end of the transaction.
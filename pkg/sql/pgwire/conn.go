@@ -104,6 +104,11 @@ type conn struct {
 	readBuf    pgwirebase.ReadBuffer
 	msgBuilder writeBuffer
 
+	// replicationStream is set while a START_REPLICATION command is streaming
+	// changes to the client. It is only accessed by the network routine, which
+	// forwards the CopyData messages sent by the client to the stream.
+	replicationStream *replicationStream
+
 	// vecsScratch is a scratch space used by bufferBatch.
 	vecsScratch coldata.TypedVecs
 
@@ -113,6 +118,15 @@ type conn struct {
 	alwaysLogAuthActivity bool
 }
 
+// replicationStream is the network routine's side of an active
+// START_REPLICATION command.
+type replicationStream struct {
+	// msgs receives the payloads of the CopyData messages sent by the client.
+	msgs chan []byte
+	// done is closed by the connExecutor once the stream has finished.
+	done chan struct{}
+}
+
 func (c *conn) setErr(err error) {
 	c.err.Store(err)
 }
@@ -259,6 +273,14 @@ func (c *conn) bufferParamStatus(param, value string) error {
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(n sql.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(n.PID)
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgNoticeResponse)
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
@@ -333,6 +355,32 @@ func (c *conn) bufferInitialReadyForQuery(queryCancelKey pgwirecancel.BackendKey
 	return nil
 }
 
+// handleReplicationStreamMsg forwards a CopyData, CopyDone or CopyFail message
+// sent by the client to the active replication stream. The client ending the
+// stream, or the stream having already finished, detaches the stream from the
+// connection.
+func (c *conn) handleReplicationStreamMsg(
+	ctx context.Context, typ pgwirebase.ClientMessageType,
+) error {
+	s := c.replicationStream
+	if typ != pgwirebase.ClientMsgCopyData {
+		close(s.msgs)
+		c.replicationStream = nil
+		return nil
+	}
+	// The buffer is reused for the next message, so the payload needs to be
+	// copied.
+	msg := append([]byte(nil), c.readBuf.Msg...)
+	select {
+	case s.msgs <- msg:
+	case <-s.done:
+		c.replicationStream = nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
 // An error is returned iff the statement buffer has been closed. In that case,
 // the connection should be considered toast.
 func (c *conn) handleSimpleQuery(
@@ -354,8 +402,25 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch ast := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem, *pgrepltree.CreateReplicationSlot, *pgrepltree.DropReplicationSlot:
+		case *pgrepltree.StartReplication:
+			// START_REPLICATION is special: it switches the connection to the
+			// Copy-both subprotocol. The network routine keeps reading from the
+			// connection and forwards the client's CopyData messages to the
+			// connExecutor until the client ends the stream.
+			msgs := make(chan []byte, 16)
+			done := make(chan struct{})
+			c.replicationStream = &replicationStream{msgs: msgs, done: done}
+			return c.stmtBuf.Push(ctx, sql.StartReplication{
+				ParsedStmt:     stmt,
+				Stmt:           ast,
+				ClientMessages: msgs,
+				StreamDone:     done,
+				TimeReceived:   timeReceived,
+				ParseStart:     startParse,
+				ParseEnd:       timeutil.Now(),
+			})
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -868,6 +933,9 @@ func cookTag(
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 		}
 
+	case tree.Replication:
+		// Replication commands have no row count.
+
 	default:
 		panic(errors.AssertionFailedf("unexpected result type %v", stmtType))
 	}
@@ -1184,6 +1252,14 @@ func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	return nil
 }
 
+func (c *conn) bufferCopyBoth() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	// Replication streams always use the binary format and have no columns.
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatBinary))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferCopyDone() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
@@ -1414,6 +1490,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
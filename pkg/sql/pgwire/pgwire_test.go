@@ -29,8 +29,12 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
@@ -1923,3 +1927,177 @@ func TestFailPrepareFailsTxn(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestListenNotify verifies that notifications sent with NOTIFY are delivered
+// to the sessions that are listening on the channel, on any node, once the
+// notifying transaction commits.
+func TestListenNotify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Enable enterprise features so READ COMMITTED can be tested.
+	defer ccl.TestingEnableEnterprise()()
+
+	ctx := context.Background()
+	// Notifications are delivered once the closed timestamp of
+	// system.notifications passes their commit timestamp, so keep it low.
+	st := cluster.MakeTestingClusterSettings()
+	closedts.TargetDuration.Override(ctx, &st.SV, 10*time.Millisecond)
+	closedts.SideTransportCloseInterval.Override(ctx, &st.SV, 10*time.Millisecond)
+	kvserver.RangeFeedRefreshInterval.Override(ctx, &st.SV, 10*time.Millisecond)
+	tc := serverutils.StartCluster(t, 2, base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{Insecure: true, Settings: st},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	connect := func(s serverutils.ApplicationLayerInterface) *pgx.Conn {
+		host, ports, _ := net.SplitHostPort(s.AdvSQLAddr())
+		port, _ := strconv.Atoi(ports)
+		connCfg, err := pgx.ParseConfig(
+			fmt.Sprintf("postgresql://%s@%s:%d/defaultdb?sslmode=disable", username.RootUser, host, port),
+		)
+		require.NoError(t, err)
+		connCfg.TLSConfig = nil
+		connCfg.Logger = pgxTestLogger{}
+		conn, err := pgx.ConnectConfig(ctx, connCfg)
+		require.NoError(t, err)
+		return conn
+	}
+
+	// The listener and the notifier are connected to different nodes.
+	listener := connect(tc.ApplicationLayer(0))
+	defer func() { _ = listener.Close(ctx) }()
+	notifier := connect(tc.ApplicationLayer(1))
+	defer func() { _ = notifier.Close(ctx) }()
+
+	waitForNotification := func(channel, payload string) {
+		t.Helper()
+		waitCtx, cancel := context.WithTimeout(ctx, testutils.DefaultSucceedsSoonDuration)
+		defer cancel()
+		n, err := listener.WaitForNotification(waitCtx)
+		require.NoError(t, err)
+		require.Equal(t, notifier.PgConn().PID(), n.PID)
+		require.Equal(t, channel, n.Channel)
+		require.Equal(t, payload, n.Payload)
+	}
+
+	_, err := listener.Exec(ctx, "LISTEN foo")
+	require.NoError(t, err)
+
+	_, err = notifier.Exec(ctx, "NOTIFY foo, 'hello'")
+	require.NoError(t, err)
+	waitForNotification("foo", "hello")
+
+	_, err = notifier.Exec(ctx, "SELECT pg_notify('foo', 'from pg_notify')")
+	require.NoError(t, err)
+	waitForNotification("foo", "from pg_notify")
+
+	// Notifications sent by a transaction that rolls back are discarded, and
+	// those sent by a transaction that commits are only delivered at commit
+	// time.
+	tx, err := notifier.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY foo, 'rolled back'")
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback(ctx))
+	tx, err = notifier.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY foo, 'committed'")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	waitForNotification("foo", "committed")
+
+	// Notifications sent after a savepoint are discarded when the transaction
+	// rolls back to it, and the ones sent before it are kept.
+	tx, err = notifier.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY foo, 'before savepoint'")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "SAVEPOINT s")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY foo, 'after savepoint'")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "ROLLBACK TO SAVEPOINT s")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY foo, 'after rollback'")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	waitForNotification("foo", "before savepoint")
+	waitForNotification("foo", "after rollback")
+
+	// Notifications on channels the session is not listening on are not
+	// delivered.
+	_, err = listener.Exec(ctx, "UNLISTEN foo")
+	require.NoError(t, err)
+	_, err = listener.Exec(ctx, "LISTEN bar")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY foo, 'ignored'")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY bar")
+	require.NoError(t, err)
+	waitForNotification("bar", "")
+
+	// LISTEN and UNLISTEN only take effect when the transaction commits.
+	tx, err = listener.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "LISTEN baz")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "UNLISTEN bar")
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback(ctx))
+	tx, err = listener.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "SAVEPOINT s")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "LISTEN baz")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "ROLLBACK TO SAVEPOINT s")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "LISTEN qux")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	_, err = notifier.Exec(ctx, "NOTIFY baz, 'ignored'")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY bar, 'still listening'")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY qux, 'committed'")
+	require.NoError(t, err)
+	waitForNotification("bar", "still listening")
+	waitForNotification("qux", "committed")
+
+	// A statement that is retried under READ COMMITTED queues its notification
+	// again after the rollback of its previous attempt.
+	_, err = notifier.Exec(ctx, "SET inject_retry_errors_enabled = true")
+	require.NoError(t, err)
+	tx, err = notifier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY bar, 'retried'")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	_, err = notifier.Exec(ctx, "SET inject_retry_errors_enabled = false")
+	require.NoError(t, err)
+	waitForNotification("bar", "retried")
+
+	// The same applies to the notifications queued in a PL/pgSQL block that
+	// are rolled back by its exception handler.
+	_, err = notifier.Exec(ctx, `
+CREATE FUNCTION notify_after_exception() RETURNS INT AS $$
+BEGIN
+  BEGIN
+    PERFORM pg_notify('bar', 'from routine');
+    PERFORM 1 // 0;
+  EXCEPTION WHEN division_by_zero THEN
+    NULL;
+  END;
+  PERFORM pg_notify('bar', 'from routine');
+  RETURN 0;
+END
+$$ LANGUAGE PLpgSQL`)
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "SELECT notify_after_exception()")
+	require.NoError(t, err)
+	waitForNotification("bar", "from routine")
+
+	_, err = notifier.Exec(ctx, fmt.Sprintf("NOTIFY bar, '%s'", strings.Repeat("x", 8000)))
+	require.True(t, testutils.IsError(err, "payload string too long"), "unexpected error: %v", err)
+}
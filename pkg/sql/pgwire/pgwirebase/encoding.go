@@ -317,6 +317,15 @@ func validateArrayDimensions(nDimensions int, nElements int) error {
 func DecodeDatum(
 	ctx context.Context, evalCtx *eval.Context, typ *types.T, code FormatCode, b []byte,
 ) (tree.Datum, error) {
+	if typ.IsDomain() {
+		// Domain values are decoded as values of their base type, and then
+		// checked against the constraints of the domain.
+		d, err := DecodeDatum(ctx, evalCtx, typ.DomainBaseType(), code, b)
+		if err != nil {
+			return nil, err
+		}
+		return eval.PerformCast(ctx, evalCtx, d, typ)
+	}
 	id := typ.Oid()
 	// Use a direct string pointing to b where we are sure we aren't retaining this string.
 	bs := encoding.UnsafeConvertBytesToString(b)
@@ -475,6 +484,29 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.NewDString(bs), nil
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		case types.MultiRangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDMultiRangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		case types.GeometricFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDGeometric(bs, typ)
 		}
 	case FormatBinary:
 		switch id {
@@ -786,6 +818,15 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b)
+			}
+			if typ.Family() == types.MultiRangeFamily {
+				return decodeBinaryMultiRange(ctx, evalCtx, typ, b)
+			}
+			if typ.Family() == types.GeometricFamily {
+				return decodeBinaryGeometric(typ, b)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1019,6 +1060,151 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// decodeBinaryGeometric decodes the binary format of a value of a geometric
+// type, which is the list of its coordinates as float8 values. Paths start with
+// a byte which is set for closed paths followed by the number of points, and
+// polygons start with the number of points.
+func decodeBinaryGeometric(t *types.T, b []byte) (tree.Datum, error) {
+	invalid := func() error {
+		return pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message for %s", t)
+	}
+	closed := false
+	numPoints := 0
+	switch t.Oid() {
+	case oid.T_point:
+		numPoints = 1
+	case oid.T_lseg, oid.T_box:
+		numPoints = 2
+	case oid.T_path, oid.T_polygon:
+		if t.Oid() == oid.T_path {
+			if len(b) < 1 {
+				return nil, invalid()
+			}
+			closed = b[0] != 0
+			b = b[1:]
+		}
+		if len(b) < 4 {
+			return nil, invalid()
+		}
+		numPoints = int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if numPoints <= 0 || numPoints > len(b)/16 {
+			return nil, pgerror.Newf(pgcode.InvalidBinaryRepresentation,
+				"invalid number of points in external %q value", t)
+		}
+	}
+	readFloat := func() float64 {
+		f := math.Float64frombits(binary.BigEndian.Uint64(b))
+		b = b[8:]
+		return f
+	}
+	switch t.Oid() {
+	case oid.T_line, oid.T_circle:
+		if len(b) < 24 {
+			return nil, invalid()
+		}
+		f1, f2, f3 := readFloat(), readFloat(), readFloat()
+		if t.Oid() == oid.T_line {
+			return tree.NewDLine(f1, f2, f3)
+		}
+		return tree.NewDCircle(tree.GeometricPoint{X: f1, Y: f2}, f3)
+	}
+	if len(b) < 16*numPoints {
+		return nil, invalid()
+	}
+	points := make([]tree.GeometricPoint, numPoints)
+	for i := range points {
+		points[i] = tree.GeometricPoint{X: readFloat(), Y: readFloat()}
+	}
+	switch t.Oid() {
+	case oid.T_point:
+		return tree.NewDPoint(points[0]), nil
+	case oid.T_lseg:
+		return tree.NewDLSeg(points[0], points[1]), nil
+	case oid.T_box:
+		return tree.NewDBox(points[0], points[1]), nil
+	case oid.T_path:
+		return tree.NewDPath(points, closed), nil
+	default:
+		return tree.NewDPolygon(points), nil
+	}
+}
+
+// decodeBinaryRange decodes the binary format of a range, which is a byte with
+// the flags of the range followed by the length-prefixed binary encodings of
+// its finite bounds.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, NewInvalidBinaryRepresentationErrorf("range requires a flags byte for binary format")
+	}
+	flags := tree.RangeFlags(b[0])
+	b = b[1:]
+	decodeBound := func(infiniteFlag tree.RangeFlags) (tree.Datum, error) {
+		if flags&(tree.RangeFlagEmpty|infiniteFlag) != 0 {
+			return nil, nil
+		}
+		if len(b) < elementSize {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data left in message")
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[elementSize:]
+		if n < 0 || len(b) < n {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data left in message")
+		}
+		d, err := DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, b[:n])
+		b = b[n:]
+		return d, err
+	}
+	lower, err := decodeBound(tree.RangeFlagLowerInfinite)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := decodeBound(tree.RangeFlagUpperInfinite)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("unexpected data after range bounds")
+	}
+	return tree.NewDRangeFromFlags(t, flags, lower, upper)
+}
+
+// decodeBinaryMultiRange decodes the binary format of a multirange, which is
+// the number of ranges followed by the length-prefixed binary encodings of the
+// ranges.
+func decodeBinaryMultiRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DMultiRange, error) {
+	if len(b) < elementSize {
+		return nil, NewInvalidBinaryRepresentationErrorf("multirange requires a %d byte header for binary format", elementSize)
+	}
+	count := int(int32(binary.BigEndian.Uint32(b)))
+	b = b[elementSize:]
+	if count < 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("invalid number of ranges: %d", count)
+	}
+	ranges := make([]*tree.DRange, 0, count)
+	for i := 0; i < count; i++ {
+		if len(b) < elementSize {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data left in message")
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[elementSize:]
+		if n < 0 || len(b) < n {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data left in message")
+		}
+		r, err := decodeBinaryRange(ctx, evalCtx, t.MultiRangeContents(), b[:n])
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		b = b[n:]
+	}
+	return tree.NewDMultiRange(t, ranges), nil
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tree.Datum, error) {
@@ -1188,10 +1188,14 @@ func (s *Server) serveImpl(
 				return false, isSimpleQuery, c.handleFlush(ctx)
 
 			case pgwirebase.ClientMsgCopyData, pgwirebase.ClientMsgCopyDone, pgwirebase.ClientMsgCopyFail:
-				// We're supposed to ignore these messages, per the protocol spec. This
-				// state will happen when an error occurs on the server-side during a copy
-				// operation: the server will send an error and a ready message back to
-				// the client, and must then ignore further copy messages. See:
+				if c.replicationStream != nil {
+					return false, isSimpleQuery, c.handleReplicationStreamMsg(ctx, typ)
+				}
+				// Outside of a replication stream, we're supposed to ignore these
+				// messages, per the protocol spec. This state will happen when an error
+				// occurs on the server-side during a copy operation: the server will
+				// send an error and a ready message back to the client, and must then
+				// ignore further copy messages. See:
 				// https://github.com/postgres/postgres/blob/6e1dd2773eb60a6ab87b27b8d9391b756e904ac3/src/backend/tcop/postgres.c#L4295
 				return false, isSimpleQuery, nil
 			default:
@@ -58,6 +58,8 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like in Postgres, domain types are sent as their base type.
+	t = t.DomainBaseType()
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -154,6 +156,10 @@ func writeTextDatumNotNull(
 	sessionLoc *time.Location,
 	t *types.T,
 ) {
+	if t != nil {
+		// Domain values are written as values of their base type.
+		t = t.DomainBaseType()
+	}
 
 	oldDCC := b.textFormatter.SetDataConversionConfig(conv)
 	oldLoc := b.textFormatter.SetLocation(sessionLoc)
@@ -254,6 +260,14 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange, *tree.DMultiRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DGeometric:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DTuple:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -305,7 +319,8 @@ func (b *writeBuffer) writeTextColumnarElement(
 		b.textFormatter.SetDataConversionConfig(oldDCC)
 		b.textFormatter.SetLocation(oldLoc)
 	}()
-	typ := vecs.Vecs[vecIdx].Type()
+	// Domain values are written as values of their base type.
+	typ := vecs.Vecs[vecIdx].Type().DomainBaseType()
 	if log.V(2) {
 		log.Infof(ctx, "pgwire writing TEXT columnar element of type: %s", typ)
 	}
@@ -563,6 +578,43 @@ func writeBinaryJSON(b *writeBuffer, v json.JSON, t *types.T) {
 	b.writeString(s)
 }
 
+// writeBinaryGeometric writes a value of a geometric type in the binary format
+// of Postgres, which is the list of its coordinates as float8 values. Paths
+// start with a byte which is set for closed paths followed by the number of
+// points, and polygons start with the number of points.
+func writeBinaryGeometric(b *writeBuffer, v *tree.DGeometric) {
+	var floats []float64
+	switch v.ResolvedType().Oid() {
+	case oid.T_line:
+		floats = v.Line[:]
+	case oid.T_circle:
+		floats = []float64{v.Points[0].X, v.Points[0].Y, v.Radius}
+	default:
+		floats = make([]float64, 0, 2*len(v.Points))
+		for _, p := range v.Points {
+			floats = append(floats, p.X, p.Y)
+		}
+	}
+	switch v.ResolvedType().Oid() {
+	case oid.T_path:
+		b.putInt32(int32(5 + 8*len(floats)))
+		var closed byte
+		if v.Closed {
+			closed = 1
+		}
+		b.writeByte(closed)
+		b.putInt32(int32(len(v.Points)))
+	case oid.T_polygon:
+		b.putInt32(int32(4 + 8*len(floats)))
+		b.putInt32(int32(len(v.Points)))
+	default:
+		b.putInt32(int32(8 * len(floats)))
+	}
+	for _, f := range floats {
+		b.putInt64(int64(math.Float64bits(f)))
+	}
+}
+
 // writeBinaryDatum writes d to the buffer. Type t must be specified for types
 // that have various width encodings (floats, ints, chars). It is ignored
 // (and can be nil) for types with a 1:1 datum:type mapping.
@@ -587,6 +639,10 @@ func (b *writeBuffer) writeBinaryDatum(
 func writeBinaryDatumNotNull(
 	ctx context.Context, b *writeBuffer, d tree.Datum, sessionLoc *time.Location, t *types.T,
 ) {
+	if t != nil {
+		// Domain values are written as values of their base type.
+		t = t.DomainBaseType()
+	}
 	switch v := tree.UnwrapDOidWrapper(d).(type) {
 	case *tree.DBitArray:
 		words, lastBitsUsed := v.EncodingParts()
@@ -769,6 +825,40 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		// The binary format of a range is a byte with the flags of the range,
+		// followed by the length-prefixed binary encodings of its finite bounds.
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.writeByte(byte(v.Flags()))
+		if !v.Empty {
+			subtype := v.ResolvedType().RangeContents()
+			for _, bound := range [...]tree.RangeBound{v.Lower, v.Upper} {
+				if !bound.IsInfinite() {
+					b.writeBinaryDatum(ctx, bound.Val, sessionLoc, subtype)
+				}
+			}
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
+	case *tree.DGeometric:
+		writeBinaryGeometric(b, v)
+
+	case *tree.DMultiRange:
+		// The binary format of a multirange is the number of ranges followed by
+		// the length-prefixed binary encodings of the ranges.
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			b.writeBinaryDatum(ctx, r, sessionLoc, r.ResolvedType())
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DArray:
 		if v.ParamTyp.Family() == types.ArrayFamily {
 			b.setError(unimplemented.NewWithIssueDetail(32552,
@@ -823,7 +913,8 @@ func writeBinaryDatumNotNull(
 func (b *writeBuffer) writeBinaryColumnarElement(
 	ctx context.Context, vecs *coldata.TypedVecs, vecIdx int, rowIdx int, sessionLoc *time.Location,
 ) {
-	typ := vecs.Vecs[vecIdx].Type()
+	// Domain values are written as values of their base type.
+	typ := vecs.Vecs[vecIdx].Type().DomainBaseType()
 	if log.V(2) {
 		log.Infof(ctx, "pgwire writing BINARY columnar element of type: %s", typ)
 	}
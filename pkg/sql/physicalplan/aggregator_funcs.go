@@ -469,3 +469,52 @@ var DistAggregationTable = map[execinfrapb.AggregatorSpec_Func]DistAggregationIn
 		},
 	},
 }
+
+// UserDefinedDistAggregation is the DistAggregationInfo for user-defined
+// aggregate functions that have a combine function. The local stage computes
+// the partial aggregate states and the final stage combines them with the
+// combine function before applying the final function. The specs of both
+// stages are derived from the original one with UserDefinedLocalStage and
+// UserDefinedFinalStage.
+var UserDefinedDistAggregation = DistAggregationInfo{
+	LocalStage: []execinfrapb.AggregatorSpec_Func{execinfrapb.UserDefined},
+	FinalStage: []FinalStageInfo{
+		{
+			Fn:        execinfrapb.UserDefined,
+			LocalIdxs: passThroughLocalIdxs,
+		},
+	},
+}
+
+// UserDefinedLocalStage returns the spec of the local stage of the given
+// user-defined aggregation, which outputs the aggregate state.
+func UserDefinedLocalStage(
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregation,
+) *execinfrapb.AggregatorSpec_UserDefinedAggregation {
+	return &execinfrapb.AggregatorSpec_UserDefinedAggregation{
+		StateType:        spec.StateType,
+		ResultType:       spec.StateType,
+		InitCond:         spec.InitCond,
+		Transition:       spec.Transition,
+		TransitionStrict: spec.TransitionStrict,
+	}
+}
+
+// UserDefinedFinalStage returns the spec of the final stage of the given
+// user-defined aggregation, which combines the aggregate states produced by
+// the local stage.
+func UserDefinedFinalStage(
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregation,
+) *execinfrapb.AggregatorSpec_UserDefinedAggregation {
+	// Like in Postgres, the combine function is not applied when the state is
+	// still NULL, so the first non-NULL partial state becomes the state if the
+	// combine function is strict.
+	return &execinfrapb.AggregatorSpec_UserDefinedAggregation{
+		StateType:        spec.StateType,
+		ResultType:       spec.ResultType,
+		Transition:       spec.Combine,
+		TransitionStrict: spec.CombineStrict,
+		Final:            spec.Final,
+		FinalStrict:      spec.FinalStrict,
+	}
+}
@@ -149,6 +149,7 @@ type planNodeReadingOwnWrites interface {
 
 var _ planNode = &alterIndexNode{}
 var _ planNode = &alterIndexVisibleNode{}
+var _ planNode = &alterMaterializedViewStorageParamsNode{}
 var _ planNode = &alterSchemaNode{}
 var _ planNode = &alterSequenceNode{}
 var _ planNode = &alterTableNode{}
@@ -160,10 +161,17 @@ var _ planNode = &cancelQueriesNode{}
 var _ planNode = &cancelSessionsNode{}
 var _ planNode = &changeDescriptorBackedPrivilegesNode{}
 var _ planNode = &completionsNode{}
+var _ planNode = &createAggregateNode{}
+var _ planNode = &createCollationNode{}
 var _ planNode = &createDatabaseNode{}
+var _ planNode = &createForeignTableNode{}
 var _ planNode = &createFunctionNode{}
 var _ planNode = &createIndexNode{}
+var _ planNode = &createPolicyNode{}
+var _ planNode = &createPublicationNode{}
+var _ planNode = &createReplicationSlotNode{}
 var _ planNode = &createSequenceNode{}
+var _ planNode = &createServerNode{}
 var _ planNode = &createStatsNode{}
 var _ planNode = &createTableNode{}
 var _ planNode = &createTypeNode{}
@@ -173,15 +181,22 @@ var _ planNode = &delayedNode{}
 var _ planNode = &deleteNode{}
 var _ planNode = &deleteRangeNode{}
 var _ planNode = &distinctNode{}
+var _ planNode = &dropCollationNode{}
 var _ planNode = &dropDatabaseNode{}
+var _ planNode = &dropForeignTableNode{}
 var _ planNode = &dropIndexNode{}
+var _ planNode = &dropPolicyNode{}
+var _ planNode = &dropPublicationNode{}
+var _ planNode = &dropReplicationSlotNode{}
 var _ planNode = &dropSchemaNode{}
 var _ planNode = &dropSequenceNode{}
+var _ planNode = &dropServerNode{}
 var _ planNode = &dropTableNode{}
 var _ planNode = &dropTypeNode{}
 var _ planNode = &DropRoleNode{}
 var _ planNode = &dropViewNode{}
 var _ planNode = &errorIfRowsNode{}
+var _ planNode = &expandNode{}
 var _ planNode = &explainVecNode{}
 var _ planNode = &filterNode{}
 var _ planNode = &GrantRoleNode{}
@@ -241,14 +256,25 @@ var _ planNodeReadingOwnWrites = &alterSchemaNode{}
 var _ planNodeReadingOwnWrites = &alterSequenceNode{}
 var _ planNodeReadingOwnWrites = &alterTableNode{}
 var _ planNodeReadingOwnWrites = &alterTypeNode{}
+var _ planNodeReadingOwnWrites = &createAggregateNode{}
+var _ planNodeReadingOwnWrites = &createCollationNode{}
+var _ planNodeReadingOwnWrites = &createForeignTableNode{}
 var _ planNodeReadingOwnWrites = &createFunctionNode{}
 var _ planNodeReadingOwnWrites = &createIndexNode{}
+var _ planNodeReadingOwnWrites = &createPolicyNode{}
+var _ planNodeReadingOwnWrites = &createPublicationNode{}
+var _ planNodeReadingOwnWrites = &createReplicationSlotNode{}
 var _ planNodeReadingOwnWrites = &createSequenceNode{}
 var _ planNodeReadingOwnWrites = &createDatabaseNode{}
 var _ planNodeReadingOwnWrites = &createTableNode{}
 var _ planNodeReadingOwnWrites = &createTypeNode{}
 var _ planNodeReadingOwnWrites = &createViewNode{}
 var _ planNodeReadingOwnWrites = &changeDescriptorBackedPrivilegesNode{}
+var _ planNodeReadingOwnWrites = &dropCollationNode{}
+var _ planNodeReadingOwnWrites = &dropForeignTableNode{}
+var _ planNodeReadingOwnWrites = &dropPolicyNode{}
+var _ planNodeReadingOwnWrites = &dropPublicationNode{}
+var _ planNodeReadingOwnWrites = &dropReplicationSlotNode{}
 var _ planNodeReadingOwnWrites = &dropSchemaNode{}
 var _ planNodeReadingOwnWrites = &dropTypeNode{}
 var _ planNodeReadingOwnWrites = &refreshMaterializedViewNode{}
@@ -54,6 +54,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *ordinalityNode:
 		return n.columns
+	case *expandNode:
+		return n.columns
 	case *renderNode:
 		return n.columns
 	case *scanNode:
@@ -178,6 +180,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
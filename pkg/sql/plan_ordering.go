@@ -72,6 +72,10 @@ func planReqOrdering(plan planNode) ReqOrdering {
 		return n.reqOrdering
 	case *ordinalityNode:
 		return n.reqOrdering
+	case *expandNode:
+		// Expand produces all the copies of a source row before moving on to the
+		// next source row.
+		return planReqOrdering(n.source)
 	case *renderNode:
 		return n.reqOrdering
 	case *sortNode:
@@ -117,6 +117,10 @@ type extendedEvalContext struct {
 
 	// validateDbZoneConfig should the DB zone config on commit.
 	validateDbZoneConfig *bool
+
+	// deferredConstraintChecks refers to the deferred constraint checks in
+	// extraTxnState. It is nil for internal executors.
+	deferredConstraintChecks *deferredConstraintChecks
 }
 
 // copyFromExecCfg copies relevant fields from an ExecutorConfig.
@@ -244,6 +248,8 @@ type planner struct {
 
 	createdSequences createdSequences
 
+	notifications notifications
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
@@ -279,6 +285,14 @@ type planner struct {
 
 	queryCacheSession querycache.Session
 
+	// dynamicQueryCache caches the memos for the query strings of PLpgSQL
+	// EXECUTE statements. It is shared by copies of the planner.
+	dynamicQueryCache *dynamicQueryCache
+
+	// plpgsqlStmtTimers measure the latency of the statements within PL/pgSQL
+	// routines that are being executed, innermost last.
+	plpgsqlStmtTimers []plpgsqlStmtTimer
+
 	// evalCatalogBuiltins is used as part of the eval.Context.
 	evalCatalogBuiltins evalcatalog.Builtins
 
@@ -286,6 +300,12 @@ type planner struct {
 	trackDependency map[catid.DescID]bool
 
 	reducedAuditConfig *auditlogging.ReducedAuditConfig
+
+	// allowMaterializedViewMutation is set for the statements that REFRESH
+	// MATERIALIZED VIEW ... INCREMENTAL runs through the internal executor to
+	// apply the changes to the data of the view. It is never set for statements
+	// issued by users.
+	allowMaterializedViewMutation bool
 }
 
 // hasFlowForPausablePortal returns true if the planner is for re-executing a
@@ -411,6 +431,7 @@ func newInternalPlanner(
 	p.semaCtx.TypeResolver = p
 	p.semaCtx.FunctionResolver = p
 	p.semaCtx.NameResolver = p
+	p.semaCtx.CollationResolver = p
 	p.semaCtx.DateStyle = sd.GetDateStyle()
 	p.semaCtx.IntervalStyle = sd.GetIntervalStyle()
 	p.semaCtx.UnsupportedTypeChecker = eval.NewUnsupportedTypeChecker(execCfg.Settings.Version)
@@ -462,10 +483,12 @@ func newInternalPlanner(
 	p.extendedEvalCtx.Annotations = &p.semaCtx.Annotations
 
 	p.queryCacheSession.Init()
+	p.dynamicQueryCache = newDynamicQueryCache()
 	p.optPlanningCtx.init(p)
 	p.sqlCursors = emptySqlCursors{}
 	p.preparedStatements = emptyPreparedStatements{}
 	p.createdSequences = emptyCreatedSequences{}
+	p.notifications = emptyNotifications{}
 
 	p.schemaResolver.descCollection = p.Descriptors()
 	p.schemaResolver.sessionDataStack = sds
@@ -905,6 +928,7 @@ func (p *planner) resetPlanner(
 	p.semaCtx.TypeResolver = p
 	p.semaCtx.FunctionResolver = p
 	p.semaCtx.NameResolver = p
+	p.semaCtx.CollationResolver = p
 	p.semaCtx.DateStyle = sd.GetDateStyle()
 	p.semaCtx.IntervalStyle = sd.GetIntervalStyle()
 	p.semaCtx.UnsupportedTypeChecker = eval.NewUnsupportedTypeChecker(p.execCfg.Settings.Version)
@@ -25,6 +25,8 @@ import (
 
 type lexer struct {
 	in string
+	// skippedLines is the number of lines in the function body that precede in.
+	skippedLines int
 	// tokens contains tokens generated by the scanner.
 	tokens []plpgsqlSymType
 
@@ -41,18 +43,26 @@ type lexer struct {
 	numPlaceholders int
 	numAnnotations  tree.AnnotationIdx
 
+	// numStmts is the number of statements parsed so far. It is used to assign
+	// statement IDs.
+	numStmts uint
+
 	lastError error
 
 	parser plpgsqlParser
 }
 
-func (l *lexer) init(sql string, tokens []plpgsqlSymType, nakedIntType *types.T, p plpgsqlParser) {
+func (l *lexer) init(
+	sql string, skippedLines int, tokens []plpgsqlSymType, nakedIntType *types.T, p plpgsqlParser,
+) {
 	l.in = sql
+	l.skippedLines = skippedLines
 	l.tokens = tokens
 	l.lastPos = -1
 	l.stmt = nil
 	l.numPlaceholders = 0
 	l.numAnnotations = 0
+	l.numStmts = 0
 	l.lastError = nil
 	l.nakedIntType = nakedIntType
 	l.parser = p
@@ -202,61 +212,129 @@ func (l *lexer) MakeExecSqlStmt() (*plpgsqltree.Execute, error) {
 	}, nil
 }
 
+// MakeDynamicExecuteStmt makes a DynamicExecute node. The query string
+// expression may be followed by INTO and USING clauses, in either order.
 func (l *lexer) MakeDynamicExecuteStmt() (*plpgsqltree.DynamicExecute, error) {
-	cmdStr, _, err := l.ReadSqlStatement(INTO, USING, ';')
+	queryStr, terminator, err := l.ReadSqlExpr(INTO, USING, ';')
 	if err != nil {
 		return nil, err
 	}
-	ret := &plpgsqltree.DynamicExecute{
-		Query: cmdStr,
+	query, err := l.ParseExpr(queryStr)
+	if err != nil {
+		return nil, err
 	}
-
-	var lval plpgsqlSymType
-	l.Lex(&lval)
-	for {
-		if lval.id == INTO {
-			if ret.Into {
-				return nil, errors.New("multiple INTO keywords")
+	ret := &plpgsqltree.DynamicExecute{Query: query}
+	for terminator != ';' {
+		switch terminator {
+		case INTO:
+			if ret.Target != nil {
+				return nil, errors.New("INTO specified more than once")
 			}
-			ret.Into = true
-			nextTok := l.Peek()
-			if nextTok.id == int32(STRICT) {
-				l.Lex(&lval)
+			// Move past the INTO.
+			l.lastPos++
+			if l.Peek().id == STRICT {
 				ret.Strict = true
+				l.lastPos++
 			}
-			// TODO we need to read each "INTO" variable name instead of just a
-			// string.
-			_, _, err = l.ReadSqlExpr(USING, ';')
-			if err != nil {
-				return nil, err
-			}
-			l.Lex(&lval)
-		} else if lval.id == USING {
+			ret.Target, terminator, err = l.readIntoTarget(INTO, USING, ';')
+		case USING:
 			if ret.Params != nil {
-				return nil, errors.New("multiple USING keywords")
+				return nil, errors.New("USING specified more than once")
 			}
-			ret.Params = make([]plpgsqltree.Expr, 0)
-			for {
-				_, _, err = l.ReadSqlExpr(',', ';', INTO)
-				if err != nil {
-					return nil, err
-				}
-				ret.Params = append(ret.Params, nil)
-				l.Lex(&lval)
-				if lval.id == ';' {
-					break
-				}
-			}
-		} else if lval.id == ';' {
-			break
-		} else {
-			return nil, errors.Newf("unexpected token: %s", lval.id)
+			// Move past the USING.
+			l.lastPos++
+			ret.Params, terminator, err = l.readUsingParams(INTO, USING, ';')
+		default:
+			return nil, errors.New("missing \";\" at end of EXECUTE statement")
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
-
+	// Move past the semicolon.
+	l.lastPos++
 	return ret, nil
 }
 
+// ReadDynamicOpenQuery reads the query string expression and the optional
+// USING clause of an OPEN ... FOR EXECUTE statement.
+func (l *lexer) ReadDynamicOpenQuery() (
+	query plpgsqltree.Expr,
+	params []plpgsqltree.Expr,
+	err error,
+) {
+	queryStr, terminator, err := l.ReadSqlExpr(USING, ';')
+	if err != nil {
+		return nil, nil, err
+	}
+	if query, err = l.ParseExpr(queryStr); err != nil {
+		return nil, nil, err
+	}
+	if terminator == USING {
+		// Move past the USING.
+		l.lastPos++
+		if params, terminator, err = l.readUsingParams(';'); err != nil {
+			return nil, nil, err
+		}
+	}
+	if terminator != ';' {
+		return nil, nil, errors.New("missing \";\" at end of OPEN statement")
+	}
+	// Move past the semicolon.
+	l.lastPos++
+	return query, params, nil
+}
+
+// readIntoTarget reads the comma-separated list of variables that make up the
+// target of an INTO clause, up to one of the given terminators.
+func (l *lexer) readIntoTarget(
+	terminator1 int, terminators ...int,
+) (target []plpgsqltree.Variable, terminatorMet int, err error) {
+	startPos, endPos, terminatorMet, err := l.readSQLConstruct(
+		true /* isExpr */, false /* allowEmpty */, terminator1, terminators...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	for pos := startPos; pos < endPos; pos += 2 {
+		tok := l.tokens[pos]
+		if tok.id != IDENT {
+			return nil, 0, errors.Newf("\"%s\" is not a scalar variable", tok.str)
+		}
+		if pos+1 != endPos && l.tokens[pos+1].id != ',' {
+			return nil, 0, errors.Newf("expected INTO target to be a comma-separated list")
+		}
+		variable := plpgsqltree.Variable(strings.TrimSpace(l.getStr(pos, pos+1)))
+		target = append(target, variable)
+	}
+	return target, terminatorMet, nil
+}
+
+// readUsingParams reads the comma-separated list of expressions that make up a
+// USING clause, up to one of the given terminators.
+func (l *lexer) readUsingParams(
+	terminator1 int, terminators ...int,
+) (params []plpgsqltree.Expr, terminatorMet int, err error) {
+	terminators = append([]int{terminator1}, terminators...)
+	for {
+		var paramStr string
+		paramStr, terminatorMet, err = l.ReadSqlExpr(',', terminators...)
+		if err != nil {
+			return nil, 0, err
+		}
+		param, err := l.ParseExpr(paramStr)
+		if err != nil {
+			return nil, 0, err
+		}
+		params = append(params, param)
+		if terminatorMet != ',' {
+			return params, terminatorMet, nil
+		}
+		// Move past the comma.
+		l.lastPos++
+	}
+}
+
 func (l *lexer) readSQLConstruct(
 	isExpr, allowEmpty bool, terminator1 int, terminators ...int,
 ) (startPos, endPos, terminatorMet int, err error) {
@@ -346,21 +424,10 @@ func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error)
 		}
 		// Read past the INTO.
 		l.lastPos++
-		startPos, endPos, _, err := l.readSQLConstruct(true /* isExpr */, false /* allowEmpty */, ';')
+		target, _, err = l.readIntoTarget(';')
 		if err != nil {
 			return nil, err
 		}
-		for pos := startPos; pos < endPos; pos += 2 {
-			tok := l.tokens[pos]
-			if tok.id != IDENT {
-				return nil, errors.Newf("\"%s\" is not a scalar variable", tok.str)
-			}
-			if pos+1 != endPos && l.tokens[pos+1].id != ',' {
-				return nil, errors.Newf("expected INTO target to be a comma-separated list")
-			}
-			variable := plpgsqltree.Variable(strings.TrimSpace(l.getStr(pos, pos+1)))
-			target = append(target, variable)
-		}
 		if len(target) == 0 {
 			return nil, errors.Newf("expected INTO target")
 		}
@@ -374,6 +441,78 @@ func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error)
 	}, nil
 }
 
+// ReadForLoopControl reads the control clause of a FOR loop up to the LOOP
+// keyword. The clause is either an integer range of the form
+// [REVERSE] lower..upper [BY step], or a query whose rows are iterated over.
+func (l *lexer) ReadForLoopControl() (plpgsqltree.ForLoopControl, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	var reverse bool
+	if l.Peek().id == REVERSE {
+		reverse = true
+		l.lastPos++
+	}
+	startPos, endPos, _, err := l.readSQLConstruct(false /* isExpr */, false /* allowEmpty */, LOOP)
+	if err != nil {
+		return nil, err
+	}
+	// An integer FOR loop is identified by a ".." token outside of any
+	// parentheses or brackets.
+	dotDotPos, byPos := -1, -1
+	parenLevel := 0
+	for pos := startPos; pos < endPos; pos++ {
+		switch l.tokens[pos].id {
+		case '(', '[':
+			parenLevel++
+		case ')', ']':
+			parenLevel--
+		case DOT_DOT:
+			if parenLevel == 0 && dotDotPos == -1 {
+				dotDotPos = pos
+			}
+		case BY:
+			if parenLevel == 0 && dotDotPos != -1 && byPos == -1 {
+				byPos = pos
+			}
+		}
+	}
+	if dotDotPos == -1 {
+		if reverse {
+			return nil, errors.New("cannot specify REVERSE in query FOR loop")
+		}
+		sqlStmt, err := parser.ParseOne(l.getStr(startPos, endPos))
+		if err != nil {
+			return nil, err
+		}
+		if sqlStmt.AST.StatementReturnType() != tree.Rows {
+			return nil, errors.New("FOR loop query must return rows")
+		}
+		return &plpgsqltree.QueryForLoopControl{Query: sqlStmt.AST}, nil
+	}
+	upperEndPos := endPos
+	if byPos != -1 {
+		upperEndPos = byPos
+	}
+	if dotDotPos == startPos || upperEndPos == dotDotPos+1 || byPos == endPos-1 {
+		return nil, errors.New("missing expression")
+	}
+	control := &plpgsqltree.IntForLoopControl{Reverse: reverse}
+	if control.Lower, err = l.ParseExpr(l.getStr(startPos, dotDotPos)); err != nil {
+		return nil, err
+	}
+	if control.Upper, err = l.ParseExpr(l.getStr(dotDotPos+1, upperEndPos)); err != nil {
+		return nil, err
+	}
+	if byPos != -1 {
+		if control.Step, err = l.ParseExpr(l.getStr(byPos+1, endPos)); err != nil {
+			return nil, err
+		}
+	}
+	return control, nil
+}
+
 func (l *lexer) ReadSqlExpr(
 	terminator1 int, terminators ...int,
 ) (sqlStr string, terminatorMet int, err error) {
@@ -493,6 +632,55 @@ func (l *lexer) GetTypeFromValidSQLSyntax(sqlStr string) (tree.ResolvableTypeRef
 	return parser.GetTypeFromValidSQLSyntax(sqlStr)
 }
 
+// ReadDeclDatatype reads the data type of a variable declaration, up to one of
+// the given terminators. In addition to SQL type names, the type can be copied
+// from the row type of a table with tbl%ROWTYPE, or from a column or variable
+// with name%TYPE.
+func (l *lexer) ReadDeclDatatype(
+	terminator1 int, terminators ...int,
+) (tree.ResolvableTypeReference, error) {
+	startPos, endPos, _, err := l.readSQLConstruct(
+		true /* isExpr */, false /* allowEmpty */, terminator1, terminators...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if endPos-startPos > 2 && l.tokens[endPos-2].id == '%' {
+		nameStr := l.getStr(startPos, endPos-2)
+		switch l.tokens[endPos-1].id {
+		case ROWTYPE:
+			// The row type of a table is the composite type with the same name.
+			return parser.ParseTableName(nameStr)
+		case TYPE:
+			expr, err := parser.ParseExpr(nameStr)
+			if err != nil {
+				return nil, err
+			}
+			if name, ok := expr.(*tree.UnresolvedName); ok && !name.Star {
+				return &plpgsqltree.PercentTypeReference{Name: name}, nil
+			}
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"invalid type name \"%s\"", strings.TrimSpace(nameStr),
+			)
+		}
+	}
+	return l.GetTypeFromValidSQLSyntax(l.getStr(startPos, endPos))
+}
+
+// setStmtPosition sets the line number of the given statement to the line of
+// the token at the given position, and assigns the next statement ID. Line
+// numbers start from 1 at the beginning of the routine body, as in postgres.
+// Statement IDs are assigned in the order in which the statements are parsed,
+// so a nested statement has a lower ID than the statement that contains it.
+func (l *lexer) setStmtPosition(stmt plpgsqltree.Statement, tokenPos int32) {
+	lineNo := 0
+	if tokenPos >= 0 && int(tokenPos) < len(l.tokens) {
+		lineNo = l.skippedLines + strings.Count(l.in[:l.tokens[tokenPos].pos], "\n") + 1
+	}
+	l.numStmts++
+	stmt.SetPosition(lineNo, l.numStmts)
+}
+
 func (l *lexer) ParseExpr(sqlStr string) (plpgsqltree.Expr, error) {
 	// Use ParseExprs instead of ParseExpr in order to correctly handle the case
 	// when multiple expressions are incorrectly passed.
@@ -515,3 +703,26 @@ func checkLoopLabels(start, end string) error {
 	}
 	return nil
 }
+
+// checkDiagnosticsItem returns an error if the given GET DIAGNOSTICS item is
+// not allowed for the given diagnostics area.
+func checkDiagnosticsItem(kind plpgsqltree.GetDiagnosticsKind, isStacked bool) error {
+	switch kind {
+	case plpgsqltree.GetDiagnosticsContext:
+		// PG_CONTEXT is allowed for both the current and stacked areas.
+		return nil
+	case plpgsqltree.GetDiagnosticsRowCount:
+		if isStacked {
+			return pgerror.Newf(pgcode.Syntax,
+				"diagnostics item %s is not allowed in GET STACKED DIAGNOSTICS", kind,
+			)
+		}
+	default:
+		if !isStacked {
+			return pgerror.Newf(pgcode.Syntax,
+				"diagnostics item %s is not allowed in GET CURRENT DIAGNOSTICS", kind,
+			)
+		}
+	}
+	return nil
+}
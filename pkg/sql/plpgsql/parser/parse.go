@@ -13,6 +13,7 @@ package parser
 
 import (
 	"go/constant"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/scanner"
@@ -46,30 +47,36 @@ func (p *Parser) Parse(sql string) (statements.PLpgStatement, error) {
 	return p.parseWithDepth(1, sql, defaultNakedIntType)
 }
 
-func (p *Parser) scanFnBlock() (sql string, tokens []plpgsqlSymType, done bool) {
+// scanFnBlock scans the tokens of the function body. It returns the body
+// starting from the first token, and the number of lines that precede the first
+// token in the input.
+func (p *Parser) scanFnBlock() (
+	sql string, skippedLines int, tokens []plpgsqlSymType, done bool,
+) {
 	var lval plpgsqlSymType
 	tokens = p.tokBuf[:0]
 
 	// Scan the first token.
 	p.scanner.Scan(&lval)
 	if lval.id == 0 {
-		return "", nil, true
+		return "", 0, nil, true
 	}
 
 	startPos := lval.pos
+	skippedLines = strings.Count(p.scanner.In()[:startPos], "\n")
 	// We make the resulting token positions match the returned string.
 	lval.pos = 0
 	tokens = append(tokens, lval)
 	for {
 		if lval.id == ERROR {
-			return p.scanner.In()[startPos:], tokens, true
+			return p.scanner.In()[startPos:], skippedLines, tokens, true
 		}
 		// Reset the plpgsqlSymType struct before scanning.
 		lval = plpgsqlSymType{}
 		posBeforeScan := p.scanner.Pos()
 		p.scanner.Scan(&lval)
 		if lval.id == 0 {
-			return p.scanner.In()[startPos:posBeforeScan], tokens, (lval.id == 0)
+			return p.scanner.In()[startPos:posBeforeScan], skippedLines, tokens, (lval.id == 0)
 		}
 		lval.pos -= startPos
 		tokens = append(tokens, lval)
@@ -81,8 +88,8 @@ func (p *Parser) parseWithDepth(
 ) (statements.PLpgStatement, error) {
 	p.scanner.Init(plpgsql)
 	defer p.scanner.Cleanup()
-	sql, tokens, done := p.scanFnBlock()
-	stmt, err := p.parse(depth+1, sql, tokens, nakedIntType)
+	sql, skippedLines, tokens, done := p.scanFnBlock()
+	stmt, err := p.parse(depth+1, sql, skippedLines, tokens, nakedIntType)
 	if err != nil {
 		return statements.PLpgStatement{}, err
 	}
@@ -92,11 +99,13 @@ func (p *Parser) parseWithDepth(
 	return stmt, nil
 }
 
-// parse parses a statement from the given scanned tokens.
+// parse parses a statement from the given scanned tokens. skippedLines is the
+// number of lines that precede sql in the function body, and is used to
+// compute the line numbers of statements.
 func (p *Parser) parse(
-	depth int, sql string, tokens []plpgsqlSymType, nakedIntType *types.T,
+	depth int, sql string, skippedLines int, tokens []plpgsqlSymType, nakedIntType *types.T,
 ) (statements.PLpgStatement, error) {
-	p.lexer.init(sql, tokens, nakedIntType, &p.parserImpl)
+	p.lexer.init(sql, skippedLines, tokens, nakedIntType, &p.parserImpl)
 	defer p.lexer.cleanup()
 	if p.parserImpl.Parse(&p.lexer) != 0 {
 		if p.lexer.lastError == nil {
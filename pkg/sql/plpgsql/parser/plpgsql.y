@@ -167,6 +167,14 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
     return u.val.(tree.Statement)
 }
 
+func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
+    return u.val.([]plpgsqltree.Variable)
+}
+
+func (u *plpgsqlSymUnion) forLoopControl() plpgsqltree.ForLoopControl {
+    return u.val.(plpgsqltree.ForLoopControl)
+}
+
 %}
 /*
  * Basic non-keyword token types.  These are hard-wired into the core lexer.
@@ -311,7 +319,7 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
   union plpgsqlSymUnion
 }
 
-%type <str> decl_varname decl_defkey
+%type <str> decl_varname decl_defkey decl_aliasitem
 %type <bool>	decl_const decl_notnull
 %type <plpgsqltree.Expr>	decl_defval decl_cursor_query
 %type <tree.ResolvableTypeReference>	decl_datatype
@@ -321,14 +329,15 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.Expr>	opt_exitcond
 
-%type <forvariable>	for_variable
-%type <*tree.NumVal>	foreach_slice
-%type <plpgsqltree.Statement>	for_control
+%type <[]plpgsqltree.Variable>	for_variable
+%type <int32>	foreach_slice
+%type <plpgsqltree.ForLoopControl>	for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
+%type <int32> stmt_start
 %type <[]plpgsqltree.ElseIf> stmt_elsifs
 %type <[]plpgsqltree.Statement> stmt_else loop_body
 %type <plpgsqltree.Statement>  pl_block
@@ -458,7 +467,10 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
   }
 | decl_varname ALIAS FOR decl_aliasitem ';'
   {
-    return unimplemented(plpgsqllex, "alias for")
+    $$.val = &plpgsqltree.AliasDeclaration{
+      Var: plpgsqltree.Variable($1),
+      Target: plpgsqltree.Variable($4),
+    }
   }
 | decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for decl_cursor_query
   {
@@ -549,16 +561,12 @@ decl_datatype:
   {
     // Read until reaching one of the tokens that can follow a declaration
     // data type.
-    sqlStr, _, err := plpgsqllex.(*lexer).ReadSqlExpr(
+    typ, err := plpgsqllex.(*lexer).ReadDeclDatatype(
       ';', COLLATE, NOT, '=', COLON_EQUALS, DECLARE,
     )
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    typ, err := plpgsqllex.(*lexer).GetTypeFromValidSQLSyntax(sqlStr)
-    if err != nil {
-      return setErr(plpgsqllex, err)
-    }
     $$.val = typ
   }
 ;
@@ -632,14 +640,25 @@ proc_sect:
   {
     $$.val = []plpgsqltree.Statement{}
   }
-| proc_sect proc_stmt
+| proc_sect stmt_start proc_stmt
   {
     stmts := $1.statements()
-    stmts = append(stmts, $2.statement())
+    stmt := $3.statement()
+    plpgsqllex.(*lexer).setStmtPosition(stmt, $2.int32())
+    stmts = append(stmts, stmt)
     $$.val = stmts
   }
 ;
 
+// stmt_start matches the empty string before a statement. It records the
+// position of the first token of the statement, which is the lookahead token
+// when the rule is reduced.
+stmt_start:
+  {
+    $$.val = int32(plpgsqllex.(*lexer).lastPos)
+  }
+;
+
 proc_stmt:pl_block ';'
   {
     $$.val = $1.block()
@@ -736,7 +755,12 @@ proc_stmt:pl_block ';'
 
 stmt_perform: PERFORM stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    // PERFORM is equivalent to a SELECT statement with its result discarded.
+    stmt, err := parser.ParseOne("SELECT " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Perform{SqlStmt: stmt.AST}
   }
 ;
 
@@ -770,15 +794,33 @@ stmt_assign: IDENT assign_operator expr_until_semi ';'
       Value: expr,
     }
   }
+| IDENT '.' any_identifier assign_operator expr_until_semi ';'
+  {
+    expr, err := plpgsqllex.(*lexer).ParseExpr($5)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Assignment{
+      Var: plpgsqltree.Variable($1),
+      Field: tree.Name($3),
+      Value: expr,
+    }
+  }
 ;
 
 stmt_getdiag: GET getdiag_area_opt DIAGNOSTICS getdiag_list ';'
   {
-  $$.val = &plpgsqltree.GetDiagnostics{
-    IsStacked: $2.bool(),
-    DiagItems: $4.getDiagnosticsItemList(),
-  }
-  // TODO(drewk): Check information items are valid for area option.
+    isStacked := $2.bool()
+    items := $4.getDiagnosticsItemList()
+    for _, item := range items {
+      if err := checkDiagnosticsItem(item.Kind, isStacked); err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    $$.val = &plpgsqltree.GetDiagnostics{
+      IsStacked: isStacked,
+      DiagItems: items,
+    }
   }
 ;
 
@@ -809,9 +851,8 @@ getdiag_list: getdiag_list ',' getdiag_list_item
 getdiag_list_item: IDENT assign_operator getdiag_item
   {
     $$.val = &plpgsqltree.GetDiagnosticsItem{
-      Kind : $3.getDiagnosticsKind(),
-      TargetName: $1,
-      // TODO(drewk): set the target from $1.
+      Kind: $3.getDiagnosticsKind(),
+      Target: plpgsqltree.Variable($1),
     }
   }
 ;
@@ -992,54 +1033,86 @@ stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_variable IN for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    loopLabel, loopEndLabel := $1, $8
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForLoop{
+      Label: $1,
+      Target: $3.variables(),
+      Control: $5.forLoopControl(),
+      Body: $7.statements(),
+    }
   }
 ;
 
-for_control: for_variable IN
-  // TODO(drewk) need to parse the sql expression here.
+/*
+ * We don't know whether this is an integer FOR loop or a loop over the rows of
+ * a query until we have read the control tokens up to LOOP, so the lexer reads
+ * them all and decides based on the presence of a top-level "..".
+ */
+for_control:
+  EXECUTE
   {
-    return unimplemented(plpgsqllex, "for loop")
+    return unimplemented(plpgsqllex, "dynamic for loop")
+  }
+| /* EMPTY */
+  {
+    control, err := plpgsqllex.(*lexer).ReadForLoopControl()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = control
   }
 ;
 
 /*
- * Processing the for_variable is tricky because we don't yet know if the
- * FOR is an integer FOR loop or a loop over query results.  In the former
- * case, the variable is just a name that we must instantiate as a loop
- * local variable, regardless of any other definition it might have.
- * Therefore, we always save the actual identifier into $$.name where it
- * can be used for that case.  We also save the outer-variable definition,
- * if any, because that's what we need for the loop-over-query case.  Note
- * that we must NOT apply check_assignable() or any other semantic check
- * until we know what's what.
- *
- * However, if we see a comma-separated list of names, we know that it
- * can't be an integer FOR loop and so it's OK to check the variables
- * immediately.  In particular, for T_WORD followed by comma, we should
- * complain that the name is not known rather than say it's a syntax error.
- * Note that the non-error result of this case sets *both* $$.scalar and
- * $$.row; see the for_control production.
+ * A FOR loop that iterates over a query can assign to a comma-separated list
+ * of variables, one for each column of the query.
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.Variable{plpgsqltree.Variable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.variables(), plpgsqltree.Variable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    loopLabel, loopEndLabel := $1, $10
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: $1,
+      Target: $3.variables(),
+      Slice: int($4.int32()),
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = int32(0)
   }
 | SLICE ICONST
   {
+    slice, err := $2.numVal().AsInt32()
+    if err != nil || slice < 0 {
+      return setErr(plpgsqllex, errors.New("SLICE must be a non-negative integer constant"))
+    }
+    $$.val = slice
   }
 ;
 
@@ -1073,39 +1146,42 @@ stmt_return: RETURN return_expr ';'
     }
     $$.val = &plpgsqltree.Return{Expr: expr}
   }
-| RETURN_NEXT NEXT
+| RETURN_NEXT NEXT return_expr ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    var expr plpgsqltree.Expr
+    if $3 != "" {
+      var err error
+      expr, err = plpgsqllex.(*lexer).ParseExpr($3)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    $$.val = &plpgsqltree.ReturnNext{Expr: expr}
   }
-| RETURN_QUERY QUERY
- {
-   return unimplemented (plpgsqllex, "return query")
- }
-;
-
-return_expr:
+| RETURN_QUERY QUERY EXECUTE
   {
-    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
+    return unimplemented(plpgsqllex, "return dynamic sql query")
+  }
+| RETURN_QUERY QUERY stmt_until_semi ';'
+  {
+    stmt, err := parser.ParseOne($3)
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    $$ = sqlStr
+    if stmt.AST.StatementReturnType() != tree.Rows {
+      return setErr(plpgsqllex, errors.New("RETURN QUERY used with a command that cannot return data"))
+    }
+    $$.val = &plpgsqltree.ReturnQuery{SqlStmt: stmt.AST}
   }
 ;
 
-query_options:
+return_expr:
   {
-    _, terminator, err := plpgsqllex.(*lexer).ReadSqlExpr(EXECUTE, ';')
-    if err != nil {
-      return setErr(plpgsqllex, err)
-    }
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
-    }
-    _, _, err = plpgsqllex.(*lexer).ReadSqlExpr(';')
+    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
+    $$ = sqlStr
   }
 ;
 
@@ -1312,9 +1388,18 @@ stmt_open: OPEN IDENT ';'
   {
     $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
   }
-| OPEN IDENT opt_scrollable FOR EXECUTE 
+| OPEN IDENT opt_scrollable FOR EXECUTE
   {
-    return unimplemented(plpgsqllex, "cursor for execute")
+    query, params, err := plpgsqllex.(*lexer).ReadDynamicOpenQuery()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Open{
+      CurVar: plpgsqltree.Variable($2),
+      Scroll: $3.cursorScrollOption(),
+      DynamicQuery: query,
+      Params: params,
+    }
   }
 | OPEN IDENT opt_scrollable FOR stmt_until_semi ';'
   {
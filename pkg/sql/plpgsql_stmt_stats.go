@@ -0,0 +1,289 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/lib/pq/oid"
+)
+
+var plpgsqlStmtStatsEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.metrics.plpgsql_statement_stats.enabled",
+	"collect per-node execution statistics for the statements within PL/pgSQL "+
+		"routines, which are exposed in crdb_internal.node_plpgsql_statement_statistics",
+	true,
+)
+
+// maxPLpgSQLStmtStatsEntries is the maximum number of statements for which the
+// node-level PLpgSQLStmtStats tracks execution statistics. Once the limit is
+// reached, the statistics of the least recently executed statements are
+// evicted.
+const maxPLpgSQLStmtStatsEntries = 10000
+
+// numPLpgSQLStmtStatsShards is the number of shards used by the node-level
+// PLpgSQLStmtStats. Each session records into a single shard, so that
+// concurrent sessions rarely contend on the same mutex.
+const numPLpgSQLStmtStatsShards = 16
+
+// PLpgSQLStmtStats collects execution statistics for the statements within
+// PL/pgSQL routines. A node-level instance is kept in the ExecutorConfig, and a
+// statement-level instance is used by the instrumentationHelper to surface the
+// statistics in EXPLAIN ANALYZE (VERBOSE) and statement diagnostics bundles.
+type PLpgSQLStmtStats struct {
+	shards []plpgsqlStmtStatsShard
+}
+
+// plpgsqlStmtStatsShard holds the statistics recorded by a subset of the
+// sessions. The statistics of a single statement may be spread across several
+// shards; they are combined when read.
+type plpgsqlStmtStatsShard struct {
+	mu struct {
+		syncutil.Mutex
+		// stats maps a plpgsqlStmtKey to a *plpgsqlStmtStatsEntry.
+		stats *cache.UnorderedCache
+	}
+}
+
+// plpgsqlStmtKey uniquely identifies a statement within a version of a
+// PL/pgSQL routine.
+type plpgsqlStmtKey struct {
+	routineID      oid.Oid
+	routineVersion uint64
+	stmtID         uint
+}
+
+// plpgsqlStmtStatsEntry contains the execution statistics of a single
+// statement within a PL/pgSQL routine.
+type plpgsqlStmtStatsEntry struct {
+	info         tree.RoutineStmtInfo
+	execCount    int64
+	errorCount   int64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// meanLatency returns the mean execution latency of the statement.
+func (e *plpgsqlStmtStatsEntry) meanLatency() time.Duration {
+	if e.execCount == 0 {
+		return 0
+	}
+	return e.totalLatency / time.Duration(e.execCount)
+}
+
+// add merges the statistics of the given entry into e.
+func (e *plpgsqlStmtStatsEntry) add(other *plpgsqlStmtStatsEntry) {
+	e.execCount += other.execCount
+	e.errorCount += other.errorCount
+	e.totalLatency += other.totalLatency
+	if other.maxLatency > e.maxLatency {
+		e.maxLatency = other.maxLatency
+	}
+}
+
+// NewPLpgSQLStmtStats returns a new, empty PLpgSQLStmtStats for collecting
+// node-level statistics.
+func NewPLpgSQLStmtStats() *PLpgSQLStmtStats {
+	return newPLpgSQLStmtStats(numPLpgSQLStmtStatsShards, maxPLpgSQLStmtStatsEntries)
+}
+
+// newPLpgSQLStmtStats returns a new, empty PLpgSQLStmtStats with the given
+// number of shards, which tracks at most maxEntries statements. If maxEntries
+// is zero, the number of statements is not limited.
+func newPLpgSQLStmtStats(numShards, maxEntries int) *PLpgSQLStmtStats {
+	s := &PLpgSQLStmtStats{shards: make([]plpgsqlStmtStatsShard, numShards)}
+	maxShardEntries := maxEntries / numShards
+	for i := range s.shards {
+		s.shards[i].mu.stats = cache.NewUnorderedCache(cache.Config{
+			Policy: cache.CacheLRU,
+			ShouldEvict: func(size int, _, _ interface{}) bool {
+				return maxShardEntries > 0 && size > maxShardEntries
+			},
+		})
+	}
+	return s
+}
+
+// record adds a single execution of the given statement, which took the given
+// latency and returned the given error (which may be nil). The shard hint
+// determines the shard that is used; it should be stable for a session.
+func (s *PLpgSQLStmtStats) record(
+	shardHint uint64, info *tree.RoutineStmtInfo, latency time.Duration, err error,
+) {
+	key := plpgsqlStmtKey{
+		routineID:      info.RoutineID,
+		routineVersion: info.RoutineVersion,
+		stmtID:         info.StmtID,
+	}
+	shard := &s.shards[shardHint%uint64(len(s.shards))]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	var e *plpgsqlStmtStatsEntry
+	if v, ok := shard.mu.stats.Get(key); ok {
+		e = v.(*plpgsqlStmtStatsEntry)
+	} else {
+		e = &plpgsqlStmtStatsEntry{info: *info}
+		shard.mu.stats.Add(key, e)
+	}
+	e.execCount++
+	if err != nil {
+		e.errorCount++
+	}
+	e.totalLatency += latency
+	if latency > e.maxLatency {
+		e.maxLatency = latency
+	}
+}
+
+// entries returns a copy of the collected statistics, ordered by routine and
+// then by the position of the statement within the routine.
+func (s *PLpgSQLStmtStats) entries() []plpgsqlStmtStatsEntry {
+	var res []plpgsqlStmtStatsEntry
+	idx := make(map[plpgsqlStmtKey]int)
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		shard.mu.stats.Do(func(ce *cache.Entry) {
+			e := ce.Value.(*plpgsqlStmtStatsEntry)
+			key := ce.Key.(plpgsqlStmtKey)
+			if j, ok := idx[key]; ok {
+				res[j].add(e)
+				return
+			}
+			idx[key] = len(res)
+			res = append(res, *e)
+		})
+		shard.mu.Unlock()
+	}
+	sort.Slice(res, func(i, j int) bool {
+		l, r := &res[i].info, &res[j].info
+		if l.RoutineSignature != r.RoutineSignature {
+			return l.RoutineSignature < r.RoutineSignature
+		}
+		if l.RoutineID != r.RoutineID {
+			return l.RoutineID < r.RoutineID
+		}
+		if l.RoutineVersion != r.RoutineVersion {
+			return l.RoutineVersion < r.RoutineVersion
+		}
+		if l.LineNo != r.LineNo {
+			return l.LineNo < r.LineNo
+		}
+		return l.StmtID < r.StmtID
+	})
+	return res
+}
+
+// String formats the collected statistics with one line per statement. It is
+// used for the statement diagnostics bundle.
+func (s *PLpgSQLStmtStats) String() string {
+	var sb strings.Builder
+	for _, e := range s.entries() {
+		fmt.Fprintf(&sb,
+			"%s (statement %d): executions: %d, errors: %d, total time: %s, mean time: %s, max time: %s\n",
+			e.info.Context(), e.info.StmtID, e.execCount, e.errorCount,
+			humanizeutil.Duration(e.totalLatency), humanizeutil.Duration(e.meanLatency()),
+			humanizeutil.Duration(e.maxLatency),
+		)
+	}
+	return sb.String()
+}
+
+// recordPLpgSQLStmtStats records a single execution of the given statement
+// within a PL/pgSQL routine, both in the statistics collected for the current
+// statement (if any) and in the node-level statistics.
+func (p *planner) recordPLpgSQLStmtStats(
+	info *tree.RoutineStmtInfo, latency time.Duration, err error,
+) {
+	if stmtStats := p.instrumentation.plpgsqlStmtStats; stmtStats != nil {
+		stmtStats.record(0 /* shardHint */, info, latency, err)
+	}
+	if nodeStats := p.execCfg.PLpgSQLStmtStats; nodeStats != nil &&
+		plpgsqlStmtStatsEnabled.Get(&p.execCfg.Settings.SV) {
+		// The high bits of the session ID contain its creation timestamp, so
+		// mix them to spread the sessions evenly across the shards.
+		sessionID := p.ExtendedEvalContext().SessionID
+		shardHint := ((sessionID.Hi ^ sessionID.Lo) * 0x9E3779B97F4A7C15) >> 32
+		nodeStats.record(shardHint, info, latency, err)
+	}
+}
+
+// plpgsqlStmtTimer measures the latency of an execution of a statement within
+// a PL/pgSQL routine.
+type plpgsqlStmtTimer struct {
+	info  *tree.RoutineStmtInfo
+	start time.Time
+	// stopped is set once the execution has been recorded.
+	stopped bool
+}
+
+// startPLpgSQLStmtTimer starts measuring the latency of an execution of the
+// given statement. It must be followed by a call to stopPLpgSQLStmtTimer.
+func (p *planner) startPLpgSQLStmtTimer(info *tree.RoutineStmtInfo) {
+	p.plpgsqlStmtTimers = append(p.plpgsqlStmtTimers, plpgsqlStmtTimer{
+		info:  info,
+		start: timeutil.Now(),
+	})
+}
+
+// stopPLpgSQLStmtTimer removes the innermost statement timer, and records the
+// execution of its statement unless the continuation of the statement already
+// did so (see finishPLpgSQLStmt).
+func (p *planner) stopPLpgSQLStmtTimer(err error) {
+	n := len(p.plpgsqlStmtTimers)
+	if n == 0 {
+		return
+	}
+	t := p.plpgsqlStmtTimers[n-1]
+	p.plpgsqlStmtTimers = p.plpgsqlStmtTimers[:n-1]
+	if !t.stopped {
+		p.recordPLpgSQLStmtStats(t.info, timeutil.Since(t.start), err)
+	}
+}
+
+// finishPLpgSQLStmt is called when a continuation routine that is called at the
+// end of the body statement of the given statement starts. It records the
+// execution of the statement, so that the time spent in the continuation, which
+// executes the remaining statements of the routine, is not attributed to it.
+// It is a no-op if the statement is not being timed, e.g. because the
+// continuation was deferred by tail-call optimization and the timer was
+// already stopped.
+func (p *planner) finishPLpgSQLStmt(info *tree.RoutineStmtInfo) {
+	n := len(p.plpgsqlStmtTimers)
+	if n == 0 {
+		return
+	}
+	t := &p.plpgsqlStmtTimers[n-1]
+	if t.stopped || *t.info != *info {
+		return
+	}
+	t.stopped = true
+	p.recordPLpgSQLStmtStats(t.info, timeutil.Since(t.start), nil /* err */)
+}
+
+// shouldRecordPLpgSQLStmtStats returns true if execution statistics should be
+// collected for the statements within PL/pgSQL routines.
+func (p *planner) shouldRecordPLpgSQLStmtStats() bool {
+	if p.instrumentation.plpgsqlStmtStats != nil {
+		return true
+	}
+	return p.execCfg.PLpgSQLStmtStats != nil && plpgsqlStmtStatsEnabled.Get(&p.execCfg.Settings.SV)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPLpgSQLStmtStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	stmt := func(routineID oid.Oid, version uint64, stmtID uint) *tree.RoutineStmtInfo {
+		return &tree.RoutineStmtInfo{
+			RoutineSignature: "f(bigint)",
+			RoutineID:        routineID,
+			RoutineVersion:   version,
+			StmtID:           stmtID,
+			LineNo:           int(stmtID),
+			StmtType:         "RAISE",
+		}
+	}
+
+	t.Run("merge shards", func(t *testing.T) {
+		s := newPLpgSQLStmtStats(4 /* numShards */, 0 /* maxEntries */)
+		for shard := uint64(0); shard < 4; shard++ {
+			s.record(shard, stmt(100, 1, 1), time.Duration(shard+1)*time.Second, nil /* err */)
+		}
+		s.record(1, stmt(100, 1, 1), time.Second, errors.New("boom"))
+		entries := s.entries()
+		require.Len(t, entries, 1)
+		require.Equal(t, int64(5), entries[0].execCount)
+		require.Equal(t, int64(1), entries[0].errorCount)
+		require.Equal(t, 11*time.Second, entries[0].totalLatency)
+		require.Equal(t, 4*time.Second, entries[0].maxLatency)
+	})
+
+	t.Run("routine versions", func(t *testing.T) {
+		s := newPLpgSQLStmtStats(1 /* numShards */, 0 /* maxEntries */)
+		s.record(0, stmt(100, 1, 1), time.Second, nil /* err */)
+		s.record(0, stmt(100, 2, 1), time.Second, nil /* err */)
+		s.record(0, stmt(101, 1, 1), time.Second, nil /* err */)
+		entries := s.entries()
+		require.Len(t, entries, 3)
+		for i, exp := range []struct {
+			routineID oid.Oid
+			version   uint64
+		}{{100, 1}, {100, 2}, {101, 1}} {
+			require.Equal(t, exp.routineID, entries[i].info.RoutineID)
+			require.Equal(t, exp.version, entries[i].info.RoutineVersion)
+		}
+	})
+
+	t.Run("evict least recently executed", func(t *testing.T) {
+		s := newPLpgSQLStmtStats(1 /* numShards */, 2 /* maxEntries */)
+		s.record(0, stmt(100, 1, 1), time.Second, nil /* err */)
+		s.record(0, stmt(100, 1, 2), time.Second, nil /* err */)
+		s.record(0, stmt(100, 1, 1), time.Second, nil /* err */)
+		s.record(0, stmt(100, 1, 3), time.Second, nil /* err */)
+		entries := s.entries()
+		require.Len(t, entries, 2)
+		require.Equal(t, uint(1), entries[0].info.StmtID)
+		require.Equal(t, int64(2), entries[0].execCount)
+		require.Equal(t, uint(3), entries[1].info.StmtID)
+	})
+}
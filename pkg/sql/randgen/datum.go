@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geogen"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
@@ -293,11 +294,87 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.RangeFamily:
+		return randRange(rng, typ)
+	case types.MultiRangeFamily:
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = randRange(rng, typ.MultiRangeContents())
+		}
+		return tree.NewDMultiRange(typ, ranges)
+	case types.GeometricFamily:
+		return randGeometric(rng, typ)
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
 }
 
+// randGeometric generates a random value of the given geometric type.
+func randGeometric(rng *rand.Rand, typ *types.T) *tree.DGeometric {
+	randPoint := func() tree.GeometricPoint {
+		return tree.GeometricPoint{X: rng.NormFloat64() * 100, Y: rng.NormFloat64() * 100}
+	}
+	randPoints := func(n int) []tree.GeometricPoint {
+		points := make([]tree.GeometricPoint, n)
+		for i := range points {
+			points[i] = randPoint()
+		}
+		return points
+	}
+	switch typ.Oid() {
+	case oid.T_point:
+		return tree.NewDPoint(randPoint())
+	case oid.T_line:
+		// The line through two distinct points.
+		for {
+			if l, err := tree.NewDLineFromPoints(randPoint(), randPoint()); err == nil {
+				return l
+			}
+		}
+	case oid.T_lseg:
+		return tree.NewDLSeg(randPoint(), randPoint())
+	case oid.T_box:
+		return tree.NewDBox(randPoint(), randPoint())
+	case oid.T_path:
+		return tree.NewDPath(randPoints(1+rng.Intn(5)), rng.Intn(2) == 0)
+	case oid.T_polygon:
+		return tree.NewDPolygon(randPoints(1 + rng.Intn(5)))
+	case oid.T_circle:
+		c, err := tree.NewDCircle(randPoint(), rng.Float64()*100)
+		if err != nil {
+			panic(err)
+		}
+		return c
+	default:
+		panic(errors.AssertionFailedf("invalid geometric type %v", typ.DebugString()))
+	}
+}
+
+// randRange generates a random range of the given range type. The range is
+// empty if random bounds cannot form a valid range.
+func randRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewEmptyDRange(typ)
+	}
+	var lower, upper tree.RangeBound
+	lower.Inclusive = rng.Intn(2) == 0
+	upper.Inclusive = rng.Intn(2) == 0
+	if rng.Intn(5) != 0 {
+		lower.Val = RandDatum(rng, typ.RangeContents(), false /* nullOk */)
+	}
+	if rng.Intn(5) != 0 {
+		upper.Val = RandDatum(rng, typ.RangeContents(), false /* nullOk */)
+	}
+	if lower.Val != nil && upper.Val != nil && lower.Val.Compare(&eval.Context{}, upper.Val) > 0 {
+		lower.Val, upper.Val = upper.Val, lower.Val
+	}
+	r, err := tree.NewDRange(typ, lower, upper)
+	if err != nil {
+		return tree.NewEmptyDRange(typ)
+	}
+	return r
+}
+
 // RandArray generates a random DArray where the contents have nullChance
 // of being null.
 func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
@@ -699,7 +699,8 @@ var postgresStatementMutator MultiStatementMutation = func(rng *rand.Rand, stmts
 						def.PartitionByIndex = nil
 						changed = true
 					}
-					if def.WithoutIndex {
+					// Postgres supports exclusion constraints natively.
+					if def.WithoutIndex && !def.IsExclusion() {
 						def.WithoutIndex = false
 						changed = true
 					}
@@ -13,7 +13,9 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -21,6 +23,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
 )
 
 type refreshMaterializedViewNode struct {
@@ -64,6 +68,10 @@ func (p *planner) RefreshMaterializedView(
 }
 
 func (n *refreshMaterializedViewNode) startExec(params runParams) error {
+	if n.n.Incremental {
+		return n.refreshIncrementally(params)
+	}
+
 	// We refresh a materialized view by creating a new set of indexes to write
 	// the result of the view query into. The existing set of indexes will remain
 	// present and readable so that reads of the view during the refresh operation
@@ -122,6 +130,68 @@ func (n *refreshMaterializedViewNode) startExec(params runParams) error {
 	)
 }
 
+// refreshIncrementally updates the data of the materialized view in place,
+// within the current transaction. Rather than recomputing the whole result of
+// the view query, the rows of the tables the view depends on that changed since
+// the last refresh are read from the MVCC history of the tables, and only the
+// rows of the view derived from them are recomputed. See incrementalViewQuery
+// for the queries that support this.
+func (n *refreshMaterializedViewNode) refreshIncrementally(params runParams) error {
+	p := params.p
+	if !p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V24_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"REFRESH MATERIALIZED VIEW INCREMENTAL is not supported until the cluster version is finalized")
+	}
+	if !p.extendedEvalCtx.TxnIsSingleStmt {
+		return pgerror.Newf(pgcode.InvalidTransactionState, "cannot refresh view in a multi-statement transaction")
+	}
+	if n.desc.IsRefreshViewRequired() {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"materialized view %q has not been populated", n.desc.GetName()),
+			"use REFRESH MATERIALIZED VIEW without INCREMENTAL to populate it.",
+		)
+	}
+	if n.desc.LastRefreshTime.IsEmpty() {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"materialized view %q has no data to refresh incrementally", n.desc.GetName()),
+			"use REFRESH MATERIALIZED VIEW without INCREMENTAL once to refresh it.",
+		)
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaRefreshMaterializedViewIncremental)
+
+	if n.n.Concurrently {
+		p.BufferClientNotice(
+			params.ctx,
+			pgnotice.Newf("CONCURRENTLY is not required as views are refreshed concurrently"),
+		)
+	}
+
+	q, err := p.makeIncrementalViewQuery(params.ctx, n.desc)
+	if err != nil {
+		return err
+	}
+	lastRefresh, readTS := n.desc.LastRefreshTime, p.txn.ReadTimestamp()
+	changedRows := func(
+		ctx context.Context, table catalog.TableDescriptor, maxRows int, acc *mon.BoundAccount,
+	) ([]tree.Datums, error) {
+		return p.changedPrimaryKeys(ctx, n.desc, table, lastRefresh, readTS, maxRows, acc)
+	}
+	if err := p.refreshIncrementalViewData(params.ctx, q, lastRefresh, readTS, changedRows); err != nil {
+		return err
+	}
+
+	n.desc.LastRefreshTime = readTS
+	return p.writeSchemaChange(
+		params.ctx,
+		n.desc,
+		descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
 func (n *refreshMaterializedViewNode) Next(params runParams) (bool, error) { return false, nil }
 func (n *refreshMaterializedViewNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *refreshMaterializedViewNode) Close(ctx context.Context)           {}
@@ -0,0 +1,999 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// incrementalRefreshMaxChangedRows limits the number of rows of the tables a
+// materialized view depends on that can change between two incremental
+// refreshes of the view.
+var incrementalRefreshMaxChangedRows = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.materialized_view.incremental_refresh.max_changed_rows",
+	"the maximum number of changed rows in the tables a materialized view depends "+
+		"on that an incremental refresh of the view applies",
+	100000,
+	settings.PositiveInt,
+)
+
+// incrementalRefreshBatchSize is the number of rows or groups of the view that
+// are deleted or inserted by a single statement during an incremental refresh.
+const incrementalRefreshBatchSize = 100
+
+// incrementalViewQuery is the query of a materialized view that can be
+// refreshed incrementally. The query must be a single SELECT over tables and
+// inner joins of tables, optionally with GROUP BY, HAVING or DISTINCT. It must
+// not contain subqueries, window functions or expressions that are not
+// immutable.
+//
+// Every row of the result of such a query is derived from exactly one row of
+// each table in its FROM clause. So the rows of the view that can be affected
+// by a set of changes to the tables are the rows that are derived from one of
+// the changed rows, both as of the last refresh and now. If the query
+// aggregates or deduplicates its rows, all rows of the groups that contain an
+// affected row are recomputed instead.
+type incrementalViewQuery struct {
+	view catalog.TableDescriptor
+	// tables are the tables in the FROM clause of the query, in order.
+	tables []incrementalViewTable
+	// grouped is true if the query aggregates or deduplicates its rows.
+	grouped bool
+	// groupCols are the ordinals of the columns of the view that identify the
+	// group of a row if grouped is true. If it is empty, the query is a scalar
+	// aggregation which always has a single group.
+	groupCols []int
+}
+
+// incrementalViewTable is a table in the FROM clause of the query of a
+// materialized view.
+type incrementalViewTable struct {
+	desc catalog.TableDescriptor
+	// ref is the name the query uses to refer to the table.
+	ref tree.TableName
+}
+
+// viewRowDelta is the change of the number of occurrences of a row in the
+// data of a materialized view.
+type viewRowDelta struct {
+	row   tree.Datums
+	delta int
+}
+
+func incrementalRefreshUnsupportedErr(view catalog.TableDescriptor, reason string) error {
+	return errors.WithHint(
+		pgerror.Newf(pgcode.FeatureNotSupported,
+			"materialized view %q cannot be refreshed incrementally: %s", view.GetName(), reason),
+		"use REFRESH MATERIALIZED VIEW without INCREMENTAL.",
+	)
+}
+
+// parseViewSelectClause parses the query of the view and returns its SELECT
+// clause. A new AST is returned on every call, so it can be modified freely.
+func parseViewSelectClause(view catalog.TableDescriptor) (*tree.SelectClause, error) {
+	stmt, err := parser.ParseOne(view.GetViewQuery())
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	for ok {
+		if sel.With != nil || sel.Limit != nil || len(sel.Locking) > 0 {
+			break
+		}
+		// The order of the rows of a materialized view is not preserved, so
+		// ORDER BY is ignored.
+		switch t := sel.Select.(type) {
+		case *tree.ParenSelect:
+			sel = t.Select
+		case *tree.SelectClause:
+			return t, nil
+		default:
+			ok = false
+		}
+	}
+	return nil, incrementalRefreshUnsupportedErr(view,
+		"its query must be a single SELECT clause without WITH or LIMIT")
+}
+
+// makeIncrementalViewQuery checks that the query of the given materialized
+// view can be refreshed incrementally, and analyzes it.
+func (p *planner) makeIncrementalViewQuery(
+	ctx context.Context, view catalog.TableDescriptor,
+) (*incrementalViewQuery, error) {
+	sel, err := parseViewSelectClause(view)
+	if err != nil {
+		return nil, err
+	}
+	q := &incrementalViewQuery{view: view}
+	switch {
+	case sel.DistinctOn != nil:
+		return nil, incrementalRefreshUnsupportedErr(view, "DISTINCT ON is not supported")
+	case len(sel.Window) > 0:
+		return nil, incrementalRefreshUnsupportedErr(view, "window functions are not supported")
+	case sel.From.AsOf.Expr != nil:
+		return nil, incrementalRefreshUnsupportedErr(view, "AS OF SYSTEM TIME is not supported")
+	case len(sel.From.Tables) == 0:
+		return nil, incrementalRefreshUnsupportedErr(view, "its query must read from a table")
+	}
+
+	// Build the query. This checks that the current user is allowed to run it,
+	// and computes the volatility of its expressions.
+	var optFactory norm.Factory
+	optFactory.Init(ctx, p.EvalContext(), p.optPlanningCtx.catalog)
+	optBld := optbuilder.New(
+		ctx, p.SemaCtx(), p.EvalContext(), p.optPlanningCtx.catalog, &optFactory, &tree.Select{Select: sel},
+	)
+	if err := optBld.Build(); err != nil {
+		return nil, err
+	}
+	vol := optFactory.Memo().RootExpr().(memo.RelExpr).Relational().VolatilitySet
+	if vol.HasStable() || vol.HasVolatile() {
+		return nil, incrementalRefreshUnsupportedErr(view,
+			"its query must only contain immutable expressions")
+	}
+
+	// The query was built from the AST, which may have been annotated in the
+	// process, so continue with a fresh one.
+	if sel, err = parseViewSelectClause(view); err != nil {
+		return nil, err
+	}
+	v := incrementalViewExprChecker{ctx: ctx, p: p}
+	for _, t := range sel.From.Tables {
+		if err := p.addIncrementalViewTables(ctx, q, &v, t); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range sel.Exprs {
+		switch t := e.Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			return nil, incrementalRefreshUnsupportedErr(view, "* is not supported")
+		case *tree.UnresolvedName:
+			if t.Star {
+				return nil, incrementalRefreshUnsupportedErr(view, "* is not supported")
+			}
+		}
+		tree.WalkExprConst(&v, e.Expr)
+	}
+	if sel.Where != nil {
+		tree.WalkExprConst(&v, sel.Where.Expr)
+	}
+	for _, e := range sel.GroupBy {
+		tree.WalkExprConst(&v, e)
+	}
+	if sel.Having != nil {
+		tree.WalkExprConst(&v, sel.Having.Expr)
+	}
+	if v.err != nil {
+		return nil, v.err
+	}
+	if v.unsupported != "" {
+		return nil, incrementalRefreshUnsupportedErr(view, v.unsupported)
+	}
+	if len(sel.Exprs) != len(view.VisibleColumns()) {
+		return nil, errors.AssertionFailedf(
+			"materialized view %q has %d columns, but its query has %d",
+			view.GetName(), len(view.VisibleColumns()), len(sel.Exprs))
+	}
+
+	q.grouped = sel.Distinct || len(sel.GroupBy) > 0 || sel.Having != nil || v.hasAggregate
+	switch {
+	case sel.Distinct && (len(sel.GroupBy) > 0 || sel.Having != nil || v.hasAggregate):
+		return nil, incrementalRefreshUnsupportedErr(view,
+			"DISTINCT cannot be combined with aggregation")
+	case sel.Distinct:
+		for i := range sel.Exprs {
+			q.groupCols = append(q.groupCols, i)
+		}
+	default:
+		for _, g := range sel.GroupBy {
+			ord, ok := findGroupingColumn(sel, g)
+			if !ok {
+				return nil, incrementalRefreshUnsupportedErr(view, errors.Newf(
+					"GROUP BY expression %s must be a column of the view", tree.AsString(g),
+				).Error())
+			}
+			q.groupCols = append(q.groupCols, ord)
+		}
+	}
+	return q, nil
+}
+
+// addIncrementalViewTables resolves the tables in the given table expression
+// from the FROM clause of the query of the view. The join conditions are
+// checked with the given checker.
+func (p *planner) addIncrementalViewTables(
+	ctx context.Context, q *incrementalViewQuery, v *incrementalViewExprChecker, expr tree.TableExpr,
+) error {
+	switch t := expr.(type) {
+	case *tree.ParenTableExpr:
+		return p.addIncrementalViewTables(ctx, q, v, t.Expr)
+
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+			return incrementalRefreshUnsupportedErr(q.view, "only inner joins are supported")
+		}
+		if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+			tree.WalkExprConst(v, on.Expr)
+		}
+		if err := p.addIncrementalViewTables(ctx, q, v, t.Left); err != nil {
+			return err
+		}
+		return p.addIncrementalViewTables(ctx, q, v, t.Right)
+
+	case *tree.AliasedTableExpr:
+		tn, ok := t.Expr.(*tree.TableName)
+		if !ok || t.Ordinality || t.Lateral || len(t.As.Cols) > 0 {
+			break
+		}
+		name := *tn
+		_, desc, err := resolver.ResolveExistingTableObject(ctx, p, &name, tree.ObjectLookupFlags{
+			Required:             true,
+			DesiredObjectKind:    tree.TableObject,
+			DesiredTableDescKind: tree.ResolveRequireTableDesc,
+		})
+		if err != nil {
+			return err
+		}
+		if !desc.IsPhysicalTable() || desc.IsView() || desc.IsSequence() {
+			break
+		}
+		primary := desc.GetPrimaryIndex()
+		for i := 0; i < primary.NumKeyColumns(); i++ {
+			col, err := catalog.MustFindColumnByID(desc, primary.GetKeyColumnID(i))
+			if err != nil {
+				return err
+			}
+			if col.GetType().Family() == types.CollatedStringFamily {
+				return incrementalRefreshUnsupportedErr(q.view, errors.Newf(
+					"the primary key of table %q contains a collated string", desc.GetName(),
+				).Error())
+			}
+		}
+		ref := *tn
+		if t.As.Alias != "" {
+			ref = tree.MakeUnqualifiedTableName(t.As.Alias)
+		}
+		q.tables = append(q.tables, incrementalViewTable{desc: desc, ref: ref})
+		return nil
+	}
+	return incrementalRefreshUnsupportedErr(q.view, "only tables and inner joins of tables are supported")
+}
+
+// findGroupingColumn returns the ordinal of the column of the view that is
+// computed by the given GROUP BY expression.
+func findGroupingColumn(sel *tree.SelectClause, g tree.Expr) (int, bool) {
+	for i := range sel.Exprs {
+		if tree.Serialize(sel.Exprs[i].Expr) == tree.Serialize(g) {
+			return i, true
+		}
+	}
+	switch t := g.(type) {
+	case *tree.NumVal:
+		// GROUP BY refers to the column with the given ordinal.
+		if ord, err := t.AsInt64(); err == nil && ord > 0 && int(ord) <= len(sel.Exprs) {
+			return int(ord) - 1, true
+		}
+	case *tree.UnresolvedName:
+		// GROUP BY refers to the column with the given name.
+		if t.NumParts == 1 {
+			for i := range sel.Exprs {
+				if string(sel.Exprs[i].As) == t.Parts[0] {
+					return i, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// incrementalViewExprChecker finds the expressions in the query of a view which
+// prevent it from being refreshed incrementally, and whether the query
+// aggregates its rows.
+type incrementalViewExprChecker struct {
+	ctx          context.Context
+	p            *planner
+	hasAggregate bool
+	unsupported  string
+	err          error
+}
+
+var _ tree.Visitor = &incrementalViewExprChecker{}
+
+func (v *incrementalViewExprChecker) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.unsupported != "" || v.err != nil {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.unsupported = "subqueries are not supported"
+		return false, expr
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.unsupported = "window functions are not supported"
+			return false, expr
+		}
+		searchPath := v.p.CurrentSearchPath()
+		def, err := t.Func.Resolve(v.ctx, &searchPath, v.p)
+		if err != nil {
+			v.err = err
+			return false, expr
+		}
+		for i := range def.Overloads {
+			if def.Overloads[i].Class == tree.AggregateClass {
+				v.hasAggregate = true
+			}
+		}
+	}
+	return true, expr
+}
+
+func (v *incrementalViewExprChecker) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// checkTableUnchangedSinceRefresh checks that the given table of the view was
+// not altered since the last refresh in a way that changes the result of the
+// query of the view for rows that did not change, or the primary index the
+// changed rows are read from. Other changes to the descriptor, such as the
+// addition of dependent views, are allowed.
+func (p *planner) checkTableUnchangedSinceRefresh(
+	ctx context.Context, view, table catalog.TableDescriptor, lastRefresh hlc.Timestamp,
+) error {
+	if !lastRefresh.Less(table.GetModificationTime()) {
+		return nil
+	}
+	leased, err := p.LeaseMgr().Acquire(ctx, lastRefresh, table.GetID())
+	if err != nil {
+		return err
+	}
+	defer leased.Release(ctx)
+	old, ok := leased.Underlying().(catalog.TableDescriptor)
+	if !ok {
+		return errors.AssertionFailedf("descriptor %d is not a table", table.GetID())
+	}
+	changed := old.GetPrimaryIndexID() != table.GetPrimaryIndexID() ||
+		!old.GetPrivileges().Equal(table.GetPrivileges()) ||
+		old.IsRowLevelSecurityEnabled() != table.IsRowLevelSecurityEnabled() ||
+		old.IsRowLevelSecurityForced() != table.IsRowLevelSecurityForced() ||
+		len(old.GetPolicies()) != len(table.GetPolicies()) ||
+		len(old.PublicColumns()) != len(table.PublicColumns())
+	for i := 0; !changed && i < len(old.GetPolicies()); i++ {
+		changed = !old.GetPolicies()[i].Equal(&table.GetPolicies()[i])
+	}
+	for i := 0; !changed && i < len(old.PublicColumns()); i++ {
+		oldCol, col := old.PublicColumns()[i], table.PublicColumns()[i]
+		changed = oldCol.GetID() != col.GetID() || oldCol.GetName() != col.GetName() ||
+			!oldCol.GetType().Identical(col.GetType())
+	}
+	if changed {
+		return incrementalRefreshUnsupportedErr(view, errors.Newf(
+			"table %q was altered after the last refresh", table.GetName(),
+		).Error())
+	}
+	return nil
+}
+
+// changedRowsSource returns the primary keys of the rows of a table of a
+// materialized view that changed since the last refresh of the view, or an
+// error if more than maxRows rows changed. The memory used by the keys is
+// registered with the given account.
+type changedRowsSource func(
+	ctx context.Context, table catalog.TableDescriptor, maxRows int, acc *mon.BoundAccount,
+) ([]tree.Datums, error)
+
+// primaryKeyDecoder decodes the primary keys of the rows of a table from the
+// prefixes of their KV keys.
+type primaryKeyDecoder struct {
+	codec    keys.SQLCodec
+	prefix   roachpb.Key
+	colTypes []*types.T
+	colDirs  []catenumpb.IndexColumn_Direction
+	alloc    tree.DatumAlloc
+}
+
+func makePrimaryKeyDecoder(
+	codec keys.SQLCodec, table catalog.TableDescriptor,
+) (*primaryKeyDecoder, error) {
+	primary := table.GetPrimaryIndex()
+	d := &primaryKeyDecoder{
+		codec:    codec,
+		prefix:   rowenc.MakeIndexKeyPrefix(codec, table.GetID(), primary.GetID()),
+		colTypes: make([]*types.T, primary.NumKeyColumns()),
+		colDirs:  make([]catenumpb.IndexColumn_Direction, primary.NumKeyColumns()),
+	}
+	for i := range d.colTypes {
+		col, err := catalog.MustFindColumnByID(table, primary.GetKeyColumnID(i))
+		if err != nil {
+			return nil, err
+		}
+		d.colTypes[i] = col.GetType()
+		d.colDirs[i] = primary.GetKeyColumnDirection(i)
+	}
+	return d, nil
+}
+
+// decode returns the primary key of the row with the given key prefix, and the
+// memory it uses.
+func (d *primaryKeyDecoder) decode(rowPrefix roachpb.Key) (tree.Datums, int64, error) {
+	vals := make([]rowenc.EncDatum, len(d.colTypes))
+	if _, err := rowenc.DecodeIndexKey(d.codec, vals, d.colDirs, rowPrefix); err != nil {
+		return nil, 0, err
+	}
+	row := make(tree.Datums, len(vals))
+	size := int64(len(rowPrefix))
+	for i := range vals {
+		if err := vals[i].EnsureDecoded(d.colTypes[i], &d.alloc); err != nil {
+			return nil, 0, err
+		}
+		row[i] = vals[i].Datum
+		size += int64(row[i].Size())
+	}
+	return row, size, nil
+}
+
+// changedPrimaryKeys returns the primary keys of the rows of the table that
+// were written after the start timestamp and up to the end timestamp, by
+// exporting the revisions of the primary index in that interval. Rows that
+// were deleted are included. The memory used by the keys is registered with
+// the given account.
+func (p *planner) changedPrimaryKeys(
+	ctx context.Context,
+	view, table catalog.TableDescriptor,
+	start, end hlc.Timestamp,
+	maxRows int,
+	acc *mon.BoundAccount,
+) ([]tree.Datums, error) {
+	dec, err := makePrimaryKeyDecoder(p.ExecCfg().Codec, table)
+	if err != nil {
+		return nil, err
+	}
+	span := roachpb.Span{Key: dec.prefix, EndKey: dec.prefix.PrefixEnd()}
+	header := kvpb.Header{
+		Timestamp:                   end,
+		ReturnElasticCPUResumeSpans: true,
+	}
+	seen := make(map[string]struct{})
+	var res []tree.Datums
+	for len(span.Key) != 0 {
+		req := &kvpb.ExportRequest{
+			RequestHeader: kvpb.RequestHeader{Key: span.Key, EndKey: span.EndKey},
+			StartTime:     start,
+			MVCCFilter:    kvpb.MVCCFilter_All,
+		}
+		rawResp, pErr := kv.SendWrappedWith(ctx, p.ExecCfg().DB.NonTransactionalSender(), header, req)
+		if pErr != nil {
+			err := pErr.GoError()
+			if errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+				return nil, incrementalRefreshUnsupportedErr(view, errors.Newf(
+					"the changes to table %q since the last refresh were garbage collected",
+					table.GetName(),
+				).Error())
+			}
+			return nil, err
+		}
+		resp := rawResp.(*kvpb.ExportResponse)
+		for _, file := range resp.Files {
+			if err := func() error {
+				it, err := storage.NewMemSSTIterator(file.SST, false, /* verify */
+					storage.IterOptions{
+						KeyTypes:   storage.IterKeyTypePointsAndRanges,
+						LowerBound: keys.MinKey,
+						UpperBound: keys.MaxKey,
+					})
+				if err != nil {
+					return err
+				}
+				defer it.Close()
+				for it.SeekGE(storage.NilKey); ; it.Next() {
+					if ok, err := it.Valid(); err != nil {
+						return err
+					} else if !ok {
+						return nil
+					}
+					if _, hasRange := it.HasPointAndRange(); hasRange {
+						// The keys deleted by an MVCC range deletion cannot be
+						// enumerated.
+						return incrementalRefreshUnsupportedErr(view, errors.Newf(
+							"table %q had a range of rows deleted since the last refresh",
+							table.GetName(),
+						).Error())
+					}
+					key := it.UnsafeKey().Key
+					prefixLen, err := keys.GetRowPrefixLength(key)
+					if err != nil {
+						return err
+					}
+					// The revisions of all column families of a row share the
+					// prefix of the row.
+					rowPrefix := key[:prefixLen]
+					if _, ok := seen[string(rowPrefix)]; ok {
+						continue
+					}
+					if len(seen) >= maxRows {
+						return tooManyChangedRowsErr(view, maxRows)
+					}
+					row, size, err := dec.decode(rowPrefix)
+					if err != nil {
+						return err
+					}
+					if err := acc.Grow(ctx, size); err != nil {
+						return err
+					}
+					seen[string(rowPrefix)] = struct{}{}
+					res = append(res, row)
+				}
+			}(); err != nil {
+				return nil, err
+			}
+		}
+		span = roachpb.Span{}
+		if resp.ResumeSpan != nil {
+			span = *resp.ResumeSpan
+		}
+	}
+	return res, nil
+}
+
+func tooManyChangedRowsErr(view catalog.TableDescriptor, maxRows int) error {
+	return incrementalRefreshUnsupportedErr(view, errors.Newf(
+		"more than %d rows changed since the last refresh", maxRows,
+	).Error())
+}
+
+// affectedRowsFilter returns a filter for the query of the view which only
+// keeps the rows derived from a changed row of one of the tables. changed
+// contains the primary keys of the changed rows of each table of the query.
+func (q *incrementalViewQuery) affectedRowsFilter(changed [][]tree.Datums) tree.Expr {
+	var filter tree.Expr
+	for i := range q.tables {
+		if len(changed[i]) == 0 {
+			continue
+		}
+		t := &q.tables[i]
+		primary := t.desc.GetPrimaryIndex()
+		cols := make(tree.Exprs, primary.NumKeyColumns())
+		for j := range cols {
+			cols[j] = tree.NewColumnItem(&t.ref, tree.Name(primary.GetKeyColumnName(j)))
+		}
+		keys := make(tree.Exprs, len(changed[i]))
+		for j, row := range changed[i] {
+			if len(row) == 1 {
+				keys[j] = row[0]
+				continue
+			}
+			tuple := make(tree.Exprs, len(row))
+			for k := range row {
+				tuple[k] = row[k]
+			}
+			keys[j] = &tree.Tuple{Exprs: tuple}
+		}
+		var left tree.Expr = &tree.Tuple{Exprs: cols}
+		if len(cols) == 1 {
+			left = cols[0]
+		}
+		in := &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.In),
+			Left:     left,
+			Right:    &tree.Tuple{Exprs: keys},
+		}
+		if filter == nil {
+			filter = in
+		} else {
+			filter = &tree.OrExpr{Left: filter, Right: in}
+		}
+	}
+	return filter
+}
+
+// addFilter adds the given filter to the WHERE clause of the query.
+func addFilter(sel *tree.SelectClause, filter tree.Expr) {
+	if sel.Where == nil {
+		sel.Where = tree.NewWhere(tree.AstWhere, filter)
+		return
+	}
+	sel.Where.Expr = &tree.AndExpr{
+		Left:  &tree.ParenExpr{Expr: sel.Where.Expr},
+		Right: &tree.ParenExpr{Expr: filter},
+	}
+}
+
+// queryAffectedRows returns the rows of the query of the view that are derived
+// from the changed rows, either as of the given timestamp, or as of the read
+// timestamp of the current transaction if the timestamp is empty. If the query
+// is grouped, only the distinct values of the grouping columns are returned.
+//
+// Historical reads cannot be performed in the current transaction, so they use
+// a separate one. Both run as the current user.
+func (p *planner) queryAffectedRows(
+	ctx context.Context,
+	q *incrementalViewQuery,
+	changed [][]tree.Datums,
+	asOf hlc.Timestamp,
+	acc *mon.BoundAccount,
+) ([]tree.Datums, error) {
+	sel, err := parseViewSelectClause(q.view)
+	if err != nil {
+		return nil, err
+	}
+	if q.grouped {
+		exprs := make(tree.SelectExprs, len(q.groupCols))
+		for i, ord := range q.groupCols {
+			exprs[i] = tree.SelectExpr{Expr: sel.Exprs[ord].Expr}
+		}
+		sel.Exprs = exprs
+		sel.Distinct = true
+		sel.GroupBy = nil
+		sel.Having = nil
+	}
+	addFilter(sel, q.affectedRowsFilter(changed))
+
+	var rows []tree.Datums
+	if asOf.IsEmpty() {
+		stmt := tree.AsStringWithFlags(&tree.Select{Select: sel}, tree.FmtParsable)
+		rows, err = p.InternalSQLTxn().QueryBufferedEx(
+			ctx, "refresh-materialized-view-incremental", p.txn,
+			sessiondata.NoSessionDataOverride, stmt,
+		)
+	} else {
+		sel.From.AsOf = tree.AsOfClause{Expr: tree.NewStrVal(asOf.AsOfSystemTime())}
+		stmt := tree.AsStringWithFlags(&tree.Select{Select: sel}, tree.FmtParsable)
+		ie := p.ExecCfg().InternalDB.NewInternalExecutor(p.SessionData())
+		rows, err = ie.QueryBufferedEx(
+			ctx, "refresh-materialized-view-incremental", nil, /* txn */
+			sessiondata.NoSessionDataOverride, stmt,
+		)
+		if errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+			return nil, incrementalRefreshUnsupportedErr(q.view,
+				"the rows of the tables of the view as of the last refresh were garbage collected")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		for _, d := range row {
+			if err := acc.Grow(ctx, int64(d.Size())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rows, nil
+}
+
+// refreshIncrementalViewData applies the changes made to the tables of the
+// view between the last refresh and the given read timestamp of the current
+// transaction to the data of the view. The rows of the tables that changed in
+// that interval are read from the given source.
+//
+// The read timestamp of the transaction may be moved forward after the changes
+// were read, so the given timestamp, not the final one, must be recorded as the
+// time of the refresh. Rows of the tables which were written after it and were
+// read by the refresh cause the transaction to restart instead.
+func (p *planner) refreshIncrementalViewData(
+	ctx context.Context,
+	q *incrementalViewQuery,
+	lastRefresh, readTS hlc.Timestamp,
+	changedRows changedRowsSource,
+) error {
+	acc := p.Mon().MakeBoundAccount()
+	defer acc.Close(ctx)
+
+	maxRows := int(incrementalRefreshMaxChangedRows.Get(&p.ExecCfg().Settings.SV))
+	changed := make([][]tree.Datums, len(q.tables))
+	changedByID := make(map[descpb.ID][]tree.Datums)
+	var numChanged int
+	for i := range q.tables {
+		t := &q.tables[i]
+		if err := p.checkTableUnchangedSinceRefresh(ctx, q.view, t.desc, lastRefresh); err != nil {
+			return err
+		}
+		keys, ok := changedByID[t.desc.GetID()]
+		if !ok {
+			var err error
+			keys, err = changedRows(ctx, t.desc, maxRows-numChanged, &acc)
+			if err != nil {
+				return err
+			}
+			changedByID[t.desc.GetID()] = keys
+			numChanged += len(keys)
+		}
+		changed[i] = keys
+	}
+	if numChanged == 0 {
+		return nil
+	}
+
+	return p.applyIncrementalViewChanges(ctx, q, func(apply *incrementalViewChanges) error {
+		if q.grouped {
+			if len(q.groupCols) == 0 {
+				// A scalar aggregation has a single row, which is recomputed.
+				return apply.recomputeGroups(ctx, nil /* groups */, true /* all */)
+			}
+			var groups []tree.Datums
+			seen := make(map[string]struct{})
+			for _, asOf := range []hlc.Timestamp{lastRefresh, {}} {
+				rows, err := p.queryAffectedRows(ctx, q, changed, asOf, &acc)
+				if err != nil {
+					return err
+				}
+				for _, row := range rows {
+					key := tree.AsStringWithFlags(&row, tree.FmtParsable)
+					if _, ok := seen[key]; !ok {
+						seen[key] = struct{}{}
+						groups = append(groups, row)
+					}
+				}
+			}
+			return apply.recomputeGroups(ctx, groups, false /* all */)
+		}
+
+		// Compute the difference between the affected rows now and as of the
+		// last refresh as multisets.
+		var deltas []viewRowDelta
+		idx := make(map[string]int)
+		for _, asOf := range []hlc.Timestamp{lastRefresh, {}} {
+			rows, err := p.queryAffectedRows(ctx, q, changed, asOf, &acc)
+			if err != nil {
+				return err
+			}
+			delta := 1
+			if !asOf.IsEmpty() {
+				delta = -1
+			}
+			for _, row := range rows {
+				key := tree.AsStringWithFlags(&row, tree.FmtParsable)
+				i, ok := idx[key]
+				if !ok {
+					i = len(deltas)
+					idx[key] = i
+					deltas = append(deltas, viewRowDelta{row: row})
+				}
+				deltas[i].delta += delta
+			}
+		}
+		return apply.applyRowDeltas(ctx, deltas)
+	})
+}
+
+// incrementalViewChanges applies changes to the data of a materialized view.
+type incrementalViewChanges struct {
+	p    *planner
+	q    *incrementalViewQuery
+	cols []catalog.Column
+}
+
+// applyIncrementalViewChanges runs the given function, which may apply changes
+// to the data of the view. Materialized views cannot be mutated by users, so
+// the statements that apply the changes are only allowed to do so here.
+func (p *planner) applyIncrementalViewChanges(
+	ctx context.Context, q *incrementalViewQuery, fn func(*incrementalViewChanges) error,
+) error {
+	txn := p.InternalSQLTxn()
+	if txn == nil {
+		return errors.AssertionFailedf("no transaction for incremental refresh")
+	}
+	c := &incrementalViewChanges{p: p, q: q, cols: q.view.VisibleColumns()}
+	return p.internalSQLTxn.withMaterializedViewMutations(func() error {
+		return fn(c)
+	})
+}
+
+func (c *incrementalViewChanges) exec(ctx context.Context, stmt tree.Statement) error {
+	_, err := c.p.InternalSQLTxn().ExecEx(
+		ctx, "refresh-materialized-view-incremental", c.p.txn,
+		sessiondata.NoSessionDataOverride, tree.AsStringWithFlags(stmt, tree.FmtParsable),
+	)
+	return err
+}
+
+func (c *incrementalViewChanges) viewRef() *tree.TableRef {
+	return &tree.TableRef{TableID: int64(c.q.view.GetID()), As: tree.AliasClause{Alias: "v"}}
+}
+
+func (c *incrementalViewChanges) colNames() tree.NameList {
+	names := make(tree.NameList, len(c.cols))
+	for i, col := range c.cols {
+		names[i] = tree.Name(col.GetName())
+	}
+	return names
+}
+
+// matchRow returns a filter that matches the rows of the view whose columns
+// with the given ordinals have the given values. If exact is set, values that
+// are equal but distinguishable, such as 1.0 and 1.00, do not match.
+func (c *incrementalViewChanges) matchRow(ords []int, vals tree.Datums, exact bool) tree.Expr {
+	var filter tree.Expr
+	for i, ord := range ords {
+		col := c.cols[ord]
+		colRef := tree.NewColumnItem(nil /* tn */, tree.Name(col.GetName()))
+		var e tree.Expr
+		if vals[i] == tree.DNull {
+			e = &tree.IsNullExpr{Expr: colRef}
+		} else {
+			e = &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.EQ),
+				Left:     colRef,
+				Right:    vals[i],
+			}
+			if exact && colinfo.CanHaveCompositeKeyEncoding(col.GetType()) {
+				e = &tree.AndExpr{Left: e, Right: &tree.ComparisonExpr{
+					Operator: treecmp.MakeComparisonOperator(treecmp.EQ),
+					Left:     &tree.CastExpr{Expr: colRef, Type: types.String, SyntaxMode: tree.CastShort},
+					Right:    &tree.CastExpr{Expr: vals[i], Type: types.String, SyntaxMode: tree.CastShort},
+				}}
+			}
+		}
+		if filter == nil {
+			filter = e
+		} else {
+			filter = &tree.AndExpr{Left: filter, Right: e}
+		}
+	}
+	if filter == nil {
+		return tree.DBoolTrue
+	}
+	return filter
+}
+
+// recomputeGroups deletes the rows of the given groups from the view, and
+// inserts them again as computed by the query of the view. If all is set, all
+// rows of the view are recomputed instead.
+func (c *incrementalViewChanges) recomputeGroups(
+	ctx context.Context, groups []tree.Datums, all bool,
+) error {
+	for len(groups) > 0 || all {
+		batch := groups
+		if len(batch) > incrementalRefreshBatchSize {
+			batch = batch[:incrementalRefreshBatchSize]
+		}
+		groups = groups[len(batch):]
+
+		var viewFilter tree.Expr = tree.DBoolTrue
+		sel, err := parseViewSelectClause(c.q.view)
+		if err != nil {
+			return err
+		}
+		if !all {
+			var queryFilter tree.Expr
+			viewFilter = nil
+			for _, g := range batch {
+				m := c.matchRow(c.q.groupCols, g, false /* exact */)
+				qm := matchGroup(sel, c.q.groupCols, g)
+				if viewFilter == nil {
+					viewFilter, queryFilter = m, qm
+				} else {
+					viewFilter = &tree.OrExpr{Left: viewFilter, Right: m}
+					queryFilter = &tree.OrExpr{Left: queryFilter, Right: qm}
+				}
+			}
+			addFilter(sel, queryFilter)
+		}
+		if err := c.exec(ctx, &tree.Delete{
+			Table:     c.viewRef(),
+			Where:     tree.NewWhere(tree.AstWhere, viewFilter),
+			Returning: tree.AbsentReturningClause,
+		}); err != nil {
+			return err
+		}
+		if err := c.exec(ctx, &tree.Insert{
+			Table:     c.viewRef(),
+			Columns:   c.colNames(),
+			Rows:      &tree.Select{Select: sel},
+			Returning: tree.AbsentReturningClause,
+		}); err != nil {
+			return err
+		}
+		all = false
+	}
+	return nil
+}
+
+// matchGroup returns a filter for the query of the view that only keeps the
+// rows of the given group.
+func matchGroup(sel *tree.SelectClause, groupCols []int, group tree.Datums) tree.Expr {
+	var filter tree.Expr
+	for i, ord := range groupCols {
+		expr := &tree.ParenExpr{Expr: sel.Exprs[ord].Expr}
+		var e tree.Expr
+		if group[i] == tree.DNull {
+			e = &tree.IsNullExpr{Expr: expr}
+		} else {
+			e = &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.EQ),
+				Left:     expr,
+				Right:    group[i],
+			}
+		}
+		if filter == nil {
+			filter = e
+		} else {
+			filter = &tree.AndExpr{Left: filter, Right: e}
+		}
+	}
+	return &tree.ParenExpr{Expr: filter}
+}
+
+// applyRowDeltas deletes and inserts rows of the view according to the change
+// of their number of occurrences.
+func (c *incrementalViewChanges) applyRowDeltas(ctx context.Context, deltas []viewRowDelta) error {
+	allOrds := make([]int, len(c.cols))
+	for i := range allOrds {
+		allOrds[i] = i
+	}
+	var inserts []tree.Exprs
+	flushInserts := func() error {
+		if len(inserts) == 0 {
+			return nil
+		}
+		err := c.exec(ctx, &tree.Insert{
+			Table:     c.viewRef(),
+			Columns:   c.colNames(),
+			Rows:      &tree.Select{Select: &tree.ValuesClause{Rows: inserts}},
+			Returning: tree.AbsentReturningClause,
+		})
+		inserts = inserts[:0]
+		return err
+	}
+	for _, d := range deltas {
+		switch {
+		case d.delta < 0:
+			// The rows of a materialized view are only identified by a hidden
+			// column, so delete any of the rows with the same values.
+			if err := c.exec(ctx, &tree.Delete{
+				Table:     c.viewRef(),
+				Where:     tree.NewWhere(tree.AstWhere, c.matchRow(allOrds, d.row, true /* exact */)),
+				Limit:     &tree.Limit{Count: tree.NewDInt(tree.DInt(-d.delta))},
+				Returning: tree.AbsentReturningClause,
+			}); err != nil {
+				return err
+			}
+		case d.delta > 0:
+			for i := 0; i < d.delta; i++ {
+				row := make(tree.Exprs, len(d.row))
+				for j := range d.row {
+					row[j] = d.row[j]
+				}
+				inserts = append(inserts, row)
+				if len(inserts) >= incrementalRefreshBatchSize {
+					if err := flushInserts(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return flushInserts()
+}
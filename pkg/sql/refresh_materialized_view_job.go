@@ -0,0 +1,544 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// maybeStartMaterializedViewRefreshJob creates the job that refreshes the given
+// materialized view in the background if the view has a refresh_max_staleness
+// and no such job yet. If the view has no refresh_max_staleness, its job is
+// detached from it, which makes the job stop.
+func (p *planner) maybeStartMaterializedViewRefreshJob(
+	ctx context.Context, desc *tabledesc.Mutable,
+) error {
+	if desc.RefreshMaxStaleness == 0 {
+		desc.RefreshJobID = catpb.InvalidJobID
+		return nil
+	}
+	if desc.RefreshJobID != catpb.InvalidJobID {
+		return nil
+	}
+	registry := p.ExecCfg().JobRegistry
+	record := jobs.Record{
+		JobID:       registry.MakeJobID(),
+		Description: fmt.Sprintf("refresh materialized view %s in the background", desc.GetName()),
+		Username:    p.User(),
+		Details:     jobspb.MaterializedViewRefreshDetails{ViewID: desc.GetID()},
+		Progress:    jobspb.MaterializedViewRefreshProgress{},
+	}
+	if _, err := registry.CreateAdoptableJobWithTxn(ctx, record, record.JobID, p.InternalSQLTxn()); err != nil {
+		return err
+	}
+	desc.RefreshJobID = catpb.JobID(record.JobID)
+	return nil
+}
+
+// materializedViewRefreshResumer implements the job that keeps a materialized
+// view with a refresh_max_staleness refreshed. Once the data of the view is
+// older than the staleness, the job refreshes it incrementally, with the
+// changes to the tables of the view collected by a rangefeed since the last
+// refresh. If an incremental refresh is not possible, the error is reported as
+// the status of the job, and the refresh is retried after the staleness again,
+// so the job resumes once the view is refreshed in full.
+type materializedViewRefreshResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*materializedViewRefreshResumer)(nil)
+
+// Resume is part of the jobs.Resumer interface.
+func (r *materializedViewRefreshResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	viewID := r.job.Details().(jobspb.MaterializedViewRefreshDetails).ViewID
+
+	var feed *viewChangeFeed
+	defer func() {
+		if feed != nil {
+			feed.close()
+		}
+	}()
+	var t timeutil.Timer
+	defer t.Stop()
+	for {
+		var view catalog.TableDescriptor
+		var tables []catalog.TableDescriptor
+		if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+			view, tables, err = loadMaterializedViewTables(ctx, txn, viewID)
+			return err
+		}); err != nil {
+			return err
+		}
+		if view == nil || view.TableDesc().RefreshJobID != catpb.JobID(r.job.ID()) {
+			// The view was dropped, or its refresh_max_staleness was reset.
+			return nil
+		}
+
+		wait := view.TableDesc().RefreshMaxStaleness
+		lastRefresh := view.TableDesc().LastRefreshTime
+		// The view has no data until it is refreshed in full.
+		if view.Public() && !view.IsRefreshViewRequired() && !lastRefresh.IsEmpty() {
+			if feed != nil && (lastRefresh.Less(feed.start) || !feed.watches(execCfg.Codec, tables)) {
+				feed.close()
+				feed = nil
+			}
+			if feed == nil {
+				var err error
+				if feed, err = startViewChangeFeed(ctx, execCfg, view, tables); err != nil {
+					return err
+				}
+			}
+			feed.prune(lastRefresh)
+
+			wait = lastRefresh.GoTime().Add(view.TableDesc().RefreshMaxStaleness).Sub(timeutil.Now())
+			if wait <= 0 {
+				err := r.refresh(ctx, execCfg, viewID, feed)
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				status := jobs.RunningStatus("")
+				if err != nil {
+					log.Warningf(ctx, "failed to refresh materialized view %q: %v", view.GetName(), err)
+					status = jobs.RunningStatus(err.Error())
+					// Collect the changes from the last refresh again.
+					feed.close()
+					feed = nil
+					wait = view.TableDesc().RefreshMaxStaleness
+				}
+				if err := r.job.NoTxn().RunningStatus(ctx, status); err != nil {
+					log.Warningf(ctx, "failed to update the status of job %d: %v", r.job.ID(), err)
+				}
+				if err == nil {
+					continue
+				}
+			}
+		}
+
+		t.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			t.Read = true
+		}
+	}
+}
+
+// loadMaterializedViewTables returns the materialized view with the given ID
+// and the tables it depends on, or nil if the view was dropped.
+func loadMaterializedViewTables(
+	ctx context.Context, txn descs.Txn, viewID descpb.ID,
+) (catalog.TableDescriptor, []catalog.TableDescriptor, error) {
+	view, err := txn.Descriptors().ByID(txn.KV()).Get().Table(ctx, viewID)
+	if errors.Is(err, catalog.ErrDescriptorNotFound) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	if view.Dropped() {
+		return nil, nil, nil
+	}
+	var tables []catalog.TableDescriptor
+	for _, id := range view.GetDependsOn() {
+		table, err := txn.Descriptors().ByID(txn.KV()).Get().Table(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Only the rows of physical tables are refreshed incrementally.
+		if table.IsPhysicalTable() && !table.IsSequence() && !table.MaterializedView() {
+			tables = append(tables, table)
+		}
+	}
+	return view, tables, nil
+}
+
+// refresh refreshes the view incrementally with the changes collected by the
+// feed, as the owner of the view.
+func (r *materializedViewRefreshResumer) refresh(
+	ctx context.Context, execCfg *ExecutorConfig, viewID descpb.ID, feed *viewChangeFeed,
+) error {
+	return execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		view, err := txn.Descriptors().MutableByID(txn.KV()).Table(ctx, viewID)
+		if err != nil {
+			return err
+		}
+		if view.RefreshJobID != catpb.JobID(r.job.ID()) || view.LastRefreshTime.Less(feed.start) {
+			// The view changed since it was loaded, which the next iteration of
+			// the job handles.
+			return nil
+		}
+		p, cleanup := NewInternalPlanner(
+			"refresh-materialized-view",
+			txn.KV(),
+			view.GetPrivileges().Owner(),
+			&MemoryMetrics{},
+			execCfg,
+			txn.SessionData(),
+			WithDescCollection(txn.Descriptors()),
+		)
+		defer cleanup()
+		return p.(*planner).refreshViewFromChangeFeed(ctx, view, feed)
+	})
+}
+
+// refreshViewFromChangeFeed refreshes the view incrementally in the current
+// transaction, like REFRESH MATERIALIZED VIEW ... INCREMENTAL, with the rows
+// that changed since the last refresh collected by the given feed.
+func (p *planner) refreshViewFromChangeFeed(
+	ctx context.Context, view *tabledesc.Mutable, feed *viewChangeFeed,
+) error {
+	q, err := p.makeIncrementalViewQuery(ctx, view)
+	if err != nil {
+		return err
+	}
+	readTS := p.txn.ReadTimestamp()
+	if err := feed.waitForFrontier(ctx, readTS); err != nil {
+		return err
+	}
+	if err := p.refreshIncrementalViewData(ctx, q, view.LastRefreshTime, readTS, feed.changedPrimaryKeys); err != nil {
+		return err
+	}
+	view.LastRefreshTime = readTS
+	return p.Descriptors().WriteDesc(ctx, false /* kvTrace */, view, p.txn)
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. It detaches the job
+// from the view, so that setting refresh_max_staleness again starts a new job.
+func (r *materializedViewRefreshResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	viewID := r.job.Details().(jobspb.MaterializedViewRefreshDetails).ViewID
+	return execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		view, err := txn.Descriptors().MutableByID(txn.KV()).Table(ctx, viewID)
+		if errors.Is(err, catalog.ErrDescriptorNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if view.Dropped() || view.RefreshJobID != catpb.JobID(r.job.ID()) {
+			return nil
+		}
+		view.RefreshJobID = catpb.InvalidJobID
+		return txn.Descriptors().WriteDesc(ctx, false /* kvTrace */, view, txn.KV())
+	})
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *materializedViewRefreshResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+// viewChangeFeed is the source of the changed rows of the tables of a
+// materialized view that is refreshed in the background. It runs a rangefeed
+// over the primary indexes of the tables from the last refresh of the view,
+// and collects the primary keys of the rows written since then. Unlike
+// planner.changedPrimaryKeys, a refresh does not need to read the history of
+// the tables again.
+type viewChangeFeed struct {
+	view  catalog.TableDescriptor
+	codec keys.SQLCodec
+	sv    *cluster.Settings
+	// start is the timestamp after which the changes are collected.
+	start hlc.Timestamp
+	spans []roachpb.Span
+	names map[descpb.ID]string
+	rf    *rangefeed.RangeFeed
+
+	mu struct {
+		syncutil.Mutex
+		// rows contains the key prefixes of the changed rows of each table,
+		// with the timestamp of their latest change.
+		rows    map[descpb.ID]map[string]hlc.Timestamp
+		numRows int
+		// frontier is the timestamp up to which all the changes were collected.
+		frontier hlc.Timestamp
+		// advanced is closed when the frontier advances or err is set.
+		advanced chan struct{}
+		// err is set if the changes can no longer be collected.
+		err error
+	}
+}
+
+func viewChangeFeedSpans(codec keys.SQLCodec, tables []catalog.TableDescriptor) []roachpb.Span {
+	spans := make([]roachpb.Span, len(tables))
+	for i, t := range tables {
+		spans[i] = t.PrimaryIndexSpan(codec)
+	}
+	return spans
+}
+
+// startViewChangeFeed starts collecting the changes to the given tables of the
+// view since its last refresh.
+func startViewChangeFeed(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	view catalog.TableDescriptor,
+	tables []catalog.TableDescriptor,
+) (*viewChangeFeed, error) {
+	f := &viewChangeFeed{
+		view:  view,
+		codec: execCfg.Codec,
+		sv:    execCfg.Settings,
+		start: view.TableDesc().LastRefreshTime,
+		spans: viewChangeFeedSpans(execCfg.Codec, tables),
+		names: make(map[descpb.ID]string, len(tables)),
+	}
+	for _, t := range tables {
+		f.names[t.GetID()] = t.GetName()
+	}
+	f.mu.rows = make(map[descpb.ID]map[string]hlc.Timestamp)
+	f.mu.advanced = make(chan struct{})
+	if len(f.spans) == 0 {
+		f.mu.frontier = hlc.MaxTimestamp
+		return f, nil
+	}
+	rf, err := execCfg.RangeFeedFactory.RangeFeed(ctx, "materialized-view-refresh", f.spans, f.start,
+		f.onValue,
+		rangefeed.WithOnFrontierAdvance(f.onFrontierAdvance),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			if errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+				err = incrementalRefreshUnsupportedErr(view,
+					"the changes to its tables since the last refresh were garbage collected")
+			}
+			f.setErr(err)
+		}),
+		rangefeed.WithOnSSTable(func(ctx context.Context, sst *kvpb.RangeFeedSSTable, _ roachpb.Span) {
+			f.setErr(f.unsupportedSpanErr(sst.Span, "had rows ingested"))
+		}),
+		rangefeed.WithOnDeleteRange(func(ctx context.Context, dr *kvpb.RangeFeedDeleteRange) {
+			f.setErr(f.unsupportedSpanErr(dr.Span, "had a range of rows deleted"))
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	f.rf = rf
+	return f, nil
+}
+
+// watches returns whether the feed collects the changes to the primary
+// indexes of the given tables.
+func (f *viewChangeFeed) watches(codec keys.SQLCodec, tables []catalog.TableDescriptor) bool {
+	spans := viewChangeFeedSpans(codec, tables)
+	if len(spans) != len(f.spans) {
+		return false
+	}
+	for i := range spans {
+		if !spans[i].Equal(f.spans[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *viewChangeFeed) close() {
+	if f.rf != nil {
+		f.rf.Close()
+	}
+}
+
+func (f *viewChangeFeed) unsupportedSpanErr(span roachpb.Span, what string) error {
+	name := "of the view"
+	if _, id, err := f.codec.DecodeTablePrefix(span.Key); err == nil {
+		if n, ok := f.names[descpb.ID(id)]; ok {
+			name = fmt.Sprintf("%q", n)
+		}
+	}
+	return incrementalRefreshUnsupportedErr(f.view, fmt.Sprintf(
+		"table %s %s since the last refresh", name, what))
+}
+
+func (f *viewChangeFeed) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setErrLocked(err)
+}
+
+func (f *viewChangeFeed) setErrLocked(err error) {
+	if f.mu.err == nil {
+		f.mu.err = err
+		close(f.mu.advanced)
+	}
+}
+
+func (f *viewChangeFeed) onValue(ctx context.Context, value *kvpb.RangeFeedValue) {
+	_, tableID, err := f.codec.DecodeTablePrefix(value.Key)
+	var prefixLen int
+	if err == nil {
+		prefixLen, err = keys.GetRowPrefixLength(value.Key)
+	}
+	maxRows := int(incrementalRefreshMaxChangedRows.Get(&f.sv.SV))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mu.err != nil {
+		return
+	}
+	if err != nil {
+		f.setErrLocked(err)
+		return
+	}
+	rows, ok := f.mu.rows[descpb.ID(tableID)]
+	if !ok {
+		rows = make(map[string]hlc.Timestamp)
+		f.mu.rows[descpb.ID(tableID)] = rows
+	}
+	// The revisions of all column families of a row share the prefix of the
+	// row.
+	rowPrefix := string(value.Key[:prefixLen])
+	ts, ok := rows[rowPrefix]
+	if !ok {
+		if f.mu.numRows >= maxRows {
+			f.setErrLocked(tooManyChangedRowsErr(f.view, maxRows))
+			return
+		}
+		f.mu.numRows++
+	}
+	if ts.Less(value.Value.Timestamp) {
+		rows[rowPrefix] = value.Value.Timestamp
+	}
+}
+
+func (f *viewChangeFeed) onFrontierAdvance(ctx context.Context, frontier hlc.Timestamp) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mu.err != nil || !f.mu.frontier.Less(frontier) {
+		return
+	}
+	f.mu.frontier = frontier
+	close(f.mu.advanced)
+	f.mu.advanced = make(chan struct{})
+}
+
+// viewChangeFeedFrontierTimeout bounds how long a refresh waits for the
+// rangefeed of the view to catch up with its read timestamp, which it never
+// does if rangefeeds are disabled.
+const viewChangeFeedFrontierTimeout = time.Minute
+
+// waitForFrontier waits until all the changes up to the given timestamp were
+// collected.
+func (f *viewChangeFeed) waitForFrontier(ctx context.Context, ts hlc.Timestamp) error {
+	var t timeutil.Timer
+	defer t.Stop()
+	t.Reset(viewChangeFeedFrontierTimeout)
+	for {
+		f.mu.Lock()
+		frontier, advanced, err := f.mu.frontier, f.mu.advanced, f.mu.err
+		f.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if ts.LessEq(frontier) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			t.Read = true
+			return errors.Newf(
+				"timed out waiting for the changes to the tables of materialized view %q; "+
+					"rangefeeds must be enabled with the kv.rangefeed.enabled cluster setting",
+				f.view.GetName(),
+			)
+		case <-advanced:
+		}
+	}
+}
+
+// prune forgets the rows whose latest change was applied by the refresh at the
+// given timestamp. Rows that also changed after it are kept, so they are
+// refreshed again by the next refresh. This is harmless for the rows that did
+// not change in between.
+func (f *viewChangeFeed) prune(lastRefresh hlc.Timestamp) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, rows := range f.mu.rows {
+		for rowPrefix, ts := range rows {
+			if ts.LessEq(lastRefresh) {
+				delete(rows, rowPrefix)
+				f.mu.numRows--
+			}
+		}
+	}
+}
+
+// changedPrimaryKeys is a changedRowsSource which returns the primary keys of
+// the rows of the table that the feed saw change.
+func (f *viewChangeFeed) changedPrimaryKeys(
+	ctx context.Context, table catalog.TableDescriptor, maxRows int, acc *mon.BoundAccount,
+) ([]tree.Datums, error) {
+	dec, err := makePrimaryKeyDecoder(f.codec, table)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mu.err != nil {
+		return nil, f.mu.err
+	}
+	rows := f.mu.rows[table.GetID()]
+	if len(rows) > maxRows {
+		return nil, tooManyChangedRowsErr(f.view, maxRows)
+	}
+	res := make([]tree.Datums, 0, len(rows))
+	for rowPrefix := range rows {
+		if !bytes.HasPrefix([]byte(rowPrefix), dec.prefix) {
+			continue
+		}
+		row, size, err := dec.decode(roachpb.Key(rowPrefix))
+		if err != nil {
+			return nil, err
+		}
+		if err := acc.Grow(ctx, size); err != nil {
+			return nil, err
+		}
+		res = append(res, row)
+	}
+	return res, nil
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeMaterializedViewRefresh,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &materializedViewRefreshResumer{
+				job: job,
+			}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
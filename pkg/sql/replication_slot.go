@@ -0,0 +1,355 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// maxReplicationSlotNameLength is the maximum length of a replication slot
+// name, which matches NAMEDATALEN-1 in Postgres.
+const maxReplicationSlotNameLength = 63
+
+// replicationSlotPTSMetaType is the meta type of the protected timestamp
+// records of replication slots. The meta of a record is the name of its slot.
+const replicationSlotPTSMetaType = "replication_slot"
+
+// replicationSlotPTSNamespace is the namespace of the IDs of the protected
+// timestamp records of replication slots.
+var replicationSlotPTSNamespace = uuid.Must(uuid.FromString("2f44c9bf-4396-4fc5-8509-cefbe70e6508"))
+
+// replicationSlotPTSRecordID returns the ID of the protected timestamp record
+// of a replication slot. The ID is derived from the name of the slot, so it
+// does not need to be stored with the slot.
+func replicationSlotPTSRecordID(name string) uuid.UUID {
+	return uuid.NewV5(replicationSlotPTSNamespace, name)
+}
+
+// validateReplicationSlotName checks that the name only uses the characters
+// Postgres allows in replication slot names.
+func validateReplicationSlotName(name string) error {
+	if name == "" {
+		return pgerror.Newf(pgcode.InvalidName, "replication slot name %q is too short", name)
+	}
+	if len(name) > maxReplicationSlotNameLength {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName,
+					"replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// replicationOptionValue returns the value of a replication command option as
+// a string, or the empty string if the option has no value.
+func replicationOptionValue(o pgrepltree.Option) string {
+	switch v := o.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return v.RawString()
+	default:
+		return tree.AsStringWithFlags(v, tree.FmtBareStrings)
+	}
+}
+
+// replicationOptionBool returns the value of a boolean replication command
+// option. An option without a value is true.
+func replicationOptionBool(o pgrepltree.Option) (bool, error) {
+	v := replicationOptionValue(o)
+	if v == "" {
+		return true, nil
+	}
+	b, err := tree.ParseBool(v)
+	if err != nil {
+		return false, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s requires a Boolean value", o.Key)
+	}
+	return b, nil
+}
+
+// checkLogicalReplicationConnection returns an error if the session is not a
+// logical replication connection to a database, or if the cluster has not
+// been upgraded to the version which stores replication slots.
+func (p *planner) checkLogicalReplicationConnection(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemReplicationSlotsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"replication slots not supported until version 24.1")
+	}
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a database connection")
+	}
+	if p.CurrentDatabase() == "" {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a current database")
+	}
+	return nil
+}
+
+// replicationSlot is a logical replication slot, stored in
+// system.replication_slots. A logical replication slot belongs to the
+// database of the connection which created it, but its name is unique across
+// the cluster like in Postgres. The changes of the tables of the database
+// after the confirmed position of the slot are protected from garbage
+// collection by a protected timestamp record, which is advanced when the
+// consumer confirms a new position and released when the slot is dropped.
+type replicationSlot struct {
+	name   string
+	dbID   descpb.ID
+	plugin string
+	// confirmedFlushLSN is the LSN up to which the consumer of the slot has
+	// confirmed receiving the changes. Changes are sent from this position
+	// when replication is started from the slot.
+	confirmedFlushLSN lsn.LSN
+}
+
+func makeReplicationSlot(row tree.Datums) replicationSlot {
+	return replicationSlot{
+		name:              string(tree.MustBeDString(row[0])),
+		dbID:              descpb.ID(tree.MustBeDInt(row[1])),
+		plugin:            string(tree.MustBeDString(row[2])),
+		confirmedFlushLSN: lsn.LSN(tree.MustBeDInt(row[3])),
+	}
+}
+
+// getReplicationSlot reads the replication slot with the given name.
+func getReplicationSlot(
+	ctx context.Context, txn isql.Txn, name string,
+) (_ replicationSlot, found bool, _ error) {
+	row, err := txn.QueryRowEx(ctx, "get-replication-slot", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT slot_name, database_id, plugin, confirmed_flush_lsn
+FROM system.replication_slots WHERE slot_name = $1`, name,
+	)
+	if err != nil || row == nil {
+		return replicationSlot{}, false, err
+	}
+	return makeReplicationSlot(row), true, nil
+}
+
+// getReplicationSlots reads all the replication slots.
+func getReplicationSlots(ctx context.Context, txn isql.Txn) ([]replicationSlot, error) {
+	rows, err := txn.QueryBufferedEx(ctx, "get-replication-slots", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT slot_name, database_id, plugin, confirmed_flush_lsn
+FROM system.replication_slots ORDER BY slot_name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]replicationSlot, len(rows))
+	for i, row := range rows {
+		slots[i] = makeReplicationSlot(row)
+	}
+	return slots, nil
+}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	n    *pgrepltree.CreateReplicationSlot
+	slot replicationSlot
+	// exportSnapshot is set if the snapshot of the slot is exported.
+	exportSnapshot bool
+	shown          bool
+}
+
+// CreateReplicationSlot creates a logical replication slot in the current
+// database. Changes are streamed from the slot starting at the timestamp of
+// its creation.
+//
+// Snapshots are exported as the timestamp of the consistent point of the
+// slot, at which the initial contents of the tables can be read using AS OF
+// SYSTEM TIME. The snapshot remains readable until the consumer of the slot
+// confirms a later position or the slot is dropped.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.NewWithIssue(105130, "physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.NewWithIssue(105130, "temporary replication slots are not supported")
+	}
+	if err := p.checkLogicalReplicationConnection(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	if n.Plugin != pgoutput.PluginName {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"logical decoding output plugin %q is not supported", n.Plugin)
+	}
+	exportSnapshot := true
+	for _, o := range n.Options {
+		switch key := strings.ToLower(string(o.Key)); key {
+		case "snapshot":
+			switch v := strings.ToLower(replicationOptionValue(o)); v {
+			case "export":
+				exportSnapshot = true
+			case "nothing", "use":
+				exportSnapshot = false
+			default:
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"unrecognized value for CREATE_REPLICATION_SLOT option %q: %q", key, v)
+			}
+		case "reserve_wal":
+			if _, err := replicationOptionBool(o); err != nil {
+				return nil, err
+			}
+		case "two_phase":
+			twoPhase, err := replicationOptionBool(o)
+			if err != nil {
+				return nil, err
+			}
+			if twoPhase {
+				return nil, unimplemented.NewWithIssue(105130,
+					"two-phase decoding is not supported")
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unrecognized CREATE_REPLICATION_SLOT option %q", key)
+		}
+	}
+
+	dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	return &createReplicationSlotNode{
+		n: n,
+		slot: replicationSlot{
+			name:   string(n.Slot),
+			dbID:   dbDesc.GetID(),
+			plugin: string(n.Plugin),
+			// Only changes committed after the slot is created are streamed.
+			confirmedFlushLSN: lsnutil.HLCToLSN(p.Txn().ReadTimestamp()),
+		},
+		exportSnapshot: exportSnapshot,
+	}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createReplicationSlotNode) ReadingOwnWrites() {}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	rowsAffected, err := params.p.InternalSQLTxn().ExecEx(
+		params.ctx, "create-replication-slot", params.p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots (slot_name, database_id, plugin, confirmed_flush_lsn)
+VALUES ($1, $2, $3, $4) ON CONFLICT (slot_name) DO NOTHING`,
+		n.slot.name, n.slot.dbID, n.slot.plugin, int64(n.slot.confirmedFlushLSN),
+	)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"replication slot %q already exists", n.slot.name)
+	}
+	id := replicationSlotPTSRecordID(n.slot.name)
+	pts := params.ExecCfg().ProtectedTimestampProvider.WithTxn(params.p.InternalSQLTxn())
+	return pts.Protect(params.ctx, &ptpb.Record{
+		ID:        id.GetBytesMut(),
+		Timestamp: lsnutil.LSNToHLC(n.slot.confirmedFlushLSN),
+		Mode:      ptpb.PROTECT_AFTER,
+		MetaType:  replicationSlotPTSMetaType,
+		Meta:      []byte(n.slot.name),
+		Target:    ptpb.MakeSchemaObjectsTarget(descpb.IDs{n.slot.dbID}),
+	})
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	snapshotName := tree.DNull
+	if n.exportSnapshot {
+		snapshotName = tree.NewDString(lsnutil.LSNToHLC(n.slot.confirmedFlushLSN).AsOfSystemTime())
+	}
+	return tree.Datums{
+		tree.NewDString(n.slot.name),
+		tree.NewDString(n.slot.confirmedFlushLSN.String()),
+		snapshotName,
+		tree.NewDString(n.slot.plugin),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+type dropReplicationSlotNode struct {
+	n *pgrepltree.DropReplicationSlot
+}
+
+// DropReplicationSlot drops a logical replication slot.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := p.checkLogicalReplicationConnection(ctx); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropReplicationSlotNode) ReadingOwnWrites() {}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	rowsAffected, err := params.p.InternalSQLTxn().ExecEx(
+		params.ctx, "drop-replication-slot", params.p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1`,
+		string(n.n.Slot),
+	)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", n.n.Slot)
+	}
+	pts := params.ExecCfg().ProtectedTimestampProvider.WithTxn(params.p.InternalSQLTxn())
+	return pts.Release(params.ctx, replicationSlotPTSRecordID(string(n.n.Slot)))
+}
+
+func (n *dropReplicationSlotNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropReplicationSlotNode) Close(context.Context)        {}
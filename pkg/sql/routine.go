@@ -15,15 +15,24 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/execbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
@@ -222,6 +231,11 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the eval.ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
+	if g.expr.CalledAfterStmt != nil {
+		// This continuation executes the statements that follow a PL/pgSQL
+		// statement, so the execution of that statement is finished.
+		g.p.finishPLpgSQLStmt(g.expr.CalledAfterStmt)
+	}
 	for {
 		err = g.startInternal(ctx, txn)
 		if err != nil || g.deferredRoutine.expr == nil {
@@ -282,6 +296,7 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 	ef := newExecFactory(ctx, g.p)
 	rrw := NewRowResultWriter(&g.rch)
 	var cursorHelper *plpgsqlCursorHelper
+	var dynQuery *dynamicQueryResultWriter
 	err = g.expr.ForEachPlan(ctx, ef, g.args, func(plan tree.RoutinePlan, stmtForDistSQLDiagram string, isFinalPlan bool) error {
 		stmtIdx++
 		opName := "udf-stmt-" + g.expr.Name + "-" + strconv.Itoa(stmtIdx)
@@ -293,9 +308,14 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 		if isFinalPlan {
 			// The result of this statement is the routine's output.
 			w = rrw
+		} else if openCursor && g.expr.CursorDeclaration.Dynamic {
+			// The first statement produces the query string and arguments for an
+			// OPEN ... FOR EXECUTE statement.
+			dynQuery = &dynamicQueryResultWriter{}
+			w = dynQuery
 		} else if openCursor {
 			// The result of the first statement will be used to open a SQL cursor.
-			cursorHelper, err = g.newCursorHelper(plan.(*planComponents))
+			cursorHelper, err = g.newCursorHelper(plan.(*planComponents), g.expr.CursorDeclaration.CursorSQL)
 			if err != nil {
 				return err
 			}
@@ -316,11 +336,26 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 			}
 		}
 
-		// Run the plan.
+		// Run the plan. If the plan was built from a single PL/pgSQL statement,
+		// collect execution statistics for that statement.
+		stmtInfo := g.plpgsqlStmtInfo(stmtIdx)
+		if stmtInfo != nil {
+			g.p.startPLpgSQLStmtTimer(stmtInfo)
+		}
 		err = runPlanInsidePlan(ctx, g.p.RunParams(ctx), plan.(*planComponents), w, g, stmtForDistSQLDiagram)
+		if stmtInfo != nil {
+			g.p.stopPLpgSQLStmtTimer(err)
+		}
 		if err != nil {
 			return err
 		}
+		if dynQuery != nil {
+			cursorHelper, err = g.openDynamicCursor(ctx, dynQuery.firstRow)
+			if err != nil {
+				return err
+			}
+			dynQuery = nil
+		}
 		if openCursor {
 			return cursorHelper.createCursor(g.p, g.expr.BlockState)
 		}
@@ -338,6 +373,25 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 	return nil
 }
 
+// plpgsqlStmtInfo returns the PL/pgSQL statement from which the body statement
+// with the given (1-based) index was built, or nil if there is no such
+// statement or if execution statistics are not being collected.
+//
+// The body statement may end with a call to a continuation routine which
+// executes the following statements of the routine. If the call is not deferred
+// via tail-call optimization, the statement is considered finished when the
+// continuation starts (see finishPLpgSQLStmt), so the following statements are
+// never attributed to it.
+func (g *routineGenerator) plpgsqlStmtInfo(stmtIdx int) *tree.RoutineStmtInfo {
+	if stmtIdx > len(g.expr.StmtInfos) || g.expr.StmtInfos[stmtIdx-1] == nil {
+		return nil
+	}
+	if !g.p.shouldRecordPLpgSQLStmtStats() {
+		return nil
+	}
+	return g.expr.StmtInfos[stmtIdx-1]
+}
+
 // handleException attempts to match the code of the given error to an exception
 // handler for the routine. If the error finds a match, the corresponding branch
 // for the exception handler is executed as a routine.
@@ -349,6 +403,13 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 // using the current PLpgSQL block's savepoint. Otherwise, it will do nothing,
 // in which case the savepoint will be rolled back either by a parent PLpgSQL
 // block (if the error is eventually caught), or when the transaction aborts.
+//
+// A retryable error can be caught if it does not require the transaction to
+// restart from the beginning, which is the case under READ COMMITTED isolation.
+// Rolling back to the savepoint allows the transaction to continue from the
+// start of the block, so a routine can implement its own retry loop by catching
+// serialization_failure. Catching any other retryable error returns
+// catchRetryableErr.
 func (g *routineGenerator) handleException(ctx context.Context, err error) error {
 	caughtCode := pgerror.GetPGCode(err)
 	if caughtCode == pgcode.Uncategorized {
@@ -363,13 +424,6 @@ func (g *routineGenerator) handleException(ctx context.Context, err error) error
 			// This block has no exception handler.
 			continue
 		}
-		if !g.p.Txn().CanUseSavepoint(ctx, blockState.SavepointTok.(kv.SavepointToken)) {
-			// The current transaction state does not allow roll-back.
-			// TODO(111446): some retryable errors allow the transaction to be rolled
-			// back partially (e.g. for read committed). We should be able to take
-			// advantage of that mechanism here as well.
-			return err
-		}
 		// Unset the exception handler to indicate that it has already encountered an
 		// error.
 		exceptionHandler := blockState.ExceptionHandler
@@ -388,17 +442,40 @@ func (g *routineGenerator) handleException(ctx context.Context, err error) error
 				break
 			}
 		}
+		spTok := blockState.SavepointTok.(kv.SavepointToken)
+		partialRetry := false
+		if branch != nil && !g.p.Txn().CanUseSavepoint(ctx, spTok) {
+			// The current transaction state does not allow a regular roll-back.
+			// Retryable errors that allow a partial retry of the transaction (e.g.
+			// under READ COMMITTED isolation) can still be caught.
+			if !g.p.Txn().CanRollbackToSavepointForPartialRetry(ctx, spTok) {
+				if errors.HasType(err, (*kvpb.TransactionRetryWithProtoRefreshError)(nil)) {
+					// The handler caught a retryable error that requires the
+					// transaction to restart from the beginning.
+					return errors.WithSecondaryError(catchRetryableErr, err)
+				}
+				return err
+			}
+			partialRetry = true
+		}
 		if branch != nil {
 			cursErr := g.closeCursors(blockState)
 			if cursErr != nil {
 				// This error is unexpected, so return immediately.
 				return errors.CombineErrors(err, cursErr)
 			}
-			spErr := g.p.Txn().RollbackToSavepoint(ctx, blockState.SavepointTok.(kv.SavepointToken))
+			spErr := g.p.Txn().RollbackToSavepoint(ctx, spTok)
 			if spErr != nil {
 				// This error is unexpected, so return immediately.
 				return errors.CombineErrors(err, spErr)
 			}
+			g.p.notifications.discardQueuedAfter(blockState.NumNotifications)
+			if partialRetry {
+				// The transaction can now continue from the savepoint.
+				if spErr = g.p.Txn().PrepareForPartialRetry(ctx); spErr != nil {
+					return errors.CombineErrors(err, spErr)
+				}
+			}
 			// Truncate the arguments using the number of variables in scope for the
 			// current block. This is necessary because the error may originate from
 			// a child block, but propagate up to a parent block. See the BlockState
@@ -419,6 +496,13 @@ func (g *routineGenerator) handleException(ctx context.Context, err error) error
 	return err
 }
 
+// catchRetryableErr is returned when a PL/pgSQL exception handler catches a
+// retryable error that requires the transaction to restart from the beginning,
+// which is always the case under SERIALIZABLE isolation.
+var catchRetryableErr = unimplemented.NewWithIssue(111446,
+	"catching a Transaction Retry error in a PLpgSQL EXCEPTION block is only supported under READ COMMITTED isolation",
+)
+
 // closeCursors closes any cursors that were opened within the scope of the
 // current block. It is used for PLpgSQL exception handling.
 func (g *routineGenerator) closeCursors(blockState *tree.BlockState) error {
@@ -457,6 +541,7 @@ func (g *routineGenerator) maybeInitBlockState(ctx context.Context) error {
 		if blockState.SavepointTok, err = g.p.Txn().CreateSavepoint(ctx); err != nil {
 			return err
 		}
+		blockState.NumNotifications = g.p.notifications.numQueued()
 	}
 	return nil
 }
@@ -541,7 +626,41 @@ func (d *droppingResultWriter) Err() error {
 	return d.err
 }
 
-func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursorHelper, error) {
+// openDynamicCursor plans and executes the query for an OPEN ... FOR EXECUTE
+// statement, and returns a cursor helper that holds its result. row contains
+// the query string and a tuple of the USING arguments.
+func (g *routineGenerator) openDynamicCursor(
+	ctx context.Context, row tree.Datums,
+) (*plpgsqlCursorHelper, error) {
+	if len(row) != 2 {
+		return nil, errors.AssertionFailedf("expected query string and arguments for dynamic cursor")
+	}
+	if row[0] == tree.DNull {
+		return nil, pgerror.New(
+			pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null",
+		)
+	}
+	query := string(tree.MustBeDString(row[0]))
+	plan, _, err := g.p.planDynamicQuery(ctx, query, tree.MustBeDTuple(row[1]), true /* forCursor */)
+	if err != nil {
+		return nil, err
+	}
+	cursorHelper, err := g.newCursorHelper(plan, query)
+	if err != nil {
+		plan.close(ctx)
+		return nil, err
+	}
+	w := NewRowResultWriter(&cursorHelper.container)
+	err = runPlanInsidePlan(ctx, g.p.RunParams(ctx), plan, w, nil /* deferredRoutineSender */, query)
+	if err != nil {
+		return nil, errors.CombineErrors(err, cursorHelper.Close())
+	}
+	return cursorHelper, nil
+}
+
+func (g *routineGenerator) newCursorHelper(
+	plan *planComponents, cursorSQL string,
+) (*plpgsqlCursorHelper, error) {
 	open := g.expr.CursorDeclaration
 	if open.NameArgIdx < 0 || open.NameArgIdx >= len(g.args) {
 		panic(errors.AssertionFailedf("unexpected name argument index: %d", open.NameArgIdx))
@@ -562,7 +681,7 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 		ctx:        context.Background(),
 		cursorName: cursorName,
 		resultCols: make(colinfo.ResultColumns, len(planCols)),
-		cursorSql:  open.CursorSQL,
+		cursorSql:  cursorSQL,
 	}
 	copy(cursorHelper.resultCols, planCols)
 	mon := g.p.Mon()
@@ -673,6 +792,270 @@ func (h *plpgsqlCursorHelper) HasResults() bool {
 	return h.lastRow != nil
 }
 
+// planDynamicQuery plans the given SQL string for a PLpgSQL EXECUTE or
+// OPEN ... FOR EXECUTE statement. The elements of args are substituted for the
+// placeholders in the query. The memo for the query is cached by the session,
+// keyed on the SQL text, so that a query string that is executed repeatedly
+// (e.g. in a loop) is only parsed and built once. If forCursor is true, the
+// query is used to open a cursor, and must be a SELECT without mutations.
+func (p *planner) planDynamicQuery(
+	ctx context.Context, query string, args *tree.DTuple, forCursor bool,
+) (*planComponents, statements.Statement[tree.Statement], error) {
+	entry, err := p.getDynamicQueryMemo(ctx, query, args)
+	if err != nil {
+		return nil, statements.Statement[tree.Statement]{}, err
+	}
+	stmt := entry.stmt
+	if _, ok := stmt.AST.(*tree.Select); forCursor && !ok {
+		return nil, stmt, pgerror.Newf(pgcode.InvalidCursorDefinition,
+			"cannot open %s query as cursor", stmt.AST.StatementTag(),
+		)
+	}
+
+	// Assign the placeholders and finish optimization, similar to a cached memo
+	// for a prepared statement.
+	semaCtx := p.semaCtx
+	semaCtx.Placeholders = tree.PlaceholderInfo{
+		PlaceholderTypesInfo: tree.PlaceholderTypesInfo{
+			TypeHints: entry.typeHints,
+			Types:     entry.types,
+		},
+		Values: make(tree.QueryArguments, stmt.NumPlaceholders),
+	}
+	for i := range semaCtx.Placeholders.Values {
+		semaCtx.Placeholders.Values[i] = args.D[i]
+	}
+	evalCtx := p.EvalContext().Copy()
+	evalCtx.Placeholders = &semaCtx.Placeholders
+	var o xform.Optimizer
+	o.Init(ctx, evalCtx, p.optPlanningCtx.catalog)
+	f := o.Factory()
+	f.FoldingControl().AllowStableFolds()
+	if err = f.AssignPlaceholders(entry.memo); err != nil {
+		return nil, stmt, err
+	}
+	optimizedExpr, err := o.Optimize()
+	if err != nil {
+		return nil, stmt, err
+	}
+	if forCursor && optimizedExpr.(memo.RelExpr).Relational().CanMutate {
+		return nil, stmt, pgerror.New(pgcode.FeatureNotSupported,
+			"DECLARE CURSOR must not contain data-modifying statements in WITH",
+		)
+	}
+	eb := execbuilder.New(
+		ctx, newExecFactory(ctx, p), &o, f.Memo(), p.optPlanningCtx.catalog, optimizedExpr,
+		&semaCtx, evalCtx, false /* allowAutoCommit */, statements.IsANSIDML(stmt.AST),
+	)
+	eb.PlanAsRoutineStatement()
+	plan, err := eb.Build()
+	if err != nil {
+		return nil, stmt, err
+	}
+	return plan.(*planComponents), stmt, nil
+}
+
+// getDynamicQueryMemo returns a memo with unassigned placeholders for the given
+// SQL string, using the types of args as the placeholder type hints. The memo
+// is retrieved from the session's dynamic query cache if possible; otherwise,
+// the query is parsed and built into a new memo, which is added to the cache.
+func (p *planner) getDynamicQueryMemo(
+	ctx context.Context, query string, args *tree.DTuple,
+) (*dynamicQueryCacheEntry, error) {
+	typeHints := make(tree.PlaceholderTypes, len(args.D))
+	for i := range args.D {
+		if typ := args.D[i].ResolvedType(); typ.Family() != types.UnknownFamily {
+			typeHints[i] = typ
+		}
+	}
+	if entry, ok := p.dynamicQueryCache.find(query); ok {
+		numPlaceholders := entry.stmt.NumPlaceholders
+		if numPlaceholders <= len(args.D) && entry.typeHints.Identical(typeHints[:numPlaceholders]) {
+			isStale, err := entry.memo.IsStale(ctx, p.EvalContext(), p.optPlanningCtx.catalog)
+			if err != nil {
+				return nil, err
+			}
+			if !isStale {
+				return entry, nil
+			}
+		}
+	}
+
+	stmt, err := parser.ParseOneWithInt(
+		query, parser.NakedIntTypeFromDefaultIntSize(p.SessionData().DefaultIntSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+	switch stmt.AST.(type) {
+	case *tree.Select, *tree.Insert, *tree.Update, *tree.Delete:
+	default:
+		return nil, unimplemented.Newf("plpgsql dynamic "+stmt.AST.StatementTag(),
+			"%s statement is not yet supported in EXECUTE", stmt.AST.StatementTag(),
+		)
+	}
+	if stmt.NumPlaceholders > len(args.D) {
+		return nil, pgerror.Newf(pgcode.UndefinedParameter,
+			"there is no parameter $%d", len(args.D)+1,
+		)
+	}
+	semaCtx := p.semaCtx
+	semaCtx.Placeholders.Init(stmt.NumPlaceholders, typeHints[:stmt.NumPlaceholders])
+	evalCtx := p.EvalContext().Copy()
+	evalCtx.Placeholders = &semaCtx.Placeholders
+	var o xform.Optimizer
+	o.Init(ctx, evalCtx, p.optPlanningCtx.catalog)
+	bld := optbuilder.New(ctx, &semaCtx, evalCtx, p.optPlanningCtx.catalog, o.Factory(), stmt.AST)
+	bld.KeepPlaceholders = true
+	if err = bld.Build(); err != nil {
+		return nil, err
+	}
+	semaCtx.Placeholders.MaybeExtendTypes()
+	if err = semaCtx.Placeholders.Types.AssertAllSet(); err != nil {
+		return nil, err
+	}
+	entry := &dynamicQueryCacheEntry{
+		stmt:      stmt,
+		typeHints: semaCtx.Placeholders.TypeHints,
+		types:     semaCtx.Placeholders.Types,
+		memo:      o.DetachMemo(ctx),
+	}
+	// Similar to the query cache, don't cache the memo if the transaction has
+	// uncommitted DDL, since descriptor versions cannot be relied upon to detect
+	// a stale memo.
+	if !bld.DisableMemoReuse && !p.Descriptors().HasUncommittedTables() {
+		p.dynamicQueryCache.add(query, entry)
+	}
+	return entry, nil
+}
+
+// dynamicQueryCacheSize is the maximum number of memos held by a
+// dynamicQueryCache.
+const dynamicQueryCacheSize = 64
+
+// dynamicQueryCache is a per-session LRU cache of the memos built for the
+// query strings of PLpgSQL EXECUTE and OPEN ... FOR EXECUTE statements, keyed
+// on the SQL text. The cached memos are detached and have unassigned
+// placeholders, so they can be reused with different USING arguments. A nil
+// dynamicQueryCache caches nothing.
+type dynamicQueryCache struct {
+	mu struct {
+		syncutil.Mutex
+		c *cache.UnorderedCache
+	}
+}
+
+// dynamicQueryCacheEntry is a memo in the dynamicQueryCache, along with the
+// statement it was built from and the types of its placeholders.
+type dynamicQueryCacheEntry struct {
+	stmt      statements.Statement[tree.Statement]
+	typeHints tree.PlaceholderTypes
+	types     tree.PlaceholderTypes
+	memo      *memo.Memo
+}
+
+var dynamicQueryCacheCfg = cache.Config{
+	Policy: cache.CacheLRU,
+	ShouldEvict: func(size int, _, _ interface{}) bool {
+		return size > dynamicQueryCacheSize
+	},
+}
+
+// newDynamicQueryCache returns a new dynamicQueryCache. The underlying cache is
+// allocated lazily, since most sessions never execute dynamic SQL.
+func newDynamicQueryCache() *dynamicQueryCache {
+	return &dynamicQueryCache{}
+}
+
+func (c *dynamicQueryCache) find(sql string) (*dynamicQueryCacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.c == nil {
+		return nil, false
+	}
+	if v, ok := c.mu.c.Get(sql); ok {
+		return v.(*dynamicQueryCacheEntry), true
+	}
+	return nil, false
+}
+
+func (c *dynamicQueryCache) add(sql string, entry *dynamicQueryCacheEntry) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.c == nil {
+		c.mu.c = cache.NewUnorderedCache(dynamicQueryCacheCfg)
+	}
+	c.mu.c.Add(sql, entry)
+}
+
+// PLpgSQLExecute is part of the eval.Planner interface.
+func (p *planner) PLpgSQLExecute(
+	ctx context.Context, query string, args *tree.DTuple, into, strict bool,
+) (res tree.Datums, err error) {
+	plan, stmt, err := p.planDynamicQuery(ctx, query, args, false /* forCursor */)
+	if err != nil {
+		return nil, err
+	}
+	if into && stmt.AST.StatementReturnType() != tree.Rows {
+		plan.close(ctx)
+		return nil, pgerror.New(pgcode.Syntax, "INTO used with a command that cannot return data")
+	}
+	w := &dynamicQueryResultWriter{}
+	err = runPlanInsidePlan(ctx, p.RunParams(ctx), plan, w, nil /* deferredRoutineSender */, query)
+	if err != nil {
+		return nil, err
+	}
+	if strict {
+		if w.numRows == 0 {
+			return nil, pgerror.New(pgcode.NoDataFound, "query returned no rows")
+		}
+		if w.numRows > 1 {
+			return nil, pgerror.New(pgcode.TooManyRows, "query returned more than one row")
+		}
+	}
+	return w.firstRow, nil
+}
+
+// dynamicQueryResultWriter is a rowResultWriter that keeps the first row added
+// to it, and counts the total number of rows.
+type dynamicQueryResultWriter struct {
+	firstRow tree.Datums
+	numRows  int
+	err      error
+}
+
+var _ rowResultWriter = &dynamicQueryResultWriter{}
+
+// AddRow is part of the rowResultWriter interface.
+func (w *dynamicQueryResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if w.numRows == 0 {
+		// The caller owns the row slice, so make a copy.
+		w.firstRow = make(tree.Datums, len(row))
+		copy(w.firstRow, row)
+	}
+	w.numRows++
+	return nil
+}
+
+// SetRowsAffected is part of the rowResultWriter interface.
+func (w *dynamicQueryResultWriter) SetRowsAffected(ctx context.Context, n int) {}
+
+// SetError is part of the rowResultWriter interface.
+func (w *dynamicQueryResultWriter) SetError(err error) {
+	w.err = err
+}
+
+// Err is part of the rowResultWriter interface.
+func (w *dynamicQueryResultWriter) Err() error {
+	return w.err
+}
+
 // storedProcTxnStateAccessor provides a method for stored procedures to request
 // that the current transaction be committed or aborted and supply a
 // continuation stored procedure to resume execution in the new transaction.
@@ -734,6 +1117,33 @@ func (p *planner) EvalTxnControlExpr(
 	if err != nil {
 		return nil, err
 	}
-	p.storedProcTxnState.setStoredProcTxnState(expr.Op, &expr.Modes, resumeProc.(*memo.Memo))
+	txnModes := expr.Modes
+	if expr.Chain {
+		txnModes = p.chainTxnModes(txnModes)
+	}
+	p.storedProcTxnState.setStoredProcTxnState(expr.Op, &txnModes, resumeProc.(*memo.Memo))
 	return tree.DNull, nil
 }
+
+// chainTxnModes returns the transaction modes for the new transaction started
+// by a COMMIT AND CHAIN or ROLLBACK AND CHAIN statement. The new transaction
+// has the same isolation level, priority and read-write mode as the current
+// transaction, unless they are set by the given modes (e.g. by a following
+// SET TRANSACTION statement).
+func (p *planner) chainTxnModes(modes tree.TransactionModes) tree.TransactionModes {
+	if modes.Isolation == tree.UnspecifiedIsolation {
+		modes.Isolation = tree.IsolationLevelFromKVTxnIsolationLevel(p.Txn().IsoLevel())
+	}
+	if modes.UserPriority == tree.UnspecifiedUserPriority {
+		modes.UserPriority = txnPriorityFromProto(p.Txn().UserPriority())
+	}
+	if modes.ReadWriteMode == tree.UnspecifiedReadWriteMode && modes.AsOf.Expr == nil {
+		// A historical transaction is always read-only, so the read-write mode is
+		// only inherited if AS OF SYSTEM TIME is not set for the new transaction.
+		modes.ReadWriteMode = tree.ReadWrite
+		if p.EvalContext().TxnReadOnly {
+			modes.ReadWriteMode = tree.ReadOnly
+		}
+	}
+	return modes
+}
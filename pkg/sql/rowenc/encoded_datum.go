@@ -327,6 +327,9 @@ func mustUseValueEncodingForFingerprinting(t *types.T) bool {
 	// behavior can result in incorrect results in mixed version clusters).
 	case types.JsonFamily, types.TSQueryFamily, types.TSVectorFamily:
 		return true
+	case types.GeometricFamily:
+		// The geometric types don't have key-encoding.
+		return true
 	case types.ArrayFamily:
 		// Note that at time of this writing we don't support arrays of JSON
 		// (tracked via #23468) nor of TSQuery / TSVector types (tracked by
@@ -180,6 +180,8 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return append(b, []byte(*t)...), nil
 	case *tree.DJSON:
 		return encodeJSONKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
 	}
 	if buildutil.CrdbTestBuild {
 		return nil, errors.AssertionFailedf("unable to encode table key: %T", val)
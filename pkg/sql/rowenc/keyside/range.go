@@ -0,0 +1,136 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+)
+
+// The following markers are used in the key encoding of ranges. They are
+// chosen so that the encoded ranges sort like tree.DRange.Compare: empty ranges
+// sort first, then ranges are ordered by their lower bounds, where an infinite
+// lower bound sorts first and an inclusive lower bound sorts before an
+// exclusive one with the same value, and then by their upper bounds, where an
+// exclusive upper bound sorts before an inclusive one with the same value and
+// an infinite upper bound sorts last.
+const (
+	rangeKeyEmpty    = 0
+	rangeKeyNonEmpty = 1
+
+	rangeKeyLowerInfinite = 0
+	rangeKeyLowerFinite   = 1
+	rangeKeyUpperFinite   = 0
+	rangeKeyUpperInfinite = 1
+
+	rangeKeyLowerInclusive = 0
+	rangeKeyLowerExclusive = 1
+	rangeKeyUpperExclusive = 0
+	rangeKeyUpperInclusive = 1
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The components
+// of a non-empty range [a, b) are encoded in ascending order as follows:
+// [nonEmpty, lowerFinite, enc(a), lowerInclusive, upperFinite, enc(b), upperExclusive].
+// Infinite bounds are encoded with only their marker. The components are then
+// wrapped in a single bytes encoding in the requested direction, which keeps
+// the ordering since the component encodings are prefix-free, and allows the
+// key to be skipped like any other single value.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	var buf []byte
+	if r.Empty {
+		buf = encoding.EncodeVarintAscending(buf, rangeKeyEmpty)
+	} else {
+		buf = encoding.EncodeVarintAscending(buf, rangeKeyNonEmpty)
+		var err error
+		if r.Lower.IsInfinite() {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyLowerInfinite)
+		} else {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyLowerFinite)
+			if buf, err = Encode(buf, r.Lower.Val, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			inclusivity := int64(rangeKeyLowerExclusive)
+			if r.Lower.Inclusive {
+				inclusivity = rangeKeyLowerInclusive
+			}
+			buf = encoding.EncodeVarintAscending(buf, inclusivity)
+		}
+		if r.Upper.IsInfinite() {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyUpperInfinite)
+		} else {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyUpperFinite)
+			if buf, err = Encode(buf, r.Upper.Val, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			inclusivity := int64(rangeKeyUpperExclusive)
+			if r.Upper.Inclusive {
+				inclusivity = rangeKeyUpperInclusive
+			}
+			buf = encoding.EncodeVarintAscending(buf, inclusivity)
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, buf), nil
+	}
+	return encoding.EncodeBytesDescending(b, buf), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var buf []byte
+	var err error
+	if dir == encoding.Ascending {
+		key, buf, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		key, buf, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var marker int64
+	if buf, marker, err = encoding.DecodeVarintAscending(buf); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyEmpty {
+		return tree.NewEmptyDRange(t), key, nil
+	}
+	decodeBound := func(finite, inclusive int64) (tree.RangeBound, error) {
+		var bound tree.RangeBound
+		if buf, marker, err = encoding.DecodeVarintAscending(buf); err != nil || marker != finite {
+			return bound, err
+		}
+		if bound.Val, buf, err = Decode(a, t.RangeContents(), buf, encoding.Ascending); err != nil {
+			return bound, err
+		}
+		if buf, marker, err = encoding.DecodeVarintAscending(buf); err != nil {
+			return bound, err
+		}
+		bound.Inclusive = marker == inclusive
+		return bound, nil
+	}
+	lower, err := decodeBound(rangeKeyLowerFinite, rangeKeyLowerInclusive)
+	if err != nil {
+		return nil, nil, err
+	}
+	upper, err := decodeBound(rangeKeyUpperFinite, rangeKeyUpperInclusive)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := tree.NewDRange(t, lower, upper)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, key, nil
+}
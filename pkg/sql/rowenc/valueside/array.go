@@ -211,7 +211,8 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 	case types.DecimalFamily:
 		return encoding.Decimal, nil
 	case types.BytesFamily, types.StringFamily, types.CollatedStringFamily,
-		types.EnumFamily, types.RefCursorFamily:
+		types.EnumFamily, types.RefCursorFamily, types.RangeFamily, types.MultiRangeFamily,
+		types.GeometricFamily:
 		return encoding.Bytes, nil
 	case types.TimestampFamily, types.TimestampTZFamily:
 		return encoding.Time, nil
@@ -329,6 +330,20 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 			return nil, err
 		}
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(nil /* appendTo */, t, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DMultiRange:
+		encoded, err := encodeMultiRange(nil /* appendTo */, t, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DGeometric:
+		return encoding.EncodeUntaggedBytesValue(b, encodeGeometric(nil /* appendTo */, t)), nil
 	default:
 		return nil, errors.Errorf("don't know how to encode %s (%T)", d, d)
 	}
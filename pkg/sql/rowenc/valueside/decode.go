@@ -250,6 +250,27 @@ func DecodeUntaggedDatum(
 			return nil, nil, err
 		}
 		return a.NewDEnum(tree.DEnum{EnumTyp: t, PhysicalRep: phys, LogicalRep: log}), b, nil
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, _, err := decodeRange(a, t, data)
+		return r, b, err
+	case types.MultiRangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		mr, _, err := decodeMultiRange(a, t, data)
+		return mr, b, err
+	case types.GeometricFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		g, err := decodeGeometric(t, data)
+		return g, b, err
 	case types.VoidFamily:
 		return a.NewDVoid(), buf, nil
 	default:
@@ -110,6 +110,20 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.Oid)), nil
 	case *tree.DEnum:
 		return encoding.EncodeBytesValue(appendTo, uint32(colID), t.PhysicalRep), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(nil /* appendTo */, t, scratch)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DMultiRange:
+		encoded, err := encodeMultiRange(nil /* appendTo */, t, scratch)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DGeometric:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encodeGeometric(nil /* appendTo */, t)), nil
 	case *tree.DVoid:
 		return encoding.EncodeVoidValue(appendTo, uint32(colID)), nil
 	default:
@@ -0,0 +1,118 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// encodeGeometric produces the encoding of a value of a geometric type which
+// is stored as a bytes value. The encoding is a list of float64 values: the
+// coefficients of a line, or the number of points of any other type followed
+// by the coordinates of its points. A circle is followed by its radius and a
+// path by a byte which is set for closed paths.
+func encodeGeometric(appendTo []byte, g *tree.DGeometric) []byte {
+	appendFloat := func(f float64) {
+		appendTo = encoding.EncodeUint64Ascending(appendTo, math.Float64bits(f))
+	}
+	if g.ResolvedType().Oid() == oid.T_line {
+		for _, f := range g.Line {
+			appendFloat(f)
+		}
+		return appendTo
+	}
+	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(g.Points)))
+	for _, p := range g.Points {
+		appendFloat(p.X)
+		appendFloat(p.Y)
+	}
+	switch g.ResolvedType().Oid() {
+	case oid.T_circle:
+		appendFloat(g.Radius)
+	case oid.T_path:
+		var closed byte
+		if g.Closed {
+			closed = 1
+		}
+		appendTo = append(appendTo, closed)
+	}
+	return appendTo
+}
+
+// decodeGeometric decodes a value of a geometric type encoded by
+// encodeGeometric.
+func decodeGeometric(t *types.T, b []byte) (*tree.DGeometric, error) {
+	var err error
+	decodeFloat := func() float64 {
+		var u uint64
+		if err == nil {
+			b, u, err = encoding.DecodeUint64Ascending(b)
+		}
+		return math.Float64frombits(u)
+	}
+	if t.Oid() == oid.T_line {
+		var coefs [3]float64
+		for i := range coefs {
+			coefs[i] = decodeFloat()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDLine(coefs[0], coefs[1], coefs[2])
+	}
+	var n uint64
+	if b, _, n, err = encoding.DecodeNonsortingUvarint(b); err != nil {
+		return nil, err
+	}
+	minPoints := uint64(1)
+	switch t.Oid() {
+	case oid.T_lseg, oid.T_box:
+		minPoints = 2
+	}
+	if n < minPoints || n > uint64(len(b)/16) {
+		return nil, errors.AssertionFailedf("invalid %s encoding: %d points", t, n)
+	}
+	points := make([]tree.GeometricPoint, n)
+	for i := range points {
+		points[i] = tree.GeometricPoint{X: decodeFloat(), Y: decodeFloat()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch t.Oid() {
+	case oid.T_point:
+		return tree.NewDPoint(points[0]), nil
+	case oid.T_lseg:
+		return tree.NewDLSeg(points[0], points[1]), nil
+	case oid.T_box:
+		return tree.NewDBox(points[0], points[1]), nil
+	case oid.T_path:
+		if len(b) == 0 {
+			return nil, errors.AssertionFailedf("invalid path encoding (missing flag)")
+		}
+		return tree.NewDPath(points, b[0] != 0), nil
+	case oid.T_polygon:
+		return tree.NewDPolygon(points), nil
+	case oid.T_circle:
+		radius := decodeFloat()
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDCircle(points[0], radius)
+	}
+	return nil, errors.AssertionFailedf("unexpected geometric type %s", t)
+}
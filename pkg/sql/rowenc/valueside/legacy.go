@@ -213,6 +213,29 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(v.PhysicalRep)
 			return r, nil
 		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			data, err := encodeRange(nil /* appendTo */, v, nil /* scratch */)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.MultiRangeFamily:
+		if v, ok := val.(*tree.DMultiRange); ok {
+			data, err := encodeMultiRange(nil /* appendTo */, v, nil /* scratch */)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.GeometricFamily:
+		if v, ok := val.(*tree.DGeometric); ok {
+			r.SetBytes(encodeGeometric(nil /* appendTo */, v))
+			return r, nil
+		}
 	default:
 		return r, errors.AssertionFailedf("unsupported column type: %s", colType.Family())
 	}
@@ -432,6 +455,26 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDEnum(tree.DEnum{EnumTyp: typ, PhysicalRep: phys, LogicalRep: log}), nil
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		r, _, err := decodeRange(a, typ, v)
+		return r, err
+	case types.MultiRangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		mr, _, err := decodeMultiRange(a, typ, v)
+		return mr, err
+	case types.GeometricFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeGeometric(typ, v)
 	default:
 		return nil, errors.Errorf("unsupported column type: %s", typ.Family())
 	}
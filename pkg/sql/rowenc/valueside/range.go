@@ -0,0 +1,95 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeRange produces the encoding of a range which is stored as a bytes
+// value. The encoding is a byte with the tree.RangeFlags of the range, followed
+// by the value encodings of its finite bounds.
+func encodeRange(appendTo []byte, r *tree.DRange, scratch []byte) ([]byte, error) {
+	appendTo = append(appendTo, byte(r.Flags()))
+	if r.Empty {
+		return appendTo, nil
+	}
+	var err error
+	for _, b := range [...]tree.RangeBound{r.Lower, r.Upper} {
+		if b.IsInfinite() {
+			continue
+		}
+		if appendTo, err = Encode(appendTo, NoColumnID, b.Val, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeRange decodes a range encoded by encodeRange.
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := tree.RangeFlags(b[0])
+	b = b[1:]
+	if flags&tree.RangeFlagEmpty != 0 {
+		return tree.NewEmptyDRange(t), b, nil
+	}
+	var lower, upper tree.Datum
+	var err error
+	if flags&tree.RangeFlagLowerInfinite == 0 {
+		if lower, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	if flags&tree.RangeFlagUpperInfinite == 0 {
+		if upper, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	r, err := tree.NewDRangeFromFlags(t, flags, lower, upper)
+	return r, b, err
+}
+
+// encodeMultiRange produces the encoding of a multirange which is stored as a
+// bytes value. The encoding is the number of ranges in the multirange followed
+// by the encodings of the ranges.
+func encodeMultiRange(appendTo []byte, mr *tree.DMultiRange, scratch []byte) ([]byte, error) {
+	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(mr.Ranges)))
+	var err error
+	for _, r := range mr.Ranges {
+		if appendTo, err = encodeRange(appendTo, r, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeMultiRange decodes a multirange encoded by encodeMultiRange.
+func decodeMultiRange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultiRange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges := make([]*tree.DRange, n)
+	for i := range ranges {
+		if ranges[i], b, err = decodeRange(a, t.MultiRangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree.NewDMultiRange(t, ranges), b, nil
+}
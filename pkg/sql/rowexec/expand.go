@@ -0,0 +1,149 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// expandProcessor is the processor of the Expand operator, which produces a
+// copy of each input row for each grouping set of a GROUPING SETS, ROLLUP or
+// CUBE clause.
+type expandProcessor struct {
+	execinfra.ProcessorBase
+
+	input execinfra.RowSource
+	spec  *execinfrapb.ExpandSpec
+
+	// setIDs contains the encoded index of each grouping set.
+	setIDs []rowenc.EncDatum
+
+	// inputRow is the input row that is being expanded, and nextSet is the
+	// index of the grouping set of its next copy.
+	inputRow rowenc.EncDatumRow
+	nextSet  int
+
+	// outRow is the buffer for the produced rows.
+	outRow rowenc.EncDatumRow
+}
+
+var _ execinfra.Processor = &expandProcessor{}
+var _ execinfra.RowSource = &expandProcessor{}
+
+const expandProcName = "expand"
+
+func newExpandProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.ExpandSpec,
+	input execinfra.RowSource,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.RowSourcedProcessor, error) {
+	e := &expandProcessor{input: input, spec: spec}
+
+	inputTypes := input.OutputTypes()
+	colTypes := make([]*types.T, 0, len(inputTypes)+len(spec.GroupingCols)+1)
+	colTypes = append(colTypes, inputTypes...)
+	for _, col := range spec.GroupingCols {
+		colTypes = append(colTypes, inputTypes[col])
+	}
+	colTypes = append(colTypes, types.Int)
+	if err := e.Init(
+		ctx,
+		e,
+		post,
+		colTypes,
+		flowCtx,
+		processorID,
+		nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			InputsToDrain: []execinfra.RowSource{e.input},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	e.setIDs = make([]rowenc.EncDatum, len(spec.GroupingSets))
+	for i := range e.setIDs {
+		e.setIDs[i] = rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(i)))
+	}
+	e.outRow = make(rowenc.EncDatumRow, len(colTypes))
+
+	if execstats.ShouldCollectStats(ctx, flowCtx.CollectStats) {
+		e.input = newInputStatCollector(e.input)
+		e.ExecStatsForTrace = e.execStatsForTrace
+	}
+
+	return e, nil
+}
+
+// Start is part of the RowSource interface.
+func (e *expandProcessor) Start(ctx context.Context) {
+	ctx = e.StartInternal(ctx, expandProcName)
+	e.input.Start(ctx)
+}
+
+// Next is part of the RowSource interface.
+func (e *expandProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for e.State == execinfra.StateRunning {
+		if e.inputRow == nil || e.nextSet == len(e.spec.GroupingSets) {
+			row, meta := e.input.Next()
+			if meta != nil {
+				if meta.Err != nil {
+					e.MoveToDraining(nil /* err */)
+				}
+				return nil, meta
+			}
+			if row == nil {
+				e.MoveToDraining(nil /* err */)
+				break
+			}
+			e.inputRow = row
+			e.nextSet = 0
+		}
+
+		numInputCols := copy(e.outRow, e.inputRow)
+		groupingColsOut := e.outRow[numInputCols : numInputCols+len(e.spec.GroupingCols)]
+		for i := range groupingColsOut {
+			groupingColsOut[i] = rowenc.NullEncDatum()
+		}
+		for _, idx := range e.spec.GroupingSets[e.nextSet].Cols {
+			groupingColsOut[idx] = e.inputRow[e.spec.GroupingCols[idx]]
+		}
+		e.outRow[len(e.outRow)-1] = e.setIDs[e.nextSet]
+		e.nextSet++
+
+		if outRow := e.ProcessRowHelper(e.outRow); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, e.DrainHelper()
+}
+
+// execStatsForTrace implements ProcessorBase.ExecStatsForTrace.
+func (e *expandProcessor) execStatsForTrace() *execinfrapb.ComponentStats {
+	is, ok := getInputStats(e.input)
+	if !ok {
+		return nil
+	}
+	return &execinfrapb.ComponentStats{
+		Inputs: []execinfrapb.InputStats{is},
+		Output: e.OutputHelper.Stats(),
+	}
+}
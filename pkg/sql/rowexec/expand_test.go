@@ -0,0 +1,133 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/distsqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestExpand(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	v := [15]rowenc.EncDatum{}
+	for i := range v {
+		v[i] = rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(i)))
+	}
+	null := rowenc.NullEncDatum()
+
+	testCases := []struct {
+		spec     execinfrapb.ExpandSpec
+		input    rowenc.EncDatumRows
+		expected rowenc.EncDatumRows
+	}{
+		{
+			// GROUPING SETS ((@1), (@2)).
+			spec: execinfrapb.ExpandSpec{
+				GroupingCols: []uint32{0, 1},
+				GroupingSets: []execinfrapb.ExpandSpec_GroupingSet{
+					{Cols: []uint32{0}},
+					{Cols: []uint32{1}},
+				},
+			},
+			input: rowenc.EncDatumRows{
+				{v[1], v[2]},
+				{v[3], null},
+			},
+			expected: rowenc.EncDatumRows{
+				{v[1], v[2], v[1], null, v[0]},
+				{v[1], v[2], null, v[2], v[1]},
+				{v[3], null, v[3], null, v[0]},
+				{v[3], null, null, null, v[1]},
+			},
+		},
+		{
+			// ROLLUP (@2, @1) without the empty grouping set.
+			spec: execinfrapb.ExpandSpec{
+				GroupingCols: []uint32{1, 0},
+				GroupingSets: []execinfrapb.ExpandSpec_GroupingSet{
+					{Cols: []uint32{0, 1}},
+					{Cols: []uint32{0}},
+				},
+			},
+			input: rowenc.EncDatumRows{
+				{v[4], v[5]},
+			},
+			expected: rowenc.EncDatumRows{
+				{v[4], v[5], v[5], v[4], v[0]},
+				{v[4], v[5], v[5], null, v[1]},
+			},
+		},
+		{
+			spec: execinfrapb.ExpandSpec{
+				GroupingCols: []uint32{0},
+				GroupingSets: []execinfrapb.ExpandSpec_GroupingSet{
+					{Cols: []uint32{0}},
+					{Cols: []uint32{0}},
+				},
+			},
+			input:    rowenc.EncDatumRows{},
+			expected: nil,
+		},
+	}
+
+	outTypes := []*types.T{types.Int, types.Int, types.Int, types.Int, types.Int}
+	for _, c := range testCases {
+		t.Run("", func(t *testing.T) {
+			in := distsqlutils.NewRowBuffer(types.TwoIntCols, c.input, distsqlutils.RowBufferArgs{})
+			out := &distsqlutils.RowBuffer{}
+
+			st := cluster.MakeTestingClusterSettings()
+			evalCtx := eval.MakeTestingEvalContext(st)
+			defer evalCtx.Stop(context.Background())
+			flowCtx := execinfra.FlowCtx{
+				Cfg:     &execinfra.ServerConfig{Settings: st},
+				EvalCtx: &evalCtx,
+				Mon:     evalCtx.TestingMon,
+			}
+
+			e, err := newExpandProcessor(context.Background(), &flowCtx, 0 /* processorID */, &c.spec, in, &execinfrapb.PostProcessSpec{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			e.Run(context.Background(), out)
+			if !out.ProducerClosed() {
+				t.Fatalf("output RowReceiver not closed")
+			}
+			var res rowenc.EncDatumRows
+			for {
+				row := out.NextNoMeta(t).Copy()
+				if row == nil {
+					break
+				}
+				res = append(res, row)
+			}
+
+			typs := outTypes[:len(c.spec.GroupingCols)+3]
+			if result := res.String(typs); result != c.expected.String(typs) {
+				t.Errorf("invalid results: %s, expected %s", result, c.expected.String(typs))
+			}
+		})
+	}
+}
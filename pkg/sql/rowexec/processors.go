@@ -173,6 +173,12 @@ func NewProcessor(
 		}
 		return newOrdinalityProcessor(ctx, flowCtx, processorID, core.Ordinality, inputs[0], post)
 	}
+	if core.Expand != nil {
+		if err := checkNumIn(inputs, 1); err != nil {
+			return nil, err
+		}
+		return newExpandProcessor(ctx, flowCtx, processorID, core.Expand, inputs[0], post)
+	}
 	if core.Aggregator != nil {
 		if err := checkNumIn(inputs, 1); err != nil {
 			return nil, err
@@ -252,6 +258,15 @@ func NewProcessor(
 		}
 		return NewCloudStorageTestProcessor(ctx, flowCtx, processorID, *core.CloudStorageTest, post)
 	}
+	if core.ForeignTableReader != nil {
+		if err := checkNumIn(inputs, 0); err != nil {
+			return nil, err
+		}
+		if NewForeignTableReaderProcessor == nil {
+			return nil, errors.New("ForeignTableReader processor unimplemented")
+		}
+		return NewForeignTableReaderProcessor(ctx, flowCtx, processorID, core.ForeignTableReader, post)
+	}
 	if core.IngestStopped != nil {
 		if err := checkNumIn(inputs, 0); err != nil {
 			return nil, err
@@ -404,6 +419,11 @@ var NewReadImportDataProcessor func(context.Context, *execinfra.FlowCtx, int32,
 // NewCloudStorageTestProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewCloudStorageTestProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.CloudStorageTestSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
+// NewForeignTableReaderProcessor is implemented in the importer package, which
+// reads the files of foreign tables, and then injected here via runtime
+// initialization.
+var NewForeignTableReaderProcessor func(context.Context, *execinfra.FlowCtx, int32, *execinfrapb.ForeignTableReaderSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
+
 // NewIngestStoppedProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewIngestStoppedProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.IngestStoppedSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
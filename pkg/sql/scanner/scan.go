@@ -321,6 +321,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.CONTAINED_BY)
 			return
+		case '-': // <-
+			if s.peekN(1) == '>' {
+				// <->
+				s.pos += 2
+				lval.SetID(lexbase.DISTANCE)
+				return
+			}
 		}
 		return
 
@@ -392,6 +399,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.REGIMATCH)
 			return
+		case '=': // ~=
+			s.pos++
+			lval.SetID(lexbase.SAME_AS)
+			return
 		}
 		return
 
@@ -429,6 +440,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.FETCHVAL)
 			return
+		case '|': // -|
+			if s.peekN(1) == '-' {
+				// -|-
+				s.pos += 2
+				lval.SetID(lexbase.RANGE_ADJACENT)
+				return
+			}
 		}
 		return
 
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -562,6 +563,12 @@ func (sc *SchemaChanger) maybeMakeAddTablePublic(
 			return nil
 		}
 		mut.State = descpb.DescriptorState_PUBLIC
+		// Record the timestamp of the data backfilled into a materialized view,
+		// which is the starting point of its first incremental refresh.
+		if mut.MaterializedView() && !mut.IsRefreshViewRequired() &&
+			sc.settings.Version.IsActive(ctx, clusterversion.V24_1) {
+			mut.LastRefreshTime = mut.GetCreateAsOfTime()
+		}
 		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, mut, txn.KV())
 	})
 }
@@ -916,6 +923,10 @@ func (sc *SchemaChanger) exec(ctx context.Context) error {
 		return err
 	}
 
+	if err := sc.maybeSummarizeBlockRangeIndexes(ctx, tableDesc); err != nil {
+		return err
+	}
+
 	if sc.mutationID == descpb.InvalidMutationID {
 		// Nothing more to do.
 		isCreateTableAs := tableDesc.Adding() && tableDesc.IsAs()
@@ -1595,6 +1606,10 @@ func (sc *SchemaChanger) done(ctx context.Context) error {
 				// If we are mutation is in the ADD state, then start GC jobs for the
 				// existing indexes on the table.
 				if m.Adding() {
+					scTable.LastRefreshTime = hlc.Timestamp{}
+					if refresh.ShouldBackfill() && sc.settings.Version.IsActive(ctx, clusterversion.V24_1) {
+						scTable.LastRefreshTime = refresh.AsOf()
+					}
 					desc := fmt.Sprintf("REFRESH MATERIALIZED VIEW %q cleanup", scTable.Name)
 					for _, idx := range scTable.ActiveIndexes() {
 						if err := sc.createIndexGCJob(ctx, idx.GetID(), txn, desc); err != nil {
@@ -512,6 +512,23 @@ func (b *builderState) IsTableEmpty(table *scpb.Table) bool {
 	return b.tr.IsTableEmpty(b.ctx, table.TableID, index.IndexID)
 }
 
+// HasPolicies implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) HasPolicies(tableID catid.DescID) bool {
+	b.ensureDescriptor(tableID)
+	tbl, ok := b.descCache[tableID].desc.(catalog.TableDescriptor)
+	return ok && len(tbl.GetPolicies()) > 0
+}
+
+// DefaultCollation implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) DefaultCollation(databaseID catid.DescID) string {
+	b.ensureDescriptor(databaseID)
+	db, ok := b.descCache[databaseID].desc.(catalog.DatabaseDescriptor)
+	if !ok {
+		return ""
+	}
+	return db.GetDefaultCollation()
+}
+
 func (b *builderState) nextIndexID(id catid.DescID) (ret catid.IndexID) {
 	{
 		b.ensureDescriptor(id)
@@ -982,7 +999,7 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_ENUM:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
-	case descpb.TypeDescriptor_COMPOSITE:
+	case descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_DOMAIN:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
@@ -1035,6 +1052,12 @@ func (b *builderState) resolveRelation(
 		if t.IsTemporary() {
 			panic(scerrors.NotImplementedErrorf(nil /* n */, "dropping a temporary table"))
 		}
+		if t.IsForeignTable() {
+			panic(scerrors.NotImplementedErrorf(nil /* n */, "foreign tables"))
+		}
+		if len(t.GetBlockRangeIndexes()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil /* n */, "tables with block range indexes"))
+		}
 	} else if typ, isType := rel.(catalog.TypeDescriptor); isType {
 		if typ.GetKind() == descpb.TypeDescriptor_ALIAS && typ.GetID() == descpb.InvalidID {
 			// This case handles the types in types.PublicSchemaAliases -- BOX2D,
@@ -1139,6 +1162,9 @@ func (b *builderState) ResolveIndex(
 		panic(pgerror.Newf(pgcode.WrongObjectType,
 			"%q is not an indexable table or a materialized view", rel.GetName()))
 	}
+	if len(rel.GetBlockRangeIndexes()) > 0 {
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "tables with block range indexes"))
+	}
 	b.requirePrivilege(rel.GetID(), p.RequiredPrivilege)
 	elts := b.QueryByID(rel.GetID())
 	var indexID catid.IndexID
@@ -1320,6 +1346,9 @@ func (b *builderState) ResolveRoutine(
 			),
 		)
 	}
+	if ol.Class == tree.AggregateClass {
+		panic(scerrors.NotImplementedErrorf(nil, "user-defined aggregate functions"))
+	}
 
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 	b.mustOwn(fnID)
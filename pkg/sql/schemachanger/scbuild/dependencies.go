@@ -120,6 +120,7 @@ type CatalogReader interface {
 	tree.TypeReferenceResolver
 	tree.QualifiedNameResolver
 	tree.FunctionReferenceResolver
+	tree.CollationResolver
 
 	// MayResolveDatabase looks up a database by name.
 	MayResolveDatabase(ctx context.Context, name tree.Name) catalog.DatabaseDescriptor
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/screl"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -126,6 +127,9 @@ func AlterTable(b BuildCtx, n *tree.AlterTable) {
 			"table %q is being dropped, try again later", n.Table.Object()))
 	}
 	panicIfSchemaIsLocked(elts)
+	if b.HasPolicies(tbl.TableID) {
+		panic(scerrors.NotImplementedErrorf(n, "ALTER TABLE on a table with row-level security policies"))
+	}
 	tn.ObjectNamePrefix = b.NamePrefix(tbl)
 	b.SetUnresolvedNameAnnotation(n.Table, &tn)
 	b.IncrementSchemaChangeAlterCounter("table")
@@ -114,6 +114,8 @@ func alterTableAddColumn(
 				"regional by row partitioning is not supported"))
 		}
 	}
+	_, _, tableNamespace := scpb.FindNamespace(b.QueryByID(tbl.TableID))
+	tabledesc.MaybeApplyDefaultCollation(d, b.DefaultCollation(tableNamespace.DatabaseID))
 	cdd, err := tabledesc.MakeColumnDefDescs(b, d, b.SemaCtx(), b.EvalCtx(), tree.ColumnDefaultExprInAddColumn)
 	if err != nil {
 		panic(err)
@@ -152,7 +154,6 @@ func alterTableAddColumn(
 		ElementCreationMetadata: scdecomp.NewElementCreationMetadata(b.EvalCtx().Settings.Version.ActiveVersion(b)),
 	}
 
-	_, _, tableNamespace := scpb.FindNamespace(b.QueryByID(tbl.TableID))
 	spec.colType.TypeT = b.ResolveTypeRef(d.Type)
 	if spec.colType.TypeT.Type.UserDefined() {
 		typeID := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.Oid())
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -195,6 +196,9 @@ func alterTableAddForeignKey(
 	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableAddConstraint,
 ) {
 	fkDef := t.ConstraintDef.(*tree.ForeignKeyConstraintTableDef)
+	if fkDef.Deferrability.Deferrable {
+		panic(scerrors.NotImplementedErrorf(t, "deferrable foreign key constraints are not supported"))
+	}
 	// fromColsFRNames is fully resolved column names from `fkDef.FromCols`, and
 	// is only used in constructing error messages to be consistent with legacy
 	// schema changer.
@@ -421,7 +425,8 @@ func alterTableAddForeignKey(
 }
 
 // alterTableAddUniqueWithoutIndex contains logic for building
-// `ALTER TABLE ... ADD UNIQUE WITHOUT INDEX ... [NOT VALID]`.
+// `ALTER TABLE ... ADD UNIQUE WITHOUT INDEX ... [NOT VALID]` and
+// `ALTER TABLE ... ADD EXCLUDE ... [NOT VALID]`.
 // It assumes `t` is such a command.
 func alterTableAddUniqueWithoutIndex(
 	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableAddConstraint,
@@ -429,11 +434,18 @@ func alterTableAddUniqueWithoutIndex(
 	d := t.ConstraintDef.(*tree.UniqueConstraintTableDef)
 
 	// 1. A bunch of checks.
-	if !b.SessionData().EnableUniqueWithoutIndexConstraints {
+	if d.Deferrability.Deferrable {
+		panic(scerrors.NotImplementedErrorf(t, "deferrable unique constraints are not supported"))
+	}
+	if !d.IsExclusion() && !b.SessionData().EnableUniqueWithoutIndexConstraints {
 		panic(pgerror.New(pgcode.FeatureNotSupported,
 			"unique constraints without an index are not yet supported",
 		))
 	}
+	if d.IsExclusion() && !b.EvalCtx().Settings.Version.IsActive(b, clusterversion.V24_1_ExclusionConstraints) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"EXCLUDE constraints are not supported until version 24.1"))
+	}
 	if len(d.Storing) > 0 {
 		panic(pgerror.New(pgcode.FeatureNotSupported,
 			"unique constraints without an index cannot store columns",
@@ -466,6 +478,20 @@ func alterTableAddUniqueWithoutIndex(
 		colIDs = append(colIDs, colID)
 		colNames = append(colNames, string(col.Column))
 	}
+	var exclusionOperators []string
+	if d.IsExclusion() {
+		colTypes := make([]*types.T, len(colIDs))
+		for i, colID := range colIDs {
+			colTypes[i] = mustRetrieveColumnTypeElem(b, tbl.TableID, colID).Type
+		}
+		var err error
+		exclusionOperators, err = schemaexpr.ValidateExclusionOperators(
+			string(d.ExclusionMethod), colTypes, d.ExclusionOps,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
 
 	// 3. If a name is provided, check that this name is not used; Otherwise, generate
 	// a unique name for it.
@@ -475,8 +501,12 @@ func alterTableAddUniqueWithoutIndex(
 		return
 	}
 	if d.Name == "" {
+		name := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if d.IsExclusion() {
+			name = fmt.Sprintf("%s_%s_excl", tn.Object(), strings.Join(colNames, "_"))
+		}
 		d.Name = tree.Name(tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			name,
 			func(name string) bool {
 				return constraintNameInUse(b, tbl.TableID, name)
 			},
@@ -512,6 +542,8 @@ func alterTableAddUniqueWithoutIndex(
 			ConstraintID:         constraintID,
 			ColumnIDs:            colIDs,
 			IndexIDForValidation: getIndexIDForValidationForConstraint(b, tbl.TableID),
+			ExclusionOperators:   exclusionOperators,
+			ExclusionMethod:      string(d.ExclusionMethod),
 		}
 		if d.Predicate != nil {
 			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
@@ -520,9 +552,11 @@ func alterTableAddUniqueWithoutIndex(
 		b.LogEventForExistingTarget(uwi)
 	} else {
 		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
-			TableID:      tbl.TableID,
-			ConstraintID: constraintID,
-			ColumnIDs:    colIDs,
+			TableID:            tbl.TableID,
+			ConstraintID:       constraintID,
+			ColumnIDs:          colIDs,
+			ExclusionOperators: exclusionOperators,
+			ExclusionMethod:    string(d.ExclusionMethod),
 		}
 		if d.Predicate != nil {
 			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
@@ -300,6 +300,14 @@ func dropColumn(
 				Constraint:   tree.Name(constraintName.Name),
 				DropBehavior: behavior,
 			})
+		case *scpb.Trigger:
+			if behavior != tree.DropCascade {
+				panic(sqlerrors.NewDependentBlocksOpError("drop", "column", cn.Name, "trigger", e.Name))
+			}
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+				"dropping trigger %q which depends on column %q", e.Name, cn.Name,
+			))
+			b.Drop(e)
 		default:
 			b.Drop(e)
 		}
@@ -344,7 +352,8 @@ func walkDropColumnDependencies(b BuildCtx, col *scpb.Column, fn func(e scpb.Ele
 			case *scpb.Column, *scpb.ColumnName, *scpb.ColumnComment, *scpb.ColumnNotNull,
 				*scpb.ColumnDefaultExpression, *scpb.ColumnOnUpdateExpression,
 				*scpb.UniqueWithoutIndexConstraint, *scpb.CheckConstraint,
-				*scpb.UniqueWithoutIndexConstraintUnvalidated, *scpb.CheckConstraintUnvalidated:
+				*scpb.UniqueWithoutIndexConstraintUnvalidated, *scpb.CheckConstraintUnvalidated,
+				*scpb.Trigger:
 				fn(e)
 			case *scpb.ColumnType:
 				if elt.ColumnID == col.ColumnID {
@@ -189,10 +189,10 @@ func createDatabasePreChecks(b BuildCtx, n *tree.CreateDatabase) {
 	}
 
 	if col := n.Collate; col != "" {
-		// We only support C and C.UTF-8.
+		// Only C and C.UTF-8 are supported here; databases with a default
+		// collation are created by the legacy schema changer.
 		if col != "C" && col != "C.UTF-8" {
-			panic(unimplemented.NewWithIssueDetailf(16618, "create.db.collation",
-				"unsupported collation: %s", col))
+			panic(scerrors.NotImplementedErrorf(n, "unsupported collation: %s", col))
 		}
 	}
 
@@ -48,6 +48,15 @@ import (
 
 // CreateIndex implements CREATE INDEX.
 func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
+	if n.BlockRange {
+		panic(scerrors.NotImplementedErrorf(n, "block range indexes"))
+	}
+	if n.Hash {
+		var err error
+		if n, err = schemaexpr.HashIndexAsExpressionIndex(n); err != nil {
+			panic(err)
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("index")
 	// Resolve the table name and start building the new index element.
 	relationElements := b.ResolveRelation(n.Table.ToUnresolvedObjectName(), ResolveParams{
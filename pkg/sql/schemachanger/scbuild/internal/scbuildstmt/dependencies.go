@@ -302,6 +302,15 @@ type TableHelpers interface {
 
 	// IsTableEmpty returns if the table is empty or not.
 	IsTableEmpty(tbl *scpb.Table) bool
+
+	// HasPolicies returns true if the table has any row-level security
+	// policies. These are not represented as elements yet.
+	HasPolicies(tableID catid.DescID) bool
+
+	// DefaultCollation returns the locale of the default collation of the
+	// database, or the empty string for the "C" collation. It is not
+	// represented as an element yet.
+	DefaultCollation(databaseID catid.DescID) string
 }
 
 type FunctionHelpers interface {
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -22,11 +23,6 @@ import (
 )
 
 func DropFunction(b BuildCtx, n *tree.DropRoutine) {
-	if n.DropBehavior == tree.DropCascade {
-		// TODO(chengxiong): remove this when we allow UDF usage.
-		panic(scerrors.NotImplementedErrorf(n, "cascade dropping functions"))
-	}
-
 	routineType := tree.UDFRoutine
 	if n.Procedure {
 		routineType = tree.ProcedureRoutine
@@ -44,6 +40,9 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 			continue
 		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
+		if n.DropBehavior == tree.DropCascade {
+			dropTriggersReferencingFunction(b, n, fn.FunctionID)
+		}
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
 			_, _, fnName := scpb.FindFunctionName(elts)
@@ -66,3 +65,22 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 		}
 	}
 }
+
+// dropTriggersReferencingFunction drops the triggers which execute the given
+// function. Triggers are the only kind of dependent which can be dropped along
+// with a function for now, so any other dependent results in an error.
+func dropTriggersReferencingFunction(b BuildCtx, n *tree.DropRoutine, fnID catid.DescID) {
+	undroppedBackrefs(b, fnID).ForEach(func(_ scpb.Status, _ scpb.TargetStatus, e scpb.Element) {
+		if _, ok := e.(*scpb.Trigger); !ok {
+			// TODO(chengxiong): remove this when we allow UDF usage.
+			panic(scerrors.NotImplementedErrorf(n, "cascade dropping functions"))
+		}
+	})
+	undroppedBackrefs(b, fnID).ForEach(func(_ scpb.Status, _ scpb.TargetStatus, e scpb.Element) {
+		t := e.(*scpb.Trigger)
+		b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+			"drop cascades to trigger %s on table %s", t.Name, qualifiedName(b, t.TableID),
+		))
+		b.Drop(e)
+	})
+}
@@ -11,6 +11,7 @@
 package scbuildstmt
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -22,7 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 )
 
-// DropType implements DROP TYPE.
+// DropType implements DROP TYPE and DROP DOMAIN.
 func DropType(b BuildCtx, n *tree.DropType) {
 	if n.DropBehavior == tree.DropCascade {
 		panic(scerrors.NotImplementedErrorf(n, "DROP TYPE CASCADE is not yet supported"))
@@ -36,6 +37,10 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		})
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
+		_, _, domain := scpb.FindDomainType(elts)
+		if n.Domain && domain == nil && elts != nil {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name))
+		}
 		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
 			b.IncrementEnumCounter(sqltelemetry.EnumDrop)
 			typeID, arrayTypeID = enum.TypeID, enum.ArrayTypeID
@@ -43,6 +48,14 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		} else if _, _, composite := scpb.FindCompositeType(elts); composite != nil {
 			typeID, arrayTypeID = composite.TypeID, composite.ArrayTypeID
 			typ = composite
+		} else if domain != nil {
+			// Domains can only be created once the cluster is upgraded to 24.1, so
+			// only the current rules need to support them.
+			if !b.EvalCtx().Settings.Version.IsActive(b, clusterversion.V24_1) {
+				panic(scerrors.NotImplementedErrorf(n, "DROP DOMAIN is not supported until version 24.1"))
+			}
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
 		} else {
 			continue
 		}
@@ -37,6 +37,7 @@ func newSemaCtx(d Dependencies) *tree.SemaContext {
 	semaCtx.TypeResolver = d.CatalogReader()
 	semaCtx.FunctionResolver = d.CatalogReader()
 	semaCtx.NameResolver = d.CatalogReader()
+	semaCtx.CollationResolver = d.CatalogReader()
 	semaCtx.DateStyle = d.SessionData().GetDateStyle()
 	semaCtx.IntervalStyle = d.SessionData().GetIntervalStyle()
 	semaCtx.UnsupportedTypeChecker = eval.NewUnsupportedTypeChecker(d.ClusterSettings().Version)
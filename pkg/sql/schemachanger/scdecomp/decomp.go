@@ -217,6 +217,11 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if domain := typ.AsDomainTypeDescriptor(); domain != nil {
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      domain.GetID(),
+			ArrayTypeID: domain.GetArrayTypeID(),
+		})
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -396,6 +401,9 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	for _, c := range tbl.OutboundForeignKeys() {
 		w.walkForeignKeyConstraint(tbl, c)
 	}
+	for i := range tbl.GetTriggers() {
+		w.walkTrigger(tbl, &tbl.GetTriggers()[i])
+	}
 
 	_ = tbl.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 		w.backRefs.Add(dep.ID)
@@ -435,6 +443,28 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	}
 }
 
+func (w *walkCtx) walkTrigger(tbl catalog.TableDescriptor, t *descpb.TableDescriptor_Trigger) {
+	events := make([]scpb.Trigger_Event, len(t.Events))
+	for i, ev := range t.Events {
+		events[i] = scpb.Trigger_Event{
+			Type:      scpb.Trigger_Event_Type(ev.Type),
+			ColumnIDs: ev.ColumnIDs,
+		}
+	}
+	w.ev(scpb.Status_PUBLIC, &scpb.Trigger{
+		TableID:    tbl.GetID(),
+		TriggerID:  t.ID,
+		Name:       t.Name,
+		ActionTime: scpb.Trigger_ActionTime(t.ActionTime),
+		Events:     events,
+		ForEachRow: t.ForEachRow,
+		WhenExpr:   t.WhenExpr,
+		FunctionID: t.FuncID,
+		FuncArgs:   t.FuncArgs,
+		ColumnIDs:  t.ColumnIDs,
+	})
+}
+
 func (w *walkCtx) walkLocality(tbl catalog.TableDescriptor, l *catpb.LocalityConfig) {
 	if g := l.GetGlobal(); g != nil {
 		w.ev(scpb.Status_PUBLIC, &scpb.TableLocalityGlobal{
@@ -693,20 +723,31 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 				c.GetName(), tbl.GetName(), tbl.GetID()))
 		}
 	}
+	colIDs := c.CollectKeyColumnIDs().Ordered()
+	desc := c.UniqueWithoutIndexDesc()
+	if desc.IsExclusion() {
+		// The columns of an exclusion constraint are matched to its operators by
+		// position, so their order must be preserved.
+		colIDs = desc.ColumnIDs
+	}
 	if c.IsConstraintUnvalidated() && w.clusterVersion.IsActive(clusterversion.V23_1) {
 		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:            tbl.GetID(),
+			ConstraintID:       c.GetConstraintID(),
+			ColumnIDs:          colIDs,
+			Predicate:          expr,
+			ExclusionOperators: desc.ExclusionOperators,
+			ExclusionMethod:    desc.ExclusionMethod,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	} else {
 		uwi := &scpb.UniqueWithoutIndexConstraint{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:            tbl.GetID(),
+			ConstraintID:       c.GetConstraintID(),
+			ColumnIDs:          colIDs,
+			Predicate:          expr,
+			ExclusionOperators: desc.ExclusionOperators,
+			ExclusionMethod:    desc.ExclusionMethod,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	}
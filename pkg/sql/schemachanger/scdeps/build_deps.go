@@ -108,6 +108,22 @@ func (d *buildDeps) MayResolveDatabase(
 	return db
 }
 
+// ResolveCollation implements the scbuild.CatalogReader interface.
+func (d *buildDeps) ResolveCollation(
+	ctx context.Context, name string,
+) (locale string, found bool, err error) {
+	dbName := d.schemaResolver.CurrentDatabase()
+	if dbName == "" {
+		return "", false, nil
+	}
+	db, err := d.descsCollection.ByName(d.txn).MaybeGet().Database(ctx, dbName)
+	if err != nil || db == nil {
+		return "", false, err
+	}
+	c, found := db.GetCollation(name)
+	return c.Locale, found, nil
+}
+
 // MayResolveSchema implements the scbuild.CatalogReader interface.
 func (d *buildDeps) MayResolveSchema(
 	ctx context.Context, name tree.ObjectNamePrefix, withOffline bool,
@@ -263,6 +263,18 @@ func (s *TestState) MayResolveDatabase(
 	return db
 }
 
+// ResolveCollation implements the scbuild.CatalogReader interface.
+func (s *TestState) ResolveCollation(
+	ctx context.Context, name string,
+) (locale string, found bool, err error) {
+	db := s.MayResolveDatabase(ctx, tree.Name(s.CurrentDatabase()))
+	if db == nil {
+		return "", false, nil
+	}
+	c, found := db.GetCollation(name)
+	return c.Locale, found, nil
+}
+
 // MayResolveSchema implements the scbuild.CatalogReader interface.
 func (s *TestState) MayResolveSchema(
 	ctx context.Context, name tree.ObjectNamePrefix, withOffline bool,
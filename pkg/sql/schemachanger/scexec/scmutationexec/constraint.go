@@ -525,6 +525,9 @@ func (i *immediateVisitor) AddUniqueWithoutIndexConstraint(
 		Validity:     op.Validity,
 		ConstraintID: op.ConstraintID,
 		Predicate:    string(op.PartialExpr),
+
+		ExclusionOperators: op.ExclusionOperators,
+		ExclusionMethod:    op.ExclusionMethod,
 	}
 	if op.Validity == descpb.ConstraintValidity_Unvalidated {
 		// Unvalidated constraint doesn't need to transition through an intermediate
@@ -335,6 +335,32 @@ func (i *immediateVisitor) RemoveTableConstraintBackReferencesFromFunctions(
 	return nil
 }
 
+func (i *immediateVisitor) AddTriggerBackReferencesInFunctions(
+	ctx context.Context, op scop.AddTriggerBackReferencesInFunctions,
+) error {
+	for _, fnID := range op.FunctionIDs {
+		fnDesc, err := i.checkOutFunction(ctx, fnID)
+		if err != nil {
+			return err
+		}
+		fnDesc.AddTriggerReference(op.BackReferencedTableID, op.BackReferencedTriggerID)
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTriggerBackReferencesInFunctions(
+	ctx context.Context, op scop.RemoveTriggerBackReferencesInFunctions,
+) error {
+	for _, fnID := range op.FunctionIDs {
+		fnDesc, err := i.checkOutFunction(ctx, fnID)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveTriggerReference(op.BackReferencedTableID, op.BackReferencedTriggerID)
+	}
+	return nil
+}
+
 func (i *immediateVisitor) AddTableColumnBackReferencesInFunctions(
 	ctx context.Context, op scop.AddTableColumnBackReferencesInFunctions,
 ) error {
@@ -632,6 +658,8 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 			ReturnType:  t.GetReturnType().Type,
 			ReturnSet:   t.GetReturnType().ReturnSet,
 			IsProcedure: t.IsProcedure(),
+			IsAggregate: t.IsAggregate(),
+			IsVariadic:  t.IsVariadic(),
 		}
 		for pIdx, p := range t.Params {
 			class := funcdesc.ToTreeRoutineParamClass(p.Class)
@@ -0,0 +1,63 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/errors"
+)
+
+func (i *immediateVisitor) AddTrigger(ctx context.Context, op scop.AddTrigger) error {
+	tbl, err := i.checkOutTable(ctx, op.Trigger.TableID)
+	if err != nil || tbl.Dropped() {
+		return err
+	}
+	events := make([]descpb.TableDescriptor_Trigger_Event, len(op.Trigger.Events))
+	for idx, ev := range op.Trigger.Events {
+		events[idx] = descpb.TableDescriptor_Trigger_Event{
+			Type:      descpb.TableDescriptor_Trigger_Event_Type(ev.Type),
+			ColumnIDs: ev.ColumnIDs,
+		}
+	}
+	tbl.Triggers = append(tbl.Triggers, descpb.TableDescriptor_Trigger{
+		Name:       op.Trigger.Name,
+		ID:         op.Trigger.TriggerID,
+		ActionTime: descpb.TableDescriptor_Trigger_ActionTime(op.Trigger.ActionTime),
+		Events:     events,
+		ForEachRow: op.Trigger.ForEachRow,
+		WhenExpr:   op.Trigger.WhenExpr,
+		FuncID:     op.Trigger.FunctionID,
+		FuncArgs:   op.Trigger.FuncArgs,
+		ColumnIDs:  op.Trigger.ColumnIDs,
+	})
+	if tbl.NextTriggerID <= op.Trigger.TriggerID {
+		tbl.NextTriggerID = op.Trigger.TriggerID + 1
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTrigger(ctx context.Context, op scop.RemoveTrigger) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		return err
+	}
+	for idx := range tbl.Triggers {
+		if tbl.Triggers[idx].ID == op.TriggerID {
+			tbl.Triggers = append(tbl.Triggers[:idx], tbl.Triggers[idx+1:]...)
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("failed to find trigger %d in table %q (%d)",
+		op.TriggerID, tbl.GetName(), tbl.GetID())
+}
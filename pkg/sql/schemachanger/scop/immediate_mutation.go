@@ -319,6 +319,19 @@ type AddCheckConstraint struct {
 	Validity              descpb.ConstraintValidity
 }
 
+// AddTrigger adds a trigger to a table.
+type AddTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+// RemoveTrigger removes a trigger from a table.
+type RemoveTrigger struct {
+	immediateMutationOp
+	TableID   descpb.ID
+	TriggerID descpb.TriggerID
+}
+
 // MakeAbsentColumnNotNullWriteOnly adds a non-existent NOT NULL constraint,
 // disguised as a CHECK constraint, to the table in the WRITE_ONLY state.
 type MakeAbsentColumnNotNullWriteOnly struct {
@@ -416,6 +429,10 @@ type AddUniqueWithoutIndexConstraint struct {
 	ColumnIDs    []descpb.ColumnID
 	PartialExpr  catpb.Expression
 	Validity     descpb.ConstraintValidity
+	// ExclusionOperators and ExclusionMethod are only set for exclusion
+	// constraints.
+	ExclusionOperators []string
+	ExclusionMethod    string
 }
 
 // MakeValidatedUniqueWithoutIndexConstraintPublic moves a new, validated unique_without_index
@@ -590,6 +607,24 @@ type RemoveTableColumnBackReferencesInFunctions struct {
 	FunctionIDs            []descpb.ID
 }
 
+// AddTriggerBackReferencesInFunctions adds back-references to a trigger in
+// the referenced functions.
+type AddTriggerBackReferencesInFunctions struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID descpb.TriggerID
+	FunctionIDs             []descpb.ID
+}
+
+// RemoveTriggerBackReferencesInFunctions removes back-references to a trigger
+// from the referenced functions.
+type RemoveTriggerBackReferencesInFunctions struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID descpb.TriggerID
+	FunctionIDs             []descpb.ID
+}
+
 // SetColumnName renames a column.
 type SetColumnName struct {
 	immediateMutationOp
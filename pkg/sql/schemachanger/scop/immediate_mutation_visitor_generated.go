@@ -59,6 +59,8 @@ type ImmediateMutationVisitor interface {
 	RemoveCheckConstraint(context.Context, RemoveCheckConstraint) error
 	RemoveColumnNotNull(context.Context, RemoveColumnNotNull) error
 	AddCheckConstraint(context.Context, AddCheckConstraint) error
+	AddTrigger(context.Context, AddTrigger) error
+	RemoveTrigger(context.Context, RemoveTrigger) error
 	MakeAbsentColumnNotNullWriteOnly(context.Context, MakeAbsentColumnNotNullWriteOnly) error
 	MakePublicCheckConstraintValidated(context.Context, MakePublicCheckConstraintValidated) error
 	MakePublicColumnNotNullValidated(context.Context, MakePublicColumnNotNullValidated) error
@@ -92,6 +94,8 @@ type ImmediateMutationVisitor interface {
 	RemoveTableConstraintBackReferencesFromFunctions(context.Context, RemoveTableConstraintBackReferencesFromFunctions) error
 	AddTableColumnBackReferencesInFunctions(context.Context, AddTableColumnBackReferencesInFunctions) error
 	RemoveTableColumnBackReferencesInFunctions(context.Context, RemoveTableColumnBackReferencesInFunctions) error
+	AddTriggerBackReferencesInFunctions(context.Context, AddTriggerBackReferencesInFunctions) error
+	RemoveTriggerBackReferencesInFunctions(context.Context, RemoveTriggerBackReferencesInFunctions) error
 	SetColumnName(context.Context, SetColumnName) error
 	SetIndexName(context.Context, SetIndexName) error
 	SetConstraintName(context.Context, SetConstraintName) error
@@ -318,6 +322,16 @@ func (op AddCheckConstraint) Visit(ctx context.Context, v ImmediateMutationVisit
 	return v.AddCheckConstraint(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTrigger(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op MakeAbsentColumnNotNullWriteOnly) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.MakeAbsentColumnNotNullWriteOnly(ctx, op)
@@ -483,6 +497,16 @@ func (op RemoveTableColumnBackReferencesInFunctions) Visit(ctx context.Context,
 	return v.RemoveTableColumnBackReferencesInFunctions(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTriggerBackReferencesInFunctions) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTriggerBackReferencesInFunctions(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTriggerBackReferencesInFunctions) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTriggerBackReferencesInFunctions(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op SetColumnName) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.SetColumnName(ctx, op)
@@ -792,6 +792,43 @@ func (c *ElementCollection[E]) FilterDatabaseRoleSetting() *ElementCollection[*D
 	return (*ElementCollection[*DatabaseRoleSetting])(ret)
 }
 
+func (e DomainType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainType) Element() Element {
+	return e.DomainType
+}
+
+// ForEachDomainType iterates over elements of type DomainType.
+// Deprecated
+func ForEachDomainType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  c.FilterDomainType().ForEach(fn)
+}
+
+// FindDomainType finds the first element of type DomainType.
+// Deprecated
+func FindDomainType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainType) {
+	if tc := c.FilterDomainType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainType)
+	}
+	return current, target, element
+}
+
+// DomainTypeElements filters elements of type DomainType.
+func (c *ElementCollection[E]) FilterDomainType() *ElementCollection[*DomainType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainType)
+		return ok
+	})
+	return (*ElementCollection[*DomainType])(ret)
+}
+
 func (e EnumType) element() {}
 
 // Element implements ElementGetter.
@@ -2309,6 +2346,43 @@ func (c *ElementCollection[E]) FilterTemporaryIndex() *ElementCollection[*Tempor
 	return (*ElementCollection[*TemporaryIndex])(ret)
 }
 
+func (e Trigger) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_Trigger) Element() Element {
+	return e.Trigger
+}
+
+// ForEachTrigger iterates over elements of type Trigger.
+// Deprecated
+func ForEachTrigger(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *Trigger),
+) {
+  c.FilterTrigger().ForEach(fn)
+}
+
+// FindTrigger finds the first element of type Trigger.
+// Deprecated
+func FindTrigger(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *Trigger) {
+	if tc := c.FilterTrigger(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*Trigger)
+	}
+	return current, target, element
+}
+
+// TriggerElements filters elements of type Trigger.
+func (c *ElementCollection[E]) FilterTrigger() *ElementCollection[*Trigger] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*Trigger)
+		return ok
+	})
+	return (*ElementCollection[*Trigger])(ret)
+}
+
 func (e UniqueWithoutIndexConstraint) element() {}
 
 // Element implements ElementGetter.
@@ -2504,6 +2578,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_DatabaseRegionConfig{ DatabaseRegionConfig: t}
 		case *DatabaseRoleSetting:
 			e.ElementOneOf = &ElementProto_DatabaseRoleSetting{ DatabaseRoleSetting: t}
+		case *DomainType:
+			e.ElementOneOf = &ElementProto_DomainType{ DomainType: t}
 		case *EnumType:
 			e.ElementOneOf = &ElementProto_EnumType{ EnumType: t}
 		case *EnumTypeValue:
@@ -2586,6 +2662,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_TableZoneConfig{ TableZoneConfig: t}
 		case *TemporaryIndex:
 			e.ElementOneOf = &ElementProto_TemporaryIndex{ TemporaryIndex: t}
+		case *Trigger:
+			e.ElementOneOf = &ElementProto_Trigger{ Trigger: t}
 		case *UniqueWithoutIndexConstraint:
 			e.ElementOneOf = &ElementProto_UniqueWithoutIndexConstraint{ UniqueWithoutIndexConstraint: t}
 		case *UniqueWithoutIndexConstraintUnvalidated:
@@ -2621,6 +2699,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_DatabaseData)(nil)),
 	((*ElementProto_DatabaseRegionConfig)(nil)),
 	((*ElementProto_DatabaseRoleSetting)(nil)),
+	((*ElementProto_DomainType)(nil)),
 	((*ElementProto_EnumType)(nil)),
 	((*ElementProto_EnumTypeValue)(nil)),
 	((*ElementProto_ForeignKeyConstraint)(nil)),
@@ -2662,6 +2741,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_TableSchemaLocked)(nil)),
 	((*ElementProto_TableZoneConfig)(nil)),
 	((*ElementProto_TemporaryIndex)(nil)),
+	((*ElementProto_Trigger)(nil)),
 	((*ElementProto_UniqueWithoutIndexConstraint)(nil)),
 	((*ElementProto_UniqueWithoutIndexConstraintUnvalidated)(nil)),
 	((*ElementProto_UserPrivileges)(nil)),
@@ -2694,6 +2774,7 @@ func GetElementTypes() []interface{} {
 	((*DatabaseData)(nil)),
 	((*DatabaseRegionConfig)(nil)),
 	((*DatabaseRoleSetting)(nil)),
+	((*DomainType)(nil)),
 	((*EnumType)(nil)),
 	((*EnumTypeValue)(nil)),
 	((*ForeignKeyConstraint)(nil)),
@@ -2735,6 +2816,7 @@ func GetElementTypes() []interface{} {
 	((*TableSchemaLocked)(nil)),
 	((*TableZoneConfig)(nil)),
 	((*TemporaryIndex)(nil)),
+	((*Trigger)(nil)),
 	((*UniqueWithoutIndexConstraint)(nil)),
 	((*UniqueWithoutIndexConstraintUnvalidated)(nil)),
 	((*UserPrivileges)(nil)),
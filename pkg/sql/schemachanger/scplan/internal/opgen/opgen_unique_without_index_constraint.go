@@ -28,11 +28,13 @@ func init() {
 						partialExpr = this.Predicate.Expr
 					}
 					return &scop.AddUniqueWithoutIndexConstraint{
-						TableID:      this.TableID,
-						ConstraintID: this.ConstraintID,
-						ColumnIDs:    this.ColumnIDs,
-						PartialExpr:  partialExpr,
-						Validity:     descpb.ConstraintValidity_Validating,
+						TableID:            this.TableID,
+						ConstraintID:       this.ConstraintID,
+						ColumnIDs:          this.ColumnIDs,
+						PartialExpr:        partialExpr,
+						Validity:           descpb.ConstraintValidity_Validating,
+						ExclusionOperators: this.ExclusionOperators,
+						ExclusionMethod:    this.ExclusionMethod,
 					}
 				}),
 				emit(func(this *scpb.UniqueWithoutIndexConstraint) *scop.UpdateTableBackReferencesInTypes {
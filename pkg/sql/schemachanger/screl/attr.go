@@ -138,6 +138,9 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.CompositeType)(nil)),
 		rel.EntityAttr(DescID, "TypeID"),
 	),
+	rel.EntityMapping(t((*scpb.DomainType)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
 	rel.EntityMapping(t((*scpb.CompositeTypeAttrName)(nil)),
 		rel.EntityAttr(DescID, "CompositeTypeID"),
 		rel.EntityAttr(Name, "Name"),
@@ -390,6 +393,12 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.TableSchemaLocked)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
+	rel.EntityMapping(t((*scpb.Trigger)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(Name, "Name"),
+		rel.EntityAttr(ReferencedDescID, "FunctionID"),
+		rel.EntityAttr(ReferencedColumnIDs, "ColumnIDs"),
+	),
 	rel.EntityMapping(t((*scpb.Function)(nil)),
 		rel.EntityAttr(DescID, "FunctionID"),
 	),
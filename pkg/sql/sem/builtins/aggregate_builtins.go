@@ -5409,3 +5409,175 @@ func (a *jsonObjectAggregate) Close(ctx context.Context) {
 func (a *jsonObjectAggregate) Size() int64 {
 	return sizeOfJSONObjectAggregate
 }
+
+// UserDefinedAggregate describes a user-defined aggregate function in terms of
+// expressions that evaluate its support functions. The expressions refer to
+// the aggregate state as the IndexedVar with index 0 and to the aggregated
+// arguments as the IndexedVars following it.
+type UserDefinedAggregate struct {
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// InitCond is the initial value of the state. It is DNull if the initial
+	// state is NULL.
+	InitCond tree.Datum
+	// Transition computes the new state from the current state and the
+	// arguments of an input row.
+	Transition tree.TypedExpr
+	// TransitionStrict is true if Transition should not be evaluated when any
+	// of the arguments is NULL.
+	TransitionStrict bool
+	// Final, if set, computes the result of the aggregate from the final state.
+	// Otherwise, the final state is the result.
+	Final tree.TypedExpr
+	// FinalStrict is true if Final should not be evaluated when the final
+	// state is NULL.
+	FinalStrict bool
+}
+
+// See NewUserDefinedAggregate.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	def      *UserDefinedAggregate
+	argTypes []*types.T
+	evalCtx  *eval.Context
+	// row contains the current state followed by the arguments of the input
+	// row being added. It serves as the IndexedVarContainer when evaluating
+	// the support function expressions.
+	row tree.Datums
+	// noState is true if the state is NULL because the initial state is NULL
+	// and no input row has been added yet. It is only used if the transition
+	// function is strict, in which case the first input that contains no NULLs
+	// becomes the state.
+	noState bool
+	// ctx is the context passed to the most recent call to Add or Reset. It is
+	// used when evaluating the final function in Result, which is not passed a
+	// context.
+	ctx context.Context
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+var _ eval.IndexedVarContainer = &userDefinedAggregate{}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+// NewUserDefinedAggregate returns an aggregate function that evaluates the
+// given user-defined aggregate over arguments of the given types. Like in
+// Postgres, the support functions are evaluated with the following semantics:
+//
+//   - if the transition function is strict, input rows that contain a NULL
+//     argument are skipped. If the initial state is NULL, the first remaining
+//     input becomes the state, and if the state becomes NULL afterward, all
+//     remaining rows are skipped.
+//   - if the final function is strict, the result is NULL when the final state
+//     is NULL.
+func NewUserDefinedAggregate(
+	evalCtx *eval.Context, def *UserDefinedAggregate, argTypes []*types.T,
+) eval.AggregateFunc {
+	a := &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		def:                      def,
+		argTypes:                 argTypes,
+		evalCtx:                  evalCtx,
+		row:                      make(tree.Datums, len(argTypes)+1),
+	}
+	a.init()
+	return a
+}
+
+func (a *userDefinedAggregate) init() {
+	for i := range a.row {
+		a.row[i] = tree.DNull
+	}
+	a.row[0] = a.def.InitCond
+	a.noState = a.def.InitCond == tree.DNull
+}
+
+// IndexedVarEval is part of the eval.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarEval(idx int) (tree.Datum, error) {
+	return a.row[idx], nil
+}
+
+// IndexedVarResolvedType is part of the tree.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarResolvedType(idx int) *types.T {
+	if idx == 0 {
+		return a.def.StateType
+	}
+	return a.argTypes[idx-1]
+}
+
+func (a *userDefinedAggregate) eval(ctx context.Context, expr tree.TypedExpr) (tree.Datum, error) {
+	a.evalCtx.PushIVarContainer(a)
+	defer a.evalCtx.PopIVarContainer()
+	return eval.Expr(ctx, a.evalCtx, expr)
+}
+
+func (a *userDefinedAggregate) setState(ctx context.Context, state tree.Datum) error {
+	a.row[0] = state
+	return a.updateMemoryUsage(ctx, int64(state.Size()))
+}
+
+// Add evaluates the transition function on the current state and the given
+// arguments.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.ctx = ctx
+	if len(a.row) > 1 {
+		a.row[1] = firstArg
+		copy(a.row[2:], otherArgs)
+	}
+	if a.def.TransitionStrict {
+		for _, arg := range a.row[1:] {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.noState {
+			// The first input becomes the state. CREATE AGGREGATE ensures that
+			// there is a single argument of the state type in this case.
+			a.noState = false
+			return a.setState(ctx, firstArg)
+		}
+		if a.row[0] == tree.DNull {
+			return nil
+		}
+	}
+	state, err := a.eval(ctx, a.def.Transition)
+	if err != nil {
+		return err
+	}
+	a.noState = false
+	return a.setState(ctx, state)
+}
+
+// Result evaluates the final function on the current state.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	state := a.row[0]
+	if a.def.Final == nil || (a.def.FinalStrict && state == tree.DNull) {
+		return state, nil
+	}
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return a.eval(ctx, a.def.Final)
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.ctx = ctx
+	a.init()
+	a.reset(ctx)
+}
+
+// Close allows the aggregate to release the memory it requested during
+// operation.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
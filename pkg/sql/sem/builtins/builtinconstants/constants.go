@@ -47,10 +47,12 @@ const (
 	CategoryGenerator           = "Set-returning"
 	CategoryTrigram             = "Trigrams"
 	CategoryFuzzyStringMatching = "Fuzzy String Matching"
+	CategoryGeometric           = "Geometric"
 	CategoryIDGeneration        = "ID generation"
 	CategoryJSON                = "JSONB"
 	CategoryMultiRegion         = "Multi-region"
 	CategoryMultiTenancy        = "Multi-tenancy"
+	CategoryRange               = "Range"
 	CategorySequences           = "Sequence"
 	CategorySpatial             = "Spatial"
 	CategoryString              = "String and byte"
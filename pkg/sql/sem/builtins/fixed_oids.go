@@ -2573,6 +2573,223 @@ var builtinOidsArray = []string{
 	2605: `merge_aggregated_stmt_metadata(arg1: jsonb) -> jsonb`,
 	2606: `crdb_internal.protect_mvcc_history(timestamp: decimal, expiration_window: interval, description: string) -> int`,
 	2607: `crdb_internal.extend_mvcc_history_protection(job_id: int) -> void`,
+	2608: `lower(range: anyrange) -> anyelement`,
+	2609: `lower(multirange: anymultirange) -> anyelement`,
+	2610: `upper(range: anyrange) -> anyelement`,
+	2611: `upper(multirange: anymultirange) -> anyelement`,
+	2612: `isempty(range: anyrange) -> bool`,
+	2613: `isempty(multirange: anymultirange) -> bool`,
+	2614: `lower_inc(range: anyrange) -> bool`,
+	2615: `lower_inc(multirange: anymultirange) -> bool`,
+	2616: `upper_inc(range: anyrange) -> bool`,
+	2617: `upper_inc(multirange: anymultirange) -> bool`,
+	2618: `lower_inf(range: anyrange) -> bool`,
+	2619: `lower_inf(multirange: anymultirange) -> bool`,
+	2620: `upper_inf(range: anyrange) -> bool`,
+	2621: `upper_inf(multirange: anymultirange) -> bool`,
+	2622: `range_merge(left: anyrange, right: anyrange) -> anyelement`,
+	2623: `range_merge(multirange: anymultirange) -> anyelement`,
+	2624: `multirange(range: anyrange) -> anyelement`,
+	2625: `int4range(lower: int4, upper: int4) -> int4range`,
+	2626: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2627: `int8range(lower: int, upper: int) -> int8range`,
+	2628: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2629: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2630: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2631: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2632: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2633: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2634: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2635: `daterange(lower: date, upper: date) -> daterange`,
+	2636: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2637: `int4multirange() -> int4multirange`,
+	2638: `int4multirange(int4range...) -> int4multirange`,
+	2639: `int8multirange() -> int8multirange`,
+	2640: `int8multirange(int8range...) -> int8multirange`,
+	2641: `nummultirange() -> nummultirange`,
+	2642: `nummultirange(numrange...) -> nummultirange`,
+	2643: `tsmultirange() -> tsmultirange`,
+	2644: `tsmultirange(tsrange...) -> tsmultirange`,
+	2645: `tstzmultirange() -> tstzmultirange`,
+	2646: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2647: `datemultirange() -> datemultirange`,
+	2648: `datemultirange(daterange...) -> datemultirange`,
+	2649: `anyrange_send(anyrange: anyrange) -> bytes`,
+	2650: `anyrange_out(anyrange: anyrange) -> bytes`,
+	2651: `anyrange_recv(input: anyelement) -> anyrange`,
+	2652: `anyrange_in(input: anyelement) -> anyrange`,
+	2653: `int4rangesend(int4range: int4range) -> bytes`,
+	2654: `int4rangeout(int4range: int4range) -> bytes`,
+	2655: `int4rangerecv(input: anyelement) -> int4range`,
+	2656: `int4rangein(input: anyelement) -> int4range`,
+	2657: `int8rangesend(int8range: int8range) -> bytes`,
+	2658: `int8rangeout(int8range: int8range) -> bytes`,
+	2659: `int8rangerecv(input: anyelement) -> int8range`,
+	2660: `int8rangein(input: anyelement) -> int8range`,
+	2661: `numrangesend(numrange: numrange) -> bytes`,
+	2662: `numrangeout(numrange: numrange) -> bytes`,
+	2663: `numrangerecv(input: anyelement) -> numrange`,
+	2664: `numrangein(input: anyelement) -> numrange`,
+	2665: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2666: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2667: `tsrangerecv(input: anyelement) -> tsrange`,
+	2668: `tsrangein(input: anyelement) -> tsrange`,
+	2669: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2670: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2671: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2672: `tstzrangein(input: anyelement) -> tstzrange`,
+	2673: `daterangesend(daterange: daterange) -> bytes`,
+	2674: `daterangeout(daterange: daterange) -> bytes`,
+	2675: `daterangerecv(input: anyelement) -> daterange`,
+	2676: `daterangein(input: anyelement) -> daterange`,
+	2677: `anymultirange_send(anymultirange: anymultirange) -> bytes`,
+	2678: `anymultirange_out(anymultirange: anymultirange) -> bytes`,
+	2679: `anymultirange_recv(input: anyelement) -> anymultirange`,
+	2680: `anymultirange_in(input: anyelement) -> anymultirange`,
+	2681: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2682: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2683: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2684: `int4multirangein(input: anyelement) -> int4multirange`,
+	2685: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2686: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2687: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2688: `int8multirangein(input: anyelement) -> int8multirange`,
+	2689: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2690: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2691: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2692: `nummultirangein(input: anyelement) -> nummultirange`,
+	2693: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2694: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2695: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2696: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2697: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2698: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2699: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2700: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2701: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2702: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2703: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2704: `datemultirangein(input: anyelement) -> datemultirange`,
+	2705: `point(x: float, y: float) -> point`,
+	2706: `line(p1: point, p2: point) -> line`,
+	2707: `lseg(p1: point, p2: point) -> lseg`,
+	2708: `box(corner1: point, corner2: point) -> box`,
+	2709: `circle(center: point, radius: float) -> circle`,
+	2710: `area(box: box) -> float`,
+	2711: `area(path: path) -> float`,
+	2712: `area(circle: circle) -> float`,
+	2713: `center(box: box) -> point`,
+	2714: `center(circle: circle) -> point`,
+	2715: `diameter(circle: circle) -> float`,
+	2716: `radius(circle: circle) -> float`,
+	2717: `height(box: box) -> float`,
+	2718: `width(box: box) -> float`,
+	2719: `npoints(path: path) -> int`,
+	2720: `npoints(polygon: polygon) -> int`,
+	2721: `isclosed(path: path) -> bool`,
+	2722: `isopen(path: path) -> bool`,
+	2723: `pclose(path: path) -> path`,
+	2724: `popen(path: path) -> path`,
+	2725: `point_send(point: point) -> bytes`,
+	2726: `point_out(point: point) -> bytes`,
+	2727: `point_recv(input: anyelement) -> point`,
+	2728: `point_in(input: anyelement) -> point`,
+	2729: `line_send(line: line) -> bytes`,
+	2730: `line_out(line: line) -> bytes`,
+	2731: `line_recv(input: anyelement) -> line`,
+	2732: `line_in(input: anyelement) -> line`,
+	2733: `lseg_send(lseg: lseg) -> bytes`,
+	2734: `lseg_out(lseg: lseg) -> bytes`,
+	2735: `lseg_recv(input: anyelement) -> lseg`,
+	2736: `lseg_in(input: anyelement) -> lseg`,
+	2737: `box_send(box: box) -> bytes`,
+	2738: `box_out(box: box) -> bytes`,
+	2739: `box_recv(input: anyelement) -> box`,
+	2740: `box_in(input: anyelement) -> box`,
+	2741: `path_send(path: path) -> bytes`,
+	2742: `path_out(path: path) -> bytes`,
+	2743: `path_recv(input: anyelement) -> path`,
+	2744: `path_in(input: anyelement) -> path`,
+	2745: `poly_send(polygon: polygon) -> bytes`,
+	2746: `poly_out(polygon: polygon) -> bytes`,
+	2747: `poly_recv(input: anyelement) -> polygon`,
+	2748: `poly_in(input: anyelement) -> polygon`,
+	2749: `circle_send(circle: circle) -> bytes`,
+	2750: `circle_out(circle: circle) -> bytes`,
+	2751: `circle_recv(input: anyelement) -> circle`,
+	2752: `circle_in(input: anyelement) -> circle`,
+	2753: `box(box: box) -> box`,
+	2754: `box(circle: circle) -> box`,
+	2755: `box(point: point) -> box`,
+	2756: `box(polygon: polygon) -> box`,
+	2757: `box(string: string) -> box`,
+	2758: `bpchar(box: box) -> char`,
+	2759: `bpchar(circle: circle) -> char`,
+	2760: `bpchar(line: line) -> char`,
+	2761: `bpchar(lseg: lseg) -> char`,
+	2762: `bpchar(path: path) -> char`,
+	2763: `bpchar(point: point) -> char`,
+	2764: `bpchar(polygon: polygon) -> char`,
+	2765: `char(box: box) -> "char"`,
+	2766: `char(circle: circle) -> "char"`,
+	2767: `char(line: line) -> "char"`,
+	2768: `char(lseg: lseg) -> "char"`,
+	2769: `char(path: path) -> "char"`,
+	2770: `char(point: point) -> "char"`,
+	2771: `char(polygon: polygon) -> "char"`,
+	2772: `circle(box: box) -> circle`,
+	2773: `circle(circle: circle) -> circle`,
+	2774: `circle(polygon: polygon) -> circle`,
+	2775: `circle(string: string) -> circle`,
+	2776: `geometry(path: path) -> geometry`,
+	2777: `geometry(point: point) -> geometry`,
+	2778: `geometry(polygon: polygon) -> geometry`,
+	2779: `line(line: line) -> line`,
+	2780: `line(string: string) -> line`,
+	2781: `lseg(box: box) -> lseg`,
+	2782: `lseg(lseg: lseg) -> lseg`,
+	2783: `lseg(string: string) -> lseg`,
+	2784: `name(box: box) -> name`,
+	2785: `name(circle: circle) -> name`,
+	2786: `name(line: line) -> name`,
+	2787: `name(lseg: lseg) -> name`,
+	2788: `name(path: path) -> name`,
+	2789: `name(point: point) -> name`,
+	2790: `name(polygon: polygon) -> name`,
+	2791: `path(geometry: geometry) -> path`,
+	2792: `path(path: path) -> path`,
+	2793: `path(polygon: polygon) -> path`,
+	2794: `path(string: string) -> path`,
+	2795: `point(box: box) -> point`,
+	2796: `point(circle: circle) -> point`,
+	2797: `point(geometry: geometry) -> point`,
+	2798: `point(lseg: lseg) -> point`,
+	2799: `point(path: path) -> point`,
+	2800: `point(point: point) -> point`,
+	2801: `point(polygon: polygon) -> point`,
+	2802: `point(string: string) -> point`,
+	2803: `polygon(box: box) -> polygon`,
+	2804: `polygon(circle: circle) -> polygon`,
+	2805: `polygon(geometry: geometry) -> polygon`,
+	2806: `polygon(path: path) -> polygon`,
+	2807: `polygon(polygon: polygon) -> polygon`,
+	2808: `polygon(string: string) -> polygon`,
+	2809: `text(box: box) -> string`,
+	2810: `text(circle: circle) -> string`,
+	2811: `text(line: line) -> string`,
+	2812: `text(lseg: lseg) -> string`,
+	2813: `text(path: path) -> string`,
+	2814: `text(point: point) -> string`,
+	2815: `text(polygon: polygon) -> string`,
+	2816: `varchar(box: box) -> varchar`,
+	2817: `varchar(circle: circle) -> varchar`,
+	2818: `varchar(line: line) -> varchar`,
+	2819: `varchar(lseg: lseg) -> varchar`,
+	2820: `varchar(path: path) -> varchar`,
+	2821: `varchar(point: point) -> varchar`,
+	2822: `varchar(polygon: polygon) -> varchar`,
+	2823: `crdb_internal.plpgsql_execute(query: string, args: tuple, strict: bool, resultTypes: anyelement) -> anyelement`,
+	2824: `pg_notify(channel: string, payload: string) -> void`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
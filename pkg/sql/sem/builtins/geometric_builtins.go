@@ -0,0 +1,220 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+func init() {
+	for k, v := range geometricBuiltins {
+		v.props.Category = builtinconstants.CategoryGeometric
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// geometricPointArg returns the point of the given point argument.
+func geometricPointArg(d tree.Datum) tree.GeometricPoint {
+	return tree.MustBeDGeometric(d).Points[0]
+}
+
+// makeGeometricPointsConstructor returns an overload constructing a value of
+// the given type from two points with fn.
+func makeGeometricPointsConstructor(
+	typ *types.T,
+	names [2]string,
+	info string,
+	fn func(p1, p2 tree.GeometricPoint) (*tree.DGeometric, error),
+) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: names[0], Typ: types.Point}, {Name: names[1], Typ: types.Point}},
+		ReturnType: tree.FixedReturnType(typ),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return fn(geometricPointArg(args[0]), geometricPointArg(args[1]))
+		},
+		Class:      tree.NormalClass,
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// geometricConstructorOverloads are the overloads of the constructor functions
+// of the geometric types, indexed by the oid of the type. The constructors are
+// named after the types, so the overloads are registered along with the cast
+// builtins, e.g. point(1, 2) along with point('(1,2)'::box).
+var geometricConstructorOverloads = map[oid.Oid][]tree.Overload{
+	oid.T_point: {{
+		Types:      tree.ParamTypes{{Name: "x", Typ: types.Float}, {Name: "y", Typ: types.Float}},
+		ReturnType: tree.FixedReturnType(types.Point),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return tree.NewDPoint(tree.GeometricPoint{
+				X: float64(tree.MustBeDFloat(args[0])),
+				Y: float64(tree.MustBeDFloat(args[1])),
+			}), nil
+		},
+		Class:      tree.NormalClass,
+		Info:       "Constructs a point from its coordinates.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_line: {makeGeometricPointsConstructor(
+		types.Line, [2]string{"p1", "p2"},
+		"Constructs the line going through the two given points.",
+		tree.NewDLineFromPoints,
+	)},
+	oid.T_lseg: {makeGeometricPointsConstructor(
+		types.LSeg, [2]string{"p1", "p2"},
+		"Constructs the line segment between the two given points.",
+		func(p1, p2 tree.GeometricPoint) (*tree.DGeometric, error) {
+			return tree.NewDLSeg(p1, p2), nil
+		},
+	)},
+	oid.T_box: {makeGeometricPointsConstructor(
+		types.Box, [2]string{"corner1", "corner2"},
+		"Constructs the box with the two given opposite corners.",
+		func(p1, p2 tree.GeometricPoint) (*tree.DGeometric, error) {
+			return tree.NewDBox(p1, p2), nil
+		},
+	)},
+	oid.T_circle: {{
+		Types:      tree.ParamTypes{{Name: "center", Typ: types.Point}, {Name: "radius", Typ: types.Float}},
+		ReturnType: tree.FixedReturnType(types.Circle),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return tree.NewDCircle(geometricPointArg(args[0]), float64(tree.MustBeDFloat(args[1])))
+		},
+		Class:      tree.NormalClass,
+		Info:       "Constructs a circle from its center and its radius.",
+		Volatility: volatility.Immutable,
+	}},
+}
+
+// makeGeometricAccessorBuiltin returns a builtin with an overload for each of
+// the given geometric types, which are all evaluated with fn.
+func makeGeometricAccessorBuiltin(
+	typs []*types.T, retType *types.T, info string, fn func(g *tree.DGeometric) tree.Datum,
+) builtinDefinition {
+	overloads := make([]tree.Overload, len(typs))
+	for i, typ := range typs {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: typ.Name(), Typ: typ}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(tree.MustBeDGeometric(args[0])), nil
+			},
+			Info:       fmt.Sprintf(info, typ.Name()),
+			Volatility: volatility.Immutable,
+		}
+	}
+	return makeBuiltin(defProps(), overloads...)
+}
+
+var geometricBuiltins = map[string]builtinDefinition{
+	"area": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Box, types.Path, types.Circle},
+		types.Float,
+		"Returns the area of the given %s, or NULL for an open path.",
+		func(g *tree.DGeometric) tree.Datum {
+			area, ok := g.Area()
+			if !ok {
+				return tree.DNull
+			}
+			return tree.NewDFloat(tree.DFloat(area))
+		},
+	),
+	"center": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Box, types.Circle},
+		types.Point,
+		"Returns the center of the given %s.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDPoint(g.Center())
+		},
+	),
+	"diameter": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Circle},
+		types.Float,
+		"Returns the diameter of the given %s.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDFloat(tree.DFloat(2 * g.Radius))
+		},
+	),
+	"radius": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Circle},
+		types.Float,
+		"Returns the radius of the given %s.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDFloat(tree.DFloat(g.Radius))
+		},
+	),
+	"height": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Box},
+		types.Float,
+		"Returns the vertical size of the given %s.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDFloat(tree.DFloat(g.Points[0].Y - g.Points[1].Y))
+		},
+	),
+	"width": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Box},
+		types.Float,
+		"Returns the horizontal size of the given %s.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDFloat(tree.DFloat(g.Points[0].X - g.Points[1].X))
+		},
+	),
+	"npoints": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Path, types.Polygon},
+		types.Int,
+		"Returns the number of points of the given %s.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDInt(tree.DInt(len(g.Points)))
+		},
+	),
+	"isclosed": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Path},
+		types.Bool,
+		"Returns whether the given %s is closed.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.MakeDBool(tree.DBool(g.Closed))
+		},
+	),
+	"isopen": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Path},
+		types.Bool,
+		"Returns whether the given %s is open.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!g.Closed))
+		},
+	),
+	"pclose": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Path},
+		types.Path,
+		"Converts the given %s to a closed path.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDPath(g.Points, true /* closed */)
+		},
+	),
+	"popen": makeGeometricAccessorBuiltin(
+		[]*types.T{types.Path},
+		types.Path,
+		"Converts the given %s to an open path.",
+		func(g *tree.DGeometric) tree.Datum {
+			return tree.NewDPath(g.Points, false /* closed */)
+		},
+	),
+}
@@ -69,24 +69,33 @@ func makeNotUsableFalseBuiltin() builtinDefinition {
 // programmatically determine whether or not this underscore is present, hence
 // the existence of this map.
 var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
-	types.Any.Oid():         {},
-	types.AnyArray.Oid():    {},
-	types.Date.Oid():        {},
-	types.Time.Oid():        {},
-	types.TimeTZ.Oid():      {},
-	types.Decimal.Oid():     {},
-	types.Interval.Oid():    {},
-	types.Json.Oid():        {},
-	types.Jsonb.Oid():       {},
-	types.Uuid.Oid():        {},
-	types.VarBit.Oid():      {},
-	types.Geometry.Oid():    {},
-	types.Geography.Oid():   {},
-	types.Box2D.Oid():       {},
-	oid.T_bit:               {},
-	types.Timestamp.Oid():   {},
-	types.TimestampTZ.Oid(): {},
-	types.AnyTuple.Oid():    {},
+	types.Any.Oid():           {},
+	types.AnyArray.Oid():      {},
+	types.Date.Oid():          {},
+	types.Time.Oid():          {},
+	types.TimeTZ.Oid():        {},
+	types.Decimal.Oid():       {},
+	types.Interval.Oid():      {},
+	types.Json.Oid():          {},
+	types.Jsonb.Oid():         {},
+	types.Uuid.Oid():          {},
+	types.VarBit.Oid():        {},
+	types.Geometry.Oid():      {},
+	types.Geography.Oid():     {},
+	types.Box2D.Oid():         {},
+	oid.T_bit:                 {},
+	types.Timestamp.Oid():     {},
+	types.TimestampTZ.Oid():   {},
+	types.AnyTuple.Oid():      {},
+	types.AnyRange.Oid():      {},
+	types.AnyMultiRange.Oid(): {},
+	types.Point.Oid():         {},
+	types.Line.Oid():          {},
+	types.LSeg.Oid():          {},
+	types.Box.Oid():           {},
+	types.Path.Oid():          {},
+	types.Polygon.Oid():       {},
+	types.Circle.Oid():        {},
 }
 
 // PGIOBuiltinPrefix returns the string prefix to a type's IO functions. This
@@ -94,6 +103,10 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 // name plus an underscore, depending on the type.
 func PGIOBuiltinPrefix(typ *types.T) string {
 	builtinPrefix := typ.PGName()
+	if typ.Oid() == oid.T_polygon {
+		// The i/o builtins of polygons are abbreviated, e.g. poly_in.
+		builtinPrefix = "poly"
+	}
 	if _, ok := typeBuiltinsHaveUnderscore[typ.Oid()]; ok {
 		return builtinPrefix + "_"
 	}
@@ -202,6 +215,9 @@ func init() {
 		)
 	}
 	for toOID, def := range castBuiltins {
+		// The constructors of the geometric types are named after the types,
+		// like the casts to the types.
+		def.overloads = append(def.overloads, geometricConstructorOverloads[toOID]...)
 		n := cast.CastTypeName(types.OidToType[toOID])
 		CastBuiltinNames[n] = struct{}{}
 		registerBuiltin(n, *def, tree.NormalClass, enforceClass)
@@ -1358,6 +1374,35 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "channel", Typ: types.String},
+				{Name: "payload", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(types.Void),
+			CalledOnNullInput: true,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.Planner.SendNotification(ctx, channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification with the given payload to the sessions listening " +
+				"on the given channel. The notification is only sent if the current " +
+				"transaction commits.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	// pg_is_in_recovery returns true if the Postgres database is currently in
 	// recovery.  This is not applicable so this can always return false.
 	// https://www.postgresql.org/docs/current/static/functions-admin.html#FUNCTIONS-RECOVERY-INFO-TABLE
@@ -0,0 +1,311 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+	for i, rangeTyp := range types.RangeTypes {
+		multiRangeTyp := types.MultiRangeTypes[i]
+		for k, v := range map[string]builtinDefinition{
+			rangeTyp.Name():      makeRangeConstructorBuiltin(rangeTyp),
+			multiRangeTyp.Name(): makeMultiRangeConstructorBuiltin(multiRangeTyp),
+		} {
+			v.props.Category = builtinconstants.CategoryRange
+			const enforceClass = true
+			registerBuiltin(k, v, tree.NormalClass, enforceClass)
+		}
+	}
+}
+
+// rangeSubtypeReturnType returns the subtype of the range or multirange
+// argument at the given index.
+func rangeSubtypeReturnType(idx int) tree.ReturnTyper {
+	return func(args []tree.TypedExpr) *types.T {
+		if len(args) <= idx {
+			return tree.UnknownReturnType
+		}
+		t := args[idx].ResolvedType()
+		if t.Family() == types.MultiRangeFamily {
+			t = t.MultiRangeContents()
+		}
+		if t.Family() != types.RangeFamily {
+			return tree.UnknownReturnType
+		}
+		return t.RangeContents()
+	}
+}
+
+// rangeOrSpan returns the given range, or the span of the given multirange.
+func rangeOrSpan(d tree.Datum) *tree.DRange {
+	if mr, ok := d.(*tree.DMultiRange); ok {
+		return mr.Span()
+	}
+	return tree.MustBeDRange(d)
+}
+
+// makeRangeAccessorOverloads returns an overload for anyrange and one for
+// anymultirange, which are both evaluated with fn. The multirange is passed to
+// fn as the smallest range containing all of its values.
+func makeRangeAccessorOverloads(
+	retType tree.ReturnTyper, info string, fn func(r *tree.DRange) tree.Datum,
+) []tree.Overload {
+	makeOverload := func(paramTyp *types.T, kind string) tree.Overload {
+		return tree.Overload{
+			Types:      tree.ParamTypes{{Name: kind, Typ: paramTyp}},
+			ReturnType: retType,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(rangeOrSpan(args[0])), nil
+			},
+			Info:       fmt.Sprintf(info, kind),
+			Volatility: volatility.Immutable,
+		}
+	}
+	return []tree.Overload{
+		makeOverload(types.AnyRange, "range"),
+		makeOverload(types.AnyMultiRange, "multirange"),
+	}
+}
+
+func makeRangeAccessorBuiltin(
+	retType tree.ReturnTyper, info string, fn func(r *tree.DRange) tree.Datum,
+) builtinDefinition {
+	return makeBuiltin(defProps(), makeRangeAccessorOverloads(retType, info, fn)...)
+}
+
+// rangeLowerOverloads are the overloads of the lower builtin for ranges and
+// multiranges. The overload for strings is defined along with the other string
+// builtins.
+var rangeLowerOverloads = makeRangeAccessorOverloads(
+	rangeSubtypeReturnType(0),
+	"Returns the lower bound of the given %s, or NULL if it is empty or the lower bound is infinite.",
+	func(r *tree.DRange) tree.Datum {
+		if r.Empty || r.Lower.IsInfinite() {
+			return tree.DNull
+		}
+		return r.Lower.Val
+	},
+)
+
+// rangeUpperOverloads are the overloads of the upper builtin for ranges and
+// multiranges.
+var rangeUpperOverloads = makeRangeAccessorOverloads(
+	rangeSubtypeReturnType(0),
+	"Returns the upper bound of the given %s, or NULL if it is empty or the upper bound is infinite.",
+	func(r *tree.DRange) tree.Datum {
+		if r.Empty || r.Upper.IsInfinite() {
+			return tree.DNull
+		}
+		return r.Upper.Val
+	},
+)
+
+// rangeBoundFlags maps the bounds argument of the range constructors to
+// whether the lower and upper bounds are inclusive.
+var rangeBoundFlags = map[string][2]bool{
+	"()": {false, false},
+	"(]": {false, true},
+	"[)": {true, false},
+	"[]": {true, true},
+}
+
+// makeRangeFromArgs constructs a range of the given type from the arguments of
+// a range constructor. NULL bounds are infinite.
+func makeRangeFromArgs(typ *types.T, lower, upper tree.Datum, bounds string) (tree.Datum, error) {
+	flags, ok := rangeBoundFlags[bounds]
+	if !ok {
+		return nil, errors.WithHint(
+			pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+			`Valid values are "[]", "[)", "(]", and "()".`,
+		)
+	}
+	l := tree.RangeBound{Inclusive: flags[0]}
+	if lower != tree.DNull {
+		l.Val = lower
+	}
+	u := tree.RangeBound{Inclusive: flags[1]}
+	if upper != tree.DNull {
+		u.Val = upper
+	}
+	return tree.NewDRange(typ, l, u)
+}
+
+// makeRangeConstructorBuiltin returns the constructor function of the given
+// range type, e.g. int4range(1, 10, '[]').
+func makeRangeConstructorBuiltin(typ *types.T) builtinDefinition {
+	subtyp := typ.RangeContents()
+	return makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types:             tree.ParamTypes{{Name: "lower", Typ: subtyp}, {Name: "upper", Typ: subtyp}},
+			ReturnType:        tree.FixedReturnType(typ),
+			CalledOnNullInput: true,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return makeRangeFromArgs(typ, args[0], args[1], "[)")
+			},
+			Info: "Constructs a range with the given inclusive lower bound and exclusive upper " +
+				"bound. A NULL bound means that the range is unbounded on that side.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: subtyp}, {Name: "upper", Typ: subtyp}, {Name: "bounds", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(typ),
+			CalledOnNullInput: true,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.DataException,
+						"range constructor flags argument must not be null")
+				}
+				return makeRangeFromArgs(typ, args[0], args[1], string(tree.MustBeDString(args[2])))
+			},
+			Info: "Constructs a range with the given bounds. The bounds argument is one of " +
+				"'()', '(]', '[)' or '[]' and specifies whether the bounds are inclusive. A NULL " +
+				"bound means that the range is unbounded on that side.",
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeMultiRangeConstructorBuiltin returns the constructor function of the
+// given multirange type, e.g. int4multirange(int4range(1, 3), int4range(5, 7)).
+func makeMultiRangeConstructorBuiltin(typ *types.T) builtinDefinition {
+	return makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return tree.NewDMultiRange(typ, nil /* ranges */), nil
+			},
+			Info:       "Constructs an empty multirange.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.VariadicType{VarType: typ.MultiRangeContents()},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ranges := make([]*tree.DRange, len(args))
+				for i := range args {
+					ranges[i] = tree.MustBeDRange(args[i])
+				}
+				return tree.NewDMultiRange(typ, ranges), nil
+			},
+			Info:       "Constructs a multirange containing the values of the given ranges.",
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeRangeAccessorBuiltin(
+		tree.FixedReturnType(types.Bool),
+		"Returns whether the given %s is empty.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(r.Empty))
+		},
+	),
+	"lower_inc": makeRangeAccessorBuiltin(
+		tree.FixedReturnType(types.Bool),
+		"Returns whether the lower bound of the given %s is inclusive.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.Inclusive))
+		},
+	),
+	"upper_inc": makeRangeAccessorBuiltin(
+		tree.FixedReturnType(types.Bool),
+		"Returns whether the upper bound of the given %s is inclusive.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.Inclusive))
+		},
+	),
+	"lower_inf": makeRangeAccessorBuiltin(
+		tree.FixedReturnType(types.Bool),
+		"Returns whether the lower bound of the given %s is infinite.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.IsInfinite()))
+		},
+	),
+	"upper_inf": makeRangeAccessorBuiltin(
+		tree.FixedReturnType(types.Bool),
+		"Returns whether the upper bound of the given %s is infinite.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.IsInfinite()))
+		},
+	),
+	"range_merge": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.AnyRange}, {Name: "right", Typ: types.AnyRange}},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDRange(args[0]), tree.MustBeDRange(args[1])
+				if l.ResolvedType().Oid() != r.ResolvedType().Oid() {
+					return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+						"range types %s and %s do not match",
+						l.ResolvedType().SQLStringForError(), r.ResolvedType().SQLStringForError())
+				}
+				return l.Merge(r), nil
+			},
+			Info:       "Returns the smallest range which contains both of the given ranges.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{{Name: "multirange", Typ: types.AnyMultiRange}},
+			ReturnType: func(args []tree.TypedExpr) *types.T {
+				if len(args) == 0 || args[0].ResolvedType().Family() != types.MultiRangeFamily {
+					return tree.UnknownReturnType
+				}
+				return args[0].ResolvedType().MultiRangeContents()
+			},
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MustBeDMultiRange(args[0]).Span(), nil
+			},
+			Info:       "Returns the smallest range which contains all of the values of the given multirange.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"multirange": makeBuiltin(defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{{Name: "range", Typ: types.AnyRange}},
+			ReturnType: func(args []tree.TypedExpr) *types.T {
+				if len(args) == 0 || args[0].ResolvedType().Family() != types.RangeFamily {
+					return tree.UnknownReturnType
+				}
+				return types.MakeMultiRange(args[0].ResolvedType())
+			},
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				r := tree.MustBeDRange(args[0])
+				return tree.NewDMultiRange(types.MakeMultiRange(r.ResolvedType()), []*tree.DRange{r}), nil
+			},
+			Info:       "Returns a multirange containing only the given range.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
@@ -213,6 +213,14 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs, so they can't be populated in castMap. Like in
+	// Postgres, a cast to or from a domain is valid if the cast to or from its
+	// base type is valid. The constraints of a target domain are checked when
+	// the cast is evaluated.
+	if src.IsDomain() || tgt.IsDomain() {
+		return LookupCast(src.DomainBaseType(), tgt.DomainBaseType())
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
@@ -283,6 +291,33 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Casts between string types and range or multirange types use the text
+	// representation of the range, which depends on the subtype. Like in
+	// Postgres, they are stable.
+	if srcFamily == types.StringFamily &&
+		(tgtFamily == types.RangeFamily || tgtFamily == types.MultiRangeFamily) {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if (srcFamily == types.RangeFamily || srcFamily == types.MultiRangeFamily) &&
+		tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+
+	// A range can be cast to the multirange of the same range type.
+	if srcFamily == types.RangeFamily && tgtFamily == types.MultiRangeFamily &&
+		tgt.MultiRangeContents().Oid() == src.Oid() {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Immutable,
+		}, true
+	}
+
 	// Casts from int types to bit and varbit types are allowed only if the the
 	// length of the bit or varbit is defined
 	if srcFamily == types.IntFamily &&
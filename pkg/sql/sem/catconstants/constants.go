@@ -101,6 +101,8 @@ const (
 	MVCCStatistics                         SystemTableName = "mvcc_statistics"
 	StmtExecInsightsTableName              SystemTableName = "statement_execution_insights"
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
+	NotificationsTableName                 SystemTableName = "notifications"
+	ReplicationSlotsTableName              SystemTableName = "replication_slots"
 )
 
 // Oid for virtual database and table.
@@ -430,7 +432,8 @@ const (
 	PgExtensionGeographyColumnsTableID
 	PgExtensionGeometryColumnsTableID
 	PgExtensionSpatialRefSysTableID
-	MinVirtualID = PgExtensionSpatialRefSysTableID
+	CrdbInternalNodePLpgSQLStmtStatsTableID
+	MinVirtualID = CrdbInternalNodePLpgSQLStmtStatsTableID
 )
 
 // ConstraintType is used to identify the type of a constraint.
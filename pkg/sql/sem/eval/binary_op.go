@@ -323,6 +323,95 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeContains(a, b))), nil
+}
+
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeContains(b, a))), nil
+}
+
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeOverlaps(left, right))), nil
+}
+
+func (e *evaluator) EvalAdjacentRangeOp(
+	ctx context.Context, _ *tree.AdjacentRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.RangeAdjacent(left, right))), nil
+}
+
+func (e *evaluator) EvalUnionRangeOp(
+	ctx context.Context, _ *tree.UnionRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	if l, ok := left.(*tree.DRange); ok {
+		return l.Union(tree.MustBeDRange(right))
+	}
+	return tree.MustBeDMultiRange(left).Union(tree.MustBeDMultiRange(right)), nil
+}
+
+func (e *evaluator) EvalIntersectRangeOp(
+	ctx context.Context, _ *tree.IntersectRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	if l, ok := left.(*tree.DRange); ok {
+		return l.Intersect(tree.MustBeDRange(right)), nil
+	}
+	return tree.MustBeDMultiRange(left).Intersect(tree.MustBeDMultiRange(right)), nil
+}
+
+func (e *evaluator) EvalDifferenceRangeOp(
+	ctx context.Context, _ *tree.DifferenceRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	if l, ok := left.(*tree.DRange); ok {
+		return l.Difference(tree.MustBeDRange(right))
+	}
+	return tree.MustBeDMultiRange(left).Difference(tree.MustBeDMultiRange(right)), nil
+}
+
+func (e *evaluator) EvalSameGeometricOp(
+	ctx context.Context, _ *tree.SameGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	same := tree.GeometricSame(tree.MustBeDGeometric(left), tree.MustBeDGeometric(right))
+	return tree.MakeDBool(tree.DBool(same)), nil
+}
+
+func (e *evaluator) EvalDistanceGeometricOp(
+	ctx context.Context, _ *tree.DistanceGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	dist, err := tree.GeometricDistance(tree.MustBeDGeometric(left), tree.MustBeDGeometric(right))
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDFloat(tree.DFloat(dist)), nil
+}
+
+func (e *evaluator) EvalContainsGeometricOp(
+	ctx context.Context, _ *tree.ContainsGeometricOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	c := tree.GeometricContains(tree.MustBeDGeometric(a), tree.MustBeDGeometric(b))
+	return tree.MakeDBool(tree.DBool(c)), nil
+}
+
+func (e *evaluator) EvalContainedByGeometricOp(
+	ctx context.Context, _ *tree.ContainedByGeometricOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	c := tree.GeometricContains(tree.MustBeDGeometric(b), tree.MustBeDGeometric(a))
+	return tree.MakeDBool(tree.DBool(c)), nil
+}
+
+func (e *evaluator) EvalOverlapsGeometricOp(
+	ctx context.Context, _ *tree.OverlapsGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	o := tree.GeometricOverlaps(tree.MustBeDGeometric(left), tree.MustBeDGeometric(right))
+	return tree.MakeDBool(tree.DBool(o)), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
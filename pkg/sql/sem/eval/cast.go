@@ -101,6 +101,18 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	if t.IsDomain() {
+		// A cast to a domain is a cast to its base type followed by a check of
+		// the constraints of the domain.
+		d, err := performCast(ctx, evalCtx, d, t.DomainBaseType(), truncateWidth)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkDomainConstraints(ctx, evalCtx, d, t); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -460,7 +472,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange, *tree.DMultiRange, *tree.DGeometric:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -714,6 +726,12 @@ func performCastWithoutPrecisionTruncation(
 				return nil, err
 			}
 			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DGeometric:
+			g, err := d.AsGeometry()
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
 		case *tree.DBytes:
 			g, err := geo.ParseGeometryFromEWKB(geopb.EWKB(*d))
 			if err != nil {
@@ -920,6 +938,41 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return &tree.DTSVector{TSVector: vec}, nil
 		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, v.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return d, nil
+		}
+	case types.MultiRangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultiRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDMultiRangeFromString(evalCtx, v.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return tree.NewDMultiRange(t, []*tree.DRange{v}), nil
+		case *tree.DMultiRange:
+			return d, nil
+		}
+	case types.GeometricFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDGeometric(string(*v), t)
+		case *tree.DCollatedString:
+			return tree.ParseDGeometric(v.Contents, t)
+		case *tree.DGeometric:
+			return tree.ConvertGeometric(v, t)
+		case *tree.DGeometry:
+			return tree.NewDGeometricFromGeometry(v.Geometry, t)
+		}
 	case types.ArrayFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -450,6 +450,13 @@ func (p *fakePlannerWithMonitor) PLpgSQLFetchCursor(
 	return nil, nil
 }
 
+// PLpgSQLExecute is part of the eval.Planner interface.
+func (p *fakePlannerWithMonitor) PLpgSQLExecute(
+	ctx context.Context, query string, args *tree.DTuple, into, strict bool,
+) (res tree.Datums, err error) {
+	return nil, nil
+}
+
 // AutoCommit is part of the eval.Planner interface.
 func (p *fakePlannerWithMonitor) AutoCommit() bool {
 	return false
@@ -347,6 +347,22 @@ type Planner interface {
 	// for the current transaction.
 	IsConstraintActive(ctx context.Context, tableID int, constraintName string) (bool, error)
 
+	// CanDeferConstraintChecks returns whether the checks of deferrable
+	// constraints can be postponed in the current context. If it returns false,
+	// the checks must be performed immediately.
+	CanDeferConstraintChecks() bool
+
+	// DeferConstraintCheck postpones the check of the given key for the given
+	// deferrable constraint on the given table until the end of the current
+	// transaction, or until the constraint is made immediate with SET
+	// CONSTRAINTS. The key holds the values of the constrained columns of a row
+	// that violated the constraint when the statement was executed.
+	DeferConstraintCheck(ctx context.Context, tableID int, constraintName string, key tree.Datums)
+
+	// SendNotification sends a notification on the given channel, which is
+	// delivered to the listening sessions if the current transaction commits.
+	SendNotification(ctx context.Context, channel, payload string) error
+
 	// ValidateTTLScheduledJobsInCurrentDB checks scheduled jobs for each table
 	// in the database maps to a scheduled job.
 	ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error
@@ -432,6 +448,15 @@ type Planner interface {
 	// PLpgSQL FETCH statement.
 	PLpgSQLFetchCursor(ctx context.Context, cursor *tree.CursorStmt) (res tree.Datums, err error)
 
+	// PLpgSQLExecute plans and executes the given SQL string, using the elements
+	// of args as the values of its placeholders. It is used to implement the
+	// PLpgSQL EXECUTE statement. If into is true, the first row of the result is
+	// returned, or nil if there are no rows. If strict is also true, an error is
+	// returned unless the query returns exactly one row.
+	PLpgSQLExecute(
+		ctx context.Context, query string, args *tree.DTuple, into, strict bool,
+	) (res tree.Datums, err error)
+
 	// AutoCommit indicates whether the Planner has flagged the current statement
 	// as eligible for transaction auto-commit.
 	AutoCommit() bool
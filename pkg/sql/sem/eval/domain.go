@@ -0,0 +1,79 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// checkDomainConstraints returns an error if the given datum, which must be of
+// the base type of the domain type t, violates the NOT NULL or CHECK
+// constraints of t. Like in Postgres, the CHECK constraints are evaluated for
+// NULL values too, and a constraint that evaluates to NULL is satisfied.
+func checkDomainConstraints(ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T) error {
+	md := t.TypeMeta.DomainData
+	if md == nil {
+		return errors.AssertionFailedf("domain %s is not hydrated", t.SQLStringForError())
+	}
+	if d == tree.DNull && md.NotNull {
+		return pgerror.Newf(pgcode.NotNullViolation,
+			"domain %s does not allow null values", t.Name())
+	}
+	if len(md.CheckConstraints) == 0 {
+		return nil
+	}
+	// The CHECK constraints refer to the value being checked with the ordinal
+	// reference @1.
+	evalCtx.PushIVarContainer(&domainValueContainer{d: d, typ: t.DomainBaseType()})
+	defer evalCtx.PopIVarContainer()
+	for i := range md.CheckConstraints {
+		c := &md.CheckConstraints[i]
+		typedExpr, ok := c.TypedExpr.(tree.TypedExpr)
+		if !ok {
+			return errors.AssertionFailedf(
+				"CHECK constraint %q of domain %s is not type checked", c.Name, t.Name())
+		}
+		res, err := Expr(ctx, evalCtx, typedExpr)
+		if err != nil {
+			return err
+		}
+		if res == tree.DBoolFalse {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"value for domain %s violates check constraint %q", t.Name(), c.Name)
+		}
+	}
+	return nil
+}
+
+// domainValueContainer is an IndexedVarContainer for the value checked by the
+// CHECK constraints of a domain.
+type domainValueContainer struct {
+	d   tree.Datum
+	typ *types.T
+}
+
+var _ IndexedVarContainer = &domainValueContainer{}
+
+// IndexedVarEval implements the IndexedVarContainer interface.
+func (c *domainValueContainer) IndexedVarEval(idx int) (tree.Datum, error) {
+	return c.d, nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c *domainValueContainer) IndexedVarResolvedType(idx int) *types.T {
+	return c.typ
+}
@@ -182,9 +182,12 @@ func (e *evaluator) EvalCastExpr(ctx context.Context, expr *tree.CastExpr) (tree
 		return nil, err
 	}
 
-	// NULL cast to anything is NULL.
+	// NULL cast to anything is NULL, unless the target is a domain that does
+	// not allow NULL values.
 	if d == tree.DNull {
-		return d, nil
+		if typ, ok := expr.Type.(*types.T); !ok || !typ.IsDomain() {
+			return d, nil
+		}
 	}
 	d = UnwrapDatum(ctx, e.ctx(), d)
 	return PerformCast(ctx, e.ctx(), d, expr.ResolvedType())
@@ -46,5 +46,13 @@ func (tc *unsupportedTypeChecker) CheckType(ctx context.Context, typ *types.T) e
 			"%s not supported until version 23.2", errorTypeString,
 		)
 	}
+	switch typ.Family() {
+	case types.RangeFamily, types.MultiRangeFamily, types.GeometricFamily:
+		if !tc.version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s not supported until version 24.1", typ.SQLStandardName(),
+			)
+		}
+	}
 	return nil
 }
@@ -16,7 +16,6 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 type Expr = tree.Expr
@@ -25,6 +24,7 @@ type Statement interface {
 	tree.NodeFormatter
 	GetLineNo() int
 	GetStmtID() uint
+	SetPosition(lineNo int, stmtID uint)
 	plpgsqlStmt()
 	WalkStmt(StatementVisitor) Statement
 }
@@ -34,14 +34,15 @@ type TaggedStatement interface {
 }
 
 type StatementImpl struct {
-	// TODO(drewk): figure out how to get line number from scanner.
+	// LineNo is the line number of the first token of the statement, starting
+	// from 1 at the beginning of the routine body. It is 0 for statements that
+	// were not produced by the parser.
 	LineNo int
 	/*
 	 * Unique statement ID in this function (starting at 1; 0 is invalid/not
 	 * set).  This can be used by a profiler as the index for an array of
 	 * per-statement metrics.
 	 */
-	// TODO(drewk): figure out how to get statement id from parser.
 	StmtID uint
 }
 
@@ -53,6 +54,13 @@ func (s *StatementImpl) GetStmtID() uint {
 	return s.StmtID
 }
 
+// SetPosition sets the line number and the ID of the statement. It is used by
+// the parser.
+func (s *StatementImpl) SetPosition(lineNo int, stmtID uint) {
+	s.LineNo = lineNo
+	s.StmtID = stmtID
+}
+
 func (s *StatementImpl) plpgsqlStmt() {}
 
 // pl_block
@@ -189,6 +197,55 @@ func (s *Declaration) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// PercentTypeReference is the type of a variable that is declared with the
+// name%TYPE syntax, which copies the type of a table column or of another
+// variable. It is resolved when the routine body is built.
+type PercentTypeReference struct {
+	Name *tree.UnresolvedName
+}
+
+var _ tree.ResolvableTypeReference = &PercentTypeReference{}
+var _ tree.NodeFormatter = &PercentTypeReference{}
+
+// SQLString implements the tree.ResolvableTypeReference interface.
+func (r *PercentTypeReference) SQLString() string {
+	return tree.AsStringWithFlags(r, tree.FmtParsable)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (r *PercentTypeReference) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(r.Name)
+	ctx.WriteString("%TYPE")
+}
+
+// AliasDeclaration declares a new name for an existing variable.
+type AliasDeclaration struct {
+	StatementImpl
+	Var    Variable
+	Target Variable
+}
+
+func (s *AliasDeclaration) CopyNode() *AliasDeclaration {
+	copyNode := *s
+	return &copyNode
+}
+
+func (s *AliasDeclaration) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" ALIAS FOR ")
+	ctx.FormatNode(&s.Target)
+	ctx.WriteString(";\n")
+}
+
+func (s *AliasDeclaration) PlpgSQLStatementTag() string {
+	return "decl_alias"
+}
+
+func (s *AliasDeclaration) WalkStmt(visitor StatementVisitor) Statement {
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
+}
+
 type CursorDeclaration struct {
 	StatementImpl
 	Name   Variable
@@ -226,7 +283,10 @@ func (s *CursorDeclaration) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_assign
 type Assignment struct {
 	StatementImpl
-	Var   Variable
+	Var Variable
+	// Field is set when the assignment targets a single field of a composite
+	// variable, as in "r.x := 1".
+	Field tree.Name
 	Value Expr
 }
 
@@ -241,6 +301,10 @@ func (s *Assignment) PlpgSQLStatementTag() string {
 
 func (s *Assignment) Format(ctx *tree.FmtCtx) {
 	ctx.FormatNode(&s.Var)
+	if s.Field != "" {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&s.Field)
+	}
 	ctx.WriteString(" := ")
 	ctx.FormatNode(s.Value)
 	ctx.WriteString(";\n")
@@ -606,107 +670,167 @@ func (s *While) WalkStmt(visitor StatementVisitor) Statement {
 }
 
 // stmt_for
-type ForInt struct {
+type ForLoop struct {
 	StatementImpl
 	Label   string
-	Var     Variable
-	Lower   Expr
-	Upper   Expr
-	Step    Expr
-	Reverse int
+	Target  []Variable
+	Control ForLoopControl
 	Body    []Statement
 }
 
-func (s *ForInt) Format(ctx *tree.FmtCtx) {
-}
-
-func (s *ForInt) PlpgSQLStatementTag() string {
-	return "stmt_for_int_loop"
-}
-
-func (s *ForInt) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
-}
-
-type ForQuery struct {
-	StatementImpl
-	Label string
-	Var   Variable
-	Body  []Statement
-}
-
-func (s *ForQuery) Format(ctx *tree.FmtCtx) {
-}
-
-func (s *ForQuery) PlpgSQLStatementTag() string {
-	return "stmt_for_query_loop"
-}
-
-func (s *ForQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+func (s *ForLoop) CopyNode() *ForLoop {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
 }
 
-type ForSelect struct {
-	ForQuery
-	Query Expr
+func (s *ForLoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	ctx.WriteString(" IN ")
+	ctx.FormatNode(s.Control)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
-func (s *ForSelect) Format(ctx *tree.FmtCtx) {
+func (s *ForLoop) PlpgSQLStatementTag() string {
+	switch s.Control.(type) {
+	case *IntForLoopControl:
+		return "stmt_for_int_loop"
+	case *QueryForLoopControl:
+		return "stmt_for_query_loop"
+	}
+	return "stmt_for_unknown"
 }
 
-func (s *ForSelect) PlpgSQLStatementTag() string {
-	return "stmt_query_select_loop"
-}
+func (s *ForLoop) WalkStmt(visitor StatementVisitor) Statement {
+	newStmt, recurse := visitor.Visit(s)
 
-func (s *ForSelect) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForLoop).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
-type ForCursor struct {
-	ForQuery
-	CurVar   int
-	ArgQuery Expr
+// ForLoopControl is the part of a FOR loop that determines the values taken
+// on by the loop target: either an integer range or the rows of a query.
+type ForLoopControl interface {
+	tree.NodeFormatter
+	isForLoopControl()
 }
 
-func (s *ForCursor) Format(ctx *tree.FmtCtx) {
-}
+var _ ForLoopControl = &IntForLoopControl{}
+var _ ForLoopControl = &QueryForLoopControl{}
 
-func (s *ForCursor) PlpgSQLStatementTag() string {
-	return "stmt_for_query_cursor_loop"
+// IntForLoopControl iterates over an integer range, optionally in reverse
+// order and with a step other than one.
+type IntForLoopControl struct {
+	Reverse bool
+	Lower   Expr
+	Upper   Expr
+	Step    Expr
 }
 
-func (s *ForCursor) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
-}
+func (c *IntForLoopControl) isForLoopControl() {}
 
-type ForDynamic struct {
-	ForQuery
-	Query  Expr
-	Params []Expr
+func (c *IntForLoopControl) Format(ctx *tree.FmtCtx) {
+	if c.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(c.Lower)
+	ctx.WriteString("..")
+	ctx.FormatNode(c.Upper)
+	if c.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(c.Step)
+	}
 }
 
-func (s *ForDynamic) Format(ctx *tree.FmtCtx) {
+// QueryForLoopControl iterates over the rows returned by a query.
+type QueryForLoopControl struct {
+	Query tree.Statement
 }
 
-func (s *ForDynamic) PlpgSQLStatementTag() string {
-	return "stmt_for_dyn_loop"
-}
+func (c *QueryForLoopControl) isForLoopControl() {}
 
-func (s *ForDynamic) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+func (c *QueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(c.Query)
 }
 
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
-	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Label  string
+	Target []Variable
+	// Slice is the number of array dimensions assigned to the target in each
+	// iteration. It is zero when the loop iterates over individual elements.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
+func (s *ForEachArray) CopyNode() *ForEachArray {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOREACH ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	if s.Slice != 0 {
+		ctx.Printf(" SLICE %d", s.Slice)
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -714,7 +838,20 @@ func (s *ForEachArray) PlpgSQLStatementTag() string {
 }
 
 func (s *ForEachArray) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForEachArray).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 // stmt_exit
@@ -815,13 +952,24 @@ func (s *Return) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// stmt_return_next
 type ReturnNext struct {
 	StatementImpl
-	Expr   Expr
-	RetVar Variable
+	Expr Expr
+}
+
+func (s *ReturnNext) CopyNode() *ReturnNext {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -829,17 +977,25 @@ func (s *ReturnNext) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnNext) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
+// stmt_return_query
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
-	DynamicQuery Expr
-	Params       []Expr
+	SqlStmt tree.Statement
+}
+
+func (s *ReturnQuery) CopyNode() *ReturnQuery {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	ctx.FormatNode(s.SqlStmt)
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -847,7 +1003,8 @@ func (s *ReturnQuery) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_raise
@@ -987,35 +1144,51 @@ func (s *Execute) WalkStmt(visitor StatementVisitor) Statement {
 }
 
 // stmt_dynexecute
-// TODO(chengxiong): query should be a better expression type.
 type DynamicExecute struct {
 	StatementImpl
-	Query  string
-	Into   bool
-	Strict bool
-	Target Variable
+	Query  Expr
+	Strict bool // INTO STRICT flag
+	Target []Variable
 	Params []Expr
 }
 
 func (s *DynamicExecute) CopyNode() *DynamicExecute {
 	copyNode := *s
+	copyNode.Target = append([]Variable(nil), s.Target...)
 	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
 func (s *DynamicExecute) Format(ctx *tree.FmtCtx) {
-	// TODO(drewk): Pretty print the original command
-	ctx.WriteString("EXECUTE a dynamic command")
-	if s.Into {
-		ctx.WriteString(" WITH INTO")
+	ctx.WriteString("EXECUTE ")
+	ctx.FormatNode(s.Query)
+	if s.Target != nil {
+		ctx.WriteString(" INTO ")
 		if s.Strict {
-			ctx.WriteString(" STRICT")
+			ctx.WriteString("STRICT ")
+		}
+		for i := range s.Target {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&s.Target[i])
 		}
 	}
-	if s.Params != nil {
-		ctx.WriteString(" WITH USING")
+	formatUsingParams(ctx, s.Params)
+	ctx.WriteString(";\n")
+}
+
+// formatUsingParams formats the USING clause of a dynamic EXECUTE or OPEN
+// statement, if any.
+func formatUsingParams(ctx *tree.FmtCtx, params []Expr) {
+	for i := range params {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(params[i])
 	}
-	ctx.WriteString("\n")
 }
 
 func (s *DynamicExecute) PlpgSQLStatementTag() string {
@@ -1030,10 +1203,25 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// SqlStmt is the SELECT statement that results from replacing PERFORM with
+	// SELECT. It is executed only for its side effects.
+	SqlStmt tree.Statement
+}
+
+func (s *Perform) CopyNode() *Perform {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// The PERFORM keyword takes the place of the SELECT keyword of the query.
+	start := ctx.Len()
+	ctx.FormatNode(s.SqlStmt)
+	query := strings.TrimPrefix(ctx.String()[start:], "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(query)
+	ctx.WriteString(";\n")
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -1041,7 +1229,8 @@ func (s *Perform) PlpgSQLStatementTag() string {
 }
 
 func (s *Perform) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_call
@@ -1079,7 +1268,15 @@ func (s *Call) WalkStmt(visitor StatementVisitor) Statement {
 type GetDiagnostics struct {
 	StatementImpl
 	IsStacked bool
-	DiagItems GetDiagnosticsItemList // TODO(drewk): what is this?
+	// DiagItems is the list of status items to retrieve, and the variables to
+	// which they are assigned.
+	DiagItems GetDiagnosticsItemList
+}
+
+func (s *GetDiagnostics) CopyNode() *GetDiagnostics {
+	copyNode := *s
+	copyNode.DiagItems = append(GetDiagnosticsItemList(nil), s.DiagItems...)
+	return &copyNode
 }
 
 func (s *GetDiagnostics) Format(ctx *tree.FmtCtx) {
@@ -1089,23 +1286,23 @@ func (s *GetDiagnostics) Format(ctx *tree.FmtCtx) {
 		ctx.WriteString("GET DIAGNOSTICS ")
 	}
 	for idx, i := range s.DiagItems {
-		ctx.FormatNode(i)
-		if idx != len(s.DiagItems)-1 {
-			ctx.WriteString(" ")
+		if idx > 0 {
+			ctx.WriteString(", ")
 		}
+		ctx.FormatNode(i)
 	}
-	ctx.WriteString("\n")
+	ctx.WriteString(";\n")
 }
 
 type GetDiagnosticsItem struct {
-	Kind GetDiagnosticsKind
-	// TODO(drewk): TargetName is temporary -- should be removed and use Target.
-	TargetName string
-	Target     int // where to assign it?
+	Kind   GetDiagnosticsKind
+	Target Variable
 }
 
 func (s *GetDiagnosticsItem) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString(fmt.Sprintf("%s := %s", s.TargetName, s.Kind.String()))
+	ctx.FormatNode(&s.Target)
+	ctx.WriteString(" := ")
+	ctx.WriteString(s.Kind.String())
 }
 
 type GetDiagnosticsItemList []*GetDiagnosticsItem
@@ -1125,10 +1322,17 @@ type Open struct {
 	CurVar Variable
 	Scroll tree.CursorScrollOption
 	Query  tree.Statement
+
+	// DynamicQuery is set for OPEN ... FOR EXECUTE, in which case Query is nil.
+	// It is a string expression that is planned and executed at runtime, using
+	// Params as the values of its placeholders.
+	DynamicQuery Expr
+	Params       []Expr
 }
 
 func (s *Open) CopyNode() *Open {
 	copyNode := *s
+	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
@@ -1144,6 +1348,10 @@ func (s *Open) Format(ctx *tree.FmtCtx) {
 	if s.Query != nil {
 		ctx.WriteString(" FOR ")
 		ctx.FormatNode(s.Query)
+	} else if s.DynamicQuery != nil {
+		ctx.WriteString(" FOR EXECUTE ")
+		ctx.FormatNode(s.DynamicQuery)
+		formatUsingParams(ctx, s.Params)
 	}
 	ctx.WriteString(";\n")
 }
@@ -1166,6 +1374,12 @@ type Fetch struct {
 	IsMove bool
 }
 
+func (s *Fetch) CopyNode() *Fetch {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), s.Target...)
+	return &copyNode
+}
+
 func (s *Fetch) Format(ctx *tree.FmtCtx) {
 	if s.IsMove {
 		ctx.WriteString("MOVE ")
@@ -1212,6 +1426,11 @@ type Close struct {
 	CurVar Variable
 }
 
+func (s *Close) CopyNode() *Close {
+	copyNode := *s
+	return &copyNode
+}
+
 func (s *Close) Format(ctx *tree.FmtCtx) {
 	ctx.WriteString("CLOSE ")
 	ctx.FormatNode(&s.CurVar)
@@ -20,7 +20,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
-	unimp "github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -163,11 +162,28 @@ func (v *SQLStmtVisitor) Visit(
 		if v.Err != nil {
 			return stmt, false
 		}
-		if t.Query != s {
+		e, v.Err = simpleVisit(t.DynamicQuery, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s || t.DynamicQuery != e {
 			cpy := t.CopyNode()
 			cpy.Query = s
+			cpy.DynamicQuery = e
 			newStmt = cpy
 		}
+		for i, p := range t.Params {
+			e, v.Err = simpleVisit(p, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if t.Params[i] != e {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*plpgsqltree.Open).Params[i] = e
+			}
+		}
 	case *plpgsqltree.Declaration:
 		e, v.Err = simpleVisit(t.Expr, v.Fn)
 		if v.Err != nil {
@@ -297,15 +313,23 @@ func (v *SQLStmtVisitor) Visit(
 		}
 
 	case *plpgsqltree.DynamicExecute:
+		e, v.Err = simpleVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != e {
+			cpy := t.CopyNode()
+			cpy.Query = e
+			newStmt = cpy
+		}
 		for i, p := range t.Params {
 			e, v.Err = simpleVisit(p, v.Fn)
 			if v.Err != nil {
 				return stmt, false
 			}
 			if t.Params[i] != e {
-				if newStmt != stmt {
-					cpy := t.CopyNode()
-					newStmt = cpy
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
 				}
 				newStmt.(*plpgsqltree.DynamicExecute).Params[i] = e
 			}
@@ -321,10 +345,78 @@ func (v *SQLStmtVisitor) Visit(
 			newStmt = cpy
 		}
 
-	case *plpgsqltree.ForInt, *plpgsqltree.ForSelect, *plpgsqltree.ForCursor,
-		*plpgsqltree.ForDynamic, *plpgsqltree.ForEachArray, *plpgsqltree.ReturnNext,
-		*plpgsqltree.ReturnQuery, *plpgsqltree.Perform:
-		panic(unimp.New("plpgsql visitor", "Unimplemented PLpgSQL visitor"))
+	case *plpgsqltree.ForLoop:
+		switch c := t.Control.(type) {
+		case *plpgsqltree.IntForLoopControl:
+			var lower, upper, step tree.Expr
+			if lower, v.Err = simpleVisit(c.Lower, v.Fn); v.Err != nil {
+				return stmt, false
+			}
+			if upper, v.Err = simpleVisit(c.Upper, v.Fn); v.Err != nil {
+				return stmt, false
+			}
+			if step, v.Err = simpleVisit(c.Step, v.Fn); v.Err != nil {
+				return stmt, false
+			}
+			if c.Lower != lower || c.Upper != upper || c.Step != step {
+				cpy := t.CopyNode()
+				cpy.Control = &plpgsqltree.IntForLoopControl{
+					Reverse: c.Reverse, Lower: lower, Upper: upper, Step: step,
+				}
+				newStmt = cpy
+			}
+		case *plpgsqltree.QueryForLoopControl:
+			s, v.Err = simpleStmtVisit(c.Query, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != s {
+				cpy := t.CopyNode()
+				cpy.Control = &plpgsqltree.QueryForLoopControl{Query: s}
+				newStmt = cpy
+			}
+		}
+	case *plpgsqltree.ForEachArray:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnNext:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnQuery:
+		s, v.Err = simpleStmtVisit(t.SqlStmt, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s {
+			cpy := t.CopyNode()
+			cpy.SqlStmt = s
+			newStmt = cpy
+		}
+
+	case *plpgsqltree.Perform:
+		s, v.Err = simpleStmtVisit(t.SqlStmt, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s {
+			cpy := t.CopyNode()
+			cpy.SqlStmt = s
+			newStmt = cpy
+		}
 	}
 	if v.Err != nil {
 		return stmt, false
@@ -350,6 +442,11 @@ func (v *TypeRefVisitor) Visit(
 	}
 	newStmt = stmt
 	if t, ok := stmt.(*plpgsqltree.Declaration); ok {
+		if _, ok := t.Typ.(*plpgsqltree.PercentTypeReference); ok {
+			// A %TYPE reference names a column or variable rather than a type, so
+			// there is nothing to replace.
+			return newStmt, true
+		}
 		var newTyp tree.ResolvableTypeReference
 		newTyp, v.Err = v.Fn(t.Typ)
 		if v.Err != nil {
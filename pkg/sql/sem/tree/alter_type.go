@@ -10,15 +10,21 @@
 
 package tree
 
-// AlterType represents an ALTER TYPE statement.
+// AlterType represents an ALTER TYPE or ALTER DOMAIN statement.
 type AlterType struct {
 	Type *UnresolvedObjectName
 	Cmd  AlterTypeCmd
+	// Domain is true if this is an ALTER DOMAIN statement.
+	Domain bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterType) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER TYPE ")
+	if node.Domain {
+		ctx.WriteString("ALTER DOMAIN ")
+	} else {
+		ctx.WriteString("ALTER TYPE ")
+	}
 	ctx.FormatNode(node.Type)
 	ctx.FormatNode(node.Cmd)
 }
@@ -38,12 +44,25 @@ func (*AlterTypeSetSchema) alterTypeCmd()   {}
 func (*AlterTypeOwner) alterTypeCmd()       {}
 func (*AlterTypeDropValue) alterTypeCmd()   {}
 
+func (*AlterDomainSetDefault) alterTypeCmd()       {}
+func (*AlterDomainSetNotNull) alterTypeCmd()       {}
+func (*AlterDomainDropNotNull) alterTypeCmd()      {}
+func (*AlterDomainAddConstraint) alterTypeCmd()    {}
+func (*AlterDomainDropConstraint) alterTypeCmd()   {}
+func (*AlterDomainRenameConstraint) alterTypeCmd() {}
+
 var _ AlterTypeCmd = &AlterTypeAddValue{}
 var _ AlterTypeCmd = &AlterTypeRenameValue{}
 var _ AlterTypeCmd = &AlterTypeRename{}
 var _ AlterTypeCmd = &AlterTypeSetSchema{}
 var _ AlterTypeCmd = &AlterTypeOwner{}
 var _ AlterTypeCmd = &AlterTypeDropValue{}
+var _ AlterTypeCmd = &AlterDomainSetDefault{}
+var _ AlterTypeCmd = &AlterDomainSetNotNull{}
+var _ AlterTypeCmd = &AlterDomainDropNotNull{}
+var _ AlterTypeCmd = &AlterDomainAddConstraint{}
+var _ AlterTypeCmd = &AlterDomainDropConstraint{}
+var _ AlterTypeCmd = &AlterDomainRenameConstraint{}
 
 // AlterTypeAddValue represents an ALTER TYPE ADD VALUE command.
 type AlterTypeAddValue struct {
@@ -163,3 +182,120 @@ func (node *AlterTypeOwner) Format(ctx *FmtCtx) {
 func (node *AlterTypeOwner) TelemetryName() string {
 	return "owner"
 }
+
+// AlterDomainSetDefault represents an ALTER DOMAIN SET DEFAULT or DROP DEFAULT
+// command.
+type AlterDomainSetDefault struct {
+	// Default is nil for DROP DEFAULT.
+	Default Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetDefault) Format(ctx *FmtCtx) {
+	if node.Default == nil {
+		ctx.WriteString(" DROP DEFAULT")
+	} else {
+		ctx.WriteString(" SET DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterDomainSetDefault) TelemetryName() string {
+	return "set_default"
+}
+
+// AlterDomainSetNotNull represents an ALTER DOMAIN SET NOT NULL command.
+type AlterDomainSetNotNull struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetNotNull) Format(ctx *FmtCtx) {
+	ctx.WriteString(" SET NOT NULL")
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterDomainSetNotNull) TelemetryName() string {
+	return "set_not_null"
+}
+
+// AlterDomainDropNotNull represents an ALTER DOMAIN DROP NOT NULL command.
+type AlterDomainDropNotNull struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainDropNotNull) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP NOT NULL")
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterDomainDropNotNull) TelemetryName() string {
+	return "drop_not_null"
+}
+
+// AlterDomainAddConstraint represents an ALTER DOMAIN ADD CONSTRAINT command.
+type AlterDomainAddConstraint struct {
+	ConstraintName Name
+	Expr           Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainAddConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ADD ")
+	if node.ConstraintName != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.ConstraintName)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("CHECK (")
+	ctx.FormatNode(node.Expr)
+	ctx.WriteByte(')')
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterDomainAddConstraint) TelemetryName() string {
+	return "add_constraint"
+}
+
+// AlterDomainDropConstraint represents an ALTER DOMAIN DROP CONSTRAINT
+// command.
+type AlterDomainDropConstraint struct {
+	IfExists     bool
+	Constraint   Name
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainDropConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP CONSTRAINT ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Constraint)
+	if node.DropBehavior != DropDefault {
+		ctx.Printf(" %s", node.DropBehavior)
+	}
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterDomainDropConstraint) TelemetryName() string {
+	return "drop_constraint"
+}
+
+// AlterDomainRenameConstraint represents an ALTER DOMAIN RENAME CONSTRAINT
+// command.
+type AlterDomainRenameConstraint struct {
+	Constraint Name
+	NewName    Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainRenameConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RENAME CONSTRAINT ")
+	ctx.FormatNode(&node.Constraint)
+	ctx.WriteString(" TO ")
+	ctx.FormatNode(&node.NewName)
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterDomainRenameConstraint) TelemetryName() string {
+	return "rename_constraint"
+}
@@ -544,10 +544,29 @@ var (
 		types.Jsonb,
 		types.PGLSN,
 		types.PGLSNArray,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.DateRange,
+		types.Int4MultiRange,
+		types.Int8MultiRange,
+		types.NumMultiRange,
+		types.TSMultiRange,
+		types.TSTZMultiRange,
+		types.DateMultiRange,
 		types.RefCursor,
 		types.RefCursorArray,
 		types.TSQuery,
 		types.TSVector,
+		types.Point,
+		types.Line,
+		types.LSeg,
+		types.Box,
+		types.Path,
+		types.Polygon,
+		types.Circle,
 		types.VarBit,
 		types.AnyEnum,
 		types.AnyEnumArray,
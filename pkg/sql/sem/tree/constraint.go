@@ -127,3 +127,23 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checks for a constraint can be
+// deferred until the end of the transaction, and whether they are deferred by
+// default.
+type ConstraintDeferrability struct {
+	Deferrable        bool
+	InitiallyDeferred bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if !node.Deferrable {
+		// NOT DEFERRABLE is the default.
+		return
+	}
+	ctx.WriteString(" DEFERRABLE")
+	if node.InitiallyDeferred {
+		ctx.WriteString(" INITIALLY DEFERRED")
+	}
+}
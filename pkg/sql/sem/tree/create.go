@@ -28,12 +28,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
-	"golang.org/x/text/language"
 )
 
 // CreateDatabase represents a CREATE DATABASE statement.
@@ -235,10 +235,16 @@ type IndexInvisibility struct {
 
 // CreateIndex represents a CREATE INDEX statement.
 type CreateIndex struct {
-	Name        Name
-	Table       TableName
-	Unique      bool
-	Inverted    bool
+	Name     Name
+	Table    TableName
+	Unique   bool
+	Inverted bool
+	// BlockRange is set for CREATE INDEX ... USING brin, which creates a block
+	// range index that summarizes a column rather than an index of its own.
+	BlockRange bool
+	// Hash is set for CREATE INDEX ... USING hash, which indexes a hash of its
+	// single column or expression and only supports equality lookups.
+	Hash        bool
 	IfNotExists bool
 	Columns     IndexElemList
 	Sharded     *ShardedIndexDef
@@ -277,6 +283,12 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	}
 	ctx.WriteString("ON ")
 	ctx.FormatNode(&node.Table)
+	if node.BlockRange {
+		ctx.WriteString(" USING brin")
+	}
+	if node.Hash {
+		ctx.WriteString(" USING hash")
+	}
 
 	ctx.WriteString(" (")
 	ctx.FormatNode(&node.Columns)
@@ -374,6 +386,8 @@ type CreateType struct {
 	// CompositeTypeList is set when this repesnets a CREATE TYPE ... AS ( )
 	// statement.
 	CompositeTypeList []CompositeTypeElem
+	// DomainDef is set when this represents a CREATE DOMAIN statement.
+	DomainDef *DomainDef
 	// IfNotExists is true if IF NOT EXISTS was requested.
 	IfNotExists bool
 }
@@ -382,6 +396,12 @@ var _ Statement = &CreateType{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateType) Format(ctx *FmtCtx) {
+	if node.Variety == Domain {
+		ctx.WriteString("CREATE DOMAIN ")
+		ctx.FormatNode(node.TypeName)
+		ctx.FormatNode(node.DomainDef)
+		return
+	}
 	ctx.WriteString("CREATE TYPE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -412,6 +432,107 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// DomainDef is the definition of a domain type in a CREATE DOMAIN statement.
+type DomainDef struct {
+	BaseType    ResolvableTypeReference
+	DefaultExpr Expr
+	Nullable    struct {
+		Nullability    Nullability
+		ConstraintName Name
+	}
+	CheckExprs []ColumnTableDefCheckExpr
+}
+
+// NewDomainDef constructs a domain definition for a CREATE DOMAIN statement
+// from the base type and the constraints following it, which use the syntax
+// of column qualifications.
+func NewDomainDef(
+	name *UnresolvedObjectName,
+	typRef ResolvableTypeReference,
+	qualifications []NamedColumnQualification,
+) (*DomainDef, error) {
+	d := &DomainDef{BaseType: typRef}
+	d.Nullable.Nullability = SilentNull
+	for _, c := range qualifications {
+		switch t := c.Qualification.(type) {
+		case *ColumnDefault:
+			if d.DefaultExpr != nil {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"multiple default expressions")
+			}
+			d.DefaultExpr = t.Expr
+		case NotNullConstraint:
+			if d.Nullable.Nullability == Null {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"conflicting NULL/NOT NULL constraints")
+			}
+			d.Nullable.Nullability = NotNull
+			d.Nullable.ConstraintName = c.Name
+		case NullConstraint:
+			if d.Nullable.Nullability == NotNull {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"conflicting NULL/NOT NULL constraints")
+			}
+			d.Nullable.Nullability = Null
+			d.Nullable.ConstraintName = c.Name
+		case *ColumnCheckConstraint:
+			d.CheckExprs = append(d.CheckExprs, ColumnTableDefCheckExpr{
+				Expr:           t.Expr,
+				ConstraintName: c.Name,
+			})
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unsupported constraint for domain %q", name)
+		}
+	}
+	return d, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainDef) Format(ctx *FmtCtx) {
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.BaseType)
+	if node.DefaultExpr != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.DefaultExpr)
+	}
+	if node.Nullable.Nullability != SilentNull && node.Nullable.ConstraintName != "" {
+		ctx.WriteString(" CONSTRAINT ")
+		ctx.FormatNode(&node.Nullable.ConstraintName)
+	}
+	switch node.Nullable.Nullability {
+	case Null:
+		ctx.WriteString(" NULL")
+	case NotNull:
+		ctx.WriteString(" NOT NULL")
+	}
+	for _, checkExpr := range node.CheckExprs {
+		if checkExpr.ConstraintName != "" {
+			ctx.WriteString(" CONSTRAINT ")
+			ctx.FormatNode(&checkExpr.ConstraintName)
+		}
+		ctx.WriteString(" CHECK (")
+		ctx.FormatNode(checkExpr.Expr)
+		ctx.WriteByte(')')
+	}
+}
+
+// DomainValueName is the name by which the CHECK constraints of a domain refer
+// to the value being checked.
+const DomainValueName = "value"
+
+// ReplaceDomainValue returns a copy of the CHECK constraint expression of a
+// domain in which all references to VALUE are replaced with the given
+// expression.
+func ReplaceDomainValue(expr Expr, value Expr) (Expr, error) {
+	return SimpleVisit(expr, func(expr Expr) (recurse bool, newExpr Expr, err error) {
+		if n, ok := expr.(*UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == DomainValueName {
+			return false, value, nil
+		}
+		return true, expr, nil
+	})
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -518,6 +639,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -586,12 +708,9 @@ func NewColumnTableDef(
 			// In postgres, all strings have collations defaulting to "default".
 			// In CRDB, collated strings are treated separately to string family types.
 			// To most behave like postgres, set the CollatedString type if a non-"default"
-			// collation is used.
+			// collation is used. The locale is validated once the type is resolved,
+			// since it may be the name of a collation created with CREATE COLLATION.
 			if locale != collatedstring.DefaultCollationTag {
-				_, err := language.Parse(locale)
-				if err != nil {
-					return nil, pgerror.Wrapf(err, pgcode.Syntax, "invalid locale %s", locale)
-				}
 				collatedTyp, err := processCollationOnType(name, d.Type, t)
 				if err != nil {
 					return nil, err
@@ -701,6 +820,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrability = t.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -871,6 +991,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -1009,10 +1130,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table         TableName
+	Col           Name // empty-string means use PK
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1110,6 +1232,48 @@ type UniqueConstraintTableDef struct {
 	PrimaryKey   bool
 	WithoutIndex bool
 	IfNotExists  bool
+	// Deferrability can only be set for UNIQUE WITHOUT INDEX constraints.
+	Deferrability ConstraintDeferrability
+	// ExclusionOps is set for EXCLUDE constraints, which are represented as
+	// unique constraints without an index whose columns are compared with the
+	// corresponding operator rather than with equality.
+	ExclusionOps []treecmp.ComparisonOperator
+	// ExclusionMethod is the access method of an EXCLUDE constraint.
+	ExclusionMethod Name
+}
+
+// ExclusionElem is a column of an EXCLUDE constraint along with the operator
+// used to compare it. It is only used by the parser.
+type ExclusionElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// NewExclusionConstraintTableDef constructs the definition of an EXCLUDE
+// constraint.
+func NewExclusionConstraintTableDef(
+	method Name, elems []ExclusionElem, predicate Expr, deferrability ConstraintDeferrability,
+) *UniqueConstraintTableDef {
+	def := &UniqueConstraintTableDef{
+		IndexTableDef: IndexTableDef{
+			Columns:   make(IndexElemList, len(elems)),
+			Predicate: predicate,
+		},
+		WithoutIndex:    true,
+		Deferrability:   deferrability,
+		ExclusionOps:    make([]treecmp.ComparisonOperator, len(elems)),
+		ExclusionMethod: method,
+	}
+	for i := range elems {
+		def.Columns[i] = IndexElem{Column: elems[i].Column}
+		def.ExclusionOps[i] = elems[i].Operator
+	}
+	return def
+}
+
+// IsExclusion returns true if the definition is for an EXCLUDE constraint.
+func (node *UniqueConstraintTableDef) IsExclusion() bool {
+	return len(node.ExclusionOps) > 0
 }
 
 // SetName implements the TableDef interface.
@@ -1132,6 +1296,10 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Name)
 		ctx.WriteByte(' ')
 	}
+	if node.IsExclusion() {
+		node.formatExclusion(ctx)
+		return
+	}
 	if node.PrimaryKey {
 		ctx.WriteString("PRIMARY KEY ")
 	} else {
@@ -1154,6 +1322,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1171,15 +1340,40 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 }
 
+func (node *UniqueConstraintTableDef) formatExclusion(ctx *FmtCtx) {
+	ctx.WriteString("EXCLUDE ")
+	if node.ExclusionMethod != "" {
+		ctx.WriteString("USING ")
+		ctx.WriteString(string(node.ExclusionMethod))
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	for i := range node.Columns {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node.Columns[i].Column)
+		ctx.WriteString(" WITH ")
+		ctx.WriteString(node.ExclusionOps[i].String())
+	}
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+	ctx.FormatNode(&node.Deferrability)
+}
+
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
+	IfNotExists   bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -1210,6 +1404,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1622,12 +1817,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
@@ -1952,6 +2148,11 @@ type CreateView struct {
 	Replace      bool
 	Materialized bool
 	WithData     bool
+	// Recursive is set for CREATE RECURSIVE VIEW, in which case AsSource may
+	// refer to the view itself. See RecursiveViewQuery.
+	Recursive bool
+	// StorageParams are the storage parameters of a materialized view.
+	StorageParams StorageParams
 }
 
 // Format implements the NodeFormatter interface.
@@ -1970,6 +2171,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString("MATERIALIZED ")
 	}
 
+	if node.Recursive {
+		ctx.WriteString("RECURSIVE ")
+	}
+
 	ctx.WriteString("VIEW ")
 
 	if node.IfNotExists {
@@ -1984,6 +2189,12 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteByte(')')
 	}
 
+	if len(node.StorageParams) > 0 {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.StorageParams)
+		ctx.WriteString(")")
+	}
+
 	ctx.WriteString(" AS ")
 	ctx.FormatNode(node.AsSource)
 	if node.Materialized && node.WithData {
@@ -1993,11 +2204,46 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 	}
 }
 
+// RecursiveViewQuery returns the query of a recursive view. Like in Postgres,
+//
+//	CREATE RECURSIVE VIEW name (columns) AS source
+//
+// is equivalent to
+//
+//	CREATE VIEW name AS
+//	  WITH RECURSIVE name (columns) AS (source) SELECT columns FROM name
+func (node *CreateView) RecursiveViewQuery() *Select {
+	cols := make(ColumnDefList, len(node.ColumnNames))
+	exprs := make(SelectExprs, len(node.ColumnNames))
+	for i, name := range node.ColumnNames {
+		cols[i] = ColumnDef{Name: name}
+		exprs[i] = SelectExpr{Expr: NewUnresolvedName(string(name))}
+	}
+	return &Select{
+		With: &With{
+			Recursive: true,
+			CTEList: []*CTE{{
+				Name: AliasClause{Alias: node.Name.ObjectName, Cols: cols},
+				Stmt: node.AsSource,
+			}},
+		},
+		Select: &SelectClause{
+			Exprs: exprs,
+			From: From{Tables: TableExprs{
+				&AliasedTableExpr{Expr: NewUnqualifiedTableName(node.Name.ObjectName)},
+			}},
+		},
+	}
+}
+
 // RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW statement.
 type RefreshMaterializedView struct {
 	Name              *UnresolvedObjectName
 	Concurrently      bool
 	RefreshDataOption RefreshDataOption
+	// Incremental is set if only the rows of the view that changed should be
+	// written, rather than recomputing all of its data into new indexes.
+	Incremental bool
 }
 
 // RefreshDataOption corresponds to arguments for the REFRESH MATERIALIZED VIEW
@@ -2029,6 +2275,9 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 	case RefreshDataClear:
 		ctx.WriteString(" WITH NO DATA")
 	}
+	if node.Incremental {
+		ctx.WriteString(" INCREMENTAL")
+	}
 }
 
 // CreateStats represents a CREATE STATISTICS statement.
@@ -0,0 +1,64 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreateCollation represents a CREATE COLLATION statement.
+type CreateCollation struct {
+	Name        Name
+	IfNotExists bool
+	// Options are the options of the collation, e.g. LOCALE and DETERMINISTIC.
+	// They are empty if From is set.
+	Options StorageParams
+	// From is the name of the collation to copy for a CREATE COLLATION ...
+	// FROM statement.
+	From Name
+}
+
+var _ Statement = &CreateCollation{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateCollation) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE COLLATION ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	if node.From != "" {
+		ctx.WriteString(" FROM ")
+		ctx.FormatNode(&node.From)
+		return
+	}
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// DropCollation represents a DROP COLLATION statement.
+type DropCollation struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropCollation{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropCollation) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP COLLATION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
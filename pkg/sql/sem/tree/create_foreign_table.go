@@ -0,0 +1,133 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// ForeignOption is a single option of the OPTIONS clause of a foreign server
+// or foreign table, such as location 's3://bucket/path'.
+type ForeignOption struct {
+	Name  Name
+	Value string
+}
+
+// ForeignOptions is the list of options of an OPTIONS clause.
+type ForeignOptions []ForeignOption
+
+// Format implements the NodeFormatter interface.
+func (node *ForeignOptions) Format(ctx *FmtCtx) {
+	ctx.WriteString("OPTIONS (")
+	for i := range *node {
+		o := &(*node)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&o.Name)
+		ctx.WriteByte(' ')
+		ctx.FormatNode(NewStrVal(o.Value))
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateServer represents a CREATE SERVER statement.
+type CreateServer struct {
+	IfNotExists bool
+	Name        Name
+	Wrapper     Name
+	Options     ForeignOptions
+}
+
+var _ Statement = &CreateServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	IfNotExists bool
+	Table       TableName
+	Defs        TableDefs
+	Server      Name
+	Options     ForeignOptions
+}
+
+var _ Statement = &CreateForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// DropServer represents a DROP SERVER statement.
+type DropServer struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP SERVER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropForeignTable represents a DROP FOREIGN TABLE statement.
+type DropForeignTable struct {
+	Names        TableNames
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP FOREIGN TABLE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
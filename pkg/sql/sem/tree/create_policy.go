@@ -0,0 +1,129 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	Name  Name
+	Table *UnresolvedObjectName
+	Type  PolicyType
+	Cmd   PolicyCommand
+	// Roles is the list of roles to which the policy applies. An empty list
+	// means that the policy applies to all roles (PUBLIC).
+	Roles     RoleSpecList
+	Using     Expr
+	WithCheck Expr
+}
+
+var _ Statement = &CreatePolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" AS ")
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" FOR ")
+	ctx.WriteString(node.Cmd.String())
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteByte(')')
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteByte(')')
+	}
+}
+
+// PolicyType describes how a row-level security policy is combined with the
+// other policies that apply to the same command.
+type PolicyType uint8
+
+const (
+	// PolicyTypePermissive indicates that the policy is combined with the other
+	// permissive policies using OR. This is the default.
+	PolicyTypePermissive PolicyType = iota
+	// PolicyTypeRestrictive indicates that the policy is combined with the
+	// other policies using AND.
+	PolicyTypeRestrictive
+)
+
+var policyTypeName = [...]string{
+	PolicyTypePermissive:  "PERMISSIVE",
+	PolicyTypeRestrictive: "RESTRICTIVE",
+}
+
+func (t PolicyType) String() string {
+	return policyTypeName[t]
+}
+
+// PolicyCommand describes the command to which a row-level security policy
+// applies.
+type PolicyCommand uint8
+
+const (
+	// PolicyCommandAll indicates that the policy applies to all commands. This
+	// is the default.
+	PolicyCommandAll PolicyCommand = iota
+	// PolicyCommandSelect indicates that the policy applies to SELECT.
+	PolicyCommandSelect
+	// PolicyCommandInsert indicates that the policy applies to INSERT.
+	PolicyCommandInsert
+	// PolicyCommandUpdate indicates that the policy applies to UPDATE.
+	PolicyCommandUpdate
+	// PolicyCommandDelete indicates that the policy applies to DELETE.
+	PolicyCommandDelete
+)
+
+var policyCommandName = [...]string{
+	PolicyCommandAll:    "ALL",
+	PolicyCommandSelect: "SELECT",
+	PolicyCommandInsert: "INSERT",
+	PolicyCommandUpdate: "UPDATE",
+	PolicyCommandDelete: "DELETE",
+}
+
+func (c PolicyCommand) String() string {
+	return policyCommandName[c]
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	IfExists     bool
+	Policy       Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Policy)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
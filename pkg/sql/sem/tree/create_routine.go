@@ -13,6 +13,7 @@ package tree
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -393,15 +394,17 @@ const (
 	RoutineParamOut
 	// RoutineParamInOut args can be used as both input and output.
 	RoutineParamInOut
-	// RoutineParamVariadic args are variadic.
+	// RoutineParamVariadic args are variadic. A VARIADIC parameter must be the
+	// last input parameter and must have an array type; it collects all
+	// trailing arguments of the routine call into an array.
 	RoutineParamVariadic
 )
 
 // IsInParamClass returns true if the given parameter class specifies an input
-// parameter (i.e. either unspecified, IN or, INOUT).
+// parameter (i.e. either unspecified, IN, INOUT, or VARIADIC).
 func IsInParamClass(class RoutineParamClass) bool {
 	switch class {
-	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut:
+	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut, RoutineParamVariadic:
 		return true
 	default:
 		return false
@@ -419,8 +422,8 @@ func IsOutParamClass(class RoutineParamClass) bool {
 	}
 }
 
-// IsInParam returns true if the parameter is an input parameter (i.e. either IN
-// or INOUT).
+// IsInParam returns true if the parameter is an input parameter (i.e. either IN,
+// INOUT, or VARIADIC).
 func (node *RoutineParam) IsInParam() bool {
 	return IsInParamClass(node.Class)
 }
@@ -437,10 +440,12 @@ type RoutineReturnType struct {
 	SetOf bool
 }
 
-// DropRoutine represents a DROP FUNCTION or DROP PROCEDURE statement.
+// DropRoutine represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropRoutine struct {
 	IfExists     bool
 	Procedure    bool
+	Aggregate    bool
 	Routines     RoutineObjs
 	DropBehavior DropBehavior
 }
@@ -449,6 +454,8 @@ type DropRoutine struct {
 func (node *DropRoutine) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("DROP AGGREGATE ")
 	} else {
 		ctx.WriteString("DROP FUNCTION ")
 	}
@@ -622,12 +629,18 @@ const (
 	ColumnDefaultExprInNewView      SchemaExprContext = "DEFAULT (in CREATE VIEW)"
 	ColumnDefaultExprInSetDefault   SchemaExprContext = "DEFAULT (in SET DEFAULT)"
 	CheckConstraintExpr             SchemaExprContext = "CHECK"
+	DomainDefaultExpr               SchemaExprContext = "DEFAULT (in CREATE DOMAIN)"
+	DomainCheckExpr                 SchemaExprContext = "CHECK (in CREATE DOMAIN)"
+	DomainDefaultExprInAlterDomain  SchemaExprContext = "DEFAULT (in ALTER DOMAIN)"
+	DomainCheckExprInAlterDomain    SchemaExprContext = "CHECK (in ALTER DOMAIN)"
 	UniqueWithoutIndexPredicateExpr SchemaExprContext = "UNIQUE WITHOUT INDEX PREDICATE"
 	IndexPredicateExpr              SchemaExprContext = "INDEX PREDICATE"
 	ExpressionIndexElementExpr      SchemaExprContext = "EXPRESSION INDEX ELEMENT"
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	PolicyUsingExpr                 SchemaExprContext = "POLICY USING"
+	PolicyWithCheckExpr             SchemaExprContext = "POLICY WITH CHECK"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -700,3 +713,116 @@ func GetRoutineVolatility(options RoutineOptions) RoutineVolatility {
 	}
 	return RoutineVolatile
 }
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	// SFunc is the state transition function, which is called with the current
+	// state and the aggregated arguments of each input row.
+	SFunc RoutineName
+	// SType is the type of the aggregate state.
+	SType ResolvableTypeReference
+	// FinalFunc, if set, computes the result of the aggregate from the final
+	// state.
+	FinalFunc *RoutineName
+	// CombineFunc, if set, merges two partial aggregate states. It allows the
+	// aggregate to be evaluated in multiple stages.
+	CombineFunc *RoutineName
+	// InitCond, if set, is the string representation of the initial state.
+	InitCond *string
+}
+
+// AggregateOptionKind identifies an option of a CREATE AGGREGATE statement.
+type AggregateOptionKind int
+
+const (
+	// AggregateOptionSFunc is the SFUNC option.
+	AggregateOptionSFunc AggregateOptionKind = iota
+	// AggregateOptionSType is the STYPE option.
+	AggregateOptionSType
+	// AggregateOptionFinalFunc is the FINALFUNC option.
+	AggregateOptionFinalFunc
+	// AggregateOptionCombineFunc is the COMBINEFUNC option.
+	AggregateOptionCombineFunc
+	// AggregateOptionInitCond is the INITCOND option.
+	AggregateOptionInitCond
+)
+
+// AggregateOption is a single option of a CREATE AGGREGATE statement. Only
+// the field that corresponds to Kind is set.
+type AggregateOption struct {
+	Kind    AggregateOptionKind
+	Routine RoutineName
+	Type    ResolvableTypeReference
+	Value   string
+}
+
+// NewCreateAggregate constructs a CREATE AGGREGATE statement from the list of
+// options in the parenthesized definition of the aggregate.
+func NewCreateAggregate(
+	replace bool, name RoutineName, params RoutineParams, options []AggregateOption,
+) (*CreateAggregate, error) {
+	n := &CreateAggregate{Replace: replace, Name: name, Params: params}
+	var seen [AggregateOptionInitCond + 1]bool
+	for i := range options {
+		o := &options[i]
+		if seen[o.Kind] {
+			return nil, ErrConflictingRoutineOption
+		}
+		seen[o.Kind] = true
+		switch o.Kind {
+		case AggregateOptionSFunc:
+			n.SFunc = o.Routine
+		case AggregateOptionSType:
+			n.SType = o.Type
+		case AggregateOptionFinalFunc:
+			n.FinalFunc = &o.Routine
+		case AggregateOptionCombineFunc:
+			n.CombineFunc = &o.Routine
+		case AggregateOptionInitCond:
+			n.InitCond = &o.Value
+		}
+	}
+	if !seen[AggregateOptionSFunc] {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if !seen[AggregateOptionSType] {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	return n, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (SFUNC = ")
+	ctx.FormatNode(&node.SFunc)
+	ctx.WriteString(", STYPE = ")
+	ctx.FormatTypeReference(node.SType)
+	if node.FinalFunc != nil {
+		ctx.WriteString(", FINALFUNC = ")
+		ctx.FormatNode(node.FinalFunc)
+	}
+	if node.CombineFunc != nil {
+		ctx.WriteString(", COMBINEFUNC = ")
+		ctx.FormatNode(node.CombineFunc)
+	}
+	if node.InitCond != nil {
+		ctx.WriteString(", INITCOND = ")
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, *node.InitCond, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace     bool
+	Name        Name
+	ActionTime  TriggerActionTime
+	Events      []*TriggerEvent
+	TableName   *UnresolvedObjectName
+	Transitions []*TriggerTransition
+	ForEach     TriggerForEach
+	When        Expr
+	FuncName    *UnresolvedName
+	FuncArgs    []string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	for i := range node.Events {
+		if i > 0 {
+			ctx.WriteString(" OR")
+		}
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Events[i])
+	}
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if len(node.Transitions) > 0 {
+		ctx.WriteString(" REFERENCING")
+		for i := range node.Transitions {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(node.Transitions[i])
+		}
+	}
+	ctx.WriteString(" FOR EACH ")
+	ctx.WriteString(node.ForEach.String())
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteByte('(')
+	for i := range node.FuncArgs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		// Trigger function arguments are always string literals. They are not
+		// subject to literal or identifier anonymization.
+		lexbase.EncodeSQLString(&ctx.Buffer, node.FuncArgs[i])
+	}
+	ctx.WriteByte(')')
+}
+
+// TriggerActionTime describes when a trigger fires relative to the event that
+// caused it.
+type TriggerActionTime uint8
+
+const (
+	// TriggerActionTimeUnknown is the zero value, and is not a valid action
+	// time.
+	TriggerActionTimeUnknown TriggerActionTime = iota
+	// TriggerActionTimeBefore indicates that the trigger fires before the
+	// event.
+	TriggerActionTimeBefore
+	// TriggerActionTimeAfter indicates that the trigger fires after the event.
+	TriggerActionTimeAfter
+	// TriggerActionTimeInsteadOf indicates that the trigger fires in place of
+	// the event. It is only valid for triggers on views.
+	TriggerActionTimeInsteadOf
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeUnknown:   "UNKNOWN",
+	TriggerActionTimeBefore:    "BEFORE",
+	TriggerActionTimeAfter:     "AFTER",
+	TriggerActionTimeInsteadOf: "INSTEAD OF",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEventType describes a type of event that can cause a trigger to fire.
+type TriggerEventType uint8
+
+const (
+	// TriggerEventTypeUnknown is the zero value, and is not a valid event type.
+	TriggerEventTypeUnknown TriggerEventType = iota
+	// TriggerEventInsert indicates that the trigger fires on INSERT.
+	TriggerEventInsert
+	// TriggerEventUpdate indicates that the trigger fires on UPDATE.
+	TriggerEventUpdate
+	// TriggerEventDelete indicates that the trigger fires on DELETE.
+	TriggerEventDelete
+	// TriggerEventTruncate indicates that the trigger fires on TRUNCATE.
+	TriggerEventTruncate
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventTypeUnknown: "UNKNOWN",
+	TriggerEventInsert:      "INSERT",
+	TriggerEventUpdate:      "UPDATE",
+	TriggerEventDelete:      "DELETE",
+	TriggerEventTruncate:    "TRUNCATE",
+}
+
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvent represents one of the events that can cause a trigger to fire.
+// Columns is only set for UPDATE OF column_name [, ...].
+type TriggerEvent struct {
+	EventType TriggerEventType
+	Columns   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvent) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.EventType.String())
+	if len(node.Columns) > 0 {
+		ctx.WriteString(" OF ")
+		ctx.FormatNode(&node.Columns)
+	}
+}
+
+// TriggerTransition represents a REFERENCING clause, which names the
+// transition relation for the old or new rows of a statement-level trigger.
+type TriggerTransition struct {
+	Name  Name
+	IsNew bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerTransition) Format(ctx *FmtCtx) {
+	if node.IsNew {
+		ctx.WriteString("NEW")
+	} else {
+		ctx.WriteString("OLD")
+	}
+	ctx.WriteString(" TABLE AS ")
+	ctx.FormatNode(&node.Name)
+}
+
+// TriggerForEach describes whether a trigger fires once for each affected row
+// or once for the whole statement.
+type TriggerForEach uint8
+
+const (
+	// TriggerForEachStatement indicates that the trigger fires once for each
+	// statement. This is the default.
+	TriggerForEachStatement TriggerForEach = iota
+	// TriggerForEachRow indicates that the trigger fires once for each row.
+	TriggerForEachRow
+)
+
+var triggerForEachName = [...]string{
+	TriggerForEachStatement: "STATEMENT",
+	TriggerForEachRow:       "ROW",
+}
+
+func (t TriggerForEach) String() string {
+	return triggerForEachName[t]
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	IfExists     bool
+	Trigger      Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Trigger)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -0,0 +1,915 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"math"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+	"github.com/twpayne/go-geom"
+)
+
+// GeometricPoint is a point of a value of a geometric type.
+type GeometricPoint struct {
+	X, Y float64
+}
+
+// DGeometric is the Datum representation of the geometric types point, line,
+// lseg, box, path, polygon and circle. The meaning of its fields depends on the
+// type of the value:
+//   - point: Points contains the point.
+//   - line: Line contains the coefficients A, B and C of the equation of the
+//     line Ax + By + C = 0.
+//   - lseg: Points contains the two end points of the segment.
+//   - box: Points contains the upper right and the lower left corners of the
+//     box, in that order.
+//   - path: Points contains the points of the path. Closed is set for closed
+//     paths.
+//   - polygon: Points contains the vertices of the polygon.
+//   - circle: Points contains the center of the circle and Radius its radius.
+type DGeometric struct {
+	typ    *types.T
+	Points []GeometricPoint
+	Line   [3]float64
+	Radius float64
+	Closed bool
+}
+
+// NewDPoint returns a point.
+func NewDPoint(p GeometricPoint) *DGeometric {
+	return &DGeometric{typ: types.Point, Points: []GeometricPoint{p}}
+}
+
+// NewDLine returns the line Ax + By + C = 0. An error is returned if A and B
+// are both zero.
+func NewDLine(a, b, c float64) (*DGeometric, error) {
+	if fpZero(a) && fpZero(b) {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"invalid line specification: A and B cannot both be zero")
+	}
+	return &DGeometric{typ: types.Line, Line: [3]float64{a, b, c}}, nil
+}
+
+// NewDLineFromPoints returns the line going through the two given points. An
+// error is returned if the points are the same.
+func NewDLineFromPoints(p1, p2 GeometricPoint) (*DGeometric, error) {
+	if p1.eq(p2) {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"invalid line specification: must be two distinct points")
+	}
+	// This is line_construct in Postgres, with the slope of the line computed
+	// like point_sl.
+	switch {
+	case fpEq(p1.X, p2.X):
+		return NewDLine(-1, 0, p1.X)
+	case fpEq(p1.Y, p2.Y):
+		return NewDLine(0, -1, p1.Y)
+	}
+	m := (p1.Y - p2.Y) / (p1.X - p2.X)
+	c := p1.Y - m*p1.X
+	if c == 0 {
+		// Avoid -0.
+		c = 0
+	}
+	return NewDLine(m, -1, c)
+}
+
+// NewDLSeg returns the line segment between the two given points.
+func NewDLSeg(p1, p2 GeometricPoint) *DGeometric {
+	return &DGeometric{typ: types.LSeg, Points: []GeometricPoint{p1, p2}}
+}
+
+// NewDBox returns the box with the two given opposite corners. The corners are
+// normalized so that the upper right corner is stored first.
+func NewDBox(p1, p2 GeometricPoint) *DGeometric {
+	high := GeometricPoint{X: math.Max(p1.X, p2.X), Y: math.Max(p1.Y, p2.Y)}
+	low := GeometricPoint{X: math.Min(p1.X, p2.X), Y: math.Min(p1.Y, p2.Y)}
+	return &DGeometric{typ: types.Box, Points: []GeometricPoint{high, low}}
+}
+
+// NewDPath returns a path with the given points, which must not be empty.
+func NewDPath(points []GeometricPoint, closed bool) *DGeometric {
+	return &DGeometric{typ: types.Path, Points: points, Closed: closed}
+}
+
+// NewDPolygon returns a polygon with the given vertices, which must not be
+// empty.
+func NewDPolygon(points []GeometricPoint) *DGeometric {
+	return &DGeometric{typ: types.Polygon, Points: points}
+}
+
+// NewDCircle returns a circle. An error is returned if the radius is negative.
+func NewDCircle(center GeometricPoint, radius float64) (*DGeometric, error) {
+	if radius < 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "circle radius cannot be negative")
+	}
+	return &DGeometric{typ: types.Circle, Points: []GeometricPoint{center}, Radius: radius}, nil
+}
+
+// AsDGeometric attempts to retrieve a *DGeometric from an Expr, returning a
+// *DGeometric and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DGeometric wrapped by a *DOidWrapper is possible.
+func AsDGeometric(e Expr) (*DGeometric, bool) {
+	switch t := e.(type) {
+	case *DGeometric:
+		return t, true
+	case *DOidWrapper:
+		return AsDGeometric(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDGeometric attempts to retrieve a *DGeometric from an Expr, panicking
+// if the assertion fails.
+func MustBeDGeometric(e Expr) *DGeometric {
+	g, ok := AsDGeometric(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DGeometric, found %T", e))
+	}
+	return g
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DGeometric) ResolvedType() *types.T {
+	return d.typ
+}
+
+// Compare implements the Datum interface.
+func (d *DGeometric) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DGeometric) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DGeometric)
+	if !ok || d.typ.Oid() != v.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.compare(v), nil
+}
+
+// compare orders values of the same geometric type by their components. The
+// geometric types have no ordering operators in Postgres, so this order is
+// only used internally, e.g. to deduplicate constants.
+func (d *DGeometric) compare(other *DGeometric) int {
+	for i := 0; i < len(d.Points) && i < len(other.Points); i++ {
+		if c := compareGeometricFloats(d.Points[i].X, other.Points[i].X); c != 0 {
+			return c
+		}
+		if c := compareGeometricFloats(d.Points[i].Y, other.Points[i].Y); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(d.Points) < len(other.Points):
+		return -1
+	case len(d.Points) > len(other.Points):
+		return 1
+	}
+	for i := range d.Line {
+		if c := compareGeometricFloats(d.Line[i], other.Line[i]); c != 0 {
+			return c
+		}
+	}
+	if c := compareGeometricFloats(d.Radius, other.Radius); c != 0 {
+		return c
+	}
+	switch {
+	case d.Closed == other.Closed:
+		return 0
+	case !d.Closed:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareGeometricFloats compares two coordinates like DFloat.Compare, so that
+// NaN is smaller than any other value.
+func compareGeometricFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	case math.IsNaN(a):
+		if math.IsNaN(b) {
+			return 0
+		}
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Prev implements the Datum interface.
+func (d *DGeometric) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DGeometric) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DGeometric) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DGeometric) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DGeometric) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DGeometric) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DGeometric) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DGeometric) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	d.formatText(ctx)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// formatText writes the Postgres text representation of the value, e.g.
+// (1,2) for a point or <(1,2),3> for a circle.
+func (d *DGeometric) formatText(ctx *FmtCtx) {
+	conv := ctx.dataConversionConfig
+	if !ctx.HasFlags(fmtPgwireFormat) {
+		// Outside of pgwire formatting, the coordinates use the shortest
+		// representation which round-trips, like DFloat.
+		conv.ExtraFloatDigits = 1
+	}
+	writeFloat := func(f float64) {
+		ctx.Write(PgwireFormatFloat(ctx.scratch[:0], f, conv, types.Float))
+	}
+	writePoint := func(p GeometricPoint) {
+		ctx.WriteByte('(')
+		writeFloat(p.X)
+		ctx.WriteByte(',')
+		writeFloat(p.Y)
+		ctx.WriteByte(')')
+	}
+	writePoints := func() {
+		for i, p := range d.Points {
+			if i > 0 {
+				ctx.WriteByte(',')
+			}
+			writePoint(p)
+		}
+	}
+	switch d.typ.Oid() {
+	case oid.T_point:
+		writePoint(d.Points[0])
+	case oid.T_line:
+		ctx.WriteByte('{')
+		for i, f := range d.Line {
+			if i > 0 {
+				ctx.WriteByte(',')
+			}
+			writeFloat(f)
+		}
+		ctx.WriteByte('}')
+	case oid.T_lseg:
+		ctx.WriteByte('[')
+		writePoints()
+		ctx.WriteByte(']')
+	case oid.T_box:
+		writePoints()
+	case oid.T_path:
+		if d.Closed {
+			ctx.WriteByte('(')
+		} else {
+			ctx.WriteByte('[')
+		}
+		writePoints()
+		if d.Closed {
+			ctx.WriteByte(')')
+		} else {
+			ctx.WriteByte(']')
+		}
+	case oid.T_polygon:
+		ctx.WriteByte('(')
+		writePoints()
+		ctx.WriteByte(')')
+	case oid.T_circle:
+		ctx.WriteByte('<')
+		writePoint(d.Points[0])
+		ctx.WriteByte(',')
+		writeFloat(d.Radius)
+		ctx.WriteByte('>')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DGeometric) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Points))*unsafe.Sizeof(GeometricPoint{})
+}
+
+// geometricEpsilon is the tolerance of the fuzzy comparisons of coordinates,
+// like EPSILON in the geometric functions of Postgres.
+const geometricEpsilon = 1.0e-06
+
+func fpZero(a float64) bool { return math.Abs(a) <= geometricEpsilon }
+
+func fpEq(a, b float64) bool { return a == b || math.Abs(a-b) <= geometricEpsilon }
+
+func fpLe(a, b float64) bool { return a-b <= geometricEpsilon }
+
+func (p GeometricPoint) eq(other GeometricPoint) bool {
+	return fpEq(p.X, other.X) && fpEq(p.Y, other.Y)
+}
+
+func (p GeometricPoint) distance(other GeometricPoint) float64 {
+	return math.Hypot(p.X-other.X, p.Y-other.Y)
+}
+
+// geometricSegment is a line segment of the outline of a geometric value. The
+// end points are the same for the segment of a single point.
+type geometricSegment struct {
+	a, b GeometricPoint
+}
+
+// distanceToPoint returns the distance between the segment and a point.
+func (s geometricSegment) distanceToPoint(p GeometricPoint) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return p.distance(s.a)
+	}
+	t := ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / l
+	t = math.Max(0, math.Min(1, t))
+	return p.distance(GeometricPoint{X: s.a.X + t*dx, Y: s.a.Y + t*dy})
+}
+
+// containsPoint returns whether the point lies on the segment.
+func (s geometricSegment) containsPoint(p GeometricPoint) bool {
+	return fpZero(s.distanceToPoint(p))
+}
+
+// orientation returns a positive value if r is to the left of the line going
+// from p to q, a negative value if it is to the right and zero if the three
+// points are collinear.
+func orientation(p, q, r GeometricPoint) float64 {
+	return (q.X-p.X)*(r.Y-p.Y) - (q.Y-p.Y)*(r.X-p.X)
+}
+
+// crosses returns whether the segments intersect at a single point which is
+// not an end point of either segment.
+func (s geometricSegment) crosses(other geometricSegment) bool {
+	d1 := orientation(other.a, other.b, s.a)
+	d2 := orientation(other.a, other.b, s.b)
+	d3 := orientation(s.a, s.b, other.a)
+	d4 := orientation(s.a, s.b, other.b)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+// intersects returns whether the segments have at least one point in common.
+func (s geometricSegment) intersects(other geometricSegment) bool {
+	return s.crosses(other) ||
+		s.containsPoint(other.a) || s.containsPoint(other.b) ||
+		other.containsPoint(s.a) || other.containsPoint(s.b)
+}
+
+// distance returns the distance between two segments.
+func (s geometricSegment) distance(other geometricSegment) float64 {
+	if s.intersects(other) {
+		return 0
+	}
+	return math.Min(
+		math.Min(s.distanceToPoint(other.a), s.distanceToPoint(other.b)),
+		math.Min(other.distanceToPoint(s.a), other.distanceToPoint(s.b)),
+	)
+}
+
+// boxCorners returns the four corners of a box, starting with the lower left
+// corner and going clockwise like box_poly in Postgres.
+func (d *DGeometric) boxCorners() []GeometricPoint {
+	high, low := d.Points[0], d.Points[1]
+	return []GeometricPoint{
+		low,
+		{X: low.X, Y: high.Y},
+		high,
+		{X: high.X, Y: low.Y},
+	}
+}
+
+// vertices returns the points of the outline of the value. It must not be
+// called on lines and circles.
+func (d *DGeometric) vertices() []GeometricPoint {
+	if d.typ.Oid() == oid.T_box {
+		return d.boxCorners()
+	}
+	return d.Points
+}
+
+// segments returns the segments of the outline of the value. It must not be
+// called on lines and circles.
+func (d *DGeometric) segments() []geometricSegment {
+	points := d.vertices()
+	if len(points) == 1 {
+		return []geometricSegment{{a: points[0], b: points[0]}}
+	}
+	closed := false
+	switch d.typ.Oid() {
+	case oid.T_box, oid.T_polygon:
+		closed = true
+	case oid.T_path:
+		closed = d.Closed
+	}
+	segments := make([]geometricSegment, 0, len(points))
+	for i := 0; i+1 < len(points); i++ {
+		segments = append(segments, geometricSegment{a: points[i], b: points[i+1]})
+	}
+	if closed && len(points) > 2 {
+		segments = append(segments, geometricSegment{a: points[len(points)-1], b: points[0]})
+	}
+	return segments
+}
+
+// hasInterior returns whether the value covers an area.
+func (d *DGeometric) hasInterior() bool {
+	switch d.typ.Oid() {
+	case oid.T_box, oid.T_polygon:
+		return true
+	}
+	return false
+}
+
+// areaContainsPoint returns whether a box or a polygon contains a point,
+// including the points on its boundary.
+func (d *DGeometric) areaContainsPoint(p GeometricPoint) bool {
+	if d.typ.Oid() == oid.T_box {
+		high, low := d.Points[0], d.Points[1]
+		return fpLe(low.X, p.X) && fpLe(p.X, high.X) && fpLe(low.Y, p.Y) && fpLe(p.Y, high.Y)
+	}
+	for _, s := range d.segments() {
+		if s.containsPoint(p) {
+			return true
+		}
+	}
+	// Count the edges crossed by a ray going from the point to the right.
+	inside := false
+	points := d.Points
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a, b := points[i], points[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Center returns the center of the value, like the casts to point in Postgres.
+// The center of a path or a polygon is the average of its points. It must not
+// be called on lines.
+func (d *DGeometric) Center() GeometricPoint {
+	switch d.typ.Oid() {
+	case oid.T_point, oid.T_circle:
+		return d.Points[0]
+	}
+	var c GeometricPoint
+	for _, p := range d.Points {
+		c.X += p.X
+		c.Y += p.Y
+	}
+	c.X /= float64(len(d.Points))
+	c.Y /= float64(len(d.Points))
+	return c
+}
+
+// Area returns the area of a box, a closed path, a polygon or a circle. The
+// second return value is false for open paths, which have no area.
+func (d *DGeometric) Area() (float64, bool) {
+	switch d.typ.Oid() {
+	case oid.T_box:
+		high, low := d.Points[0], d.Points[1]
+		return (high.X - low.X) * (high.Y - low.Y), true
+	case oid.T_circle:
+		return math.Pi * d.Radius * d.Radius, true
+	case oid.T_path:
+		if !d.Closed {
+			return 0, false
+		}
+	}
+	// Compute the area with the shoelace formula, like path_area in Postgres.
+	var area float64
+	points := d.Points
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		area += points[j].X*points[i].Y - points[i].X*points[j].Y
+	}
+	return math.Abs(area) / 2, true
+}
+
+// boundingBox returns the smallest box containing all of the points of the
+// value.
+func (d *DGeometric) boundingBox() *DGeometric {
+	high, low := d.Points[0], d.Points[0]
+	for _, p := range d.Points[1:] {
+		high.X, high.Y = math.Max(high.X, p.X), math.Max(high.Y, p.Y)
+		low.X, low.Y = math.Min(low.X, p.X), math.Min(low.Y, p.Y)
+	}
+	return NewDBox(high, low)
+}
+
+// GeometricSame returns whether two values of the same geometric type are the
+// same, like the ~= operator.
+func GeometricSame(a, b *DGeometric) bool {
+	switch a.typ.Oid() {
+	case oid.T_circle:
+		return a.Points[0].eq(b.Points[0]) && fpEq(a.Radius, b.Radius)
+	case oid.T_polygon:
+		return samePointLists(a.Points, b.Points)
+	}
+	if len(a.Points) != len(b.Points) {
+		return false
+	}
+	for i := range a.Points {
+		if !a.Points[i].eq(b.Points[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// samePointLists returns whether two lists of vertices describe the same
+// polygon. The vertices must be in the same cyclic order, in either direction,
+// like plist_same in Postgres.
+func samePointLists(a, b []GeometricPoint) bool {
+	n := len(a)
+	if n != len(b) {
+		return false
+	}
+	for start := 0; start < n; start++ {
+		if !a[0].eq(b[start]) {
+			continue
+		}
+		forward, backward := true, true
+		for i := 1; i < n && (forward || backward); i++ {
+			forward = forward && a[i].eq(b[(start+i)%n])
+			backward = backward && a[i].eq(b[(start-i+n)%n])
+		}
+		if forward || backward {
+			return true
+		}
+	}
+	return false
+}
+
+// GeometricDistance returns the distance between two geometric values, like
+// the <-> operator. Lines are only supported along with points and lines.
+func GeometricDistance(a, b *DGeometric) (float64, error) {
+	if a.typ.Oid() == oid.T_line || b.typ.Oid() == oid.T_line {
+		return lineDistance(a, b)
+	}
+	// The distance to a circle is the distance to its center minus its radius.
+	var radius float64
+	if a.typ.Oid() == oid.T_circle {
+		radius += a.Radius
+		a = NewDPoint(a.Points[0])
+	}
+	if b.typ.Oid() == oid.T_circle {
+		radius += b.Radius
+		b = NewDPoint(b.Points[0])
+	}
+	return math.Max(0, shapeDistance(a, b)-radius), nil
+}
+
+// shapeDistance returns the distance between two values which are neither
+// lines nor circles.
+func shapeDistance(a, b *DGeometric) float64 {
+	if (a.hasInterior() && a.areaContainsPoint(b.vertices()[0])) ||
+		(b.hasInterior() && b.areaContainsPoint(a.vertices()[0])) {
+		return 0
+	}
+	dist := math.Inf(1)
+	for _, s1 := range a.segments() {
+		for _, s2 := range b.segments() {
+			dist = math.Min(dist, s1.distance(s2))
+		}
+	}
+	return dist
+}
+
+// lineDistance returns the distance between a line and a point or another
+// line, in either order.
+func lineDistance(a, b *DGeometric) (float64, error) {
+	if a.typ.Oid() != oid.T_line {
+		a, b = b, a
+	}
+	l := a.Line
+	switch b.typ.Oid() {
+	case oid.T_point:
+		p := b.Points[0]
+		return math.Abs(l[0]*p.X+l[1]*p.Y+l[2]) / math.Hypot(l[0], l[1]), nil
+	case oid.T_line:
+		// Like line_distance in Postgres, lines which are not parallel have a
+		// distance of zero.
+		l2 := b.Line
+		if !linesParallel(l, l2) {
+			return 0, nil
+		}
+		ratio := 1.0
+		if !fpZero(l[0]) && !fpZero(l2[0]) {
+			ratio = l[0] / l2[0]
+		} else if !fpZero(l[1]) && !fpZero(l2[1]) {
+			ratio = l[1] / l2[1]
+		}
+		return math.Abs(l[2]-ratio*l2[2]) / math.Hypot(l[0], l[1]), nil
+	}
+	return 0, pgerror.Newf(pgcode.FeatureNotSupported,
+		"distance between line and %s is not supported", b.typ)
+}
+
+// linesParallel returns whether two lines are parallel, like the check in
+// line_interpt_line in Postgres.
+func linesParallel(l1, l2 [3]float64) bool {
+	switch {
+	case !fpZero(l1[1]):
+		return fpEq(l2[0], l1[0]*(l2[1]/l1[1]))
+	case !fpZero(l2[1]):
+		return fpEq(l1[0], l2[0]*(l1[1]/l2[1]))
+	}
+	// Both lines are vertical.
+	return true
+}
+
+// GeometricContains returns whether the first geometric value contains the
+// second one, like the @> operator. The supported combinations are the ones
+// of the overloads of the @> operator.
+func GeometricContains(a, b *DGeometric) bool {
+	switch a.typ.Oid() {
+	case oid.T_box, oid.T_polygon:
+		for _, p := range b.vertices() {
+			if !a.areaContainsPoint(p) {
+				return false
+			}
+		}
+		if a.typ.Oid() == oid.T_box || b.typ.Oid() == oid.T_point {
+			return true
+		}
+		// The vertices of b are all in a, but the edges of b could still leave a
+		// if a is not convex.
+		for _, sb := range b.segments() {
+			mid := GeometricPoint{X: (sb.a.X + sb.b.X) / 2, Y: (sb.a.Y + sb.b.Y) / 2}
+			if !a.areaContainsPoint(mid) {
+				return false
+			}
+			for _, sa := range a.segments() {
+				if sa.crosses(sb) {
+					return false
+				}
+			}
+		}
+		return true
+	case oid.T_circle:
+		c := a.Points[0]
+		if b.typ.Oid() == oid.T_circle {
+			return fpLe(c.distance(b.Points[0])+b.Radius, a.Radius)
+		}
+		return fpLe(c.distance(b.Points[0]), a.Radius)
+	case oid.T_path:
+		p := b.Points[0]
+		if a.Closed {
+			return NewDPolygon(a.Points).areaContainsPoint(p)
+		}
+		for _, s := range a.segments() {
+			if fpEq(p.distance(s.a)+p.distance(s.b), s.a.distance(s.b)) {
+				return true
+			}
+		}
+		return false
+	case oid.T_lseg:
+		p, s := b.Points[0], a.Points
+		return fpEq(p.distance(s[0])+p.distance(s[1]), s[0].distance(s[1]))
+	case oid.T_line:
+		p, l := b.Points[0], a.Line
+		return fpZero(l[0]*p.X + l[1]*p.Y + l[2])
+	}
+	return false
+}
+
+// GeometricOverlaps returns whether two boxes, polygons or circles overlap,
+// like the && operator.
+func GeometricOverlaps(a, b *DGeometric) bool {
+	switch a.typ.Oid() {
+	case oid.T_box:
+		return boxesOverlap(a, b)
+	case oid.T_polygon:
+		if !boxesOverlap(a.boundingBox(), b.boundingBox()) {
+			return false
+		}
+		return shapeDistance(a, b) == 0
+	case oid.T_circle:
+		return fpLe(a.Points[0].distance(b.Points[0]), a.Radius+b.Radius)
+	}
+	return false
+}
+
+func boxesOverlap(a, b *DGeometric) bool {
+	aHigh, aLow := a.Points[0], a.Points[1]
+	bHigh, bLow := b.Points[0], b.Points[1]
+	return fpLe(aLow.X, bHigh.X) && fpLe(bLow.X, aHigh.X) &&
+		fpLe(aLow.Y, bHigh.Y) && fpLe(bLow.Y, aHigh.Y)
+}
+
+// circlePolygonPoints is the number of points of the polygon that a circle is
+// converted to, like in Postgres.
+const circlePolygonPoints = 12
+
+// ConvertGeometric converts a geometric value to another geometric type, like
+// the casts between geometric types in Postgres.
+func ConvertGeometric(d *DGeometric, t *types.T) (*DGeometric, error) {
+	from, to := d.typ.Oid(), t.Oid()
+	if from == to {
+		return d, nil
+	}
+	switch to {
+	case oid.T_point:
+		switch from {
+		case oid.T_lseg, oid.T_box:
+			p1, p2 := d.Points[0], d.Points[1]
+			return NewDPoint(GeometricPoint{X: (p1.X + p2.X) / 2, Y: (p1.Y + p2.Y) / 2}), nil
+		case oid.T_path, oid.T_polygon, oid.T_circle:
+			return NewDPoint(d.Center()), nil
+		}
+	case oid.T_lseg:
+		if from == oid.T_box {
+			// This is the diagonal of the box.
+			return NewDLSeg(d.Points[0], d.Points[1]), nil
+		}
+	case oid.T_box:
+		switch from {
+		case oid.T_point:
+			return NewDBox(d.Points[0], d.Points[0]), nil
+		case oid.T_polygon:
+			return d.boundingBox(), nil
+		case oid.T_circle:
+			// This is the largest box inside the circle.
+			delta := d.Radius / math.Sqrt2
+			c := d.Points[0]
+			return NewDBox(
+				GeometricPoint{X: c.X + delta, Y: c.Y + delta},
+				GeometricPoint{X: c.X - delta, Y: c.Y - delta},
+			), nil
+		}
+	case oid.T_path:
+		if from == oid.T_polygon {
+			return NewDPath(d.Points, true /* closed */), nil
+		}
+	case oid.T_polygon:
+		switch from {
+		case oid.T_box:
+			return NewDPolygon(d.boxCorners()), nil
+		case oid.T_path:
+			if !d.Closed {
+				return nil, pgerror.New(pgcode.InvalidParameterValue,
+					"open path cannot be converted to polygon")
+			}
+			return NewDPolygon(d.Points), nil
+		case oid.T_circle:
+			if d.Radius == 0 {
+				return nil, pgerror.New(pgcode.FeatureNotSupported,
+					"cannot convert circle with radius zero to polygon")
+			}
+			c := d.Points[0]
+			points := make([]GeometricPoint, circlePolygonPoints)
+			step := 2 * math.Pi / float64(len(points))
+			for i := range points {
+				angle := float64(i) * step
+				points[i] = GeometricPoint{
+					X: c.X - d.Radius*math.Cos(angle),
+					Y: c.Y + d.Radius*math.Sin(angle),
+				}
+			}
+			return NewDPolygon(points), nil
+		}
+	case oid.T_circle:
+		switch from {
+		case oid.T_box:
+			p1, p2 := d.Points[0], d.Points[1]
+			c := GeometricPoint{X: (p1.X + p2.X) / 2, Y: (p1.Y + p2.Y) / 2}
+			return NewDCircle(c, c.distance(p1))
+		case oid.T_polygon:
+			// The radius is the average distance of the vertices to the center.
+			c := d.Center()
+			var radius float64
+			for _, p := range d.Points {
+				radius += c.distance(p)
+			}
+			return NewDCircle(c, radius/float64(len(d.Points)))
+		}
+	}
+	return nil, pgerror.Newf(pgcode.CannotCoerce, "invalid cast: %s -> %s", d.typ, t)
+}
+
+// AsGeometry converts a point, path or polygon to a geometry, like the casts
+// to geometry of PostGIS. A closed path is converted to a closed linestring.
+func (d *DGeometric) AsGeometry() (geo.Geometry, error) {
+	flat := make([]float64, 0, 2*(len(d.Points)+1))
+	for _, p := range d.Points {
+		flat = append(flat, p.X, p.Y)
+	}
+	var g geom.T
+	switch d.typ.Oid() {
+	case oid.T_point:
+		g = geom.NewPointFlat(geom.XY, flat)
+	case oid.T_path:
+		if d.Closed {
+			flat = append(flat, d.Points[0].X, d.Points[0].Y)
+		}
+		g = geom.NewLineStringFlat(geom.XY, flat)
+	case oid.T_polygon:
+		if first, last := d.Points[0], d.Points[len(d.Points)-1]; first != last {
+			flat = append(flat, first.X, first.Y)
+		}
+		g = geom.NewPolygonFlat(geom.XY, flat, []int{len(flat)})
+	default:
+		return geo.Geometry{}, pgerror.Newf(pgcode.CannotCoerce,
+			"invalid cast: %s -> geometry", d.typ)
+	}
+	return geo.MakeGeometryFromGeomT(g)
+}
+
+// NewDGeometricFromGeometry converts a geometry to a point, path or polygon,
+// like the casts from geometry of PostGIS. Points can only be converted from
+// point geometries, paths from linestrings and polygons from polygons without
+// holes.
+func NewDGeometricFromGeometry(g geo.Geometry, t *types.T) (*DGeometric, error) {
+	gt, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	// Only the X and Y coordinates are kept.
+	toPoints := func(flat []float64) []GeometricPoint {
+		stride := gt.Layout().Stride()
+		points := make([]GeometricPoint, 0, len(flat)/stride)
+		for i := 0; i+stride <= len(flat); i += stride {
+			points = append(points, GeometricPoint{X: flat[i], Y: flat[i+1]})
+		}
+		return points
+	}
+	switch t.Oid() {
+	case oid.T_point:
+		if p, ok := gt.(*geom.Point); ok && !p.Empty() {
+			return NewDPoint(GeometricPoint{X: p.X(), Y: p.Y()}), nil
+		}
+	case oid.T_path:
+		if ls, ok := gt.(*geom.LineString); ok && !ls.Empty() {
+			return NewDPath(toPoints(ls.FlatCoords()), false /* closed */), nil
+		}
+	case oid.T_polygon:
+		if p, ok := gt.(*geom.Polygon); ok && !p.Empty() && p.NumLinearRings() == 1 {
+			points := toPoints(p.LinearRing(0).FlatCoords())
+			// Remove the closing point of the ring.
+			return NewDPolygon(points[:len(points)-1]), nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+		"cannot convert geometry of type %s to %s",
+		strings.ToLower(g.ShapeType2D().String()), t)
+}
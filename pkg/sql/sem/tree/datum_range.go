@@ -0,0 +1,995 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// RangeBound is the lower or upper bound of a range.
+type RangeBound struct {
+	// Val is the value of the bound. It is nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val is contained in the range. It is always false
+	// for infinite bounds.
+	Inclusive bool
+}
+
+// IsInfinite returns true if the bound is infinite, i.e. if the range is
+// unbounded on this side.
+func (b RangeBound) IsInfinite() bool {
+	return b.Val == nil
+}
+
+// DRange is the Datum representation of a range type. Ranges are normalized
+// upon construction so that equal ranges have the same representation: empty
+// ranges have no bounds, and ranges of discrete subtypes (integers and dates)
+// always have an inclusive lower bound and an exclusive upper bound, like in
+// Postgres.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. They are unset if the range
+	// is empty.
+	Lower, Upper RangeBound
+	// Empty is true if the range contains no values.
+	Empty bool
+}
+
+// NewEmptyDRange returns an empty range of the given range type.
+func NewEmptyDRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRange returns a range of the given range type with the given bounds. The
+// bound values must have the subtype of the range type. An error is returned
+// if the lower bound is greater than the upper bound.
+func NewDRange(typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	if lower.IsInfinite() {
+		lower.Inclusive = false
+	}
+	if upper.IsInfinite() {
+		upper.Inclusive = false
+	}
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		cmp := compareRangeValues(lower.Val, upper.Val)
+		if cmp > 0 {
+			return nil, pgerror.New(pgcode.DataException,
+				"range lower bound must be less than or equal to range upper bound")
+		}
+		if cmp == 0 && !(lower.Inclusive && upper.Inclusive) {
+			return NewEmptyDRange(typ), nil
+		}
+	}
+	if err := canonicalizeRangeBounds(typ.RangeContents(), &lower, &upper); err != nil {
+		return nil, err
+	}
+	return newDRangeFromBounds(typ, lower, upper), nil
+}
+
+// newDRangeFromBounds returns a range with the given bounds, which must already
+// be canonical and ordered. It only checks whether the range is empty.
+func newDRangeFromBounds(typ *types.T, lower, upper RangeBound) *DRange {
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		cmp := compareRangeValues(lower.Val, upper.Val)
+		if cmp > 0 || (cmp == 0 && !(lower.Inclusive && upper.Inclusive)) {
+			return NewEmptyDRange(typ)
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper}
+}
+
+// RangeFlags are the flags which describe the bounds of a range in the binary
+// representation of ranges in Postgres. They are used by the pgwire binary
+// encoding and by the value encoding of ranges.
+type RangeFlags uint8
+
+const (
+	// RangeFlagEmpty is set if the range is empty.
+	RangeFlagEmpty RangeFlags = 0x01
+	// RangeFlagLowerInclusive is set if the lower bound is inclusive.
+	RangeFlagLowerInclusive RangeFlags = 0x02
+	// RangeFlagUpperInclusive is set if the upper bound is inclusive.
+	RangeFlagUpperInclusive RangeFlags = 0x04
+	// RangeFlagLowerInfinite is set if the lower bound is infinite.
+	RangeFlagLowerInfinite RangeFlags = 0x08
+	// RangeFlagUpperInfinite is set if the upper bound is infinite.
+	RangeFlagUpperInfinite RangeFlags = 0x10
+)
+
+// Flags returns the RangeFlags describing the bounds of the range.
+func (d *DRange) Flags() RangeFlags {
+	if d.Empty {
+		return RangeFlagEmpty
+	}
+	var flags RangeFlags
+	if d.Lower.Inclusive {
+		flags |= RangeFlagLowerInclusive
+	}
+	if d.Upper.Inclusive {
+		flags |= RangeFlagUpperInclusive
+	}
+	if d.Lower.IsInfinite() {
+		flags |= RangeFlagLowerInfinite
+	}
+	if d.Upper.IsInfinite() {
+		flags |= RangeFlagUpperInfinite
+	}
+	return flags
+}
+
+// NewDRangeFromFlags returns a range of the given range type with the bounds
+// described by the given flags. The lower and upper bound values are ignored
+// if the flags indicate that the range is empty or that the respective bound
+// is infinite.
+func NewDRangeFromFlags(typ *types.T, flags RangeFlags, lowerVal, upperVal Datum) (*DRange, error) {
+	if flags&RangeFlagEmpty != 0 {
+		return NewEmptyDRange(typ), nil
+	}
+	lower := RangeBound{Inclusive: flags&RangeFlagLowerInclusive != 0}
+	if flags&RangeFlagLowerInfinite == 0 {
+		lower.Val = lowerVal
+	}
+	upper := RangeBound{Inclusive: flags&RangeFlagUpperInclusive != 0}
+	if flags&RangeFlagUpperInfinite == 0 {
+		upper.Val = upperVal
+	}
+	return NewDRange(typ, lower, upper)
+}
+
+// isDiscreteRangeSubtype returns whether the given subtype of a range type is
+// discrete, in which case ranges are canonicalized to have an inclusive lower
+// bound and an exclusive upper bound.
+func isDiscreteRangeSubtype(subtyp *types.T) bool {
+	switch subtyp.Family() {
+	case types.IntFamily, types.DateFamily:
+		return true
+	}
+	return false
+}
+
+// canonicalizeRangeBounds converts the given bounds of a range with a discrete
+// subtype to the canonical [lower, upper) form.
+func canonicalizeRangeBounds(subtyp *types.T, lower, upper *RangeBound) error {
+	if !isDiscreteRangeSubtype(subtyp) {
+		return nil
+	}
+	if !lower.IsInfinite() && !lower.Inclusive {
+		next, err := nextDiscreteRangeValue(subtyp, lower.Val)
+		if err != nil {
+			return err
+		}
+		lower.Val, lower.Inclusive = next, true
+	}
+	if !upper.IsInfinite() && upper.Inclusive {
+		next, err := nextDiscreteRangeValue(subtyp, upper.Val)
+		if err != nil {
+			return err
+		}
+		upper.Val, upper.Inclusive = next, false
+	}
+	return nil
+}
+
+// nextDiscreteRangeValue returns the value following the given value of a
+// discrete range subtype.
+func nextDiscreteRangeValue(subtyp *types.T, d Datum) (Datum, error) {
+	switch t := d.(type) {
+	case *DInt:
+		if subtyp.Width() == 32 && *t >= math.MaxInt32 {
+			return nil, pgerror.New(pgcode.NumericValueOutOfRange, "integer out of range")
+		}
+		if *t == math.MaxInt64 {
+			return nil, pgerror.New(pgcode.NumericValueOutOfRange, "bigint out of range")
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		// Like in Postgres, infinite dates are left as is.
+		if !t.IsFinite() {
+			return t, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, pgerror.WithCandidateCode(err, pgcode.DatetimeFieldOverflow)
+		}
+		return NewDDate(next), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected discrete range value %T", d)
+}
+
+// rangeCompareContext is the CompareContext used to compare the bound values
+// of ranges. None of the range subtypes depend on the context for comparisons.
+type rangeCompareContext struct{}
+
+var _ CompareContext = rangeCompareContext{}
+
+// UnwrapDatum implements the CompareContext interface.
+func (rangeCompareContext) UnwrapDatum(d Datum) Datum { return UnwrapDOidWrapper(d) }
+
+// GetLocation implements the CompareContext interface.
+func (rangeCompareContext) GetLocation() *time.Location { return time.UTC }
+
+// GetRelativeParseTime implements the CompareContext interface.
+func (rangeCompareContext) GetRelativeParseTime() time.Time { return time.Time{} }
+
+// MustGetPlaceholderValue implements the CompareContext interface.
+func (rangeCompareContext) MustGetPlaceholderValue(p *Placeholder) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder in range bound"))
+}
+
+// compareRangeValues compares two values of a range subtype.
+func compareRangeValues(a, b Datum) int {
+	return a.Compare(rangeCompareContext{}, b)
+}
+
+// compareRangeBounds compares two range bounds, each of which can be either a
+// lower or an upper bound, like range_cmp_bounds in Postgres.
+func compareRangeBounds(b1 RangeBound, isLower1 bool, b2 RangeBound, isLower2 bool) int {
+	// An infinite lower bound is smaller than everything else, and an infinite
+	// upper bound is larger than everything else.
+	if b1.IsInfinite() && b2.IsInfinite() {
+		if isLower1 == isLower2 {
+			return 0
+		}
+		if isLower1 {
+			return -1
+		}
+		return 1
+	}
+	if b1.IsInfinite() {
+		if isLower1 {
+			return -1
+		}
+		return 1
+	}
+	if b2.IsInfinite() {
+		if isLower2 {
+			return 1
+		}
+		return -1
+	}
+	res := compareRangeValues(b1.Val, b2.Val)
+	if res != 0 {
+		return res
+	}
+	// The values are equal, so the inclusiveness of the bounds determines the
+	// order. An exclusive lower bound is greater than an inclusive bound at the
+	// same value, and an exclusive upper bound is smaller.
+	switch {
+	case !b1.Inclusive && !b2.Inclusive:
+		if isLower1 == isLower2 {
+			return 0
+		}
+		if isLower1 {
+			return 1
+		}
+		return -1
+	case !b1.Inclusive:
+		if isLower1 {
+			return 1
+		}
+		return -1
+	case !b2.Inclusive:
+		if isLower2 {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok || d.typ.Oid() != v.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.compare(v), nil
+}
+
+// compare orders ranges like Postgres: the empty range is smaller than all
+// other ranges, and other ranges are ordered by their lower bound, then by
+// their upper bound.
+func (d *DRange) compare(other *DRange) int {
+	if d.Empty || other.Empty {
+		switch {
+		case d.Empty && other.Empty:
+			return 0
+		case d.Empty:
+			return -1
+		default:
+			return 1
+		}
+	}
+	if c := compareRangeBounds(d.Lower, true /* isLower1 */, other.Lower, true /* isLower2 */); c != 0 {
+		return c
+	}
+	return compareRangeBounds(d.Upper, false /* isLower1 */, other.Upper, false /* isLower2 */)
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range [...]RangeBound{d.Lower, d.Upper} {
+		if cdatum, ok := b.Val.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx CompareContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx CompareContext) (Datum, bool) {
+	return NewEmptyDRange(d.typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.text(ctx)
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// text returns the Postgres text representation of the range, e.g. [1,10).
+// The bound values are formatted according to the given context.
+func (d *DRange) text(ctx *FmtCtx) string {
+	if d.Empty {
+		return "empty"
+	}
+	var buf bytes.Buffer
+	if d.Lower.Inclusive {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+	d.formatBound(ctx, &buf, d.Lower)
+	buf.WriteByte(',')
+	d.formatBound(ctx, &buf, d.Upper)
+	if d.Upper.Inclusive {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+func (d *DRange) formatBound(ctx *FmtCtx, buf *bytes.Buffer, b RangeBound) {
+	if b.IsInfinite() {
+		return
+	}
+	flags := FmtBareStrings | (ctx.flags & fmtPgwireFormat)
+	s := AsStringWithFlags(
+		b.Val, flags, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location),
+	)
+	formatRangeBoundString(buf, s)
+}
+
+var rangeQuoteSet asciiSet
+
+func init() {
+	var ok bool
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
+}
+
+// formatRangeBoundString writes the text representation of a range bound value
+// to buf, quoting it like Postgres does if it contains special characters.
+// Within quotes, double quotes and backslashes are doubled.
+func formatRangeBoundString(buf *bytes.Buffer, in string) {
+	quote := in == "" || rangeQuoteSet.in(in)
+	if quote {
+		buf.WriteByte('"')
+	}
+	for _, r := range in {
+		if r == '"' || r == '\\' {
+			buf.WriteByte(byte(r))
+		}
+		buf.WriteRune(r)
+	}
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower.Val != nil {
+		sz += d.Lower.Val.Size()
+	}
+	if d.Upper.Val != nil {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// ContainsValue returns whether the range contains the given value of its
+// subtype.
+func (d *DRange) ContainsValue(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if !d.Lower.IsInfinite() {
+		c := compareRangeValues(d.Lower.Val, v)
+		if c > 0 || (c == 0 && !d.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !d.Upper.IsInfinite() {
+		c := compareRangeValues(d.Upper.Val, v)
+		if c < 0 || (c == 0 && !d.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsRange returns whether the range contains all of the values of the
+// other range.
+func (d *DRange) ContainsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Lower, true) <= 0 &&
+		compareRangeBounds(d.Upper, false, other.Upper, false) >= 0
+}
+
+// Overlaps returns whether the range has any values in common with the other
+// range.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Upper, false) <= 0 &&
+		compareRangeBounds(other.Lower, true, d.Upper, false) <= 0
+}
+
+// IsAdjacent returns whether the range and the other range do not overlap but
+// there are no values between them, i.e. whether their union is contiguous.
+func (d *DRange) IsAdjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.Upper, other.Lower) || rangeBoundsAdjacent(other.Upper, d.Lower)
+}
+
+// rangeBoundsAdjacent returns whether the given upper bound is adjacent to the
+// given lower bound. Since ranges of discrete subtypes are canonicalized, this
+// is the case iff both bounds have the same value and exactly one of them is
+// inclusive.
+func rangeBoundsAdjacent(upper, lower RangeBound) bool {
+	if upper.IsInfinite() || lower.IsInfinite() {
+		return false
+	}
+	return compareRangeValues(upper.Val, lower.Val) == 0 && upper.Inclusive != lower.Inclusive
+}
+
+// Merge returns the smallest range that contains both the range and the other
+// range. This implements the range_merge builtin.
+func (d *DRange) Merge(other *DRange) *DRange {
+	if d.Empty {
+		return other
+	}
+	if other.Empty {
+		return d
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(other.Lower, true, lower, true) < 0 {
+		lower = other.Lower
+	}
+	if compareRangeBounds(other.Upper, false, upper, false) > 0 {
+		upper = other.Upper
+	}
+	return newDRangeFromBounds(d.typ, lower, upper)
+}
+
+// Union returns the union of the range and the other range. An error is
+// returned if the union is not contiguous.
+func (d *DRange) Union(other *DRange) (*DRange, error) {
+	if !d.Empty && !other.Empty && !d.Overlaps(other) && !d.IsAdjacent(other) {
+		return nil, pgerror.New(pgcode.DataException, "result of range union would not be contiguous")
+	}
+	return d.Merge(other), nil
+}
+
+// Intersect returns the intersection of the range and the other range.
+func (d *DRange) Intersect(other *DRange) *DRange {
+	if !d.Overlaps(other) {
+		return NewEmptyDRange(d.typ)
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(other.Lower, true, lower, true) > 0 {
+		lower = other.Lower
+	}
+	if compareRangeBounds(other.Upper, false, upper, false) < 0 {
+		upper = other.Upper
+	}
+	return newDRangeFromBounds(d.typ, lower, upper)
+}
+
+// Difference returns the values of the range which are not in the other range.
+// An error is returned if the result is not contiguous.
+func (d *DRange) Difference(other *DRange) (*DRange, error) {
+	pieces := d.minus(other)
+	switch len(pieces) {
+	case 0:
+		return NewEmptyDRange(d.typ), nil
+	case 1:
+		return pieces[0], nil
+	default:
+		return nil, pgerror.New(pgcode.DataException, "result of range difference would not be contiguous")
+	}
+}
+
+// minus returns the non-empty ranges which contain the values of the range
+// which are not in the other range. There are at most two such ranges.
+func (d *DRange) minus(other *DRange) []*DRange {
+	if d.Empty {
+		return nil
+	}
+	if !d.Overlaps(other) {
+		return []*DRange{d}
+	}
+	var pieces []*DRange
+	if compareRangeBounds(d.Lower, true, other.Lower, true) < 0 {
+		// The values below the lower bound of the other range remain. The lower
+		// bound of the other range cannot be infinite here.
+		upper := RangeBound{Val: other.Lower.Val, Inclusive: !other.Lower.Inclusive}
+		if r := newDRangeFromBounds(d.typ, d.Lower, upper); !r.Empty {
+			pieces = append(pieces, r)
+		}
+	}
+	if compareRangeBounds(d.Upper, false, other.Upper, false) > 0 {
+		lower := RangeBound{Val: other.Upper.Val, Inclusive: !other.Upper.Inclusive}
+		if r := newDRangeFromBounds(d.typ, lower, d.Upper); !r.Empty {
+			pieces = append(pieces, r)
+		}
+	}
+	return pieces
+}
+
+// DMultiRange is the Datum representation of a multirange type. The ranges of a
+// multirange are always normalized: they are non-empty, sorted, and do not
+// overlap or touch each other.
+type DMultiRange struct {
+	typ    *types.T
+	Ranges []*DRange
+}
+
+// NewDMultiRange returns a multirange of the given multirange type containing
+// the values of the given ranges, which are normalized.
+func NewDMultiRange(typ *types.T, ranges []*DRange) *DMultiRange {
+	normalized := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			normalized = append(normalized, r)
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		return normalized[i].compare(normalized[j]) < 0
+	})
+	// Merge the ranges which overlap or are adjacent to the previous one.
+	n := 0
+	for _, r := range normalized {
+		if n > 0 && (normalized[n-1].Overlaps(r) || normalized[n-1].IsAdjacent(r)) {
+			normalized[n-1] = normalized[n-1].Merge(r)
+			continue
+		}
+		normalized[n] = r
+		n++
+	}
+	return &DMultiRange{typ: typ, Ranges: normalized[:n]}
+}
+
+// AsDMultiRange attempts to retrieve a *DMultiRange from an Expr, returning a
+// *DMultiRange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultiRange wrapped by a *DOidWrapper is possible.
+func AsDMultiRange(e Expr) (*DMultiRange, bool) {
+	switch t := e.(type) {
+	case *DMultiRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultiRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultiRange attempts to retrieve a *DMultiRange from an Expr, panicking
+// if the assertion fails.
+func MustBeDMultiRange(e Expr) *DMultiRange {
+	m, ok := AsDMultiRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultiRange, found %T", e))
+	}
+	return m
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultiRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// Compare implements the Datum interface.
+func (d *DMultiRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMultiRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultiRange)
+	if !ok || d.typ.Oid() != v.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	// Multiranges are compared range by range, and a multirange that is a
+	// prefix of another is smaller.
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		if c := d.Ranges[i].compare(v.Ranges[i]); c != 0 {
+			return c, nil
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultiRange) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultiRange) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultiRange) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultiRange) IsMin(ctx CompareContext) bool {
+	return len(d.Ranges) == 0
+}
+
+// Max implements the Datum interface.
+func (d *DMultiRange) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultiRange) Min(ctx CompareContext) (Datum, bool) {
+	return &DMultiRange{typ: d.typ}, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+// AmbiguousFormat implements the Datum interface.
+func (*DMultiRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMultiRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	var buf strings.Builder
+	buf.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(r.text(ctx))
+	}
+	buf.WriteByte('}')
+	str := buf.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DMultiRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsEmpty returns whether the multirange contains no values.
+func (d *DMultiRange) IsEmpty() bool {
+	return len(d.Ranges) == 0
+}
+
+// Span returns the smallest range containing all of the values of the
+// multirange.
+func (d *DMultiRange) Span() *DRange {
+	rangeTyp := d.typ.MultiRangeContents()
+	if d.IsEmpty() {
+		return NewEmptyDRange(rangeTyp)
+	}
+	return newDRangeFromBounds(rangeTyp, d.Ranges[0].Lower, d.Ranges[len(d.Ranges)-1].Upper)
+}
+
+// ContainsValue returns whether the multirange contains the given value of its
+// subtype.
+func (d *DMultiRange) ContainsValue(v Datum) bool {
+	for _, r := range d.Ranges {
+		if r.ContainsValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsRange returns whether the multirange contains all of the values of
+// the given range.
+func (d *DMultiRange) ContainsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	// Since the ranges of the multirange do not touch each other, the given
+	// range must be contained in one of them.
+	for _, r := range d.Ranges {
+		if r.ContainsRange(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsMultiRange returns whether the multirange contains all of the values
+// of the other multirange.
+func (d *DMultiRange) ContainsMultiRange(other *DMultiRange) bool {
+	for _, r := range other.Ranges {
+		if !d.ContainsRange(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// OverlapsRange returns whether the multirange has any values in common with
+// the given range.
+func (d *DMultiRange) OverlapsRange(other *DRange) bool {
+	for _, r := range d.Ranges {
+		if r.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverlapsMultiRange returns whether the multirange has any values in common
+// with the other multirange.
+func (d *DMultiRange) OverlapsMultiRange(other *DMultiRange) bool {
+	for _, r := range other.Ranges {
+		if d.OverlapsRange(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdjacent returns whether the multirange and the other multirange do not
+// overlap but there are no values between them.
+func (d *DMultiRange) IsAdjacent(other *DMultiRange) bool {
+	return d.Span().IsAdjacent(other.Span())
+}
+
+// Union returns the union of the multirange and the other multirange.
+func (d *DMultiRange) Union(other *DMultiRange) *DMultiRange {
+	ranges := make([]*DRange, 0, len(d.Ranges)+len(other.Ranges))
+	ranges = append(ranges, d.Ranges...)
+	ranges = append(ranges, other.Ranges...)
+	return NewDMultiRange(d.typ, ranges)
+}
+
+// Intersect returns the intersection of the multirange and the other
+// multirange.
+func (d *DMultiRange) Intersect(other *DMultiRange) *DMultiRange {
+	var ranges []*DRange
+	for _, r1 := range d.Ranges {
+		for _, r2 := range other.Ranges {
+			if r := r1.Intersect(r2); !r.Empty {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+	return NewDMultiRange(d.typ, ranges)
+}
+
+// Difference returns the values of the multirange which are not in the other
+// multirange.
+func (d *DMultiRange) Difference(other *DMultiRange) *DMultiRange {
+	ranges := d.Ranges
+	for _, r2 := range other.Ranges {
+		var remaining []*DRange
+		for _, r1 := range ranges {
+			remaining = append(remaining, r1.minus(r2)...)
+		}
+		ranges = remaining
+	}
+	return NewDMultiRange(d.typ, ranges)
+}
+
+// multiRangeOperand returns the given range or multirange operand as a
+// multirange, so that operators with mixed operands can be evaluated using the
+// multirange methods. It returns false if the operand is neither a range nor a
+// multirange.
+func multiRangeOperand(d Datum) (*DMultiRange, bool) {
+	switch t := d.(type) {
+	case *DMultiRange:
+		return t, true
+	case *DRange:
+		return NewDMultiRange(types.MakeMultiRange(t.typ), []*DRange{t}), true
+	}
+	return nil, false
+}
+
+// RangeContains implements the @> operator. The container must be a range or a
+// multirange, and the containee a range, a multirange, or a value of the
+// subtype of the container.
+func RangeContains(container, containee Datum) bool {
+	if l, ok := container.(*DRange); ok {
+		switch r := containee.(type) {
+		case *DRange:
+			return l.ContainsRange(r)
+		case *DMultiRange:
+			return r.IsEmpty() || l.ContainsRange(r.Span())
+		default:
+			return l.ContainsValue(r)
+		}
+	}
+	l := MustBeDMultiRange(container)
+	switch r := containee.(type) {
+	case *DRange:
+		return l.ContainsRange(r)
+	case *DMultiRange:
+		return l.ContainsMultiRange(r)
+	default:
+		return l.ContainsValue(r)
+	}
+}
+
+// RangeOverlaps implements the && operator for ranges and multiranges.
+func RangeOverlaps(left, right Datum) bool {
+	if l, ok := left.(*DRange); ok {
+		if r, ok := right.(*DRange); ok {
+			return l.Overlaps(r)
+		}
+	}
+	l, _ := multiRangeOperand(left)
+	r, _ := multiRangeOperand(right)
+	return l.OverlapsMultiRange(r)
+}
+
+// RangeAdjacent implements the -|- operator for ranges and multiranges.
+func RangeAdjacent(left, right Datum) bool {
+	if l, ok := left.(*DRange); ok {
+		if r, ok := right.(*DRange); ok {
+			return l.IsAdjacent(r)
+		}
+	}
+	l, _ := multiRangeOperand(left)
+	r, _ := multiRangeOperand(right)
+	return l.IsAdjacent(r)
+}
@@ -169,18 +169,24 @@ func (node *DropRole) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Names)
 }
 
-// DropType represents a DROP TYPE command.
+// DropType represents a DROP TYPE or DROP DOMAIN command.
 type DropType struct {
 	Names        []*UnresolvedObjectName
 	IfExists     bool
 	DropBehavior DropBehavior
+	// Domain is true if this is a DROP DOMAIN command.
+	Domain bool
 }
 
 var _ Statement = &DropType{}
 
 // Format implements the NodeFormatter interface.
 func (node *DropType) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP TYPE ")
+	if node.Domain {
+		ctx.WriteString("DROP DOMAIN ")
+	} else {
+		ctx.WriteString("DROP TYPE ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
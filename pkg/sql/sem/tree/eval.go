@@ -464,10 +464,67 @@ func initNonArrayToNonArrayConcatenation() {
 	}
 }
 
+// initRangeOperators adds the union (+), intersection (*) and difference (-)
+// operators for ranges and multiranges.
+func initRangeOperators() {
+	addRangeBinOps := func(t *types.T) {
+		for _, op := range []struct {
+			sym    treebin.BinaryOperatorSymbol
+			evalOp BinaryEvalOp
+		}{
+			{treebin.Plus, &UnionRangeOp{}},
+			{treebin.Mult, &IntersectRangeOp{}},
+			{treebin.Minus, &DifferenceRangeOp{}},
+		} {
+			addBinOp(op.sym, &BinOp{
+				LeftType:   t,
+				RightType:  t,
+				ReturnType: t,
+				EvalOp:     op.evalOp,
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	for _, t := range types.RangeTypes {
+		addRangeBinOps(t)
+	}
+	for _, t := range types.MultiRangeTypes {
+		addRangeBinOps(t)
+	}
+}
+
+// initGeometricOperators adds the distance (<->) operator for the geometric
+// types. The distance can be computed between any two geometric values except
+// lines, which only support the distance to points and other lines.
+func initGeometricOperators() {
+	addDistance := func(a, b *types.T) {
+		addBinOp(treebin.Distance, &BinOp{
+			LeftType:   a,
+			RightType:  b,
+			ReturnType: types.Float,
+			EvalOp:     &DistanceGeometricOp{},
+			Volatility: volatility.Immutable,
+		})
+	}
+	isLineOrPoint := func(t *types.T) bool {
+		return t.Oid() == oid.T_line || t.Oid() == oid.T_point
+	}
+	for _, a := range types.GeometricTypes {
+		for _, b := range types.GeometricTypes {
+			if (a.Oid() == oid.T_line || b.Oid() == oid.T_line) && !(isLineOrPoint(a) && isLineOrPoint(b)) {
+				continue
+			}
+			addDistance(a, b)
+		}
+	}
+}
+
 func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
+	initGeometricOperators()
 }
 
 func init() {
@@ -1537,7 +1594,7 @@ func makeIsFn(a, b *types.T, v volatility.V) *CmpOp {
 
 // CmpOps contains the comparison operations indexed by operation type.
 var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
-	treecmp.EQ: {overloads: []*CmpOp{
+	treecmp.EQ: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeEqFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeEqFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1596,9 +1653,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeComparisonOperators(makeEqFn)...)},
 
-	treecmp.LT: {overloads: []*CmpOp{
+	treecmp.LT: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeLtFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeLtFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1655,9 +1712,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeComparisonOperators(makeLtFn)...)},
 
-	treecmp.LE: {overloads: []*CmpOp{
+	treecmp.LE: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeLeFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeLeFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1714,9 +1771,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeComparisonOperators(makeLeFn)...)},
 
-	treecmp.IsNotDistinctFrom: {overloads: []*CmpOp{
+	treecmp.IsNotDistinctFrom: {overloads: append([]*CmpOp{
 		{
 			LeftType:  types.Unknown,
 			RightType: types.Unknown,
@@ -1805,9 +1862,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeComparisonOperators(makeIsFn)...)},
 
-	treecmp.In: {overloads: []*CmpOp{
+	treecmp.In: {overloads: append([]*CmpOp{
 		makeEvalTupleIn(types.AnyEnum, volatility.Leakproof),
 		makeEvalTupleIn(types.Bool, volatility.Leakproof),
 		makeEvalTupleIn(types.Bytes, volatility.Leakproof),
@@ -1833,7 +1890,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.TimestampTZ, volatility.Leakproof),
 		makeEvalTupleIn(types.Uuid, volatility.Leakproof),
 		makeEvalTupleIn(types.VarBit, volatility.Leakproof),
-	}},
+	}, makeRangeComparisonOperators(func(t, _ *types.T, v volatility.V) *CmpOp {
+		return makeEvalTupleIn(t, v)
+	})...)},
 
 	treecmp.Like: {overloads: []*CmpOp{
 		{
@@ -1913,7 +1972,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1926,9 +1985,12 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, append(
+		makeRangeContainmentOperators(&ContainsRangeOp{}, false /* containedBy */),
+		makeGeometricContainmentOperators(&ContainsGeometricOp{}, false /* containedBy */)...,
+	)...)},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1941,7 +2003,10 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, append(
+		makeRangeContainmentOperators(&ContainedByRangeOp{}, true /* containedBy */),
+		makeGeometricContainmentOperators(&ContainedByGeometricOp{}, true /* containedBy */)...,
+	)...)},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1955,11 +2020,14 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
+	}, append(makeBox2DComparisonOperators(
 		func(lhs, rhs *geo.CartesianBoundingBox) bool {
 			return lhs.Intersects(rhs)
 		},
-	)...),
+	), append(
+		makeRangeOperators(&OverlapsRangeOp{}),
+		makeGeometricOperators(&OverlapsGeometricOp{}, types.Box, types.Polygon, types.Circle)...,
+	)...)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -1975,6 +2043,10 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			Volatility: volatility.Immutable,
 		},
 	}},
+	treecmp.Adjacent: {overloads: makeRangeOperators(&AdjacentRangeOp{})},
+	treecmp.Same: {overloads: makeGeometricOperators(
+		&SameGeometricOp{}, types.Point, types.Box, types.Polygon, types.Circle,
+	)},
 })
 
 func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bool) []*CmpOp {
@@ -2026,6 +2098,100 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// makeRangeComparisonOperators returns the overloads of the ordering
+// comparison operator constructed by makeFn for all of the range and multirange
+// types.
+func makeRangeComparisonOperators(makeFn func(a, b *types.T, v volatility.V) *CmpOp) []*CmpOp {
+	ops := make([]*CmpOp, 0, len(types.RangeTypes)+len(types.MultiRangeTypes))
+	for _, t := range types.RangeTypes {
+		ops = append(ops, makeFn(t, t, volatility.Immutable))
+	}
+	for _, t := range types.MultiRangeTypes {
+		ops = append(ops, makeFn(t, t, volatility.Immutable))
+	}
+	return ops
+}
+
+// makeRangeOperators returns the overloads of a range operator which accepts
+// any combination of a range and the multirange of the same range type.
+func makeRangeOperators(evalOp BinaryEvalOp) []*CmpOp {
+	var ops []*CmpOp
+	for i, r := range types.RangeTypes {
+		mr := types.MultiRangeTypes[i]
+		for _, operands := range [][2]*types.T{{r, r}, {r, mr}, {mr, r}, {mr, mr}} {
+			ops = append(ops, &CmpOp{
+				LeftType:   operands[0],
+				RightType:  operands[1],
+				EvalOp:     evalOp,
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return ops
+}
+
+// makeRangeContainmentOperators returns the overloads of the @> operator, or of
+// the <@ operator if containedBy is true, for ranges and multiranges. A range
+// or multirange can contain a range or multirange of the same range type, or a
+// value of its subtype.
+func makeRangeContainmentOperators(evalOp BinaryEvalOp, containedBy bool) []*CmpOp {
+	ops := makeRangeOperators(evalOp)
+	for i, r := range types.RangeTypes {
+		for _, container := range []*types.T{r, types.MultiRangeTypes[i]} {
+			op := &CmpOp{
+				LeftType:   container,
+				RightType:  r.RangeContents(),
+				EvalOp:     evalOp,
+				Volatility: volatility.Immutable,
+			}
+			if containedBy {
+				op.LeftType, op.RightType = op.RightType, op.LeftType
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// makeGeometricOperators returns the overloads of a geometric operator which
+// accepts two values of the same type, for each of the given types.
+func makeGeometricOperators(evalOp BinaryEvalOp, typs ...*types.T) []*CmpOp {
+	ops := make([]*CmpOp, len(typs))
+	for i, t := range typs {
+		ops[i] = &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     evalOp,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return ops
+}
+
+// makeGeometricContainmentOperators returns the overloads of the @> operator,
+// or of the <@ operator if containedBy is true, for the geometric types. All of
+// the geometric types except points can contain a point, and boxes, polygons
+// and circles can also contain a value of the same type.
+func makeGeometricContainmentOperators(evalOp BinaryEvalOp, containedBy bool) []*CmpOp {
+	ops := makeGeometricOperators(evalOp, types.Box, types.Polygon, types.Circle)
+	for _, t := range types.GeometricTypes {
+		if t.Oid() == oid.T_point {
+			continue
+		}
+		op := &CmpOp{
+			LeftType:   t,
+			RightType:  types.Point,
+			EvalOp:     evalOp,
+			Volatility: volatility.Immutable,
+		}
+		if containedBy {
+			op.LeftType, op.RightType = op.RightType, op.LeftType
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
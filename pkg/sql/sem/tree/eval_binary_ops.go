@@ -387,3 +387,37 @@ type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
+
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
+// AdjacentRangeOp is a BinaryEvalOp.
+type AdjacentRangeOp struct{}
+
+type (
+	// UnionRangeOp is a BinaryEvalOp.
+	UnionRangeOp struct{}
+	// IntersectRangeOp is a BinaryEvalOp.
+	IntersectRangeOp struct{}
+	// DifferenceRangeOp is a BinaryEvalOp.
+	DifferenceRangeOp struct{}
+)
+
+type (
+	// SameGeometricOp is a BinaryEvalOp.
+	SameGeometricOp struct{}
+	// DistanceGeometricOp is a BinaryEvalOp.
+	DistanceGeometricOp struct{}
+	// ContainsGeometricOp is a BinaryEvalOp.
+	ContainsGeometricOp struct{}
+	// ContainedByGeometricOp is a BinaryEvalOp.
+	ContainedByGeometricOp struct{}
+	// OverlapsGeometricOp is a BinaryEvalOp.
+	OverlapsGeometricOp struct{}
+)
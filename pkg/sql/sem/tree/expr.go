@@ -602,6 +602,20 @@ func (node *IsOfTypeExpr) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// GroupingExpr represents the GROUPING(a, b, ...) function, which returns a
+// bit mask indicating which of its arguments are not grouped on in the
+// grouping set of the current row.
+type GroupingExpr struct {
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingExpr) Format(ctx *FmtCtx) {
+	ctx.WriteString("GROUPING(")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // IfErrExpr represents an IFERROR expression.
 type IfErrExpr struct {
 	Cond    Expr
@@ -1045,7 +1059,7 @@ var binaryOpPrio = [...]int{
 	treebin.Pow:  1,
 	treebin.Mult: 2, treebin.Div: 2, treebin.FloorDiv: 2, treebin.Mod: 2,
 	treebin.Plus: 3, treebin.Minus: 3,
-	treebin.LShift: 4, treebin.RShift: 4,
+	treebin.LShift: 4, treebin.RShift: 4, treebin.Distance: 4,
 	treebin.Bitand: 5,
 	treebin.Bitxor: 6,
 	treebin.Bitor:  7,
@@ -1058,7 +1072,7 @@ var binaryOpFullyAssoc = [...]bool{
 	treebin.Pow:  false,
 	treebin.Mult: true, treebin.Div: false, treebin.FloorDiv: false, treebin.Mod: false,
 	treebin.Plus: true, treebin.Minus: false,
-	treebin.LShift: false, treebin.RShift: false,
+	treebin.LShift: false, treebin.RShift: false, treebin.Distance: false,
 	treebin.Bitand: true,
 	treebin.Bitxor: true,
 	treebin.Bitor:  true,
@@ -1275,6 +1289,11 @@ type FuncExpr struct {
 	// InCall is true when the FuncExpr is part of a CALL statement.
 	InCall bool
 
+	// Variadic is true when the last argument is marked VARIADIC, in which
+	// case it is an array that is bound directly to the VARIADIC parameter of
+	// the routine: f(a, VARIADIC ARRAY[b, c]).
+	Variadic bool
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1386,7 +1405,17 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if node.Variadic && len(node.Exprs) > 0 {
+		n := len(node.Exprs) - 1
+		for i := 0; i < n; i++ {
+			ctx.FormatNode(node.Exprs[i])
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString("VARIADIC ")
+		ctx.FormatNode(node.Exprs[n])
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
@@ -1737,7 +1766,10 @@ func (node *DDecimal) String() string         { return AsString(node) }
 func (node *DFloat) String() string           { return AsString(node) }
 func (node *DBox2D) String() string           { return AsString(node) }
 func (node *DPGLSN) String() string           { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
+func (node *DMultiRange) String() string      { return AsString(node) }
 func (node *DGeography) String() string       { return AsString(node) }
+func (node *DGeometric) String() string       { return AsString(node) }
 func (node *DGeometry) String() string        { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
 func (node *DInterval) String() string        { return AsString(node) }
@@ -1756,6 +1788,8 @@ func (node *DVoid) String() string            { return AsString(node) }
 func (node *Exprs) String() string            { return AsString(node) }
 func (node *ArrayFlatten) String() string     { return AsString(node) }
 func (node *FuncExpr) String() string         { return AsString(node) }
+func (node *GroupingExpr) String() string     { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node *IfExpr) String() string           { return AsString(node) }
 func (node *IfErrExpr) String() string        { return AsString(node) }
 func (node *IndexedVar) String() string       { return AsString(node) }
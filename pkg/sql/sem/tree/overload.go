@@ -285,6 +285,27 @@ type Overload struct {
 	// OutParamTypes contains types of all OUT parameters (it has 1-to-1 match
 	// with OutParamOrdinals).
 	OutParamTypes TypeList
+	// UserDefinedAggregate is set if the overload represents a user-defined
+	// aggregate function. Class is AggregateClass in this case.
+	UserDefinedAggregate *UserDefinedAggregate
+}
+
+// UserDefinedAggregate describes a user-defined aggregate function created with
+// CREATE AGGREGATE.
+type UserDefinedAggregate struct {
+	// TransitionFunc is the OID of the state transition function.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the final function. It is zero if the result of
+	// the aggregate is its final state.
+	FinalFunc oid.Oid
+	// CombineFunc is the OID of the function that merges two partial states.
+	// It is zero if the aggregate cannot be evaluated in multiple stages.
+	CombineFunc oid.Oid
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// InitCond is the string representation of the initial state. The initial
+	// state is NULL if it is nil.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
@@ -414,6 +435,7 @@ type TypeList interface {
 var _ TypeList = ParamTypes{}
 var _ TypeList = HomogeneousType{}
 var _ TypeList = VariadicType{}
+var _ TypeList = VariadicParamTypes{}
 
 // ParamTypes is a list of function parameter names and their types.
 type ParamTypes []ParamType
@@ -633,6 +655,114 @@ func (v VariadicType) String() string {
 	return s.String()
 }
 
+// VariadicParamTypes is a TypeList implementation for user-defined routines
+// whose last input parameter is VARIADIC. The last parameter has an array type
+// and collects one or more trailing arguments of the array's element type.
+//
+// Unlike the other matching methods, MatchIdentical and MatchAtIdentical match
+// the declared signature of the routine, in which the VARIADIC parameter has
+// the array type, since they are used to look up a routine by its signature.
+type VariadicParamTypes []ParamType
+
+// Match is part of the TypeList interface.
+func (p VariadicParamTypes) Match(types []*types.T) bool {
+	if !p.MatchLen(len(types)) {
+		return false
+	}
+	for i := range types {
+		if !p.MatchAt(types[i], i) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchIdentical is part of the TypeList interface.
+func (p VariadicParamTypes) MatchIdentical(types []*types.T) bool {
+	return ParamTypes(p).MatchIdentical(types)
+}
+
+// MatchAt is part of the TypeList interface.
+func (p VariadicParamTypes) MatchAt(typ *types.T, i int) bool {
+	if i < len(p)-1 {
+		return ParamTypes(p).MatchAt(typ, i)
+	}
+	return typ.Family() == types.UnknownFamily || p.GetAt(i).Equivalent(typ)
+}
+
+// MatchAtIdentical is part of the TypeList interface.
+func (p VariadicParamTypes) MatchAtIdentical(typ *types.T, i int) bool {
+	return ParamTypes(p).MatchAtIdentical(typ, i)
+}
+
+// MatchLen is part of the TypeList interface.
+func (p VariadicParamTypes) MatchLen(l int) bool {
+	return l >= len(p)
+}
+
+// GetAt is part of the TypeList interface.
+func (p VariadicParamTypes) GetAt(i int) *types.T {
+	if i < len(p)-1 {
+		return p[i].Typ
+	}
+	return p[len(p)-1].Typ.ArrayContents()
+}
+
+// Length is part of the TypeList interface.
+func (p VariadicParamTypes) Length() int {
+	return len(p)
+}
+
+// Types is part of the TypeList interface. It returns the types of the
+// declared signature, in which the VARIADIC parameter has the array type.
+func (p VariadicParamTypes) Types() []*types.T {
+	return ParamTypes(p).Types()
+}
+
+func (p VariadicParamTypes) String() string {
+	var s strings.Builder
+	for i, param := range p {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		if i == len(p)-1 {
+			s.WriteString("VARIADIC ")
+		}
+		s.WriteString(param.Name)
+		s.WriteString(": ")
+		s.WriteString(param.Typ.String())
+	}
+	return s.String()
+}
+
+// variadicCallOverloads returns the overloads that can be called with the
+// given number of arguments when the last argument is marked VARIADIC. Only
+// routines with a VARIADIC parameter qualify, and the array argument is bound
+// directly to that parameter, so the returned overloads match the declared
+// signature of the routine.
+func variadicCallOverloads(overloads []QualifiedOverload, numArgs int) []QualifiedOverload {
+	var ret []QualifiedOverload
+	for _, o := range overloads {
+		if p, ok := o.Types.(VariadicParamTypes); ok && len(p) == numArgs {
+			ret = append(ret, MakeQualifiedOverload(o.Schema, withVariadicArrayParam(o.Overload)))
+		}
+	}
+	return ret
+}
+
+// withVariadicArrayParam returns a copy of the given overload in which the
+// VARIADIC parameter, if any, accepts an array argument rather than the
+// trailing arguments of the array's element type.
+func withVariadicArrayParam(o *Overload) *Overload {
+	p, ok := o.Types.(VariadicParamTypes)
+	if !ok {
+		return o
+	}
+	ret := *o
+	ret.Types = ParamTypes(p)
+	return &ret
+}
+
 // UnknownReturnType is returned from ReturnTypers when the arguments provided are
 // not sufficient to determine a return type. This is necessary for cases like overload
 // resolution, where the argument types are not resolved yet so the type-level function
@@ -1014,6 +1144,11 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		}
 	}
 
+	// Like in Postgres, a routine with a fixed number of parameters is preferred
+	// over a variadic routine that accepts the same argument types once its
+	// VARIADIC parameter is expanded.
+	s.overloadIdxs = filterShadowedVariadicOverloads(s.overloadIdxs, s.overloads, s.params, exprsLen)
+
 	// At this point, all remaining overload candidates accept the argument list,
 	// so we begin checking for a single remaining candidate implementation to choose.
 	// In case there is more than one candidate remaining, the following code uses
@@ -1395,6 +1530,44 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 	return nil
 }
 
+// filterShadowedVariadicOverloads removes the overloads with VARIADIC
+// parameters for which another remaining overload without a VARIADIC parameter
+// has identical parameter types for the given number of arguments.
+func filterShadowedVariadicOverloads(
+	idxs []uint8, overloads []overloadImpl, params []TypeList, numArgs int,
+) []uint8 {
+	var fixed []TypeList
+	var foundVariadic bool
+	for _, idx := range idxs {
+		if _, ok := params[idx].(VariadicParamTypes); ok {
+			foundVariadic = true
+		} else if routineType, _, _ := overloads[idx].outParamInfo(); routineType != ProcedureRoutine {
+			fixed = append(fixed, params[idx])
+		}
+	}
+	if !foundVariadic || len(fixed) == 0 {
+		return idxs
+	}
+	return filterParams(idxs, overloads, params, func(_ overloadImpl, p TypeList) bool {
+		if _, ok := p.(VariadicParamTypes); !ok {
+			return true
+		}
+		for _, other := range fixed {
+			if !other.MatchLen(numArgs) {
+				continue
+			}
+			identical := true
+			for i := 0; identical && i < numArgs; i++ {
+				identical = other.GetAt(i).Identical(p.GetAt(i))
+			}
+			if identical {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 // filterAttempt attempts to filter the overloads down to a single candidate.
 // If it succeeds, it will return true, along with the overload (in a slice for
 // convenience) and a possible error. If it fails, it will return false and
@@ -1577,6 +1750,9 @@ func getFuncSig(expr *FuncExpr, typedInputExprs []TypedExpr, desiredType *types.
 	for _, expr := range typedInputExprs {
 		typeNames = append(typeNames, expr.ResolvedType().String())
 	}
+	if n := len(typeNames); expr.Variadic && n > 0 {
+		typeNames[n-1] = "VARIADIC " + typeNames[n-1]
+	}
 	var desStr string
 	if desiredType.Family() != types.AnyFamily {
 		desStr = fmt.Sprintf(" (desired <%s>)", desiredType)
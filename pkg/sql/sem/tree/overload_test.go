@@ -95,13 +95,42 @@ func TestVariadicFunctions(t *testing.T) {
 	}
 }
 
+func TestVariadicParamTypes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	p := VariadicParamTypes{{Name: "a", Typ: types.Int}, {Name: "b", Typ: types.StringArray}}
+	require.Equal(t, "a: int, VARIADIC b: string[]", p.String())
+	for _, tc := range []struct {
+		args    []*types.T
+		matches bool
+	}{
+		{[]*types.T{types.Int}, false},
+		{[]*types.T{types.Int, types.String}, true},
+		{[]*types.T{types.Int, types.String, types.Unknown, types.String}, true},
+		{[]*types.T{types.Unknown, types.String}, true},
+		{[]*types.T{types.Int, types.StringArray}, false},
+		{[]*types.T{types.Int, types.String, types.Int}, false},
+	} {
+		require.Equal(t, tc.matches, p.Match(tc.args), "%v", tc.args)
+	}
+	// The identical variants match the declared signature.
+	require.True(t, p.MatchIdentical([]*types.T{types.Int, types.StringArray}))
+	require.False(t, p.MatchIdentical([]*types.T{types.Int, types.String}))
+	require.False(t, p.MatchIdentical([]*types.T{types.Int, types.StringArray, types.StringArray}))
+	require.Equal(t, []*types.T{types.Int, types.StringArray}, p.Types())
+}
+
 type testOverload struct {
 	paramTypes ParamTypes
 	retType    *types.T
 	pref       bool
+	variadic   bool
 }
 
 func (to *testOverload) params() TypeList {
+	if to.variadic {
+		return VariadicParamTypes(to.paramTypes)
+	}
 	return to.paramTypes
 }
 
@@ -122,6 +151,11 @@ func (to testOverload) withPreferred(pref bool) *testOverload {
 	return &to
 }
 
+func (to testOverload) withVariadic() *testOverload {
+	to.variadic = true
+	return &to
+}
+
 func (to *testOverload) String() string {
 	typeNames := make([]string, len(to.paramTypes))
 	for i, param := range to.paramTypes {
@@ -183,6 +217,8 @@ func TestTypeCheckOverloadedExprs(t *testing.T) {
 	binaryStringFloatFn2 := makeTestOverload(types.Float, types.String, types.Float)
 	binaryIntDateFn := makeTestOverload(types.Date, types.Int, types.Date)
 	binaryArrayIntFn := makeTestOverload(types.Int, types.AnyArray, types.Int)
+	variadicIntFn := makeTestOverload(types.Int, types.IntArray).withVariadic()
+	variadicStringIntFn := makeTestOverload(types.Int, types.String, types.IntArray).withVariadic()
 
 	// Out-of-band values used below to distinguish error cases.
 	unsupported := &testOverload{}
@@ -274,6 +310,17 @@ func TestTypeCheckOverloadedExprs(t *testing.T) {
 		// array_length where the array argument is a placeholder (#36153).
 		{nil, []Expr{placeholder(0), intConst("1")}, []overloadImpl{binaryArrayIntFn}, unsupported, false},
 		{nil, []Expr{placeholder(0), intConst("1")}, []overloadImpl{binaryArrayIntFn}, unsupported, true},
+		// Variadic routines.
+		{nil, []Expr{}, []overloadImpl{variadicIntFn}, unsupported, false},
+		{nil, []Expr{intConst("1")}, []overloadImpl{binaryIntFn, variadicIntFn}, variadicIntFn, false},
+		{nil, []Expr{intConst("1"), intConst("2")}, []overloadImpl{binaryIntFn, variadicIntFn}, binaryIntFn, false},
+		{nil, []Expr{NewDInt(1), DNull}, []overloadImpl{binaryIntFn, variadicIntFn}, binaryIntFn, false},
+		{nil, []Expr{intConst("1"), intConst("2"), intConst("3")}, []overloadImpl{binaryIntFn, variadicIntFn}, variadicIntFn, false},
+		{nil, []Expr{intConst("1"), intConst("2")}, []overloadImpl{binaryFloatFn, variadicIntFn}, variadicIntFn, false},
+		{nil, []Expr{NewDFloat(1), NewDFloat(2)}, []overloadImpl{binaryFloatFn, variadicIntFn}, binaryFloatFn, false},
+		{nil, []Expr{NewDString("a")}, []overloadImpl{variadicIntFn, variadicStringIntFn}, unsupported, false},
+		{nil, []Expr{NewDString("a"), intConst("1")}, []overloadImpl{variadicIntFn, variadicStringIntFn}, variadicStringIntFn, false},
+		{nil, []Expr{intConst("1"), intConst("2")}, []overloadImpl{variadicIntFn, variadicStringIntFn}, variadicIntFn, false},
 	}
 	ctx := context.Background()
 	for i, d := range testData {
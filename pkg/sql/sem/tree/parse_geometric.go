@@ -0,0 +1,320 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// ParseDGeometric parses the text representation of a value of one of the
+// geometric types. The accepted formats are the ones of Postgres, for example:
+//
+//	point:   (x,y) or x,y
+//	line:    {A,B,C} or [(x1,y1),(x2,y2)]
+//	lseg:    [(x1,y1),(x2,y2)] or ((x1,y1),(x2,y2))
+//	box:     (x1,y1),(x2,y2) or ((x1,y1),(x2,y2))
+//	path:    [(x1,y1),...] for an open path or ((x1,y1),...) for a closed one
+//	polygon: ((x1,y1),...)
+//	circle:  <(x,y),r> or ((x,y),r)
+func ParseDGeometric(s string, t *types.T) (*DGeometric, error) {
+	d, err := parseGeometric(s, t)
+	if err != nil {
+		if errors.HasType(err, (*geometricParseError)(nil)) {
+			return nil, MakeParseError(s, t, nil)
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// geometricParseError is returned by the geometricDecoder when the input is
+// malformed. It is replaced by a parse error mentioning the whole input.
+type geometricParseError struct{}
+
+func (*geometricParseError) Error() string { return "invalid geometric value" }
+
+var errGeometricParse = &geometricParseError{}
+
+func parseGeometric(s string, t *types.T) (*DGeometric, error) {
+	d := geometricDecoder{s: s}
+	switch t.Oid() {
+	case oid.T_point:
+		p, err := d.pair()
+		if err != nil {
+			return nil, err
+		}
+		if err := d.end(); err != nil {
+			return nil, err
+		}
+		return NewDPoint(p), nil
+
+	case oid.T_line:
+		d.skipSpaces()
+		if d.consume('{') {
+			var coefs [3]float64
+			for i := range coefs {
+				if i > 0 && !d.consumeAfterSpaces(',') {
+					return nil, errGeometricParse
+				}
+				f, err := d.float()
+				if err != nil {
+					return nil, err
+				}
+				coefs[i] = f
+			}
+			if !d.consumeAfterSpaces('}') {
+				return nil, errGeometricParse
+			}
+			if err := d.end(); err != nil {
+				return nil, err
+			}
+			return NewDLine(coefs[0], coefs[1], coefs[2])
+		}
+		points, _, err := d.path(true /* allowOpen */, 2)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.end(); err != nil {
+			return nil, err
+		}
+		return NewDLineFromPoints(points[0], points[1])
+
+	case oid.T_lseg, oid.T_box:
+		points, _, err := d.path(t.Oid() == oid.T_lseg /* allowOpen */, 2)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.end(); err != nil {
+			return nil, err
+		}
+		if t.Oid() == oid.T_lseg {
+			return NewDLSeg(points[0], points[1]), nil
+		}
+		return NewDBox(points[0], points[1]), nil
+
+	case oid.T_path:
+		n := pairCount(s)
+		if n <= 0 {
+			return nil, errGeometricParse
+		}
+		// A single leading parenthesis encloses the whole path.
+		depth := 0
+		d.skipSpaces()
+		if rest := d.s[d.pos:]; strings.HasPrefix(rest, "(") && strings.LastIndexByte(rest, '(') == 0 {
+			d.pos++
+			depth++
+		}
+		points, open, err := d.path(true /* allowOpen */, n)
+		if err != nil {
+			return nil, err
+		}
+		if depth > 0 && !d.consumeAfterSpaces(')') {
+			return nil, errGeometricParse
+		}
+		if err := d.end(); err != nil {
+			return nil, err
+		}
+		return NewDPath(points, !open), nil
+
+	case oid.T_polygon:
+		n := pairCount(s)
+		if n <= 0 {
+			return nil, errGeometricParse
+		}
+		points, _, err := d.path(false /* allowOpen */, n)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.end(); err != nil {
+			return nil, err
+		}
+		return NewDPolygon(points), nil
+
+	case oid.T_circle:
+		depth := 0
+		d.skipSpaces()
+		if d.consume('<') {
+			depth++
+		} else if d.peekPairAfterParen() {
+			// Two opening parentheses: the first one encloses the whole circle.
+			d.pos++
+			depth++
+		}
+		center, err := d.pair()
+		if err != nil {
+			return nil, err
+		}
+		if !d.consumeAfterSpaces(',') {
+			return nil, errGeometricParse
+		}
+		radius, err := d.float()
+		if err != nil {
+			return nil, err
+		}
+		for ; depth > 0; depth-- {
+			d.skipSpaces()
+			if !d.consume(')') && !(depth == 1 && d.consume('>')) {
+				return nil, errGeometricParse
+			}
+		}
+		if err := d.end(); err != nil {
+			return nil, err
+		}
+		return NewDCircle(center, radius)
+	}
+	return nil, errors.AssertionFailedf("unexpected geometric type %s", t)
+}
+
+// pairCount returns the number of points in the text representation of a
+// path or a polygon, or -1 if the number of commas does not match a list of
+// points.
+func pairCount(s string) int {
+	n := strings.Count(s, ",")
+	if n%2 == 0 {
+		return -1
+	}
+	return (n + 1) / 2
+}
+
+// geometricDecoder decodes the text representation of geometric values,
+// following pair_decode and path_decode of Postgres.
+type geometricDecoder struct {
+	s   string
+	pos int
+}
+
+func (d *geometricDecoder) skipSpaces() {
+	for d.pos < len(d.s) && isGeometricSpace(d.s[d.pos]) {
+		d.pos++
+	}
+}
+
+func isGeometricSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
+
+// consume advances past the byte c if it is the next byte of the input.
+func (d *geometricDecoder) consume(c byte) bool {
+	if d.pos < len(d.s) && d.s[d.pos] == c {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+func (d *geometricDecoder) consumeAfterSpaces(c byte) bool {
+	d.skipSpaces()
+	return d.consume(c)
+}
+
+// peekPairAfterParen returns whether the input continues with an opening
+// parenthesis followed by another one, ignoring spaces.
+func (d *geometricDecoder) peekPairAfterParen() bool {
+	if d.pos >= len(d.s) || d.s[d.pos] != '(' {
+		return false
+	}
+	rest := strings.TrimLeft(d.s[d.pos+1:], " \t\n\r\v\f")
+	return strings.HasPrefix(rest, "(")
+}
+
+// end returns an error if anything but spaces is left in the input.
+func (d *geometricDecoder) end() error {
+	d.skipSpaces()
+	if d.pos != len(d.s) {
+		return errGeometricParse
+	}
+	return nil
+}
+
+// float decodes a floating point number, including NaN and infinities.
+func (d *geometricDecoder) float() (float64, error) {
+	d.skipSpaces()
+	start := d.pos
+	for d.pos < len(d.s) {
+		c := d.s[d.pos]
+		if isGeometricSpace(c) || strings.IndexByte(",()[]<>{}", c) >= 0 {
+			break
+		}
+		d.pos++
+	}
+	f, err := strconv.ParseFloat(d.s[start:d.pos], 64)
+	if err != nil {
+		return 0, errGeometricParse
+	}
+	return f, nil
+}
+
+// pair decodes a point written as x,y or (x,y).
+func (d *geometricDecoder) pair() (GeometricPoint, error) {
+	d.skipSpaces()
+	paren := d.consume('(')
+	x, err := d.float()
+	if err != nil {
+		return GeometricPoint{}, err
+	}
+	if !d.consumeAfterSpaces(',') {
+		return GeometricPoint{}, errGeometricParse
+	}
+	y, err := d.float()
+	if err != nil {
+		return GeometricPoint{}, err
+	}
+	if paren && !d.consumeAfterSpaces(')') {
+		return GeometricPoint{}, errGeometricParse
+	}
+	return GeometricPoint{X: x, Y: y}, nil
+}
+
+// path decodes n points, which may be enclosed in parentheses or, if
+// allowOpen is set, in square brackets. It returns whether square brackets
+// were used.
+func (d *geometricDecoder) path(allowOpen bool, n int) (_ []GeometricPoint, open bool, _ error) {
+	depth := 0
+	d.skipSpaces()
+	if d.consume('[') {
+		if !allowOpen {
+			return nil, false, errGeometricParse
+		}
+		open = true
+		depth++
+	} else if d.peekPairAfterParen() || (d.pos < len(d.s) && d.s[d.pos] == '(' &&
+		strings.LastIndexByte(d.s[d.pos:], '(') == 0) {
+		// Either two opening parentheses, the first of which encloses the list
+		// of points, or a single one enclosing a list of bare coordinates.
+		d.pos++
+		depth++
+	}
+	points := make([]GeometricPoint, n)
+	for i := range points {
+		p, err := d.pair()
+		if err != nil {
+			return nil, false, err
+		}
+		points[i] = p
+		d.consumeAfterSpaces(',')
+	}
+	for ; depth > 0; depth-- {
+		d.skipSpaces()
+		if !d.consume(')') && !(open && depth == 1 && d.consume(']')) {
+			return nil, false, errGeometricParse
+		}
+	}
+	return points, open, nil
+}
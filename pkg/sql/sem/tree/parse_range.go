@@ -0,0 +1,266 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const emptyRangeLiteral = "empty"
+
+func malformedRangeLiteralError(s string, t *types.T, detail string) error {
+	return errors.WithDetail(
+		MakeParseError(s, t, errors.New("malformed range literal")),
+		detail,
+	)
+}
+
+func malformedMultiRangeLiteralError(s string, t *types.T, detail string) error {
+	return errors.WithDetail(
+		MakeParseError(s, t, errors.New("malformed multirange literal")),
+		detail,
+	)
+}
+
+// ParseDRangeFromString parses the string-form of a range of type t, e.g.
+// "[1,10)" or "empty", like range_in in Postgres.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParser{s: s, t: t}
+	p.skipWhitespace()
+	if p.hasEmptyKeyword() {
+		p.pos += len(emptyRangeLiteral)
+		p.skipWhitespace()
+		if !p.eof() {
+			return nil, false, malformedRangeLiteralError(s, t, "Junk after \"empty\" key word.")
+		}
+		return NewEmptyDRange(t), false, nil
+	}
+
+	var lower, upper RangeBound
+	switch p.next() {
+	case '[':
+		lower.Inclusive = true
+	case '(':
+	default:
+		return nil, false, malformedRangeLiteralError(s, t, "Missing left parenthesis or bracket.")
+	}
+	lowerStr, lowerInf, err := p.parseBound()
+	if err != nil {
+		return nil, false, err
+	}
+	if p.next() != ',' {
+		return nil, false, malformedRangeLiteralError(s, t, "Missing comma after lower bound.")
+	}
+	upperStr, upperInf, err := p.parseBound()
+	if err != nil {
+		return nil, false, err
+	}
+	switch p.next() {
+	case ']':
+		upper.Inclusive = true
+	case ')':
+	case ',':
+		return nil, false, malformedRangeLiteralError(s, t, "Too many commas.")
+	default:
+		return nil, false, malformedRangeLiteralError(s, t, "Missing right parenthesis or bracket.")
+	}
+	p.skipWhitespace()
+	if !p.eof() {
+		return nil, false, malformedRangeLiteralError(s, t, "Junk after right parenthesis or bracket.")
+	}
+
+	subtyp := t.RangeContents()
+	if !lowerInf {
+		var dep bool
+		lower.Val, dep, err = ParseAndRequireString(subtyp, lowerStr, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || dep
+	}
+	if !upperInf {
+		var dep bool
+		upper.Val, dep, err = ParseAndRequireString(subtyp, upperStr, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || dep
+	}
+	r, err := NewDRange(t, lower, upper)
+	return r, dependsOnContext, err
+}
+
+// ParseDMultiRangeFromString parses the string-form of a multirange of type t,
+// e.g. "{[1,3), [5,7)}", like multirange_in in Postgres.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultiRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultiRange, dependsOnContext bool, _ error) {
+	p := rangeParser{s: s, t: t}
+	p.skipWhitespace()
+	if p.next() != '{' {
+		return nil, false, malformedMultiRangeLiteralError(s, t, "Missing left brace.")
+	}
+	var ranges []*DRange
+	p.skipWhitespace()
+	if p.peek() == '}' {
+		p.pos++
+	} else {
+		for {
+			p.skipWhitespace()
+			start := p.pos
+			if p.hasEmptyKeyword() {
+				p.pos += len(emptyRangeLiteral)
+			} else {
+				if c := p.peek(); c != '[' && c != '(' {
+					return nil, false, malformedMultiRangeLiteralError(s, t, "Expected range start.")
+				}
+				if !p.skipRangeLiteral() {
+					return nil, false, malformedMultiRangeLiteralError(s, t, "Unexpected end of input.")
+				}
+			}
+			r, dep, err := ParseDRangeFromString(ctx, s[start:p.pos], t.MultiRangeContents())
+			if err != nil {
+				return nil, false, err
+			}
+			dependsOnContext = dependsOnContext || dep
+			ranges = append(ranges, r)
+			p.skipWhitespace()
+			c := p.next()
+			if c == '}' {
+				break
+			}
+			if c != ',' {
+				return nil, false, malformedMultiRangeLiteralError(s, t, "Expected comma or end of multirange.")
+			}
+		}
+	}
+	p.skipWhitespace()
+	if !p.eof() {
+		return nil, false, malformedMultiRangeLiteralError(s, t, "Junk after closing right brace.")
+	}
+	return NewDMultiRange(t, ranges), dependsOnContext, nil
+}
+
+// rangeParser is a helper for parsing the text representation of ranges and
+// multiranges.
+type rangeParser struct {
+	s   string
+	t   *types.T
+	pos int
+}
+
+func (p *rangeParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+// peek returns the next byte of the input, or 0 at the end of the input.
+func (p *rangeParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// next consumes and returns the next byte of the input, or 0 at the end of the
+// input.
+func (p *rangeParser) next() byte {
+	c := p.peek()
+	if !p.eof() {
+		p.pos++
+	}
+	return c
+}
+
+func (p *rangeParser) skipWhitespace() {
+	for !p.eof() && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *rangeParser) hasEmptyKeyword() bool {
+	return len(p.s)-p.pos >= len(emptyRangeLiteral) &&
+		strings.EqualFold(p.s[p.pos:p.pos+len(emptyRangeLiteral)], emptyRangeLiteral)
+}
+
+// parseBound parses the bound of a range up to the following comma or closing
+// bracket or parenthesis. Double quotes can be used to quote special
+// characters, and backslashes escape the following character. A bound with no
+// characters is infinite.
+func (p *rangeParser) parseBound() (_ string, infinite bool, _ error) {
+	switch p.peek() {
+	case ',', ')', ']':
+		return "", true, nil
+	}
+	var sb strings.Builder
+	inQuote := false
+	for {
+		c := p.peek()
+		if !inQuote && (c == ',' || c == ')' || c == ']') {
+			return sb.String(), false, nil
+		}
+		if p.eof() {
+			return "", false, malformedRangeLiteralError(p.s, p.t, "Unexpected end of input.")
+		}
+		p.pos++
+		switch c {
+		case '\\':
+			if p.eof() {
+				return "", false, malformedRangeLiteralError(p.s, p.t, "Unexpected end of input.")
+			}
+			sb.WriteByte(p.next())
+		case '"':
+			if !inQuote {
+				inQuote = true
+			} else if p.peek() == '"' {
+				// A doubled quote within quotes is a literal quote.
+				sb.WriteByte(p.next())
+			} else {
+				inQuote = false
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+// skipRangeLiteral advances past a non-empty range literal, which ends with the
+// first closing bracket or parenthesis which is not quoted or escaped. It
+// returns false if the end of the input is reached first.
+func (p *rangeParser) skipRangeLiteral() bool {
+	inQuote := false
+	p.pos++
+	for !p.eof() {
+		c := p.next()
+		switch c {
+		case '\\':
+			p.pos++
+		case '"':
+			inQuote = !inQuote
+		case ')', ']':
+			if !inQuote {
+				return true
+			}
+		}
+	}
+	return false
+}
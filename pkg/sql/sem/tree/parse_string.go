@@ -69,6 +69,10 @@ func ParseAndRequireString(
 		d, err = ParseDIntervalWithTypeMetadata(intervalStyle(ctx), s, itm)
 	case types.PGLSNFamily:
 		d, err = ParseDPGLSN(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultiRangeFamily:
+		d, dependsOnContext, err = ParseDMultiRangeFromString(ctx, s, t)
 	case types.RefCursorFamily:
 		d = NewDRefCursor(s)
 	case types.Box2DFamily:
@@ -77,6 +81,8 @@ func ParseAndRequireString(
 		d, err = ParseDGeography(s)
 	case types.GeometryFamily:
 		d, err = ParseDGeometry(s)
+	case types.GeometricFamily:
+		d, err = ParseDGeometric(s, t)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
 	case types.OidFamily:
@@ -729,6 +729,18 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 
 	if len(node.Exprs) > 0 {
 		args := node.Exprs.doc(p)
+		if node.Variadic {
+			n := len(node.Exprs) - 1
+			argDocs := make([]pretty.Doc, len(node.Exprs))
+			for i, e := range node.Exprs {
+				if p.Simplify {
+					e = StripParens(e)
+				}
+				argDocs[i] = p.Doc(e)
+			}
+			argDocs[n] = pretty.ConcatSpace(pretty.Keyword("VARIADIC"), argDocs[n])
+			args = p.commaSeparated(argDocs...)
+		}
 		if node.Type != 0 {
 			args = pretty.ConcatLine(
 				pretty.Text(funcTypeName[node.Type]),
@@ -1314,7 +1326,7 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// CREATE [TEMP] VIEW name ( ... ) AS
+	// CREATE [TEMP] [RECURSIVE] VIEW name ( ... ) AS
 	//     SELECT ...
 	//
 	title := pretty.Keyword("CREATE")
@@ -1327,6 +1339,9 @@ func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	if node.Materialized {
 		title = pretty.ConcatSpace(title, pretty.Keyword("MATERIALIZED"))
 	}
+	if node.Recursive {
+		title = pretty.ConcatSpace(title, pretty.Keyword("RECURSIVE"))
+	}
 	title = pretty.ConcatSpace(title, pretty.Keyword("VIEW"))
 	if node.IfNotExists {
 		title = pretty.ConcatSpace(title, pretty.Keyword("IF NOT EXISTS"))
@@ -1628,7 +1643,7 @@ func (node *ShardedIndexDef) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	// CREATE [UNIQUE] [INVERTED] INDEX [name]
-	//    ON tbl (cols...)
+	//    ON tbl [USING brin] (cols...)
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
@@ -1656,10 +1671,15 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	}
 
 	clauses := make([]pretty.Doc, 0, 7)
-	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace,
-		pretty.Keyword("ON"),
-		p.Doc(&node.Table),
-		p.bracket("(", p.Doc(&node.Columns), ")")))
+	on := []pretty.Doc{pretty.Keyword("ON"), p.Doc(&node.Table)}
+	if node.BlockRange {
+		on = append(on, pretty.Keyword("USING brin"))
+	}
+	if node.Hash {
+		on = append(on, pretty.Keyword("USING hash"))
+	}
+	on = append(on, p.bracket("(", p.Doc(&node.Columns), ")"))
+	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace, on...))
 
 	if node.Sharded != nil {
 		clauses = append(clauses, p.Doc(node.Sharded))
@@ -1781,6 +1801,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE | VISIBILITY ...]
 	//
@@ -1790,9 +1811,13 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE | VISIBILITY ...]
 	//
+	if node.IsExclusion() {
+		return p.docAsString(node)
+	}
 	clauses := make([]pretty.Doc, 0, 6)
 	var title pretty.Doc
 	if node.PrimaryKey {
@@ -1821,6 +1846,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if d := p.Doc(&node.Deferrability); d != pretty.Nil {
+		clauses = append(clauses, d)
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1851,6 +1879,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1858,8 +1887,9 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
-	clauses := make([]pretty.Doc, 0, 4)
+	clauses := make([]pretty.Doc, 0, 5)
 	title := pretty.ConcatSpace(
 		pretty.Keyword("FOREIGN KEY"),
 		p.bracket("(", p.Doc(&node.FromCols), ")"))
@@ -1884,6 +1914,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if d := p.Doc(&node.Deferrability); d != pretty.Nil {
+		clauses = append(clauses, d)
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2062,7 +2096,7 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if node.References.Col != "" {
 			fkHead = pretty.ConcatSpace(fkHead, p.bracket("(", p.Doc(&node.References.Col), ")"))
 		}
-		fkDetails := make([]pretty.Doc, 0, 2)
+		fkDetails := make([]pretty.Doc, 0, 3)
 		// We omit MATCH SIMPLE because it is the default.
 		if node.References.Match != MatchSimple {
 			fkDetails = append(fkDetails, pretty.Keyword(node.References.Match.String()))
@@ -2070,6 +2104,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if d := p.Doc(&node.References.Deferrability); d != pretty.Nil {
+			fkDetails = append(fkDetails, d)
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -2147,6 +2184,16 @@ func (node *ReferenceActions) doc(p *PrettyCfg) pretty.Doc {
 	return pretty.Fold(pretty.ConcatSpace, docs...)
 }
 
+func (node *ConstraintDeferrability) doc(p *PrettyCfg) pretty.Doc {
+	if !node.Deferrable {
+		return pretty.Nil
+	}
+	if node.InitiallyDeferred {
+		return pretty.Keyword("DEFERRABLE INITIALLY DEFERRED")
+	}
+	return pretty.Keyword("DEFERRABLE")
+}
+
 func (node *Backup) doc(p *PrettyCfg) pretty.Doc {
 	items := make([]pretty.TableRow, 0, 7)
 
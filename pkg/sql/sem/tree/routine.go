@@ -12,11 +12,13 @@ package tree
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // RoutinePlanGenerator generates a plan for the execution of each statement
@@ -135,6 +137,19 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// StmtInfos, if set, identifies the PL/pgSQL statement that each body
+	// statement was built from, so that execution statistics can be collected
+	// for it. The i-th element corresponds to the i-th plan generated by
+	// ForEachPlan, and is nil if the body statement does not correspond to a
+	// single PL/pgSQL statement.
+	StmtInfos []*RoutineStmtInfo
+
+	// CalledAfterStmt, if set, identifies the PL/pgSQL statement at the end of
+	// whose body statement this continuation routine is called. The execution
+	// of the statement is considered finished once the routine starts, so that
+	// the time spent in the routine is not attributed to the statement.
+	CalledAfterStmt *RoutineStmtInfo
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -151,6 +166,8 @@ func NewTypedRoutineExpr(
 	procedure bool,
 	blockState *BlockState,
 	cursorDeclaration *RoutineOpenCursor,
+	stmtInfos []*RoutineStmtInfo,
+	calledAfterStmt *RoutineStmtInfo,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -165,6 +182,8 @@ func NewTypedRoutineExpr(
 		Procedure:         procedure,
 		BlockState:        blockState,
 		CursorDeclaration: cursorDeclaration,
+		StmtInfos:         stmtInfos,
+		CalledAfterStmt:   calledAfterStmt,
 	}
 }
 
@@ -218,6 +237,47 @@ type RoutineOpenCursor struct {
 	// CursorSQL is a formatted string used to associate the original SQL
 	// statement with the cursor.
 	CursorSQL string
+
+	// Dynamic is true if the cursor is opened with OPEN ... FOR EXECUTE. In this
+	// case, the first body statement returns a single row with the query string
+	// and a tuple of the USING arguments. The query is planned and executed,
+	// and its result used to open the cursor, when the routine is evaluated.
+	// CursorSQL is unset.
+	Dynamic bool
+}
+
+// RoutineStmtInfo identifies a statement within a PL/pgSQL routine. It is used
+// to attribute execution statistics to the statements of the routine.
+type RoutineStmtInfo struct {
+	// RoutineSignature is the name of the routine followed by its parameter
+	// types, e.g. "f(bigint)".
+	RoutineSignature string
+
+	// RoutineID is the OID of the routine.
+	RoutineID oid.Oid
+
+	// RoutineVersion is the version of the routine's descriptor.
+	RoutineVersion uint64
+
+	// StmtID uniquely identifies the statement within the routine.
+	StmtID uint
+
+	// LineNo is the line number of the statement within the routine body.
+	LineNo int
+
+	// StmtType describes the kind of statement, e.g. "SQL statement" or
+	// "RAISE". It uses the same names as the PG_CONTEXT diagnostics item.
+	StmtType string
+}
+
+// Context returns a description of the statement in the format used by the
+// PG_CONTEXT diagnostics item, e.g.:
+//
+//	PL/pgSQL function f(bigint) line 3 at RAISE
+func (info *RoutineStmtInfo) Context() string {
+	return fmt.Sprintf(
+		"PL/pgSQL function %s line %d at %s", info.RoutineSignature, info.LineNo, info.StmtType,
+	)
 }
 
 // BlockState is shared state between all routines that make up a PLpgSQL block.
@@ -263,6 +323,12 @@ type BlockState struct {
 	// kv.SavepointToken to avoid import cycles.
 	SavepointTok interface{}
 
+	// NumNotifications is the number of notifications queued by NOTIFY in the
+	// transaction when the savepoint was created. The notifications queued
+	// after that are discarded when the exception handler rolls back to the
+	// savepoint.
+	NumNotifications int
+
 	// Cursors is a list of the names of cursors that have been opened within the
 	// current block. If the exception handler catches an exception, these cursors
 	// must be closed before the handler can proceed.
@@ -314,6 +380,11 @@ type TxnControlExpr struct {
 	Args  TypedExprs
 	Gen   TxnControlPlanGenerator
 
+	// Chain is true for COMMIT AND CHAIN and ROLLBACK AND CHAIN. The new
+	// transaction inherits the characteristics of the current transaction that
+	// are not set by Modes.
+	Chain bool
+
 	Name string
 	Typ  *types.T
 }
@@ -324,6 +395,7 @@ var _ Expr = &TxnControlExpr{}
 func NewTxnControlExpr(
 	opType StoredProcTxnOp,
 	txnModes TransactionModes,
+	chain bool,
 	args TypedExprs,
 	gen TxnControlPlanGenerator,
 	name string,
@@ -332,6 +404,7 @@ func NewTxnControlExpr(
 	return &TxnControlExpr{
 		Op:    opType,
 		Modes: txnModes,
+		Chain: chain,
 		Args:  args,
 		Gen:   gen,
 		Name:  name,
@@ -358,7 +431,11 @@ func (node *TxnControlExpr) Format(ctx *FmtCtx) {
 			panic(errors.AssertionFailedf("called Format for no-op txn control expr"))
 		}
 	}
-	ctx.Printf("%s; CALL %s(", node.Op, node.Name)
+	ctx.Printf("%s", node.Op)
+	if node.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
+	ctx.Printf("; CALL %s(", node.Name)
 	ctx.FormatNode(&node.Args)
 	ctx.WriteByte(')')
 }
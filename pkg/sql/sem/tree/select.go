@@ -780,6 +780,45 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType describes the kind of a GroupingSet.
+type GroupingSetType uint8
+
+const (
+	// RollupGroupingSet is ROLLUP (a, b, ...), which groups on every prefix of
+	// its elements, including the empty prefix.
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet is CUBE (a, b, ...), which groups on every subset of its
+	// elements.
+	CubeGroupingSet
+	// ExplicitGroupingSets is GROUPING SETS (...), which groups on each of the
+	// listed grouping sets.
+	ExplicitGroupingSets
+)
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. For ROLLUP and CUBE, each element of Exprs is an expression, or a
+// tuple of expressions which are treated as a single unit. For GROUPING SETS,
+// each element of Exprs is an expression, a tuple of expressions, or a nested
+// GroupingSet.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	switch node.Type {
+	case RollupGroupingSet:
+		ctx.WriteString("ROLLUP (")
+	case CubeGroupingSet:
+		ctx.WriteString("CUBE (")
+	case ExplicitGroupingSets:
+		ctx.WriteString("GROUPING SETS (")
+	}
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // presetTypesForTesting is a mapping of qualified names to types that can be mocked out
@@ -83,6 +84,12 @@ func SampleDatum(t *types.T) Datum {
 		return NewDOid(1009)
 	case types.PGLSNFamily:
 		return NewDPGLSN(0x1000000100)
+	case types.RangeFamily:
+		r, _ := NewDRange(t, RangeBound{Val: SampleDatum(t.RangeContents()), Inclusive: true}, RangeBound{})
+		return r
+	case types.MultiRangeFamily:
+		r := SampleDatum(t.MultiRangeContents()).(*DRange)
+		return NewDMultiRange(t, []*DRange{r})
 	case types.RefCursorFamily:
 		return NewDRefCursor("Wheezer")
 	case types.Box2DFamily:
@@ -92,6 +99,23 @@ func SampleDatum(t *types.T) Datum {
 		return NewDGeography(geo.MustParseGeographyFromEWKB([]byte("\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f")))
 	case types.GeometryFamily:
 		return NewDGeometry(geo.MustParseGeometryFromEWKB([]byte("\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f")))
+	case types.GeometricFamily:
+		s := "((1,2),(3,4),(5,6))"
+		switch t.Oid() {
+		case oid.T_point:
+			s = "(1,2)"
+		case oid.T_line:
+			s = "{1,2,3}"
+		case oid.T_lseg, oid.T_box:
+			s = "((1,2),(3,4))"
+		case oid.T_circle:
+			s = "<(1,2),3>"
+		}
+		d, err := ParseDGeometric(s, t)
+		if err != nil {
+			panic(err)
+		}
+		return d
 	default:
 		panic(errors.AssertionFailedf("SampleDatum not implemented for %s", t))
 	}
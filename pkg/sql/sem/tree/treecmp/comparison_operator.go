@@ -70,6 +70,8 @@ const (
 	JSONAllExists
 	Overlaps
 	TSMatches
+	Adjacent
+	Same
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -122,6 +124,8 @@ var comparisonOpName = [...]string{
 	JSONAllExists:     "?&",
 	Overlaps:          "&&",
 	TSMatches:         "@@",
+	Adjacent:          "-|-",
+	Same:              "~=",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -134,6 +138,17 @@ func (i ComparisonOperatorSymbol) String() string {
 	return comparisonOpName[i]
 }
 
+// ComparisonOperatorSymbolFromString returns the comparison operator symbol
+// whose String method returns the given name.
+func ComparisonOperatorSymbolFromString(name string) (ComparisonOperatorSymbol, bool) {
+	for i := range comparisonOpName {
+		if comparisonOpName[i] == name {
+			return ComparisonOperatorSymbol(i), true
+		}
+	}
+	return 0, false
+}
+
 // HasSubOperator returns if the ComparisonOperator is used with a sub-operator.
 func (i ComparisonOperatorSymbol) HasSubOperator() bool {
 	switch i {
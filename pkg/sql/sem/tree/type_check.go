@@ -69,6 +69,10 @@ type SemaContext struct {
 	// name of a table given its ID.
 	NameResolver QualifiedNameResolver
 
+	// CollationResolver manages resolving the names of the collations created
+	// with CREATE COLLATION into locales. It may be unset.
+	CollationResolver CollationResolver
+
 	Properties SemaProperties
 
 	// DateStyle refers to the DateStyle to parse as.
@@ -284,6 +288,53 @@ func (sc *SemaContext) GetTypeResolver() TypeReferenceResolver {
 	return sc.TypeResolver
 }
 
+// CollationResolver resolves the names of the collations created with CREATE
+// COLLATION into the locales they refer to.
+type CollationResolver interface {
+	// ResolveCollation returns the locale of the collation with the given name
+	// in the current database. found is false if there is no such collation.
+	ResolveCollation(ctx context.Context, name string) (locale string, found bool, err error)
+}
+
+// ResolveLocale returns the locale which the given collation name refers to.
+// The name is either the name of a collation created with CREATE COLLATION or
+// a locale, which is returned as is. Collations cannot be named after locales,
+// so locales are not looked up.
+func (sc *SemaContext) ResolveLocale(ctx context.Context, name string) (string, error) {
+	if sc == nil || sc.CollationResolver == nil {
+		return name, nil
+	}
+	if _, err := language.Parse(name); err == nil {
+		return name, nil
+	}
+	locale, found, err := sc.CollationResolver.ResolveCollation(ctx, name)
+	if err != nil || !found {
+		return name, err
+	}
+	return locale, nil
+}
+
+// ResolveCollatedType returns the given type with the collations of the
+// collated string types it contains resolved into locales with ResolveLocale.
+func (sc *SemaContext) ResolveCollatedType(ctx context.Context, typ *types.T) (*types.T, error) {
+	switch typ.Family() {
+	case types.CollatedStringFamily:
+		locale, err := sc.ResolveLocale(ctx, typ.Locale())
+		if err != nil || locale == typ.Locale() {
+			return typ, err
+		}
+		return types.MakeCollatedString(typ, locale), nil
+	case types.ArrayFamily:
+		contents, err := sc.ResolveCollatedType(ctx, typ.ArrayContents())
+		if err != nil || contents == typ.ArrayContents() {
+			return typ, err
+		}
+		return types.MakeArray(contents), nil
+	default:
+		return typ, nil
+	}
+}
+
 func placeholderTypeAmbiguityError(idx PlaceholderIdx) error {
 	return errors.WithHint(
 		pgerror.WithCandidateCode(
@@ -793,9 +844,19 @@ func (expr *CollateExpr) TypeCheck(
 	if collatedstring.IsDefaultEquivalentCollation(expr.Locale) {
 		return subExpr, nil
 	}
-	if _, err := language.Parse(expr.Locale); err != nil {
+	locale, err := semaCtx.ResolveLocale(ctx, expr.Locale)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := language.Parse(locale); err != nil {
 		return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
-			"invalid locale %s", expr.Locale)
+			"invalid locale %s", locale)
+	}
+	if locale != expr.Locale {
+		// The expression refers to a collation created with CREATE COLLATION.
+		// The typed expression uses its locale, leaving the original expression
+		// untouched.
+		expr = &CollateExpr{Expr: expr.Expr, Locale: locale}
 	}
 	t := subExpr.ResolvedType()
 	if types.IsStringType(t) {
@@ -811,6 +872,14 @@ func (expr *CollateExpr) TypeCheck(
 		"incompatible type for COLLATE: %s", t)
 }
 
+// newCollateExprForCoercion returns the given expression of a string type
+// with the given collation applied to it.
+func newCollateExprForCoercion(expr TypedExpr, locale string) TypedExpr {
+	c := &CollateExpr{Expr: expr, Locale: locale}
+	c.typ = types.MakeCollatedString(expr.ResolvedType(), locale)
+	return c
+}
+
 // NewTypeIsNotCompositeError generates an error suitable to report
 // when a ColumnAccessExpr or TupleStar is applied to a non-composite
 // type.
@@ -989,6 +1058,8 @@ var (
 	errInvalidDefaultUsage = pgerror.New(pgcode.Syntax, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
 	errInvalidMaxUsage     = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
 	errInvalidMinUsage     = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
+	errInvalidGroupingSet  = pgerror.New(pgcode.Syntax, "ROLLUP, CUBE and GROUPING SETS can only appear in a GROUP BY clause")
+	errInvalidGrouping     = pgerror.New(pgcode.Grouping, "GROUPING can only be used in a query with a GROUP BY clause")
 	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
 )
 
@@ -1194,8 +1265,13 @@ func (expr *FuncExpr) TypeCheck(
 		return sb.String()
 	}
 
+	overloads := def.Overloads
+	if expr.Variadic {
+		overloads = variadicCallOverloads(def.Overloads, len(expr.Exprs))
+	}
+
 	s := getOverloadTypeChecker(
-		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
+		(*qualifiedOverloads)(&overloads), expr.Exprs...,
 	)
 	defer s.release()
 
@@ -1211,7 +1287,7 @@ func (expr *FuncExpr) TypeCheck(
 			// resetting the UDF overloads to their original state.
 			var functionIdxs []int
 			var functionOverloads []QualifiedOverload
-			for idx, o := range def.Overloads {
+			for idx, o := range overloads {
 				if o.Type == UDFRoutine {
 					o.Type = ProcedureRoutine
 					functionIdxs = append(functionIdxs, idx)
@@ -1221,7 +1297,7 @@ func (expr *FuncExpr) TypeCheck(
 			if len(functionIdxs) > 0 {
 				defer func() {
 					for _, idx := range functionIdxs {
-						def.Overloads[idx].Type = UDFRoutine
+						overloads[idx].Type = UDFRoutine
 					}
 				}()
 				s2 := getOverloadTypeChecker((*qualifiedOverloads)(&functionOverloads), expr.Exprs...)
@@ -1247,13 +1323,13 @@ func (expr *FuncExpr) TypeCheck(
 	var hasUDFOverload bool
 	var calledOnNullInputFns, notCalledOnNullInputFns intsets.Fast
 	for _, idx := range s.overloadIdxs {
-		if def.Overloads[idx].CalledOnNullInput {
+		if overloads[idx].CalledOnNullInput {
 			calledOnNullInputFns.Add(int(idx))
 		} else {
 			notCalledOnNullInputFns.Add(int(idx))
 		}
 		// TODO(harding): Check if this is a record-returning UDF instead.
-		if def.Overloads[idx].Type == UDFRoutine {
+		if overloads[idx].Type == UDFRoutine {
 			hasUDFOverload = true
 		}
 	}
@@ -1273,7 +1349,7 @@ func (expr *FuncExpr) TypeCheck(
 			if s.typedExprs[i].ResolvedType().Family() == types.UnknownFamily {
 				var filtered intsets.Fast
 				for j, ok := notCalledOnNullInputFns.Next(0); ok; j, ok = notCalledOnNullInputFns.Next(j + 1) {
-					if def.Overloads[j].params().GetAt(i).Equivalent(types.String) {
+					if overloads[j].params().GetAt(i).Equivalent(types.String) {
 						filtered.Add(j)
 					}
 				}
@@ -1334,11 +1410,11 @@ func (expr *FuncExpr) TypeCheck(
 		// If the function is resolved by OID, we know that there is always only one
 		// overload qualified. As long as it passes the argument type checks above,
 		// there is no need to worry about the search path.
-		favoredOverload = def.Overloads[0]
+		favoredOverload = overloads[0]
 	} else {
 		// Get overloads from the most significant schema in search path.
 		favoredOverload, err = getMostSignificantOverload(
-			def.Overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
+			overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
 			func() string { return getFuncSig(expr, s.typedExprs, desired) },
 		)
 		if err != nil {
@@ -1357,6 +1433,9 @@ func (expr *FuncExpr) TypeCheck(
 		if err != nil {
 			return nil, err
 		}
+		if expr.Variadic {
+			overloadImpl = withVariadicArrayParam(overloadImpl)
+		}
 	}
 
 	if overloadImpl.Type == BuiltinRoutine && (def.Name == "min" || def.Name == "max") {
@@ -1802,6 +1881,22 @@ func (expr DefaultVal) TypeCheck(
 	return nil, errInvalidDefaultUsage
 }
 
+// TypeCheck implements the Expr interface. GroupingSets are only valid in a
+// GROUP BY clause, where they are handled by the optimizer.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingSet
+}
+
+// TypeCheck implements the Expr interface. GROUPING is replaced by the
+// optimizer when building a query with a GROUP BY clause.
+func (expr *GroupingExpr) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGrouping
+}
+
 // TypeCheck implements the Expr interface.
 func (expr PartitionMinVal) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -2102,12 +2197,30 @@ func (d *DPGLSN) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Typed
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultiRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeography) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DGeometric) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeometry) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -2700,6 +2813,25 @@ func typeCheckComparisonOp(
 		typeMismatch = !leftReturn.Equivalent(rightReturn)
 	}
 
+	// Like in Postgres, a string expression compared to a collated string takes
+	// the collation of the latter.
+	if len(s.overloadIdxs) == 0 {
+		coercedLeft, coercedRight, coerced := leftExpr, rightExpr, true
+		switch {
+		case leftFamily == types.CollatedStringFamily && rightFamily == types.StringFamily:
+			coercedRight = newCollateExprForCoercion(rightExpr, leftReturn.Locale())
+		case leftFamily == types.StringFamily && rightFamily == types.CollatedStringFamily:
+			coercedLeft = newCollateExprForCoercion(leftExpr, rightReturn.Locale())
+		default:
+			coerced = false
+		}
+		if coerced {
+			if fn, ok := ops.LookupImpl(coercedLeft.ResolvedType(), coercedRight.ResolvedType()); ok {
+				return coercedLeft, coercedRight, fn, false, nil
+			}
+		}
+	}
+
 	// Throw a typing error if overload resolution found either no compatible candidates
 	// or if it found an ambiguity.
 	if len(s.overloadIdxs) != 1 || typeMismatch {
@@ -0,0 +1,76 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sessiondata
+
+// ConstraintMode is the check timing of deferrable constraints, as set by SET
+// CONSTRAINTS.
+type ConstraintMode uint8
+
+const (
+	// ConstraintModeDefault indicates that the check timing of a constraint is
+	// determined by whether it is INITIALLY DEFERRED.
+	ConstraintModeDefault ConstraintMode = iota
+	// ConstraintModeImmediate indicates that constraints are checked at the end
+	// of each statement.
+	ConstraintModeImmediate
+	// ConstraintModeDeferred indicates that deferrable constraints are checked
+	// at the end of the transaction.
+	ConstraintModeDeferred
+)
+
+// ConstraintModes tracks the check timing of deferrable constraints for the
+// current transaction. The zero value leaves every constraint in its default
+// mode.
+//
+// ConstraintModes is shallow-copied when the SessionData is cloned, so the
+// Named map must never be mutated in place.
+type ConstraintModes struct {
+	// All is the mode set by SET CONSTRAINTS ALL.
+	All ConstraintMode
+	// Named contains the modes set for individual constraints since the last
+	// SET CONSTRAINTS ALL. They take precedence over All.
+	Named map[string]ConstraintMode
+}
+
+// IsDeferred returns whether the checks for the deferrable constraint with the
+// given name should be postponed until the end of the transaction.
+func (m ConstraintModes) IsDeferred(name string, initiallyDeferred bool) bool {
+	mode := m.All
+	if named, ok := m.Named[name]; ok {
+		mode = named
+	}
+	switch mode {
+	case ConstraintModeImmediate:
+		return false
+	case ConstraintModeDeferred:
+		return true
+	default:
+		return initiallyDeferred
+	}
+}
+
+// WithAll returns a copy of m with all constraints set to the given mode.
+func (m ConstraintModes) WithAll(mode ConstraintMode) ConstraintModes {
+	return ConstraintModes{All: mode}
+}
+
+// WithNamed returns a copy of m with the given constraints set to the given
+// mode.
+func (m ConstraintModes) WithNamed(names []string, mode ConstraintMode) ConstraintModes {
+	named := make(map[string]ConstraintMode, len(m.Named)+len(names))
+	for k, v := range m.Named {
+		named[k] = v
+	}
+	for _, name := range names {
+		named[name] = mode
+	}
+	return ConstraintModes{All: m.All, Named: named}
+}
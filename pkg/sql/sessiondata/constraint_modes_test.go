@@ -0,0 +1,37 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sessiondata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintModes(t *testing.T) {
+	var m ConstraintModes
+	require.False(t, m.IsDeferred("a", false /* initiallyDeferred */))
+	require.True(t, m.IsDeferred("a", true /* initiallyDeferred */))
+
+	all := m.WithAll(ConstraintModeDeferred)
+	require.True(t, all.IsDeferred("a", false /* initiallyDeferred */))
+
+	named := all.WithNamed([]string{"a"}, ConstraintModeImmediate)
+	require.False(t, named.IsDeferred("a", true /* initiallyDeferred */))
+	require.True(t, named.IsDeferred("b", false /* initiallyDeferred */))
+	// The original modes must not be modified.
+	require.True(t, all.IsDeferred("a", false /* initiallyDeferred */))
+
+	// SET CONSTRAINTS ALL overrides the modes of the named constraints.
+	reset := named.WithAll(ConstraintModeImmediate)
+	require.False(t, reset.IsDeferred("b", true /* initiallyDeferred */))
+	require.Empty(t, reset.Named)
+}
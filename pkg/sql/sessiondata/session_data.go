@@ -174,6 +174,10 @@ type LocalUnmigratableSessionData struct {
 	// IsSSL indicates whether the session is using SSL/TLS.
 	IsSSL bool
 
+	// ConstraintModes contains the check timing of deferrable constraints set
+	// by SET CONSTRAINTS. It is reset at the end of each transaction.
+	ConstraintModes ConstraintModes
+
 	// ////////////////////////////////////////////////////////////////////////
 	// WARNING: consider whether a session parameter you're adding needs to  //
 	// be propagated to the remote nodes or needs to persist amongst session //
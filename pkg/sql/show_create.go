@@ -86,6 +86,9 @@ func ShowCreateTable(
 		fmtFlags |= tree.FmtMarkRedactionNode | tree.FmtOmitNameRedaction
 	}
 	f := p.ExtendedEvalContext().FmtCtx(fmtFlags)
+	if desc.IsForeignTable() {
+		return showCreateForeignTable(ctx, p, f, tn, desc, displayOptions)
+	}
 	f.WriteString("CREATE ")
 	if desc.IsTemporary() {
 		f.WriteString("TEMP ")
@@ -197,6 +200,10 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	if err := showBlockRangeIndexes(tn, desc, f); err != nil {
+		return "", err
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
@@ -206,6 +213,73 @@ func ShowCreateTable(
 	return f.CloseAndGetString(), nil
 }
 
+// showBlockRangeIndexes appends the CREATE INDEX statements of the block
+// range indexes of the table, which are not part of CREATE TABLE.
+func showBlockRangeIndexes(
+	tn *tree.TableName, desc catalog.TableDescriptor, f *tree.FmtCtx,
+) error {
+	for _, idx := range desc.GetBlockRangeIndexes() {
+		col, err := catalog.MustFindColumnByID(desc, idx.ColumnID)
+		if err != nil {
+			return err
+		}
+		f.WriteString(";\n")
+		f.FormatNode(&tree.CreateIndex{
+			Name:       tree.Name(idx.Name),
+			Table:      *tn,
+			BlockRange: true,
+			Columns:    tree.IndexElemList{{Column: tree.Name(col.GetName())}},
+			StorageParams: tree.StorageParams{{
+				Key:   "pages_per_range",
+				Value: tree.NewDInt(tree.DInt(idx.RangeSize)),
+			}},
+		})
+	}
+	return nil
+}
+
+// showCreateForeignTable returns the CREATE FOREIGN TABLE statement of a
+// foreign table. Its hidden rowid column is not part of the statement.
+func showCreateForeignTable(
+	ctx context.Context,
+	p PlanHookState,
+	f *tree.FmtCtx,
+	tn *tree.TableName,
+	desc catalog.TableDescriptor,
+	displayOptions ShowCreateDisplayOptions,
+) (string, error) {
+	f.WriteString("CREATE FOREIGN TABLE ")
+	f.FormatNode(tn)
+	f.WriteString(" (")
+	for i, col := range desc.VisibleColumns() {
+		if i != 0 {
+			f.WriteString(",")
+		}
+		f.WriteString("\n\t")
+		colstr, err := schemaexpr.FormatColumnForDisplay(
+			ctx, desc, col, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(),
+			displayOptions.RedactableValues,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(colstr)
+	}
+	f.WriteString("\n) SERVER ")
+	ft := desc.GetForeignTable()
+	f.FormatNameP(&ft.Server)
+	opts := foreignTableOptions(ft)
+	f.WriteByte(' ')
+	f.FormatNode(&opts)
+
+	if !displayOptions.IgnoreComments {
+		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
+			return "", err
+		}
+	}
+	return f.CloseAndGetString(), nil
+}
+
 // formatQuoteNames quotes and adds commas between names.
 func formatQuoteNames(buf *bytes.Buffer, names ...string) {
 	f := tree.NewFmtCtx(tree.FmtSimple)
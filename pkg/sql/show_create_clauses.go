@@ -139,7 +139,13 @@ func ShowCreateView(
 			f.WriteRune(',')
 		}
 	}
-	f.WriteString(") AS ")
+	f.WriteString(")")
+	if storageParams := desc.GetStorageParams(true /* spaceBetweenEqual */); len(storageParams) > 0 {
+		f.WriteString(" WITH (")
+		f.WriteString(strings.Join(storageParams, ", "))
+		f.WriteString(")")
+	}
+	f.WriteString(" AS ")
 
 	cfg := tree.DefaultPrettyCfg()
 	cfg.UseTabs = true
@@ -586,6 +592,12 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+		if fk.InitiallyDeferred {
+			buf.WriteString(" INITIALLY DEFERRED")
+		}
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -847,6 +859,14 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
+		if c.UniqueWithoutIndexDesc().IsExclusion() {
+			if err := formatExclusionConstraint(
+				ctx, f, desc, c, semaCtx, sessionData, exprFmtFlags,
+			); err != nil {
+				return err
+			}
+			continue
+		}
 		f.WriteString("UNIQUE WITHOUT INDEX (")
 		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
 		if err != nil {
@@ -854,6 +874,10 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		f.FormatNode(&tree.ConstraintDeferrability{
+			Deferrable:        c.UniqueWithoutIndexDesc().Deferrable,
+			InitiallyDeferred: c.UniqueWithoutIndexDesc().InitiallyDeferred,
+		})
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -871,3 +895,60 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// formatExclusionConstraint formats an exclusion constraint, without its name,
+// in the form:
+//
+//	EXCLUDE USING <method> (<col> WITH <op>, ...) [WHERE <pred>] [DEFERRABLE ...] [NOT VALID]
+func formatExclusionConstraint(
+	ctx context.Context,
+	f *tree.FmtCtx,
+	desc catalog.TableDescriptor,
+	c catalog.UniqueWithoutIndexConstraint,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	exprFmtFlags tree.FmtFlags,
+) error {
+	uc := c.UniqueWithoutIndexDesc()
+	colNames, err := catalog.ColumnNamesForIDs(desc, uc.ColumnIDs)
+	if err != nil {
+		return err
+	}
+	f.WriteString("EXCLUDE")
+	if uc.ExclusionMethod != "" {
+		f.WriteString(" USING ")
+		f.WriteString(uc.ExclusionMethod)
+	}
+	f.WriteString(" (")
+	for i := range colNames {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		formatQuoteNames(&f.Buffer, colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(uc.ExclusionOperators[i])
+	}
+	f.WriteString(")")
+	if c.IsPartial() {
+		f.WriteString(" WHERE ")
+		pred, err := schemaexpr.FormatExprForDisplay(
+			ctx, desc, c.GetPredicate(), semaCtx, sessionData, exprFmtFlags,
+		)
+		if err != nil {
+			return err
+		}
+		if f.HasFlags(tree.FmtPGCatalog) {
+			// Postgres always parenthesizes the predicate in pg_constraint.
+			pred = "(" + pred + ")"
+		}
+		f.WriteString(pred)
+	}
+	f.FormatNode(&tree.ConstraintDeferrability{
+		Deferrable:        uc.Deferrable,
+		InitiallyDeferred: uc.InitiallyDeferred,
+	})
+	if !c.IsConstraintValidated() {
+		f.WriteString(" NOT VALID")
+	}
+	return nil
+}
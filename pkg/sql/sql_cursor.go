@@ -16,15 +16,21 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -32,20 +38,10 @@ import (
 // DeclareCursor implements the DECLARE statement.
 // See https://www.postgresql.org/docs/current/sql-declare.html for details.
 func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (planNode, error) {
-	if s.Binary {
-		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
-	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
-	}
-
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
-			if p.extendedEvalCtx.TxnImplicit {
-				if s.Hold {
-					return nil, unimplemented.NewWithIssue(77101, "DECLARE CURSOR WITH HOLD can only be used in transaction blocks")
-				}
+			if p.extendedEvalCtx.TxnImplicit && !s.Hold {
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
@@ -118,6 +114,32 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				statement:  statement,
 				created:    timeutil.Now(),
 				withHold:   s.Hold,
+				scroll:     s.Scroll == tree.Scroll,
+				binary:     s.Binary,
+			}
+			if cursor.scroll || cursor.withHold {
+				parentMon := p.Mon()
+				if cursor.withHold {
+					parentMon = p.sessionMonitor
+					if parentMon == nil {
+						_ = cursor.Close()
+						return nil, errors.AssertionFailedf("cannot declare cursor WITH HOLD without an active session")
+					}
+				}
+				cursor.buffer = newCursorRowBuffer(
+					itCtx, getTypesFromResultColumns(rows.Types()), parentMon, p.ExtendedEvalContextCopy(),
+				)
+				// SCROLL cursors can move anywhere in the result set, so their query
+				// is executed eagerly. So is the query of a WITH HOLD cursor declared
+				// outside a transaction block, since the implicit transaction commits
+				// as soon as the DECLARE finishes. Other WITH HOLD cursors are
+				// materialized when their transaction commits.
+				if cursor.scroll || p.extendedEvalCtx.TxnImplicit {
+					if err := cursor.materialize(ctx); err != nil {
+						_ = cursor.Close()
+						return nil, err
+					}
+				}
 			}
 			if err := p.sqlCursors.addCursor(s.Name, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
@@ -160,7 +182,7 @@ func (p *planner) newFetchNode(s *tree.CursorStmt) (*fetchNode, error) {
 			pgcode.InvalidCursorName, "cursor %q does not exist", s.Name,
 		)
 	}
-	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
+	if !cursor.scroll && (s.Count < 0 || s.FetchType == tree.FetchBackwardAll) {
 		return nil, errBackwardScan
 	}
 	node := &fetchNode{
@@ -206,6 +228,9 @@ func (f *fetchNode) startInternal() error {
 }
 
 func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
+	if f.cursor.scroll {
+		return f.nextScroll(ctx)
+	}
 	if f.fetchType == tree.FetchAll {
 		return f.cursor.Next(ctx)
 	}
@@ -254,6 +279,53 @@ func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
 	return f.cursor.Next(ctx)
 }
 
+// nextScroll is the variant of nextInternal for SCROLL cursors, which can be
+// positioned anywhere in their result set. Following Postgres, position 0 is
+// before the first row, and the position after the last row is one more than
+// the number of rows.
+func (f *fetchNode) nextScroll(ctx context.Context) (bool, error) {
+	c := f.cursor
+	if !f.seeked {
+		f.seeked = true
+		switch f.fetchType {
+		case tree.FetchNormal:
+			if f.n == 0 {
+				// FETCH 0 returns the current row again, if there is one.
+				return c.seek(ctx, c.curRow)
+			}
+		case tree.FetchFirst:
+			return c.seek(ctx, 1)
+		case tree.FetchLast:
+			return c.seek(ctx, c.buffer.len())
+		case tree.FetchAbsolute:
+			pos := f.offset
+			if pos < 0 {
+				// Negative positions count backward from the end of the result.
+				pos += c.buffer.len() + 1
+			}
+			return c.seek(ctx, pos)
+		case tree.FetchRelative:
+			return c.seek(ctx, c.curRow+f.offset)
+		}
+	}
+	switch f.fetchType {
+	case tree.FetchAll:
+		return c.seek(ctx, c.curRow+1)
+	case tree.FetchBackwardAll:
+		return c.seek(ctx, c.curRow-1)
+	case tree.FetchNormal:
+		if f.n > 0 {
+			f.n--
+			return c.seek(ctx, c.curRow+1)
+		}
+		if f.n < 0 {
+			f.n++
+			return c.seek(ctx, c.curRow-1)
+		}
+	}
+	return false, nil
+}
+
 func (f *fetchNode) startExec(params runParams) error {
 	return f.startInternal()
 }
@@ -286,7 +358,7 @@ func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNod
 		name: n.String(),
 		constructor: func(ctx context.Context, p *planner) (planNode, error) {
 			if n.All {
-				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(cursorCloseForExplicitClose)
+				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(ctx, cursorCloseForExplicitClose)
 			}
 			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(n.Name)
 		},
@@ -343,11 +415,26 @@ type sqlCursor struct {
 	created    time.Time
 	curRow     int64
 	withHold   bool
+	// exhausted is set once the iterator over the cursor's query returned its
+	// last row, after which the cursor is positioned after the last row.
+	exhausted bool
+	// scroll is set for cursors declared with the SCROLL option, which can
+	// move backward. The rows of such cursors are always read from buffer.
+	scroll bool
+	// binary is set for cursors declared with the BINARY option, which return
+	// their rows in the binary format when fetched through the simple protocol.
+	binary bool
 	// eagerExecution indicates that the cursor's query was executed eagerly and
 	// stored in a row container. If true, there is no need to set the transaction
 	// sequence number, since the query is no longer active. In addition, the
 	// cursor need not be closed when its parent transaction closes.
 	eagerExecution bool
+	// buffer, if set, is the row container that the cursor's query is
+	// materialized into. It is set for cursors declared with SCROLL or WITH
+	// HOLD, and is only read from once eagerExecution is set.
+	buffer *cursorRowBuffer
+	// cur is the current row of a cursor that reads from buffer.
+	cur tree.Datums
 	// committed is set when the transaction that created the cursor has
 	// successfully committed. It is only used for cursors declared using
 	// WITH HOLD. It is used to ensure that aborting a transaction only closes
@@ -357,13 +444,184 @@ type sqlCursor struct {
 
 // Next implements the Rows interface.
 func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
+	if s.buffered() {
+		return s.seek(ctx, s.curRow+1)
+	}
+	if s.exhausted {
+		return false, nil
+	}
 	more, err := s.Rows.Next(ctx)
 	if err == nil {
 		s.curRow++
+		s.exhausted = !more
 	}
 	return more, err
 }
 
+// Cur implements the Rows interface.
+func (s *sqlCursor) Cur() tree.Datums {
+	if s.buffered() {
+		return s.cur
+	}
+	return s.Rows.Cur()
+}
+
+// Close implements the Rows interface.
+func (s *sqlCursor) Close() error {
+	var err error
+	// Rows was already closed by materialize if the cursor reads from its
+	// buffer.
+	if !s.buffered() {
+		err = s.Rows.Close()
+	}
+	if s.buffer != nil {
+		s.buffer.Close(context.Background())
+		s.buffer = nil
+	}
+	return err
+}
+
+// buffered returns true if the cursor reads its rows from its row buffer
+// rather than from the iterator over its query.
+func (s *sqlCursor) buffered() bool {
+	return s.buffer != nil && s.eagerExecution
+}
+
+// materialize reads the remaining rows of the cursor's query into the
+// cursor's row buffer, after which the query is no longer active and the
+// cursor can outlive its transaction.
+func (s *sqlCursor) materialize(ctx context.Context) (retErr error) {
+	if s.exhausted {
+		// There is no current row, and the cursor is positioned after its
+		// last row, which seek represents as one more than the number of rows.
+		s.buffer.offset = s.curRow - 1
+		s.cur = nil
+		s.eagerExecution = true
+		return s.Rows.Close()
+	}
+	if s.curRow > 0 {
+		// Keep the current row around, since it can still be fetched again.
+		s.cur = append(tree.Datums(nil), s.Rows.Cur()...)
+	}
+	// Read at the sequence number the cursor was declared with, as FETCH does.
+	origTxnSeqNum := s.txn.GetReadSeqNum()
+	if err := s.txn.SetReadSeqNum(s.readSeqNum); err != nil {
+		return err
+	}
+	defer func() {
+		if err := s.txn.SetReadSeqNum(origTxnSeqNum); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	s.buffer.offset = s.curRow
+	for {
+		more, err := s.Rows.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+		if err := s.buffer.addRow(ctx, s.Rows.Cur()); err != nil {
+			return err
+		}
+	}
+	s.eagerExecution = true
+	return s.Rows.Close()
+}
+
+// seek positions a buffered cursor at the given position and reports whether
+// there is a row at that position. Positions before the first row and after
+// the last row are clamped to 0 and one more than the number of rows,
+// respectively.
+func (s *sqlCursor) seek(ctx context.Context, pos int64) (bool, error) {
+	b := s.buffer
+	switch n := b.len(); {
+	case pos <= 0:
+		s.curRow, s.cur = 0, nil
+		return false, nil
+	case pos > n:
+		s.curRow, s.cur = n+1, nil
+		return false, nil
+	case pos <= b.offset:
+		// The rows before offset were consumed before the cursor was
+		// materialized, which is only the case for cursors without SCROLL.
+		return false, errBackwardScan
+	}
+	row, err := b.getRow(ctx, pos)
+	if err != nil {
+		return false, err
+	}
+	s.curRow, s.cur = pos, row
+	return true, nil
+}
+
+// cursorRowBuffer stores the rows of a cursor's query in a disk-backed row
+// container that supports random access. This allows SCROLL cursors to move
+// backward, and WITH HOLD cursors to outlive their transaction.
+type cursorRowBuffer struct {
+	memMonitor  *mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+	rows        *rowcontainer.DiskBackedIndexedRowContainer
+	scratch     rowenc.EncDatumRow
+	// offset is the number of rows that the cursor had already consumed when
+	// its query was materialized. It is always 0 for SCROLL cursors.
+	offset int64
+}
+
+// newCursorRowBuffer returns a new cursorRowBuffer whose memory usage is
+// accounted for by the given monitor. It must be closed once no longer needed.
+func newCursorRowBuffer(
+	ctx context.Context,
+	typs []*types.T,
+	parent *mon.BytesMonitor,
+	evalContext *extendedEvalContext,
+) *cursorRowBuffer {
+	distSQLCfg := &evalContext.DistSQLPlanner.distSQLSrv.ServerConfig
+	b := &cursorRowBuffer{
+		memMonitor: execinfra.NewLimitedMonitorNoFlowCtx(
+			ctx, parent, distSQLCfg, evalContext.SessionData(), "sql-cursor-limited",
+		),
+		diskMonitor: execinfra.NewMonitor(ctx, distSQLCfg.ParentDiskMonitor, "sql-cursor-disk"),
+		scratch:     make(rowenc.EncDatumRow, len(typs)),
+	}
+	b.rows = rowcontainer.NewDiskBackedIndexedRowContainer(
+		colinfo.NoOrdering, typs, &evalContext.Context,
+		distSQLCfg.TempStorage, b.memMonitor, b.diskMonitor,
+	)
+	return b
+}
+
+// addRow appends the given row to the buffer.
+func (b *cursorRowBuffer) addRow(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		b.scratch[i].Datum = row[i]
+	}
+	return b.rows.AddRow(ctx, b.scratch)
+}
+
+// len returns the position of the last row in the buffer.
+func (b *cursorRowBuffer) len() int64 {
+	return b.offset + int64(b.rows.Len())
+}
+
+// getRow returns the row at the given position, which must be in the range
+// (offset, len()].
+func (b *cursorRowBuffer) getRow(ctx context.Context, pos int64) (tree.Datums, error) {
+	row, err := b.rows.GetRow(ctx, int(pos-b.offset-1))
+	if err != nil {
+		return nil, err
+	}
+	return row.GetDatums(0, len(b.scratch))
+}
+
+// Close releases the resources of the buffer.
+func (b *cursorRowBuffer) Close(ctx context.Context) {
+	b.rows.Close(ctx)
+	b.memMonitor.Stop(ctx)
+	b.diskMonitor.Stop(ctx)
+}
+
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
 	// closeAll closes cursors in the set according to the following rules:
@@ -373,10 +631,9 @@ type sqlCursors interface {
 	//   * If the reason for closing is an explicit CLOSE ALL or the session
 	//     closing, all cursors are closed unconditionally.
 	//
-	// NOTE: a SQL cursor declared WITH HOLD will currently result in an
-	// "unimplemented" error if the reason for closing is txn commit, since
-	// WITH HOLD is not yet implemented for SQL cursors.
-	closeAll(reason cursorCloseReason) error
+	// HOLD cursors that were not yet materialized are read into their row
+	// buffer when the reason for closing is txn commit.
+	closeAll(ctx context.Context, reason cursorCloseReason) error
 	// closeCursor closes the named cursor, returning an error if that cursor
 	// didn't exist in the set.
 	closeCursor(tree.Name) error
@@ -399,7 +656,7 @@ type emptySqlCursors struct{}
 
 var _ sqlCursors = emptySqlCursors{}
 
-func (e emptySqlCursors) closeAll(cursorCloseReason) error {
+func (e emptySqlCursors) closeAll(context.Context, cursorCloseReason) error {
 	return errors.AssertionFailedf("closeAll not supported in emptySqlCursors")
 }
 
@@ -439,18 +696,22 @@ const (
 	cursorCloseForExplicitClose
 )
 
-func (c *cursorMap) closeAll(reason cursorCloseReason) error {
+func (c *cursorMap) closeAll(ctx context.Context, reason cursorCloseReason) error {
 	for n, curs := range c.cursors {
 		switch reason {
 		case cursorCloseForTxnCommit:
 			if curs.withHold {
-				if curs.eagerExecution {
-					// Cursors declared using WITH HOLD are not closed at transaction
-					// commit, and become the responsibility of the session.
-					curs.committed = true
-					continue
+				if !curs.eagerExecution {
+					// The cursor's query must finish within the transaction, so read
+					// the rest of its result before committing.
+					if err := curs.materialize(ctx); err != nil {
+						return err
+					}
 				}
-				return unimplemented.NewWithIssuef(77101, "cursor %s WITH HOLD must be closed before committing", n)
+				// Cursors declared using WITH HOLD are not closed at transaction
+				// commit, and become the responsibility of the session.
+				curs.committed = true
+				continue
 			}
 		case cursorCloseForTxnRollback:
 			if curs.committed {
@@ -518,8 +779,8 @@ type connExCursorAccessor struct {
 	ex *connExecutor
 }
 
-func (c connExCursorAccessor) closeAll(reason cursorCloseReason) error {
-	return c.ex.extraTxnState.sqlCursors.closeAll(reason)
+func (c connExCursorAccessor) closeAll(ctx context.Context, reason cursorCloseReason) error {
+	return c.ex.extraTxnState.sqlCursors.closeAll(ctx, reason)
 }
 
 func (c connExCursorAccessor) closeCursor(s tree.Name) error {
@@ -42,6 +42,10 @@ var (
 	// CreateTempViewCounter is to be incremented every time a TEMP VIEW
 	// has been created.
 	CreateTempViewCounter = telemetry.GetCounterOnce("sql.schema.create_temp_view")
+
+	// CreateRecursiveViewCounter is to be incremented every time a RECURSIVE
+	// VIEW has been created.
+	CreateRecursiveViewCounter = telemetry.GetCounterOnce("sql.schema.create_recursive_view")
 )
 
 var (
@@ -178,6 +182,10 @@ var CreateUnloggedTableCounter = telemetry.GetCounterOnce("sql.schema.create_unl
 // view is refreshed.
 var SchemaRefreshMaterializedView = telemetry.GetCounterOnce("sql.schema.refresh_materialized_view")
 
+// SchemaRefreshMaterializedViewIncremental is to be incremented every time a
+// materialized view is refreshed with REFRESH MATERIALIZED VIEW ... INCREMENTAL.
+var SchemaRefreshMaterializedViewIncremental = telemetry.GetCounterOnce("sql.schema.refresh_materialized_view.incremental")
+
 // SchemaChangeErrorCounter is to be incremented for different types
 // of errors.
 func SchemaChangeErrorCounter(typ string) telemetry.Counter {
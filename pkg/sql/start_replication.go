@@ -0,0 +1,925 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// replicationKeepaliveInterval is the interval at which keepalive messages are
+// sent to the client of a replication stream.
+const replicationKeepaliveInterval = 10 * time.Second
+
+var replicationPendingChangesMemoryLimit = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.pgwire.logical_replication.pending_changes_memory_limit",
+	"maximum memory used by a logical replication stream to buffer the changes "+
+		"which cannot be sent until the changes of all the replicated tables up "+
+		"to their timestamp have been received",
+	64<<20, /* 64 MiB */
+)
+
+// rangeFeedValueOverhead is the memory used by a buffered change in addition
+// to its key and values.
+const rangeFeedValueOverhead = int64(unsafe.Sizeof(kvpb.RangeFeedValue{})) +
+	int64(unsafe.Sizeof((*kvpb.RangeFeedValue)(nil)))
+
+// execStartReplication streams the changes of the tables of a set of
+// publications to the client using the pgoutput format, starting from the
+// position of a logical replication slot. The stream runs until the client
+// ends it, the query is canceled or an error occurs.
+//
+// START_REPLICATION runs outside of any transaction. The network routine
+// keeps reading from the connection while the stream is active, and forwards
+// the messages sent by the client through cmd.ClientMessages.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) (retEv fsm.Event, retPayload fsm.EventPayload) {
+	defer close(cmd.StreamDone)
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return ex.makeErrEvent(pgerror.Newf(pgcode.ActiveSQLTransaction,
+			"%s cannot be executed inside a transaction block", cmd.Stmt.StatementTag(),
+		), cmd.ParsedStmt.AST)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+		if !payloadHasError(retPayload) {
+			ex.incrementExecutedStmtCounter(cmd.Stmt)
+		}
+	}()
+
+	if err := ex.streamLogicalReplication(ctx, cmd, res); err != nil {
+		if ctx.Err() != nil {
+			err = cancelchecker.QueryCanceledError
+		}
+		return eventNonRetriableErr{IsCommit: fsm.False}, eventNonRetriableErrPayload{err: err}
+	}
+	return nil, nil
+}
+
+// startReplicationOptions are the options of the pgoutput plugin passed to
+// START_REPLICATION.
+type startReplicationOptions struct {
+	protoVersion int
+	publications []string
+}
+
+func parseStartReplicationOptions(opts pgrepltree.Options) (startReplicationOptions, error) {
+	var ret startReplicationOptions
+	for _, o := range opts {
+		switch key := strings.ToLower(string(o.Key)); key {
+		case "proto_version":
+			v, err := strconv.Atoi(replicationOptionValue(o))
+			if err != nil {
+				return ret, pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid proto_version: %q", replicationOptionValue(o))
+			}
+			if v < pgoutput.ProtoVersion || v > pgoutput.MaxProtoVersion {
+				return ret, pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%d but server only supports protocol %d to %d",
+					v, pgoutput.ProtoVersion, pgoutput.MaxProtoVersion)
+			}
+			ret.protoVersion = v
+		case "publication_names":
+			names, err := splitIdentifierList(replicationOptionValue(o))
+			if err != nil {
+				return ret, err
+			}
+			ret.publications = names
+		case "binary":
+			binary, err := replicationOptionBool(o)
+			if err != nil {
+				return ret, err
+			}
+			if binary {
+				return ret, unimplemented.NewWithIssue(105130,
+					"binary transfer of replicated values is not supported")
+			}
+		case "two_phase":
+			twoPhase, err := replicationOptionBool(o)
+			if err != nil {
+				return ret, err
+			}
+			if twoPhase {
+				return ret, unimplemented.NewWithIssue(105130,
+					"two-phase decoding is not supported")
+			}
+		case "streaming":
+			if v := strings.ToLower(replicationOptionValue(o)); v == "parallel" {
+				return ret, unimplemented.NewWithIssue(105130,
+					"streaming of in-progress transactions is not supported")
+			}
+			streaming, err := replicationOptionBool(o)
+			if err != nil {
+				return ret, err
+			}
+			if streaming {
+				return ret, unimplemented.NewWithIssue(105130,
+					"streaming of in-progress transactions is not supported")
+			}
+		case "messages":
+			// Logical decoding messages are never written, so there is nothing to
+			// send either way.
+			if _, err := replicationOptionBool(o); err != nil {
+				return ret, err
+			}
+		case "origin":
+			// Changes do not have a replication origin.
+			switch v := strings.ToLower(replicationOptionValue(o)); v {
+			case "any", "none":
+			default:
+				return ret, pgerror.Newf(pgcode.InvalidParameterValue,
+					"unrecognized origin value: %q", v)
+			}
+		default:
+			return ret, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", key)
+		}
+	}
+	if ret.protoVersion == 0 {
+		return ret, pgerror.New(pgcode.InvalidParameterValue, "proto_version option missing")
+	}
+	if len(ret.publications) == 0 {
+		return ret, pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return ret, nil
+}
+
+// splitIdentifierList splits a comma-separated list of identifiers. Unquoted
+// identifiers are folded to lower case, like in Postgres.
+func splitIdentifierList(s string) ([]string, error) {
+	var names []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && part[0] == '"' && part[len(part)-1] == '"' {
+			part = strings.ReplaceAll(part[1:len(part)-1], `""`, `"`)
+		} else {
+			part = strings.ToLower(part)
+		}
+		if part == "" {
+			return nil, pgerror.Newf(pgcode.InvalidName, "invalid list syntax: %q", s)
+		}
+		names = append(names, part)
+	}
+	return names, nil
+}
+
+// replicatedTable is a table whose changes are streamed.
+type replicatedTable struct {
+	id         descpb.ID
+	schemaName string
+	// These fields specify the operations published for the table by any of
+	// the publications of the stream.
+	publishInsert, publishUpdate, publishDelete bool
+
+	// The following fields are initialized for the version of the descriptor
+	// of the last decoded change.
+	version descpb.DescriptorVersion
+	// relation is the Relation message describing the table.
+	relation pgoutput.Relation
+	// relationSent is set once the Relation message has been sent for the
+	// current version of the table.
+	relationSent bool
+	fetcher      row.Fetcher
+	kvProvider   row.KVProvider
+	alloc        tree.DatumAlloc
+}
+
+// replicationEvent is an event of the rangefeed of a replication stream.
+// Exactly one of value, sst, deleteRange, frontier and err is set.
+type replicationEvent struct {
+	value *kvpb.RangeFeedValue
+	sst   *kvpb.RangeFeedSSTable
+	// sstSpan is the span of the rangefeed registration which received sst.
+	sstSpan     roachpb.Span
+	deleteRange *kvpb.RangeFeedDeleteRange
+	frontier    hlc.Timestamp
+	err         error
+}
+
+// logicalReplicationStream streams the changes of a set of tables to a
+// client.
+type logicalReplicationStream struct {
+	cfg        *ExecutorConfig
+	res        StartReplicationResult
+	clientMsgs <-chan []byte
+
+	dbID     descpb.ID
+	slotName string
+	tables   map[descpb.ID]*replicatedTable
+	// spans are the spans of the replicated tables.
+	spans []roachpb.Span
+	start hlc.Timestamp
+
+	// pending are the changes received from the rangefeed which have not been
+	// sent yet because the frontier has not reached their timestamp. Their
+	// memory is accounted for in pendingAcc.
+	pending    []*kvpb.RangeFeedValue
+	pendingMon *mon.BytesMonitor
+	pendingAcc mon.BoundAccount
+	// frontier is the timestamp up to which all the changes have been sent.
+	frontier hlc.Timestamp
+	// xid is the transaction ID of the last sent transaction. Transactions are
+	// numbered sequentially within a stream.
+	xid uint32
+
+	// safePoints are the LSNs, in increasing order, which the client can
+	// confirm: the LSNs of the sent transactions and of the keepalive
+	// messages. Only those are persisted, so that a client confirming the end
+	// of a message does not cause later transactions to be skipped.
+	safePoints []lsn.LSN
+	// confirmed is the LSN up to which the client confirmed receiving the
+	// changes, and persisted is the one stored in the replication slot.
+	confirmed, persisted lsn.LSN
+
+	buf []byte
+}
+
+func (ex *connExecutor) streamLogicalReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) error {
+	if cmd.Stmt.Kind != pgrepltree.LogicalReplication {
+		return unimplemented.NewWithIssue(105130, "physical replication is not supported")
+	}
+	if !ex.server.cfg.Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemReplicationSlotsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"replication slots not supported until version 24.1")
+	}
+	if ex.sessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE ||
+		ex.sessionData().Database == "" {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a database connection")
+	}
+	opts, err := parseStartReplicationOptions(cmd.Stmt.Options)
+	if err != nil {
+		return err
+	}
+	s := &logicalReplicationStream{
+		cfg:        ex.server.cfg,
+		res:        res,
+		clientMsgs: cmd.ClientMessages,
+		slotName:   string(cmd.Stmt.Slot),
+	}
+	if err := s.init(ctx, ex.sessionData().Database, cmd.Stmt.LSN, opts); err != nil {
+		return err
+	}
+	s.pendingMon = mon.NewMonitorInheritWithLimit(
+		"logical-replication", replicationPendingChangesMemoryLimit.Get(&s.cfg.Settings.SV), ex.sessionMon,
+	)
+	s.pendingMon.StartNoReserved(ctx, ex.sessionMon)
+	defer s.pendingMon.Stop(ctx)
+	s.pendingAcc = s.pendingMon.MakeBoundAccount()
+	defer s.pendingAcc.Close(ctx)
+	defer func() {
+		for _, t := range s.tables {
+			t.fetcher.Close(ctx)
+		}
+	}()
+	return s.run(ctx)
+}
+
+// init resolves the replication slot and the publications of the stream.
+func (s *logicalReplicationStream) init(
+	ctx context.Context, dbName string, startLSN lsn.LSN, opts startReplicationOptions,
+) error {
+	return s.cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		s.tables = make(map[descpb.ID]*replicatedTable)
+		s.spans = nil
+		db, err := txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Database(ctx, dbName)
+		if err != nil {
+			return err
+		}
+		s.dbID = db.GetID()
+		slot, found, err := getReplicationSlot(ctx, txn, s.slotName)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", s.slotName)
+		}
+		if slot.dbID != s.dbID {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", s.slotName)
+		}
+		// Replication starts from the confirmed flush LSN of the slot if the
+		// client requested an earlier position.
+		confirmed := slot.confirmedFlushLSN
+		if startLSN < confirmed {
+			startLSN = confirmed
+		}
+		s.start = lsnutil.LSNToHLC(startLSN)
+		s.frontier = s.start
+		s.confirmed, s.persisted = confirmed, confirmed
+
+		pubs := make([]descpb.DatabaseDescriptor_Publication, len(opts.publications))
+		for i, name := range opts.publications {
+			if pubs[i], found = db.GetPublication(name); !found {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"publication %q does not exist", name)
+			}
+		}
+		tables, err := txn.Descriptors().GetAllTablesInDatabase(ctx, txn.KV(), db)
+		if err != nil {
+			return err
+		}
+		schemaNames := make(map[descpb.ID]string)
+		return tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			table, ok := desc.(catalog.TableDescriptor)
+			if !ok || table.Dropped() || table.Offline() {
+				return nil
+			}
+			var t *replicatedTable
+			for i := range pubs {
+				if !publicationIncludesTable(&pubs[i], table) {
+					continue
+				}
+				if t == nil {
+					t = &replicatedTable{id: table.GetID()}
+				}
+				t.publishInsert = t.publishInsert || pubs[i].PublishInsert
+				t.publishUpdate = t.publishUpdate || pubs[i].PublishUpdate
+				t.publishDelete = t.publishDelete || pubs[i].PublishDelete
+			}
+			if t == nil {
+				return nil
+			}
+			if table.NumFamilies() > 1 {
+				return unimplemented.NewWithIssuef(105130,
+					"cannot replicate table %q: tables with multiple column families are not supported",
+					table.GetName())
+			}
+			schemaName, ok := schemaNames[table.GetParentSchemaID()]
+			if !ok {
+				sc, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Schema(ctx, table.GetParentSchemaID())
+				if err != nil {
+					return err
+				}
+				schemaName = sc.GetName()
+				schemaNames[table.GetParentSchemaID()] = schemaName
+			}
+			t.schemaName = schemaName
+			s.tables[t.id] = t
+			s.spans = append(s.spans, table.TableSpan(s.cfg.Codec))
+			return nil
+		})
+	})
+}
+
+// run sends the changes to the client until the client ends the stream.
+func (s *logicalReplicationStream) run(ctx context.Context) error {
+	var events chan replicationEvent
+	if len(s.tables) > 0 {
+		events = make(chan replicationEvent, 128)
+		send := func(ctx context.Context, ev replicationEvent) {
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+			}
+		}
+		rf, err := s.cfg.RangeFeedFactory.RangeFeed(ctx, "logical-replication", s.spans, s.start,
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				send(ctx, replicationEvent{value: value})
+			},
+			rangefeed.WithDiff(true),
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, frontier hlc.Timestamp) {
+				send(ctx, replicationEvent{frontier: frontier})
+			}),
+			rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+				send(ctx, replicationEvent{err: err})
+			}),
+			rangefeed.WithOnSSTable(func(ctx context.Context, sst *kvpb.RangeFeedSSTable, registeredSpan roachpb.Span) {
+				send(ctx, replicationEvent{sst: sst, sstSpan: registeredSpan})
+			}),
+			rangefeed.WithOnDeleteRange(func(ctx context.Context, deleteRange *kvpb.RangeFeedDeleteRange) {
+				send(ctx, replicationEvent{deleteRange: deleteRange})
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		defer rf.Close()
+	}
+
+	if err := s.res.SendCopyBoth(ctx); err != nil {
+		return err
+	}
+	keepalive := time.NewTicker(replicationKeepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case ev := <-events:
+			switch {
+			case ev.err != nil:
+				return ev.err
+			case ev.value != nil:
+				if err := s.addPending(ctx, ev.value); err != nil {
+					return err
+				}
+			case ev.sst != nil:
+				if err := s.addSSTable(ctx, ev.sst, ev.sstSpan); err != nil {
+					return err
+				}
+			case ev.deleteRange != nil:
+				if err := s.checkDeleteRange(ctx, ev.deleteRange); err != nil {
+					return err
+				}
+			default:
+				if err := s.flush(ctx, ev.frontier); err != nil {
+					return err
+				}
+			}
+
+		case msg, ok := <-s.clientMsgs:
+			if !ok {
+				// The client ended the stream.
+				return s.res.SendCopyDone(ctx)
+			}
+			if err := s.handleClientMessage(ctx, msg); err != nil {
+				return err
+			}
+
+		case <-keepalive.C:
+			if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// addPending buffers a change until the frontier reaches its timestamp.
+func (s *logicalReplicationStream) addPending(ctx context.Context, v *kvpb.RangeFeedValue) error {
+	if err := s.pendingAcc.Grow(ctx, pendingValueSize(v)); err != nil {
+		return errors.WithHintf(err,
+			"The replicated tables are changing faster than their changes can be resolved. "+
+				"Consider increasing %s.", replicationPendingChangesMemoryLimit.Name())
+	}
+	s.pending = append(s.pending, v)
+	return nil
+}
+
+func pendingValueSize(v *kvpb.RangeFeedValue) int64 {
+	return rangeFeedValueOverhead + int64(len(v.Key)+len(v.Value.RawBytes)+len(v.PrevValue.RawBytes))
+}
+
+// addSSTable buffers the changes of an ingested SST which fall in the span of
+// the rangefeed registration. SSTs do not carry the previous values of their
+// keys, so their rows are sent as inserts. MVCC range tombstones cannot be
+// decoded into changes of rows, so they fail the stream.
+func (s *logicalReplicationStream) addSSTable(
+	ctx context.Context, sst *kvpb.RangeFeedSSTable, registeredSpan roachpb.Span,
+) error {
+	it, err := storage.NewMemSSTIterator(sst.Data, false /* verify */, storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsAndRanges,
+		LowerBound: registeredSpan.Key,
+		UpperBound: registeredSpan.EndKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.SeekGE(storage.MVCCKey{Key: registeredSpan.Key}); ; it.Next() {
+		if ok, err := it.Valid(); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+		if hasPoint, hasRange := it.HasPointAndRange(); hasRange {
+			return unimplemented.NewWithIssuef(105130,
+				"cannot replicate the ingestion of MVCC range tombstones in span %s",
+				it.RangeBounds())
+		} else if !hasPoint {
+			continue
+		}
+		key := it.UnsafeKey()
+		v, err := it.UnsafeValue()
+		if err != nil {
+			return err
+		}
+		mvccValue, err := storage.DecodeMVCCValue(v)
+		if err != nil {
+			return err
+		}
+		value := mvccValue.Value
+		value.RawBytes = append([]byte(nil), value.RawBytes...)
+		value.Timestamp = key.Timestamp
+		if err := s.addPending(ctx, &kvpb.RangeFeedValue{
+			Key:   key.Key.Clone(),
+			Value: value,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// checkDeleteRange fails the stream if an MVCC range tombstone deleted rows of
+// a replicated table, since the deleted rows are unknown. Range tombstones
+// are otherwise written to remove the data of dropped indexes and of offline
+// tables, which are not replicated.
+func (s *logicalReplicationStream) checkDeleteRange(
+	ctx context.Context, deleteRange *kvpb.RangeFeedDeleteRange,
+) error {
+	for _, t := range s.tables {
+		if err := func() error {
+			leased, err := s.cfg.LeaseManager.Acquire(ctx, deleteRange.Timestamp, t.id)
+			if err != nil {
+				if catalog.HasInactiveDescriptorError(err) {
+					return nil
+				}
+				return err
+			}
+			defer leased.Release(ctx)
+			desc, ok := leased.Underlying().(catalog.TableDescriptor)
+			if !ok {
+				return errors.AssertionFailedf("descriptor %d is not a table", t.id)
+			}
+			if desc.PrimaryIndexSpan(s.cfg.Codec).Overlaps(deleteRange.Span) {
+				return unimplemented.NewWithIssuef(105130,
+					"cannot replicate the deletion of the rows of table %q in span %s",
+					desc.GetName(), deleteRange.Span)
+			}
+			return nil
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush sends the pending changes up to the frontier, as one transaction per
+// commit timestamp.
+func (s *logicalReplicationStream) flush(ctx context.Context, frontier hlc.Timestamp) error {
+	if frontier.LessEq(s.frontier) {
+		return nil
+	}
+	sort.SliceStable(s.pending, func(i, j int) bool {
+		return s.pending[i].Value.Timestamp.Less(s.pending[j].Value.Timestamp)
+	})
+	n := sort.Search(len(s.pending), func(i int) bool {
+		return frontier.Less(s.pending[i].Value.Timestamp)
+	})
+	ready := s.pending[:n]
+	var readySize int64
+	for _, v := range ready {
+		readySize += pendingValueSize(v)
+	}
+	for len(ready) > 0 {
+		ts := ready[0].Value.Timestamp
+		end := 1
+		for end < len(ready) && ready[end].Value.Timestamp == ts {
+			end++
+		}
+		if err := s.sendTransaction(ctx, ts, ready[:end]); err != nil {
+			return err
+		}
+		ready = ready[end:]
+	}
+	s.pending = append(s.pending[:0], s.pending[n:]...)
+	s.pendingAcc.Shrink(ctx, readySize)
+	s.frontier = frontier
+	return nil
+}
+
+// sendTransaction sends the changes committed at the given timestamp. Nothing
+// is sent if none of the changes is published.
+func (s *logicalReplicationStream) sendTransaction(
+	ctx context.Context, ts hlc.Timestamp, values []*kvpb.RangeFeedValue,
+) error {
+	commitLSN := lsnutil.HLCToLSN(ts)
+	commitTime := ts.GoTime()
+	began := false
+	for _, v := range values {
+		t, err := s.tableForKey(ctx, v.Key, ts)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			continue
+		}
+		var msg []byte
+		switch isDelete, hasPrev := !v.Value.IsPresent(), v.PrevValue.IsPresent(); {
+		case isDelete && !hasPrev:
+			// The row did not exist.
+			continue
+		case isDelete:
+			if !t.publishDelete {
+				continue
+			}
+			old, err := t.decodeRow(ctx, v.Key, v.PrevValue)
+			if err != nil {
+				return err
+			}
+			for i, c := range t.relation.Columns {
+				if !c.IsKey {
+					old[i] = pgoutput.Value{IsNull: true}
+				}
+			}
+			msg = pgoutput.AppendDelete(nil, pgoutput.Delete{RelationOID: t.relation.OID, Key: old})
+		default:
+			if (hasPrev && !t.publishUpdate) || (!hasPrev && !t.publishInsert) {
+				continue
+			}
+			newRow, err := t.decodeRow(ctx, v.Key, v.Value)
+			if err != nil {
+				return err
+			}
+			if hasPrev {
+				// The primary key of a row cannot change without deleting it and
+				// inserting it again, so the old key is never sent.
+				msg = pgoutput.AppendUpdate(nil, pgoutput.Update{RelationOID: t.relation.OID, New: newRow})
+			} else {
+				msg = pgoutput.AppendInsert(nil, pgoutput.Insert{RelationOID: t.relation.OID, New: newRow})
+			}
+		}
+		if !began {
+			s.xid++
+			if err := s.send(ctx, commitLSN, pgoutput.AppendBegin(nil, pgoutput.Begin{
+				FinalLSN:   commitLSN,
+				CommitTime: commitTime,
+				XID:        s.xid,
+			})); err != nil {
+				return err
+			}
+			began = true
+		}
+		if !t.relationSent {
+			if err := s.send(ctx, commitLSN, pgoutput.AppendRelation(nil, t.relation)); err != nil {
+				return err
+			}
+			t.relationSent = true
+		}
+		if err := s.send(ctx, commitLSN, msg); err != nil {
+			return err
+		}
+	}
+	if !began {
+		return nil
+	}
+	if err := s.send(ctx, commitLSN, pgoutput.AppendCommit(nil, pgoutput.Commit{
+		CommitLSN:  commitLSN,
+		EndLSN:     commitLSN,
+		CommitTime: commitTime,
+	})); err != nil {
+		return err
+	}
+	s.addSafePoint(commitLSN)
+	return nil
+}
+
+// tableForKey returns the replicated table of a key of its primary index, set
+// up to decode rows with the version of its descriptor at the given
+// timestamp. It returns nil if the key belongs to another index.
+func (s *logicalReplicationStream) tableForKey(
+	ctx context.Context, key roachpb.Key, ts hlc.Timestamp,
+) (*replicatedTable, error) {
+	_, tableID, indexID, err := s.cfg.Codec.DecodeIndexPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := s.tables[descpb.ID(tableID)]
+	if !ok {
+		return nil, nil
+	}
+	leased, err := s.cfg.LeaseManager.Acquire(ctx, ts, t.id)
+	if err != nil {
+		return nil, err
+	}
+	defer leased.Release(ctx)
+	desc, ok := leased.Underlying().(catalog.TableDescriptor)
+	if !ok {
+		return nil, errors.AssertionFailedf("descriptor %d is not a table", t.id)
+	}
+	if descpb.IndexID(indexID) != desc.GetPrimaryIndexID() {
+		return nil, nil
+	}
+	if desc.GetVersion() != t.version {
+		if err := t.init(ctx, s.cfg, desc); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// init sets up the table to decode the rows of the given version of its
+// descriptor. The Relation message is sent again before the next change.
+func (t *replicatedTable) init(
+	ctx context.Context, cfg *ExecutorConfig, desc catalog.TableDescriptor,
+) error {
+	if desc.NumFamilies() > 1 {
+		return unimplemented.NewWithIssuef(105130,
+			"cannot replicate table %q: tables with multiple column families are not supported",
+			desc.GetName())
+	}
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	t.relation = pgoutput.Relation{
+		OID:             oid.Oid(desc.GetID()),
+		Namespace:       t.schemaName,
+		Name:            desc.GetName(),
+		ReplicaIdentity: pgoutput.ReplicaIdentityDefault,
+	}
+	var colIDs []descpb.ColumnID
+	for _, col := range desc.PublicColumns() {
+		// Virtual columns are not stored, like generated columns are not
+		// replicated in Postgres.
+		if col.IsInaccessible() || col.IsVirtual() {
+			continue
+		}
+		colIDs = append(colIDs, col.GetID())
+		t.relation.Columns = append(t.relation.Columns, pgoutput.Column{
+			Name:         col.GetName(),
+			TypeOID:      col.GetType().Oid(),
+			TypeModifier: col.GetType().TypeModifier(),
+			IsKey:        keyCols.Contains(col.GetID()),
+		})
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, cfg.Codec, desc, desc.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return err
+	}
+	if err := t.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &t.alloc,
+		Spec:              &spec,
+	}); err != nil {
+		return err
+	}
+	t.version = desc.GetVersion()
+	t.relationSent = false
+	return nil
+}
+
+// decodeRow decodes the row stored in a key of the primary index.
+func (t *replicatedTable) decodeRow(
+	ctx context.Context, key roachpb.Key, value roachpb.Value,
+) (pgoutput.Tuple, error) {
+	t.kvProvider.KVs = append(t.kvProvider.KVs[:0], roachpb.KeyValue{Key: key, Value: value})
+	if err := t.fetcher.ConsumeKVProvider(ctx, &t.kvProvider); err != nil {
+		return nil, err
+	}
+	datums, err := t.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if datums == nil {
+		return nil, errors.AssertionFailedf("no row decoded for key %s", key)
+	}
+	tuple := make(pgoutput.Tuple, len(datums))
+	for i, d := range datums {
+		if d == tree.DNull {
+			tuple[i].IsNull = true
+			continue
+		}
+		tuple[i].Text = []byte(tree.AsStringWithFlags(d, tree.FmtPgwireText))
+	}
+	return tuple, nil
+}
+
+// send sends a message of the pgoutput plugin in an XLogData message.
+func (s *logicalReplicationStream) send(ctx context.Context, at lsn.LSN, msg []byte) error {
+	s.buf = pgoutput.AppendXLogData(s.buf[:0], at, at, timeutil.Now())
+	s.buf = append(s.buf, msg...)
+	return s.res.SendCopyBothData(ctx, s.buf)
+}
+
+func (s *logicalReplicationStream) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	frontier := s.frontier
+	if len(s.tables) == 0 {
+		// There is nothing to replicate, so the stream is always up to date.
+		frontier = s.cfg.Clock.Now()
+	}
+	walEnd := lsnutil.HLCToLSN(frontier)
+	s.addSafePoint(walEnd)
+	s.buf = pgoutput.AppendPrimaryKeepalive(s.buf[:0], walEnd, timeutil.Now(), replyRequested)
+	return s.res.SendCopyBothData(ctx, s.buf)
+}
+
+func (s *logicalReplicationStream) addSafePoint(l lsn.LSN) {
+	if n := len(s.safePoints); n > 0 && s.safePoints[n-1] >= l {
+		return
+	}
+	s.safePoints = append(s.safePoints, l)
+}
+
+// handleClientMessage processes a CopyData message sent by the client.
+func (s *logicalReplicationStream) handleClientMessage(ctx context.Context, msg []byte) error {
+	if !pgoutput.IsStandbyStatusUpdate(msg) {
+		// Hot standby feedback is irrelevant for logical replication.
+		return nil
+	}
+	update, err := pgoutput.ParseStandbyStatusUpdate(msg)
+	if err != nil {
+		return pgerror.WithCandidateCode(err, pgcode.ProtocolViolation)
+	}
+	n := sort.Search(len(s.safePoints), func(i int) bool {
+		return s.safePoints[i] > update.FlushedLSN
+	})
+	if n > 0 {
+		if l := s.safePoints[n-1]; l > s.confirmed {
+			s.confirmed = l
+		}
+		s.safePoints = append(s.safePoints[:0], s.safePoints[n:]...)
+	}
+	if err := s.persistConfirmedLSN(ctx); err != nil {
+		return err
+	}
+	if update.ReplyRequested {
+		return s.sendKeepalive(ctx, false /* replyRequested */)
+	}
+	return nil
+}
+
+// persistConfirmedLSN stores the LSN confirmed by the client in the
+// replication slot, and advances the protected timestamp record of the slot
+// to it so that the changes which were confirmed can be garbage collected.
+func (s *logicalReplicationStream) persistConfirmedLSN(ctx context.Context) error {
+	if s.confirmed <= s.persisted {
+		return nil
+	}
+	if err := s.cfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		slot, found, err := getReplicationSlot(ctx, txn, s.slotName)
+		if err != nil {
+			return err
+		}
+		if !found || slot.dbID != s.dbID {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was dropped", s.slotName)
+		}
+		if slot.confirmedFlushLSN >= s.confirmed {
+			return nil
+		}
+		_, err = txn.ExecEx(ctx, "update-replication-slot", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots SET confirmed_flush_lsn = $2 WHERE slot_name = $1`,
+			s.slotName, int64(s.confirmed),
+		)
+		if err != nil {
+			return err
+		}
+		pts := s.cfg.ProtectedTimestampProvider.WithTxn(txn)
+		return pts.UpdateTimestamp(ctx, replicationSlotPTSRecordID(s.slotName), lsnutil.LSNToHLC(s.confirmed))
+	}); err != nil {
+		return err
+	}
+	s.persisted = s.confirmed
+	return nil
+}
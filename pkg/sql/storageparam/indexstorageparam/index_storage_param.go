@@ -30,6 +30,8 @@ import (
 // Setter observes storage parameters for indexes.
 type Setter struct {
 	IndexDesc *descpb.IndexDescriptor
+	// BlockRangeIndex is set instead of IndexDesc for block range indexes.
+	BlockRangeIndex *descpb.TableDescriptor_BlockRangeIndex
 }
 
 var _ storageparam.Setter = (*Setter)(nil)
@@ -107,6 +109,26 @@ func (po *Setter) applyGeometryIndexSetting(
 	return nil
 }
 
+func (po *Setter) applyBlockRangeIndexSetting(
+	ctx context.Context, evalCtx *eval.Context, key string, expr tree.Datum,
+) error {
+	switch key {
+	case `pages_per_range`:
+		val, err := paramparse.DatumAsInt(ctx, evalCtx, key, expr)
+		if err != nil {
+			return errors.Wrapf(err, "error decoding %q", key)
+		}
+		if val < 1 {
+			return pgerror.Newf(pgcode.InvalidParameterValue, "%q must be at least 1", key)
+		}
+		po.BlockRangeIndex.RangeSize = val
+		return nil
+	case `autosummarize`:
+		return unimplemented.NewWithIssuef(43299, "storage parameter %q", key)
+	}
+	return pgerror.Newf(pgcode.InvalidParameterValue, "invalid storage parameter %q for BRIN index", key)
+}
+
 // Set implements the Setter interface.
 func (po *Setter) Set(
 	ctx context.Context,
@@ -115,6 +137,9 @@ func (po *Setter) Set(
 	key string,
 	expr tree.Datum,
 ) error {
+	if po.BlockRangeIndex != nil {
+		return po.applyBlockRangeIndexSetting(ctx, evalCtx, key, expr)
+	}
 	switch key {
 	case `fillfactor`:
 		return storageparam.SetFillFactor(ctx, evalCtx, key, expr)
@@ -130,11 +155,14 @@ func (po *Setter) Set(
 	// indexes.
 	case `bucket_count`:
 		return nil
+	case `pages_per_range`:
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue, "%q can only be applied to BRIN indexes", key,
+		)
 	case `vacuum_cleanup_index_scale_factor`,
 		`buffering`,
 		`fastupdate`,
 		`gin_pending_list_limit`,
-		`pages_per_range`,
 		`autosummarize`:
 		return unimplemented.NewWithIssuef(43299, "storage parameter %q", key)
 	}
@@ -148,6 +176,9 @@ func (po *Setter) Reset(ctx context.Context, evalCtx *eval.Context, key string)
 
 // RunPostChecks implements the Setter interface.
 func (po *Setter) RunPostChecks() error {
+	if po.BlockRangeIndex != nil {
+		return nil
+	}
 	s2Config := getS2ConfigFromIndex(po.IndexDesc)
 	if s2Config != nil {
 		if (s2Config.MaxLevel)%s2Config.LevelMod != 0 {
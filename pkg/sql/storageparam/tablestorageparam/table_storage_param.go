@@ -17,6 +17,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
@@ -568,6 +569,31 @@ var tableParams = map[string]tableParam{
 			return nil
 		},
 	},
+	`refresh_max_staleness`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			if !po.TableDesc.MaterializedView() {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"storage parameter %q can only be set on materialized views", key)
+			}
+			if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"storage parameter %q is not supported until version 24.1", key)
+			}
+			d, err := paramparse.DatumAsDuration(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			if d <= 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue, `value of %q must be positive`, key)
+			}
+			po.TableDesc.RefreshMaxStaleness = d
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			po.TableDesc.RefreshMaxStaleness = 0
+			return nil
+		},
+	},
 	`schema_locked`: {
 		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
 			boolVal, err := boolFromDatum(ctx, evalCtx, key, datum)
@@ -730,6 +756,10 @@ func (po *Setter) Set(
 			"cannot modify TTL settings while another schema change on the table is being processed",
 		)
 	}
+	if po.TableDesc.MaterializedView() && key != `refresh_max_staleness` {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"storage parameter %q is not supported on materialized views", key)
+	}
 	if p, ok := tableParams[key]; ok {
 		return p.onSet(ctx, po, semaCtx, evalCtx, key, datum)
 	}
@@ -744,6 +774,10 @@ func (po *Setter) Reset(ctx context.Context, evalCtx *eval.Context, key string)
 			"cannot modify TTL settings while another schema change on the table is being processed",
 		)
 	}
+	if po.TableDesc.MaterializedView() && key != `refresh_max_staleness` {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"storage parameter %q is not supported on materialized views", key)
+	}
 	if p, ok := tableParams[key]; ok {
 		return p.onReset(ctx, po, evalCtx, key)
 	}
@@ -146,6 +146,10 @@ type tableWriterBase struct {
 	forceProductionBatchSizes bool
 	// Adapter to make expose a kv.Batch as a Putter
 	putter row.KVBatchAdapter
+	// blockRanges, if set, accumulates the changes to the summaries of the
+	// table's block range indexes. They are added to the batch before it is
+	// run.
+	blockRanges *blockRangeSummaries
 }
 
 var maxBatchBytes = settings.RegisterByteSizeSetting(
@@ -174,6 +178,13 @@ func (tb *tableWriterBase) init(
 		batchMaxBytes = int(maxBatchBytes.Get(&evalCtx.Settings.SV))
 	}
 	tb.maxBatchByteSize = mutations.MaxBatchByteSize(batchMaxBytes, tb.forceProductionBatchSizes)
+	tb.blockRanges = nil
+	if indexes := tableDesc.GetBlockRangeIndexes(); len(indexes) > 0 {
+		if evalCtx == nil {
+			return errors.AssertionFailedf("writing to a table with block range indexes requires an eval context")
+		}
+		tb.blockRanges = makeBlockRangeSummaries(evalCtx, evalCtx.Codec, tableDesc, indexes)
+	}
 	tb.initNewBatch()
 	return nil
 }
@@ -192,6 +203,9 @@ func (tb *tableWriterBase) setRowsWrittenLimit(sd *sessiondata.SessionData) {
 // flushAndStartNewBatch shares the common flushAndStartNewBatch() code between
 // tableWriters.
 func (tb *tableWriterBase) flushAndStartNewBatch(ctx context.Context) error {
+	if err := tb.flushBlockRanges(); err != nil {
+		return err
+	}
 	if err := tb.txn.Run(ctx, tb.b); err != nil {
 		return row.ConvertBatchError(ctx, tb.desc, tb.b)
 	}
@@ -210,6 +224,9 @@ func (tb *tableWriterBase) finalize(ctx context.Context) (err error) {
 	// NB: unlike flushAndStartNewBatch, we don't bother with admission control
 	// for response processing when finalizing.
 	tb.rowsWritten += int64(tb.currentBatchSize)
+	if err := tb.flushBlockRanges(); err != nil {
+		return err
+	}
 	if tb.autoCommit == autoCommitEnabled &&
 		// We can only auto commit if the rows written guardrail is disabled or
 		// we haven't exceeded the specified limit (the optimizer is responsible
@@ -235,6 +252,15 @@ func (tb *tableWriterBase) finalize(ctx context.Context) (err error) {
 	return tb.tryDoResponseAdmission(ctx)
 }
 
+// flushBlockRanges adds the pending block range summaries to the current
+// batch.
+func (tb *tableWriterBase) flushBlockRanges() error {
+	if tb.blockRanges == nil {
+		return nil
+	}
+	return tb.blockRanges.flush(tb.b)
+}
+
 func (tb *tableWriterBase) tryDoResponseAdmission(ctx context.Context) error {
 	// Do admission control for response processing. This is the shared write
 	// path for most SQL mutations.
@@ -41,6 +41,11 @@ func (ti *tableInserter) row(
 	ctx context.Context, values tree.Datums, pm row.PartialIndexUpdateHelper, traceKV bool,
 ) error {
 	ti.currentBatchSize++
+	if ti.blockRanges != nil {
+		if err := ti.blockRanges.add(values, ti.ri.InsertColIDtoRowIndex); err != nil {
+			return err
+		}
+	}
 	return ti.ri.InsertRow(ctx, &ti.putter, values, pm, false /* overwrite */, traceKV)
 }
 
@@ -54,7 +54,16 @@ func (tu *tableUpdater) rowForUpdate(
 	traceKV bool,
 ) (tree.Datums, error) {
 	tu.currentBatchSize++
-	return tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, traceKV)
+	if err != nil {
+		return nil, err
+	}
+	if tu.blockRanges != nil && tu.blockRanges.affectedBy(tu.ru.UpdateCols) {
+		if err := tu.blockRanges.add(newValues, tu.ru.FetchColIDtoRowIndex); err != nil {
+			return nil, err
+		}
+	}
+	return newValues, nil
 }
 
 // tableDesc is part of the tableWriter interface.
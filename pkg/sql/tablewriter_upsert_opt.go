@@ -79,12 +79,22 @@ type optTableUpserter struct {
 	// an update is performed. This column will always be one of the fetchCols.
 	canaryOrdinal int
 
+	// mergeActionOrdinal is the ordinal position of the column within the input
+	// row that contains the tree.MergeActionType of the row, if the upsert
+	// implements a MERGE statement. It is -1 otherwise. The column follows the
+	// canary column, which is never -1 for a MERGE.
+	mergeActionOrdinal int
+
 	// resultRow is a reusable slice of Datums used to store result rows.
 	resultRow tree.Datums
 
 	// ru is used when updating rows.
 	ru row.Updater
 
+	// rd is used when deleting rows. It is only initialized if
+	// mergeActionOrdinal is not -1.
+	rd row.Deleter
+
 	// tabColIdxToRetIdx is the mapping from the columns in the table to the
 	// columns in the resultRowBuffer. A value of -1 is used to indicate
 	// that the table column at that index is not part of the resultRowBuffer
@@ -180,8 +190,15 @@ func (tu *optTableUpserter) row(
 		return tu.insertNonConflictingRow(ctx, row[:insertEnd], pm, false /* overwrite */, traceKV)
 	}
 
-	// If no columns need to be updated, then possibly collect the unchanged row.
+	// Consult the merge action column to determine whether to delete the
+	// existing row instead of updating it.
 	fetchEnd := insertEnd + len(tu.fetchCols)
+	if tu.mergeActionOrdinal != -1 &&
+		tree.MergeActionType(tree.MustBeDInt(row[tu.mergeActionOrdinal])) == tree.MergeDelete {
+		return tu.deleteMatchedRow(ctx, row[insertEnd:fetchEnd], pm, traceKV)
+	}
+
+	// If no columns need to be updated, then possibly collect the unchanged row.
 	if len(tu.updateCols) == 0 {
 		if !tu.rowsNeeded {
 			return nil
@@ -215,6 +232,11 @@ func (tu *optTableUpserter) insertNonConflictingRow(
 	if err := tu.ri.InsertRow(ctx, &tu.putter, insertRow, pm, overwrite, traceKV); err != nil {
 		return err
 	}
+	if tu.blockRanges != nil {
+		if err := tu.blockRanges.add(insertRow, tu.ri.InsertColIDtoRowIndex); err != nil {
+			return err
+		}
+	}
 
 	if !tu.rowsNeeded {
 		return nil
@@ -274,10 +296,15 @@ func (tu *optTableUpserter) updateConflictingRow(
 	// Queue the update in KV. This also returns an "update row"
 	// containing the updated values for every column in the
 	// table. This is useful for RETURNING, which we collect below.
-	_, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, pm, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, pm, traceKV)
 	if err != nil {
 		return err
 	}
+	if tu.blockRanges != nil && tu.blockRanges.affectedBy(tu.ru.UpdateCols) {
+		if err := tu.blockRanges.add(newValues, tu.ru.FetchColIDtoRowIndex); err != nil {
+			return err
+		}
+	}
 
 	// We only need a result row if we're collecting rows.
 	if !tu.rowsNeeded {
@@ -312,6 +339,33 @@ func (tu *optTableUpserter) updateConflictingRow(
 	return err
 }
 
+// deleteMatchedRow deletes an existing row in the table that was matched by a
+// MERGE statement. The existing values from the row are provided in fetchRow.
+// If the RETURNING clause was specified, then the deleted row is stored in the
+// rowsUpserted collection.
+func (tu *optTableUpserter) deleteMatchedRow(
+	ctx context.Context, fetchRow tree.Datums, pm row.PartialIndexUpdateHelper, traceKV bool,
+) error {
+	if err := tu.rd.DeleteRow(ctx, tu.b, fetchRow, pm, traceKV); err != nil {
+		return err
+	}
+
+	// We only need a result row if we're collecting rows.
+	if !tu.rowsNeeded {
+		return nil
+	}
+
+	// Map the deleted columns into the result row before adding it.
+	tableRow := tu.makeResultFromRow(fetchRow, tu.rd.FetchColIDtoRowIndex)
+	for tabIdx := range tableRow {
+		if retIdx := tu.tabColIdxToRetIdx[tabIdx]; retIdx >= 0 {
+			tu.resultRow[retIdx] = tableRow[tabIdx]
+		}
+	}
+	_, err := tu.rows.AddRow(ctx, tu.resultRow)
+	return err
+}
+
 // tableDesc is part of the tableWriter interface.
 func (tu *optTableUpserter) tableDesc() catalog.TableDescriptor {
 	return tu.ri.Helper.TableDesc
@@ -86,6 +86,20 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_pg_lsn:       PGLSN,
 	oid.T_record:       AnyTuple,
 	oid.T_refcursor:    RefCursor,
+	oid.T_anyrange:     AnyRange,
+	oid.T_int4range:    Int4Range,
+	oid.T_int8range:    Int8Range,
+	oid.T_numrange:     NumRange,
+	oid.T_tsrange:      TSRange,
+	oid.T_tstzrange:    TSTZRange,
+	oid.T_daterange:    DateRange,
+	oid.T_point:        Point,
+	oid.T_line:         Line,
+	oid.T_lseg:         LSeg,
+	oid.T_box:          Box,
+	oid.T_path:         Path,
+	oid.T_polygon:      Polygon,
+	oid.T_circle:       Circle,
 	oid.T_regclass:     RegClass,
 	oid.T_regnamespace: RegNamespace,
 	oid.T_regproc:      RegProc,
@@ -99,6 +113,7 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timestamptz:  TimestampTZ,
 	oid.T_tsquery:      TSQuery,
 	oid.T_tsvector:     TSVector,
+	oid.T_trigger:      Trigger,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
 	oid.T_varbit:       VarBit,
@@ -108,6 +123,14 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
+
+	oidext.T_anymultirange:  AnyMultiRange,
+	oidext.T_int4multirange: Int4MultiRange,
+	oidext.T_int8multirange: Int8MultiRange,
+	oidext.T_nummultirange:  NumMultiRange,
+	oidext.T_tsmultirange:   TSMultiRange,
+	oidext.T_tstzmultirange: TSTZMultiRange,
+	oidext.T_datemultirange: DateMultiRange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -135,6 +158,19 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_pg_lsn:       oid.T__pg_lsn,
 	oid.T_record:       oid.T__record,
 	oid.T_refcursor:    oid.T__refcursor,
+	oid.T_int4range:    oid.T__int4range,
+	oid.T_int8range:    oid.T__int8range,
+	oid.T_numrange:     oid.T__numrange,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
+	oid.T_daterange:    oid.T__daterange,
+	oid.T_point:        oid.T__point,
+	oid.T_line:         oid.T__line,
+	oid.T_lseg:         oid.T__lseg,
+	oid.T_box:          oid.T__box,
+	oid.T_path:         oid.T__path,
+	oid.T_polygon:      oid.T__polygon,
+	oid.T_circle:       oid.T__circle,
 	oid.T_regclass:     oid.T__regclass,
 	oid.T_regnamespace: oid.T__regnamespace,
 	oid.T_regproc:      oid.T__regproc,
@@ -155,6 +191,13 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geometry:  oidext.T__geometry,
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
+
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -174,6 +217,9 @@ var familyToOid = map[Family]oid.Oid{
 	CollatedStringFamily: oid.T_text,
 	OidFamily:            oid.T_oid,
 	PGLSNFamily:          oid.T_pg_lsn,
+	RangeFamily:          oid.T_anyrange,
+	GeometricFamily:      oid.T_point,
+	MultiRangeFamily:     oidext.T_anymultirange,
 	RefCursorFamily:      oid.T_refcursor,
 	UnknownFamily:        oid.T_unknown,
 	UuidFamily:           oid.T_uuid,
@@ -185,6 +231,7 @@ var familyToOid = map[Family]oid.Oid{
 	TSQueryFamily:        oid.T_tsquery,
 	TSVectorFamily:       oid.T_tsvector,
 	TupleFamily:          oid.T_record,
+	TriggerFamily:        oid.T_trigger,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
 
@@ -206,6 +253,9 @@ func init() {
 // CalcArrayOid returns the OID of the array type having elements of the given
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
+	if elemTyp.IsDomain() {
+		return elemTyp.UserDefinedArrayOID()
+	}
 	o := elemTyp.Oid()
 	switch elemTyp.Family() {
 	case ArrayFamily:
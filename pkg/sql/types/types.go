@@ -161,6 +161,39 @@ import (
 // When these types are themselves made into arrays, the Oids become T__int2vector and
 // T__oidvector, respectively.
 //
+// Range types
+// -----------
+//
+// Range and multirange types are identified by their Oid, which also
+// determines the type of their bounds (see RangeContents).
+//
+// | SQL type          | Family         | Oid                | RangeContents |
+// |-------------------|----------------|--------------------|---------------|
+// | INT4RANGE         | RANGE          | T_int4range        | Int4          |
+// | INT8RANGE         | RANGE          | T_int8range        | Int           |
+// | NUMRANGE          | RANGE          | T_numrange         | Decimal       |
+// | TSRANGE           | RANGE          | T_tsrange          | Timestamp     |
+// | TSTZRANGE         | RANGE          | T_tstzrange        | TimestampTZ   |
+// | DATERANGE         | RANGE          | T_daterange        | Date          |
+// | INT4MULTIRANGE    | MULTIRANGE     | T_int4multirange   | Int4          |
+// | ...               | MULTIRANGE     | ...                | ...           |
+//
+// Geometric types
+// ---------------
+//
+// The native Postgres geometric types are identified by their Oid. They are
+// unrelated to the GEOMETRY and GEOGRAPHY spatial types.
+//
+// | SQL type          | Family         | Oid                |
+// |-------------------|----------------|--------------------|
+// | POINT             | GEOMETRIC      | T_point            |
+// | LINE              | GEOMETRIC      | T_line             |
+// | LSEG              | GEOMETRIC      | T_lseg             |
+// | BOX               | GEOMETRIC      | T_box              |
+// | PATH              | GEOMETRIC      | T_path             |
+// | POLYGON           | GEOMETRIC      | T_polygon          |
+// | CIRCLE            | GEOMETRIC      | T_circle           |
+//
 // User defined types
 // ------------------
 //
@@ -215,6 +248,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -241,6 +277,30 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed for evaluation.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, if any.
+	DefaultExpr *string
+	// CheckConstraints are the CHECK constraints of the domain.
+	CheckConstraints []DomainCheckConstraint
+}
+
+// DomainCheckConstraint is a CHECK constraint of a DOMAIN type.
+type DomainCheckConstraint struct {
+	// Name is the name of the constraint.
+	Name string
+	// Expr is the serialized boolean expression of the constraint, in which the
+	// VALUE keyword refers to the value being checked.
+	Expr string
+	// TypedExpr is the tree.TypedExpr of Expr, in which VALUE is replaced by
+	// the ordinal reference @1. It is type checked once when the type is
+	// hydrated, so that the constraint is not parsed and type checked for every
+	// value.
+	TypedExpr interface{}
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -496,6 +556,16 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo-type of the value returned by a trigger function.
+	// It can only be used as the return type of a function.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -537,6 +607,98 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4MultiRange is the multirange type of Int4Range.
+	Int4MultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8MultiRange is the multirange type of Int8Range.
+	Int8MultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultiRange is the multirange type of NumRange.
+	NumMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TSMultiRange is the multirange type of TSRange.
+	TSMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultiRange is the multirange type of TSTZRange.
+	TSTZMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultiRange is the multirange type of DateRange.
+	DateMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// RangeTypes contains all of the range types.
+	RangeTypes = []*T{Int4Range, Int8Range, NumRange, TSRange, TSTZRange, DateRange}
+
+	// rangeSubtypes contains the types of the bounds of the range types in
+	// RangeTypes, in the same order.
+	rangeSubtypes = []*T{Int4, Int, Decimal, Timestamp, TimestampTZ, Date}
+
+	// MultiRangeTypes contains all of the multirange types, in the same order
+	// as the corresponding range types in RangeTypes.
+	MultiRangeTypes = []*T{
+		Int4MultiRange, Int8MultiRange, NumMultiRange, TSMultiRange, TSTZMultiRange, DateMultiRange,
+	}
+
+	// Point is the type of a point on a plane.
+	Point = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_point, Locale: &emptyLocale}}
+
+	// Line is the type of an infinite line.
+	Line = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_line, Locale: &emptyLocale}}
+
+	// LSeg is the type of a finite line segment.
+	LSeg = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_lseg, Locale: &emptyLocale}}
+
+	// Box is the type of a rectangular box.
+	Box = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_box, Locale: &emptyLocale}}
+
+	// Path is the type of an open or closed path.
+	Path = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_path, Locale: &emptyLocale}}
+
+	// Polygon is the type of a polygon, which is similar to a closed path.
+	Polygon = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_polygon, Locale: &emptyLocale}}
+
+	// Circle is the type of a circle.
+	Circle = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_circle, Locale: &emptyLocale}}
+
+	// GeometricTypes contains all of the geometric types.
+	GeometricTypes = []*T{Point, Line, LSeg, Box, Path, Polygon, Circle}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -600,6 +762,18 @@ var (
 	AnyTupleArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: AnyTuple, Oid: oid.T__record, Locale: &emptyLocale}}
 
+	// AnyRange is a special type used only during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never have
+	// this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_anyrange, Locale: &emptyLocale}}
+
+	// AnyMultiRange is a special type used only during static analysis as a
+	// wildcard type that matches any multirange type. Execution-time values
+	// should never have this type.
+	AnyMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_anymultirange, Locale: &emptyLocale}}
+
 	// AnyCollatedString is a special type used only during static analysis as a
 	// wildcard type that matches a collated string with any locale. Execution-
 	// time values should never have this type.
@@ -1174,6 +1348,20 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type over the given base
+// type, with the given stable type ID. The domain type has the family and
+// modifiers of its base type. Note that it does not hydrate cached fields on the
+// type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	t := &T{InternalType: base.InternalType}
+	t.InternalType.Oid = typeOID
+	t.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+		BaseTypeOID:  base.Oid(),
+	}
+	return t
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1400,6 +1588,11 @@ func (t *T) WithoutTypeModifiers() *T {
 		return t
 	}
 
+	// The type modifiers of a domain are part of the domain definition.
+	if t.IsDomain() {
+		return t
+	}
+
 	// For types that can be a collated string, we copy the type and set the width
 	// to 0 rather than returning the default OidToType type so that we retain the
 	// locale value if the type is collated.
@@ -1444,6 +1637,52 @@ func (t *T) TupleContents() []*T {
 	return t.InternalType.TupleContents
 }
 
+// RangeContents returns the type of the bounds of a range type, or of the
+// ranges of a multirange type. This is nil for types that are not in the
+// RangeFamily or MultiRangeFamily.
+func (t *T) RangeContents() *T {
+	if IsWildcardRangeType(t) {
+		return Any
+	}
+	for i := range RangeTypes {
+		if t.Oid() == RangeTypes[i].Oid() || t.Oid() == MultiRangeTypes[i].Oid() {
+			return rangeSubtypes[i]
+		}
+	}
+	return nil
+}
+
+// MultiRangeContents returns the range type of the ranges of a multirange
+// type. This is nil for types that are not in the MultiRangeFamily.
+func (t *T) MultiRangeContents() *T {
+	if t.Family() != MultiRangeFamily {
+		return nil
+	}
+	if IsWildcardRangeType(t) {
+		return AnyRange
+	}
+	for i := range MultiRangeTypes {
+		if t.Oid() == MultiRangeTypes[i].Oid() {
+			return RangeTypes[i]
+		}
+	}
+	return nil
+}
+
+// MakeMultiRange returns the multirange type whose ranges have the given range
+// type.
+func MakeMultiRange(rangeTyp *T) *T {
+	if IsWildcardRangeType(rangeTyp) {
+		return AnyMultiRange
+	}
+	for i := range RangeTypes {
+		if rangeTyp.Oid() == RangeTypes[i].Oid() {
+			return MultiRangeTypes[i]
+		}
+	}
+	panic(errors.AssertionFailedf("unexpected range type: %v", rangeTyp))
+}
+
 // TupleLabels returns a slice containing the labels of each tuple field. This
 // is nil for types not in the TupleFamily, or if the tuple type does not
 // specify labels.
@@ -1476,6 +1715,23 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 	}
 }
 
+// IsDomain returns whether or not t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.BaseTypeOID != 0
+}
+
+// DomainBaseType returns the base type of a domain type. It returns t if t is
+// not a domain type.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return t
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.BaseTypeOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
@@ -1500,13 +1756,16 @@ var familyNames = map[Family]redact.SafeString{
 	EnumFamily:           "enum",
 	FloatFamily:          "float",
 	GeographyFamily:      "geography",
+	GeometricFamily:      "geometric",
 	GeometryFamily:       "geometry",
 	INetFamily:           "inet",
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	MultiRangeFamily:     "multirange",
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
+	RangeFamily:          "range",
 	RefCursorFamily:      "refcursor",
 	StringFamily:         "string",
 	TimeFamily:           "time",
@@ -1519,6 +1778,7 @@ var familyNames = map[Family]redact.SafeString{
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
 	VoidFamily:           "void",
+	TriggerFamily:        "trigger",
 	EncodedKeyFamily:     "encodedkey",
 }
 
@@ -1541,6 +1801,13 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return "unknown_domain"
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1585,6 +1852,9 @@ func (t *T) Name() string {
 	case OidFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily, MultiRangeFamily, GeometricFamily:
+		return t.SQLStandardName()
+
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1678,6 +1948,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1784,6 +2057,12 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		}
 	case PGLSNFamily:
 		return "pg_lsn"
+	case RangeFamily, MultiRangeFamily, GeometricFamily:
+		name, ok := oidext.TypeName(t.Oid())
+		if !ok {
+			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
+		}
+		return strings.ToLower(name)
 	case RefCursorFamily:
 		return "refcursor"
 	case StringFamily, CollatedStringFamily:
@@ -1859,6 +2138,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "uuid"
 	case VoidFamily:
 		return "void"
+	case TriggerFamily:
+		return "trigger"
 	case EnumFamily:
 		return t.TypeMeta.Name.Basename()
 	default:
@@ -1876,6 +2157,10 @@ func (t *T) InformationSchemaName() string {
 	if t.Family() == ArrayFamily {
 		return "ARRAY"
 	}
+	// Like in Postgres, domain types report the name of their base type.
+	if t.IsDomain() {
+		return t.DomainBaseType().InformationSchemaName()
+	}
 	// TypeMeta attributes are populated only when it is user defined type.
 	if t.TypeMeta.Name != nil {
 		return "USER-DEFINED"
@@ -1887,6 +2172,12 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -2002,12 +2293,14 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		// Show the redacted SQLString output with an un-redacted prefix to indicate
 		// that the type is user defined (and possibly enum or record).
 		prefix := "TYPE"
-		switch t.Family() {
-		case EnumFamily:
+		switch {
+		case t.IsDomain():
+			prefix = "DOMAIN"
+		case t.Family() == EnumFamily:
 			prefix = "ENUM"
-		case TupleFamily:
+		case t.Family() == TupleFamily:
 			prefix = "RECORD"
-		case ArrayFamily:
+		case t.Family() == ArrayFamily:
 			prefix = "ARRAY"
 		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
@@ -2022,7 +2315,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, RefCursorFamily, RangeFamily, MultiRangeFamily,
+		GeometricFamily, TriggerFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2097,6 +2391,21 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily, MultiRangeFamily:
+		// Similarly to enums, anyrange and anymultirange match any range and
+		// multirange type, respectively.
+		if IsWildcardRangeType(t) || IsWildcardRangeType(other) {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
+
+	case GeometricFamily:
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2258,7 +2567,8 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		}
 	}
 	if t.UDTMetadata != nil && other.UDTMetadata != nil {
-		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
+		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID ||
+			t.UDTMetadata.BaseTypeOID != other.UDTMetadata.BaseTypeOID {
 			return false
 		}
 	} else if t.UDTMetadata != nil {
@@ -2293,6 +2603,16 @@ func (t *T) Unmarshal(data []byte) error {
 // setting required values. This is necessary to preserve backwards-
 // compatibility with older formats (e.g. restoring database from old backup).
 func (t *T) upgradeType() error {
+	// Domain types are stored in the format of their base type, so the base type
+	// is upgraded and the domain OID restored afterwards.
+	if t.IsDomain() {
+		domainOID := t.InternalType.Oid
+		t.InternalType.Oid = t.InternalType.UDTMetadata.BaseTypeOID
+		defer func() {
+			t.InternalType.UDTMetadata.BaseTypeOID = t.InternalType.Oid
+			t.InternalType.Oid = domainOID
+		}()
+	}
 	switch t.Family() {
 	case IntFamily:
 		// Check VisibleType field that was populated in previous versions.
@@ -2530,6 +2850,12 @@ func (t *T) MarshalTo(data []byte) (int, error) {
 // CRDB. This is necessary to preserve backwards-compatibility in mixed-version
 // scenarios, such as during upgrade.
 func (t *T) downgradeType() error {
+	// Domain types are stored in the format of their base type.
+	if t.IsDomain() {
+		domainOID := t.InternalType.Oid
+		t.InternalType.Oid = t.InternalType.UDTMetadata.BaseTypeOID
+		defer func() { t.InternalType.Oid = domainOID }()
+	}
 	// Set Family and VisibleType for 19.1 backwards-compatibility.
 	switch t.Family() {
 	case BitFamily:
@@ -2600,6 +2926,9 @@ func (t *T) downgradeType() error {
 // TODO(andyk): It'd be nice to have this return SqlString() method output,
 // since that is more descriptive.
 func (t *T) String() string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	switch t.Family() {
 	case CollatedStringFamily:
 		if t.Locale() == "" {
@@ -2694,6 +3023,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily, MultiRangeFamily:
+		return IsWildcardRangeType(t)
 	}
 	return false
 }
@@ -2836,6 +3167,16 @@ func IsWildcardTupleType(t *T) bool {
 	return len(t.TupleContents()) == 1 && t.TupleContents()[0].Family() == AnyFamily
 }
 
+// IsWildcardRangeType returns true if this is the wildcard AnyRange or
+// AnyMultiRange type.
+func IsWildcardRangeType(t *T) bool {
+	switch t.Oid() {
+	case oid.T_anyrange, oidext.T_anymultirange:
+		return true
+	}
+	return false
+}
+
 // IsRecordType returns true if this is a RECORD type. This should only be used
 // when processing UDFs. A record differs from AnyTuple in that the tuple
 // contents may contain types other than Any.
@@ -2996,16 +3337,11 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
 	"cidr":          18846,
-	"circle":        21286,
 	"jsonpath":      22513,
-	"line":          21286,
-	"lseg":          21286,
 	"macaddr":       45813,
 	"macaddr8":      45813,
 	"money":         41578,
-	"path":          21286,
 	"txid_snapshot": -1,
 	"xml":           43355,
 }
@@ -3031,10 +3367,19 @@ func (t *T) Delimiter() string {
 	switch t.Family() {
 	case Geometry.Family(), Geography.Family():
 		return ":"
+	case GeometricFamily:
+		// The text representation of a box contains commas.
+		if t.Oid() == oid.T_box {
+			return ";"
+		}
+		return ","
 	case ArrayFamily:
 		if t.Oid() == oidext.T__geometry || t.Oid() == oidext.T__geography {
 			return ":"
 		}
+		if t.Oid() == oid.T__box {
+			return ";"
+		}
 		return ","
 	default:
 		return ","
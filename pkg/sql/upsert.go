@@ -138,10 +138,20 @@ func (n *upsertNode) BatchedNext(params runParams) (bool, error) {
 // processSourceRow processes one row from the source for upsertion.
 // The table writer is in charge of accumulating the result rows.
 func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) error {
-	if err := enforceLocalColumnConstraints(rowVals, n.run.insertCols); err != nil {
-		return err
+	// The insert columns of a MERGE statement only contain values for the rows
+	// that are inserted, so only those rows are checked.
+	merging := n.run.tw.mergeActionOrdinal != -1
+	if !merging || rowVals[n.run.tw.canaryOrdinal] == tree.DNull {
+		if err := enforceLocalColumnConstraints(rowVals, n.run.insertCols); err != nil {
+			return err
+		}
 	}
 
+	// Rows deleted by a MERGE statement are not checked against the CHECK
+	// constraints.
+	deleting := merging &&
+		tree.MergeActionType(tree.MustBeDInt(rowVals[n.run.tw.mergeActionOrdinal])) == tree.MergeDelete
+
 	// Create a set of partial index IDs to not add or remove entries from.
 	var pm row.PartialIndexUpdateHelper
 	if numPartialIndexes := len(n.run.tw.tableDesc().PartialIndexes()); numPartialIndexes > 0 {
@@ -149,6 +159,9 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 		if n.run.tw.canaryOrdinal != -1 {
 			offset++
 		}
+		if merging {
+			offset++
+		}
 		partialIndexVals := rowVals[offset:]
 		partialIndexPutVals := partialIndexVals[:numPartialIndexes]
 		partialIndexDelVals := partialIndexVals[numPartialIndexes : numPartialIndexes*2]
@@ -170,11 +183,16 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 		if n.run.tw.canaryOrdinal != -1 {
 			ord++
 		}
-		checkVals := rowVals[ord:]
-		if err := checkMutationInput(
-			params.ctx, &params.p.semaCtx, params.p.SessionData(), n.run.tw.tableDesc(), n.run.checkOrds, checkVals,
-		); err != nil {
-			return err
+		if merging {
+			ord++
+		}
+		if !deleting {
+			checkVals := rowVals[ord:]
+			if err := checkMutationInput(
+				params.ctx, &params.p.semaCtx, params.p.SessionData(), n.run.tw.tableDesc(), n.run.checkOrds, checkVals,
+			); err != nil {
+				return err
+			}
 		}
 		rowVals = rowVals[:ord]
 	}
@@ -0,0 +1,328 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/metadata"
+	"github.com/apache/arrow/go/v11/parquet/schema"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// Reader reads the scalar columns of a parquet file as datums.
+//
+// Columns are matched by name. Columns written by this package's Writer are
+// decoded with the decoder matching their CRDB type. Columns written by other
+// tools are decoded based on their physical and logical parquet types, so the
+// returned datums may need to be coerced to the requested type by the caller.
+type Reader struct {
+	reader *file.Reader
+	cols   []readerColumn
+}
+
+type readerColumn struct {
+	// idx is the index of the physical column in the file, or -1 if the file
+	// has no column with the requested name.
+	idx int
+	dec decoder
+	// ordered is true if the min and max statistics of the column, decoded with
+	// dec, are the min and max datums of the column.
+	ordered bool
+}
+
+// NewReader returns a Reader for the columns with the given names and types.
+// Columns missing from the file are read as NULL.
+func NewReader(r parquet.ReaderAtSeeker, colNames []string, colTypes []*types.T) (*Reader, error) {
+	if len(colNames) != len(colTypes) {
+		return nil, errors.AssertionFailedf("the number of column names must match the number of column types")
+	}
+	reader, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, err
+	}
+	sch := reader.MetaData().Schema
+	byName := make(map[string]int, sch.NumColumns())
+	for i := 0; i < sch.NumColumns(); i++ {
+		if path := sch.Column(i).ColumnPath(); len(path) == 1 {
+			byName[path[0]] = i
+		}
+	}
+
+	res := &Reader{reader: reader, cols: make([]readerColumn, len(colNames))}
+	for i, name := range colNames {
+		idx, ok := byName[name]
+		if !ok {
+			for n, j := range byName {
+				if strings.EqualFold(n, name) {
+					idx, ok = j, true
+					break
+				}
+			}
+		}
+		if !ok {
+			res.cols[i] = readerColumn{idx: -1}
+			continue
+		}
+		col := sch.Column(idx)
+		if col.MaxRepetitionLevel() > 0 || col.MaxDefinitionLevel() > 1 {
+			return nil, errors.Newf("column %q has an unsupported nested type", name)
+		}
+		if col.PhysicalType() == parquet.Types.Int96 {
+			return nil, errors.Newf("column %q has unsupported physical type INT96", name)
+		}
+		dec, ordered, err := readerDecoder(col, colTypes[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", name)
+		}
+		res.cols[i] = readerColumn{idx: idx, dec: dec, ordered: ordered}
+	}
+	return res, nil
+}
+
+// readerDecoder returns the decoder for a column of the file that is read as
+// a column of type typ, and whether the statistics of the column are ordered
+// like the decoded datums.
+func readerDecoder(col *schema.Column, typ *types.T) (_ decoder, ordered bool, _ error) {
+	switch col.PhysicalType() {
+	case parquet.Types.Boolean, parquet.Types.Int32, parquet.Types.Int64:
+		ordered = col.SortOrder() == schema.SortSIGNED
+	case parquet.Types.ByteArray:
+		_, isString := col.LogicalType().(schema.StringLogicalType)
+		ordered = isString && typ.Family() == types.StringFamily &&
+			col.SortOrder() == schema.SortUNSIGNED
+	}
+
+	// Enums and collated strings are decoded without their type, so read them
+	// as strings and let the caller coerce them.
+	if typ.Family() != types.EnumFamily && typ.Family() != types.CollatedStringFamily {
+		if expected, err := makeColumn(col.Name(), typ, defaultRepetitions); err == nil {
+			if node, ok := expected.node.(*schema.PrimitiveNode); ok &&
+				node.PhysicalType() == col.PhysicalType() &&
+				node.LogicalType().Equals(col.LogicalType()) {
+				dec, err := decoderFromFamilyAndType(typ.Oid(), typ.Family())
+				return dec, ordered, err
+			}
+		}
+	}
+
+	logical := col.LogicalType()
+	switch col.PhysicalType() {
+	case parquet.Types.Boolean:
+		return boolDecoder{}, ordered, nil
+	case parquet.Types.Int32:
+		return physicalInt32Decoder{logical: logical}, ordered, nil
+	case parquet.Types.Int64:
+		return physicalInt64Decoder{logical: logical}, ordered, nil
+	case parquet.Types.Float:
+		return float32Decoder{}, false, nil
+	case parquet.Types.Double:
+		return float64Decoder{}, false, nil
+	case parquet.Types.ByteArray:
+		return physicalByteArrayDecoder{logical: logical}, ordered, nil
+	case parquet.Types.FixedLenByteArray:
+		return physicalFixedLenByteArrayDecoder{logical: logical}, false, nil
+	default:
+		return nil, false, errors.Newf("unsupported physical type %s", col.PhysicalType())
+	}
+}
+
+// NumRowGroups returns the number of row groups in the file.
+func (r *Reader) NumRowGroups() int {
+	return r.reader.NumRowGroups()
+}
+
+// RowGroupNumRows returns the number of rows in a row group.
+func (r *Reader) RowGroupNumRows(rg int) int64 {
+	return r.reader.MetaData().RowGroup(rg).NumRows()
+}
+
+// ReadRowGroup reads all the rows of a row group. The returned datums are
+// indexed by column, then by row.
+func (r *Reader) ReadRowGroup(rg int) ([][]tree.Datum, error) {
+	rgr := r.reader.RowGroup(rg)
+	numRows := rgr.NumRows()
+	res := make([][]tree.Datum, len(r.cols))
+	for i, c := range r.cols {
+		if c.idx < 0 {
+			res[i] = make([]tree.Datum, numRows)
+			for j := range res[i] {
+				res[i][j] = tree.DNull
+			}
+			continue
+		}
+		col, err := rgr.Column(c.idx)
+		if err != nil {
+			return nil, err
+		}
+		res[i], err = readColInRowGroup(col, c.dec, numRows, false /* isArray */, false /* isTuple */)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// RowGroupBounds returns the smallest and largest non-NULL values of a column
+// in a row group. ok is false if the file has no usable statistics for the
+// column.
+func (r *Reader) RowGroupBounds(rg int, col int) (min, max tree.Datum, ok bool, err error) {
+	c := r.cols[col]
+	if c.idx < 0 || !c.ordered {
+		return nil, nil, false, nil
+	}
+	chunk, err := r.reader.MetaData().RowGroup(rg).ColumnChunk(c.idx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	stats, err := chunk.Statistics()
+	if err != nil || stats == nil || !stats.HasMinMax() {
+		return nil, nil, false, err
+	}
+	switch s := stats.(type) {
+	case *metadata.BooleanStatistics:
+		min, max, err = decodeBounds(c.dec, s.Min(), s.Max())
+	case *metadata.Int32Statistics:
+		min, max, err = decodeBounds(c.dec, s.Min(), s.Max())
+	case *metadata.Int64Statistics:
+		min, max, err = decodeBounds(c.dec, s.Min(), s.Max())
+	case *metadata.ByteArrayStatistics:
+		min, max, err = decodeBounds(c.dec, s.Min(), s.Max())
+	default:
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return min, max, true, nil
+}
+
+func decodeBounds[T parquetDatatypes](dec decoder, min, max T) (tree.Datum, tree.Datum, error) {
+	minDatum, err := decode(dec, min)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxDatum, err := decode(dec, max)
+	if err != nil {
+		return nil, nil, err
+	}
+	return minDatum, maxDatum, nil
+}
+
+// Close closes the underlying file reader.
+func (r *Reader) Close() error {
+	return r.reader.Close()
+}
+
+// The physical decoders below decode columns written by other tools, based
+// on the physical and logical types of the column.
+
+type physicalInt32Decoder struct {
+	logical schema.LogicalType
+}
+
+func (d physicalInt32Decoder) decode(v int32) (tree.Datum, error) {
+	switch t := d.logical.(type) {
+	case schema.DateLogicalType:
+		date, err := pgdate.MakeDateFromUnixEpoch(int64(v))
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDDate(date), nil
+	case schema.DecimalLogicalType:
+		return makeScaledDecimal(big.NewInt(int64(v)), t.Scale()), nil
+	}
+	return tree.NewDInt(tree.DInt(v)), nil
+}
+
+type physicalInt64Decoder struct {
+	logical schema.LogicalType
+}
+
+func (d physicalInt64Decoder) decode(v int64) (tree.Datum, error) {
+	switch t := d.logical.(type) {
+	case schema.TimestampLogicalType:
+		var ts time.Time
+		switch t.TimeUnit() {
+		case schema.TimeUnitMillis:
+			ts = time.UnixMilli(v)
+		case schema.TimeUnitMicros:
+			ts = time.UnixMicro(v)
+		default:
+			ts = time.Unix(0, v)
+		}
+		if t.IsAdjustedToUTC() {
+			return tree.MakeDTimestampTZ(ts.UTC(), time.Microsecond)
+		}
+		return tree.MakeDTimestamp(ts.UTC(), time.Microsecond)
+	case schema.DecimalLogicalType:
+		return makeScaledDecimal(big.NewInt(v), t.Scale()), nil
+	}
+	return tree.NewDInt(tree.DInt(v)), nil
+}
+
+type physicalByteArrayDecoder struct {
+	logical schema.LogicalType
+}
+
+func (d physicalByteArrayDecoder) decode(v parquet.ByteArray) (tree.Datum, error) {
+	switch t := d.logical.(type) {
+	case schema.StringLogicalType, schema.EnumLogicalType, schema.JSONLogicalType:
+		return tree.NewDString(string(v)), nil
+	case schema.DecimalLogicalType:
+		return makeScaledDecimal(bigIntFromTwosComplement(v), t.Scale()), nil
+	}
+	return tree.NewDBytes(tree.DBytes(v)), nil
+}
+
+type physicalFixedLenByteArrayDecoder struct {
+	logical schema.LogicalType
+}
+
+func (d physicalFixedLenByteArrayDecoder) decode(v parquet.FixedLenByteArray) (tree.Datum, error) {
+	switch t := d.logical.(type) {
+	case schema.UUIDLogicalType:
+		uid, err := uuid.FromBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDUuid(tree.DUuid{UUID: uid}), nil
+	case schema.DecimalLogicalType:
+		return makeScaledDecimal(bigIntFromTwosComplement(v), t.Scale()), nil
+	}
+	return tree.NewDBytes(tree.DBytes(v)), nil
+}
+
+// bigIntFromTwosComplement decodes a big-endian two's complement integer, which
+// is how parquet stores the unscaled value of byte array decimals.
+func bigIntFromTwosComplement(b []byte) *big.Int {
+	res := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		res.Sub(res, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return res
+}
+
+func makeScaledDecimal(unscaled *big.Int, scale int32) *tree.DDecimal {
+	d := &tree.DDecimal{}
+	d.Negative = unscaled.Sign() < 0
+	d.Coeff.SetMathBigInt(unscaled.Abs(unscaled))
+	d.Exponent = -scale
+	return d
+}
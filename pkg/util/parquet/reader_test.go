@@ -0,0 +1,93 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	schemaDef, err := NewSchema(
+		[]string{"a", "b", "c"},
+		[]*types.T{types.Int, types.String, types.Decimal},
+	)
+	require.NoError(t, err)
+
+	ten := &tree.DDecimal{}
+	ten.SetInt64(10)
+	rows := [][]tree.Datum{
+		{tree.NewDInt(1), tree.NewDString("x"), tree.DNull},
+		{tree.NewDInt(2), tree.DNull, ten},
+		{tree.NewDInt(3), tree.NewDString("z"), tree.DNull},
+		{tree.NewDInt(4), tree.NewDString("y"), tree.DNull},
+		{tree.DNull, tree.DNull, tree.DNull},
+	}
+	buf := bytes.Buffer{}
+	writer, err := NewWriter(schemaDef, &buf, WithMaxRowGroupLength(2))
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, writer.AddRow(row))
+	}
+	require.NoError(t, writer.Close())
+
+	// Columns are matched by name, and missing columns are read as NULL.
+	reader, err := NewReader(
+		bytes.NewReader(buf.Bytes()),
+		[]string{"B", "a", "missing", "c"},
+		[]*types.T{types.String, types.Int, types.Int, types.Decimal},
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, reader.Close()) }()
+	require.Equal(t, 3, reader.NumRowGroups())
+
+	var read [][]tree.Datum
+	for rg := 0; rg < reader.NumRowGroups(); rg++ {
+		cols, err := reader.ReadRowGroup(rg)
+		require.NoError(t, err)
+		require.Len(t, cols, 4)
+		for i := range cols[0] {
+			read = append(read, []tree.Datum{cols[0][i], cols[1][i], cols[2][i], cols[3][i]})
+		}
+	}
+	require.Len(t, read, len(rows))
+	for i, row := range rows {
+		require.Equal(t, row[1].String(), read[i][0].String())
+		require.Equal(t, row[0].String(), read[i][1].String())
+		require.Equal(t, tree.DNull, read[i][2])
+		require.Equal(t, row[2].String(), read[i][3].String())
+	}
+
+	// Integer and string columns have usable statistics.
+	min, max, ok, err := reader.RowGroupBounds(1, 1 /* col */)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "3", min.String())
+	require.Equal(t, "4", max.String())
+
+	min, max, ok, err = reader.RowGroupBounds(1, 0 /* col */)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "'y'", min.String())
+	require.Equal(t, "'z'", max.String())
+
+	// Decimals are written as strings, so their statistics are not ordered
+	// like the decimals, and missing columns have no statistics.
+	for _, col := range []int{2, 3} {
+		_, _, ok, err = reader.RowGroupBounds(0, col)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+}